@@ -0,0 +1,228 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"github.com/sdreger/lib-manager-go/internal/blobtstore"
+	"github.com/sdreger/lib-manager-go/internal/calibre"
+	"github.com/sdreger/lib-manager-go/internal/config"
+	"github.com/sdreger/lib-manager-go/internal/domain/cover"
+	"github.com/sdreger/lib-manager-go/internal/importer"
+	"io"
+	"log/slog"
+	"maps"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"syscall"
+)
+
+// command - a CLI subcommand of the API binary, e.g. 'app cover-audit --fix=delete-orphans'.
+// Commands share the same configuration and dependencies as the API service
+type command struct {
+	description string
+	run         func(ctx context.Context, deps commandDeps, args []string) error
+}
+
+type commandDeps struct {
+	logger    *slog.Logger
+	config    config.AppConfig
+	db        *sqlx.DB
+	blobStore *blobtstore.MinioStore
+	output    io.Writer
+}
+
+var commands = map[string]command{
+	"cover-audit": {
+		description: "reports missing, orphaned and misplaced book covers, and optionally fixes them",
+		run:         runCoverAudit,
+	},
+	"cover-rehash": {
+		description: "moves legacy '{publisher}/{file}' covers to the content-addressed storage",
+		run:         runCoverRehash,
+	},
+	"cover-backfill": {
+		description: "records dimensions, dominant colors and BlurHash strings of the existing covers",
+		run:         runCoverBackfill,
+	},
+	"calibre-import": {
+		description: "imports the books, covers and files of a Calibre library, e.g. 'calibre-import ~/Calibre'",
+		run:         runCalibreImport,
+	},
+	"import": {
+		description: "imports the books from a CSV or NDJSON file, e.g. 'import --map=title:Name catalog.csv'",
+		run:         runImport,
+	},
+}
+
+func runCommand(logger *slog.Logger, name string, args []string) error {
+	cmd, ok := commands[name]
+	if !ok {
+		return fmt.Errorf("unknown command %q, available commands: %s", name,
+			strings.Join(slices.Sorted(maps.Keys(commands)), ", "))
+	}
+
+	// ==================== Configuration Parsing ====================
+	appConfig, err := config.New()
+	if err != nil {
+		return err
+	}
+
+	// ==================== Get Main Context ====================
+	mainCtx, cancelFunc := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancelFunc()
+
+	logger.Info("running command", "command", name, "description", cmd.description)
+	return withDependencies(mainCtx, logger, appConfig,
+		func(db *sqlx.DB, blobStore *blobtstore.MinioStore) error {
+			deps := commandDeps{
+				logger:    logger,
+				config:    appConfig,
+				db:        db,
+				blobStore: blobStore,
+				output:    os.Stdout,
+			}
+			return cmd.run(mainCtx, deps, args)
+		})
+}
+
+func runCoverAudit(ctx context.Context, deps commandDeps, args []string) error {
+	flags := flag.NewFlagSet("cover-audit", flag.ContinueOnError)
+	fix := flags.String("fix", "", fmt.Sprintf("comma-separated fix actions to apply: %v", cover.AllowedFixActions))
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	options, err := cover.NewAuditOptions(splitCommandList(*fix))
+	if err != nil {
+		return err
+	}
+
+	report, err := cover.NewAuditor(deps.logger, deps.db, deps.blobStore).Audit(ctx, options)
+	if err != nil {
+		return err
+	}
+
+	return writeCommandResult(deps.output, report)
+}
+
+func runCoverRehash(ctx context.Context, deps commandDeps, args []string) error {
+	flags := flag.NewFlagSet("cover-rehash", flag.ContinueOnError)
+	deleteLegacy := flags.Bool("delete-legacy", false, "delete the legacy cover objects after the migration")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	report, err := cover.NewService(deps.logger, deps.db, deps.blobStore).RehashLegacyCovers(ctx, *deleteLegacy)
+	if err != nil {
+		return err
+	}
+
+	return writeCommandResult(deps.output, report)
+}
+
+func runCoverBackfill(ctx context.Context, deps commandDeps, args []string) error {
+	flags := flag.NewFlagSet("cover-backfill", flag.ContinueOnError)
+	all := flags.Bool("all", false, "recompute the metadata of all covers, not only the missing ones")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	report, err := cover.NewService(deps.logger, deps.db, deps.blobStore).BackfillCoverMetadata(ctx, *all)
+	if err != nil {
+		return err
+	}
+
+	return writeCommandResult(deps.output, report)
+}
+
+func runImport(ctx context.Context, deps commandDeps, args []string) error {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	format := flags.String("format", "", "the import format: csv or ndjson, detected by the file extension by default")
+	dryRun := flags.Bool("dry-run", false, "validate and report the rows without storing them")
+	batchSize := flags.Int("batch-size", importer.DefaultBatchSize, "the number of rows imported in one transaction")
+	mapping := flags.String("map", "", "comma-separated 'field:column' CSV column mapping")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return errors.New("the import file path is required, use '-' for the standard input")
+	}
+	if *batchSize < 1 || *batchSize > importer.MaxBatchSize {
+		return fmt.Errorf("the batch size should be from 1 to %d", importer.MaxBatchSize)
+	}
+
+	columnMapping, err := importer.ParseMapping(splitCommandList(*mapping))
+	if err != nil {
+		return err
+	}
+	options := importer.Options{
+		Format:    strings.ToLower(*format),
+		DryRun:    *dryRun,
+		BatchSize: *batchSize,
+		Mapping:   columnMapping,
+	}
+
+	input := io.Reader(os.Stdin)
+	if fileName := flags.Arg(0); fileName != "-" {
+		file, err := os.Open(fileName)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = file.Close()
+		}()
+		input = file
+		if options.Format == "" {
+			options.Format = importer.FormatByFileName(fileName)
+		}
+	}
+
+	report, err := importer.NewService(deps.logger, deps.db).Import(ctx, input, options)
+	if err != nil {
+		return err
+	}
+
+	return writeCommandResult(deps.output, report)
+}
+
+func runCalibreImport(ctx context.Context, deps commandDeps, args []string) error {
+	flags := flag.NewFlagSet("calibre-import", flag.ContinueOnError)
+	dryRun := flags.Bool("dry-run", false, "report the books to be created and matched without storing them")
+	defaultLanguage := flags.String("default-language", deps.config.Inbox.DefaultLanguage,
+		"the language of the new books without one")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return errors.New("the Calibre library directory is required")
+	}
+
+	options := calibre.Options{DryRun: *dryRun, DefaultLanguage: *defaultLanguage}
+	report, err := calibre.NewService(deps.logger, deps.db, deps.blobStore).Import(ctx, flags.Arg(0), options)
+	if err != nil {
+		return err
+	}
+
+	return writeCommandResult(deps.output, report)
+}
+
+func splitCommandList(value string) []string {
+	if value == "" {
+		return nil
+	}
+
+	return strings.Split(value, ",")
+}
+
+func writeCommandResult(w io.Writer, result any) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(result)
+}
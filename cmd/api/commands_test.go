@@ -0,0 +1,62 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"github.com/sdreger/lib-manager-go/internal/calibre"
+	"github.com/sdreger/lib-manager-go/internal/importer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"log/slog"
+	"os"
+	"testing"
+)
+
+func TestRunCommand_UnknownCommand(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.Level(100)}))
+
+	err := runCommand(logger, "unknown", nil)
+	require.Error(t, err, "should not run an unknown command")
+	assert.Contains(t, err.Error(), `unknown command "unknown"`)
+	assert.Contains(t, err.Error(), "cover-audit")
+	assert.Contains(t, err.Error(), "cover-rehash")
+	assert.Contains(t, err.Error(), "cover-backfill")
+	assert.Contains(t, err.Error(), "import")
+}
+
+func TestRunImport_InvalidArgs(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.Level(100)}))
+	deps := commandDeps{logger: logger, output: &bytes.Buffer{}}
+
+	err := runImport(context.Background(), deps, []string{"--dry-run"})
+	require.Error(t, err, "should require the import file")
+	err = runImport(context.Background(), deps, []string{"--batch-size=0", "catalog.csv"})
+	require.Error(t, err, "should reject the invalid batch size")
+	err = runImport(context.Background(), deps, []string{"--map=name:Title", "catalog.csv"})
+	require.ErrorIs(t, err, importer.ErrInvalidMapping)
+	err = runImport(context.Background(), deps, []string{"missing.csv"})
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestRunCalibreImport_InvalidArgs(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.Level(100)}))
+	deps := commandDeps{logger: logger, output: &bytes.Buffer{}}
+
+	err := runCalibreImport(context.Background(), deps, []string{"--dry-run"})
+	require.Error(t, err, "should require the library directory")
+	err = runCalibreImport(context.Background(), deps, []string{t.TempDir()})
+	require.ErrorIs(t, err, calibre.ErrInvalidLibrary)
+}
+
+func TestSplitCommandList(t *testing.T) {
+	assert.Nil(t, splitCommandList(""))
+	assert.Equal(t, []string{"delete-orphans"}, splitCommandList("delete-orphans"))
+	assert.Equal(t, []string{"delete-orphans", "clear-dangling"}, splitCommandList("delete-orphans,clear-dangling"))
+}
+
+func TestWriteCommandResult(t *testing.T) {
+	output := bytes.Buffer{}
+	err := writeCommandResult(&output, map[string]int{"books_scanned": 1})
+	require.NoError(t, err, "should write the result")
+	assert.JSONEq(t, `{"books_scanned": 1}`, output.String())
+}
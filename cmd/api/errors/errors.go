@@ -8,7 +8,8 @@ import (
 )
 
 var (
-	ErrNotFound = errors.New("the requested resource could not be found")
+	ErrNotFound    = errors.New("the requested resource could not be found")
+	ErrUnavailable = errors.New("the upstream service is unavailable")
 )
 
 type ValidationError struct {
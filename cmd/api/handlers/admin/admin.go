@@ -0,0 +1,5 @@
+package admin
+
+const (
+	group = "/admin"
+)
@@ -0,0 +1,70 @@
+package admin
+
+import (
+	"context"
+	"github.com/jmoiron/sqlx"
+	"github.com/sdreger/lib-manager-go/cmd/api/handlers"
+	"github.com/sdreger/lib-manager-go/internal/blobtstore"
+	"github.com/sdreger/lib-manager-go/internal/domain/cover"
+	"github.com/sdreger/lib-manager-go/internal/response"
+	"log/slog"
+	"net/http"
+)
+
+const (
+	queryParamFix = "fix"
+)
+
+type CoverAuditService interface {
+	Audit(ctx context.Context, options cover.AuditOptions) (cover.AuditReport, error)
+}
+
+type CoverAuditController struct {
+	logger       *slog.Logger
+	auditService CoverAuditService
+}
+
+func NewCoverAuditController(logger *slog.Logger, db *sqlx.DB, blobStore *blobtstore.MinioStore) *CoverAuditController {
+	return &CoverAuditController{
+		logger:       logger,
+		auditService: cover.NewAuditor(logger, db, blobStore),
+	}
+}
+
+func (cnt *CoverAuditController) RegisterRoutes(registrar handlers.RouteRegistrar) {
+	registrar.RegisterRoute(http.MethodGet, group, "/covers/audit", cnt.GetCoverAudit)
+	registrar.RegisterRoute(http.MethodPost, group, "/covers/audit", cnt.FixCoverAudit)
+}
+
+// GetCoverAudit - returns a read-only cover audit report. The whole cover bucket is listed,
+// so the server write timeout is lifted
+func (cnt *CoverAuditController) GetCoverAudit(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	if err := handlers.ClearWriteDeadline(w); err != nil {
+		return err
+	}
+	report, err := cnt.auditService.Audit(ctx, cover.AuditOptions{})
+	if err != nil {
+		return err
+	}
+
+	return response.RenderDataJSON(w, http.StatusOK, report)
+}
+
+// FixCoverAudit - performs a cover audit, and applies the fix actions provided by the 'fix' query parameters.
+// The server write timeout is lifted, since the fixes may take longer than the audit itself
+func (cnt *CoverAuditController) FixCoverAudit(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	options, err := cover.NewAuditOptions(r.URL.Query()[queryParamFix])
+	if err != nil {
+		return err
+	}
+
+	if err := handlers.ClearWriteDeadline(w); err != nil {
+		return err
+	}
+	report, err := cnt.auditService.Audit(ctx, options)
+	if err != nil {
+		return err
+	}
+
+	return response.RenderDataJSON(w, http.StatusOK, report)
+}
@@ -0,0 +1,127 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	apiErrors "github.com/sdreger/lib-manager-go/cmd/api/errors"
+	"github.com/sdreger/lib-manager-go/cmd/api/handlers"
+	"github.com/sdreger/lib-manager-go/internal/domain/cover"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestCoverAuditController_RegisterRoutes(t *testing.T) {
+	testRegistrar := handlers.RouteRegistrarMock{}
+	cnt := getCoverAuditController()
+	cnt.RegisterRoutes(&testRegistrar)
+
+	assert.True(t, testRegistrar.IsRouteRegistered("GET /admin/covers/audit", cnt.GetCoverAudit))
+	assert.True(t, testRegistrar.IsRouteRegistered("POST /admin/covers/audit", cnt.FixCoverAudit))
+}
+
+func TestCoverAuditController_GetCoverAudit(t *testing.T) {
+	ctx := context.Background()
+	controller := getCoverAuditController()
+	report := getTestAuditReport()
+
+	mockService := NewMockCoverAuditService(t)
+	mockService.EXPECT().Audit(ctx, cover.AuditOptions{}).Return(report, nil)
+	injectCoverAuditMocks(controller, mockService)
+
+	request := httptest.NewRequest("GET", "/admin/covers/audit?fix=delete-orphans", nil)
+	recorder := httptest.NewRecorder()
+	err := controller.GetCoverAudit(ctx, recorder, request)
+	require.NoError(t, err, "should get an audit report")
+
+	result := recorder.Result()
+	defer result.Body.Close()
+	require.Equal(t, http.StatusOK, result.StatusCode, "should get a 200 OK response")
+
+	data, err := io.ReadAll(result.Body)
+	require.NoError(t, err, "should read body")
+	var reportJSON map[string]cover.AuditReport
+	_ = json.Unmarshal(data, &reportJSON)
+	assert.Equal(t, report, reportJSON["data"], "body should match")
+}
+
+func TestCoverAuditController_FixCoverAudit(t *testing.T) {
+	ctx := context.Background()
+	controller := getCoverAuditController()
+	report := getTestAuditReport()
+	report.Fixes = []cover.FixResult{{Action: cover.FixDeleteOrphans, Path: "manning/orphan.jpg"}}
+
+	expectedOptions := cover.AuditOptions{Fixes: []cover.FixAction{cover.FixDeleteOrphans, cover.FixClearDangling}}
+	mockService := NewMockCoverAuditService(t)
+	mockService.EXPECT().Audit(ctx, expectedOptions).Return(report, nil)
+	injectCoverAuditMocks(controller, mockService)
+
+	request := httptest.NewRequest("POST", "/admin/covers/audit?fix=delete-orphans&fix=clear-dangling", nil)
+	recorder := httptest.NewRecorder()
+	err := controller.FixCoverAudit(ctx, recorder, request)
+	require.NoError(t, err, "should fix covers")
+
+	result := recorder.Result()
+	defer result.Body.Close()
+	require.Equal(t, http.StatusOK, result.StatusCode, "should get a 200 OK response")
+
+	data, err := io.ReadAll(result.Body)
+	require.NoError(t, err, "should read body")
+	var reportJSON map[string]cover.AuditReport
+	_ = json.Unmarshal(data, &reportJSON)
+	assert.Equal(t, report, reportJSON["data"], "body should match")
+}
+
+func TestCoverAuditController_FixCoverAudit_WrongFix(t *testing.T) {
+	ctx := context.Background()
+	controller := getCoverAuditController()
+
+	request := httptest.NewRequest("POST", "/admin/covers/audit?fix=delete-everything", nil)
+	recorder := httptest.NewRecorder()
+	err := controller.FixCoverAudit(ctx, recorder, request)
+	require.Error(t, err, "should not fix covers")
+	assert.ErrorAs(t, err, &apiErrors.ValidationError{}, "should get a validation error")
+}
+
+func TestCoverAuditController_GetCoverAudit_ServiceError(t *testing.T) {
+	ctx := context.Background()
+	controller := getCoverAuditController()
+
+	serviceError := errors.New("service error")
+	mockService := NewMockCoverAuditService(t)
+	mockService.EXPECT().Audit(ctx, cover.AuditOptions{}).Return(cover.AuditReport{}, serviceError)
+	injectCoverAuditMocks(controller, mockService)
+
+	request := httptest.NewRequest("GET", "/admin/covers/audit", nil)
+	recorder := httptest.NewRecorder()
+	err := controller.GetCoverAudit(ctx, recorder, request)
+	assert.ErrorIs(t, err, serviceError, "should get service error")
+}
+
+func getTestAuditReport() cover.AuditReport {
+	return cover.AuditReport{
+		BooksScanned:   2,
+		ObjectsScanned: 2,
+		MissingCovers: []cover.MissingCover{{
+			BookID: 1, Publisher: "OReilly", CoverFileName: "1111111111.jpg", ExpectedPath: "oreilly/1111111111.jpg",
+		}},
+		OrphanObjects:      []cover.OrphanObject{{Path: "manning/orphan.jpg", Size: 512}},
+		MismatchedPrefixes: []cover.MismatchedPrefix{},
+		Fixes:              []cover.FixResult{},
+	}
+}
+
+func getCoverAuditController() *CoverAuditController {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.Level(100)}))
+	return NewCoverAuditController(logger, nil, nil)
+}
+
+func injectCoverAuditMocks(controller *CoverAuditController, auditService *MockCoverAuditService) {
+	controller.auditService = auditService
+}
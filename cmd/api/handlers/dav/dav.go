@@ -0,0 +1,5 @@
+package dav
+
+const (
+	group = "/dav"
+)
@@ -0,0 +1,177 @@
+package dav
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/jmoiron/sqlx"
+	apiErrors "github.com/sdreger/lib-manager-go/cmd/api/errors"
+	"github.com/sdreger/lib-manager-go/cmd/api/handlers"
+	"github.com/sdreger/lib-manager-go/internal/blobtstore"
+	share "github.com/sdreger/lib-manager-go/internal/dav"
+	"html/template"
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+const (
+	// methodPropfind - the WebDAV method, returning the resource properties, see https://www.rfc-editor.org/rfc/rfc4918
+	methodPropfind = "PROPFIND"
+	allowedMethods = "OPTIONS, GET, HEAD, PROPFIND"
+
+	contentTypeXML  = "application/xml; charset=utf-8"
+	contentTypeHTML = "text/html; charset=utf-8"
+)
+
+// listingTemplate - the collection page, for the GET requests made by the browsers
+var listingTemplate = template.Must(template.New("listing").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head><meta charset="utf-8"><title>Index of {{.Path}}</title></head>
+<body>
+<h1>Index of {{.Path}}</h1>
+<ul>
+{{- range .Members}}
+<li><a href="{{.Href}}">{{.Name}}{{if .Collection}}/{{end}}</a></li>
+{{- end}}
+</ul>
+</body>
+</html>
+`))
+
+type ShareService interface {
+	GetResources(ctx context.Context, resourcePath string, depth int) ([]share.Resource, error)
+	GetFile(ctx context.Context, resourcePath string) (share.FileContent, error)
+}
+
+// ShareController - serves the read-only WebDAV share of the book files, so the library can be mounted
+// as a network drive. The write methods are not registered, so those are rejected with the 405 status
+type ShareController struct {
+	logger       *slog.Logger
+	shareService ShareService
+}
+
+func NewShareController(logger *slog.Logger, db *sqlx.DB, blobStore *blobtstore.MinioStore) *ShareController {
+	return &ShareController{logger: logger, shareService: share.NewService(logger, db, blobStore)}
+}
+
+func (cnt *ShareController) RegisterRoutes(registrar handlers.RouteRegistrar) {
+	registrar.RegisterRoute(http.MethodOptions, group, "/", cnt.Options)
+	registrar.RegisterRoute(http.MethodGet, group, "/", cnt.GetResource)
+	registrar.RegisterRoute(methodPropfind, group, "/", cnt.Propfind)
+}
+
+// Options - advertises the WebDAV compliance class 1, and the supported methods
+func (cnt *ShareController) Options(_ context.Context, w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("DAV", "1")
+	w.Header().Set("Allow", allowedMethods)
+	w.Header().Set("MS-Author-Via", "DAV")
+	w.WriteHeader(http.StatusOK)
+
+	return nil
+}
+
+// GetResource - streams the file content, the range and conditional requests are handled by http.ServeContent.
+// The ETag is the file SHA-256 checksum. The collections are rendered as the HTML pages, listing their members
+func (cnt *ShareController) GetResource(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	resourcePath := strings.TrimPrefix(r.URL.Path, group)
+	file, err := cnt.shareService.GetFile(ctx, resourcePath)
+	if errors.Is(err, share.ErrCollection) {
+		return cnt.renderListing(ctx, w, resourcePath)
+	}
+	if errors.Is(err, share.ErrNotFound) {
+		return apiErrors.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = file.Content.Close()
+	}()
+
+	// the large book file download outlives the server write timeout
+	if err := handlers.ClearWriteDeadline(w); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", file.ContentType)
+	if file.ETag != "" {
+		w.Header().Set("ETag", `"`+file.ETag+`"`)
+	}
+	http.ServeContent(w, r, "", file.UpdatedAt, file.Content)
+
+	return nil
+}
+
+// Propfind - returns the properties of the resource, and of its members for the depth 1.
+// The infinite depth, also implied by the missing 'Depth' header, is rejected with the 403 status
+func (cnt *ShareController) Propfind(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	var depth int
+	switch r.Header.Get("Depth") {
+	case "0":
+		depth = 0
+	case "1":
+		depth = 1
+	default:
+		return writeXML(w, http.StatusForbidden, func(buffer *bytes.Buffer) error {
+			return share.WriteError(buffer, share.ConditionFiniteDepth)
+		})
+	}
+
+	resources, err := cnt.shareService.GetResources(ctx, strings.TrimPrefix(r.URL.Path, group), depth)
+	if errors.Is(err, share.ErrNotFound) {
+		return apiErrors.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	return writeXML(w, http.StatusMultiStatus, func(buffer *bytes.Buffer) error {
+		return share.WriteMultistatus(buffer, group, resources)
+	})
+}
+
+func (cnt *ShareController) renderListing(ctx context.Context, w http.ResponseWriter, resourcePath string) error {
+	resources, err := cnt.shareService.GetResources(ctx, resourcePath, 1)
+	if err != nil {
+		return err
+	}
+
+	type member struct {
+		Href       string
+		Name       string
+		Collection bool
+	}
+	data := struct {
+		Path    string
+		Members []member
+	}{Path: group + resources[0].Path}
+	for _, resource := range resources[1:] {
+		data.Members = append(data.Members, member{
+			Href:       resource.Href(group),
+			Name:       resource.Name,
+			Collection: resource.Collection,
+		})
+	}
+
+	var buffer bytes.Buffer
+	if err := listingTemplate.Execute(&buffer, data); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", contentTypeHTML)
+	_, err = w.Write(buffer.Bytes())
+
+	return err
+}
+
+// writeXML - writes the XML response, it is buffered, so the encoding errors are handled by the error middleware
+func writeXML(w http.ResponseWriter, status int, write func(buffer *bytes.Buffer) error) error {
+	var buffer bytes.Buffer
+	if err := write(&buffer); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", contentTypeXML)
+	w.WriteHeader(status)
+	_, err := w.Write(buffer.Bytes())
+
+	return err
+}
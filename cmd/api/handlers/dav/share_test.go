@@ -0,0 +1,175 @@
+package dav
+
+import (
+	"context"
+	"errors"
+	apiErrors "github.com/sdreger/lib-manager-go/cmd/api/errors"
+	"github.com/sdreger/lib-manager-go/cmd/api/handlers"
+	share "github.com/sdreger/lib-manager-go/internal/dav"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+var (
+	testUpdated  = time.Date(2026, 10, 5, 8, 30, 0, 0, time.UTC)
+	testFilePath = "/by-publisher/OReilly/Learning Go.epub"
+)
+
+type testContent struct {
+	*strings.Reader
+}
+
+func (c testContent) Close() error {
+	return nil
+}
+
+func TestShareController_RegisterRoutes(t *testing.T) {
+	testRegistrar := handlers.RouteRegistrarMock{}
+	cnt := getShareController()
+	cnt.RegisterRoutes(&testRegistrar)
+
+	assert.True(t, testRegistrar.IsRouteRegistered("OPTIONS /dav/", cnt.Options))
+	assert.True(t, testRegistrar.IsRouteRegistered("GET /dav/", cnt.GetResource))
+	assert.True(t, testRegistrar.IsRouteRegistered("PROPFIND /dav/", cnt.Propfind))
+}
+
+func TestShareController_Options(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	err := getShareController().Options(context.Background(), recorder, httptest.NewRequest("OPTIONS", "/dav/", nil))
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "1", recorder.Header().Get("DAV"))
+	assert.Equal(t, "OPTIONS, GET, HEAD, PROPFIND", recorder.Header().Get("Allow"))
+}
+
+func TestShareController_GetResource(t *testing.T) {
+	ctx := context.Background()
+	controller := getShareController()
+
+	mockService := NewMockShareService(t)
+	mockService.EXPECT().GetFile(ctx, testFilePath).RunAndReturn(
+		func(context.Context, string) (share.FileContent, error) {
+			return share.FileContent{
+				Resource: share.Resource{Path: testFilePath, Name: "Learning Go.epub", Size: 7,
+					ContentType: "application/epub+zip", ETag: "e1", UpdatedAt: testUpdated},
+				Content: testContent{strings.NewReader("content")},
+			}, nil
+		}).Twice()
+	controller.shareService = mockService
+
+	recorder := httptest.NewRecorder()
+	err := controller.GetResource(ctx, recorder,
+		httptest.NewRequest("GET", "/dav/by-publisher/OReilly/Learning%20Go.epub", nil))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "application/epub+zip", recorder.Header().Get("Content-Type"))
+	assert.Equal(t, `"e1"`, recorder.Header().Get("ETag"))
+	assert.Equal(t, "content", recorder.Body.String())
+
+	recorder = httptest.NewRecorder()
+	request := httptest.NewRequest("GET", "/dav/by-publisher/OReilly/Learning%20Go.epub", nil)
+	request.Header.Set("If-None-Match", `"e1"`)
+	err = controller.GetResource(ctx, recorder, request)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotModified, recorder.Code, "the file ETag should be matched")
+}
+
+func TestShareController_GetResource_Collection(t *testing.T) {
+	ctx := context.Background()
+	controller := getShareController()
+
+	mockService := NewMockShareService(t)
+	mockService.EXPECT().GetFile(ctx, "/by-publisher/").Return(share.FileContent{}, share.ErrCollection).Once()
+	mockService.EXPECT().GetResources(ctx, "/by-publisher/", 1).Return([]share.Resource{
+		{Path: "/by-publisher/", Name: "by-publisher", Collection: true},
+		{Path: "/by-publisher/O Reilly/", Name: "O Reilly", Collection: true},
+	}, nil).Once()
+	mockService.EXPECT().GetFile(ctx, "/by-year/").Return(share.FileContent{}, share.ErrNotFound).Once()
+	controller.shareService = mockService
+
+	recorder := httptest.NewRecorder()
+	err := controller.GetResource(ctx, recorder, httptest.NewRequest("GET", "/dav/by-publisher/", nil))
+	require.NoError(t, err)
+	assert.Equal(t, "text/html; charset=utf-8", recorder.Header().Get("Content-Type"))
+	assert.Contains(t, recorder.Body.String(), "<h1>Index of /dav/by-publisher/</h1>")
+	assert.Contains(t, recorder.Body.String(), `<a href="/dav/by-publisher/O%20Reilly/">O Reilly/</a>`)
+
+	err = controller.GetResource(ctx, httptest.NewRecorder(), httptest.NewRequest("GET", "/dav/by-year/", nil))
+	assert.ErrorIs(t, err, apiErrors.ErrNotFound)
+}
+
+func TestShareController_Propfind(t *testing.T) {
+	ctx := context.Background()
+	controller := getShareController()
+
+	mockService := NewMockShareService(t)
+	mockService.EXPECT().GetResources(ctx, "/by-publisher/OReilly/", 1).Return([]share.Resource{
+		{Path: "/by-publisher/OReilly/", Name: "OReilly", Collection: true, UpdatedAt: testUpdated},
+		{Path: testFilePath, Name: "Learning Go.epub", Size: 7, ContentType: "application/epub+zip", ETag: "e1",
+			UpdatedAt: testUpdated},
+	}, nil).Once()
+	mockService.EXPECT().GetResources(ctx, "/by-year/", 0).Return(nil, share.ErrNotFound).Once()
+	controller.shareService = mockService
+
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest("PROPFIND", "/dav/by-publisher/OReilly/", nil)
+	request.Header.Set("Depth", "1")
+	err := controller.Propfind(ctx, recorder, request)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusMultiStatus, recorder.Code)
+	assert.Equal(t, "application/xml; charset=utf-8", recorder.Header().Get("Content-Type"))
+	body, _ := io.ReadAll(recorder.Body)
+	assert.Contains(t, string(body), "<D:href>/dav/by-publisher/OReilly/Learning%20Go.epub</D:href>")
+	assert.Contains(t, string(body), "<D:getetag>&#34;e1&#34;</D:getetag>")
+
+	request = httptest.NewRequest("PROPFIND", "/dav/by-year/", nil)
+	request.Header.Set("Depth", "0")
+	err = controller.Propfind(ctx, httptest.NewRecorder(), request)
+	assert.ErrorIs(t, err, apiErrors.ErrNotFound)
+}
+
+func TestShareController_Propfind_InfiniteDepth(t *testing.T) {
+	controller := getShareController()
+	controller.shareService = NewMockShareService(t)
+
+	for _, depth := range []string{"infinity", ""} {
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest("PROPFIND", "/dav/", nil)
+		if depth != "" {
+			request.Header.Set("Depth", depth)
+		}
+		err := controller.Propfind(context.Background(), recorder, request)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusForbidden, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), "<D:propfind-finite-depth>")
+	}
+}
+
+func TestShareController_ServiceError(t *testing.T) {
+	ctx := context.Background()
+	controller := getShareController()
+	serviceError := errors.New("service error")
+
+	mockService := NewMockShareService(t)
+	mockService.EXPECT().GetResources(ctx, "/", 1).Return(nil, serviceError).Once()
+	controller.shareService = mockService
+
+	request := httptest.NewRequest("PROPFIND", "/dav/", nil)
+	request.Header.Set("Depth", "1")
+	err := controller.Propfind(ctx, httptest.NewRecorder(), request)
+	assert.ErrorIs(t, err, serviceError)
+}
+
+func getShareController() *ShareController {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	return NewShareController(logger, nil, nil)
+}
@@ -0,0 +1,137 @@
+package feeds
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/jmoiron/sqlx"
+	"github.com/sdreger/lib-manager-go/cmd/api/handlers"
+	"github.com/sdreger/lib-manager-go/internal/domain/book"
+	"github.com/sdreger/lib-manager-go/internal/feed"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// feedSize - the number of the newest books in the feed
+	feedSize = 50
+
+	feedIDPrefix     = "urn:lib-manager:feeds:new"
+	feedCacheControl = "public, max-age=300"
+)
+
+type FeedService interface {
+	GetNewBooks(ctx context.Context, filter book.Filter, limit uint64) ([]feed.Item, error)
+}
+
+type BookFeedController struct {
+	logger      *slog.Logger
+	feedService FeedService
+}
+
+func NewBookFeedController(logger *slog.Logger, db *sqlx.DB) *BookFeedController {
+	return &BookFeedController{logger: logger, feedService: feed.NewService(logger, db)}
+}
+
+func (cnt *BookFeedController) RegisterRoutes(registrar handlers.RouteRegistrar) {
+	registrar.RegisterRoute(http.MethodGet, group, "/new.atom", cnt.GetNewBooksAtom)
+	registrar.RegisterRoute(http.MethodGet, group, "/new.rss", cnt.GetNewBooksRSS)
+}
+
+// GetNewBooksAtom - returns the Atom feed of the newest books, matching the book filter query parameters
+func (cnt *BookFeedController) GetNewBooksAtom(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	return cnt.renderNewBooks(ctx, w, r, feed.FormatAtom)
+}
+
+// GetNewBooksRSS - returns the RSS feed of the newest books, matching the book filter query parameters
+func (cnt *BookFeedController) GetNewBooksRSS(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	return cnt.renderNewBooks(ctx, w, r, feed.FormatRSS)
+}
+
+// renderNewBooks - writes the feed in the format. The ETag is the feed content hash, and the last modification
+// time is the latest book update time, the conditional requests are handled by http.ServeContent
+func (cnt *BookFeedController) renderNewBooks(ctx context.Context, w http.ResponseWriter, r *http.Request,
+	format string) error {
+
+	filter, err := book.NewFilter(r.URL.Query())
+	if err != nil {
+		return err
+	}
+
+	items, err := cnt.feedService.GetNewBooks(ctx, filter, feedSize)
+	if err != nil {
+		return err
+	}
+
+	newBooksFeed := newFeed(r, items)
+	var buffer bytes.Buffer
+	if err := feed.WriteFeed(&buffer, format, newBooksFeed); err != nil {
+		return err
+	}
+
+	sum := sha256.Sum256(buffer.Bytes())
+	w.Header().Set("Content-Type", feed.ContentType(format)+"; charset=utf-8")
+	w.Header().Set("ETag", `"`+hex.EncodeToString(sum[:16])+`"`)
+	w.Header().Set("Cache-Control", feedCacheControl)
+	http.ServeContent(w, r, "", newBooksFeed.Updated, bytes.NewReader(buffer.Bytes()))
+
+	return nil
+}
+
+// newFeed - returns the feed of the books, the feeds of the different filters have the different IDs.
+// The empty feed was last updated at the Unix epoch, so it is rendered the same way every time
+func newFeed(r *http.Request, items []feed.Item) feed.Feed {
+	baseURL := handlers.BaseURL(r)
+	entries := make([]feed.Entry, 0, len(items))
+	for _, item := range items {
+		entries = append(entries, newEntry(baseURL, item))
+	}
+
+	feedID := feedIDPrefix
+	if r.URL.RawQuery != "" {
+		feedID += "?" + r.URL.Query().Encode()
+	}
+	updated := feed.Updated(entries)
+	if updated.IsZero() {
+		updated = time.Unix(0, 0)
+	}
+
+	return feed.Feed{
+		ID:          feedID,
+		Title:       "New books",
+		Description: "The books recently added to the library",
+		Link:        baseURL + "/v1/books?sort=created_at,desc",
+		SelfLink:    baseURL + r.URL.RequestURI(),
+		Updated:     updated,
+		Entries:     entries,
+	}
+}
+
+func newEntry(baseURL string, item feed.Item) feed.Entry {
+	bookURL := baseURL + "/v1/books/" + strconv.FormatInt(item.ID, 10)
+	entry := feed.Entry{
+		ID:         feed.BookID(item.ID),
+		Title:      item.Title,
+		Summary:    item.Description,
+		Link:       bookURL,
+		Authors:    item.Authors,
+		Categories: append(append([]string{}, item.Categories...), item.Tags...),
+		Published:  item.CreatedAt,
+		Updated:    item.UpdatedAt,
+	}
+	if entry.Summary == "" {
+		entry.Summary = item.Subtitle
+	}
+	if item.Cover != nil {
+		entry.Enclosure = &feed.Enclosure{
+			URL:    bookURL + "/cover",
+			Type:   item.Cover.MIMEType,
+			Length: item.Cover.Size,
+		}
+	}
+
+	return entry
+}
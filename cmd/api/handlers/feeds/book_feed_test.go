@@ -0,0 +1,199 @@
+package feeds
+
+import (
+	"context"
+	"errors"
+	apiErrors "github.com/sdreger/lib-manager-go/cmd/api/errors"
+	"github.com/sdreger/lib-manager-go/cmd/api/handlers"
+	"github.com/sdreger/lib-manager-go/internal/domain/book"
+	"github.com/sdreger/lib-manager-go/internal/feed"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"testing"
+	"time"
+)
+
+var (
+	testAdded   = time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	testUpdated = time.Date(2026, 10, 5, 8, 30, 0, 0, time.UTC)
+)
+
+func TestBookFeedController_RegisterRoutes(t *testing.T) {
+	testRegistrar := handlers.RouteRegistrarMock{}
+	cnt := getBookFeedController()
+	cnt.RegisterRoutes(&testRegistrar)
+
+	assert.True(t, testRegistrar.IsRouteRegistered("GET /feeds/new.atom", cnt.GetNewBooksAtom))
+	assert.True(t, testRegistrar.IsRouteRegistered("GET /feeds/new.rss", cnt.GetNewBooksRSS))
+}
+
+func TestBookFeedController_GetNewBooksAtom(t *testing.T) {
+	ctx := context.Background()
+	controller := getBookFeedController()
+
+	mockService := NewMockFeedService(t)
+	mockService.EXPECT().GetNewBooks(ctx, getFilter(t, "tag=3"), uint64(feedSize)).
+		Return(getTestItems(), nil).Once()
+	injectBookFeedMocks(controller, mockService)
+
+	request := httptest.NewRequest("GET", "https://books.example.com/feeds/new.atom?tag=3", nil)
+	recorder := httptest.NewRecorder()
+	require.NoError(t, controller.GetNewBooksAtom(ctx, recorder, request))
+
+	body := readBody(t, recorder)
+	assert.Equal(t, "application/atom+xml; charset=utf-8", recorder.Header().Get("Content-Type"))
+	assert.NotEmpty(t, recorder.Header().Get("ETag"))
+	assert.Equal(t, testUpdated.Format(http.TimeFormat), recorder.Header().Get("Last-Modified"))
+	assert.Contains(t, body, "<id>urn:lib-manager:feeds:new?tag=3</id>")
+	assert.Contains(t, body, "<updated>2026-10-05T08:30:00Z</updated>")
+	assert.Contains(t, body, `<link rel="self" href="https://books.example.com/feeds/new.atom?tag=3"`)
+	assert.Contains(t, body, "<published>2026-10-01T12:00:00Z</published>")
+	assert.Contains(t, body, `<link rel="alternate" href="https://books.example.com/v1/books/2">`)
+	assert.Contains(t, body,
+		`<link rel="enclosure" href="https://books.example.com/v1/books/2/cover" type="image/jpeg" length="1024">`)
+	assert.Contains(t, body, "<summary>A subtitle</summary>", "the subtitle should be the summary fallback")
+	assert.Contains(t, body, `<category term="Programming">`)
+	assert.Contains(t, body, `<category term="go">`)
+}
+
+func TestBookFeedController_GetNewBooksRSS(t *testing.T) {
+	ctx := context.Background()
+	controller := getBookFeedController()
+
+	mockService := NewMockFeedService(t)
+	mockService.EXPECT().GetNewBooks(ctx, getFilter(t, ""), uint64(feedSize)).Return(getTestItems(), nil).Once()
+	injectBookFeedMocks(controller, mockService)
+
+	request := handlers.WithBaseURL(httptest.NewRequest("GET", "/feeds/new.rss", nil), "https://books.example.com")
+	recorder := httptest.NewRecorder()
+	require.NoError(t, controller.GetNewBooksRSS(ctx, recorder, request))
+
+	body := readBody(t, recorder)
+	assert.Equal(t, "application/rss+xml; charset=utf-8", recorder.Header().Get("Content-Type"))
+	assert.Contains(t, body, `<atom:link rel="self" href="https://books.example.com/feeds/new.rss"`)
+	assert.Contains(t, body, "<link>https://books.example.com/v1/books/2</link>")
+	assert.Contains(t, body, `<guid isPermaLink="false">urn:lib-manager:book:2</guid>`)
+	assert.Contains(t, body,
+		`<enclosure url="https://books.example.com/v1/books/2/cover" length="1024" type="image/jpeg">`)
+}
+
+func TestBookFeedController_GetNewBooks_Empty(t *testing.T) {
+	ctx := context.Background()
+	controller := getBookFeedController()
+
+	mockService := NewMockFeedService(t)
+	mockService.EXPECT().GetNewBooks(ctx, getFilter(t, ""), uint64(feedSize)).Return([]feed.Item{}, nil).Once()
+	injectBookFeedMocks(controller, mockService)
+
+	request := httptest.NewRequest("GET", "/feeds/new.atom", nil)
+	recorder := httptest.NewRecorder()
+	require.NoError(t, controller.GetNewBooksAtom(ctx, recorder, request))
+
+	assert.Contains(t, readBody(t, recorder), "<updated>1970-01-01T00:00:00Z</updated>")
+}
+
+func TestBookFeedController_GetNewBooks_NotModified(t *testing.T) {
+	ctx := context.Background()
+	controller := getBookFeedController()
+
+	mockService := NewMockFeedService(t)
+	mockService.EXPECT().GetNewBooks(ctx, getFilter(t, ""), uint64(feedSize)).Return(getTestItems(), nil).Times(3)
+	injectBookFeedMocks(controller, mockService)
+
+	recorder := httptest.NewRecorder()
+	require.NoError(t, controller.GetNewBooksAtom(ctx, recorder, httptest.NewRequest("GET", "/feeds/new.atom", nil)))
+	etag := recorder.Header().Get("ETag")
+
+	request := httptest.NewRequest("GET", "/feeds/new.atom", nil)
+	request.Header.Set("If-None-Match", etag)
+	recorder = httptest.NewRecorder()
+	require.NoError(t, controller.GetNewBooksAtom(ctx, recorder, request))
+	assert.Equal(t, http.StatusNotModified, recorder.Code, "the matching ETag should not be modified")
+
+	request = httptest.NewRequest("GET", "/feeds/new.atom", nil)
+	request.Header.Set("If-Modified-Since", testUpdated.Format(http.TimeFormat))
+	recorder = httptest.NewRecorder()
+	require.NoError(t, controller.GetNewBooksAtom(ctx, recorder, request))
+	assert.Equal(t, http.StatusNotModified, recorder.Code, "the feed should not be modified since the last update")
+}
+
+func TestBookFeedController_GetNewBooks_ValidationError(t *testing.T) {
+	ctx := context.Background()
+	controller := getBookFeedController()
+
+	request := httptest.NewRequest("GET", "/feeds/new.atom?tag=go", nil)
+	err := controller.GetNewBooksAtom(ctx, httptest.NewRecorder(), request)
+	var validationError apiErrors.ValidationError
+	assert.ErrorAs(t, err, &validationError)
+}
+
+func TestBookFeedController_GetNewBooks_ServiceError(t *testing.T) {
+	ctx := context.Background()
+	controller := getBookFeedController()
+	serviceError := errors.New("service error")
+
+	mockService := NewMockFeedService(t)
+	mockService.EXPECT().GetNewBooks(ctx, mock.Anything, mock.Anything).Return(nil, serviceError).Once()
+	injectBookFeedMocks(controller, mockService)
+
+	request := httptest.NewRequest("GET", "/feeds/new.rss", nil)
+	err := controller.GetNewBooksRSS(ctx, httptest.NewRecorder(), request)
+	assert.ErrorIs(t, err, serviceError)
+}
+
+func getTestItems() []feed.Item {
+	return []feed.Item{
+		{
+			Book: book.Book{
+				ID:         2,
+				Title:      "Learning Go",
+				Subtitle:   "A subtitle",
+				Authors:    []string{"Jon Bodner"},
+				Categories: []string{"Programming"},
+				Tags:       []string{"go"},
+				CreatedAt:  testAdded,
+				UpdatedAt:  testUpdated,
+			},
+			Cover: &feed.Cover{MIMEType: "image/jpeg", Size: 1024},
+		},
+		{
+			Book: book.Book{ID: 1, Title: "Go in Action", CreatedAt: testAdded, UpdatedAt: testAdded},
+		},
+	}
+}
+
+func getFilter(t *testing.T, query string) book.Filter {
+	values, err := url.ParseQuery(query)
+	require.NoError(t, err)
+	filter, err := book.NewFilter(values)
+	require.NoError(t, err)
+
+	return filter
+}
+
+func readBody(t *testing.T, recorder *httptest.ResponseRecorder) string {
+	t.Helper()
+	result := recorder.Result()
+	defer result.Body.Close()
+	require.Equal(t, http.StatusOK, result.StatusCode)
+	data, err := io.ReadAll(result.Body)
+	require.NoError(t, err)
+
+	return string(data)
+}
+
+func getBookFeedController() *BookFeedController {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	return NewBookFeedController(logger, nil)
+}
+
+func injectBookFeedMocks(controller *BookFeedController, service FeedService) {
+	controller.feedService = service
+}
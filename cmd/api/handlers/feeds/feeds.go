@@ -0,0 +1,5 @@
+package feeds
+
+const (
+	group = "/feeds"
+)
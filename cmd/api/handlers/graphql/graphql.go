@@ -0,0 +1,122 @@
+package graphql
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	gql "github.com/graph-gophers/graphql-go"
+	"github.com/jmoiron/sqlx"
+	apiErrors "github.com/sdreger/lib-manager-go/cmd/api/errors"
+	"github.com/sdreger/lib-manager-go/cmd/api/handlers"
+	"github.com/sdreger/lib-manager-go/internal/domain/book"
+	"github.com/sdreger/lib-manager-go/internal/domain/filetype"
+	"github.com/sdreger/lib-manager-go/internal/domain/publisher"
+	"github.com/sdreger/lib-manager-go/internal/domain/relation"
+	"github.com/sdreger/lib-manager-go/internal/paging"
+	"github.com/sdreger/lib-manager-go/internal/response"
+	"log/slog"
+	"net/http"
+)
+
+const (
+	graphqlPath = "/graphql"
+
+	// maxQueryDepth - limits the nested relation lists, e.g. the books of the authors of the books
+	maxQueryDepth = 8
+	// maxRequestSize - limits the request body size
+	maxRequestSize = 1 << 20
+	// maxPageSize - limits the page size of the lists
+	maxPageSize = 100
+	// maxQueryCost - limits the number of the books and the relation items, resolved by a query, since the nested
+	// pages multiply each other within the depth limit
+	maxQueryCost = 10_000
+)
+
+//go:embed schema.graphql
+var schemaSource string
+
+type BookService interface {
+	GetBooks(ctx context.Context, pageRequest paging.PageRequest, sort paging.Sort, filter book.Filter) (
+		paging.Page[book.LookupItem], error)
+	GetBookByID(ctx context.Context, bookID int64) (book.Book, error)
+	GetRelationBooks(ctx context.Context, relation string, itemIDs []int64, pageRequest paging.PageRequest,
+		sort paging.Sort) (map[int64]paging.Page[book.LookupItem], error)
+}
+
+type PublisherService interface {
+	GetPublishers(ctx context.Context, pageRequest paging.PageRequest, sort paging.Sort) (
+		paging.Page[publisher.LookupItem], error)
+}
+
+type FileTypeService interface {
+	GetFileTypes(ctx context.Context, pageRequest paging.PageRequest, sort paging.Sort) (
+		paging.Page[filetype.LookupItem], error)
+}
+
+type RelationService interface {
+	GetItems(ctx context.Context, rel relation.Relation, pageRequest paging.PageRequest, sort paging.Sort) (
+		paging.Page[relation.Item], error)
+	GetBookItems(ctx context.Context, rel relation.Relation, bookIDs []int64) (map[int64][]relation.Item, error)
+}
+
+// queryRequest - the GraphQL request, sent either as the POST JSON body, or as the GET query parameters
+type queryRequest struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+type Controller struct {
+	logger   *slog.Logger
+	resolver *Resolver
+	schema   *gql.Schema
+}
+
+func NewController(logger *slog.Logger, db *sqlx.DB) *Controller {
+	resolver := &Resolver{
+		logger:           logger,
+		bookService:      book.NewService(logger, db),
+		publisherService: publisher.NewService(logger, db),
+		fileTypeService:  filetype.NewService(logger, db),
+		relationService:  relation.NewService(logger, db),
+	}
+
+	return &Controller{
+		logger:   logger,
+		resolver: resolver,
+		schema: gql.MustParseSchema(schemaSource, resolver,
+			gql.UseStringDescriptions(), gql.MaxDepth(maxQueryDepth)),
+	}
+}
+
+func (cnt *Controller) RegisterRoutes(registrar handlers.RouteRegistrar) {
+	registrar.RegisterRoute(http.MethodGet, "", graphqlPath, cnt.Query)
+	registrar.RegisterRoute(http.MethodPost, "", graphqlPath, cnt.Query)
+}
+
+// Query - executes the GraphQL query. The query errors are returned in the response 'errors' list,
+// along with the partial data, as the GraphQL clients expect
+func (cnt *Controller) Query(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	request := queryRequest{
+		Query:         r.URL.Query().Get("query"),
+		OperationName: r.URL.Query().Get("operationName"),
+	}
+	if variables := r.URL.Query().Get("variables"); variables != "" {
+		if err := json.Unmarshal([]byte(variables), &request.Variables); err != nil {
+			return apiErrors.ValidationError{Field: "variables", Message: "the variables should be a JSON object"}
+		}
+	}
+	if r.Method == http.MethodPost {
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestSize)).Decode(&request); err != nil {
+			return apiErrors.ValidationError{Field: "body", Message: "the request body should be a GraphQL JSON request"}
+		}
+	}
+	if request.Query == "" {
+		return apiErrors.ValidationError{Field: "query", Message: "the query should not be empty"}
+	}
+
+	ctx = withLoader(ctx, newRelationLoader(cnt.resolver.relationService, cnt.resolver.bookService))
+	result := cnt.schema.Exec(ctx, request.Query, request.OperationName, request.Variables)
+
+	return response.RenderJSONWithHeaders(w, http.StatusOK, result, nil)
+}
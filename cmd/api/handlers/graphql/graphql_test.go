@@ -0,0 +1,301 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/sdreger/lib-manager-go/cmd/api/handlers"
+	"github.com/sdreger/lib-manager-go/internal/domain/book"
+	"github.com/sdreger/lib-manager-go/internal/domain/filetype"
+	"github.com/sdreger/lib-manager-go/internal/domain/publisher"
+	"github.com/sdreger/lib-manager-go/internal/domain/relation"
+	"github.com/sdreger/lib-manager-go/internal/paging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testResponse struct {
+	Data   map[string]any `json:"data"`
+	Errors []struct {
+		Message    string         `json:"message"`
+		Extensions map[string]any `json:"extensions"`
+	} `json:"errors"`
+}
+
+func TestController_RegisterRoutes(t *testing.T) {
+	testRegistrar := handlers.RouteRegistrarMock{}
+	cnt := getController()
+	cnt.RegisterRoutes(&testRegistrar)
+
+	assert.True(t, testRegistrar.IsRouteRegistered("GET /graphql", cnt.Query))
+	assert.True(t, testRegistrar.IsRouteRegistered("POST /graphql", cnt.Query))
+}
+
+func TestController_Query_Books(t *testing.T) {
+	controller := getController()
+	values := url.Values{"page": {"1"}, "size": {"2"}, "sort": {"title,asc"}, "author": {"1", "2"}, "query": {"go"}}
+	pageRequest, _ := paging.NewPageRequest(values)
+	sort, _ := paging.NewSort(values, book.AllowedSortFields)
+	filter, _ := book.NewFilter(values)
+	books := []book.LookupItem{
+		{ID: 1, Title: "Go in Action", ISBN13: 9781617291784, Pages: 264, Edition: 1,
+			PubDate: time.Date(2015, time.November, 4, 0, 0, 0, 0, time.UTC)},
+		{ID: 2, Title: "Learning Go", Subtitle: "An Idiomatic Approach"},
+	}
+
+	bookService := NewMockBookService(t)
+	bookService.EXPECT().GetBooks(mock.Anything, pageRequest, sort, filter).
+		Return(paging.NewPage(pageRequest, 3, books), nil).Once()
+	relationService := NewMockRelationService(t)
+	relationService.EXPECT().GetBookItems(mock.Anything, relation.Authors, mock.Anything).
+		RunAndReturn(func(ctx context.Context, rel relation.Relation, bookIDs []int64) (
+			map[int64][]relation.Item, error) {
+			assert.ElementsMatch(t, []int64{1, 2}, bookIDs, "the authors should be loaded for all the books at once")
+			return map[int64][]relation.Item{1: {{ID: 1, Name: "William Kennedy"}}, 2: {}}, nil
+		}).Once()
+	relationService.EXPECT().GetBookItems(mock.Anything, relation.Publishers, mock.Anything).
+		Return(map[int64][]relation.Item{1: {{ID: 1, Name: "Manning"}}, 2: {}}, nil).Once()
+	injectMocks(controller, bookService, relationService)
+
+	result := execute(t, controller, `query Books($authors: [ID!]) {
+  books(page: 1, size: 2, sort: "title,asc", filter: {query: "go", authors: $authors}) {
+    page size totalPages totalElements
+    content { id title subtitle isbn13 pubDate pages edition authors { id name } publisher { name } }
+  }
+}`, map[string]any{"authors": []string{"1", "2"}})
+
+	require.Empty(t, result.Errors)
+	assert.Equal(t, map[string]any{
+		"page": 1.0, "size": 2.0, "totalPages": 2.0, "totalElements": 3.0,
+		"content": []any{
+			map[string]any{"id": "1", "title": "Go in Action", "subtitle": nil, "isbn13": "9781617291784",
+				"pubDate": "2015-11-04", "pages": 264.0, "edition": 1.0,
+				"authors":   []any{map[string]any{"id": "1", "name": "William Kennedy"}},
+				"publisher": map[string]any{"name": "Manning"}},
+			map[string]any{"id": "2", "title": "Learning Go", "subtitle": "An Idiomatic Approach", "isbn13": nil,
+				"pubDate": nil, "pages": 0.0, "edition": 0.0, "authors": []any{}, "publisher": nil},
+		},
+	}, result.Data["books"])
+}
+
+func TestController_Query_Book(t *testing.T) {
+	controller := getController()
+
+	bookService := NewMockBookService(t)
+	bookService.EXPECT().GetBookByID(mock.Anything, int64(1)).
+		Return(book.Book{ID: 1, Title: "Go in Action"}, nil).Once()
+	bookService.EXPECT().GetBookByID(mock.Anything, int64(2)).Return(book.Book{}, book.ErrNotFound).Once()
+	relationService := NewMockRelationService(t)
+	relationService.EXPECT().GetBookItems(mock.Anything, relation.Tags, []int64{1}).
+		Return(map[int64][]relation.Item{1: {{ID: 3, Name: "golang"}}}, nil).Once()
+	injectMocks(controller, bookService, relationService)
+
+	result := execute(t, controller, `{ found: book(id: 1) { title tags { name } } missing: book(id: 2) { title } }`, nil)
+
+	require.Empty(t, result.Errors)
+	assert.Equal(t, map[string]any{"title": "Go in Action", "tags": []any{map[string]any{"name": "golang"}}},
+		result.Data["found"])
+	assert.Nil(t, result.Data["missing"], "the missing book should be null")
+}
+
+func TestController_Query_NestedBooks(t *testing.T) {
+	controller := getController()
+	values := url.Values{"page": {"1"}, "size": {"10"}}
+	pageRequest, _ := paging.NewPageRequest(values)
+	sort, _ := paging.NewSort(values, book.AllowedSortFields)
+
+	bookService := NewMockBookService(t)
+	bookService.EXPECT().GetRelationBooks(mock.Anything, "tag", mock.Anything, pageRequest, sort).
+		RunAndReturn(func(ctx context.Context, rel string, itemIDs []int64, pageRequest paging.PageRequest,
+			sort paging.Sort) (map[int64]paging.Page[book.LookupItem], error) {
+			assert.ElementsMatch(t, []int64{3, 4}, itemIDs, "the books should be loaded for all the tags at once")
+			return map[int64]paging.Page[book.LookupItem]{
+				3: paging.NewPage(pageRequest, 1, []book.LookupItem{{ID: 5, Title: "Concurrency in Go"}}),
+				4: paging.NewPage(pageRequest, 0, []book.LookupItem{}),
+			}, nil
+		}).Once()
+	relationService := NewMockRelationService(t)
+	relationService.EXPECT().GetItems(mock.Anything, relation.Tags, mock.Anything, mock.Anything).
+		Return(paging.Page[relation.Item]{Page: 1, Size: 2, TotalPages: 1, TotalItems: 2,
+			Content: []relation.Item{{ID: 3, Name: "golang"}, {ID: 4, Name: "rust"}}}, nil).Once()
+	injectMocks(controller, bookService, relationService)
+
+	result := execute(t, controller, `{ tags { content { name books { content { title } } } } }`, nil)
+
+	require.Empty(t, result.Errors)
+	assert.Equal(t, map[string]any{"content": []any{
+		map[string]any{"name": "golang",
+			"books": map[string]any{"content": []any{map[string]any{"title": "Concurrency in Go"}}}},
+		map[string]any{"name": "rust", "books": map[string]any{"content": []any{}}},
+	}}, result.Data["tags"])
+}
+
+func TestController_Query_PublishersAndFileTypes(t *testing.T) {
+	controller := getController()
+	publisherService := NewMockPublisherService(t)
+	publisherService.EXPECT().GetPublishers(mock.Anything, mock.Anything, mock.Anything).
+		Return(paging.Page[publisher.LookupItem]{Page: 1, Content: []publisher.LookupItem{{ID: 1, Name: "Manning"}}},
+			nil).Once()
+	fileTypeService := NewMockFileTypeService(t)
+	fileTypeService.EXPECT().GetFileTypes(mock.Anything, mock.Anything, mock.Anything).
+		Return(paging.Page[filetype.LookupItem]{Page: 1, Content: []filetype.LookupItem{{ID: 2, Name: "epub"}}},
+			nil).Once()
+	controller.resolver.publisherService = publisherService
+	controller.resolver.fileTypeService = fileTypeService
+
+	result := execute(t, controller, `{ publishers(sort: "name,asc") { content { id name } }
+  fileTypes { content { id name } } }`, nil)
+
+	require.Empty(t, result.Errors)
+	assert.Equal(t, map[string]any{"content": []any{map[string]any{"id": "1", "name": "Manning"}}},
+		result.Data["publishers"])
+	assert.Equal(t, map[string]any{"content": []any{map[string]any{"id": "2", "name": "epub"}}},
+		result.Data["fileTypes"])
+}
+
+func TestController_Query_ValidationError(t *testing.T) {
+	controller := getController()
+
+	result := execute(t, controller, `{ authors(sort: "title,asc") { totalElements } }`, nil)
+
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, `sort field "title" is not allowed`, result.Errors[0].Message)
+	assert.Equal(t, "sort", result.Errors[0].Extensions["field"])
+}
+
+func TestController_Query_MaxPageSize(t *testing.T) {
+	controller := getController()
+
+	result := execute(t, controller, `{ books(size: 1000) { totalElements } }`, nil)
+
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, "the page size should not exceed 100", result.Errors[0].Message)
+	assert.Equal(t, "size", result.Errors[0].Extensions["field"])
+}
+
+func TestController_Query_MaxCost(t *testing.T) {
+	controller := getController()
+	pageRequest, _ := paging.NewPageRequest(url.Values{"size": {"100"}})
+	items := make([]relation.Item, 100)
+	for i := range items {
+		items[i] = relation.Item{ID: int64(i + 1), Name: strconv.Itoa(i + 1)}
+	}
+	pages := make(map[int64]paging.Page[book.LookupItem])
+	for i := range items {
+		books := make([]book.LookupItem, 100)
+		for j := range books {
+			books[j] = book.LookupItem{ID: int64(i*100 + j + 1), Title: "Book"}
+		}
+		pages[int64(i+1)] = paging.NewPage(pageRequest, 100, books)
+	}
+
+	bookService := NewMockBookService(t)
+	bookService.EXPECT().GetRelationBooks(mock.Anything, "author", mock.Anything, mock.Anything, mock.Anything).
+		Return(pages, nil).Once()
+	relationService := NewMockRelationService(t)
+	relationService.EXPECT().GetItems(mock.Anything, relation.Authors, mock.Anything, mock.Anything).
+		Return(paging.NewPage(pageRequest, 100, items), nil).Once()
+	injectMocks(controller, bookService, relationService)
+
+	result := execute(t, controller, `{ authors(size: 100) { content { books(size: 100) { content { title } } } } }`,
+		nil)
+
+	require.NotEmpty(t, result.Errors, "the too costly query should be rejected")
+	assert.Equal(t, "the query exceeds the limit of 10000 resolved objects", result.Errors[0].Message)
+}
+
+func TestController_Query_ServiceError(t *testing.T) {
+	controller := getController()
+	bookService := NewMockBookService(t)
+	bookService.EXPECT().GetBooks(mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(paging.Page[book.LookupItem]{}, errors.New("connection refused")).Once()
+	injectMocks(controller, bookService, NewMockRelationService(t))
+
+	result := execute(t, controller, `{ books { totalElements } }`, nil)
+
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, "internal server error", result.Errors[0].Message, "the internal details should not be exposed")
+}
+
+func TestController_Query_GET(t *testing.T) {
+	controller := getController()
+	relationService := NewMockRelationService(t)
+	relationService.EXPECT().GetItems(mock.Anything, relation.Languages, mock.Anything, mock.Anything).
+		Return(paging.Page[relation.Item]{Page: 1, Content: []relation.Item{{ID: 1, Name: "English"}}}, nil).Once()
+	injectMocks(controller, NewMockBookService(t), relationService)
+
+	query := url.Values{
+		"query":     {`query Languages($size: Int) { languages(size: $size) { content { name } } }`},
+		"variables": {`{"size": 5}`},
+	}
+	request := httptest.NewRequest(http.MethodGet, "/graphql?"+query.Encode(), nil)
+	recorder := httptest.NewRecorder()
+	require.NoError(t, controller.Query(context.Background(), recorder, request))
+
+	var result testResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &result))
+	require.Empty(t, result.Errors)
+	assert.Equal(t, map[string]any{"content": []any{map[string]any{"name": "English"}}}, result.Data["languages"])
+}
+
+func TestController_Query_BadRequest(t *testing.T) {
+	controller := getController()
+
+	request := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(`{"query": ""}`))
+	err := controller.Query(context.Background(), httptest.NewRecorder(), request)
+	assert.ErrorContains(t, err, "the query should not be empty")
+
+	request = httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(`not a JSON`))
+	err = controller.Query(context.Background(), httptest.NewRecorder(), request)
+	assert.ErrorContains(t, err, "the request body should be a GraphQL JSON request")
+
+	request = httptest.NewRequest(http.MethodGet, "/graphql?query=%7Bbooks%7D&variables=1", nil)
+	err = controller.Query(context.Background(), httptest.NewRecorder(), request)
+	assert.ErrorContains(t, err, "the variables should be a JSON object")
+}
+
+func TestController_Query_MaxDepth(t *testing.T) {
+	controller := getController()
+
+	result := execute(t, controller, `{ books { content { authors { books { content { tags { books {
+  content { authors { name } } } } } } } } } }`, nil)
+
+	require.NotEmpty(t, result.Errors, "the too deep query should be rejected")
+	assert.Contains(t, result.Errors[0].Message, "exceeds max depth")
+}
+
+func execute(t *testing.T, controller *Controller, query string, variables map[string]any) testResponse {
+	t.Helper()
+	body, err := json.Marshal(map[string]any{"query": query, "variables": variables})
+	require.NoError(t, err)
+
+	request := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(string(body)))
+	recorder := httptest.NewRecorder()
+	require.NoError(t, controller.Query(context.Background(), recorder, request))
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var result testResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &result))
+
+	return result
+}
+
+func getController() *Controller {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.Level(100)}))
+	return NewController(logger, nil)
+}
+
+func injectMocks(controller *Controller, bookService BookService, relationService RelationService) {
+	controller.resolver.bookService = bookService
+	controller.resolver.relationService = relationService
+}
@@ -0,0 +1,161 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	apiErrors "github.com/sdreger/lib-manager-go/cmd/api/errors"
+	"github.com/sdreger/lib-manager-go/internal/domain/book"
+	"github.com/sdreger/lib-manager-go/internal/domain/relation"
+	"github.com/sdreger/lib-manager-go/internal/paging"
+	"sync"
+)
+
+type loaderContextKey struct{}
+
+// relationLoader - the request scoped batch loader of the book relations, and of the relation item books.
+// The books and the relation items, resolved during the request, are registered in the loader. The first relation
+// lookup loads that relation for all the registered books with a single query, and the first books page lookup
+// loads the same page for all the registered items of the relation, so the nested lists do not cause a query
+// per book or per relation item. The loader also counts the resolved objects, to limit the query cost
+type relationLoader struct {
+	relationService RelationService
+	bookService     BookService
+	mu              sync.Mutex
+	bookIDs         []int64
+	// loaded - the loaded relation items by the relation name and the book ID
+	loaded map[string]map[int64][]relation.Item
+	// itemIDs - the registered relation item IDs by the relation name
+	itemIDs map[string][]int64
+	// books - the loaded book pages by the relation name and the paging arguments, and the relation item ID
+	books map[string]map[int64]paging.Page[book.LookupItem]
+	cost  int
+}
+
+func newRelationLoader(relationService RelationService, bookService BookService) *relationLoader {
+	return &relationLoader{
+		relationService: relationService,
+		bookService:     bookService,
+		loaded:          make(map[string]map[int64][]relation.Item),
+		itemIDs:         make(map[string][]int64),
+		books:           make(map[string]map[int64]paging.Page[book.LookupItem]),
+	}
+}
+
+func withLoader(ctx context.Context, loader *relationLoader) context.Context {
+	return context.WithValue(ctx, loaderContextKey{}, loader)
+}
+
+func loaderFromContext(ctx context.Context) *relationLoader {
+	return ctx.Value(loaderContextKey{}).(*relationLoader)
+}
+
+// addBooks - registers the books, those relations are loaded together
+func (l *relationLoader) addBooks(bookIDs ...int64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.bookIDs = append(l.bookIDs, bookIDs...)
+}
+
+// addItems - registers the relation items, those books are loaded together
+func (l *relationLoader) addItems(rel relation.Relation, itemIDs ...int64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.itemIDs[rel.Name] = append(l.itemIDs[rel.Name], itemIDs...)
+}
+
+// spend - adds the resolved objects to the query cost, and fails once the cost exceeds the limit
+func (l *relationLoader) spend(objects int) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.cost += objects
+	if l.cost > maxQueryCost {
+		return apiErrors.ValidationError{
+			Field:   "query",
+			Message: fmt.Sprintf("the query exceeds the limit of %d resolved objects", maxQueryCost),
+		}
+	}
+
+	return nil
+}
+
+// load - returns the relation items of the book, loading the relation for all the registered books,
+// which do not have it loaded yet
+func (l *relationLoader) load(ctx context.Context, rel relation.Relation, bookID int64) ([]relation.Item, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	loaded, ok := l.loaded[rel.Name]
+	if !ok {
+		loaded = make(map[int64][]relation.Item)
+		l.loaded[rel.Name] = loaded
+	}
+	if items, ok := loaded[bookID]; ok {
+		return items, nil
+	}
+
+	pending := pendingIDs(bookID, l.bookIDs, func(id int64) bool {
+		_, ok := loaded[id]
+		return ok
+	})
+	items, err := l.relationService.GetBookItems(ctx, rel, pending)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range pending {
+		loaded[id] = items[id]
+	}
+
+	return loaded[bookID], nil
+}
+
+// loadBooks - returns the books page of the relation item, loading the same page for all the registered items
+// of the relation, which do not have it loaded yet. The key identifies the paging arguments. The loaded books
+// are registered, so their relations are loaded together as well
+func (l *relationLoader) loadBooks(ctx context.Context, rel relation.Relation, itemID int64, key string,
+	pageRequest paging.PageRequest, sort paging.Sort) (paging.Page[book.LookupItem], error) {
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	key = rel.Name + "?" + key
+	loaded, ok := l.books[key]
+	if !ok {
+		loaded = make(map[int64]paging.Page[book.LookupItem])
+		l.books[key] = loaded
+	}
+	if page, ok := loaded[itemID]; ok {
+		return page, nil
+	}
+
+	pending := pendingIDs(itemID, l.itemIDs[rel.Name], func(id int64) bool {
+		_, ok := loaded[id]
+		return ok
+	})
+	pages, err := l.bookService.GetRelationBooks(ctx, filterParams[rel.Name], pending, pageRequest, sort)
+	if err != nil {
+		return paging.Page[book.LookupItem]{}, err
+	}
+	for _, id := range pending {
+		loaded[id] = pages[id]
+		for _, item := range pages[id].Content {
+			l.bookIDs = append(l.bookIDs, item.ID)
+		}
+	}
+
+	return loaded[itemID], nil
+}
+
+// pendingIDs - returns the requested ID, followed by the registered IDs, which are not loaded yet, without
+// the duplicates
+func pendingIDs(requestedID int64, registeredIDs []int64, isLoaded func(id int64) bool) []int64 {
+	pending := []int64{requestedID}
+	seen := map[int64]bool{requestedID: true}
+	for _, id := range registeredIDs {
+		if !isLoaded(id) && !seen[id] {
+			pending = append(pending, id)
+			seen[id] = true
+		}
+	}
+
+	return pending
+}
@@ -0,0 +1,77 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"github.com/sdreger/lib-manager-go/internal/domain/book"
+	"github.com/sdreger/lib-manager-go/internal/domain/relation"
+	"github.com/sdreger/lib-manager-go/internal/paging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestRelationLoader_Load(t *testing.T) {
+	ctx := context.Background()
+	service := NewMockRelationService(t)
+	service.EXPECT().GetBookItems(ctx, relation.Authors, []int64{2, 1, 3}).
+		Return(map[int64][]relation.Item{1: {{ID: 1, Name: "William Kennedy"}}, 2: {}, 3: {}}, nil).Once()
+	service.EXPECT().GetBookItems(ctx, relation.Authors, []int64{4}).
+		Return(map[int64][]relation.Item{4: {{ID: 2, Name: "Jon Bodner"}}}, nil).Once()
+	loader := newRelationLoader(service, nil)
+
+	loader.addBooks(1, 2, 3, 2)
+	items, err := loader.load(ctx, relation.Authors, 2)
+	require.NoError(t, err)
+	assert.Empty(t, items)
+
+	items, err = loader.load(ctx, relation.Authors, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []relation.Item{{ID: 1, Name: "William Kennedy"}}, items, "the loaded items should be reused")
+
+	loader.addBooks(4)
+	items, err = loader.load(ctx, relation.Authors, 4)
+	require.NoError(t, err)
+	assert.Equal(t, []relation.Item{{ID: 2, Name: "Jon Bodner"}}, items, "only the new books should be loaded")
+}
+
+func TestRelationLoader_Load_Error(t *testing.T) {
+	ctx := context.Background()
+	serviceError := errors.New("service error")
+	service := NewMockRelationService(t)
+	service.EXPECT().GetBookItems(ctx, relation.Tags, []int64{1}).Return(nil, serviceError).Once()
+	loader := newRelationLoader(service, nil)
+
+	_, err := loader.load(ctx, relation.Tags, 1)
+	assert.ErrorIs(t, err, serviceError)
+}
+
+func TestRelationLoader_LoadBooks(t *testing.T) {
+	ctx := context.Background()
+	pageRequest, _ := paging.NewPageRequest(nil)
+	sort, _ := paging.NewSort(nil, book.AllowedSortFields)
+	authorBooks := paging.NewPage(pageRequest, 1, []book.LookupItem{{ID: 5, Title: "Go in Action"}})
+	service := NewMockBookService(t)
+	service.EXPECT().GetRelationBooks(ctx, "author", []int64{2, 1}, pageRequest, sort).
+		Return(map[int64]paging.Page[book.LookupItem]{1: authorBooks, 2: paging.NewPage(pageRequest, 0,
+			[]book.LookupItem{})}, nil).Once()
+	loader := newRelationLoader(nil, service)
+
+	loader.addItems(relation.Authors, 1, 2)
+	loader.addItems(relation.Tags, 3)
+	page, err := loader.loadBooks(ctx, relation.Authors, 2, "page=1", pageRequest, sort)
+	require.NoError(t, err)
+	assert.Empty(t, page.Content)
+
+	page, err = loader.loadBooks(ctx, relation.Authors, 1, "page=1", pageRequest, sort)
+	require.NoError(t, err)
+	assert.Equal(t, authorBooks, page, "the loaded pages should be reused")
+	assert.Equal(t, []int64{5}, loader.bookIDs, "the loaded books should be registered")
+}
+
+func TestRelationLoader_Spend(t *testing.T) {
+	loader := newRelationLoader(nil, nil)
+
+	require.NoError(t, loader.spend(maxQueryCost))
+	assert.ErrorContains(t, loader.spend(1), "the query exceeds the limit")
+}
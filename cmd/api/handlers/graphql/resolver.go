@@ -0,0 +1,537 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	gql "github.com/graph-gophers/graphql-go"
+	apiErrors "github.com/sdreger/lib-manager-go/cmd/api/errors"
+	"github.com/sdreger/lib-manager-go/internal/domain/book"
+	"github.com/sdreger/lib-manager-go/internal/domain/filetype"
+	"github.com/sdreger/lib-manager-go/internal/domain/publisher"
+	"github.com/sdreger/lib-manager-go/internal/domain/relation"
+	"github.com/sdreger/lib-manager-go/internal/paging"
+	"log/slog"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+var (
+	errInternal = errors.New("internal server error")
+
+	// filterParams - the book filter query parameters of the relations
+	filterParams = map[string]string{
+		relation.Authors.Name:    "author",
+		relation.Categories.Name: "category",
+		relation.FileTypes.Name:  "file_type",
+		relation.Languages.Name:  "language",
+		relation.Publishers.Name: "publisher",
+		relation.Tags.Name:       "tag",
+	}
+)
+
+// queryError - the user input error, the field is reported in the GraphQL error extensions
+type queryError struct {
+	apiErrors.ValidationError
+}
+
+func (e queryError) Error() string {
+	return e.Message
+}
+
+func (e queryError) Extensions() map[string]interface{} {
+	return map[string]interface{}{"field": e.Field}
+}
+
+type pageArgs struct {
+	Page int32
+	Size int32
+	Sort *string
+}
+
+// validate - checks the page size limit, the other arguments are validated the same way as the REST ones
+func (args pageArgs) validate() error {
+	if args.Size > maxPageSize {
+		return apiErrors.ValidationError{
+			Field:   "size",
+			Message: fmt.Sprintf("the page size should not exceed %d", maxPageSize),
+		}
+	}
+
+	return nil
+}
+
+// values - the query values of the paging arguments, those are validated the same way as the REST ones
+func (args pageArgs) values() url.Values {
+	values := url.Values{
+		"page": {strconv.Itoa(int(args.Page))},
+		"size": {strconv.Itoa(int(args.Size))},
+	}
+	if args.Sort != nil {
+		values.Set("sort", *args.Sort)
+	}
+
+	return values
+}
+
+type bookFilterInput struct {
+	Query      *string
+	Sbn        *string
+	Languages  *[]gql.ID
+	Publishers *[]gql.ID
+	Authors    *[]gql.ID
+	Categories *[]gql.ID
+	FileTypes  *[]gql.ID
+	Tags       *[]gql.ID
+}
+
+// addTo - adds the filter to the query values, those are validated the same way as the REST ones
+func (filter *bookFilterInput) addTo(values url.Values) {
+	if filter == nil {
+		return
+	}
+	if filter.Query != nil {
+		values.Set("query", *filter.Query)
+	}
+	if filter.Sbn != nil {
+		values.Set("sbn", *filter.Sbn)
+	}
+	lists := map[string]*[]gql.ID{
+		filterParams[relation.Languages.Name]:  filter.Languages,
+		filterParams[relation.Publishers.Name]: filter.Publishers,
+		filterParams[relation.Authors.Name]:    filter.Authors,
+		filterParams[relation.Categories.Name]: filter.Categories,
+		filterParams[relation.FileTypes.Name]:  filter.FileTypes,
+		filterParams[relation.Tags.Name]:       filter.Tags,
+	}
+	for param, ids := range lists {
+		if ids == nil {
+			continue
+		}
+		for _, id := range *ids {
+			values.Add(param, string(id))
+		}
+	}
+}
+
+// Resolver - the root query resolver
+type Resolver struct {
+	logger           *slog.Logger
+	bookService      BookService
+	publisherService PublisherService
+	fileTypeService  FileTypeService
+	relationService  RelationService
+}
+
+func (r *Resolver) Books(ctx context.Context, args struct {
+	Page   int32
+	Size   int32
+	Sort   *string
+	Filter *bookFilterInput
+}) (*pageResolver[*bookResolver], error) {
+	pageParams := pageArgs{Page: args.Page, Size: args.Size, Sort: args.Sort}
+	if err := pageParams.validate(); err != nil {
+		return nil, r.resolveError(err)
+	}
+	values := pageParams.values()
+	args.Filter.addTo(values)
+
+	return r.books(ctx, values)
+}
+
+func (r *Resolver) Book(ctx context.Context, args struct{ ID gql.ID }) (*bookResolver, error) {
+	bookID, err := strconv.ParseInt(string(args.ID), 10, 64)
+	if err != nil {
+		return nil, queryError{apiErrors.ValidationError{Field: "id", Message: "the book ID should be a number"}}
+	}
+
+	found, err := r.bookService.GetBookByID(ctx, bookID)
+	if errors.Is(err, book.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, r.resolveError(err)
+	}
+	loader := loaderFromContext(ctx)
+	if err := loader.spend(1); err != nil {
+		return nil, r.resolveError(err)
+	}
+	loader.addBooks(found.ID)
+
+	return &bookResolver{root: r, item: lookupItem(found)}, nil
+}
+
+func (r *Resolver) Authors(ctx context.Context, args pageArgs) (*pageResolver[*relationResolver], error) {
+	return r.relationItems(ctx, relation.Authors, args)
+}
+
+func (r *Resolver) Categories(ctx context.Context, args pageArgs) (*pageResolver[*relationResolver], error) {
+	return r.relationItems(ctx, relation.Categories, args)
+}
+
+func (r *Resolver) Languages(ctx context.Context, args pageArgs) (*pageResolver[*relationResolver], error) {
+	return r.relationItems(ctx, relation.Languages, args)
+}
+
+func (r *Resolver) Tags(ctx context.Context, args pageArgs) (*pageResolver[*relationResolver], error) {
+	return r.relationItems(ctx, relation.Tags, args)
+}
+
+func (r *Resolver) FileTypes(ctx context.Context, args pageArgs) (*pageResolver[*relationResolver], error) {
+	pageRequest, sort, err := newPaging(args, filetype.AllowedSortFields)
+	if err != nil {
+		return nil, r.resolveError(err)
+	}
+	page, err := r.fileTypeService.GetFileTypes(ctx, pageRequest, sort)
+	if err != nil {
+		return nil, r.resolveError(err)
+	}
+	itemIDs := make([]int64, 0, len(page.Content))
+	for _, item := range page.Content {
+		itemIDs = append(itemIDs, item.ID)
+	}
+	if err := r.addItems(ctx, relation.FileTypes, itemIDs); err != nil {
+		return nil, err
+	}
+
+	return newPageResolver(page, func(item filetype.LookupItem) *relationResolver {
+		return &relationResolver{root: r, relation: relation.FileTypes, item: relation.Item(item)}
+	}), nil
+}
+
+func (r *Resolver) Publishers(ctx context.Context, args pageArgs) (*pageResolver[*relationResolver], error) {
+	pageRequest, sort, err := newPaging(args, publisher.AllowedSortFields)
+	if err != nil {
+		return nil, r.resolveError(err)
+	}
+	page, err := r.publisherService.GetPublishers(ctx, pageRequest, sort)
+	if err != nil {
+		return nil, r.resolveError(err)
+	}
+	itemIDs := make([]int64, 0, len(page.Content))
+	for _, item := range page.Content {
+		itemIDs = append(itemIDs, item.ID)
+	}
+	if err := r.addItems(ctx, relation.Publishers, itemIDs); err != nil {
+		return nil, err
+	}
+
+	return newPageResolver(page, func(item publisher.LookupItem) *relationResolver {
+		return &relationResolver{root: r, relation: relation.Publishers, item: relation.Item(item)}
+	}), nil
+}
+
+// books - returns a page of the books, matching the filter, and registers them in the relation loader
+func (r *Resolver) books(ctx context.Context, values url.Values) (*pageResolver[*bookResolver], error) {
+	pageRequest, err := paging.NewPageRequest(values)
+	if err != nil {
+		return nil, r.resolveError(err)
+	}
+	sort, err := paging.NewSort(values, book.AllowedSortFields)
+	if err != nil {
+		return nil, r.resolveError(err)
+	}
+	filter, err := book.NewFilter(values)
+	if err != nil {
+		return nil, r.resolveError(err)
+	}
+
+	page, err := r.bookService.GetBooks(ctx, pageRequest, sort, filter)
+	if err != nil {
+		return nil, r.resolveError(err)
+	}
+
+	return r.bookPage(ctx, page)
+}
+
+// bookPage - returns the resolver of the books page, and registers the books in the relation loader
+func (r *Resolver) bookPage(ctx context.Context, page paging.Page[book.LookupItem]) (
+	*pageResolver[*bookResolver], error) {
+
+	loader := loaderFromContext(ctx)
+	if err := loader.spend(len(page.Content)); err != nil {
+		return nil, r.resolveError(err)
+	}
+	bookIDs := make([]int64, 0, len(page.Content))
+	for _, item := range page.Content {
+		bookIDs = append(bookIDs, item.ID)
+	}
+	loader.addBooks(bookIDs...)
+
+	return newPageResolver(page, func(item book.LookupItem) *bookResolver {
+		return &bookResolver{root: r, item: item}
+	}), nil
+}
+
+func (r *Resolver) relationItems(ctx context.Context, rel relation.Relation, args pageArgs) (
+	*pageResolver[*relationResolver], error) {
+
+	pageRequest, sort, err := newPaging(args, relation.AllowedSortFields)
+	if err != nil {
+		return nil, r.resolveError(err)
+	}
+	page, err := r.relationService.GetItems(ctx, rel, pageRequest, sort)
+	if err != nil {
+		return nil, r.resolveError(err)
+	}
+	itemIDs := make([]int64, 0, len(page.Content))
+	for _, item := range page.Content {
+		itemIDs = append(itemIDs, item.ID)
+	}
+	if err := r.addItems(ctx, rel, itemIDs); err != nil {
+		return nil, err
+	}
+
+	return newPageResolver(page, func(item relation.Item) *relationResolver {
+		return &relationResolver{root: r, relation: rel, item: item}
+	}), nil
+}
+
+// addItems - adds the relation items to the query cost, and registers them in the relation loader
+func (r *Resolver) addItems(ctx context.Context, rel relation.Relation, itemIDs []int64) error {
+	loader := loaderFromContext(ctx)
+	if err := loader.spend(len(itemIDs)); err != nil {
+		return r.resolveError(err)
+	}
+	loader.addItems(rel, itemIDs...)
+
+	return nil
+}
+
+// resolveError - returns the validation errors as is, the other ones are logged, and replaced by the generic one,
+// so the internal details are not exposed
+func (r *Resolver) resolveError(err error) error {
+	var validationError apiErrors.ValidationError
+	if errors.As(err, &validationError) {
+		return queryError{validationError}
+	}
+	r.logger.Error("GraphQL query resolution failed: " + err.Error())
+
+	return errInternal
+}
+
+// newPaging - returns the page request and the sort of the paging arguments
+func newPaging(args pageArgs, allowedSortFields []string) (paging.PageRequest, paging.Sort, error) {
+	if err := args.validate(); err != nil {
+		return paging.PageRequest{}, paging.Sort{}, err
+	}
+	values := args.values()
+	pageRequest, err := paging.NewPageRequest(values)
+	if err != nil {
+		return paging.PageRequest{}, paging.Sort{}, err
+	}
+	sort, err := paging.NewSort(values, allowedSortFields)
+	if err != nil {
+		return paging.PageRequest{}, paging.Sort{}, err
+	}
+
+	return pageRequest, sort, nil
+}
+
+type pageResolver[T any] struct {
+	page    int64
+	size    int64
+	total   int64
+	pages   int64
+	content []T
+}
+
+func newPageResolver[S any, T any](page paging.Page[S], convert func(S) T) *pageResolver[T] {
+	content := make([]T, 0, len(page.Content))
+	for _, item := range page.Content {
+		content = append(content, convert(item))
+	}
+
+	return &pageResolver[T]{page: page.Page, size: page.Size, total: page.TotalItems, pages: page.TotalPages,
+		content: content}
+}
+
+func (p *pageResolver[T]) Page() int32 {
+	return int32(p.page)
+}
+
+func (p *pageResolver[T]) Size() int32 {
+	return int32(p.size)
+}
+
+func (p *pageResolver[T]) TotalPages() int32 {
+	return int32(p.pages)
+}
+
+func (p *pageResolver[T]) TotalElements() int32 {
+	return int32(p.total)
+}
+
+func (p *pageResolver[T]) Content() []T {
+	return p.content
+}
+
+type bookResolver struct {
+	root *Resolver
+	item book.LookupItem
+}
+
+func (b *bookResolver) ID() gql.ID {
+	return gql.ID(strconv.FormatInt(b.item.ID, 10))
+}
+
+func (b *bookResolver) Title() string {
+	return b.item.Title
+}
+
+func (b *bookResolver) Subtitle() *string {
+	return optional(b.item.Subtitle)
+}
+
+func (b *bookResolver) Isbn10() *string {
+	return optional(b.item.ISBN10)
+}
+
+func (b *bookResolver) Asin() *string {
+	return optional(b.item.ASIN)
+}
+
+func (b *bookResolver) Pages() int32 {
+	return int32(b.item.Pages)
+}
+
+func (b *bookResolver) Edition() int32 {
+	return int32(b.item.Edition)
+}
+
+func (b *bookResolver) BookFileSize() float64 {
+	return float64(b.item.BookFileSize)
+}
+
+func (b *bookResolver) CoverFileName() *string {
+	return optional(b.item.CoverFileName)
+}
+
+func (b *bookResolver) Isbn13() *string {
+	if b.item.ISBN13 == 0 {
+		return nil
+	}
+
+	return optional(strconv.FormatInt(b.item.ISBN13, 10))
+}
+
+func (b *bookResolver) PubDate() *string {
+	if b.item.PubDate.IsZero() {
+		return nil
+	}
+
+	return optional(b.item.PubDate.Format(time.DateOnly))
+}
+
+func (b *bookResolver) Publisher(ctx context.Context) (*relationResolver, error) {
+	return b.single(ctx, relation.Publishers)
+}
+
+func (b *bookResolver) Language(ctx context.Context) (*relationResolver, error) {
+	return b.single(ctx, relation.Languages)
+}
+
+func (b *bookResolver) Authors(ctx context.Context) ([]*relationResolver, error) {
+	return b.list(ctx, relation.Authors)
+}
+
+func (b *bookResolver) Categories(ctx context.Context) ([]*relationResolver, error) {
+	return b.list(ctx, relation.Categories)
+}
+
+func (b *bookResolver) FileTypes(ctx context.Context) ([]*relationResolver, error) {
+	return b.list(ctx, relation.FileTypes)
+}
+
+func (b *bookResolver) Tags(ctx context.Context) ([]*relationResolver, error) {
+	return b.list(ctx, relation.Tags)
+}
+
+func (b *bookResolver) list(ctx context.Context, rel relation.Relation) ([]*relationResolver, error) {
+	items, err := loaderFromContext(ctx).load(ctx, rel, b.item.ID)
+	if err != nil {
+		return nil, b.root.resolveError(err)
+	}
+
+	itemIDs := make([]int64, 0, len(items))
+	resolvers := make([]*relationResolver, 0, len(items))
+	for _, item := range items {
+		itemIDs = append(itemIDs, item.ID)
+		resolvers = append(resolvers, &relationResolver{root: b.root, relation: rel, item: item})
+	}
+	if err := b.root.addItems(ctx, rel, itemIDs); err != nil {
+		return nil, err
+	}
+
+	return resolvers, nil
+}
+
+func (b *bookResolver) single(ctx context.Context, rel relation.Relation) (*relationResolver, error) {
+	resolvers, err := b.list(ctx, rel)
+	if err != nil || len(resolvers) == 0 {
+		return nil, err
+	}
+
+	return resolvers[0], nil
+}
+
+// relationResolver - resolves any of the named book relations: authors, categories, file types, languages,
+// publishers and tags
+type relationResolver struct {
+	root     *Resolver
+	relation relation.Relation
+	item     relation.Item
+}
+
+func (r *relationResolver) ID() gql.ID {
+	return gql.ID(strconv.FormatInt(r.item.ID, 10))
+}
+
+func (r *relationResolver) Name() string {
+	return r.item.Name
+}
+
+// Books - returns a page of the books, having the relation item. The same page is loaded for all the resolved
+// items of the relation at once
+func (r *relationResolver) Books(ctx context.Context, args pageArgs) (*pageResolver[*bookResolver], error) {
+	pageRequest, sort, err := newPaging(args, book.AllowedSortFields)
+	if err != nil {
+		return nil, r.root.resolveError(err)
+	}
+	page, err := loaderFromContext(ctx).loadBooks(ctx, r.relation, r.item.ID, args.values().Encode(),
+		pageRequest, sort)
+	if err != nil {
+		return nil, r.root.resolveError(err)
+	}
+
+	return r.root.bookPage(ctx, page)
+}
+
+// lookupItem - returns the lookup item of the book, the relation IDs are not set, the relations are loaded
+// separately
+func lookupItem(found book.Book) book.LookupItem {
+	return book.LookupItem{
+		ID:            found.ID,
+		Title:         found.Title,
+		Subtitle:      found.Subtitle,
+		ISBN10:        found.ISBN10,
+		ISBN13:        found.ISBN13,
+		ASIN:          found.ASIN,
+		Pages:         found.Pages,
+		Edition:       found.Edition,
+		PubDate:       found.PubDate,
+		BookFileSize:  found.BookFileSize,
+		CoverFileName: found.CoverFileName,
+		Publisher:     found.Publisher,
+		Language:      found.Language,
+	}
+}
+
+func optional(value string) *string {
+	if value == "" {
+		return nil
+	}
+
+	return &value
+}
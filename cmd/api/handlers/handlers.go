@@ -2,7 +2,9 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"net/http"
+	"time"
 )
 
 type HTTPHandler func(ctx context.Context, w http.ResponseWriter, r *http.Request) error
@@ -12,3 +14,48 @@ type Middleware func(handler HTTPHandler) HTTPHandler
 type RouteRegistrar interface {
 	RegisterRoute(method string, group string, path string, handler HTTPHandler, mw ...Middleware)
 }
+
+type baseURLContextKey struct{}
+
+// WithBaseURL - returns the request, carrying the external base URL, resolved by the middleware
+func WithBaseURL(r *http.Request, baseURL string) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), baseURLContextKey{}, baseURL))
+}
+
+// BaseURL - returns the external base URL of the request, e.g. 'https://books.example.com', for the absolute links.
+// The one, resolved by the middleware, takes precedence, otherwise it is the URL the server is requested with
+func BaseURL(r *http.Request) string {
+	if baseURL, ok := r.Context().Value(baseURLContextKey{}).(string); ok {
+		return baseURL
+	}
+
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+
+	return scheme + "://" + r.Host
+}
+
+// ClearWriteDeadline - lifts the server write timeout for the response, so the long downloads and streams
+// are not cut off. The response writers, not supporting the deadlines, are ignored
+func ClearWriteDeadline(w http.ResponseWriter) error {
+	err := http.NewResponseController(w).SetWriteDeadline(time.Time{})
+	if err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+
+	return nil
+}
+
+// ClearReadDeadline - lifts the server read timeout for the request body, so the large uploads are not cut off.
+// The write timeout runs from the end of the request headers, so it should be lifted as well, for the response
+// to the long upload. The response writers, not supporting the deadlines, are ignored
+func ClearReadDeadline(w http.ResponseWriter) error {
+	err := http.NewResponseController(w).SetReadDeadline(time.Time{})
+	if err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"crypto/tls"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestBaseURL(t *testing.T) {
+	request := httptest.NewRequest("GET", "http://books.example.com/feeds/new.atom", nil)
+	assert.Equal(t, "http://books.example.com", BaseURL(request))
+
+	request.TLS = &tls.ConnectionState{}
+	assert.Equal(t, "https://books.example.com", BaseURL(request))
+}
+
+func TestBaseURL_Resolved(t *testing.T) {
+	request := httptest.NewRequest("GET", "http://10.0.0.5:8080/feeds/new.atom", nil)
+	request.Header.Set("X-Forwarded-Host", "spoofed.example.com")
+	assert.Equal(t, "http://10.0.0.5:8080", BaseURL(request), "the forwarded headers should not be trusted")
+
+	request = WithBaseURL(request, "https://books.example.com")
+	assert.Equal(t, "https://books.example.com", BaseURL(request))
+}
+
+func TestClearWriteDeadline(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, ClearWriteDeadline(w))
+		time.Sleep(100 * time.Millisecond)
+		_, _ = io.WriteString(w, "done")
+	}))
+	server.Config.WriteTimeout = 20 * time.Millisecond
+	server.Start()
+	defer server.Close()
+
+	response, err := http.Get(server.URL)
+	require.NoError(t, err, "the response should outlive the server write timeout")
+	defer func() {
+		_ = response.Body.Close()
+	}()
+	body, err := io.ReadAll(response.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "done", string(body))
+
+	assert.NoError(t, ClearWriteDeadline(httptest.NewRecorder()), "the unsupported deadline should be ignored")
+}
+
+func TestClearReadDeadline(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, ClearReadDeadline(w))
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		_, _ = w.Write(body)
+	}))
+	server.Config.ReadTimeout = 20 * time.Millisecond
+	server.Start()
+	defer server.Close()
+
+	bodyReader, bodyWriter := io.Pipe()
+	go func() {
+		_, _ = io.WriteString(bodyWriter, "first ")
+		time.Sleep(100 * time.Millisecond)
+		_, _ = io.WriteString(bodyWriter, "second")
+		_ = bodyWriter.Close()
+	}()
+	response, err := http.Post(server.URL, "text/plain", bodyReader)
+	require.NoError(t, err, "the request body should outlive the server read timeout")
+	defer func() {
+		_ = response.Body.Close()
+	}()
+	body, err := io.ReadAll(response.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "first second", string(body))
+
+	assert.NoError(t, ClearReadDeadline(httptest.NewRecorder()), "the unsupported deadline should be ignored")
+}
@@ -0,0 +1,5 @@
+package oai
+
+const (
+	group = "/oai"
+)
@@ -0,0 +1,112 @@
+package oai
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/jmoiron/sqlx"
+	apiErrors "github.com/sdreger/lib-manager-go/cmd/api/errors"
+	"github.com/sdreger/lib-manager-go/cmd/api/handlers"
+	"github.com/sdreger/lib-manager-go/internal/config"
+	harvest "github.com/sdreger/lib-manager-go/internal/oai"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+const (
+	contentTypeXML = "text/xml; charset=utf-8"
+)
+
+type ProviderService interface {
+	Identify(ctx context.Context) (harvest.Repository, error)
+	ListMetadataFormats(ctx context.Context, identifier string) ([]harvest.MetadataFormat, error)
+	ListSets(ctx context.Context, resumptionToken string) ([]harvest.Set, error)
+	ListRecords(ctx context.Context, request harvest.Request, baseURL string) (harvest.RecordList, error)
+	GetRecord(ctx context.Context, identifier string, metadataPrefix string, baseURL string) (harvest.Record, error)
+}
+
+// ProviderController - serves the OAI-PMH 2.0 requests, so the book metadata can be harvested by the other systems
+type ProviderController struct {
+	logger          *slog.Logger
+	providerService ProviderService
+}
+
+func NewProviderController(logger *slog.Logger, db *sqlx.DB, oaiConfig config.OAIConfig) *ProviderController {
+	return &ProviderController{logger: logger, providerService: harvest.NewService(logger, db, oaiConfig)}
+}
+
+func (cnt *ProviderController) RegisterRoutes(registrar handlers.RouteRegistrar) {
+	registrar.RegisterRoute(http.MethodGet, group, "", cnt.HandleRequest)
+	registrar.RegisterRoute(http.MethodPost, group, "", cnt.HandleRequest)
+}
+
+// HandleRequest - handles the request verb, the arguments are taken from the query of the GET requests,
+// and from the form body of the POST ones. The protocol errors are reported in the response body with
+// the 200 status, as the protocol requires, the other errors are handled by the error middleware
+func (cnt *ProviderController) HandleRequest(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	values := r.URL.Query()
+	if r.Method == http.MethodPost {
+		if err := r.ParseForm(); err != nil {
+			return apiErrors.ValidationError{
+				Field:   "body",
+				Message: "the request arguments should be sent as a form: " + err.Error(),
+			}
+		}
+		values = r.PostForm
+	}
+
+	baseURL := handlers.BaseURL(r)
+	response := harvest.Response{Date: time.Now(), BaseURL: baseURL + group}
+	request, err := harvest.ParseRequest(values)
+	if err == nil {
+		response.Request = request
+		err = cnt.handleVerb(ctx, &response, baseURL)
+	}
+	var protocolError harvest.Error
+	if errors.As(err, &protocolError) {
+		response.Error = &protocolError
+		// the invalid arguments are not echoed
+		if protocolError.Code == harvest.CodeBadVerb || protocolError.Code == harvest.CodeBadArgument {
+			response.Request = harvest.Request{}
+		}
+	} else if err != nil {
+		return err
+	}
+
+	var buffer bytes.Buffer
+	if err := harvest.WriteResponse(&buffer, response); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", contentTypeXML)
+	_, err = w.Write(buffer.Bytes())
+
+	return err
+}
+
+// handleVerb - sets the result of the request verb to the response
+func (cnt *ProviderController) handleVerb(ctx context.Context, response *harvest.Response, baseURL string) error {
+	request := response.Request
+	switch request.Verb {
+	case harvest.VerbIdentify:
+		repository, err := cnt.providerService.Identify(ctx)
+		response.Repository = &repository
+		return err
+	case harvest.VerbListMetadataFormats:
+		formats, err := cnt.providerService.ListMetadataFormats(ctx, request.Identifier)
+		response.MetadataFormats = formats
+		return err
+	case harvest.VerbListSets:
+		sets, err := cnt.providerService.ListSets(ctx, request.ResumptionToken)
+		response.Sets = sets
+		return err
+	case harvest.VerbListIdentifiers, harvest.VerbListRecords:
+		list, err := cnt.providerService.ListRecords(ctx, request, baseURL)
+		response.Records = &list
+		return err
+	default:
+		record, err := cnt.providerService.GetRecord(ctx, request.Identifier, request.MetadataPrefix, baseURL)
+		response.Record = &record
+		return err
+	}
+}
@@ -0,0 +1,155 @@
+package oai
+
+import (
+	"context"
+	"errors"
+	"github.com/sdreger/lib-manager-go/cmd/api/handlers"
+	"github.com/sdreger/lib-manager-go/internal/config"
+	harvest "github.com/sdreger/lib-manager-go/internal/oai"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	testBaseURL    = "https://library.example.com"
+	testIdentifier = "oai:lib-manager.local:book/1"
+)
+
+var testUpdated = time.Date(2026, 10, 5, 8, 30, 0, 0, time.UTC)
+
+func TestProviderController_RegisterRoutes(t *testing.T) {
+	testRegistrar := handlers.RouteRegistrarMock{}
+	cnt := getProviderController()
+	cnt.RegisterRoutes(&testRegistrar)
+
+	assert.True(t, testRegistrar.IsRouteRegistered("GET /oai", cnt.HandleRequest))
+	assert.True(t, testRegistrar.IsRouteRegistered("POST /oai", cnt.HandleRequest))
+}
+
+func TestProviderController_Identify(t *testing.T) {
+	ctx := context.Background()
+	controller := getProviderController()
+
+	mockService := NewMockProviderService(t)
+	mockService.EXPECT().Identify(ctx).Return(harvest.Repository{Name: "Library",
+		Identifier: "lib-manager.local", EarliestDatestamp: testUpdated}, nil).Once()
+	controller.providerService = mockService
+
+	recorder := httptest.NewRecorder()
+	request := handlers.WithBaseURL(httptest.NewRequest("GET", "/oai?verb=Identify", nil), testBaseURL)
+	err := controller.HandleRequest(ctx, recorder, request)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "text/xml; charset=utf-8", recorder.Header().Get("Content-Type"))
+	body := recorder.Body.String()
+	assert.Contains(t, body, `<request verb="Identify">`+testBaseURL+"/oai</request>")
+	assert.Contains(t, body, "<baseURL>"+testBaseURL+"/oai</baseURL>")
+	assert.Contains(t, body, "<earliestDatestamp>2026-10-05T08:30:00Z</earliestDatestamp>")
+}
+
+func TestProviderController_ListRecords(t *testing.T) {
+	ctx := context.Background()
+	controller := getProviderController()
+	list := harvest.RecordList{
+		Records: []harvest.Record{{
+			Header:   harvest.Header{Identifier: testIdentifier, Datestamp: testUpdated},
+			Metadata: harvest.DublinCore{Titles: []string{"CockroachDB"}},
+		}},
+		ResumptionToken: &harvest.ResumptionToken{Value: "next"},
+	}
+
+	mockService := NewMockProviderService(t)
+	mockService.EXPECT().ListRecords(ctx, mock.MatchedBy(func(request harvest.Request) bool {
+		return request.Verb == harvest.VerbListRecords && request.MetadataPrefix == harvest.MetadataPrefixDC &&
+			request.From.Equal(time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC))
+	}), "http://example.com").Return(list, nil).Once()
+	controller.providerService = mockService
+
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest("POST", "/oai",
+		strings.NewReader("verb=ListRecords&metadataPrefix=oai_dc&from=2026-10-01"))
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	err := controller.HandleRequest(ctx, recorder, request)
+	require.NoError(t, err)
+
+	body := recorder.Body.String()
+	assert.Contains(t, body, `<request verb="ListRecords" from="2026-10-01" metadataPrefix="oai_dc">`,
+		"the form arguments should be echoed")
+	assert.Contains(t, body, "<dc:title>CockroachDB</dc:title>")
+	assert.Contains(t, body, `<resumptionToken cursor="0">next</resumptionToken>`)
+}
+
+func TestProviderController_ListIdentifiers(t *testing.T) {
+	ctx := context.Background()
+	controller := getProviderController()
+	list := harvest.RecordList{Records: []harvest.Record{{
+		Header: harvest.Header{Identifier: testIdentifier, Datestamp: testUpdated, SetSpecs: []string{"publisher-1"}},
+	}}}
+
+	mockService := NewMockProviderService(t)
+	mockService.EXPECT().ListRecords(ctx, mock.Anything, "http://example.com").Return(list, nil).Once()
+	controller.providerService = mockService
+
+	recorder := httptest.NewRecorder()
+	err := controller.HandleRequest(ctx, recorder,
+		httptest.NewRequest("GET", "/oai?verb=ListIdentifiers&metadataPrefix=oai_dc&set=publisher-1", nil))
+	require.NoError(t, err)
+
+	body := recorder.Body.String()
+	assert.Contains(t, body, "<identifier>"+testIdentifier+"</identifier>")
+	assert.Contains(t, body, "<setSpec>publisher-1</setSpec>")
+	assert.NotContains(t, body, "<metadata>")
+}
+
+func TestProviderController_ProtocolErrors(t *testing.T) {
+	ctx := context.Background()
+	controller := getProviderController()
+
+	mockService := NewMockProviderService(t)
+	mockService.EXPECT().GetRecord(ctx, "oai:lib-manager.local:book/2", harvest.MetadataPrefixDC,
+		"http://example.com").Return(harvest.Record{},
+		harvest.Error{Code: harvest.CodeIDDoesNotExist, Message: "unknown"}).Once()
+	controller.providerService = mockService
+
+	recorder := httptest.NewRecorder()
+	err := controller.HandleRequest(ctx, recorder, httptest.NewRequest("GET", "/oai?verb=ListBooks", nil))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, recorder.Code, "the protocol errors should have the 200 status")
+	assert.Contains(t, recorder.Body.String(), `<error code="badVerb">`)
+	assert.Contains(t, recorder.Body.String(), "<request>http://example.com/oai</request>")
+
+	recorder = httptest.NewRecorder()
+	err = controller.HandleRequest(ctx, recorder, httptest.NewRequest("GET",
+		"/oai?verb=GetRecord&metadataPrefix=oai_dc&identifier=oai:lib-manager.local:book/2", nil))
+	require.NoError(t, err)
+	assert.Contains(t, recorder.Body.String(), `<error code="idDoesNotExist">unknown</error>`)
+	assert.Contains(t, recorder.Body.String(), `<request verb="GetRecord"`,
+		"the valid arguments should be echoed")
+}
+
+func TestProviderController_ServiceError(t *testing.T) {
+	ctx := context.Background()
+	controller := getProviderController()
+	serviceError := errors.New("service error")
+
+	mockService := NewMockProviderService(t)
+	mockService.EXPECT().ListSets(ctx, "").Return(nil, serviceError).Once()
+	controller.providerService = mockService
+
+	err := controller.HandleRequest(ctx, httptest.NewRecorder(), httptest.NewRequest("GET", "/oai?verb=ListSets", nil))
+	assert.ErrorIs(t, err, serviceError)
+}
+
+func getProviderController() *ProviderController {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	return NewProviderController(logger, nil, config.OAIConfig{})
+}
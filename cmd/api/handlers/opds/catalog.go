@@ -0,0 +1,256 @@
+package opds
+
+import (
+	"bytes"
+	"context"
+	"github.com/jmoiron/sqlx"
+	apiErrors "github.com/sdreger/lib-manager-go/cmd/api/errors"
+	"github.com/sdreger/lib-manager-go/cmd/api/handlers"
+	"github.com/sdreger/lib-manager-go/internal/domain/book"
+	"github.com/sdreger/lib-manager-go/internal/domain/bookfile"
+	catalog "github.com/sdreger/lib-manager-go/internal/opds"
+	"github.com/sdreger/lib-manager-go/internal/paging"
+	"log/slog"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	booksPath        = group + "/books"
+	searchPath       = group + "/search.xml"
+	queryParamPage   = "page"
+	queryParamSort   = "sort"
+	queryParamSearch = "query"
+
+	defaultCoverType = "image/jpeg"
+	// placeholderCoverType - the generated cover fallback type, used for the books without a cover
+	placeholderCoverType = "image/svg+xml"
+)
+
+type CatalogService interface {
+	GetFacetItems(ctx context.Context, facet catalog.Facet, pageRequest paging.PageRequest) (
+		paging.Page[catalog.FacetItem], error)
+	GetPublications(ctx context.Context, pageRequest paging.PageRequest, sort paging.Sort, filter book.Filter) (
+		paging.Page[catalog.Publication], error)
+}
+
+type CatalogController struct {
+	logger         *slog.Logger
+	catalogService CatalogService
+}
+
+func NewCatalogController(logger *slog.Logger, db *sqlx.DB) *CatalogController {
+	return &CatalogController{logger: logger, catalogService: catalog.NewService(logger, db)}
+}
+
+func (cnt *CatalogController) RegisterRoutes(registrar handlers.RouteRegistrar) {
+	registrar.RegisterRoute(http.MethodGet, group, "", cnt.GetRoot)
+	registrar.RegisterRoute(http.MethodGet, group, "/search.xml", cnt.GetSearchDescription)
+	registrar.RegisterRoute(http.MethodGet, group, "/books", cnt.GetBooks)
+	registrar.RegisterRoute(http.MethodGet, group, "/{facet}", cnt.GetFacet)
+}
+
+// GetRoot - returns the root navigation feed, listing the newest books and the navigation facets
+func (cnt *CatalogController) GetRoot(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	feed := newFeed(r, catalog.KindNavigation, catalog.FeedID(), "Library")
+	feed.Navigation = append(feed.Navigation,
+		catalog.Navigation{
+			ID:    catalog.FeedID("newest"),
+			Title: "Newest",
+			Link: catalog.Link{Rel: catalog.RelSortNew, Kind: catalog.KindAcquisition,
+				Href: booksPath + "?" + url.Values{queryParamSort: {"created_at,desc"}}.Encode()},
+		},
+		catalog.Navigation{
+			ID:    catalog.FeedID("books"),
+			Title: "All books",
+			Link: catalog.Link{Rel: "subsection", Kind: catalog.KindAcquisition,
+				Href: booksPath + "?" + url.Values{queryParamSort: {"title,asc"}}.Encode()},
+		},
+	)
+	for _, facet := range catalog.Facets {
+		feed.Navigation = append(feed.Navigation, catalog.Navigation{
+			ID:    catalog.FeedID(facet.Name),
+			Title: facet.Title,
+			Link:  catalog.Link{Rel: "subsection", Href: group + "/" + facet.Name, Kind: catalog.KindNavigation},
+		})
+	}
+
+	return cnt.renderFeed(w, r, feed)
+}
+
+// GetFacet - returns the navigation feed of the facet items, each one links to the acquisition feed
+// of the books having it
+func (cnt *CatalogController) GetFacet(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	facet, ok := catalog.FacetByName(r.PathValue("facet"))
+	if !ok {
+		return apiErrors.ErrNotFound
+	}
+	pageRequest, err := paging.NewPageRequest(r.URL.Query())
+	if err != nil {
+		return err
+	}
+
+	page, err := cnt.catalogService.GetFacetItems(ctx, facet, pageRequest)
+	if err != nil {
+		return err
+	}
+
+	feed := newFeed(r, catalog.KindNavigation, catalog.FeedID(facet.Name), facet.Title)
+	feed.Links = append(feed.Links, catalog.Link{Rel: "up", Href: group, Kind: catalog.KindNavigation})
+	addPaging(&feed, r, pageRequest, page)
+	for _, item := range page.Content {
+		query := url.Values{facet.FilterParam: {strconv.FormatInt(item.ID, 10)}}
+		feed.Navigation = append(feed.Navigation, catalog.Navigation{
+			ID:        catalog.FeedID(facet.Name, strconv.FormatInt(item.ID, 10)),
+			Title:     item.Name,
+			BookCount: item.BookCount,
+			Link:      catalog.Link{Rel: "subsection", Href: booksPath + "?" + query.Encode(), Kind: catalog.KindAcquisition},
+		})
+	}
+
+	return cnt.renderFeed(w, r, feed)
+}
+
+// GetBooks - returns the acquisition feed of the books, matching the book filter. The 'query' filter
+// is used by the catalog search
+func (cnt *CatalogController) GetBooks(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	pageRequest, err := paging.NewPageRequest(r.URL.Query())
+	if err != nil {
+		return err
+	}
+	sort, err := paging.NewSort(r.URL.Query(), book.AllowedSortFields)
+	if err != nil {
+		return err
+	}
+	filter, err := book.NewFilter(r.URL.Query())
+	if err != nil {
+		return err
+	}
+
+	page, err := cnt.catalogService.GetPublications(ctx, pageRequest, sort, filter)
+	if err != nil {
+		return err
+	}
+
+	title := "Books"
+	if filter.Query != "" {
+		title = "Search: " + filter.Query
+	}
+	feed := newFeed(r, catalog.KindAcquisition, catalog.FeedID("books"), title)
+	feed.Links = append(feed.Links, catalog.Link{Rel: "up", Href: group, Kind: catalog.KindNavigation})
+	addPaging(&feed, r, pageRequest, page)
+	for _, publication := range page.Content {
+		publication.Links = publicationLinks(publication)
+		feed.Publications = append(feed.Publications, publication)
+	}
+
+	return cnt.renderFeed(w, r, feed)
+}
+
+// GetSearchDescription - returns the OpenSearch description of the catalog search
+func (cnt *CatalogController) GetSearchDescription(ctx context.Context, w http.ResponseWriter,
+	r *http.Request) error {
+
+	var buffer bytes.Buffer
+	err := catalog.WriteOpenSearch(&buffer, catalog.OpenSearchDescription{
+		ShortName:   "Library",
+		Description: "Search the library books by title",
+		Template:    booksPath + "?" + queryParamSearch + "={searchTerms}",
+	})
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", catalog.MediaTypeOpenSearch+"; charset=utf-8")
+	_, err = w.Write(buffer.Bytes())
+
+	return err
+}
+
+// renderFeed - writes the feed in the format, negotiated by the 'Accept' header
+func (cnt *CatalogController) renderFeed(w http.ResponseWriter, r *http.Request, feed catalog.Feed) error {
+	format := catalog.Negotiate(r.Header.Get("Accept"))
+	var buffer bytes.Buffer
+	if err := catalog.WriteFeed(&buffer, format, feed); err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", catalog.ContentType(format, feed.Kind)+"; charset=utf-8")
+	w.Header().Add("Vary", "Accept")
+	_, err := w.Write(buffer.Bytes())
+
+	return err
+}
+
+func newFeed(r *http.Request, kind string, id string, title string) catalog.Feed {
+	return catalog.Feed{
+		ID:      id,
+		Title:   title,
+		Kind:    kind,
+		Updated: time.Now(),
+		Links: []catalog.Link{
+			{Rel: "self", Href: r.URL.RequestURI(), Kind: kind},
+			{Rel: "start", Href: group, Kind: catalog.KindNavigation, Title: "Library"},
+		},
+		SearchDescription: searchPath,
+		SearchTemplate:    booksPath + "{?" + queryParamSearch + "}",
+	}
+}
+
+// addPaging - sets the feed paging properties, and adds the links to the adjacent pages,
+// keeping the other request query parameters
+func addPaging[T any](feed *catalog.Feed, r *http.Request, pageRequest paging.PageRequest, page paging.Page[T]) {
+	feed.CurrentPage = page.Page
+	feed.TotalItems = page.TotalItems
+	feed.ItemsPerPage = int64(pageRequest.Limit())
+
+	pageLink := func(rel string, number int64) catalog.Link {
+		query := r.URL.Query()
+		query.Set(queryParamPage, strconv.FormatInt(number, 10))
+		return catalog.Link{Rel: rel, Href: r.URL.Path + "?" + query.Encode(), Kind: feed.Kind}
+	}
+	if page.TotalPages == 0 {
+		return
+	}
+	feed.Links = append(feed.Links, pageLink("first", 1))
+	if page.Page > 1 {
+		feed.Links = append(feed.Links, pageLink("previous", min(page.Page-1, page.TotalPages)))
+	}
+	if page.Page < page.TotalPages {
+		feed.Links = append(feed.Links, pageLink("next", page.Page+1))
+	}
+	feed.Links = append(feed.Links, pageLink("last", page.TotalPages))
+}
+
+// publicationLinks - returns the cover image and the book file acquisition links of the publication.
+// The cover falls back to the generated placeholder, if the book has no cover
+func publicationLinks(publication catalog.Publication) []catalog.Link {
+	bookPath := "/v1/books/" + strconv.FormatInt(publication.ID, 10)
+	coverType := placeholderCoverType
+	if publication.CoverFileName != "" {
+		coverType = mime.TypeByExtension(path.Ext(publication.CoverFileName))
+		if coverType == "" {
+			coverType = defaultCoverType
+		}
+	}
+	coverHref := bookPath + "/cover?fallback=generated"
+	links := []catalog.Link{
+		{Rel: catalog.RelImage, Href: coverHref, Type: coverType},
+		{Rel: catalog.RelThumbnail, Href: coverHref, Type: coverType},
+	}
+	for _, fileType := range publication.FileTypes {
+		links = append(links, catalog.Link{
+			Rel:   catalog.RelAcquisition,
+			Href:  bookPath + "/files/" + url.PathEscape(fileType),
+			Type:  bookfile.ContentType(fileType),
+			Title: strings.ToUpper(fileType),
+		})
+	}
+
+	return links
+}
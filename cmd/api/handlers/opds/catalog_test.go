@@ -0,0 +1,225 @@
+package opds
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	apiErrors "github.com/sdreger/lib-manager-go/cmd/api/errors"
+	"github.com/sdreger/lib-manager-go/cmd/api/handlers"
+	"github.com/sdreger/lib-manager-go/internal/domain/book"
+	catalog "github.com/sdreger/lib-manager-go/internal/opds"
+	"github.com/sdreger/lib-manager-go/internal/paging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestCatalogController_RegisterRoutes(t *testing.T) {
+	testRegistrar := handlers.RouteRegistrarMock{}
+	cnt := getCatalogController()
+	cnt.RegisterRoutes(&testRegistrar)
+
+	assert.True(t, testRegistrar.IsRouteRegistered("GET /opds", cnt.GetRoot))
+	assert.True(t, testRegistrar.IsRouteRegistered("GET /opds/search.xml", cnt.GetSearchDescription))
+	assert.True(t, testRegistrar.IsRouteRegistered("GET /opds/books", cnt.GetBooks))
+	assert.True(t, testRegistrar.IsRouteRegistered("GET /opds/{facet}", cnt.GetFacet))
+}
+
+func TestCatalogController_GetRoot(t *testing.T) {
+	ctx := context.Background()
+	controller := getCatalogController()
+
+	request := httptest.NewRequest("GET", "/opds", nil)
+	recorder := httptest.NewRecorder()
+	require.NoError(t, controller.GetRoot(ctx, recorder, request))
+
+	body := readBody(t, recorder)
+	assert.Equal(t, "application/atom+xml;profile=opds-catalog;kind=navigation; charset=utf-8",
+		recorder.Header().Get("Content-Type"))
+	assert.Equal(t, "Accept", recorder.Header().Get("Vary"))
+	assert.Contains(t, body, `<link rel="http://opds-spec.org/sort/new" href="/opds/books?sort=created_at%2Cdesc"`)
+	assert.Contains(t, body, `<link rel="subsection" href="/opds/authors"`)
+	assert.Contains(t, body, `<link rel="subsection" href="/opds/languages"`)
+	assert.Contains(t, body, `<link rel="search" href="/opds/search.xml"`)
+}
+
+func TestCatalogController_GetRoot_JSON(t *testing.T) {
+	ctx := context.Background()
+	controller := getCatalogController()
+
+	request := httptest.NewRequest("GET", "/opds", nil)
+	request.Header.Set("Accept", "application/opds+json")
+	recorder := httptest.NewRecorder()
+	require.NoError(t, controller.GetRoot(ctx, recorder, request))
+
+	assert.Equal(t, "application/opds+json; charset=utf-8", recorder.Header().Get("Content-Type"))
+	var feed struct {
+		Navigation []struct {
+			Href  string `json:"href"`
+			Title string `json:"title"`
+		} `json:"navigation"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(readBody(t, recorder)), &feed))
+	assert.Len(t, feed.Navigation, len(catalog.Facets)+2)
+	assert.Equal(t, "Newest", feed.Navigation[0].Title)
+}
+
+func TestCatalogController_GetFacet(t *testing.T) {
+	ctx := context.Background()
+	controller := getCatalogController()
+	values := map[string][]string{"page": {"2"}, "size": {"1"}}
+	pageRequest, _ := paging.NewPageRequest(values)
+	facet, _ := catalog.FacetByName("authors")
+	page := paging.NewPage(pageRequest, 3, []catalog.FacetItem{{ID: 7, Name: "Jon Bodner", BookCount: 2}})
+
+	mockService := NewMockCatalogService(t)
+	mockService.EXPECT().GetFacetItems(ctx, facet, pageRequest).Return(page, nil).Once()
+	injectCatalogMocks(controller, mockService)
+
+	request := httptest.NewRequest("GET", "/opds/authors?page=2&size=1", nil)
+	request.SetPathValue("facet", "authors")
+	recorder := httptest.NewRecorder()
+	require.NoError(t, controller.GetFacet(ctx, recorder, request))
+
+	body := readBody(t, recorder)
+	assert.Contains(t, body, `<title>Jon Bodner</title>`)
+	assert.Contains(t, body, `<content type="text">2 books</content>`)
+	assert.Contains(t, body, `<link rel="subsection" href="/opds/books?author=7"`)
+	assert.Contains(t, body, `<link rel="up" href="/opds"`)
+	assert.Contains(t, body, `<link rel="first" href="/opds/authors?page=1&amp;size=1"`)
+	assert.Contains(t, body, `<link rel="previous" href="/opds/authors?page=1&amp;size=1"`)
+	assert.Contains(t, body, `<link rel="next" href="/opds/authors?page=3&amp;size=1"`)
+	assert.Contains(t, body, `<link rel="last" href="/opds/authors?page=3&amp;size=1"`)
+	assert.Contains(t, body, `<opensearch:totalResults>3</opensearch:totalResults>`)
+	assert.Contains(t, body, `<opensearch:startIndex>2</opensearch:startIndex>`)
+}
+
+func TestCatalogController_GetFacet_Unknown(t *testing.T) {
+	ctx := context.Background()
+	controller := getCatalogController()
+
+	request := httptest.NewRequest("GET", "/opds/series", nil)
+	request.SetPathValue("facet", "series")
+	err := controller.GetFacet(ctx, httptest.NewRecorder(), request)
+	assert.ErrorIs(t, err, apiErrors.ErrNotFound)
+}
+
+func TestCatalogController_GetFacet_ServiceError(t *testing.T) {
+	ctx := context.Background()
+	controller := getCatalogController()
+	serviceError := errors.New("service error")
+
+	mockService := NewMockCatalogService(t)
+	mockService.EXPECT().GetFacetItems(ctx, mock.Anything, mock.Anything).
+		Return(paging.Page[catalog.FacetItem]{}, serviceError).Once()
+	injectCatalogMocks(controller, mockService)
+
+	request := httptest.NewRequest("GET", "/opds/tags", nil)
+	request.SetPathValue("facet", "tags")
+	err := controller.GetFacet(ctx, httptest.NewRecorder(), request)
+	assert.ErrorIs(t, err, serviceError)
+}
+
+func TestCatalogController_GetBooks(t *testing.T) {
+	ctx := context.Background()
+	controller := getCatalogController()
+	values := map[string][]string{"query": {"go"}}
+	pageRequest, _ := paging.NewPageRequest(values)
+	sort, _ := paging.NewSort(values, book.AllowedSortFields)
+	filter, _ := book.NewFilter(values)
+	publications := []catalog.Publication{
+		{
+			LookupItem: book.LookupItem{ID: 1, Title: "Go in Action", CoverFileName: "1617291781.jpg"},
+			Authors:    []string{"William Kennedy"},
+			FileTypes:  []string{"epub", "pdf"},
+		},
+		{LookupItem: book.LookupItem{ID: 2, Title: "Learning Go"}},
+	}
+	page := paging.NewPage(pageRequest, 2, publications)
+
+	mockService := NewMockCatalogService(t)
+	mockService.EXPECT().GetPublications(ctx, pageRequest, sort, filter).Return(page, nil).Once()
+	injectCatalogMocks(controller, mockService)
+
+	request := httptest.NewRequest("GET", "/opds/books?query=go", nil)
+	recorder := httptest.NewRecorder()
+	require.NoError(t, controller.GetBooks(ctx, recorder, request))
+
+	body := readBody(t, recorder)
+	assert.Equal(t, "application/atom+xml;profile=opds-catalog;kind=acquisition; charset=utf-8",
+		recorder.Header().Get("Content-Type"))
+	assert.Contains(t, body, `<title>Search: go</title>`)
+	assert.Contains(t, body,
+		`<link rel="http://opds-spec.org/image" href="/v1/books/1/cover?fallback=generated" type="image/jpeg">`)
+	assert.Contains(t, body,
+		`<link rel="http://opds-spec.org/acquisition" href="/v1/books/1/files/epub" type="application/epub+zip" title="EPUB">`)
+	assert.Contains(t, body,
+		`<link rel="http://opds-spec.org/acquisition" href="/v1/books/1/files/pdf" type="application/pdf" title="PDF">`)
+	assert.Contains(t, body,
+		`<link rel="http://opds-spec.org/image" href="/v1/books/2/cover?fallback=generated" type="image/svg+xml">`,
+		"the generated placeholder should be linked for the books without a cover")
+	assert.NotContains(t, body, `rel="next"`, "the single page feed should have no next link")
+}
+
+func TestCatalogController_GetBooks_ValidationError(t *testing.T) {
+	ctx := context.Background()
+	controller := getCatalogController()
+
+	request := httptest.NewRequest("GET", "/opds/books?author=abc", nil)
+	err := controller.GetBooks(ctx, httptest.NewRecorder(), request)
+	var validationError apiErrors.ValidationError
+	assert.ErrorAs(t, err, &validationError)
+}
+
+func TestCatalogController_GetBooks_ServiceError(t *testing.T) {
+	ctx := context.Background()
+	controller := getCatalogController()
+	serviceError := errors.New("service error")
+
+	mockService := NewMockCatalogService(t)
+	mockService.EXPECT().GetPublications(ctx, mock.Anything, mock.Anything, mock.Anything).
+		Return(paging.Page[catalog.Publication]{}, serviceError).Once()
+	injectCatalogMocks(controller, mockService)
+
+	request := httptest.NewRequest("GET", "/opds/books", nil)
+	err := controller.GetBooks(ctx, httptest.NewRecorder(), request)
+	assert.ErrorIs(t, err, serviceError)
+}
+
+func TestCatalogController_GetSearchDescription(t *testing.T) {
+	ctx := context.Background()
+	controller := getCatalogController()
+
+	request := httptest.NewRequest("GET", "/opds/search.xml", nil)
+	recorder := httptest.NewRecorder()
+	require.NoError(t, controller.GetSearchDescription(ctx, recorder, request))
+
+	assert.Equal(t, "application/opensearchdescription+xml; charset=utf-8", recorder.Header().Get("Content-Type"))
+	assert.Contains(t, readBody(t, recorder), `template="/opds/books?query={searchTerms}"`)
+}
+
+func readBody(t *testing.T, recorder *httptest.ResponseRecorder) string {
+	t.Helper()
+	result := recorder.Result()
+	defer result.Body.Close()
+	require.Equal(t, http.StatusOK, result.StatusCode)
+	data, err := io.ReadAll(result.Body)
+	require.NoError(t, err)
+
+	return string(data)
+}
+
+func getCatalogController() *CatalogController {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	return NewCatalogController(logger, nil)
+}
+
+func injectCatalogMocks(controller *CatalogController, service CatalogService) {
+	controller.catalogService = service
+}
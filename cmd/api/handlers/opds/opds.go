@@ -0,0 +1,5 @@
+package opds
+
+const (
+	group = "/opds"
+)
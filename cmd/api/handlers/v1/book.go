@@ -2,6 +2,7 @@ package v1
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"github.com/jmoiron/sqlx"
 	apiErrors "github.com/sdreger/lib-manager-go/cmd/api/errors"
@@ -9,6 +10,7 @@ import (
 	book "github.com/sdreger/lib-manager-go/internal/domain/book"
 	"github.com/sdreger/lib-manager-go/internal/paging"
 	"github.com/sdreger/lib-manager-go/internal/response"
+	"github.com/sdreger/lib-manager-go/internal/schemaorg"
 	"log/slog"
 	"net/http"
 	"strconv"
@@ -22,6 +24,12 @@ type BookService interface {
 		sort paging.Sort,
 		filter book.Filter,
 	) (paging.Page[book.LookupItem], error)
+	MergeBooks(ctx context.Context, targetID int64, sourceIDs []int64) (book.Book, error)
+}
+
+// MergeRequest - the books to merge into the target one
+type MergeRequest struct {
+	BookIDs []int64 `json:"book_ids"`
 }
 
 type BookController struct {
@@ -36,8 +44,10 @@ func NewBookController(logger *slog.Logger, db *sqlx.DB) *BookController {
 func (cnt *BookController) RegisterRoutes(registrar handlers.RouteRegistrar) {
 	registrar.RegisterRoute(http.MethodGet, group, "/books", cnt.GetBooks)
 	registrar.RegisterRoute(http.MethodGet, group, "/books/{bookID}", cnt.GetBook)
+	registrar.RegisterRoute(http.MethodPost, group, "/books/{bookID}/merge", cnt.MergeBooks)
 }
 
+// GetBook - returns the book, or its schema.org JSON-LD description, if the 'Accept' header prefers it
 func (cnt *BookController) GetBook(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
 	idString := r.PathValue("bookID")
 	idInt, err := strconv.Atoi(idString)
@@ -56,9 +66,28 @@ func (cnt *BookController) GetBook(ctx context.Context, w http.ResponseWriter, r
 		return err
 	}
 
+	w.Header().Add("Vary", "Accept")
+	if schemaorg.Negotiate(r.Header.Get("Accept")) {
+		return renderLinkedData(w, schemaorg.NewBook(bookEntry, schemaorg.NewLinks(handlers.BaseURL(r), bookEntry.ID)))
+	}
+
 	return response.RenderDataJSON(w, http.StatusOK, bookEntry)
 }
 
+// renderLinkedData - writes the schema.org JSON-LD document, it is not wrapped like the other responses
+func renderLinkedData(w http.ResponseWriter, node schemaorg.Book) error {
+	jsonData, err := json.Marshal(node)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", schemaorg.MediaType)
+	w.WriteHeader(http.StatusOK)
+	_, err = w.Write(jsonData)
+
+	return err
+}
+
 func (cnt *BookController) GetBooks(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
 	page, pageErr := paging.NewPageRequest(r.URL.Query())
 	if pageErr != nil {
@@ -82,3 +111,41 @@ func (cnt *BookController) GetBooks(ctx context.Context, w http.ResponseWriter,
 
 	return response.RenderDataJSON(w, http.StatusOK, bookPage)
 }
+
+// MergeBooks - merges the requested books into the path one, and returns the resulting book.
+// The merged books are soft-deleted
+func (cnt *BookController) MergeBooks(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	idInt, err := strconv.Atoi(r.PathValue("bookID"))
+	if err != nil {
+		return apiErrors.ValidationError{
+			Field:   "bookID",
+			Message: "the provided bookID should be a number",
+		}
+	}
+
+	var request MergeRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&request); err != nil {
+		return apiErrors.ValidationError{
+			Field:   "body",
+			Message: "the request body should be a JSON object: " + err.Error(),
+		}
+	}
+
+	bookEntry, err := cnt.bookService.MergeBooks(ctx, int64(idInt), request.BookIDs)
+	if errors.Is(err, book.ErrInvalidMerge) {
+		return apiErrors.ValidationError{
+			Field:   "book_ids",
+			Message: err.Error(),
+		}
+	}
+	if errors.Is(err, book.ErrNotFound) {
+		return apiErrors.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	return response.RenderDataJSON(w, http.StatusOK, bookEntry)
+}
@@ -0,0 +1,131 @@
+package v1
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"github.com/jmoiron/sqlx"
+	apiErrors "github.com/sdreger/lib-manager-go/cmd/api/errors"
+	"github.com/sdreger/lib-manager-go/cmd/api/handlers"
+	"github.com/sdreger/lib-manager-go/internal/blobtstore"
+	"github.com/sdreger/lib-manager-go/internal/domain/bookfile"
+	"github.com/sdreger/lib-manager-go/internal/response"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strconv"
+)
+
+const (
+	fileTypePathVariable = "fileType"
+
+	// checksumHeader - the optional hex-encoded SHA-256 digest of the uploaded book file
+	checksumHeader = "X-Checksum-SHA256"
+)
+
+type BookFileService interface {
+	UploadBookFile(ctx context.Context, request bookfile.UploadRequest) (bookfile.BookFile, error)
+	GetBookFile(ctx context.Context, bookID int64, fileType string) (bookfile.BookFileContent, error)
+}
+
+type BookFileController struct {
+	logger          *slog.Logger
+	bookFileService BookFileService
+}
+
+func NewBookFileController(logger *slog.Logger, db *sqlx.DB, blobStore *blobtstore.MinioStore) *BookFileController {
+	return &BookFileController{
+		logger:          logger,
+		bookFileService: bookfile.NewService(logger, db, blobStore),
+	}
+}
+
+func (cnt *BookFileController) RegisterRoutes(registrar handlers.RouteRegistrar) {
+	registrar.RegisterRoute(http.MethodGet, group, "/books/{bookID}/files/{fileType}", cnt.GetBookFile)
+	registrar.RegisterRoute(http.MethodPut, group, "/books/{bookID}/files/{fileType}", cnt.UploadBookFile)
+}
+
+// GetBookFile - streams the book file content. The range and conditional requests are handled by http.ServeContent
+func (cnt *BookFileController) GetBookFile(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	idString := r.PathValue("bookID")
+	idInt, err := strconv.Atoi(idString)
+	if err != nil {
+		return apiErrors.ValidationError{
+			Field:   "bookID",
+			Message: "the provided bookID should be a number",
+		}
+	}
+
+	bookFile, err := cnt.bookFileService.GetBookFile(ctx, int64(idInt), r.PathValue(fileTypePathVariable))
+	if errors.Is(err, bookfile.ErrNotFound) {
+		return apiErrors.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = bookFile.Content.Close()
+	}()
+
+	w.Header().Set("Content-Type", bookFile.ContentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment",
+		map[string]string{"filename": bookFile.FileName}))
+	w.Header().Set("ETag", `"`+bookFile.SHA256+`"`)
+	if digest, err := hex.DecodeString(bookFile.SHA256); err == nil {
+		w.Header().Set("Digest", "sha-256="+base64.StdEncoding.EncodeToString(digest))
+	}
+	w.Header().Set(checksumHeader, bookFile.SHA256)
+	if err := handlers.ClearWriteDeadline(w); err != nil {
+		return err
+	}
+	http.ServeContent(w, r, "", bookFile.UpdatedAt, bookFile.Content)
+
+	return nil
+}
+
+func (cnt *BookFileController) UploadBookFile(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	idString := r.PathValue("bookID")
+	idInt, err := strconv.Atoi(idString)
+	if err != nil {
+		return apiErrors.ValidationError{
+			Field:   "bookID",
+			Message: "the provided bookID should be a number",
+		}
+	}
+
+	if err := handlers.ClearReadDeadline(w); err != nil {
+		return err
+	}
+	if err := handlers.ClearWriteDeadline(w); err != nil {
+		return err
+	}
+	bookFile, err := cnt.bookFileService.UploadBookFile(ctx, bookfile.UploadRequest{
+		BookID:      int64(idInt),
+		FileType:    r.PathValue(fileTypePathVariable),
+		Content:     r.Body,
+		Size:        r.ContentLength,
+		ContentType: r.Header.Get("Content-Type"),
+		Checksum:    r.Header.Get(checksumHeader),
+	})
+	if errors.Is(err, bookfile.ErrNotFound) {
+		return apiErrors.ErrNotFound
+	}
+	if errors.Is(err, bookfile.ErrUnknownFileType) {
+		return apiErrors.ValidationError{
+			Field:   fileTypePathVariable,
+			Message: err.Error(),
+		}
+	}
+	if errors.Is(err, bookfile.ErrInvalidChecksum) || errors.Is(err, bookfile.ErrChecksumMismatch) {
+		return apiErrors.ValidationError{
+			Field:   checksumHeader,
+			Message: err.Error(),
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	return response.RenderDataJSON(w, http.StatusOK, bookFile)
+}
@@ -0,0 +1,229 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	apiErrors "github.com/sdreger/lib-manager-go/cmd/api/errors"
+	"github.com/sdreger/lib-manager-go/cmd/api/handlers"
+	"github.com/sdreger/lib-manager-go/internal/domain/bookfile"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+)
+
+const (
+	testBookFileContent  = "0123456789"
+	testBookFileChecksum = "84d89877f0d4041efb6bf91a16f0248f2fd573e6af05c19f96bedb9f882f7882"
+)
+
+func TestBookFileHandler_RegisterBookFileHandler(t *testing.T) {
+
+	testRegistrar := handlers.RouteRegistrarMock{}
+	h := getBookFileHandler()
+	h.RegisterRoutes(&testRegistrar)
+
+	assert.True(t, testRegistrar.IsRouteRegistered("GET /v1/books/{bookID}/files/{fileType}", h.GetBookFile))
+	assert.True(t, testRegistrar.IsRouteRegistered("PUT /v1/books/{bookID}/files/{fileType}", h.UploadBookFile))
+}
+
+func TestBookFileHandler_GetBookFile_Success(t *testing.T) {
+	ctx := context.Background()
+	handler := getBookFileHandler()
+
+	mockService := NewMockBookFileService(t)
+	mockService.EXPECT().GetBookFile(ctx, int64(1), "epub").Return(getTestBookFileContent(), nil)
+	injectBookFileMocks(handler, mockService)
+
+	request := httptest.NewRequest("GET", "/v1/books/1/files/epub", nil)
+	request.SetPathValue("bookID", "1")
+	request.SetPathValue("fileType", "epub")
+	recorder := httptest.NewRecorder()
+	err := handler.GetBookFile(ctx, recorder, request)
+	require.NoError(t, err, "should get a book file")
+
+	result := recorder.Result()
+	defer result.Body.Close()
+	require.Equal(t, http.StatusOK, result.StatusCode, "should get a 200 OK response")
+	assert.Equal(t, "application/epub+zip", result.Header.Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="Book 01.epub"`, result.Header.Get("Content-Disposition"))
+	assert.Equal(t, `"`+testBookFileChecksum+`"`, result.Header.Get("ETag"))
+	assert.Equal(t, "sha-256=hNiYd/DUBB77a/kaFvAkjy/Vc+avBcGflr7bn4gveII=", result.Header.Get("Digest"))
+	assert.Equal(t, "bytes", result.Header.Get("Accept-Ranges"))
+
+	data, err := io.ReadAll(result.Body)
+	require.NoError(t, err, "should read body")
+	assert.Equal(t, testBookFileContent, string(data))
+}
+
+func TestBookFileHandler_GetBookFile_Range(t *testing.T) {
+	ctx := context.Background()
+	handler := getBookFileHandler()
+
+	mockService := NewMockBookFileService(t)
+	mockService.EXPECT().GetBookFile(ctx, int64(1), "epub").Return(getTestBookFileContent(), nil)
+	injectBookFileMocks(handler, mockService)
+
+	request := httptest.NewRequest("GET", "/v1/books/1/files/epub", nil)
+	request.SetPathValue("bookID", "1")
+	request.SetPathValue("fileType", "epub")
+	request.Header.Set("Range", "bytes=2-5")
+	recorder := httptest.NewRecorder()
+	err := handler.GetBookFile(ctx, recorder, request)
+	require.NoError(t, err, "should get a book file range")
+
+	result := recorder.Result()
+	defer result.Body.Close()
+	require.Equal(t, http.StatusPartialContent, result.StatusCode, "should get a 206 Partial Content response")
+	assert.Equal(t, "bytes 2-5/10", result.Header.Get("Content-Range"))
+
+	data, err := io.ReadAll(result.Body)
+	require.NoError(t, err, "should read body")
+	assert.Equal(t, "2345", string(data))
+}
+
+func TestBookFileHandler_GetBookFile_NotModified(t *testing.T) {
+	ctx := context.Background()
+	handler := getBookFileHandler()
+
+	mockService := NewMockBookFileService(t)
+	mockService.EXPECT().GetBookFile(ctx, int64(1), "epub").Return(getTestBookFileContent(), nil)
+	injectBookFileMocks(handler, mockService)
+
+	request := httptest.NewRequest("GET", "/v1/books/1/files/epub", nil)
+	request.SetPathValue("bookID", "1")
+	request.SetPathValue("fileType", "epub")
+	request.Header.Set("If-None-Match", `"`+testBookFileChecksum+`"`)
+	recorder := httptest.NewRecorder()
+	err := handler.GetBookFile(ctx, recorder, request)
+	require.NoError(t, err, "should get a book file")
+	assert.Equal(t, http.StatusNotModified, recorder.Code, "should get a 304 Not Modified response")
+}
+
+func TestBookFileHandler_GetBookFile_Errors(t *testing.T) {
+	ctx := context.Background()
+	handler := getBookFileHandler()
+	expectedError := errors.New("some error")
+
+	mockService := NewMockBookFileService(t)
+	mockService.EXPECT().GetBookFile(ctx, int64(1), "epub").Return(bookfile.BookFileContent{}, bookfile.ErrNotFound).Once()
+	mockService.EXPECT().GetBookFile(ctx, int64(1), "epub").Return(bookfile.BookFileContent{}, expectedError).Once()
+	injectBookFileMocks(handler, mockService)
+
+	request := httptest.NewRequest("GET", "/v1/books/abc/files/epub", nil)
+	request.SetPathValue("bookID", "abc")
+	err := handler.GetBookFile(ctx, httptest.NewRecorder(), request)
+	assert.ErrorAs(t, err, &apiErrors.ValidationError{}, "should get a validation error")
+
+	request = httptest.NewRequest("GET", "/v1/books/1/files/epub", nil)
+	request.SetPathValue("bookID", "1")
+	request.SetPathValue("fileType", "epub")
+	err = handler.GetBookFile(ctx, httptest.NewRecorder(), request)
+	assert.ErrorIs(t, err, apiErrors.ErrNotFound, "should not found book file")
+
+	err = handler.GetBookFile(ctx, httptest.NewRecorder(), request)
+	assert.ErrorIs(t, err, expectedError)
+}
+
+func TestBookFileHandler_UploadBookFile_Success(t *testing.T) {
+	ctx := context.Background()
+	handler := getBookFileHandler()
+	uploadedFile := getTestBookFileContent().BookFile
+
+	mockService := NewMockBookFileService(t)
+	mockService.EXPECT().UploadBookFile(ctx, mock.MatchedBy(func(request bookfile.UploadRequest) bool {
+		return request.BookID == 1 && request.FileType == "epub" && request.Size == int64(len(testBookFileContent)) &&
+			request.ContentType == "application/epub+zip" && request.Checksum == testBookFileChecksum
+	})).Return(uploadedFile, nil)
+	injectBookFileMocks(handler, mockService)
+
+	request := httptest.NewRequest("PUT", "/v1/books/1/files/epub", bytes.NewBufferString(testBookFileContent))
+	request.SetPathValue("bookID", "1")
+	request.SetPathValue("fileType", "epub")
+	request.Header.Set("Content-Type", "application/epub+zip")
+	request.Header.Set("X-Checksum-SHA256", testBookFileChecksum)
+	recorder := httptest.NewRecorder()
+	err := handler.UploadBookFile(ctx, recorder, request)
+	require.NoError(t, err, "should upload a book file")
+
+	result := recorder.Result()
+	defer result.Body.Close()
+	require.Equal(t, http.StatusOK, result.StatusCode, "should get a 200 OK response")
+
+	data, err := io.ReadAll(result.Body)
+	require.NoError(t, err, "should read body")
+	var bookFileJSON map[string]bookfile.BookFile
+	_ = json.Unmarshal(data, &bookFileJSON)
+	assert.Equal(t, uploadedFile, bookFileJSON["data"], "body should match")
+}
+
+func TestBookFileHandler_UploadBookFile_Errors(t *testing.T) {
+	ctx := context.Background()
+	handler := getBookFileHandler()
+
+	mockService := NewMockBookFileService(t)
+	mockService.EXPECT().UploadBookFile(ctx, mock.Anything).Return(bookfile.BookFile{}, bookfile.ErrNotFound).Once()
+	mockService.EXPECT().UploadBookFile(ctx, mock.Anything).Return(bookfile.BookFile{}, bookfile.ErrUnknownFileType).Once()
+	mockService.EXPECT().UploadBookFile(ctx, mock.Anything).Return(bookfile.BookFile{}, bookfile.ErrChecksumMismatch).Once()
+	injectBookFileMocks(handler, mockService)
+
+	request := httptest.NewRequest("PUT", "/v1/books/abc/files/epub", nil)
+	request.SetPathValue("bookID", "abc")
+	err := handler.UploadBookFile(ctx, httptest.NewRecorder(), request)
+	assert.ErrorAs(t, err, &apiErrors.ValidationError{}, "should get a validation error")
+
+	request = httptest.NewRequest("PUT", "/v1/books/1/files/epub", bytes.NewBufferString(testBookFileContent))
+	request.SetPathValue("bookID", "1")
+	request.SetPathValue("fileType", "epub")
+	err = handler.UploadBookFile(ctx, httptest.NewRecorder(), request)
+	assert.ErrorIs(t, err, apiErrors.ErrNotFound, "should not found book")
+
+	var validationError apiErrors.ValidationError
+	err = handler.UploadBookFile(ctx, httptest.NewRecorder(), request)
+	require.ErrorAs(t, err, &validationError, "should get a validation error")
+	assert.Equal(t, "fileType", validationError.Field)
+
+	err = handler.UploadBookFile(ctx, httptest.NewRecorder(), request)
+	require.ErrorAs(t, err, &validationError, "should get a validation error")
+	assert.Equal(t, "X-Checksum-SHA256", validationError.Field)
+}
+
+func getBookFileHandler() *BookFileController {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.Level(100)}))
+	return NewBookFileController(logger, nil, nil)
+}
+
+func injectBookFileMocks(controller *BookFileController, bookFileService *MockBookFileService) {
+	controller.bookFileService = bookFileService
+}
+
+func getTestBookFileContent() bookfile.BookFileContent {
+	return bookfile.BookFileContent{
+		BookFile: bookfile.BookFile{
+			BookID:      1,
+			FileType:    "epub",
+			FileName:    "Book 01.epub",
+			Size:        int64(len(testBookFileContent)),
+			SHA256:      testBookFileChecksum,
+			ContentType: "application/epub+zip",
+			UpdatedAt:   time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC),
+		},
+		Content: nopSeekCloser{bytes.NewReader([]byte(testBookFileContent))},
+	}
+}
+
+type nopSeekCloser struct {
+	io.ReadSeeker
+}
+
+func (nopSeekCloser) Close() error {
+	return nil
+}
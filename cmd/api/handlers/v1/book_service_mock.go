@@ -152,3 +152,59 @@ func (_c *MockBookService_GetBooks_Call) RunAndReturn(run func(ctx context.Conte
 	_c.Call.Return(run)
 	return _c
 }
+
+// MergeBooks provides a mock function for the type MockBookService
+func (_mock *MockBookService) MergeBooks(ctx context.Context, targetID int64, sourceIDs []int64) (book.Book, error) {
+	ret := _mock.Called(ctx, targetID, sourceIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MergeBooks")
+	}
+
+	var r0 book.Book
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, []int64) (book.Book, error)); ok {
+		return returnFunc(ctx, targetID, sourceIDs)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, []int64) book.Book); ok {
+		r0 = returnFunc(ctx, targetID, sourceIDs)
+	} else {
+		r0 = ret.Get(0).(book.Book)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, []int64) error); ok {
+		r1 = returnFunc(ctx, targetID, sourceIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockBookService_MergeBooks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MergeBooks'
+type MockBookService_MergeBooks_Call struct {
+	*mock.Call
+}
+
+// MergeBooks is a helper method to define mock.On call
+//   - ctx
+//   - targetID
+//   - sourceIDs
+func (_e *MockBookService_Expecter) MergeBooks(ctx interface{}, targetID interface{}, sourceIDs interface{}) *MockBookService_MergeBooks_Call {
+	return &MockBookService_MergeBooks_Call{Call: _e.mock.On("MergeBooks", ctx, targetID, sourceIDs)}
+}
+
+func (_c *MockBookService_MergeBooks_Call) Run(run func(ctx context.Context, targetID int64, sourceIDs []int64)) *MockBookService_MergeBooks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].([]int64))
+	})
+	return _c
+}
+
+func (_c *MockBookService_MergeBooks_Call) Return(book1 book.Book, err error) *MockBookService_MergeBooks_Call {
+	_c.Call.Return(book1, err)
+	return _c
+}
+
+func (_c *MockBookService_MergeBooks_Call) RunAndReturn(run func(ctx context.Context, targetID int64, sourceIDs []int64) (book.Book, error)) *MockBookService_MergeBooks_Call {
+	_c.Call.Return(run)
+	return _c
+}
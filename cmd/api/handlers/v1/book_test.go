@@ -17,6 +17,7 @@ import (
 	"net/http/httptest"
 	"os"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 )
@@ -65,6 +66,7 @@ func TestBookController_RegisterRoutes(t *testing.T) {
 
 	assert.True(t, testRegistrar.IsRouteRegistered("GET /v1/books", cnt.GetBooks))
 	assert.True(t, testRegistrar.IsRouteRegistered("GET /v1/books/{bookID}", cnt.GetBook))
+	assert.True(t, testRegistrar.IsRouteRegistered("POST /v1/books/{bookID}/merge", cnt.MergeBooks))
 }
 
 func TestBookController_GetBook_Success(t *testing.T) {
@@ -95,6 +97,35 @@ func TestBookController_GetBook_Success(t *testing.T) {
 	assert.Equal(t, testBook, bookJSON["data"], "body should match")
 }
 
+func TestBookController_GetBook_LinkedData(t *testing.T) {
+	ctx := context.Background()
+	controller := getBookController()
+
+	mockService := NewMockBookService(t)
+	mockService.EXPECT().GetBookByID(ctx, bookID).Return(getTestBook(), nil)
+	injectBookMocks(controller, mockService)
+
+	request := httptest.NewRequest("GET", "/v1/books/1", nil)
+	request.SetPathValue("bookID", strconv.FormatInt(bookID, 10))
+	request.Header.Set("Accept", "application/ld+json, application/json;q=0.9")
+	recorder := httptest.NewRecorder()
+	err := controller.GetBook(ctx, recorder, request)
+	require.NoError(t, err, "should get a book")
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "application/ld+json", recorder.Header().Get("Content-Type"))
+	assert.Equal(t, "Accept", recorder.Header().Get("Vary"))
+	var node map[string]any
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &node), "should read body")
+	assert.Equal(t, "https://schema.org", node["@context"])
+	assert.Equal(t, "Book", node["@type"])
+	assert.Equal(t, "http://example.com/v1/books/1", node["@id"])
+	assert.Equal(t, "9781234567890", node["isbn"])
+	assert.Equal(t, float64(bookPages), node["numberOfPages"])
+	assert.Equal(t, map[string]any{"@type": "Organization", "name": bookPublisher}, node["publisher"])
+	assert.Nil(t, node["data"], "the document should not be wrapped")
+}
+
 func TestBookController_GetBook_Not_Found(t *testing.T) {
 	ctx := context.Background()
 	controller := getBookController()
@@ -231,6 +262,77 @@ func TestBookController_GetBooks_FilterError(t *testing.T) {
 	assert.ErrorAs(t, err, &apiErrors.ValidationError{}, "should get a validation error")
 }
 
+func TestBookController_MergeBooks(t *testing.T) {
+	ctx := context.Background()
+	controller := getBookController()
+	testBook := getTestBook()
+
+	mockService := NewMockBookService(t)
+	mockService.EXPECT().MergeBooks(ctx, bookID, []int64{2, 3}).Return(testBook, nil).Once()
+	injectBookMocks(controller, mockService)
+
+	request := httptest.NewRequest("POST", "/v1/books/1/merge", strings.NewReader(`{"book_ids": [2, 3]}`))
+	request.SetPathValue("bookID", "1")
+	recorder := httptest.NewRecorder()
+	err := controller.MergeBooks(ctx, recorder, request)
+	require.NoError(t, err, "should merge books")
+
+	result := recorder.Result()
+	defer result.Body.Close()
+	require.Equal(t, http.StatusOK, result.StatusCode, "should get a 200 OK response")
+
+	data, err := io.ReadAll(result.Body)
+	require.NoError(t, err, "should read body")
+	var bookJSON map[string]book.Book
+	_ = json.Unmarshal(data, &bookJSON)
+	assert.Equal(t, testBook, bookJSON["data"], "body should match")
+}
+
+func TestBookController_MergeBooks_Errors(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name       string
+		bookID     string
+		body       string
+		serviceErr error
+		wantErr    error
+	}{
+		{name: "invalid book ID", bookID: "one", body: `{"book_ids": [2]}`,
+			wantErr: apiErrors.ValidationError{Field: "bookID", Message: "the provided bookID should be a number"}},
+		{name: "invalid body", bookID: "1", body: `{"ids": [2]}`},
+		{name: "invalid merge", bookID: "1", body: `{"book_ids": [1]}`, serviceErr: book.ErrInvalidMerge,
+			wantErr: apiErrors.ValidationError{Field: "book_ids", Message: book.ErrInvalidMerge.Error()}},
+		{name: "not found", bookID: "1", body: `{"book_ids": [2]}`, serviceErr: book.ErrNotFound,
+			wantErr: apiErrors.ErrNotFound},
+		{name: "service error", bookID: "1", body: `{"book_ids": [2]}`, serviceErr: errors.New("some error")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := getBookController()
+			mockService := NewMockBookService(t)
+			if tt.serviceErr != nil {
+				mockService.EXPECT().MergeBooks(ctx, mock.Anything, mock.Anything).Return(book.Book{}, tt.serviceErr).Once()
+			}
+			injectBookMocks(controller, mockService)
+
+			request := httptest.NewRequest("POST", "/v1/books/"+tt.bookID+"/merge", strings.NewReader(tt.body))
+			request.SetPathValue("bookID", tt.bookID)
+			err := controller.MergeBooks(ctx, httptest.NewRecorder(), request)
+			require.Error(t, err, "should fail")
+			switch {
+			case tt.wantErr != nil:
+				assert.Equal(t, tt.wantErr, err)
+			case tt.serviceErr != nil:
+				assert.ErrorIs(t, err, tt.serviceErr)
+			default:
+				var validationErr apiErrors.ValidationError
+				require.ErrorAs(t, err, &validationErr)
+				assert.Equal(t, "body", validationErr.Field)
+			}
+		})
+	}
+}
+
 func getBookController() *BookController {
 	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
 	return NewBookController(logger, nil)
@@ -0,0 +1,120 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"github.com/jmoiron/sqlx"
+	apiErrors "github.com/sdreger/lib-manager-go/cmd/api/errors"
+	"github.com/sdreger/lib-manager-go/cmd/api/handlers"
+	"github.com/sdreger/lib-manager-go/internal/citation"
+	"github.com/sdreger/lib-manager-go/internal/domain/book"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type CitationService interface {
+	GetBookByID(ctx context.Context, bookID int64) (book.Book, error)
+	ExportBooks(ctx context.Context, filter book.Filter, fn func(book book.Book) error) error
+}
+
+type CitationController struct {
+	logger      *slog.Logger
+	bookService CitationService
+}
+
+func NewCitationController(logger *slog.Logger, db *sqlx.DB) *CitationController {
+	return &CitationController{logger: logger, bookService: book.NewService(logger, db)}
+}
+
+func (cnt *CitationController) RegisterRoutes(registrar handlers.RouteRegistrar) {
+	registrar.RegisterRoute(http.MethodGet, group, "/books/{bookID}/citation", cnt.GetBookCitation)
+	registrar.RegisterRoute(http.MethodGet, group, "/citations", cnt.GetCitations)
+}
+
+// GetBookCitation - returns the book citation, in the format requested by the 'format' query parameter,
+// or negotiated by the 'Accept' header. BibTeX is the default format
+func (cnt *CitationController) GetBookCitation(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	idInt, err := strconv.Atoi(r.PathValue("bookID"))
+	if err != nil {
+		return apiErrors.ValidationError{
+			Field:   "bookID",
+			Message: "the provided bookID should be a number",
+		}
+	}
+	formatName, err := citationFormat(r)
+	if err != nil {
+		return err
+	}
+
+	citedBook, err := cnt.bookService.GetBookByID(ctx, int64(idInt))
+	if errors.Is(err, book.ErrNotFound) {
+		return apiErrors.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	format := citation.Formats[formatName]
+	fileName := "book-" + strconv.Itoa(idInt) + "." + format.Extension
+	w.Header().Set("Content-Type", format.ContentType())
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("inline", map[string]string{"filename": fileName}))
+	w.Header().Add("Vary", "Accept")
+	writer, err := citation.NewWriter(formatName, w)
+	if err != nil {
+		return err
+	}
+	if err := writer.Write(citedBook); err != nil {
+		return err
+	}
+
+	return writer.Flush()
+}
+
+// GetCitations - streams the citations of all the books, matching the book filter, as a file download.
+// The format is selected the same way as for a single book
+func (cnt *CitationController) GetCitations(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	formatName, err := citationFormat(r)
+	if err != nil {
+		return err
+	}
+	filter, err := book.NewFilter(r.URL.Query())
+	if err != nil {
+		return err
+	}
+
+	if err := handlers.ClearWriteDeadline(w); err != nil {
+		return err
+	}
+
+	stream := func(fn func(book.Book) error) error {
+		return cnt.bookService.ExportBooks(ctx, filter, fn)
+	}
+	return streamBooks(cnt.logger, stream, func() (bookWriter, error) {
+		format := citation.Formats[formatName]
+		setAttachment(w, format.ContentType(), "citations", format.Extension)
+		w.Header().Add("Vary", "Accept")
+		return citation.NewWriter(formatName, w)
+	})
+}
+
+// citationFormat - returns the requested citation format, the 'format' query parameter takes precedence
+// over the 'Accept' header
+func citationFormat(r *http.Request) (string, error) {
+	if formatName := strings.ToLower(r.URL.Query().Get(queryParamFormat)); formatName != "" {
+		if _, ok := citation.Formats[formatName]; !ok {
+			return "", apiErrors.ValidationError{
+				Field:   queryParamFormat,
+				Message: citation.ErrUnsupportedFormat.Error(),
+			}
+		}
+		return formatName, nil
+	}
+	if formatName, ok := citation.Negotiate(r.Header.Get("Accept")); ok {
+		return formatName, nil
+	}
+
+	return citation.DefaultFormat, nil
+}
@@ -0,0 +1,142 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	apiErrors "github.com/sdreger/lib-manager-go/cmd/api/errors"
+	"github.com/sdreger/lib-manager-go/cmd/api/handlers"
+	"github.com/sdreger/lib-manager-go/internal/citation"
+	"github.com/sdreger/lib-manager-go/internal/domain/book"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"log/slog"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestCitationController_RegisterRoutes(t *testing.T) {
+	testRegistrar := handlers.RouteRegistrarMock{}
+	cnt := getCitationController()
+	cnt.RegisterRoutes(&testRegistrar)
+
+	assert.True(t, testRegistrar.IsRouteRegistered("GET /v1/books/{bookID}/citation", cnt.GetBookCitation))
+	assert.True(t, testRegistrar.IsRouteRegistered("GET /v1/citations", cnt.GetCitations))
+}
+
+func TestCitationController_GetBookCitation(t *testing.T) {
+	tests := []struct {
+		name        string
+		query       string
+		accept      string
+		contentType string
+		prefix      string
+	}{
+		{name: "default", contentType: "application/x-bibtex", prefix: "@book{"},
+		{name: "query", query: "format=RIS", accept: "application/x-bibtex",
+			contentType: "application/x-research-info-systems", prefix: "TY  - BOOK"},
+		{name: "accept", accept: "application/vnd.citationstyles.csl+json",
+			contentType: "application/vnd.citationstyles.csl+json", prefix: "[\n{"},
+		{name: "not acceptable", accept: "application/json", contentType: "application/x-bibtex", prefix: "@book{"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			controller := getCitationController()
+			mockService := NewMockCitationService(t)
+			mockService.EXPECT().GetBookByID(ctx, bookID).
+				Return(book.Book{ID: bookID, Title: bookTitle, Authors: []string{"Ben Darnell"}}, nil).Once()
+			controller.bookService = mockService
+
+			request := httptest.NewRequest(http.MethodGet, "/v1/books/1/citation?"+tt.query, nil)
+			request.SetPathValue("bookID", "1")
+			request.Header.Set("Accept", tt.accept)
+			recorder := httptest.NewRecorder()
+			err := controller.GetBookCitation(ctx, recorder, request)
+			require.NoError(t, err, "should return the citation")
+
+			mediaType, _, err := mime.ParseMediaType(recorder.Header().Get("Content-Type"))
+			require.NoError(t, err)
+			assert.Equal(t, tt.contentType, mediaType)
+			assert.Equal(t, "Accept", recorder.Header().Get("Vary"))
+			assert.True(t, strings.HasPrefix(recorder.Body.String(), tt.prefix), recorder.Body.String())
+			assert.Contains(t, recorder.Body.String(), bookTitle)
+		})
+	}
+}
+
+func TestCitationController_GetBookCitation_Errors(t *testing.T) {
+	ctx := context.Background()
+	controller := getCitationController()
+	mockService := NewMockCitationService(t)
+	mockService.EXPECT().GetBookByID(ctx, int64(2)).Return(book.Book{}, book.ErrNotFound).Once()
+	controller.bookService = mockService
+
+	request := httptest.NewRequest(http.MethodGet, "/v1/books/abc/citation", nil)
+	request.SetPathValue("bookID", "abc")
+	err := controller.GetBookCitation(ctx, httptest.NewRecorder(), request)
+	var validationError apiErrors.ValidationError
+	require.ErrorAs(t, err, &validationError)
+	assert.Equal(t, "bookID", validationError.Field)
+
+	request = httptest.NewRequest(http.MethodGet, "/v1/books/1/citation?format=apa", nil)
+	request.SetPathValue("bookID", "1")
+	err = controller.GetBookCitation(ctx, httptest.NewRecorder(), request)
+	require.ErrorAs(t, err, &validationError)
+	assert.Equal(t, queryParamFormat, validationError.Field)
+
+	request = httptest.NewRequest(http.MethodGet, "/v1/books/2/citation", nil)
+	request.SetPathValue("bookID", "2")
+	err = controller.GetBookCitation(ctx, httptest.NewRecorder(), request)
+	require.ErrorIs(t, err, apiErrors.ErrNotFound)
+}
+
+func TestCitationController_GetCitations(t *testing.T) {
+	ctx := context.Background()
+	controller := getCitationController()
+	request := httptest.NewRequest(http.MethodGet, "/v1/citations?author=1", nil)
+	request.Header.Set("Accept", "application/x-research-info-systems")
+
+	mockService := NewMockCitationService(t)
+	mockService.EXPECT().ExportBooks(ctx, getExportFilter(t, request), mock.Anything).
+		RunAndReturn(func(ctx context.Context, filter book.Filter, fn func(book.Book) error) error {
+			if err := fn(book.Book{ID: bookID, Title: bookTitle}); err != nil {
+				return err
+			}
+			return fn(book.Book{ID: 2, Title: "Book 02"})
+		}).Once()
+	controller.bookService = mockService
+
+	recorder := httptest.NewRecorder()
+	err := controller.GetCitations(ctx, recorder, request)
+	require.NoError(t, err, "should stream the citations")
+
+	assert.Equal(t, citation.Formats[citation.FormatRIS].ContentType(), recorder.Header().Get("Content-Type"))
+	_, params, err := mime.ParseMediaType(recorder.Header().Get("Content-Disposition"))
+	require.NoError(t, err)
+	assert.True(t, strings.HasSuffix(params["filename"], ".ris"))
+	assert.Equal(t, 2, strings.Count(recorder.Body.String(), "TY  - BOOK"))
+}
+
+func TestCitationController_GetCitations_Failure(t *testing.T) {
+	ctx := context.Background()
+	controller := getCitationController()
+	request := httptest.NewRequest(http.MethodGet, "/v1/citations", nil)
+	expectedError := errors.New("some error")
+
+	mockService := NewMockCitationService(t)
+	mockService.EXPECT().ExportBooks(ctx, getExportFilter(t, request), mock.Anything).Return(expectedError).Once()
+	controller.bookService = mockService
+
+	err := controller.GetCitations(ctx, httptest.NewRecorder(), request)
+	require.ErrorIs(t, err, expectedError)
+}
+
+func getCitationController() *CitationController {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.Level(100)}))
+	return NewCitationController(logger, nil)
+}
@@ -4,22 +4,31 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"github.com/jmoiron/sqlx"
 	apiErrors "github.com/sdreger/lib-manager-go/cmd/api/errors"
 	"github.com/sdreger/lib-manager-go/cmd/api/handlers"
 	"github.com/sdreger/lib-manager-go/internal/blobtstore"
 	"github.com/sdreger/lib-manager-go/internal/domain/cover"
+	"github.com/sdreger/lib-manager-go/internal/response"
 	"io"
 	"log/slog"
 	"net/http"
+	"strconv"
 )
 
 const (
 	publisherNamePathVariable = "publisherName"
 	coverFileNamePathVariable = "coverFileName"
+
+	bookCoverCacheControl = "public, max-age=3600"
+	coverTypeSVG          = "image/svg+xml"
 )
 
 type CoverService interface {
 	GetBookCover(ctx context.Context, filePath string) (io.Reader, error)
+	UploadBookCover(ctx context.Context, bookID int64, reader io.Reader) (cover.Cover, error)
+	GetCoverByBookID(ctx context.Context, bookID int64, placeholderFormat cover.PlaceholderFormat) (
+		cover.CoverFile, error)
 }
 
 type CoverController struct {
@@ -27,15 +36,17 @@ type CoverController struct {
 	coverService CoverService
 }
 
-func NewCoverController(logger *slog.Logger, blobStore *blobtstore.MinioStore) *CoverController {
+func NewCoverController(logger *slog.Logger, db *sqlx.DB, blobStore *blobtstore.MinioStore) *CoverController {
 	return &CoverController{
 		logger:       logger,
-		coverService: cover.NewService(logger, blobStore),
+		coverService: cover.NewService(logger, db, blobStore),
 	}
 }
 
 func (cnt *CoverController) RegisterRoutes(registrar handlers.RouteRegistrar) {
 	registrar.RegisterRoute(http.MethodGet, group, "/covers/{publisherName}/{coverFileName}", cnt.GetBookCover)
+	registrar.RegisterRoute(http.MethodGet, group, "/books/{bookID}/cover", cnt.GetCoverByBookID)
+	registrar.RegisterRoute(http.MethodPut, group, "/books/{bookID}/cover", cnt.UploadBookCover)
 }
 
 func (cnt *CoverController) GetBookCover(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
@@ -50,7 +61,91 @@ func (cnt *CoverController) GetBookCover(ctx context.Context, w http.ResponseWri
 	}
 
 	w.Header().Set("Content-Type", "octet/stream")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
 	_, coverWriteError := io.Copy(w, bookCoverReader)
 
 	return coverWriteError
 }
+
+func (cnt *CoverController) GetCoverByBookID(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	idString := r.PathValue("bookID")
+	idInt, err := strconv.Atoi(idString)
+	if err != nil {
+		return apiErrors.ValidationError{
+			Field:   "bookID",
+			Message: "the provided bookID should be a number",
+		}
+	}
+
+	placeholderFormat, err := cover.NewPlaceholderFormat(r.URL.Query())
+	if err != nil {
+		return err
+	}
+
+	coverFile, err := cnt.coverService.GetCoverByBookID(ctx, int64(idInt), placeholderFormat)
+	if errors.Is(err, cover.ErrNotFound) {
+		return apiErrors.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if closer, ok := coverFile.Content.(io.Closer); ok {
+		defer func() {
+			_ = closer.Close()
+		}()
+	}
+
+	if coverFile.ETag != "" {
+		etag := `"` + coverFile.ETag + `"`
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Cache-Control", bookCoverCacheControl)
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return nil
+		}
+	}
+	w.Header().Set("Content-Type", coverFile.ContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	if coverFile.ContentType == coverTypeSVG {
+		// the stored SVG covers and the placeholders still render in the 'img' elements, but the scripts
+		// they can carry do not run, when the cover is opened directly
+		w.Header().Set("Content-Security-Policy", "default-src 'none'")
+		w.Header().Set("Content-Disposition", "attachment")
+	}
+	_, coverWriteError := io.Copy(w, coverFile.Content)
+
+	return coverWriteError
+}
+
+func (cnt *CoverController) UploadBookCover(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	idString := r.PathValue("bookID")
+	idInt, err := strconv.Atoi(idString)
+	if err != nil {
+		return apiErrors.ValidationError{
+			Field:   "bookID",
+			Message: "the provided bookID should be a number",
+		}
+	}
+
+	if err := handlers.ClearReadDeadline(w); err != nil {
+		return err
+	}
+	if err := handlers.ClearWriteDeadline(w); err != nil {
+		return err
+	}
+	bookCover, err := cnt.coverService.UploadBookCover(ctx, int64(idInt), r.Body)
+	if errors.Is(err, cover.ErrNotFound) {
+		return apiErrors.ErrNotFound
+	}
+	if errors.Is(err, cover.ErrUnsupportedType) || errors.Is(err, cover.ErrTooLarge) {
+		return apiErrors.ValidationError{
+			Field:   "cover",
+			Message: err.Error(),
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	return response.RenderDataJSON(w, http.StatusOK, bookCover)
+}
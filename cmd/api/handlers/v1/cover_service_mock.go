@@ -10,6 +10,7 @@ import (
 	"context"
 	"io"
 
+	"github.com/sdreger/lib-manager-go/internal/domain/cover"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -96,3 +97,115 @@ func (_c *MockCoverService_GetBookCover_Call) RunAndReturn(run func(ctx context.
 	_c.Call.Return(run)
 	return _c
 }
+
+// GetCoverByBookID provides a mock function for the type MockCoverService
+func (_mock *MockCoverService) GetCoverByBookID(ctx context.Context, bookID int64, placeholderFormat cover.PlaceholderFormat) (cover.CoverFile, error) {
+	ret := _mock.Called(ctx, bookID, placeholderFormat)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCoverByBookID")
+	}
+
+	var r0 cover.CoverFile
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, cover.PlaceholderFormat) (cover.CoverFile, error)); ok {
+		return returnFunc(ctx, bookID, placeholderFormat)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, cover.PlaceholderFormat) cover.CoverFile); ok {
+		r0 = returnFunc(ctx, bookID, placeholderFormat)
+	} else {
+		r0 = ret.Get(0).(cover.CoverFile)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, cover.PlaceholderFormat) error); ok {
+		r1 = returnFunc(ctx, bookID, placeholderFormat)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCoverService_GetCoverByBookID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCoverByBookID'
+type MockCoverService_GetCoverByBookID_Call struct {
+	*mock.Call
+}
+
+// GetCoverByBookID is a helper method to define mock.On call
+//   - ctx
+//   - bookID
+//   - placeholderFormat
+func (_e *MockCoverService_Expecter) GetCoverByBookID(ctx interface{}, bookID interface{}, placeholderFormat interface{}) *MockCoverService_GetCoverByBookID_Call {
+	return &MockCoverService_GetCoverByBookID_Call{Call: _e.mock.On("GetCoverByBookID", ctx, bookID, placeholderFormat)}
+}
+
+func (_c *MockCoverService_GetCoverByBookID_Call) Run(run func(ctx context.Context, bookID int64, placeholderFormat cover.PlaceholderFormat)) *MockCoverService_GetCoverByBookID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(cover.PlaceholderFormat))
+	})
+	return _c
+}
+
+func (_c *MockCoverService_GetCoverByBookID_Call) Return(coverFile cover.CoverFile, err error) *MockCoverService_GetCoverByBookID_Call {
+	_c.Call.Return(coverFile, err)
+	return _c
+}
+
+func (_c *MockCoverService_GetCoverByBookID_Call) RunAndReturn(run func(ctx context.Context, bookID int64, placeholderFormat cover.PlaceholderFormat) (cover.CoverFile, error)) *MockCoverService_GetCoverByBookID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UploadBookCover provides a mock function for the type MockCoverService
+func (_mock *MockCoverService) UploadBookCover(ctx context.Context, bookID int64, reader io.Reader) (cover.Cover, error) {
+	ret := _mock.Called(ctx, bookID, reader)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UploadBookCover")
+	}
+
+	var r0 cover.Cover
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, io.Reader) (cover.Cover, error)); ok {
+		return returnFunc(ctx, bookID, reader)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, io.Reader) cover.Cover); ok {
+		r0 = returnFunc(ctx, bookID, reader)
+	} else {
+		r0 = ret.Get(0).(cover.Cover)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, io.Reader) error); ok {
+		r1 = returnFunc(ctx, bookID, reader)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCoverService_UploadBookCover_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UploadBookCover'
+type MockCoverService_UploadBookCover_Call struct {
+	*mock.Call
+}
+
+// UploadBookCover is a helper method to define mock.On call
+//   - ctx
+//   - bookID
+//   - reader
+func (_e *MockCoverService_Expecter) UploadBookCover(ctx interface{}, bookID interface{}, reader interface{}) *MockCoverService_UploadBookCover_Call {
+	return &MockCoverService_UploadBookCover_Call{Call: _e.mock.On("UploadBookCover", ctx, bookID, reader)}
+}
+
+func (_c *MockCoverService_UploadBookCover_Call) Run(run func(ctx context.Context, bookID int64, reader io.Reader)) *MockCoverService_UploadBookCover_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(io.Reader))
+	})
+	return _c
+}
+
+func (_c *MockCoverService_UploadBookCover_Call) Return(cover1 cover.Cover, err error) *MockCoverService_UploadBookCover_Call {
+	_c.Call.Return(cover1, err)
+	return _c
+}
+
+func (_c *MockCoverService_UploadBookCover_Call) RunAndReturn(run func(ctx context.Context, bookID int64, reader io.Reader) (cover.Cover, error)) *MockCoverService_UploadBookCover_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -3,6 +3,7 @@ package v1
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	apiErrors "github.com/sdreger/lib-manager-go/cmd/api/errors"
 	"github.com/sdreger/lib-manager-go/cmd/api/handlers"
@@ -25,6 +26,8 @@ func TestCoverHandler_RegisterCoverHandler(t *testing.T) {
 	h.RegisterRoutes(&testRegistrar)
 
 	assert.True(t, testRegistrar.IsRouteRegistered("GET /v1/covers/{publisherName}/{coverFileName}", h.GetBookCover))
+	assert.True(t, testRegistrar.IsRouteRegistered("GET /v1/books/{bookID}/cover", h.GetCoverByBookID))
+	assert.True(t, testRegistrar.IsRouteRegistered("PUT /v1/books/{bookID}/cover", h.UploadBookCover))
 }
 
 func TestCoverHandler_GetCover_Success(t *testing.T) {
@@ -89,9 +92,143 @@ func TestCoverHandler_GetCover_Error(t *testing.T) {
 
 func getCoverHandler() *CoverController {
 	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.Level(100)}))
-	return NewCoverController(logger, nil)
+	return NewCoverController(logger, nil, nil)
 }
 
 func injectCoverMocks(service *CoverController, coverService *MockCoverService) {
 	service.coverService = coverService
 }
+
+func TestCoverHandler_UploadBookCover_Success(t *testing.T) {
+	ctx := context.Background()
+	handler := getCoverHandler()
+	content := `<svg xmlns="http://www.w3.org/2000/svg" width="300" height="400"></svg>`
+	uploadedCover := cover.Cover{Hash: "abc", Size: int64(len(content)), MIMEType: "image/svg+xml", Width: 300, Height: 400}
+
+	mockService := NewMockCoverService(t)
+	mockService.EXPECT().UploadBookCover(ctx, int64(1), mock.Anything).Return(uploadedCover, nil)
+	injectCoverMocks(handler, mockService)
+
+	request := httptest.NewRequest("PUT", "/v1/books/1/cover", bytes.NewBufferString(content))
+	request.SetPathValue("bookID", "1")
+	recorder := httptest.NewRecorder()
+	err := handler.UploadBookCover(ctx, recorder, request)
+	require.NoError(t, err, "should upload a book cover")
+
+	result := recorder.Result()
+	defer result.Body.Close()
+	require.Equal(t, http.StatusOK, result.StatusCode, "should get a 200 OK response")
+
+	data, err := io.ReadAll(result.Body)
+	require.NoError(t, err, "should read body")
+	var coverJSON map[string]cover.Cover
+	_ = json.Unmarshal(data, &coverJSON)
+	assert.Equal(t, uploadedCover, coverJSON["data"], "body should match")
+}
+
+func TestCoverHandler_UploadBookCover_WrongID(t *testing.T) {
+	ctx := context.Background()
+	handler := getCoverHandler()
+
+	request := httptest.NewRequest("PUT", "/v1/books/abc/cover", nil)
+	request.SetPathValue("bookID", "abc")
+	recorder := httptest.NewRecorder()
+	err := handler.UploadBookCover(ctx, recorder, request)
+	assert.ErrorAs(t, err, &apiErrors.ValidationError{}, "should get a validation error")
+}
+
+func TestCoverHandler_UploadBookCover_Errors(t *testing.T) {
+	ctx := context.Background()
+	handler := getCoverHandler()
+
+	mockService := NewMockCoverService(t)
+	mockService.EXPECT().UploadBookCover(ctx, int64(1), mock.Anything).Return(cover.Cover{}, cover.ErrNotFound).Once()
+	mockService.EXPECT().UploadBookCover(ctx, int64(1), mock.Anything).
+		Return(cover.Cover{}, cover.ErrUnsupportedType).Once()
+	injectCoverMocks(handler, mockService)
+
+	request := httptest.NewRequest("PUT", "/v1/books/1/cover", bytes.NewBufferString("text"))
+	request.SetPathValue("bookID", "1")
+	err := handler.UploadBookCover(ctx, httptest.NewRecorder(), request)
+	assert.ErrorIs(t, err, apiErrors.ErrNotFound, "should not found book")
+
+	err = handler.UploadBookCover(ctx, httptest.NewRecorder(), request)
+	var validationError apiErrors.ValidationError
+	require.ErrorAs(t, err, &validationError, "should get a validation error")
+	assert.Equal(t, "cover", validationError.Field)
+}
+
+func TestCoverHandler_GetCoverByBookID_Success(t *testing.T) {
+	ctx := context.Background()
+	handler := getCoverHandler()
+	content := `<svg xmlns="http://www.w3.org/2000/svg" width="600" height="900"></svg>`
+	coverFile := cover.CoverFile{Content: bytes.NewBufferString(content), ContentType: "image/svg+xml", ETag: "abc"}
+
+	mockService := NewMockCoverService(t)
+	mockService.EXPECT().GetCoverByBookID(ctx, int64(1), cover.PlaceholderSVG).Return(coverFile, nil)
+	injectCoverMocks(handler, mockService)
+
+	request := httptest.NewRequest("GET", "/v1/books/1/cover?fallback=generated", nil)
+	request.SetPathValue("bookID", "1")
+	recorder := httptest.NewRecorder()
+	err := handler.GetCoverByBookID(ctx, recorder, request)
+	require.NoError(t, err, "should get a book cover")
+
+	result := recorder.Result()
+	defer result.Body.Close()
+	require.Equal(t, http.StatusOK, result.StatusCode, "should get a 200 OK response")
+	assert.Equal(t, "image/svg+xml", result.Header.Get("Content-Type"))
+	assert.Equal(t, `"abc"`, result.Header.Get("ETag"))
+	assert.NotEmpty(t, result.Header.Get("Cache-Control"))
+	assert.Equal(t, "nosniff", result.Header.Get("X-Content-Type-Options"))
+	assert.Equal(t, "default-src 'none'", result.Header.Get("Content-Security-Policy"))
+	assert.Equal(t, "attachment", result.Header.Get("Content-Disposition"), "the SVG cover should not be rendered inline")
+
+	data, err := io.ReadAll(result.Body)
+	require.NoError(t, err, "should read body")
+	assert.Equal(t, content, string(data))
+}
+
+func TestCoverHandler_GetCoverByBookID_NotModified(t *testing.T) {
+	ctx := context.Background()
+	handler := getCoverHandler()
+	coverFile := cover.CoverFile{Content: bytes.NewBufferString("png"), ContentType: "image/png", ETag: "abc"}
+
+	mockService := NewMockCoverService(t)
+	mockService.EXPECT().GetCoverByBookID(ctx, int64(1), cover.PlaceholderPNG).Return(coverFile, nil)
+	injectCoverMocks(handler, mockService)
+
+	request := httptest.NewRequest("GET", "/v1/books/1/cover?fallback=generated&format=png", nil)
+	request.SetPathValue("bookID", "1")
+	request.Header.Set("If-None-Match", `"abc"`)
+	recorder := httptest.NewRecorder()
+	err := handler.GetCoverByBookID(ctx, recorder, request)
+	require.NoError(t, err, "should get a book cover")
+	assert.Equal(t, http.StatusNotModified, recorder.Code, "should get a 304 Not Modified response")
+	assert.Empty(t, recorder.Body.String())
+}
+
+func TestCoverHandler_GetCoverByBookID_Errors(t *testing.T) {
+	ctx := context.Background()
+	handler := getCoverHandler()
+
+	mockService := NewMockCoverService(t)
+	mockService.EXPECT().GetCoverByBookID(ctx, int64(1), cover.PlaceholderFormat("")).
+		Return(cover.CoverFile{}, cover.ErrNotFound).Once()
+	injectCoverMocks(handler, mockService)
+
+	request := httptest.NewRequest("GET", "/v1/books/1/cover", nil)
+	request.SetPathValue("bookID", "1")
+	err := handler.GetCoverByBookID(ctx, httptest.NewRecorder(), request)
+	assert.ErrorIs(t, err, apiErrors.ErrNotFound, "should not found cover")
+
+	request = httptest.NewRequest("GET", "/v1/books/1/cover?fallback=something", nil)
+	request.SetPathValue("bookID", "1")
+	err = handler.GetCoverByBookID(ctx, httptest.NewRecorder(), request)
+	assert.ErrorAs(t, err, &apiErrors.ValidationError{}, "should get a validation error")
+
+	request = httptest.NewRequest("GET", "/v1/books/abc/cover", nil)
+	request.SetPathValue("bookID", "abc")
+	err = handler.GetCoverByBookID(ctx, httptest.NewRecorder(), request)
+	assert.ErrorAs(t, err, &apiErrors.ValidationError{}, "should get a validation error")
+}
@@ -0,0 +1,63 @@
+package v1
+
+import (
+	"context"
+	"github.com/jmoiron/sqlx"
+	apiErrors "github.com/sdreger/lib-manager-go/cmd/api/errors"
+	"github.com/sdreger/lib-manager-go/cmd/api/handlers"
+	"github.com/sdreger/lib-manager-go/internal/domain/duplicate"
+	"github.com/sdreger/lib-manager-go/internal/paging"
+	"github.com/sdreger/lib-manager-go/internal/response"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+const (
+	queryParamMinScore = "min_score"
+)
+
+type DuplicateService interface {
+	GetDuplicates(ctx context.Context, pageRequest paging.PageRequest, minScore float64) (
+		paging.Page[duplicate.Cluster], error)
+}
+
+type DuplicateController struct {
+	logger           *slog.Logger
+	duplicateService DuplicateService
+}
+
+func NewDuplicateController(logger *slog.Logger, db *sqlx.DB) *DuplicateController {
+	return &DuplicateController{logger: logger, duplicateService: duplicate.NewService(logger, db)}
+}
+
+func (cnt *DuplicateController) RegisterRoutes(registrar handlers.RouteRegistrar) {
+	registrar.RegisterRoute(http.MethodGet, group, "/duplicates", cnt.GetDuplicates)
+}
+
+// GetDuplicates - returns a requested page of the likely duplicate book clusters, from the most certain one
+func (cnt *DuplicateController) GetDuplicates(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	page, pageErr := paging.NewPageRequest(r.URL.Query())
+	if pageErr != nil {
+		return pageErr
+	}
+
+	minScore := duplicate.DefaultMinScore
+	if minScoreString := r.URL.Query().Get(queryParamMinScore); minScoreString != "" {
+		value, err := strconv.ParseFloat(minScoreString, 64)
+		if err != nil || value < 0 || value > 1 {
+			return apiErrors.ValidationError{
+				Field:   queryParamMinScore,
+				Message: "min_score should be a number from 0 to 1: " + minScoreString,
+			}
+		}
+		minScore = value
+	}
+
+	clusterPage, err := cnt.duplicateService.GetDuplicates(ctx, page, minScore)
+	if err != nil {
+		return err
+	}
+
+	return response.RenderDataJSON(w, http.StatusOK, clusterPage)
+}
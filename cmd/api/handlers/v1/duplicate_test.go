@@ -0,0 +1,108 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	apiErrors "github.com/sdreger/lib-manager-go/cmd/api/errors"
+	"github.com/sdreger/lib-manager-go/cmd/api/handlers"
+	"github.com/sdreger/lib-manager-go/internal/domain/duplicate"
+	"github.com/sdreger/lib-manager-go/internal/paging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestDuplicateController_RegisterRoutes(t *testing.T) {
+	testRegistrar := handlers.RouteRegistrarMock{}
+	cnt := getDuplicateController()
+	cnt.RegisterRoutes(&testRegistrar)
+
+	assert.True(t, testRegistrar.IsRouteRegistered("GET /v1/duplicates", cnt.GetDuplicates))
+}
+
+func TestDuplicateController_GetDuplicates(t *testing.T) {
+	ctx := context.Background()
+	controller := getDuplicateController()
+
+	values := map[string][]string{"page": {"1"}, "size": {"10"}}
+	pageRequest, _ := paging.NewPageRequest(values)
+	cluster := duplicate.Cluster{
+		Score:   1,
+		Reasons: []string{duplicate.ReasonIdentifier},
+		Books:   []duplicate.Candidate{{ID: 1, Title: bookTitle}, {ID: 2, Title: bookTitle}},
+	}
+	page := paging.NewPage(pageRequest, 1, []duplicate.Cluster{cluster})
+
+	mockService := NewMockDuplicateService(t)
+	mockService.EXPECT().GetDuplicates(ctx, pageRequest, 0.9).Return(page, nil).Once()
+	controller.duplicateService = mockService
+
+	request := httptest.NewRequest("GET", "/v1/duplicates?page=1&size=10&min_score=0.9", nil)
+	recorder := httptest.NewRecorder()
+	err := controller.GetDuplicates(ctx, recorder, request)
+	require.NoError(t, err, "should get a page of duplicates")
+
+	result := recorder.Result()
+	defer result.Body.Close()
+	require.Equal(t, http.StatusOK, result.StatusCode, "should get a 200 OK response")
+
+	data, err := io.ReadAll(result.Body)
+	require.NoError(t, err, "should read body")
+	var pageJSON map[string]paging.Page[duplicate.Cluster]
+	_ = json.Unmarshal(data, &pageJSON)
+	assert.Equal(t, page, pageJSON["data"], "body should match")
+}
+
+func TestDuplicateController_GetDuplicates_DefaultMinScore(t *testing.T) {
+	ctx := context.Background()
+	controller := getDuplicateController()
+
+	mockService := NewMockDuplicateService(t)
+	mockService.EXPECT().GetDuplicates(ctx, mock.Anything, duplicate.DefaultMinScore).
+		Return(paging.Page[duplicate.Cluster]{}, nil).Once()
+	controller.duplicateService = mockService
+
+	request := httptest.NewRequest("GET", "/v1/duplicates", nil)
+	err := controller.GetDuplicates(ctx, httptest.NewRecorder(), request)
+	require.NoError(t, err, "should get a page of duplicates")
+}
+
+func TestDuplicateController_GetDuplicates_InvalidMinScore(t *testing.T) {
+	ctx := context.Background()
+	controller := getDuplicateController()
+
+	for _, minScore := range []string{"abc", "-0.1", "1.5"} {
+		request := httptest.NewRequest("GET", "/v1/duplicates?min_score="+minScore, nil)
+		err := controller.GetDuplicates(ctx, httptest.NewRecorder(), request)
+		var validationErr apiErrors.ValidationError
+		require.ErrorAs(t, err, &validationErr, minScore)
+		assert.Equal(t, "min_score", validationErr.Field)
+	}
+}
+
+func TestDuplicateController_GetDuplicates_ServiceError(t *testing.T) {
+	ctx := context.Background()
+	controller := getDuplicateController()
+
+	serviceErr := errors.New("some error")
+	mockService := NewMockDuplicateService(t)
+	mockService.EXPECT().GetDuplicates(ctx, mock.Anything, mock.Anything).
+		Return(paging.Page[duplicate.Cluster]{}, serviceErr).Once()
+	controller.duplicateService = mockService
+
+	request := httptest.NewRequest("GET", "/v1/duplicates", nil)
+	err := controller.GetDuplicates(ctx, httptest.NewRecorder(), request)
+	require.ErrorIs(t, err, serviceErr)
+}
+
+func getDuplicateController() *DuplicateController {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	return NewDuplicateController(logger, nil)
+}
@@ -0,0 +1,83 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	apiErrors "github.com/sdreger/lib-manager-go/cmd/api/errors"
+	"github.com/sdreger/lib-manager-go/cmd/api/handlers"
+	"github.com/sdreger/lib-manager-go/internal/config"
+	"github.com/sdreger/lib-manager-go/internal/domain/book"
+	"github.com/sdreger/lib-manager-go/internal/metadata"
+	"github.com/sdreger/lib-manager-go/internal/response"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+type EnrichService interface {
+	Enrich(ctx context.Context, bookID int64, accept []string) (metadata.Enrichment, error)
+}
+
+// EnrichRequest - the book fields to apply, the empty list only previews the diff
+type EnrichRequest struct {
+	Accept []string `json:"accept"`
+}
+
+type EnrichController struct {
+	logger        *slog.Logger
+	enrichService EnrichService
+}
+
+func NewEnrichController(logger *slog.Logger, db *sqlx.DB, metadataConfig config.MetadataConfig) *EnrichController {
+	return &EnrichController{logger: logger, enrichService: metadata.NewService(logger, db, metadataConfig)}
+}
+
+func (cnt *EnrichController) RegisterRoutes(registrar handlers.RouteRegistrar) {
+	registrar.RegisterRoute(http.MethodPost, group, "/books/{bookID}/enrich", cnt.EnrichBook)
+}
+
+// EnrichBook - looks the book up in the metadata provider, and returns the field-by-field diff.
+// Only the accepted fields are applied to the book
+func (cnt *EnrichController) EnrichBook(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	idInt, err := strconv.Atoi(r.PathValue("bookID"))
+	if err != nil {
+		return apiErrors.ValidationError{
+			Field:   "bookID",
+			Message: "the provided bookID should be a number",
+		}
+	}
+
+	// the body is optional, the missing one previews the diff
+	var request EnrichRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&request); err != nil && !errors.Is(err, io.EOF) {
+		return apiErrors.ValidationError{
+			Field:   "body",
+			Message: "the request body should be a JSON object: " + err.Error(),
+		}
+	}
+
+	enrichment, err := cnt.enrichService.Enrich(ctx, int64(idInt), request.Accept)
+	switch {
+	case errors.Is(err, metadata.ErrUnknownField):
+		return apiErrors.ValidationError{
+			Field:   "accept",
+			Message: err.Error(),
+		}
+	case errors.Is(err, book.ErrNotFound):
+		return apiErrors.ErrNotFound
+	case errors.Is(err, metadata.ErrNotFound):
+		return fmt.Errorf("%w: %w", apiErrors.ErrNotFound, err)
+	case errors.Is(err, metadata.ErrProviderDisabled), errors.Is(err, metadata.ErrProviderFailure):
+		return fmt.Errorf("%w: %w", apiErrors.ErrUnavailable, err)
+	case err != nil:
+		return err
+	}
+
+	return response.RenderDataJSON(w, http.StatusOK, enrichment)
+}
@@ -0,0 +1,139 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	apiErrors "github.com/sdreger/lib-manager-go/cmd/api/errors"
+	"github.com/sdreger/lib-manager-go/cmd/api/handlers"
+	"github.com/sdreger/lib-manager-go/internal/config"
+	"github.com/sdreger/lib-manager-go/internal/domain/book"
+	"github.com/sdreger/lib-manager-go/internal/metadata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestEnrichController_RegisterRoutes(t *testing.T) {
+	testRegistrar := handlers.RouteRegistrarMock{}
+	cnt := getEnrichController()
+	cnt.RegisterRoutes(&testRegistrar)
+
+	assert.True(t, testRegistrar.IsRouteRegistered("POST /v1/books/{bookID}/enrich", cnt.EnrichBook))
+}
+
+func TestEnrichController_EnrichBook(t *testing.T) {
+	ctx := context.Background()
+	controller := getEnrichController()
+	enrichment := metadata.Enrichment{
+		Provider: "http://catalog.local",
+		Record:   metadata.Record{Title: bookTitle, Pages: 264},
+		Changes:  []metadata.Change{{Field: metadata.FieldPages, Current: 250.0, Proposed: 264.0, Applied: true}},
+		Book:     getTestBook(),
+	}
+
+	mockService := NewMockEnrichService(t)
+	mockService.EXPECT().Enrich(ctx, bookID, []string{metadata.FieldPages}).Return(enrichment, nil).Once()
+	controller.enrichService = mockService
+
+	request := httptest.NewRequest("POST", "/v1/books/1/enrich", strings.NewReader(`{"accept": ["pages"]}`))
+	request.SetPathValue("bookID", "1")
+	recorder := httptest.NewRecorder()
+	err := controller.EnrichBook(ctx, recorder, request)
+	require.NoError(t, err, "should enrich book")
+
+	result := recorder.Result()
+	defer result.Body.Close()
+	require.Equal(t, http.StatusOK, result.StatusCode, "should get a 200 OK response")
+
+	data, err := io.ReadAll(result.Body)
+	require.NoError(t, err, "should read body")
+	var enrichmentJSON map[string]metadata.Enrichment
+	_ = json.Unmarshal(data, &enrichmentJSON)
+	assert.Equal(t, enrichment, enrichmentJSON["data"], "body should match")
+}
+
+func TestEnrichController_EnrichBook_Preview(t *testing.T) {
+	ctx := context.Background()
+	controller := getEnrichController()
+
+	mockService := NewMockEnrichService(t)
+	mockService.EXPECT().Enrich(ctx, bookID, []string(nil)).Return(metadata.Enrichment{}, nil).Once()
+	controller.enrichService = mockService
+
+	request := httptest.NewRequest("POST", "/v1/books/1/enrich", nil)
+	request.SetPathValue("bookID", "1")
+	err := controller.EnrichBook(ctx, httptest.NewRecorder(), request)
+	require.NoError(t, err, "the empty body should preview the diff")
+}
+
+func TestEnrichController_EnrichBook_Errors(t *testing.T) {
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		serviceErr error
+		check      func(t *testing.T, err error)
+	}{
+		"unknown field": {metadata.ErrUnknownField, func(t *testing.T, err error) {
+			var validationError apiErrors.ValidationError
+			require.ErrorAs(t, err, &validationError)
+			assert.Equal(t, "accept", validationError.Field)
+		}},
+		"book not found": {book.ErrNotFound, func(t *testing.T, err error) {
+			assert.ErrorIs(t, err, apiErrors.ErrNotFound)
+		}},
+		"metadata not found": {metadata.ErrNotFound, func(t *testing.T, err error) {
+			assert.ErrorIs(t, err, apiErrors.ErrNotFound)
+		}},
+		"provider disabled": {metadata.ErrProviderDisabled, func(t *testing.T, err error) {
+			assert.ErrorIs(t, err, apiErrors.ErrUnavailable)
+		}},
+		"provider failure": {errors.Join(metadata.ErrProviderFailure, errors.New("timeout")),
+			func(t *testing.T, err error) {
+				assert.ErrorIs(t, err, apiErrors.ErrUnavailable)
+			}},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			controller := getEnrichController()
+			mockService := NewMockEnrichService(t)
+			mockService.EXPECT().Enrich(ctx, bookID, []string(nil)).
+				Return(metadata.Enrichment{}, test.serviceErr).Once()
+			controller.enrichService = mockService
+
+			request := httptest.NewRequest("POST", "/v1/books/1/enrich", strings.NewReader(`{}`))
+			request.SetPathValue("bookID", "1")
+			test.check(t, controller.EnrichBook(ctx, httptest.NewRecorder(), request))
+		})
+	}
+}
+
+func TestEnrichController_EnrichBook_InvalidRequest(t *testing.T) {
+	ctx := context.Background()
+	controller := getEnrichController()
+	controller.enrichService = NewMockEnrichService(t)
+
+	request := httptest.NewRequest("POST", "/v1/books/abc/enrich", nil)
+	request.SetPathValue("bookID", "abc")
+	err := controller.EnrichBook(ctx, httptest.NewRecorder(), request)
+	var validationError apiErrors.ValidationError
+	require.ErrorAs(t, err, &validationError)
+	assert.Equal(t, "bookID", validationError.Field)
+
+	request = httptest.NewRequest("POST", "/v1/books/1/enrich", strings.NewReader(`{"fields": ["pages"]}`))
+	request.SetPathValue("bookID", "1")
+	err = controller.EnrichBook(ctx, httptest.NewRecorder(), request)
+	require.ErrorAs(t, err, &validationError)
+	assert.Equal(t, "body", validationError.Field)
+}
+
+func getEnrichController() *EnrichController {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	return NewEnrichController(logger, nil, config.MetadataConfig{})
+}
@@ -0,0 +1,151 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	apiErrors "github.com/sdreger/lib-manager-go/cmd/api/errors"
+	"github.com/sdreger/lib-manager-go/cmd/api/handlers"
+	"github.com/sdreger/lib-manager-go/internal/config"
+	"github.com/sdreger/lib-manager-go/internal/event"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	lastEventIDHeader = "Last-Event-ID"
+	replayBatchSize   = 500
+	// eventsRetryInterval - the reconnection delay, the clients are advised to wait after the stream is closed
+	eventsRetryInterval = 3 * time.Second
+)
+
+type EventService interface {
+	Subscribe() (<-chan event.Event, func())
+	Replay(ctx context.Context, afterID int64, limit uint64) ([]event.Event, error)
+}
+
+type EventController struct {
+	logger            *slog.Logger
+	eventService      EventService
+	heartbeatInterval time.Duration
+}
+
+func NewEventController(logger *slog.Logger, broker *event.Broker, eventsConfig config.EventsConfig) *EventController {
+	return &EventController{logger: logger, eventService: broker, heartbeatInterval: eventsConfig.HeartbeatInterval}
+}
+
+func (cnt *EventController) RegisterRoutes(registrar handlers.RouteRegistrar) {
+	registrar.RegisterRoute(http.MethodGet, group, "/events", cnt.StreamEvents)
+}
+
+// StreamEvents - streams the catalog change events as the Server-Sent Events, until the client disconnects.
+// The client, reconnecting with the 'Last-Event-ID' header, first receives the events it has missed
+func (cnt *EventController) StreamEvents(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	lastEventID, replay, err := parseLastEventID(r)
+	if err != nil {
+		return err
+	}
+
+	// subscribed before the replay, so no event is missed in between, the replayed ones are skipped later
+	events, unsubscribe := cnt.eventService.Subscribe()
+	defer unsubscribe()
+
+	// the events are not in the ID order, so the replayed ones are skipped by their IDs
+	var missed []event.Event
+	replayed := make(map[int64]bool)
+	for replay {
+		batch, err := cnt.eventService.Replay(ctx, lastEventID, replayBatchSize)
+		if err != nil {
+			return err
+		}
+		missed = append(missed, batch...)
+		replay = len(batch) == replayBatchSize
+		for _, missedEvent := range batch {
+			replayed[missedEvent.ID] = true
+			lastEventID = missedEvent.ID
+		}
+	}
+
+	// the stream outlives the server write timeout
+	controller := http.NewResponseController(w)
+	if err := controller.SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	// the reverse proxies should not buffer the stream
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	// the write errors mean the client is gone, so the stream is just over
+	if _, err := fmt.Fprintf(w, "retry: %d\n\n", eventsRetryInterval.Milliseconds()); err != nil {
+		return nil
+	}
+	for _, missedEvent := range missed {
+		if err := writeEvent(w, missedEvent); err != nil {
+			return nil
+		}
+	}
+	if err := controller.Flush(); err != nil {
+		return nil
+	}
+
+	heartbeat := time.NewTicker(cnt.heartbeatInterval)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case nextEvent, ok := <-events:
+			if !ok {
+				// the server is shutting down, or the client has fallen behind, it resumes after reconnecting
+				return nil
+			}
+			if replayed[nextEvent.ID] {
+				continue
+			}
+			if err := writeEvent(w, nextEvent); err != nil {
+				return nil
+			}
+		case <-heartbeat.C:
+			if _, err := io.WriteString(w, ": heartbeat\n\n"); err != nil {
+				return nil
+			}
+		}
+		if err := controller.Flush(); err != nil {
+			return nil
+		}
+	}
+}
+
+// parseLastEventID - returns the ID of the last event received by the client, and whether to replay the later ones
+func parseLastEventID(r *http.Request) (int64, bool, error) {
+	value := r.Header.Get(lastEventIDHeader)
+	if value == "" {
+		return 0, false, nil
+	}
+
+	lastEventID, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || lastEventID < 0 {
+		return 0, false, apiErrors.ValidationError{
+			Field:   lastEventIDHeader,
+			Message: "the provided Last-Event-ID should be a non-negative number",
+		}
+	}
+
+	return lastEventID, true, nil
+}
+
+func writeEvent(w io.Writer, changeEvent event.Event) error {
+	data, err := json.Marshal(changeEvent)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", changeEvent.ID, changeEvent.Type, data)
+
+	return err
+}
@@ -0,0 +1,138 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	apiErrors "github.com/sdreger/lib-manager-go/cmd/api/errors"
+	"github.com/sdreger/lib-manager-go/cmd/api/handlers"
+	"github.com/sdreger/lib-manager-go/internal/config"
+	"github.com/sdreger/lib-manager-go/internal/event"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEventController_RegisterRoutes(t *testing.T) {
+	testRegistrar := handlers.RouteRegistrarMock{}
+	cnt := getEventController()
+	cnt.RegisterRoutes(&testRegistrar)
+
+	assert.True(t, testRegistrar.IsRouteRegistered("GET /v1/events", cnt.StreamEvents))
+}
+
+func TestEventController_StreamEvents(t *testing.T) {
+	ctx := context.Background()
+	controller := getEventController()
+	createdAt := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+
+	events := make(chan event.Event, 2)
+	events <- event.Event{ID: 7, Type: event.TypeBookCreated, BookID: 3, CreatedAt: createdAt}
+	events <- event.Event{ID: 8, Type: event.TypeCoverUpdated, BookID: 3, CreatedAt: createdAt}
+	close(events)
+	unsubscribed := false
+	mockService := NewMockEventService(t)
+	mockService.EXPECT().Subscribe().Return(events, func() { unsubscribed = true }).Once()
+	controller.eventService = mockService
+
+	recorder := httptest.NewRecorder()
+	err := controller.StreamEvents(ctx, recorder, httptest.NewRequest("GET", "/v1/events", nil))
+	require.NoError(t, err, "should stream the events")
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "text/event-stream", recorder.Header().Get("Content-Type"))
+	assert.Equal(t, "no-cache", recorder.Header().Get("Cache-Control"))
+	assert.True(t, recorder.Flushed, "the events should be flushed")
+	assert.Equal(t, "retry: 3000\n\n"+
+		"id: 7\nevent: book.created\n"+
+		`data: {"id":7,"type":"book.created","book_id":3,"created_at":"2026-10-19T09:00:00Z"}`+"\n\n"+
+		"id: 8\nevent: cover.updated\n"+
+		`data: {"id":8,"type":"cover.updated","book_id":3,"created_at":"2026-10-19T09:00:00Z"}`+"\n\n",
+		recorder.Body.String())
+	assert.True(t, unsubscribed, "should unsubscribe, once the stream is over")
+}
+
+func TestEventController_StreamEvents_Replay(t *testing.T) {
+	ctx := context.Background()
+	controller := getEventController()
+	replayed := make([]event.Event, replayBatchSize)
+	for i := range replayed {
+		replayed[i] = event.Event{ID: int64(i + 11), Type: event.TypeBookUpdated, BookID: 1}
+	}
+
+	events := make(chan event.Event, 3)
+	// the event published during the replay is not repeated
+	events <- replayed[len(replayed)-1]
+	events <- event.Event{ID: 600, Type: event.TypeBookDeleted, BookID: 1}
+	// the event of a concurrent transaction, committed later, may have a lower ID
+	events <- event.Event{ID: 7, Type: event.TypeBookCreated, BookID: 2}
+	close(events)
+	mockService := NewMockEventService(t)
+	mockService.EXPECT().Subscribe().Return(events, func() {}).Once()
+	mockService.EXPECT().Replay(ctx, int64(10), uint64(replayBatchSize)).Return(replayed, nil).Once()
+	mockService.EXPECT().Replay(ctx, int64(510), uint64(replayBatchSize)).Return([]event.Event{}, nil).Once()
+	controller.eventService = mockService
+
+	request := httptest.NewRequest("GET", "/v1/events", nil)
+	request.Header.Set("Last-Event-ID", "10")
+	recorder := httptest.NewRecorder()
+	err := controller.StreamEvents(ctx, recorder, request)
+	require.NoError(t, err, "should stream the events")
+
+	body := recorder.Body.String()
+	assert.Equal(t, replayBatchSize+2, strings.Count(body, "\nevent: "), "should stream the missed events once")
+	assert.True(t, strings.HasPrefix(body, "retry: 3000\n\nid: 11\n"), "should start with the first missed event")
+	assert.Contains(t, body, "id: 600\nevent: book.deleted\n")
+	assert.Contains(t, body, "id: 7\nevent: book.created\n")
+}
+
+func TestEventController_StreamEvents_Heartbeat(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	controller := NewEventController(slog.New(slog.NewJSONHandler(os.Stdout, nil)), nil,
+		config.EventsConfig{HeartbeatInterval: 10 * time.Millisecond})
+
+	mockService := NewMockEventService(t)
+	mockService.EXPECT().Subscribe().Return(make(chan event.Event), func() {}).Once()
+	controller.eventService = mockService
+
+	recorder := httptest.NewRecorder()
+	err := controller.StreamEvents(ctx, recorder, httptest.NewRequest("GET", "/v1/events", nil))
+	require.NoError(t, err, "the client disconnect should end the stream")
+	assert.Contains(t, recorder.Body.String(), ": heartbeat\n\n")
+}
+
+func TestEventController_StreamEvents_Errors(t *testing.T) {
+	ctx := context.Background()
+	controller := getEventController()
+	replayError := errors.New("replay error")
+
+	mockService := NewMockEventService(t)
+	controller.eventService = mockService
+
+	request := httptest.NewRequest("GET", "/v1/events", nil)
+	request.Header.Set("Last-Event-ID", "abc")
+	err := controller.StreamEvents(ctx, httptest.NewRecorder(), request)
+	var validationError apiErrors.ValidationError
+	require.ErrorAs(t, err, &validationError)
+	assert.Equal(t, "Last-Event-ID", validationError.Field)
+
+	mockService.EXPECT().Subscribe().Return(make(chan event.Event), func() {}).Once()
+	mockService.EXPECT().Replay(ctx, int64(1), mock.Anything).Return(nil, replayError).Once()
+	request.Header.Set("Last-Event-ID", "1")
+	recorder := httptest.NewRecorder()
+	err = controller.StreamEvents(ctx, recorder, request)
+	assert.ErrorIs(t, err, replayError)
+	assert.Empty(t, recorder.Body.String(), "the stream should not be started")
+}
+
+func getEventController() *EventController {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	return NewEventController(logger, nil, config.EventsConfig{HeartbeatInterval: time.Minute})
+}
@@ -0,0 +1,111 @@
+package v1
+
+import (
+	"context"
+	"github.com/jmoiron/sqlx"
+	apiErrors "github.com/sdreger/lib-manager-go/cmd/api/errors"
+	"github.com/sdreger/lib-manager-go/cmd/api/handlers"
+	"github.com/sdreger/lib-manager-go/internal/domain/book"
+	"github.com/sdreger/lib-manager-go/internal/exporter"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// exportFileTimeLayout - the export file name timestamp layout, without characters unsafe for file names
+const exportFileTimeLayout = "20060102-150405"
+
+type ExportService interface {
+	ExportBooks(ctx context.Context, filter book.Filter, fn func(book book.Book) error) error
+}
+
+type ExportController struct {
+	logger      *slog.Logger
+	bookService ExportService
+}
+
+func NewExportController(logger *slog.Logger, db *sqlx.DB) *ExportController {
+	return &ExportController{logger: logger, bookService: book.NewService(logger, db)}
+}
+
+func (cnt *ExportController) RegisterRoutes(registrar handlers.RouteRegistrar) {
+	registrar.RegisterRoute(http.MethodGet, group, "/export", cnt.Export)
+}
+
+// Export - streams all the books, matching the book filter, as a CSV or NDJSON file download
+func (cnt *ExportController) Export(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	formatName := strings.ToLower(r.URL.Query().Get(queryParamFormat))
+	if formatName == "" {
+		formatName = exporter.FormatCSV
+	}
+	format, ok := exporter.Formats[formatName]
+	if !ok {
+		return apiErrors.ValidationError{
+			Field:   queryParamFormat,
+			Message: exporter.ErrUnsupportedFormat.Error(),
+		}
+	}
+
+	filter, err := book.NewFilter(r.URL.Query())
+	if err != nil {
+		return err
+	}
+
+	// the whole library download outlives the server write timeout
+	if err := handlers.ClearWriteDeadline(w); err != nil {
+		return err
+	}
+
+	stream := func(fn func(book.Book) error) error {
+		return cnt.bookService.ExportBooks(ctx, filter, fn)
+	}
+	return streamBooks(cnt.logger, stream, func() (bookWriter, error) {
+		setAttachment(w, format.ContentType, "books", format.Extension)
+		return exporter.NewWriter(formatName, w)
+	})
+}
+
+// bookWriter - writes the streamed books in a specific format
+type bookWriter interface {
+	Write(book book.Book) error
+	Flush() error
+}
+
+// streamBooks - streams the books to the writer. The writer is only started with the first book,
+// so the errors before it are reported as usual, and the later ones abort the response,
+// to not let a truncated output look like a complete one
+func streamBooks(logger *slog.Logger, stream func(fn func(book.Book) error) error,
+	start func() (bookWriter, error)) error {
+
+	var writer bookWriter
+	err := stream(func(streamed book.Book) error {
+		if writer == nil {
+			var err error
+			if writer, err = start(); err != nil {
+				return err
+			}
+		}
+		return writer.Write(streamed)
+	})
+	if err == nil && writer == nil {
+		writer, err = start()
+	}
+	if err == nil {
+		err = writer.Flush()
+	}
+	if err != nil && writer != nil {
+		logger.Error("book streaming aborted: " + err.Error())
+		panic(http.ErrAbortHandler)
+	}
+
+	return err
+}
+
+// setAttachment - sets the download headers, the file name is timestamped, e.g. 'books-20240102-030405.csv'
+func setAttachment(w http.ResponseWriter, contentType string, baseName string, extension string) {
+	fileName := baseName + "-" + time.Now().UTC().Format(exportFileTimeLayout) + "." + extension
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": fileName}))
+}
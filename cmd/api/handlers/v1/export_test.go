@@ -0,0 +1,149 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	apiErrors "github.com/sdreger/lib-manager-go/cmd/api/errors"
+	"github.com/sdreger/lib-manager-go/cmd/api/handlers"
+	"github.com/sdreger/lib-manager-go/internal/domain/book"
+	"github.com/sdreger/lib-manager-go/internal/exporter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestExportController_RegisterRoutes(t *testing.T) {
+	testRegistrar := handlers.RouteRegistrarMock{}
+	cnt := getExportController()
+	cnt.RegisterRoutes(&testRegistrar)
+
+	assert.True(t, testRegistrar.IsRouteRegistered("GET /v1/export", cnt.Export))
+}
+
+func TestExportController_Export(t *testing.T) {
+	ctx := context.Background()
+	controller := getExportController()
+	request := httptest.NewRequest(http.MethodGet, "/v1/export?format=NDJSON&author=1&tag=2", nil)
+	filter := getExportFilter(t, request)
+	assert.Equal(t, []int64{1}, filter.Authors)
+
+	mockService := NewMockExportService(t)
+	mockService.EXPECT().ExportBooks(ctx, filter, mock.Anything).
+		RunAndReturn(func(ctx context.Context, filter book.Filter, fn func(book.Book) error) error {
+			if err := fn(book.Book{ID: bookID, Title: bookTitle}); err != nil {
+				return err
+			}
+			return fn(book.Book{ID: 2, Title: "Book 02"})
+		}).Once()
+	controller.bookService = mockService
+
+	recorder := httptest.NewRecorder()
+	err := controller.Export(ctx, recorder, request)
+	require.NoError(t, err, "should export books")
+
+	result := recorder.Result()
+	defer result.Body.Close()
+	require.Equal(t, http.StatusOK, result.StatusCode, "should get a 200 OK response")
+	assert.Equal(t, exporter.Formats[exporter.FormatNDJSON].ContentType, result.Header.Get("Content-Type"))
+	disposition, params, err := mime.ParseMediaType(result.Header.Get("Content-Disposition"))
+	require.NoError(t, err, "should set a valid Content-Disposition header")
+	assert.Equal(t, "attachment", disposition)
+	assert.True(t, strings.HasPrefix(params["filename"], "books-"))
+	assert.True(t, strings.HasSuffix(params["filename"], ".ndjson"))
+
+	data, err := io.ReadAll(result.Body)
+	require.NoError(t, err, "should read body")
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	require.Len(t, lines, 2, "should write a line per book")
+	assert.Contains(t, lines[0], `"title":"`+bookTitle+`"`)
+}
+
+func TestExportController_Export_NoBooks(t *testing.T) {
+	ctx := context.Background()
+	controller := getExportController()
+	request := httptest.NewRequest(http.MethodGet, "/v1/export", nil)
+
+	mockService := NewMockExportService(t)
+	mockService.EXPECT().ExportBooks(ctx, getExportFilter(t, request), mock.Anything).Return(nil).Once()
+	controller.bookService = mockService
+
+	recorder := httptest.NewRecorder()
+	err := controller.Export(ctx, recorder, request)
+	require.NoError(t, err, "should export an empty catalog")
+
+	assert.Equal(t, exporter.Formats[exporter.FormatCSV].ContentType, recorder.Header().Get("Content-Type"),
+		"the CSV format should be the default one")
+	assert.Equal(t, strings.Join(exporter.Columns, ",")+"\n", recorder.Body.String())
+}
+
+func TestExportController_Export_InvalidFormat(t *testing.T) {
+	controller := getExportController()
+	request := httptest.NewRequest(http.MethodGet, "/v1/export?format=xml", nil)
+	err := controller.Export(context.Background(), httptest.NewRecorder(), request)
+
+	var validationError apiErrors.ValidationError
+	require.ErrorAs(t, err, &validationError)
+	assert.Equal(t, queryParamFormat, validationError.Field)
+}
+
+func TestExportController_Export_InvalidFilter(t *testing.T) {
+	controller := getExportController()
+	request := httptest.NewRequest(http.MethodGet, "/v1/export?author=abc", nil)
+	err := controller.Export(context.Background(), httptest.NewRecorder(), request)
+	require.Error(t, err, "should validate the filter")
+}
+
+func TestExportController_Export_Failure(t *testing.T) {
+	ctx := context.Background()
+	controller := getExportController()
+	request := httptest.NewRequest(http.MethodGet, "/v1/export", nil)
+	expectedError := errors.New("some error")
+
+	mockService := NewMockExportService(t)
+	mockService.EXPECT().ExportBooks(ctx, getExportFilter(t, request), mock.Anything).Return(expectedError).Once()
+	controller.bookService = mockService
+
+	recorder := httptest.NewRecorder()
+	err := controller.Export(ctx, recorder, request)
+	require.ErrorIs(t, err, expectedError, "the error before the first book should be returned")
+	assert.Empty(t, recorder.Header().Get("Content-Disposition"))
+}
+
+func TestExportController_Export_FailureMidStream(t *testing.T) {
+	ctx := context.Background()
+	controller := getExportController()
+	request := httptest.NewRequest(http.MethodGet, "/v1/export", nil)
+
+	mockService := NewMockExportService(t)
+	mockService.EXPECT().ExportBooks(ctx, getExportFilter(t, request), mock.Anything).
+		RunAndReturn(func(ctx context.Context, filter book.Filter, fn func(book.Book) error) error {
+			if err := fn(book.Book{ID: bookID, Title: bookTitle}); err != nil {
+				return err
+			}
+			return errors.New("connection lost")
+		}).Once()
+	controller.bookService = mockService
+
+	require.PanicsWithValue(t, http.ErrAbortHandler, func() {
+		_ = controller.Export(ctx, httptest.NewRecorder(), request)
+	}, "the started response should be aborted")
+}
+
+func getExportController() *ExportController {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.Level(100)}))
+	return NewExportController(logger, nil)
+}
+
+func getExportFilter(t *testing.T, request *http.Request) book.Filter {
+	filter, err := book.NewFilter(request.URL.Query())
+	require.NoError(t, err, "failed to build filter")
+	return filter
+}
@@ -0,0 +1,137 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"github.com/jmoiron/sqlx"
+	apiErrors "github.com/sdreger/lib-manager-go/cmd/api/errors"
+	"github.com/sdreger/lib-manager-go/cmd/api/handlers"
+	"github.com/sdreger/lib-manager-go/internal/importer"
+	"github.com/sdreger/lib-manager-go/internal/response"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	importFileField = "file"
+
+	queryParamFormat    = "format"
+	queryParamDryRun    = "dry_run"
+	queryParamBatchSize = "batch_size"
+	queryParamMap       = "map"
+)
+
+type ImportService interface {
+	Import(ctx context.Context, input io.Reader, options importer.Options) (importer.Report, error)
+}
+
+type ImportController struct {
+	logger        *slog.Logger
+	importService ImportService
+}
+
+func NewImportController(logger *slog.Logger, db *sqlx.DB) *ImportController {
+	return &ImportController{logger: logger, importService: importer.NewService(logger, db)}
+}
+
+func (cnt *ImportController) RegisterRoutes(registrar handlers.RouteRegistrar) {
+	registrar.RegisterRoute(http.MethodPost, group, "/import", cnt.Import)
+}
+
+// Import - imports the books from the uploaded CSV or NDJSON file, and returns the per-row report.
+// The format is detected by the file extension, unless it is provided explicitly
+func (cnt *ImportController) Import(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	options, err := newImportOptions(r)
+	if err != nil {
+		return err
+	}
+
+	if err := handlers.ClearReadDeadline(w); err != nil {
+		return err
+	}
+	if err := handlers.ClearWriteDeadline(w); err != nil {
+		return err
+	}
+	if err := r.ParseMultipartForm(ingestMaxMemory); err != nil {
+		return apiErrors.ValidationError{
+			Field:   importFileField,
+			Message: "the import file should be uploaded as a multipart form: " + err.Error(),
+		}
+	}
+	file, fileHeader, err := r.FormFile(importFileField)
+	if err != nil {
+		return apiErrors.ValidationError{
+			Field:   importFileField,
+			Message: "the import file is required",
+		}
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	if options.Format == "" {
+		options.Format = importer.FormatByFileName(fileHeader.Filename)
+	}
+
+	report, err := cnt.importService.Import(ctx, file, options)
+	switch {
+	case errors.Is(err, importer.ErrInvalidMapping):
+		return apiErrors.ValidationError{
+			Field:   queryParamMap,
+			Message: err.Error(),
+		}
+	case errors.Is(err, importer.ErrUnsupportedFormat):
+		return apiErrors.ValidationError{
+			Field:   queryParamFormat,
+			Message: err.Error(),
+		}
+	case errors.Is(err, importer.ErrInvalidFile):
+		return apiErrors.ValidationError{
+			Field:   importFileField,
+			Message: err.Error(),
+		}
+	case err != nil:
+		return err
+	}
+
+	return response.RenderDataJSON(w, http.StatusOK, report)
+}
+
+func newImportOptions(r *http.Request) (importer.Options, error) {
+	query := r.URL.Query()
+	options := importer.Options{Format: strings.ToLower(query.Get(queryParamFormat))}
+
+	if value := query.Get(queryParamDryRun); value != "" {
+		dryRun, err := strconv.ParseBool(value)
+		if err != nil {
+			return importer.Options{}, apiErrors.ValidationError{
+				Field:   queryParamDryRun,
+				Message: "dry_run should be a boolean: " + value,
+			}
+		}
+		options.DryRun = dryRun
+	}
+	if value := query.Get(queryParamBatchSize); value != "" {
+		batchSize, err := strconv.Atoi(value)
+		if err != nil || batchSize < 1 || batchSize > importer.MaxBatchSize {
+			return importer.Options{}, apiErrors.ValidationError{
+				Field:   queryParamBatchSize,
+				Message: "batch_size should be a number from 1 to " + strconv.Itoa(importer.MaxBatchSize) + ": " + value,
+			}
+		}
+		options.BatchSize = batchSize
+	}
+
+	mapping, err := importer.ParseMapping(query[queryParamMap])
+	if err != nil {
+		return importer.Options{}, apiErrors.ValidationError{
+			Field:   queryParamMap,
+			Message: err.Error(),
+		}
+	}
+	options.Mapping = mapping
+
+	return options, nil
+}
@@ -0,0 +1,135 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	apiErrors "github.com/sdreger/lib-manager-go/cmd/api/errors"
+	"github.com/sdreger/lib-manager-go/cmd/api/handlers"
+	"github.com/sdreger/lib-manager-go/internal/importer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestImportController_RegisterRoutes(t *testing.T) {
+	testRegistrar := handlers.RouteRegistrarMock{}
+	cnt := getImportController()
+	cnt.RegisterRoutes(&testRegistrar)
+
+	assert.True(t, testRegistrar.IsRouteRegistered("POST /v1/import", cnt.Import))
+}
+
+func TestImportController_Import(t *testing.T) {
+	ctx := context.Background()
+	controller := getImportController()
+	report := importer.Report{
+		DryRun:  true,
+		Total:   1,
+		Created: 1,
+		Rows:    []importer.RowResult{{Line: 2, Status: importer.StatusCreated, BookID: 1, Title: bookTitle}},
+	}
+	options := importer.Options{
+		Format:    importer.FormatCSV,
+		DryRun:    true,
+		BatchSize: 50,
+		Mapping:   importer.Mapping{"title": "Book Title", "isbn13": "ISBN"},
+	}
+
+	mockService := NewMockImportService(t)
+	mockService.EXPECT().Import(ctx, mock.Anything, options).Return(report, nil).Once()
+	controller.importService = mockService
+
+	request := newIngestRequest(t, "catalog.CSV", "Book Title,ISBN\n")
+	request.URL.RawQuery = "dry_run=true&batch_size=50&map=title:Book+Title&map=isbn13:ISBN"
+	recorder := httptest.NewRecorder()
+	err := controller.Import(ctx, recorder, request)
+	require.NoError(t, err, "should import books")
+
+	result := recorder.Result()
+	defer result.Body.Close()
+	require.Equal(t, http.StatusOK, result.StatusCode, "should get a 200 OK response")
+
+	data, err := io.ReadAll(result.Body)
+	require.NoError(t, err, "should read body")
+	var reportJSON map[string]importer.Report
+	_ = json.Unmarshal(data, &reportJSON)
+	assert.Equal(t, report, reportJSON["data"], "body should match")
+}
+
+func TestImportController_Import_Format(t *testing.T) {
+	ctx := context.Background()
+	controller := getImportController()
+
+	mockService := NewMockImportService(t)
+	options := importer.Options{Format: importer.FormatNDJSON, Mapping: importer.Mapping{}}
+	mockService.EXPECT().Import(ctx, mock.Anything, options).Return(importer.Report{}, nil).Twice()
+	controller.importService = mockService
+
+	err := controller.Import(ctx, httptest.NewRecorder(), newIngestRequest(t, "catalog.jsonl", "{}"))
+	require.NoError(t, err, "should detect the format by the file extension")
+
+	request := newIngestRequest(t, "catalog.txt", "{}")
+	request.URL.RawQuery = "format=NDJSON"
+	err = controller.Import(ctx, httptest.NewRecorder(), request)
+	require.NoError(t, err, "should use the provided format")
+}
+
+func TestImportController_Import_InvalidOptions(t *testing.T) {
+	ctx := context.Background()
+	controller := getImportController()
+	controller.importService = NewMockImportService(t)
+
+	for query, field := range map[string]string{
+		"dry_run=maybe":   "dry_run",
+		"batch_size=0":    "batch_size",
+		"batch_size=5000": "batch_size",
+		"map=name:Title":  "map",
+	} {
+		request := newIngestRequest(t, "catalog.csv", "title\n")
+		request.URL.RawQuery = query
+		err := controller.Import(ctx, httptest.NewRecorder(), request)
+		var validationError apiErrors.ValidationError
+		require.ErrorAs(t, err, &validationError, query)
+		assert.Equal(t, field, validationError.Field, query)
+	}
+}
+
+func TestImportController_Import_Errors(t *testing.T) {
+	ctx := context.Background()
+	expectedError := errors.New("some error")
+
+	for serviceErr, field := range map[error]string{
+		importer.ErrInvalidMapping:    "map",
+		importer.ErrUnsupportedFormat: "format",
+		importer.ErrInvalidFile:       "file",
+	} {
+		controller := getImportController()
+		mockService := NewMockImportService(t)
+		mockService.EXPECT().Import(ctx, mock.Anything, mock.Anything).Return(importer.Report{}, serviceErr).Once()
+		controller.importService = mockService
+
+		err := controller.Import(ctx, httptest.NewRecorder(), newIngestRequest(t, "catalog.csv", "title\n"))
+		var validationError apiErrors.ValidationError
+		require.ErrorAs(t, err, &validationError, field)
+		assert.Equal(t, field, validationError.Field)
+	}
+
+	controller := getImportController()
+	mockService := NewMockImportService(t)
+	mockService.EXPECT().Import(ctx, mock.Anything, mock.Anything).Return(importer.Report{}, expectedError).Once()
+	controller.importService = mockService
+	err := controller.Import(ctx, httptest.NewRecorder(), newIngestRequest(t, "catalog.csv", "title\n"))
+	require.ErrorIs(t, err, expectedError)
+}
+
+func getImportController() *ImportController {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.Level(100)}))
+	return NewImportController(logger, nil)
+}
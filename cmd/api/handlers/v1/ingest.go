@@ -0,0 +1,95 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"github.com/jmoiron/sqlx"
+	apiErrors "github.com/sdreger/lib-manager-go/cmd/api/errors"
+	"github.com/sdreger/lib-manager-go/cmd/api/handlers"
+	"github.com/sdreger/lib-manager-go/internal/blobtstore"
+	"github.com/sdreger/lib-manager-go/internal/config"
+	"github.com/sdreger/lib-manager-go/internal/ingest"
+	"github.com/sdreger/lib-manager-go/internal/response"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+const (
+	ingestFileField = "file"
+
+	// the uploaded file parts, exceeding the limit, are stored in temporary files
+	ingestMaxMemory = 32 << 20 // 32 MiB
+)
+
+type IngestService interface {
+	Ingest(ctx context.Context, fileName string, content io.ReaderAt, size int64) (ingest.Draft, error)
+}
+
+type IngestController struct {
+	logger        *slog.Logger
+	maxUploadSize int64
+	ingestService IngestService
+}
+
+func NewIngestController(logger *slog.Logger, ingestConfig config.IngestConfig, db *sqlx.DB,
+	blobStore *blobtstore.MinioStore) *IngestController {
+	return &IngestController{
+		logger:        logger,
+		maxUploadSize: ingestConfig.MaxUploadSize,
+		ingestService: ingest.NewService(logger, ingestConfig, db, blobStore),
+	}
+}
+
+func (cnt *IngestController) RegisterRoutes(registrar handlers.RouteRegistrar) {
+	registrar.RegisterRoute(http.MethodPost, group, "/ingest", cnt.Ingest)
+}
+
+// Ingest - extracts the book details from the uploaded book file, and returns the proposed book record for review.
+// The request body is limited by the max upload size
+func (cnt *IngestController) Ingest(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	if err := handlers.ClearReadDeadline(w); err != nil {
+		return err
+	}
+	if err := handlers.ClearWriteDeadline(w); err != nil {
+		return err
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, cnt.maxUploadSize)
+	if err := r.ParseMultipartForm(ingestMaxMemory); err != nil {
+		var maxBytesError *http.MaxBytesError
+		if errors.As(err, &maxBytesError) {
+			return apiErrors.ValidationError{
+				Field:   ingestFileField,
+				Message: "the book file should not exceed " + strconv.FormatInt(maxBytesError.Limit, 10) + " bytes",
+			}
+		}
+		return apiErrors.ValidationError{
+			Field:   ingestFileField,
+			Message: "the book file should be uploaded as a multipart form: " + err.Error(),
+		}
+	}
+	file, fileHeader, err := r.FormFile(ingestFileField)
+	if err != nil {
+		return apiErrors.ValidationError{
+			Field:   ingestFileField,
+			Message: "the book file is required",
+		}
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	draft, err := cnt.ingestService.Ingest(ctx, fileHeader.Filename, file, fileHeader.Size)
+	if errors.Is(err, ingest.ErrUnsupportedFormat) || errors.Is(err, ingest.ErrInvalidFile) {
+		return apiErrors.ValidationError{
+			Field:   ingestFileField,
+			Message: err.Error(),
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	return response.RenderDataJSON(w, http.StatusOK, draft)
+}
@@ -0,0 +1,128 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	apiErrors "github.com/sdreger/lib-manager-go/cmd/api/errors"
+	"github.com/sdreger/lib-manager-go/cmd/api/handlers"
+	"github.com/sdreger/lib-manager-go/internal/config"
+	"github.com/sdreger/lib-manager-go/internal/domain/book"
+	"github.com/sdreger/lib-manager-go/internal/ingest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"io"
+	"log/slog"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestIngestHandler_RegisterIngestHandler(t *testing.T) {
+
+	testRegistrar := handlers.RouteRegistrarMock{}
+	h := getIngestHandler()
+	h.RegisterRoutes(&testRegistrar)
+
+	assert.True(t, testRegistrar.IsRouteRegistered("POST /v1/ingest", h.Ingest))
+}
+
+func TestIngestHandler_Ingest_Success(t *testing.T) {
+	ctx := context.Background()
+	handler := getIngestHandler()
+	draft := ingest.Draft{
+		Format:   ingest.FormatEPUB,
+		Book:     book.Book{Title: "Go in Action", Authors: []string{"William Kennedy"}},
+		Warnings: []string{"the cover is missing"},
+	}
+
+	mockService := NewMockIngestService(t)
+	mockService.EXPECT().Ingest(ctx, "book.epub", mock.Anything, int64(7)).Return(draft, nil)
+	injectIngestMocks(handler, mockService)
+
+	recorder := httptest.NewRecorder()
+	err := handler.Ingest(ctx, recorder, newIngestRequest(t, "book.epub", "content"))
+	require.NoError(t, err, "should ingest a book file")
+
+	result := recorder.Result()
+	defer result.Body.Close()
+	require.Equal(t, http.StatusOK, result.StatusCode, "should get a 200 OK response")
+
+	data, err := io.ReadAll(result.Body)
+	require.NoError(t, err, "should read body")
+	var draftJSON map[string]ingest.Draft
+	_ = json.Unmarshal(data, &draftJSON)
+	assert.Equal(t, draft, draftJSON["data"], "body should match")
+}
+
+func TestIngestHandler_Ingest_NoFile(t *testing.T) {
+	ctx := context.Background()
+	handler := getIngestHandler()
+
+	request := httptest.NewRequest("POST", "/v1/ingest", bytes.NewBufferString("content"))
+	err := handler.Ingest(ctx, httptest.NewRecorder(), request)
+	var validationError apiErrors.ValidationError
+	require.ErrorAs(t, err, &validationError, "should get a validation error")
+	assert.Equal(t, "file", validationError.Field)
+}
+
+func TestIngestHandler_Ingest_TooLarge(t *testing.T) {
+	ctx := context.Background()
+	handler := getIngestHandler() // the service should not be called
+
+	err := handler.Ingest(ctx, httptest.NewRecorder(), newIngestRequest(t, "book.epub", strings.Repeat("a", 2048)))
+	var validationError apiErrors.ValidationError
+	require.ErrorAs(t, err, &validationError, "should get a validation error")
+	assert.Equal(t, "file", validationError.Field)
+	assert.Equal(t, "the book file should not exceed 1024 bytes", validationError.Message)
+}
+
+func TestIngestHandler_Ingest_Errors(t *testing.T) {
+	ctx := context.Background()
+	handler := getIngestHandler()
+	expectedError := errors.New("some error")
+
+	mockService := NewMockIngestService(t)
+	mockService.EXPECT().Ingest(ctx, "book.txt", mock.Anything, int64(7)).
+		Return(ingest.Draft{}, ingest.ErrUnsupportedFormat).Once()
+	mockService.EXPECT().Ingest(ctx, "book.txt", mock.Anything, int64(7)).Return(ingest.Draft{}, expectedError).Once()
+	injectIngestMocks(handler, mockService)
+
+	err := handler.Ingest(ctx, httptest.NewRecorder(), newIngestRequest(t, "book.txt", "content"))
+	var validationError apiErrors.ValidationError
+	require.ErrorAs(t, err, &validationError, "should get a validation error")
+	assert.Equal(t, "file", validationError.Field)
+
+	err = handler.Ingest(ctx, httptest.NewRecorder(), newIngestRequest(t, "book.txt", "content"))
+	assert.ErrorIs(t, err, expectedError)
+}
+
+func getIngestHandler() *IngestController {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.Level(100)}))
+	return NewIngestController(logger, config.IngestConfig{MaxUploadSize: 1024}, nil, nil)
+}
+
+func injectIngestMocks(controller *IngestController, ingestService *MockIngestService) {
+	controller.ingestService = ingestService
+}
+
+func newIngestRequest(t *testing.T, fileName string, content string) *http.Request {
+	t.Helper()
+	body := bytes.Buffer{}
+	writer := multipart.NewWriter(&body)
+	fileWriter, err := writer.CreateFormFile("file", fileName)
+	require.NoError(t, err)
+	_, err = fileWriter.Write([]byte(content))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	request := httptest.NewRequest("POST", "/v1/ingest", &body)
+	request.Header.Set("Content-Type", writer.FormDataContentType())
+
+	return request
+}
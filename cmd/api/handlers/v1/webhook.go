@@ -0,0 +1,229 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/jmoiron/sqlx"
+	apiErrors "github.com/sdreger/lib-manager-go/cmd/api/errors"
+	"github.com/sdreger/lib-manager-go/cmd/api/handlers"
+	"github.com/sdreger/lib-manager-go/internal/domain/webhook"
+	"github.com/sdreger/lib-manager-go/internal/paging"
+	"github.com/sdreger/lib-manager-go/internal/response"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+type WebhookService interface {
+	GetWebhooks(ctx context.Context, pageRequest paging.PageRequest, sort paging.Sort) (
+		paging.Page[webhook.Webhook], error)
+	GetWebhook(ctx context.Context, webhookID int64) (webhook.Webhook, error)
+	CreateWebhook(ctx context.Context, request webhook.Webhook) (webhook.Webhook, error)
+	UpdateWebhook(ctx context.Context, request webhook.Webhook) (webhook.Webhook, error)
+	DeleteWebhook(ctx context.Context, webhookID int64) error
+	GetDeliveries(ctx context.Context, webhookID int64, status string, pageRequest paging.PageRequest) (
+		paging.Page[webhook.Delivery], error)
+	RetryDelivery(ctx context.Context, webhookID int64, deliveryID int64) (webhook.Delivery, error)
+}
+
+// WebhookRequest - the webhook settings. The empty events list subscribes to all the events,
+// the missing secret is generated on creation, and kept on update
+type WebhookRequest struct {
+	URL    string   `json:"url"`
+	Secret string   `json:"secret"`
+	Events []string `json:"events"`
+	Active *bool    `json:"active"`
+}
+
+type WebhookController struct {
+	logger         *slog.Logger
+	webhookService WebhookService
+}
+
+func NewWebhookController(logger *slog.Logger, db *sqlx.DB) *WebhookController {
+	return &WebhookController{logger: logger, webhookService: webhook.NewService(logger, db)}
+}
+
+func (cnt *WebhookController) RegisterRoutes(registrar handlers.RouteRegistrar) {
+	registrar.RegisterRoute(http.MethodGet, group, "/webhooks", cnt.GetWebhooks)
+	registrar.RegisterRoute(http.MethodPost, group, "/webhooks", cnt.CreateWebhook)
+	registrar.RegisterRoute(http.MethodGet, group, "/webhooks/{webhookID}", cnt.GetWebhook)
+	registrar.RegisterRoute(http.MethodPut, group, "/webhooks/{webhookID}", cnt.UpdateWebhook)
+	registrar.RegisterRoute(http.MethodDelete, group, "/webhooks/{webhookID}", cnt.DeleteWebhook)
+	registrar.RegisterRoute(http.MethodGet, group, "/webhooks/{webhookID}/deliveries", cnt.GetDeliveries)
+	registrar.RegisterRoute(http.MethodPost, group, "/webhooks/{webhookID}/deliveries/{deliveryID}/retry",
+		cnt.RetryDelivery)
+}
+
+func (cnt *WebhookController) GetWebhooks(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	page, pageErr := paging.NewPageRequest(r.URL.Query())
+	if pageErr != nil {
+		return pageErr
+	}
+
+	sort, sortErr := paging.NewSort(r.URL.Query(), webhook.AllowedSortFields)
+	if sortErr != nil {
+		return sortErr
+	}
+
+	webhookPage, err := cnt.webhookService.GetWebhooks(ctx, page, sort)
+	if err != nil {
+		return err
+	}
+
+	return response.RenderDataJSON(w, http.StatusOK, webhookPage)
+}
+
+func (cnt *WebhookController) GetWebhook(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	webhookID, err := parseID(r, "webhookID")
+	if err != nil {
+		return err
+	}
+
+	webhookEntry, err := cnt.webhookService.GetWebhook(ctx, webhookID)
+	if err != nil {
+		return mapWebhookError(err)
+	}
+
+	return response.RenderDataJSON(w, http.StatusOK, webhookEntry)
+}
+
+// CreateWebhook - creates the webhook, the response holds its secret, it is not returned afterward
+func (cnt *WebhookController) CreateWebhook(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	request, err := decodeWebhookRequest(r)
+	if err != nil {
+		return err
+	}
+
+	created, err := cnt.webhookService.CreateWebhook(ctx, request)
+	if err != nil {
+		return mapWebhookError(err)
+	}
+
+	return response.RenderDataJSON(w, http.StatusCreated, created)
+}
+
+func (cnt *WebhookController) UpdateWebhook(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	webhookID, err := parseID(r, "webhookID")
+	if err != nil {
+		return err
+	}
+
+	request, err := decodeWebhookRequest(r)
+	if err != nil {
+		return err
+	}
+	request.ID = webhookID
+
+	updated, err := cnt.webhookService.UpdateWebhook(ctx, request)
+	if err != nil {
+		return mapWebhookError(err)
+	}
+
+	return response.RenderDataJSON(w, http.StatusOK, updated)
+}
+
+func (cnt *WebhookController) DeleteWebhook(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	webhookID, err := parseID(r, "webhookID")
+	if err != nil {
+		return err
+	}
+
+	if err := cnt.webhookService.DeleteWebhook(ctx, webhookID); err != nil {
+		return mapWebhookError(err)
+	}
+	w.WriteHeader(http.StatusNoContent)
+
+	return nil
+}
+
+// GetDeliveries - returns a page of the webhook delivery log, optionally filtered by the delivery status
+func (cnt *WebhookController) GetDeliveries(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	webhookID, err := parseID(r, "webhookID")
+	if err != nil {
+		return err
+	}
+
+	page, pageErr := paging.NewPageRequest(r.URL.Query())
+	if pageErr != nil {
+		return pageErr
+	}
+
+	deliveryPage, err := cnt.webhookService.GetDeliveries(ctx, webhookID, r.URL.Query().Get("status"), page)
+	if err != nil {
+		return mapWebhookError(err)
+	}
+
+	return response.RenderDataJSON(w, http.StatusOK, deliveryPage)
+}
+
+// RetryDelivery - queues the dead-lettered delivery for another round of the attempts
+func (cnt *WebhookController) RetryDelivery(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	webhookID, err := parseID(r, "webhookID")
+	if err != nil {
+		return err
+	}
+
+	deliveryID, err := parseID(r, "deliveryID")
+	if err != nil {
+		return err
+	}
+
+	delivery, err := cnt.webhookService.RetryDelivery(ctx, webhookID, deliveryID)
+	if err != nil {
+		return mapWebhookError(err)
+	}
+
+	return response.RenderDataJSON(w, http.StatusOK, delivery)
+}
+
+func parseID(r *http.Request, pathVariable string) (int64, error) {
+	idInt, err := strconv.ParseInt(r.PathValue(pathVariable), 10, 64)
+	if err != nil {
+		return 0, apiErrors.ValidationError{
+			Field:   pathVariable,
+			Message: "the provided " + pathVariable + " should be a number",
+		}
+	}
+
+	return idInt, nil
+}
+
+func decodeWebhookRequest(r *http.Request) (webhook.Webhook, error) {
+	var request WebhookRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&request); err != nil {
+		return webhook.Webhook{}, apiErrors.ValidationError{
+			Field:   "body",
+			Message: "the request body should be a JSON object: " + err.Error(),
+		}
+	}
+
+	active := true
+	if request.Active != nil {
+		active = *request.Active
+	}
+
+	return webhook.Webhook{URL: request.URL, Secret: request.Secret, Events: request.Events, Active: active}, nil
+}
+
+func mapWebhookError(err error) error {
+	switch {
+	case errors.Is(err, webhook.ErrNotFound):
+		return apiErrors.ErrNotFound
+	case errors.Is(err, webhook.ErrInvalidURL):
+		return apiErrors.ValidationError{Field: "url", Message: err.Error()}
+	case errors.Is(err, webhook.ErrInvalidEvent):
+		return apiErrors.ValidationError{Field: "events", Message: err.Error()}
+	case errors.Is(err, webhook.ErrShortSecret):
+		return apiErrors.ValidationError{Field: "secret", Message: err.Error()}
+	case errors.Is(err, webhook.ErrInvalidStatus):
+		return apiErrors.ValidationError{Field: "status", Message: err.Error()}
+	case errors.Is(err, webhook.ErrNotDead):
+		return apiErrors.ValidationError{Field: "deliveryID", Message: err.Error()}
+	default:
+		return err
+	}
+}
@@ -0,0 +1,236 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	apiErrors "github.com/sdreger/lib-manager-go/cmd/api/errors"
+	"github.com/sdreger/lib-manager-go/cmd/api/handlers"
+	"github.com/sdreger/lib-manager-go/internal/domain/webhook"
+	"github.com/sdreger/lib-manager-go/internal/paging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+const (
+	webhookID  = int64(1)
+	deliveryID = int64(10)
+	webhookURL = "https://hooks.example.com/catalog"
+)
+
+func TestWebhookController_RegisterRoutes(t *testing.T) {
+	testRegistrar := handlers.RouteRegistrarMock{}
+	cnt := getWebhookController()
+	cnt.RegisterRoutes(&testRegistrar)
+
+	assert.True(t, testRegistrar.IsRouteRegistered("GET /v1/webhooks", cnt.GetWebhooks))
+	assert.True(t, testRegistrar.IsRouteRegistered("POST /v1/webhooks", cnt.CreateWebhook))
+	assert.True(t, testRegistrar.IsRouteRegistered("GET /v1/webhooks/{webhookID}", cnt.GetWebhook))
+	assert.True(t, testRegistrar.IsRouteRegistered("PUT /v1/webhooks/{webhookID}", cnt.UpdateWebhook))
+	assert.True(t, testRegistrar.IsRouteRegistered("DELETE /v1/webhooks/{webhookID}", cnt.DeleteWebhook))
+	assert.True(t, testRegistrar.IsRouteRegistered("GET /v1/webhooks/{webhookID}/deliveries", cnt.GetDeliveries))
+	assert.True(t, testRegistrar.IsRouteRegistered("POST /v1/webhooks/{webhookID}/deliveries/{deliveryID}/retry",
+		cnt.RetryDelivery))
+}
+
+func TestWebhookController_GetWebhooks(t *testing.T) {
+	ctx := context.Background()
+	controller := getWebhookController()
+	values := map[string][]string{"sort": {"created_at,desc"}}
+	pageRequest, _ := paging.NewPageRequest(values)
+	sort, _ := paging.NewSort(values, webhook.AllowedSortFields)
+	webhookPage := paging.NewPage(pageRequest, 1, []webhook.Webhook{getTestWebhook()})
+
+	mockService := NewMockWebhookService(t)
+	mockService.EXPECT().GetWebhooks(ctx, pageRequest, sort).Return(webhookPage, nil).Once()
+	controller.webhookService = mockService
+
+	recorder := httptest.NewRecorder()
+	err := controller.GetWebhooks(ctx, recorder, httptest.NewRequest("GET", "/v1/webhooks?sort=created_at,desc", nil))
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	var responseBody struct {
+		Data paging.Page[webhook.Webhook] `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(recorder.Body).Decode(&responseBody))
+	assert.Equal(t, []webhook.Webhook{getTestWebhook()}, responseBody.Data.Content)
+}
+
+func TestWebhookController_GetWebhook_NotFound(t *testing.T) {
+	ctx := context.Background()
+	controller := getWebhookController()
+
+	mockService := NewMockWebhookService(t)
+	mockService.EXPECT().GetWebhook(ctx, webhookID).Return(webhook.Webhook{}, webhook.ErrNotFound).Once()
+	controller.webhookService = mockService
+
+	request := httptest.NewRequest("GET", "/v1/webhooks/1", nil)
+	request.SetPathValue("webhookID", "1")
+	err := controller.GetWebhook(ctx, httptest.NewRecorder(), request)
+	assert.ErrorIs(t, err, apiErrors.ErrNotFound)
+
+	request.SetPathValue("webhookID", "one")
+	err = controller.GetWebhook(ctx, httptest.NewRecorder(), request)
+	var validationError apiErrors.ValidationError
+	require.ErrorAs(t, err, &validationError)
+	assert.Equal(t, "webhookID", validationError.Field)
+}
+
+func TestWebhookController_CreateWebhook(t *testing.T) {
+	ctx := context.Background()
+	controller := getWebhookController()
+	created := getTestWebhook()
+	created.Secret = "0123456789abcdef"
+
+	mockService := NewMockWebhookService(t)
+	mockService.EXPECT().CreateWebhook(ctx, webhook.Webhook{URL: webhookURL, Events: []string{"book.created"},
+		Active: true}).Return(created, nil).Once()
+	controller.webhookService = mockService
+
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest("POST", "/v1/webhooks",
+		strings.NewReader(`{"url":"`+webhookURL+`","events":["book.created"]}`))
+	err := controller.CreateWebhook(ctx, recorder, request)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusCreated, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), `"secret":"0123456789abcdef"`, "the secret should be returned")
+}
+
+func TestWebhookController_CreateWebhook_Errors(t *testing.T) {
+	ctx := context.Background()
+	controller := getWebhookController()
+
+	mockService := NewMockWebhookService(t)
+	mockService.EXPECT().CreateWebhook(ctx, mock.Anything).Return(webhook.Webhook{}, webhook.ErrInvalidEvent).Once()
+	controller.webhookService = mockService
+
+	var validationError apiErrors.ValidationError
+	err := controller.CreateWebhook(ctx, httptest.NewRecorder(),
+		httptest.NewRequest("POST", "/v1/webhooks", strings.NewReader(`{"uri":"`+webhookURL+`"}`)))
+	require.ErrorAs(t, err, &validationError)
+	assert.Equal(t, "body", validationError.Field, "the unknown fields should be rejected")
+
+	err = controller.CreateWebhook(ctx, httptest.NewRecorder(),
+		httptest.NewRequest("POST", "/v1/webhooks", strings.NewReader(`{"url":"`+webhookURL+`","events":["x"]}`)))
+	require.ErrorAs(t, err, &validationError)
+	assert.Equal(t, "events", validationError.Field)
+}
+
+func TestWebhookController_UpdateWebhook(t *testing.T) {
+	ctx := context.Background()
+	controller := getWebhookController()
+	updated := getTestWebhook()
+	updated.Active = false
+
+	mockService := NewMockWebhookService(t)
+	mockService.EXPECT().UpdateWebhook(ctx, webhook.Webhook{ID: webhookID, URL: webhookURL, Active: false}).
+		Return(updated, nil).Once()
+	controller.webhookService = mockService
+
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest("PUT", "/v1/webhooks/1",
+		strings.NewReader(`{"url":"`+webhookURL+`","active":false}`))
+	request.SetPathValue("webhookID", "1")
+	err := controller.UpdateWebhook(ctx, recorder, request)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), `"active":false`)
+}
+
+func TestWebhookController_DeleteWebhook(t *testing.T) {
+	ctx := context.Background()
+	controller := getWebhookController()
+
+	mockService := NewMockWebhookService(t)
+	mockService.EXPECT().DeleteWebhook(ctx, webhookID).Return(nil).Once()
+	controller.webhookService = mockService
+
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest("DELETE", "/v1/webhooks/1", nil)
+	request.SetPathValue("webhookID", "1")
+	err := controller.DeleteWebhook(ctx, recorder, request)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusNoContent, recorder.Code)
+	assert.Empty(t, recorder.Body.String())
+}
+
+func TestWebhookController_GetDeliveries(t *testing.T) {
+	ctx := context.Background()
+	controller := getWebhookController()
+	pageRequest, _ := paging.NewPageRequest(map[string][]string{})
+	deliveries := []webhook.Delivery{{ID: deliveryID, WebhookID: webhookID, Status: webhook.StatusDead}}
+
+	mockService := NewMockWebhookService(t)
+	mockService.EXPECT().GetDeliveries(ctx, webhookID, webhook.StatusDead, pageRequest).
+		Return(paging.NewPage(pageRequest, 1, deliveries), nil).Once()
+	mockService.EXPECT().GetDeliveries(ctx, webhookID, "failed", pageRequest).
+		Return(paging.Page[webhook.Delivery]{}, webhook.ErrInvalidStatus).Once()
+	controller.webhookService = mockService
+
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest("GET", "/v1/webhooks/1/deliveries?status=dead", nil)
+	request.SetPathValue("webhookID", "1")
+	err := controller.GetDeliveries(ctx, recorder, request)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), `"status":"dead"`)
+
+	request = httptest.NewRequest("GET", "/v1/webhooks/1/deliveries?status=failed", nil)
+	request.SetPathValue("webhookID", "1")
+	err = controller.GetDeliveries(ctx, httptest.NewRecorder(), request)
+	var validationError apiErrors.ValidationError
+	require.ErrorAs(t, err, &validationError)
+	assert.Equal(t, "status", validationError.Field)
+}
+
+func TestWebhookController_RetryDelivery(t *testing.T) {
+	ctx := context.Background()
+	controller := getWebhookController()
+	serviceError := errors.New("service error")
+
+	mockService := NewMockWebhookService(t)
+	mockService.EXPECT().RetryDelivery(ctx, webhookID, deliveryID).
+		Return(webhook.Delivery{ID: deliveryID, WebhookID: webhookID, Status: webhook.StatusPending}, nil).Once()
+	mockService.EXPECT().RetryDelivery(ctx, webhookID, int64(11)).Return(webhook.Delivery{}, webhook.ErrNotDead).Once()
+	mockService.EXPECT().RetryDelivery(ctx, webhookID, int64(12)).Return(webhook.Delivery{}, serviceError).Once()
+	controller.webhookService = mockService
+
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest("POST", "/v1/webhooks/1/deliveries/10/retry", nil)
+	request.SetPathValue("webhookID", "1")
+	request.SetPathValue("deliveryID", "10")
+	err := controller.RetryDelivery(ctx, recorder, request)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), `"status":"pending"`)
+
+	request.SetPathValue("deliveryID", "11")
+	err = controller.RetryDelivery(ctx, httptest.NewRecorder(), request)
+	var validationError apiErrors.ValidationError
+	require.ErrorAs(t, err, &validationError)
+	assert.Equal(t, "deliveryID", validationError.Field)
+
+	request.SetPathValue("deliveryID", "12")
+	err = controller.RetryDelivery(ctx, httptest.NewRecorder(), request)
+	assert.ErrorIs(t, err, serviceError)
+}
+
+func getTestWebhook() webhook.Webhook {
+	return webhook.Webhook{ID: webhookID, URL: webhookURL, Events: []string{}, Active: true}
+}
+
+func getWebhookController() *WebhookController {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	return NewWebhookController(logger, nil)
+}
@@ -0,0 +1,262 @@
+package web
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	apiErrors "github.com/sdreger/lib-manager-go/cmd/api/errors"
+	"github.com/sdreger/lib-manager-go/cmd/api/handlers"
+	"github.com/sdreger/lib-manager-go/internal/domain/book"
+	catalog "github.com/sdreger/lib-manager-go/internal/opds"
+	"github.com/sdreger/lib-manager-go/internal/paging"
+	"github.com/sdreger/lib-manager-go/internal/schemaorg"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	booksPath      = group
+	staticPath     = group + "/static/"
+	queryParamPage = "page"
+	queryParamSize = "size"
+	queryParamSort = "sort"
+
+	// gridPageSize, facetPageSize - the default page sizes, the 'size' query parameter overrides them
+	gridPageSize  = "24"
+	facetPageSize = "50"
+	defaultSort   = "created_at,desc"
+)
+
+// sortOptions - the book grid sort orders, offered by the search form
+var sortOptions = []option{
+	{Value: "created_at,desc", Label: "Recently added"},
+	{Value: "title,asc", Label: "Title"},
+	{Value: "pub_date,desc", Label: "Publication date"},
+	{Value: "pages,desc", Label: "Pages"},
+}
+
+type CatalogService interface {
+	GetFacetItems(ctx context.Context, facet catalog.Facet, pageRequest paging.PageRequest) (
+		paging.Page[catalog.FacetItem], error)
+	GetPublications(ctx context.Context, pageRequest paging.PageRequest, sort paging.Sort, filter book.Filter) (
+		paging.Page[catalog.Publication], error)
+	GetBookFileTypes(ctx context.Context, bookIDs []int64) (map[int64][]string, error)
+}
+
+type BookService interface {
+	GetBookByID(ctx context.Context, bookID int64) (book.Book, error)
+}
+
+// CatalogController - serves the server-rendered catalog browser, built on the OPDS catalog and the book services
+type CatalogController struct {
+	logger         *slog.Logger
+	catalogService CatalogService
+	bookService    BookService
+}
+
+func NewCatalogController(logger *slog.Logger, db *sqlx.DB) *CatalogController {
+	return &CatalogController{
+		logger:         logger,
+		catalogService: catalog.NewService(logger, db),
+		bookService:    book.NewService(logger, db),
+	}
+}
+
+func (cnt *CatalogController) RegisterRoutes(registrar handlers.RouteRegistrar) {
+	registrar.RegisterRoute(http.MethodGet, group, "", cnt.GetBooks)
+	registrar.RegisterRoute(http.MethodGet, group, "/books/{bookID}", cnt.GetBook)
+	registrar.RegisterRoute(http.MethodGet, group, "/browse/{facet}", cnt.GetFacet)
+	registrar.RegisterRoute(http.MethodGet, group, "/static/", cnt.GetStatic)
+}
+
+// GetBooks - renders the book grid, matching the search query and the book filter query parameters
+func (cnt *CatalogController) GetBooks(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	query := withDefault(r.URL.Query(), queryParamSize, gridPageSize)
+	query = withDefault(query, queryParamSort, defaultSort)
+	pageRequest, err := paging.NewPageRequest(query)
+	if err != nil {
+		return err
+	}
+	sort, err := paging.NewSort(query, book.AllowedSortFields)
+	if err != nil {
+		return err
+	}
+	filter, err := book.NewFilter(query)
+	if err != nil {
+		return err
+	}
+
+	page, err := cnt.catalogService.GetPublications(ctx, pageRequest, sort, filter)
+	if err != nil {
+		return err
+	}
+
+	data := booksPage{
+		Layout:  newLayout("Books"),
+		Query:   filter.Query,
+		Sort:    query.Get(queryParamSort),
+		Sorts:   sortOptions,
+		Filters: filterChips(r.URL.Query(), page.Content),
+		Hidden:  hiddenInputs(r.URL.Query()),
+		Pager:   newPager(r, page),
+	}
+	if filter.Query != "" {
+		data.Layout.Title = "Search: " + filter.Query
+	}
+	for _, publication := range page.Content {
+		data.Books = append(data.Books, newBookCard(publication))
+	}
+
+	return render(w, "books", data)
+}
+
+// GetBook - renders the book details page, along with the cover, the book file download links
+// and the Open Graph meta tags
+func (cnt *CatalogController) GetBook(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	bookID, err := strconv.ParseInt(r.PathValue("bookID"), 10, 64)
+	if err != nil {
+		return apiErrors.ValidationError{
+			Field:   "bookID",
+			Message: "the provided bookID should be a number",
+		}
+	}
+
+	bookEntry, err := cnt.bookService.GetBookByID(ctx, bookID)
+	if errors.Is(err, book.ErrNotFound) {
+		return apiErrors.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+	// the downloads are only offered for the stored book files
+	fileTypes, err := cnt.catalogService.GetBookFileTypes(ctx, []int64{bookEntry.ID})
+	if err != nil {
+		return err
+	}
+
+	data := bookPage{
+		Layout:   newLayout(bookEntry.Title),
+		Book:     bookEntry,
+		CoverURL: coverURL(bookEntry.ID),
+		FileSize: formatSize(bookEntry.BookFileSize),
+		OpenGraph: schemaorg.OpenGraph(
+			schemaorg.NewBook(bookEntry, schemaorg.NewLinks(handlers.BaseURL(r), bookEntry.ID))),
+	}
+	if !bookEntry.PubDate.IsZero() {
+		data.PubDate = bookEntry.PubDate.Format("January 2, 2006")
+	}
+	if bookEntry.ISBN13 != 0 {
+		data.ISBN13 = strconv.FormatInt(bookEntry.ISBN13, 10)
+	}
+	for _, fileType := range fileTypes[bookEntry.ID] {
+		data.Downloads = append(data.Downloads, link{
+			Name: strings.ToUpper(fileType),
+			URL:  fmt.Sprintf("/v1/books/%d/files/%s", bookEntry.ID, url.PathEscape(fileType)),
+		})
+	}
+
+	return render(w, "book", data)
+}
+
+// GetFacet - renders the facet items, e.g. the publishers, each one links to the book grid of the books having it
+func (cnt *CatalogController) GetFacet(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	facet, ok := catalog.FacetByName(r.PathValue("facet"))
+	if !ok {
+		return apiErrors.ErrNotFound
+	}
+	pageRequest, err := paging.NewPageRequest(withDefault(r.URL.Query(), queryParamSize, facetPageSize))
+	if err != nil {
+		return err
+	}
+
+	page, err := cnt.catalogService.GetFacetItems(ctx, facet, pageRequest)
+	if err != nil {
+		return err
+	}
+
+	data := facetPage{
+		Layout: newLayout(facetLabel(facet)),
+		Pager:  newPager(r, page),
+	}
+	for _, item := range page.Content {
+		data.Items = append(data.Items, facetEntry{
+			Link:      link{Name: item.Name, URL: filterURL(facet, item.ID)},
+			BookCount: item.BookCount,
+		})
+	}
+
+	return render(w, "facet", data)
+}
+
+// GetStatic - serves the embedded stylesheet
+func (cnt *CatalogController) GetStatic(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	static, err := fs.Sub(staticFiles, "static")
+	if err != nil {
+		return err
+	}
+	w.Header().Set("Cache-Control", "public, max-age=3600")
+	http.StripPrefix(staticPath, http.FileServer(http.FS(static))).ServeHTTP(w, r)
+
+	return nil
+}
+
+// render - executes the page template into a buffer first, so the template errors are reported
+// by the error middleware, rather than as a truncated page
+func render(w http.ResponseWriter, page string, data any) error {
+	var buffer bytes.Buffer
+	if err := pages[page].ExecuteTemplate(&buffer, "layout", data); err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, err := w.Write(buffer.Bytes())
+
+	return err
+}
+
+// withDefault - returns a copy of the query values, having the default value set, if the parameter is missing
+func withDefault(values url.Values, name string, value string) url.Values {
+	result := url.Values{}
+	for key, list := range values {
+		result[key] = list
+	}
+	if result.Get(name) == "" {
+		result.Set(name, value)
+	}
+
+	return result
+}
+
+func coverURL(bookID int64) string {
+	return fmt.Sprintf("/v1/books/%d/cover?fallback=generated", bookID)
+}
+
+func filterURL(facet catalog.Facet, id int64) string {
+	return booksPath + "?" + url.Values{facet.FilterParam: {strconv.FormatInt(id, 10)}}.Encode()
+}
+
+// facetLabel - returns the facet name, capitalized for the headings, e.g. 'Publishers'
+func facetLabel(facet catalog.Facet) string {
+	return strings.ToUpper(facet.Name[:1]) + facet.Name[1:]
+}
+
+// formatSize - returns the human-readable file size, e.g. '5.1 MB'
+func formatSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	divisor, exponent := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		divisor *= unit
+		exponent++
+	}
+
+	return fmt.Sprintf("%.1f %cB", float64(size)/float64(divisor), "KMGTPE"[exponent])
+}
@@ -0,0 +1,212 @@
+package web
+
+import (
+	"context"
+	apiErrors "github.com/sdreger/lib-manager-go/cmd/api/errors"
+	"github.com/sdreger/lib-manager-go/cmd/api/handlers"
+	"github.com/sdreger/lib-manager-go/internal/domain/book"
+	catalog "github.com/sdreger/lib-manager-go/internal/opds"
+	"github.com/sdreger/lib-manager-go/internal/paging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestCatalogController_RegisterRoutes(t *testing.T) {
+	testRegistrar := handlers.RouteRegistrarMock{}
+	cnt := getCatalogController()
+	cnt.RegisterRoutes(&testRegistrar)
+
+	assert.True(t, testRegistrar.IsRouteRegistered("GET /ui", cnt.GetBooks))
+	assert.True(t, testRegistrar.IsRouteRegistered("GET /ui/books/{bookID}", cnt.GetBook))
+	assert.True(t, testRegistrar.IsRouteRegistered("GET /ui/browse/{facet}", cnt.GetFacet))
+	assert.True(t, testRegistrar.IsRouteRegistered("GET /ui/static/", cnt.GetStatic))
+}
+
+func TestCatalogController_GetBooks(t *testing.T) {
+	ctx := context.Background()
+	controller := getCatalogController()
+	values := map[string][]string{"author": {"2"}, "size": {"24"}, "sort": {"created_at,desc"}, "page": {"2"}}
+	pageRequest, _ := paging.NewPageRequest(values)
+	sort, _ := paging.NewSort(values, book.AllowedSortFields)
+	filter, _ := book.NewFilter(values)
+	publication := catalog.Publication{
+		LookupItem: book.LookupItem{ID: 1, Title: "CockroachDB <Guide>", Publisher: "OReilly",
+			PubDate: time.Date(2022, 7, 19, 0, 0, 0, 0, time.UTC), AuthorIDs: []int64{1, 2}},
+		Authors: []string{"John Doe", "Amanda Lee"},
+	}
+	page := paging.NewPage(pageRequest, 49, []catalog.Publication{publication})
+
+	mockService := NewMockCatalogService(t)
+	mockService.EXPECT().GetPublications(ctx, pageRequest, sort, filter).
+		Return(page, nil).Once()
+	controller.catalogService = mockService
+
+	recorder := httptest.NewRecorder()
+	err := controller.GetBooks(ctx, recorder, httptest.NewRequest("GET", "/ui?author=2&page=2", nil))
+	require.NoError(t, err)
+
+	assert.Equal(t, "text/html; charset=utf-8", recorder.Header().Get("Content-Type"))
+	body := recorder.Body.String()
+	assert.Contains(t, body, "<title>Books - Library</title>")
+	assert.Contains(t, body, `<a class="title" href="/ui/books/1">CockroachDB &lt;Guide&gt;</a>`,
+		"the book values should be escaped")
+	assert.Contains(t, body, `<img src="/v1/books/1/cover?fallback=generated"`)
+	assert.Contains(t, body, `<a href="/ui?author=2">Amanda Lee</a>`)
+	assert.Contains(t, body, `<span class="meta">OReilly, 2022</span>`)
+	assert.Contains(t, body, `<li>Author: Amanda Lee <a href="/ui" title="Remove the filter">`,
+		"the filter value name should be taken from the books")
+	assert.Contains(t, body, `<input type="hidden" name="author" value="2">`)
+	assert.Contains(t, body, `<option value="created_at,desc" selected>Recently added</option>`)
+	assert.Contains(t, body, `<a href="/ui?author=2&amp;page=1" rel="prev">`)
+	assert.Contains(t, body, `<a href="/ui?author=2&amp;page=3" rel="next">`)
+	assert.Contains(t, body, "<span>Page 2 of 3</span>")
+}
+
+func TestCatalogController_GetBooks_Search(t *testing.T) {
+	ctx := context.Background()
+	controller := getCatalogController()
+	filter, _ := book.NewFilter(map[string][]string{"query": {"go"}})
+
+	mockService := NewMockCatalogService(t)
+	mockService.EXPECT().GetPublications(ctx, mock.Anything, mock.Anything, filter).
+		Return(paging.Page[catalog.Publication]{}, nil).Once()
+	controller.catalogService = mockService
+
+	recorder := httptest.NewRecorder()
+	err := controller.GetBooks(ctx, recorder, httptest.NewRequest("GET", "/ui?query=go&sort=title,asc", nil))
+	require.NoError(t, err)
+
+	body := recorder.Body.String()
+	assert.Contains(t, body, "<title>Search: go - Library</title>")
+	assert.Contains(t, body, `<input type="search" name="query" value="go"`)
+	assert.Contains(t, body, `<option value="title,asc" selected>Title</option>`)
+	assert.Contains(t, body, "No books found.")
+	assert.NotContains(t, body, `class="pager"`)
+}
+
+func TestCatalogController_GetBooks_InvalidFilter(t *testing.T) {
+	controller := getCatalogController()
+	controller.catalogService = NewMockCatalogService(t)
+
+	err := controller.GetBooks(context.Background(), httptest.NewRecorder(),
+		httptest.NewRequest("GET", "/ui?tag=abc", nil))
+	var validationError apiErrors.ValidationError
+	require.ErrorAs(t, err, &validationError)
+	assert.Equal(t, "tag", validationError.Field)
+}
+
+func TestCatalogController_GetBook(t *testing.T) {
+	ctx := context.Background()
+	controller := getCatalogController()
+	bookEntry := book.Book{
+		ID:           1,
+		Title:        "CockroachDB",
+		ISBN13:       9781234567890,
+		Pages:        256,
+		PubDate:      time.Date(2022, 7, 19, 0, 0, 0, 0, time.UTC),
+		BookFileSize: 5347737,
+		Publisher:    "OReilly",
+		Authors:      []string{"John Doe", "Amanda Lee"},
+		FileTypes:    []string{"pdf", "epub", "mobi"},
+	}
+
+	mockService := NewMockBookService(t)
+	mockService.EXPECT().GetBookByID(ctx, int64(1)).Return(bookEntry, nil).Once()
+	mockService.EXPECT().GetBookByID(ctx, int64(2)).Return(book.Book{}, book.ErrNotFound).Once()
+	controller.bookService = mockService
+	mockCatalogService := NewMockCatalogService(t)
+	mockCatalogService.EXPECT().GetBookFileTypes(ctx, []int64{1}).
+		Return(map[int64][]string{1: {"epub", "pdf"}}, nil).Once()
+	controller.catalogService = mockCatalogService
+
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest("GET", "/ui/books/1", nil)
+	request.SetPathValue("bookID", "1")
+	err := controller.GetBook(ctx, recorder, request)
+	require.NoError(t, err)
+
+	body := recorder.Body.String()
+	assert.Contains(t, body, "<h1>CockroachDB</h1>")
+	assert.Contains(t, body, `<p class="authors">by John Doe, Amanda Lee</p>`)
+	assert.Contains(t, body, "<dt>Published</dt><dd>July 19, 2022</dd>")
+	assert.Contains(t, body, "<dt>ISBN-13</dt><dd>9781234567890</dd>")
+	assert.Contains(t, body, "Download (5.1 MB):")
+	assert.Contains(t, body, `<a class="button" href="/v1/books/1/files/pdf" download>PDF</a>`)
+	assert.Contains(t, body, `<a class="button" href="/v1/books/1/files/epub" download>EPUB</a>`)
+	assert.NotContains(t, body, "/v1/books/1/files/mobi", "only the stored book files should be offered")
+	assert.NotContains(t, body, "<dt>ASIN</dt>", "the missing values should be skipped")
+	assert.Contains(t, body, `<meta property="og:type" content="book">`)
+	assert.Contains(t, body, `<meta property="og:url" content="http://example.com/ui/books/1">`)
+	assert.Contains(t, body, `<meta property="book:author" content="Amanda Lee">`)
+	assert.Contains(t, body, `<meta property="book:release_date" content="2022-07-19">`)
+	assert.NotContains(t, body, `property="og:image"`, "the book without a cover should not have an image")
+
+	request.SetPathValue("bookID", "2")
+	err = controller.GetBook(ctx, httptest.NewRecorder(), request)
+	assert.ErrorIs(t, err, apiErrors.ErrNotFound)
+
+	request.SetPathValue("bookID", "one")
+	err = controller.GetBook(ctx, httptest.NewRecorder(), request)
+	var validationError apiErrors.ValidationError
+	require.ErrorAs(t, err, &validationError)
+	assert.Equal(t, "bookID", validationError.Field)
+}
+
+func TestCatalogController_GetFacet(t *testing.T) {
+	ctx := context.Background()
+	controller := getCatalogController()
+	facet, _ := catalog.FacetByName("publishers")
+	pageRequest, _ := paging.NewPageRequest(map[string][]string{"size": {"50"}})
+	items := []catalog.FacetItem{{ID: 3, Name: "OReilly", BookCount: 12}}
+
+	mockService := NewMockCatalogService(t)
+	mockService.EXPECT().GetFacetItems(ctx, facet, pageRequest).
+		Return(paging.NewPage(pageRequest, 1, items), nil).Once()
+	controller.catalogService = mockService
+
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest("GET", "/ui/browse/publishers", nil)
+	request.SetPathValue("facet", "publishers")
+	err := controller.GetFacet(ctx, recorder, request)
+	require.NoError(t, err)
+
+	body := recorder.Body.String()
+	assert.Contains(t, body, "<h1>Publishers</h1>")
+	assert.Contains(t, body, `<li><a href="/ui?publisher=3">OReilly</a> <span class="count">12</span></li>`)
+	assert.Contains(t, body, `<a href="/ui/browse/tags">Tags</a>`, "should link the other browse pages")
+
+	request.SetPathValue("facet", "shelves")
+	err = controller.GetFacet(ctx, httptest.NewRecorder(), request)
+	assert.ErrorIs(t, err, apiErrors.ErrNotFound)
+}
+
+func TestCatalogController_GetStatic(t *testing.T) {
+	controller := getCatalogController()
+
+	recorder := httptest.NewRecorder()
+	err := controller.GetStatic(context.Background(), recorder, httptest.NewRequest("GET", "/ui/static/style.css", nil))
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Header().Get("Content-Type"), "text/css")
+	assert.Contains(t, recorder.Body.String(), ".grid {")
+}
+
+func TestFormatSize(t *testing.T) {
+	assert.Equal(t, "512 B", formatSize(512))
+	assert.Equal(t, "1.5 KB", formatSize(1536))
+	assert.Equal(t, "5.1 MB", formatSize(5347737))
+	assert.Equal(t, "2.0 GB", formatSize(2<<30))
+}
+
+func getCatalogController() *CatalogController {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	return NewCatalogController(logger, nil)
+}
@@ -0,0 +1,225 @@
+package web
+
+import (
+	"github.com/sdreger/lib-manager-go/internal/domain/book"
+	catalog "github.com/sdreger/lib-manager-go/internal/opds"
+	"github.com/sdreger/lib-manager-go/internal/paging"
+	"github.com/sdreger/lib-manager-go/internal/schemaorg"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// layout - the shared page header data: the page title and the navigation links
+type layout struct {
+	Title string
+	Nav   []link
+}
+
+type link struct {
+	Name string
+	URL  string
+}
+
+type option struct {
+	Value string
+	Label string
+}
+
+// filterChip - the active book filter value, the remove link drops it from the current query
+type filterChip struct {
+	Label     string
+	RemoveURL string
+}
+
+type hiddenInput struct {
+	Name  string
+	Value string
+}
+
+// pager - the links to the adjacent pages, the empty ones are not rendered
+type pager struct {
+	Page        int64
+	TotalPages  int64
+	TotalItems  int64
+	PreviousURL string
+	NextURL     string
+}
+
+type bookCard struct {
+	URL       string
+	CoverURL  string
+	Title     string
+	Subtitle  string
+	Authors   []link
+	Publisher string
+	Year      int
+}
+
+type booksPage struct {
+	Layout  layout
+	Query   string
+	Sort    string
+	Sorts   []option
+	Filters []filterChip
+	// Hidden - the active book filters, kept by the search form
+	Hidden []hiddenInput
+	Books  []bookCard
+	Pager  pager
+}
+
+type bookPage struct {
+	Layout    layout
+	Book      book.Book
+	CoverURL  string
+	PubDate   string
+	ISBN13    string
+	FileSize  string
+	Downloads []link
+	// OpenGraph - the meta tags of the link previews, having the absolute URLs
+	OpenGraph []schemaorg.Meta
+}
+
+type facetEntry struct {
+	Link      link
+	BookCount int64
+}
+
+type facetPage struct {
+	Layout layout
+	Items  []facetEntry
+	Pager  pager
+}
+
+func newLayout(title string) layout {
+	nav := []link{{Name: "Books", URL: booksPath}}
+	for _, facet := range catalog.Facets {
+		nav = append(nav, link{Name: facetLabel(facet), URL: group + "/browse/" + facet.Name})
+	}
+
+	return layout{Title: title, Nav: nav}
+}
+
+// newPager - returns the page links, keeping the other request query parameters
+func newPager[T any](r *http.Request, page paging.Page[T]) pager {
+	result := pager{Page: page.Page, TotalPages: page.TotalPages, TotalItems: page.TotalItems}
+	pageURL := func(number int64) string {
+		query := r.URL.Query()
+		query.Set(queryParamPage, strconv.FormatInt(number, 10))
+		return r.URL.Path + "?" + query.Encode()
+	}
+	if page.Page > 1 && page.TotalPages > 0 {
+		result.PreviousURL = pageURL(min(page.Page-1, page.TotalPages))
+	}
+	if page.Page < page.TotalPages {
+		result.NextURL = pageURL(page.Page + 1)
+	}
+
+	return result
+}
+
+func newBookCard(publication catalog.Publication) bookCard {
+	card := bookCard{
+		URL:       booksPath + "/books/" + strconv.FormatInt(publication.ID, 10),
+		CoverURL:  coverURL(publication.ID),
+		Title:     publication.Title,
+		Subtitle:  publication.Subtitle,
+		Publisher: publication.Publisher,
+	}
+	if !publication.PubDate.IsZero() {
+		card.Year = publication.PubDate.Year()
+	}
+	authors, _ := catalog.FacetByName("authors")
+	for i, name := range publication.Authors {
+		author := link{Name: name}
+		// the names are only aligned with the IDs, if all of them are known
+		if len(publication.AuthorIDs) == len(publication.Authors) {
+			author.URL = filterURL(authors, publication.AuthorIDs[i])
+		}
+		card.Authors = append(card.Authors, author)
+	}
+
+	return card
+}
+
+// filterChips - returns the active facet filters. The filter value names are taken from the found books,
+// since all of them have the value, the facet name and the ID are shown otherwise
+func filterChips(query url.Values, publications []catalog.Publication) []filterChip {
+	var chips []filterChip
+	for _, facet := range catalog.Facets {
+		values := query[facet.FilterParam]
+		for _, value := range values {
+			name := filterValueName(facet.FilterParam, value, len(values), publications)
+			if name == "" {
+				name = "#" + value
+			}
+
+			remaining := url.Values{}
+			for key, list := range query {
+				remaining[key] = list
+			}
+			remaining[facet.FilterParam] = slices.DeleteFunc(slices.Clone(values), func(v string) bool {
+				return v == value
+			})
+			remaining.Del(queryParamPage)
+			removeURL := booksPath
+			if encoded := remaining.Encode(); encoded != "" {
+				removeURL += "?" + encoded
+			}
+			chips = append(chips, filterChip{
+				Label:     strings.ToUpper(facet.FilterParam[:1]) + facet.FilterParam[1:] + ": " + name,
+				RemoveURL: removeURL,
+			})
+		}
+	}
+
+	return chips
+}
+
+func filterValueName(filterParam string, value string, valueCount int, publications []catalog.Publication) string {
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || len(publications) == 0 {
+		return ""
+	}
+
+	for _, publication := range publications {
+		var ids []int64
+		var names []string
+		switch filterParam {
+		case "author":
+			ids, names = publication.AuthorIDs, publication.Authors
+		case "category":
+			ids, names = publication.CategoryIDs, publication.Categories
+		case "tag":
+			ids, names = publication.TagIDs, publication.Tags
+		default:
+			// the books only have the publisher and the language names, those match the single filter value
+			if valueCount > 1 {
+				return ""
+			}
+			if filterParam == "publisher" {
+				return publication.Publisher
+			}
+			return publication.Language
+		}
+		if index := slices.Index(ids, id); index >= 0 && len(ids) == len(names) {
+			return names[index]
+		}
+	}
+
+	return ""
+}
+
+// hiddenInputs - returns the facet filter query parameters, so the search form keeps them
+func hiddenInputs(query url.Values) []hiddenInput {
+	var inputs []hiddenInput
+	for _, facet := range catalog.Facets {
+		for _, value := range query[facet.FilterParam] {
+			inputs = append(inputs, hiddenInput{Name: facet.FilterParam, Value: value})
+		}
+	}
+
+	return inputs
+}
@@ -0,0 +1,27 @@
+package web
+
+import (
+	"embed"
+	"html/template"
+)
+
+const (
+	group = "/ui"
+)
+
+//go:embed templates/*.html
+var templateFiles embed.FS
+
+//go:embed static
+var staticFiles embed.FS
+
+// pages - the page templates, each one is parsed along with the shared layout
+var pages = map[string]*template.Template{
+	"books": parsePage("books.html"),
+	"book":  parsePage("book.html"),
+	"facet": parsePage("facet.html"),
+}
+
+func parsePage(fileName string) *template.Template {
+	return template.Must(template.ParseFS(templateFiles, "templates/layout.html", "templates/"+fileName))
+}
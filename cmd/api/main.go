@@ -3,9 +3,12 @@ package main
 import (
 	"context"
 	"errors"
+	"github.com/jmoiron/sqlx"
 	"github.com/sdreger/lib-manager-go/internal/blobtstore"
 	"github.com/sdreger/lib-manager-go/internal/config"
 	"github.com/sdreger/lib-manager-go/internal/database"
+	"github.com/sdreger/lib-manager-go/internal/domain/webhook"
+	"github.com/sdreger/lib-manager-go/internal/inbox"
 	"log/slog"
 	"os"
 	"os/signal"
@@ -17,9 +20,20 @@ import (
 func main() {
 	// ==================== Initialize Logging ====================
 	minLogLevel := slog.LevelDebug
-	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: minLogLevel}))
+	logOutput := os.Stdout
+	commandArgs := os.Args[1:]
+	if len(commandArgs) > 0 {
+		// keep the standard output clean for the command results
+		logOutput = os.Stderr
+	}
+	logger := slog.New(slog.NewJSONHandler(logOutput, &slog.HandlerOptions{Level: minLogLevel}))
 
-	err := run(logger)
+	var err error
+	if len(commandArgs) > 0 {
+		err = runCommand(logger, commandArgs[0], commandArgs[1:])
+	} else {
+		err = run(logger)
+	}
 	if err != nil {
 		trace := string(debug.Stack())
 		logger.Error("Fatal application error", "error", err.Error(), "trace", trace)
@@ -50,6 +64,53 @@ func run(logger *slog.Logger) (err error) {
 	mainCtx, cancelFunc := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer cancelFunc()
 
+	return withDependencies(mainCtx, logger, appConfig,
+		func(db *sqlx.DB, blobStore *blobtstore.MinioStore) error {
+			// ==================== Start Inbox Importer ====================
+			if appConfig.Inbox.Enabled {
+				inboxCtx, stopInbox := context.WithCancel(mainCtx)
+				inboxDone := make(chan struct{})
+				go func() {
+					defer close(inboxDone)
+					worker := inbox.NewWorker(logger, appConfig.Inbox, appConfig.Ingest, db, blobStore)
+					if err := worker.Run(inboxCtx); err != nil {
+						logger.Error("inbox importer failed", "error", err.Error())
+					}
+				}()
+				// the importer completes the current group before the dependencies are released
+				defer func() {
+					stopInbox()
+					<-inboxDone
+				}()
+			}
+
+			// ==================== Start Webhook Dispatcher ====================
+			if appConfig.Webhooks.DispatcherEnabled {
+				dispatcherCtx, stopDispatcher := context.WithCancel(mainCtx)
+				dispatcherDone := make(chan struct{})
+				go func() {
+					defer close(dispatcherDone)
+					if err := webhook.NewDispatcher(logger, appConfig.Webhooks, db).Run(dispatcherCtx); err != nil {
+						logger.Error("webhook dispatcher failed", "error", err.Error())
+					}
+				}()
+				// the deliveries in flight are recorded before the dependencies are released
+				defer func() {
+					stopDispatcher()
+					<-dispatcherDone
+				}()
+			}
+
+			// ==================== Start HTTP Server ====================
+			return NewServerApp(appConfig, logger, db, blobStore).Serve(mainCtx)
+		})
+}
+
+// withDependencies - initializes the database and the BLOB store, calls the provided function,
+// and releases all the dependencies when the function returns
+func withDependencies(ctx context.Context, logger *slog.Logger, appConfig config.AppConfig,
+	fn func(db *sqlx.DB, blobStore *blobtstore.MinioStore) error) (err error) {
+
 	// ==================== Open DB Connection ====================
 	db, err := database.Open(appConfig.DB)
 	if err != nil {
@@ -81,15 +142,10 @@ func run(logger *slog.Logger) (err error) {
 	}()
 
 	// ==================== Create BLOB storage buckets ====================
-	err = blobStore.CreateBuckets(mainCtx)
+	err = blobStore.CreateBuckets(ctx)
 	if err != nil {
 		return err
 	}
 
-	// ==================== Start HTTP Server ====================
-	if serverAppErr := NewServerApp(appConfig, logger, db, blobStore).Serve(mainCtx); serverAppErr != nil {
-		return serverAppErr
-	}
-
-	return nil
+	return fn(db, blobStore)
 }
@@ -4,12 +4,20 @@ import (
 	"fmt"
 	"github.com/jmoiron/sqlx"
 	"github.com/sdreger/lib-manager-go/cmd/api/handlers"
+	"github.com/sdreger/lib-manager-go/cmd/api/handlers/admin"
+	"github.com/sdreger/lib-manager-go/cmd/api/handlers/dav"
+	"github.com/sdreger/lib-manager-go/cmd/api/handlers/feeds"
+	"github.com/sdreger/lib-manager-go/cmd/api/handlers/graphql"
+	"github.com/sdreger/lib-manager-go/cmd/api/handlers/oai"
+	"github.com/sdreger/lib-manager-go/cmd/api/handlers/opds"
 	"github.com/sdreger/lib-manager-go/cmd/api/handlers/spec"
 	"github.com/sdreger/lib-manager-go/cmd/api/handlers/system"
 	handlersV1 "github.com/sdreger/lib-manager-go/cmd/api/handlers/v1"
+	"github.com/sdreger/lib-manager-go/cmd/api/handlers/web"
 	"github.com/sdreger/lib-manager-go/internal/blobtstore"
 	"github.com/sdreger/lib-manager-go/internal/config"
 	"github.com/sdreger/lib-manager-go/internal/database"
+	"github.com/sdreger/lib-manager-go/internal/event"
 	"github.com/sdreger/lib-manager-go/internal/middleware"
 	"log/slog"
 	"net/http"
@@ -19,24 +27,24 @@ import (
 type Router struct {
 	mux         *http.ServeMux
 	logger      *slog.Logger
-	httpConfig  config.HTTPConfig
+	appConfig   config.AppConfig
 	routesCount atomic.Int32
 	mw          []handlers.Middleware
 }
 
-func NewRouter(logger *slog.Logger, db *sqlx.DB, blobStore *blobtstore.MinioStore,
-	httpConfig config.HTTPConfig) *Router {
+func NewRouter(logger *slog.Logger, db *sqlx.DB, blobStore *blobtstore.MinioStore, broker *event.Broker,
+	appConfig config.AppConfig) *Router {
 
 	router := Router{
 		mux:         http.NewServeMux(),
 		logger:      logger,
-		httpConfig:  httpConfig,
+		appConfig:   appConfig,
 		routesCount: atomic.Int32{},
 		mw:          []handlers.Middleware{},
 	}
 
 	router.registerApplicationMiddlewares()
-	router.registerRouteHandlers(db, blobStore)
+	router.registerRouteHandlers(db, blobStore, broker)
 	logger.Info("router initialized", "registeredRoutes", router.routesCount.Load())
 
 	return &router
@@ -51,21 +59,38 @@ func (router *Router) GetHandler() http.Handler {
 // [appMiddleware] -> ... -> [appMiddleware] -> [handlerMiddleware] -> ... -> [handlerMiddleware] -> [handler]
 func (router *Router) registerApplicationMiddlewares() {
 	// the order matters, first registered - first executed
-	router.AddApplicationMiddleware(middleware.Cors(router.httpConfig))
+	router.AddApplicationMiddleware(middleware.Cors(router.appConfig.HTTP))
+	router.AddApplicationMiddleware(middleware.BaseURL(router.appConfig.HTTP))
 	router.AddApplicationMiddleware(middleware.Errors(router.logger))
 	router.AddApplicationMiddleware(middleware.Panics())
 }
 
 // registerRouteHandlers - init REST controllers, and delegate route handlers registration to them
-func (router *Router) registerRouteHandlers(db *sqlx.DB, blobStore *blobtstore.MinioStore) {
+func (router *Router) registerRouteHandlers(db *sqlx.DB, blobStore *blobtstore.MinioStore, broker *event.Broker) {
 	logger := router.logger
 	// the custom DB data type is only needed for system controller to perform health checks
 	system.NewController(logger, (*database.DB)(db), blobStore).RegisterRoutes(router)
 	spec.NewController(logger).RegisterRoutes(router)
 	handlersV1.NewBookController(logger, db).RegisterRoutes(router)
-	handlersV1.NewCoverController(logger, blobStore).RegisterRoutes(router)
+	handlersV1.NewBookFileController(logger, db, blobStore).RegisterRoutes(router)
+	handlersV1.NewCitationController(logger, db).RegisterRoutes(router)
+	handlersV1.NewCoverController(logger, db, blobStore).RegisterRoutes(router)
+	handlersV1.NewDuplicateController(logger, db).RegisterRoutes(router)
+	handlersV1.NewEnrichController(logger, db, router.appConfig.Metadata).RegisterRoutes(router)
+	handlersV1.NewEventController(logger, broker, router.appConfig.Events).RegisterRoutes(router)
+	handlersV1.NewExportController(logger, db).RegisterRoutes(router)
 	handlersV1.NewFileTypeController(logger, db).RegisterRoutes(router)
+	handlersV1.NewImportController(logger, db).RegisterRoutes(router)
+	handlersV1.NewIngestController(logger, router.appConfig.Ingest, db, blobStore).RegisterRoutes(router)
 	handlersV1.NewPublisherController(logger, db).RegisterRoutes(router)
+	handlersV1.NewWebhookController(logger, db).RegisterRoutes(router)
+	admin.NewCoverAuditController(logger, db, blobStore).RegisterRoutes(router)
+	graphql.NewController(logger, db).RegisterRoutes(router)
+	opds.NewCatalogController(logger, db).RegisterRoutes(router)
+	feeds.NewBookFeedController(logger, db).RegisterRoutes(router)
+	web.NewCatalogController(logger, db).RegisterRoutes(router)
+	oai.NewProviderController(logger, db, router.appConfig.OAI).RegisterRoutes(router)
+	dav.NewShareController(logger, db, blobStore).RegisterRoutes(router)
 }
 
 func (router *Router) AddApplicationMiddleware(mw handlers.Middleware) {
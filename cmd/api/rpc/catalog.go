@@ -0,0 +1,283 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"github.com/jmoiron/sqlx"
+	catalogv1 "github.com/sdreger/lib-manager-go/api/catalog/v1"
+	apiErrors "github.com/sdreger/lib-manager-go/cmd/api/errors"
+	"github.com/sdreger/lib-manager-go/internal/blobtstore"
+	"github.com/sdreger/lib-manager-go/internal/domain/book"
+	"github.com/sdreger/lib-manager-go/internal/domain/cover"
+	"github.com/sdreger/lib-manager-go/internal/domain/filetype"
+	"github.com/sdreger/lib-manager-go/internal/domain/publisher"
+	"github.com/sdreger/lib-manager-go/internal/paging"
+	"io"
+	"log/slog"
+	"net/url"
+	"strconv"
+)
+
+// coverChunkSize - the max cover image chunk size of a single stream message
+const coverChunkSize = 32 * 1024
+
+type BookService interface {
+	GetBookByID(ctx context.Context, bookID int64) (book.Book, error)
+	GetBooks(
+		ctx context.Context,
+		pageRequest paging.PageRequest,
+		sort paging.Sort,
+		filter book.Filter,
+	) (paging.Page[book.LookupItem], error)
+}
+
+type PublisherService interface {
+	GetPublishers(
+		ctx context.Context,
+		pageRequest paging.PageRequest,
+		sort paging.Sort,
+	) (paging.Page[publisher.LookupItem], error)
+}
+
+type FileTypeService interface {
+	GetFileTypes(
+		ctx context.Context,
+		pageRequest paging.PageRequest,
+		sort paging.Sort,
+	) (paging.Page[filetype.LookupItem], error)
+}
+
+type CoverService interface {
+	GetCoverByBookID(ctx context.Context, bookID int64, placeholderFormat cover.PlaceholderFormat) (
+		cover.CoverFile, error)
+}
+
+// CatalogServer - the gRPC catalog service, it mirrors the v1 REST API read endpoints
+type CatalogServer struct {
+	catalogv1.UnimplementedCatalogServiceServer
+	logger           *slog.Logger
+	bookService      BookService
+	publisherService PublisherService
+	fileTypeService  FileTypeService
+	coverService     CoverService
+}
+
+func NewCatalogServer(logger *slog.Logger, db *sqlx.DB, blobStore *blobtstore.MinioStore) *CatalogServer {
+	return &CatalogServer{
+		logger:           logger,
+		bookService:      book.NewService(logger, db),
+		publisherService: publisher.NewService(logger, db),
+		fileTypeService:  filetype.NewService(logger, db),
+		coverService:     cover.NewService(logger, db, blobStore),
+	}
+}
+
+func (srv *CatalogServer) GetBook(ctx context.Context, req *catalogv1.GetBookRequest) (
+	*catalogv1.GetBookResponse, error) {
+
+	if err := validateBookID(req.GetBookId()); err != nil {
+		return nil, err
+	}
+
+	bookEntry, err := srv.bookService.GetBookByID(ctx, req.GetBookId())
+	if errors.Is(err, book.ErrNotFound) {
+		return nil, apiErrors.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &catalogv1.GetBookResponse{Book: toBook(bookEntry)}, nil
+}
+
+func (srv *CatalogServer) ListBooks(ctx context.Context, req *catalogv1.ListBooksRequest) (
+	*catalogv1.ListBooksResponse, error) {
+
+	values := pageValues(req.GetPage())
+	addFilterValues(values, req.GetFilter())
+	page, sort, err := newPaging(values, book.AllowedSortFields)
+	if err != nil {
+		return nil, err
+	}
+	filter, err := book.NewFilter(values)
+	if err != nil {
+		return nil, err
+	}
+
+	bookPage, err := srv.bookService.GetBooks(ctx, page, sort, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	books := make([]*catalogv1.BookItem, 0, len(bookPage.Content))
+	for _, item := range bookPage.Content {
+		books = append(books, toBookItem(item))
+	}
+
+	return &catalogv1.ListBooksResponse{Books: books, Page: toPageInfo(bookPage)}, nil
+}
+
+func (srv *CatalogServer) ListPublishers(ctx context.Context, req *catalogv1.ListPublishersRequest) (
+	*catalogv1.ListPublishersResponse, error) {
+
+	page, sort, err := newPaging(pageValues(req.GetPage()), publisher.AllowedSortFields)
+	if err != nil {
+		return nil, err
+	}
+
+	publisherPage, err := srv.publisherService.GetPublishers(ctx, page, sort)
+	if err != nil {
+		return nil, err
+	}
+
+	publishers := make([]*catalogv1.Publisher, 0, len(publisherPage.Content))
+	for _, item := range publisherPage.Content {
+		publishers = append(publishers, &catalogv1.Publisher{Id: item.ID, Name: item.Name})
+	}
+
+	return &catalogv1.ListPublishersResponse{Publishers: publishers, Page: toPageInfo(publisherPage)}, nil
+}
+
+func (srv *CatalogServer) ListFileTypes(ctx context.Context, req *catalogv1.ListFileTypesRequest) (
+	*catalogv1.ListFileTypesResponse, error) {
+
+	page, sort, err := newPaging(pageValues(req.GetPage()), filetype.AllowedSortFields)
+	if err != nil {
+		return nil, err
+	}
+
+	fileTypePage, err := srv.fileTypeService.GetFileTypes(ctx, page, sort)
+	if err != nil {
+		return nil, err
+	}
+
+	fileTypes := make([]*catalogv1.FileType, 0, len(fileTypePage.Content))
+	for _, item := range fileTypePage.Content {
+		fileTypes = append(fileTypes, &catalogv1.FileType{Id: item.ID, Name: item.Name})
+	}
+
+	return &catalogv1.ListFileTypesResponse{FileTypes: fileTypes, Page: toPageInfo(fileTypePage)}, nil
+}
+
+// StreamCover - streams the book cover in chunks, the first message also carries the content type and the ETag.
+// The cover without any content is streamed as a single message without the chunk
+func (srv *CatalogServer) StreamCover(req *catalogv1.StreamCoverRequest,
+	stream catalogv1.CatalogService_StreamCoverServer) error {
+
+	if err := validateBookID(req.GetBookId()); err != nil {
+		return err
+	}
+
+	values := url.Values{}
+	if req.GetGeneratedFallback() {
+		values.Set("fallback", "generated")
+		values.Set("format", req.GetPlaceholderFormat())
+	}
+	placeholderFormat, err := cover.NewPlaceholderFormat(values)
+	if err != nil {
+		return err
+	}
+
+	coverFile, err := srv.coverService.GetCoverByBookID(stream.Context(), req.GetBookId(), placeholderFormat)
+	if errors.Is(err, cover.ErrNotFound) {
+		return apiErrors.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if closer, ok := coverFile.Content.(io.Closer); ok {
+		defer func() {
+			_ = closer.Close()
+		}()
+	}
+
+	message := &catalogv1.StreamCoverResponse{ContentType: coverFile.ContentType, Etag: coverFile.ETag}
+	buffer := make([]byte, coverChunkSize)
+	sent := false
+	for {
+		n, readErr := io.ReadFull(coverFile.Content, buffer)
+		if n > 0 {
+			message.Chunk = buffer[:n]
+			if err := stream.Send(message); err != nil {
+				return err
+			}
+			message = &catalogv1.StreamCoverResponse{}
+			sent = true
+		}
+		if errors.Is(readErr, io.EOF) || errors.Is(readErr, io.ErrUnexpectedEOF) {
+			break
+		}
+		if readErr != nil {
+			return readErr
+		}
+	}
+	if !sent {
+		return stream.Send(message)
+	}
+
+	return nil
+}
+
+func validateBookID(bookID int64) error {
+	if bookID < 1 {
+		return apiErrors.ValidationError{
+			Field:   "book_id",
+			Message: "the book_id should be greater than or equal to 1",
+		}
+	}
+
+	return nil
+}
+
+// pageValues - the query values of the page request, those are validated the same way as the REST ones.
+// The zero values are omitted, so the defaults are applied
+func pageValues(page *catalogv1.PageRequest) url.Values {
+	values := url.Values{}
+	if page.GetPage() > 0 {
+		values.Set("page", strconv.FormatUint(uint64(page.GetPage()), 10))
+	}
+	if page.GetSize() > 0 {
+		values.Set("size", strconv.FormatUint(uint64(page.GetSize()), 10))
+	}
+	if page.GetSort() != "" {
+		values.Set("sort", page.GetSort())
+	}
+
+	return values
+}
+
+// addFilterValues - adds the book filter to the query values, those are validated the same way as the REST ones
+func addFilterValues(values url.Values, filter *catalogv1.BookFilter) {
+	if filter.GetQuery() != "" {
+		values.Set("query", filter.GetQuery())
+	}
+	if filter.GetSbn() != "" {
+		values.Set("sbn", filter.GetSbn())
+	}
+	lists := map[string][]int64{
+		"language":  filter.GetLanguages(),
+		"publisher": filter.GetPublishers(),
+		"author":    filter.GetAuthors(),
+		"category":  filter.GetCategories(),
+		"file_type": filter.GetFileTypes(),
+		"tag":       filter.GetTags(),
+	}
+	for param, ids := range lists {
+		for _, id := range ids {
+			values.Add(param, strconv.FormatInt(id, 10))
+		}
+	}
+}
+
+func newPaging(values url.Values, allowedSortFields []string) (paging.PageRequest, paging.Sort, error) {
+	pageRequest, err := paging.NewPageRequest(values)
+	if err != nil {
+		return paging.PageRequest{}, paging.Sort{}, err
+	}
+	sort, err := paging.NewSort(values, allowedSortFields)
+	if err != nil {
+		return paging.PageRequest{}, paging.Sort{}, err
+	}
+
+	return pageRequest, sort, nil
+}
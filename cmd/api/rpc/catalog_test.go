@@ -0,0 +1,324 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	catalogv1 "github.com/sdreger/lib-manager-go/api/catalog/v1"
+	"github.com/sdreger/lib-manager-go/internal/domain/book"
+	"github.com/sdreger/lib-manager-go/internal/domain/cover"
+	"github.com/sdreger/lib-manager-go/internal/domain/filetype"
+	"github.com/sdreger/lib-manager-go/internal/domain/publisher"
+	"github.com/sdreger/lib-manager-go/internal/paging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"io"
+	"log/slog"
+	"net"
+	"net/url"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestCatalogServer_GetBook(t *testing.T) {
+	pubDate := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	bookEntry := book.Book{
+		ID:        1,
+		Title:     "Go in Action",
+		ISBN13:    9781617291784,
+		Pages:     264,
+		Edition:   2,
+		PubDate:   pubDate,
+		Publisher: "Manning",
+		Authors:   []string{"William Kennedy"},
+		FileTypes: []string{"pdf", "epub"},
+	}
+	bookService := NewMockBookService(t)
+	bookService.EXPECT().GetBookByID(mock.Anything, int64(1)).Return(bookEntry, nil)
+	client := getCatalogClient(t, &CatalogServer{bookService: bookService})
+
+	response, err := client.GetBook(context.Background(), &catalogv1.GetBookRequest{BookId: 1})
+	require.NoError(t, err, "should get the book")
+	result := response.GetBook()
+	assert.Equal(t, bookEntry.ID, result.GetId())
+	assert.Equal(t, bookEntry.Title, result.GetTitle())
+	assert.Equal(t, bookEntry.ISBN13, result.GetIsbn13())
+	assert.Equal(t, uint32(264), result.GetPages())
+	assert.Equal(t, uint32(2), result.GetEdition())
+	assert.Equal(t, pubDate, result.GetPubDate().AsTime())
+	assert.Nil(t, result.GetCreatedAt(), "the zero time should be left unset")
+	assert.Equal(t, bookEntry.Publisher, result.GetPublisher())
+	assert.Equal(t, bookEntry.Authors, result.GetAuthors())
+	assert.Equal(t, bookEntry.FileTypes, result.GetFileTypes())
+}
+
+func TestCatalogServer_GetBook_NotFound(t *testing.T) {
+	bookService := NewMockBookService(t)
+	bookService.EXPECT().GetBookByID(mock.Anything, int64(10)).Return(book.Book{}, book.ErrNotFound)
+	client := getCatalogClient(t, &CatalogServer{bookService: bookService})
+
+	_, err := client.GetBook(context.Background(), &catalogv1.GetBookRequest{BookId: 10})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestCatalogServer_GetBook_InvalidID(t *testing.T) {
+	client := getCatalogClient(t, &CatalogServer{bookService: NewMockBookService(t)})
+
+	_, err := client.GetBook(context.Background(), &catalogv1.GetBookRequest{BookId: 0})
+	assertFieldViolation(t, err, "book_id")
+}
+
+func TestCatalogServer_ListBooks(t *testing.T) {
+	expectedPage, expectedSort, expectedFilter := getExpectedBookQuery(t, url.Values{
+		"page": {"2"}, "size": {"5"}, "sort": {"title,desc"},
+		"publisher": {"3"}, "author": {"4", "5"}, "query": {"golang"},
+	})
+	bookPage := paging.NewPage(expectedPage, 6, []book.LookupItem{
+		{ID: 6, Title: "Learning Go", Publisher: "O'Reilly", AuthorIDs: []int64{4}},
+	})
+	bookService := NewMockBookService(t)
+	bookService.EXPECT().GetBooks(mock.Anything, expectedPage, expectedSort, expectedFilter).Return(bookPage, nil)
+	client := getCatalogClient(t, &CatalogServer{bookService: bookService})
+
+	response, err := client.ListBooks(context.Background(), &catalogv1.ListBooksRequest{
+		Page: &catalogv1.PageRequest{Page: 2, Size: 5, Sort: "title,desc"},
+		Filter: &catalogv1.BookFilter{
+			Publishers: []int64{3},
+			Authors:    []int64{4, 5},
+			Query:      "golang",
+		},
+	})
+	require.NoError(t, err, "should list the books")
+	if assert.Len(t, response.GetBooks(), 1) {
+		assert.Equal(t, int64(6), response.GetBooks()[0].GetId())
+		assert.Equal(t, "Learning Go", response.GetBooks()[0].GetTitle())
+		assert.Equal(t, []int64{4}, response.GetBooks()[0].GetAuthorIds())
+	}
+	assert.Equal(t, uint32(2), response.GetPage().GetPage())
+	assert.Equal(t, uint32(1), response.GetPage().GetSize())
+	assert.Equal(t, uint32(2), response.GetPage().GetTotalPages())
+	assert.Equal(t, int64(6), response.GetPage().GetTotalItems())
+}
+
+func TestCatalogServer_ListBooks_Defaults(t *testing.T) {
+	expectedPage, expectedSort, expectedFilter := getExpectedBookQuery(t, url.Values{})
+	bookService := NewMockBookService(t)
+	bookService.EXPECT().GetBooks(mock.Anything, expectedPage, expectedSort, expectedFilter).
+		Return(paging.NewPage(expectedPage, 0, []book.LookupItem{}), nil)
+	client := getCatalogClient(t, &CatalogServer{bookService: bookService})
+
+	response, err := client.ListBooks(context.Background(), &catalogv1.ListBooksRequest{})
+	require.NoError(t, err, "should list the books")
+	assert.Empty(t, response.GetBooks())
+	assert.Equal(t, uint32(1), response.GetPage().GetPage())
+}
+
+func TestCatalogServer_ListBooks_InvalidArguments(t *testing.T) {
+	testCases := []struct {
+		name    string
+		request *catalogv1.ListBooksRequest
+		field   string
+	}{
+		{
+			name:    "sort field",
+			request: &catalogv1.ListBooksRequest{Page: &catalogv1.PageRequest{Sort: "unknown,asc"}},
+			field:   "sort",
+		},
+		{
+			name:    "filter ID",
+			request: &catalogv1.ListBooksRequest{Filter: &catalogv1.BookFilter{Tags: []int64{0}}},
+			field:   "tag",
+		},
+	}
+
+	client := getCatalogClient(t, &CatalogServer{bookService: NewMockBookService(t)})
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := client.ListBooks(context.Background(), tc.request)
+			assertFieldViolation(t, err, tc.field)
+		})
+	}
+}
+
+func TestCatalogServer_ListPublishers(t *testing.T) {
+	values := url.Values{"sort": {"name,asc"}}
+	expectedPage, expectedSort := getExpectedPaging(t, values, publisher.AllowedSortFields)
+	publisherPage := paging.NewPage(expectedPage, 2, []publisher.LookupItem{{ID: 1, Name: "Manning"}, {ID: 2, Name: "O'Reilly"}})
+	publisherService := NewMockPublisherService(t)
+	publisherService.EXPECT().GetPublishers(mock.Anything, expectedPage, expectedSort).Return(publisherPage, nil)
+	client := getCatalogClient(t, &CatalogServer{publisherService: publisherService})
+
+	response, err := client.ListPublishers(context.Background(), &catalogv1.ListPublishersRequest{
+		Page: &catalogv1.PageRequest{Sort: "name,asc"},
+	})
+	require.NoError(t, err, "should list the publishers")
+	if assert.Len(t, response.GetPublishers(), 2) {
+		assert.Equal(t, "Manning", response.GetPublishers()[0].GetName())
+		assert.Equal(t, int64(2), response.GetPublishers()[1].GetId())
+	}
+	assert.Equal(t, int64(2), response.GetPage().GetTotalItems())
+}
+
+func TestCatalogServer_ListFileTypes(t *testing.T) {
+	expectedPage, expectedSort := getExpectedPaging(t, url.Values{"size": {"1"}}, filetype.AllowedSortFields)
+	fileTypePage := paging.NewPage(expectedPage, 3, []filetype.LookupItem{{ID: 1, Name: "pdf"}})
+	fileTypeService := NewMockFileTypeService(t)
+	fileTypeService.EXPECT().GetFileTypes(mock.Anything, expectedPage, expectedSort).Return(fileTypePage, nil)
+	client := getCatalogClient(t, &CatalogServer{fileTypeService: fileTypeService})
+
+	response, err := client.ListFileTypes(context.Background(), &catalogv1.ListFileTypesRequest{
+		Page: &catalogv1.PageRequest{Size: 1},
+	})
+	require.NoError(t, err, "should list the file types")
+	if assert.Len(t, response.GetFileTypes(), 1) {
+		assert.Equal(t, "pdf", response.GetFileTypes()[0].GetName())
+	}
+	assert.Equal(t, uint32(3), response.GetPage().GetTotalPages())
+}
+
+func TestCatalogServer_ListFileTypes_Error(t *testing.T) {
+	expectedPage, expectedSort := getExpectedPaging(t, url.Values{}, filetype.AllowedSortFields)
+	fileTypeService := NewMockFileTypeService(t)
+	fileTypeService.EXPECT().GetFileTypes(mock.Anything, expectedPage, expectedSort).
+		Return(paging.Page[filetype.LookupItem]{}, errors.New("some error"))
+	client := getCatalogClient(t, &CatalogServer{fileTypeService: fileTypeService})
+
+	_, err := client.ListFileTypes(context.Background(), &catalogv1.ListFileTypesRequest{})
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.NotContains(t, err.Error(), "some error", "should not leak the internal error")
+}
+
+func TestCatalogServer_StreamCover(t *testing.T) {
+	content := bytes.Repeat([]byte{0xAB}, 2*coverChunkSize+100)
+	coverService := NewMockCoverService(t)
+	coverService.EXPECT().GetCoverByBookID(mock.Anything, int64(1), cover.PlaceholderFormat("")).
+		Return(cover.CoverFile{Content: bytes.NewReader(content), ContentType: "image/jpeg", ETag: "abc"}, nil)
+	client := getCatalogClient(t, &CatalogServer{coverService: coverService})
+
+	messages := receiveCover(t, client, &catalogv1.StreamCoverRequest{BookId: 1})
+	require.Len(t, messages, 3, "should stream the cover in chunks")
+	assert.Equal(t, "image/jpeg", messages[0].GetContentType())
+	assert.Equal(t, "abc", messages[0].GetEtag())
+	assert.Empty(t, messages[1].GetContentType(), "only the first message should carry the content type")
+	var received []byte
+	for _, message := range messages {
+		received = append(received, message.GetChunk()...)
+	}
+	assert.Equal(t, content, received)
+}
+
+func TestCatalogServer_StreamCover_Placeholder(t *testing.T) {
+	coverService := NewMockCoverService(t)
+	coverService.EXPECT().GetCoverByBookID(mock.Anything, int64(1), cover.PlaceholderPNG).
+		Return(cover.CoverFile{Content: bytes.NewReader(nil), ContentType: "image/png"}, nil)
+	client := getCatalogClient(t, &CatalogServer{coverService: coverService})
+
+	messages := receiveCover(t, client, &catalogv1.StreamCoverRequest{
+		BookId:            1,
+		GeneratedFallback: true,
+		PlaceholderFormat: "png",
+	})
+	require.Len(t, messages, 1, "should stream a single message for the empty cover")
+	assert.Equal(t, "image/png", messages[0].GetContentType())
+	assert.Empty(t, messages[0].GetChunk())
+}
+
+func TestCatalogServer_StreamCover_Errors(t *testing.T) {
+	coverService := NewMockCoverService(t)
+	coverService.EXPECT().GetCoverByBookID(mock.Anything, int64(2), cover.PlaceholderFormat("")).
+		Return(cover.CoverFile{}, cover.ErrNotFound)
+	client := getCatalogClient(t, &CatalogServer{coverService: coverService})
+
+	stream, err := client.StreamCover(context.Background(), &catalogv1.StreamCoverRequest{BookId: 2})
+	require.NoError(t, err, "should open the stream")
+	_, err = stream.Recv()
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	stream, err = client.StreamCover(context.Background(), &catalogv1.StreamCoverRequest{
+		BookId:            2,
+		GeneratedFallback: true,
+		PlaceholderFormat: "gif",
+	})
+	require.NoError(t, err, "should open the stream")
+	_, err = stream.Recv()
+	assertFieldViolation(t, err, "format")
+}
+
+func getCatalogClient(t *testing.T, catalogServer *CatalogServer) catalogv1.CatalogServiceClient {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	catalogServer.logger = logger
+	listener := bufconn.Listen(1024 * 1024)
+	server := newServer(logger)
+	catalogv1.RegisterCatalogServiceServer(server, catalogServer)
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(server.Stop)
+
+	connection, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err, "should create a client connection")
+	t.Cleanup(func() {
+		_ = connection.Close()
+	})
+
+	return catalogv1.NewCatalogServiceClient(connection)
+}
+
+func getExpectedBookQuery(t *testing.T, values url.Values) (paging.PageRequest, paging.Sort, book.Filter) {
+	page, sort := getExpectedPaging(t, values, book.AllowedSortFields)
+	filter, err := book.NewFilter(values)
+	require.NoError(t, err, "should create a filter")
+
+	return page, sort, filter
+}
+
+func getExpectedPaging(t *testing.T, values url.Values, allowedSortFields []string) (paging.PageRequest, paging.Sort) {
+	page, err := paging.NewPageRequest(values)
+	require.NoError(t, err, "should create a page request")
+	sort, err := paging.NewSort(values, allowedSortFields)
+	require.NoError(t, err, "should create a sort")
+
+	return page, sort
+}
+
+func receiveCover(t *testing.T, client catalogv1.CatalogServiceClient,
+	request *catalogv1.StreamCoverRequest) []*catalogv1.StreamCoverResponse {
+
+	stream, err := client.StreamCover(context.Background(), request)
+	require.NoError(t, err, "should open the stream")
+
+	var messages []*catalogv1.StreamCoverResponse
+	for {
+		message, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return messages
+		}
+		require.NoError(t, err, "should receive the cover")
+		messages = append(messages, message)
+	}
+}
+
+func assertFieldViolation(t *testing.T, err error, field string) {
+	statusError, ok := status.FromError(err)
+	require.True(t, ok, "should return a gRPC status")
+	require.Equal(t, codes.InvalidArgument, statusError.Code())
+	require.Len(t, statusError.Details(), 1, "should return the bad request details")
+	badRequest, ok := statusError.Details()[0].(*errdetails.BadRequest)
+	require.True(t, ok, "should return the bad request details")
+	if assert.Len(t, badRequest.GetFieldViolations(), 1) {
+		assert.Equal(t, field, badRequest.GetFieldViolations()[0].GetField())
+	}
+}
@@ -0,0 +1,129 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	apiErrors "github.com/sdreger/lib-manager-go/cmd/api/errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log/slog"
+	"runtime/debug"
+)
+
+// UnaryErrors - converts the unary call errors to the gRPC statuses, the same way the HTTP errors middleware
+// converts them to the HTTP responses. The unexpected errors are reported with the trace
+func UnaryErrors(logger *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return nil, handleError(logger, info.FullMethod, err)
+		}
+
+		return resp, nil
+	}
+}
+
+// StreamErrors - converts the streaming call errors to the gRPC statuses, see UnaryErrors
+func StreamErrors(logger *slog.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := handler(srv, ss); err != nil {
+			return handleError(logger, info.FullMethod, err)
+		}
+
+		return nil
+	}
+}
+
+// UnaryPanics - converts the unary call panics to errors.
+// Should be registered as the last interceptor, right before the handler
+func UnaryPanics() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (
+		resp any, err error) {
+
+		defer func() {
+			if rec := recover(); rec != nil {
+				err = fmt.Errorf("recover from panic: %v", rec)
+			}
+		}()
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamPanics - converts the streaming call panics to errors, see UnaryPanics
+func StreamPanics() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (
+		err error) {
+
+		defer func() {
+			if rec := recover(); rec != nil {
+				err = fmt.Errorf("recover from panic: %v", rec)
+			}
+		}()
+
+		return handler(srv, ss)
+	}
+}
+
+// handleError - returns the gRPC status of the error, and reports it.
+// The errors which already are gRPC statuses, e.g. the cancelled stream ones, are returned as is
+func handleError(logger *slog.Logger, method string, err error) error {
+	var validationError apiErrors.ValidationError
+	var validationErrors apiErrors.ValidationErrors
+	var statusError error
+	var unexpectedError bool
+	switch {
+	case errors.As(err, &validationError):
+		statusError = invalidArgument(apiErrors.ValidationErrors{validationError})
+	case errors.As(err, &validationErrors):
+		statusError = invalidArgument(validationErrors)
+	case errors.Is(err, apiErrors.ErrNotFound):
+		statusError = status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, apiErrors.ErrUnavailable):
+		statusError = status.Error(codes.Unavailable, apiErrors.ErrUnavailable.Error())
+	default:
+		if _, ok := status.FromError(err); ok {
+			statusError = err
+		} else {
+			statusError = status.Error(codes.Internal, "internal server error")
+			unexpectedError = true
+		}
+	}
+
+	reportServerError(logger, method, err, unexpectedError)
+
+	return statusError
+}
+
+// invalidArgument - returns the 'InvalidArgument' status, with the validation errors as the field violations
+func invalidArgument(validationErrors apiErrors.ValidationErrors) error {
+	violations := make([]*errdetails.BadRequest_FieldViolation, 0, len(validationErrors))
+	for _, validationError := range validationErrors {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       validationError.Field,
+			Description: validationError.Message,
+		})
+	}
+
+	invalidStatus := status.New(codes.InvalidArgument, validationErrors.Error())
+	detailedStatus, err := invalidStatus.WithDetails(&errdetails.BadRequest{FieldViolations: violations})
+	if err != nil {
+		return invalidStatus.Err()
+	}
+
+	return detailedStatus.Err()
+}
+
+func reportServerError(logger *slog.Logger, method string, err error, isUnexpected bool) {
+	var requestGroup slog.Attr
+	if isUnexpected {
+		trace := string(debug.Stack())
+		requestGroup = slog.Group("request", "method", method, "trace", trace)
+	} else {
+		requestGroup = slog.Group("request", "method", method)
+	}
+	logger.Error(err.Error(), requestGroup)
+}
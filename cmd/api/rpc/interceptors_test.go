@@ -0,0 +1,90 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	apiErrors "github.com/sdreger/lib-manager-go/cmd/api/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log/slog"
+	"os"
+	"testing"
+)
+
+func TestUnaryErrors(t *testing.T) {
+	testCases := []struct {
+		name         string
+		err          error
+		expectedCode codes.Code
+	}{
+		{name: "validation error", err: apiErrors.ValidationError{Field: "page"}, expectedCode: codes.InvalidArgument},
+		{
+			name:         "validation errors",
+			err:          apiErrors.ValidationErrors{{Field: "page"}, {Field: "size"}},
+			expectedCode: codes.InvalidArgument,
+		},
+		{name: "not found", err: fmt.Errorf("book: %w", apiErrors.ErrNotFound), expectedCode: codes.NotFound},
+		{name: "unavailable", err: apiErrors.ErrUnavailable, expectedCode: codes.Unavailable},
+		{name: "status", err: status.Error(codes.Canceled, "canceled"), expectedCode: codes.Canceled},
+		{name: "unexpected", err: errors.New("some error"), expectedCode: codes.Internal},
+	}
+
+	interceptor := UnaryErrors(slog.New(slog.NewJSONHandler(os.Stdout, nil)))
+	info := &grpc.UnaryServerInfo{FullMethod: "/test/Method"}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := interceptor(context.Background(), nil, info, func(ctx context.Context, req any) (any, error) {
+				return nil, tc.err
+			})
+			assert.Equal(t, tc.expectedCode, status.Code(err))
+		})
+	}
+}
+
+func TestUnaryErrors_ValidationDetails(t *testing.T) {
+	interceptor := UnaryErrors(slog.New(slog.NewJSONHandler(os.Stdout, nil)))
+	info := &grpc.UnaryServerInfo{FullMethod: "/test/Method"}
+	_, err := interceptor(context.Background(), nil, info, func(ctx context.Context, req any) (any, error) {
+		return nil, apiErrors.ValidationErrors{{Field: "page", Message: "wrong page"}, {Field: "size"}}
+	})
+
+	statusError, ok := status.FromError(err)
+	require.True(t, ok, "should return a gRPC status")
+	require.Len(t, statusError.Details(), 1, "should return the bad request details")
+	assert.Contains(t, statusError.Message(), "page: wrong page")
+}
+
+func TestUnaryErrors_NoError(t *testing.T) {
+	interceptor := UnaryErrors(slog.New(slog.NewJSONHandler(os.Stdout, nil)))
+	info := &grpc.UnaryServerInfo{FullMethod: "/test/Method"}
+	resp, err := interceptor(context.Background(), nil, info, func(ctx context.Context, req any) (any, error) {
+		return "OK", nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "OK", resp)
+}
+
+func TestUnaryPanics(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: "/test/Method"}
+	_, err := UnaryPanics()(context.Background(), nil, info, func(ctx context.Context, req any) (any, error) {
+		panic("some panic")
+	})
+
+	require.Error(t, err, "should convert the panic to an error")
+	assert.Contains(t, err.Error(), "some panic")
+}
+
+func TestStreamPanics(t *testing.T) {
+	info := &grpc.StreamServerInfo{FullMethod: "/test/Method"}
+	err := StreamPanics()(nil, nil, info, func(srv any, stream grpc.ServerStream) error {
+		panic("some panic")
+	})
+
+	require.Error(t, err, "should convert the panic to an error")
+	assert.Contains(t, err.Error(), "some panic")
+}
@@ -0,0 +1,29 @@
+package rpc
+
+import (
+	"github.com/jmoiron/sqlx"
+	catalogv1 "github.com/sdreger/lib-manager-go/api/catalog/v1"
+	"github.com/sdreger/lib-manager-go/internal/blobtstore"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+	"log/slog"
+)
+
+// NewServer - creates the gRPC server, and registers the API services on it.
+// The server reflection is enabled, so the generic clients can discover the services
+func NewServer(logger *slog.Logger, db *sqlx.DB, blobStore *blobtstore.MinioStore) *grpc.Server {
+	server := newServer(logger)
+	catalogv1.RegisterCatalogServiceServer(server, NewCatalogServer(logger, db, blobStore))
+	reflection.Register(server)
+
+	return server
+}
+
+// newServer - creates the gRPC server with the application-wide interceptors
+func newServer(logger *slog.Logger) *grpc.Server {
+	// the order matters, first registered - first executed
+	return grpc.NewServer(
+		grpc.ChainUnaryInterceptor(UnaryErrors(logger), UnaryPanics()),
+		grpc.ChainStreamInterceptor(StreamErrors(logger), StreamPanics()),
+	)
+}
@@ -0,0 +1,88 @@
+package rpc
+
+import (
+	catalogv1 "github.com/sdreger/lib-manager-go/api/catalog/v1"
+	"github.com/sdreger/lib-manager-go/internal/domain/book"
+	"github.com/sdreger/lib-manager-go/internal/paging"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"time"
+)
+
+func toBook(entry book.Book) *catalogv1.Book {
+	return &catalogv1.Book{
+		Id:                 entry.ID,
+		Title:              entry.Title,
+		Subtitle:           entry.Subtitle,
+		Description:        entry.Description,
+		Isbn10:             entry.ISBN10,
+		Isbn13:             entry.ISBN13,
+		Asin:               entry.ASIN,
+		Pages:              uint32(entry.Pages),
+		PublisherUrl:       entry.PublisherURL,
+		Edition:            uint32(entry.Edition),
+		PubDate:            toTimestamp(entry.PubDate),
+		BookFileName:       entry.BookFileName,
+		BookFileSize:       entry.BookFileSize,
+		CoverFileName:      entry.CoverFileName,
+		CoverHash:          entry.CoverHash,
+		CoverWidth:         int32(entry.CoverWidth),
+		CoverHeight:        int32(entry.CoverHeight),
+		CoverAspectRatio:   entry.CoverAspectRatio,
+		CoverDominantColor: entry.CoverDominantColor,
+		CoverBlurhash:      entry.CoverBlurHash,
+		Language:           entry.Language,
+		Publisher:          entry.Publisher,
+		Authors:            entry.Authors,
+		Categories:         entry.Categories,
+		FileTypes:          entry.FileTypes,
+		Tags:               entry.Tags,
+		CreatedAt:          toTimestamp(entry.CreatedAt),
+		UpdatedAt:          toTimestamp(entry.UpdatedAt),
+	}
+}
+
+func toBookItem(item book.LookupItem) *catalogv1.BookItem {
+	return &catalogv1.BookItem{
+		Id:                 item.ID,
+		Title:              item.Title,
+		Subtitle:           item.Subtitle,
+		Isbn10:             item.ISBN10,
+		Isbn13:             item.ISBN13,
+		Asin:               item.ASIN,
+		Pages:              uint32(item.Pages),
+		Edition:            uint32(item.Edition),
+		PubDate:            toTimestamp(item.PubDate),
+		BookFileSize:       item.BookFileSize,
+		CoverFileName:      item.CoverFileName,
+		CoverHash:          item.CoverHash,
+		CoverWidth:         int32(item.CoverWidth),
+		CoverHeight:        int32(item.CoverHeight),
+		CoverAspectRatio:   item.CoverAspectRatio,
+		CoverDominantColor: item.CoverDominantColor,
+		CoverBlurhash:      item.CoverBlurHash,
+		Publisher:          item.Publisher,
+		Language:           item.Language,
+		AuthorIds:          item.AuthorIDs,
+		CategoryIds:        item.CategoryIDs,
+		FileTypeIds:        item.FileTypeIDs,
+		TagIds:             item.TagIDs,
+	}
+}
+
+func toPageInfo[T any](page paging.Page[T]) *catalogv1.PageInfo {
+	return &catalogv1.PageInfo{
+		Page:       uint32(page.Page),
+		Size:       uint32(page.Size),
+		TotalPages: uint32(page.TotalPages),
+		TotalItems: page.TotalItems,
+	}
+}
+
+// toTimestamp - returns the protobuf timestamp of the time, the zero time is left unset
+func toTimestamp(value time.Time) *timestamppb.Timestamp {
+	if value.IsZero() {
+		return nil
+	}
+
+	return timestamppb.New(value)
+}
@@ -5,29 +5,60 @@ import (
 	"errors"
 	"fmt"
 	"github.com/jmoiron/sqlx"
+	"github.com/sdreger/lib-manager-go/cmd/api/rpc"
 	"github.com/sdreger/lib-manager-go/internal/blobtstore"
 	"github.com/sdreger/lib-manager-go/internal/config"
+	"github.com/sdreger/lib-manager-go/internal/event"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
 	"log/slog"
+	"net"
 	"net/http"
+	"time"
 )
 
+var errGRPCShutdownTimeout = errors.New("the in-flight calls have not completed in time, the server is stopped")
+
 type ServerApp struct {
-	config config.AppConfig
-	logger *slog.Logger
-	router *Router
+	config     config.AppConfig
+	logger     *slog.Logger
+	router     *Router
+	grpcServer *grpc.Server
+	broker     *event.Broker
 }
 
 func NewServerApp(config config.AppConfig, logger *slog.Logger, db *sqlx.DB,
 	blobStore *blobtstore.MinioStore) *ServerApp {
 
+	broker := event.NewBroker(logger, config.DB, db)
 	return &ServerApp{
-		config: config,
-		logger: logger,
-		router: NewRouter(logger, db, blobStore, config.HTTP),
+		config:     config,
+		logger:     logger,
+		router:     NewRouter(logger, db, blobStore, broker, config),
+		grpcServer: rpc.NewServer(logger, db, blobStore),
+		broker:     broker,
 	}
 }
 
+// Serve - runs the HTTP and the gRPC servers, until the context is closed, then shuts both down gracefully.
+// If either server fails, the other one is shut down as well. The change events broker runs along with them,
+// once it stops, the open event streams are closed, so they do not hold the HTTP server shutdown
 func (app *ServerApp) Serve(ctx context.Context) error {
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.Go(func() error {
+		return app.serveHTTP(groupCtx)
+	})
+	group.Go(func() error {
+		return app.serveGRPC(groupCtx)
+	})
+	group.Go(func() error {
+		return app.broker.Run(groupCtx)
+	})
+
+	return group.Wait()
+}
+
+func (app *ServerApp) serveHTTP(ctx context.Context) error {
 	appConfig := app.config
 	server := &http.Server{
 		Addr:         fmt.Sprintf("%s:%d", appConfig.HTTP.Host, appConfig.HTTP.Port),
@@ -63,3 +94,41 @@ func (app *ServerApp) Serve(ctx context.Context) error {
 
 	return nil
 }
+
+func (app *ServerApp) serveGRPC(ctx context.Context) error {
+	grpcConfig := app.config.GRPC
+	listener, err := net.Listen("tcp", fmt.Sprintf("%s:%d", grpcConfig.Host, grpcConfig.Port))
+	if err != nil {
+		return fmt.Errorf("gRPC server error: %w", err)
+	}
+
+	shutdownErrorChan := make(chan error, 1)
+	go func() {
+		<-ctx.Done()
+		app.logger.Info("graceful gRPC server shutdown initiated")
+		// the streams still open after the timeout are cancelled
+		timer := time.AfterFunc(grpcConfig.ShutdownTimeout, app.grpcServer.Stop)
+		app.grpcServer.GracefulStop()
+		if !timer.Stop() {
+			shutdownErrorChan <- errGRPCShutdownTimeout
+			return
+		}
+		shutdownErrorChan <- nil
+	}()
+
+	app.logger.Info("starting gRPC server",
+		slog.Group("server", "host", grpcConfig.Host, "port", grpcConfig.Port))
+	serverError := app.grpcServer.Serve(listener)
+	if serverError != nil && !errors.Is(serverError, grpc.ErrServerStopped) {
+		return fmt.Errorf("gRPC server error: %w", serverError)
+	}
+
+	// ==================== Server Shutdown ====================
+	err = <-shutdownErrorChan
+	if err != nil {
+		return fmt.Errorf("graceful gRPC server shutdown error: %w", err)
+	}
+	app.logger.Info("graceful gRPC server shutdown complete")
+
+	return nil
+}
@@ -3,10 +3,15 @@ package main
 import (
 	"context"
 	"fmt"
+	catalogv1 "github.com/sdreger/lib-manager-go/api/catalog/v1"
 	"github.com/sdreger/lib-manager-go/cmd/api/handlers"
 	"github.com/sdreger/lib-manager-go/internal/config"
 	"github.com/stretchr/testify/assert"
 	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 	"io"
 	"log/slog"
 	"math/rand/v2"
@@ -21,10 +26,12 @@ var testResponse = `{"status":"OK"}`
 func TestServerApp_ServeAndShutdown(t *testing.T) {
 	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
 	randomServerPort := getRandomPort()
+	randomGRPCPort := getRandomPort()
 
 	appConfig, err := config.New()
 	if assert.NoError(t, err, "should create a new default config") {
 		appConfig.HTTP.Port = randomServerPort
+		appConfig.GRPC.Port = randomGRPCPort
 		serverApp := NewServerApp(appConfig, logger, nil, nil)
 		ctx, cancel := context.WithCancel(context.Background())
 		defer cancel()
@@ -37,6 +44,7 @@ func TestServerApp_ServeAndShutdown(t *testing.T) {
 
 		time.Sleep(1 * time.Second)
 		checkTestHandlerResponse(t, randomServerPort)
+		checkGRPCResponse(t, randomGRPCPort)
 		cancel()
 
 		err := group.Wait()
@@ -69,6 +77,19 @@ func checkTestHandlerResponse(t *testing.T, serverPort int) {
 	}
 }
 
+// checkGRPCResponse - makes a gRPC call, which is rejected by the validation, so no DB is needed
+func checkGRPCResponse(t *testing.T, serverPort int) {
+	connection, err := grpc.NewClient(fmt.Sprintf("localhost:%d", serverPort),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if assert.NoError(t, err, "should create a gRPC client") {
+		defer connection.Close()
+
+		client := catalogv1.NewCatalogServiceClient(connection)
+		_, err = client.GetBook(context.Background(), &catalogv1.GetBookRequest{BookId: 0})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	}
+}
+
 func getRandomPort() int {
 	maxPort := 65534
 	minPort := 1024
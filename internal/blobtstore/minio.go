@@ -9,13 +9,33 @@ import (
 	"io"
 	"log/slog"
 	"net/http"
+	"strings"
+	"time"
 )
 
+// ErrObjectNotFound - the requested object does not exist in the bucket
+var ErrObjectNotFound = errors.New("object not found")
+
+// Object - a BLOB store object description, returned by listing operations
+type Object struct {
+	Key          string
+	Size         int64
+	ETag         string
+	ContentType  string
+	LastModified time.Time
+}
+
+// IsPrefix - reports whether the object is a common prefix ("directory") rather than a real object
+func (o Object) IsPrefix() bool {
+	return strings.HasSuffix(o.Key, "/")
+}
+
 type MinioStore struct {
 	config                config.BLOBStoreConfig
 	client                *minio.Client
 	logger                *slog.Logger
 	coverBucketName       string
+	fileBucketName        string
 	healthCheckCancelFunc context.CancelFunc
 }
 
@@ -41,12 +61,17 @@ func NewMinioStore(logger *slog.Logger, config config.BLOBStoreConfig) (*MinioSt
 		client:                client,
 		logger:                logger,
 		coverBucketName:       config.BookCoverBucket,
+		fileBucketName:        config.BookFileBucket,
 		healthCheckCancelFunc: cancelFunc,
 	}, nil
 }
 
 func (s *MinioStore) CreateBuckets(ctx context.Context) error {
-	return createBookCoverBucketIfNotExist(ctx, s.logger, s.client, s.coverBucketName)
+	if err := createBucketIfNotExist(ctx, s.logger, s.client, s.coverBucketName, "book cover"); err != nil {
+		return err
+	}
+
+	return createBucketIfNotExist(ctx, s.logger, s.client, s.fileBucketName, "book file")
 }
 
 func (s *MinioStore) CoverExists(ctx context.Context, filePath string) bool {
@@ -59,6 +84,113 @@ func (s *MinioStore) GetBookCover(ctx context.Context, filePath string) (io.Read
 	return s.getObject(ctx, s.coverBucketName, filePath)
 }
 
+func (s *MinioStore) PutCover(ctx context.Context, filePath string, reader io.Reader, size int64,
+	contentType string) error {
+
+	_, err := s.client.PutObject(ctx, s.coverBucketName, filePath, reader, size,
+		minio.PutObjectOptions{ContentType: contentType})
+
+	return err
+}
+
+// ListCovers - returns the cover bucket entries directly under the provided prefix (non-recursive).
+// Nested prefixes are returned as entries with a trailing slash, see Object.IsPrefix
+func (s *MinioStore) ListCovers(ctx context.Context, prefix string) ([]Object, error) {
+	return s.listObjects(ctx, s.coverBucketName, prefix)
+}
+
+func (s *MinioStore) DeleteCover(ctx context.Context, filePath string) error {
+	return s.client.RemoveObject(ctx, s.coverBucketName, filePath, minio.RemoveObjectOptions{})
+}
+
+// MoveCover - copies a cover object to the destination path, and removes the source one
+func (s *MinioStore) MoveCover(ctx context.Context, srcPath string, dstPath string) error {
+	_, err := s.client.CopyObject(ctx,
+		minio.CopyDestOptions{Bucket: s.coverBucketName, Object: dstPath},
+		minio.CopySrcOptions{Bucket: s.coverBucketName, Object: srcPath},
+	)
+	if err != nil {
+		return err
+	}
+
+	return s.DeleteCover(ctx, srcPath)
+}
+
+// GetBookFile - returns a seekable book file object reader, along with the object description.
+// The reader must be closed by the caller
+func (s *MinioStore) GetBookFile(ctx context.Context, filePath string) (io.ReadSeekCloser, Object, error) {
+	object, err := s.getObject(ctx, s.fileBucketName, filePath)
+	if err != nil {
+		return nil, Object{}, err
+	}
+
+	// the object is fetched lazily, the stats request surfaces a missing object error early
+	objectInfo, err := object.Stat()
+	if err != nil {
+		_ = object.Close()
+		if errors.As(err, &minio.ErrorResponse{}) && err.(minio.ErrorResponse).StatusCode == http.StatusNotFound {
+			return nil, Object{}, ErrObjectNotFound
+		}
+		return nil, Object{}, err
+	}
+
+	return object, Object{
+		Key:          objectInfo.Key,
+		Size:         objectInfo.Size,
+		ETag:         objectInfo.ETag,
+		ContentType:  objectInfo.ContentType,
+		LastModified: objectInfo.LastModified,
+	}, nil
+}
+
+func (s *MinioStore) PutBookFile(ctx context.Context, filePath string, reader io.Reader, size int64,
+	contentType string) error {
+
+	_, err := s.client.PutObject(ctx, s.fileBucketName, filePath, reader, size,
+		minio.PutObjectOptions{ContentType: contentType})
+
+	return err
+}
+
+func (s *MinioStore) DeleteBookFile(ctx context.Context, filePath string) error {
+	return s.client.RemoveObject(ctx, s.fileBucketName, filePath, minio.RemoveObjectOptions{})
+}
+
+// MoveBookFile - copies a book file object to the destination path, and removes the source one
+func (s *MinioStore) MoveBookFile(ctx context.Context, srcPath string, dstPath string) error {
+	_, err := s.client.CopyObject(ctx,
+		minio.CopyDestOptions{Bucket: s.fileBucketName, Object: dstPath},
+		minio.CopySrcOptions{Bucket: s.fileBucketName, Object: srcPath},
+	)
+	if err != nil {
+		return err
+	}
+
+	return s.DeleteBookFile(ctx, srcPath)
+}
+
+func (s *MinioStore) listObjects(ctx context.Context, bucketName string, prefix string) ([]Object, error) {
+	// cancelling the context stops the listing goroutine, in case of an early return
+	ctx, cancelFunc := context.WithCancel(ctx)
+	defer cancelFunc()
+
+	var objects []Object
+	for objectInfo := range s.client.ListObjects(ctx, bucketName, minio.ListObjectsOptions{Prefix: prefix}) {
+		if objectInfo.Err != nil {
+			return nil, objectInfo.Err
+		}
+		objects = append(objects, Object{
+			Key:          objectInfo.Key,
+			Size:         objectInfo.Size,
+			ETag:         objectInfo.ETag,
+			ContentType:  objectInfo.ContentType,
+			LastModified: objectInfo.LastModified,
+		})
+	}
+
+	return objects, nil
+}
+
 func (s *MinioStore) getObject(ctx context.Context, bucketName string, filePath string) (*minio.Object, error) {
 	return s.client.GetObject(ctx, bucketName, filePath, minio.GetObjectOptions{})
 }
@@ -90,15 +222,15 @@ func getMinioClient(endpoint, accessKeyID, secretAccessKey string, useSSL bool)
 	})
 }
 
-func createBookCoverBucketIfNotExist(ctx context.Context, logger *slog.Logger, client *minio.Client,
-	bucketName string) error {
+func createBucketIfNotExist(ctx context.Context, logger *slog.Logger, client *minio.Client,
+	bucketName string, description string) error {
 
 	exists, err := client.BucketExists(ctx, bucketName)
 	if err != nil {
 		return err
 	}
 	if !exists {
-		logger.Info("creating book cover bucket", slog.String("bucket", bucketName))
+		logger.Info("creating "+description+" bucket", slog.String("bucket", bucketName))
 		return client.MakeBucket(ctx, bucketName, minio.MakeBucketOptions{})
 	}
 
@@ -55,6 +55,55 @@ func TestNewMinioStore(t *testing.T) {
 		assert.False(t, minioStore.CoverExists(ctx, coverPath))
 	})
 
+	t.Run("PutGetDeleteBookFile", func(t *testing.T) {
+		filePath := "1/epub"
+		err := minioStore.PutBookFile(ctx, filePath, bytes.NewBufferString(testSVG), int64(len(testSVG)),
+			"application/epub+zip")
+		require.NoError(t, err, "failed to store book file")
+
+		reader, object, err := minioStore.GetBookFile(ctx, filePath)
+		require.NoError(t, err, "failed to get book file")
+		assert.Equal(t, int64(len(testSVG)), object.Size)
+		assert.Equal(t, "application/epub+zip", object.ContentType)
+
+		_, err = reader.Seek(10, io.SeekStart)
+		require.NoError(t, err, "failed to seek book file")
+		fileContent, err := io.ReadAll(reader)
+		require.NoError(t, err, "failed to read book file")
+		require.NoError(t, reader.Close())
+		assert.Equal(t, []byte(testSVG[10:]), fileContent)
+
+		err = minioStore.DeleteBookFile(ctx, filePath)
+		require.NoError(t, err, "failed to delete book file")
+		_, _, err = minioStore.GetBookFile(ctx, filePath)
+		assert.ErrorIs(t, err, ErrObjectNotFound)
+	})
+
+	t.Run("ListMoveDeleteCovers", func(t *testing.T) {
+		require.NoError(t, storeBookCover(ctx, minioStore, "manning/1111111111.svg", testSVG))
+		require.NoError(t, storeBookCover(ctx, minioStore, "manning/2222222222.svg", testSVG))
+
+		objects, err := minioStore.ListCovers(ctx, "")
+		require.NoError(t, err, "failed to list root prefixes")
+		assert.Contains(t, objects, Object{Key: "manning/"})
+
+		objects, err = minioStore.ListCovers(ctx, "manning/")
+		require.NoError(t, err, "failed to list covers")
+		require.Len(t, objects, 2)
+		assert.Equal(t, "manning/1111111111.svg", objects[0].Key)
+		assert.Equal(t, int64(len(testSVG)), objects[0].Size)
+		assert.False(t, objects[0].IsPrefix())
+
+		err = minioStore.MoveCover(ctx, "manning/1111111111.svg", "oreilly/1111111111.svg")
+		require.NoError(t, err, "failed to move cover")
+		assert.False(t, minioStore.CoverExists(ctx, "manning/1111111111.svg"))
+		assert.True(t, minioStore.CoverExists(ctx, "oreilly/1111111111.svg"))
+
+		err = minioStore.DeleteCover(ctx, "manning/2222222222.svg")
+		require.NoError(t, err, "failed to delete cover")
+		assert.False(t, minioStore.CoverExists(ctx, "manning/2222222222.svg"))
+	})
+
 	t.Run("BucketAlreadyExists", func(t *testing.T) {
 		err = minioStore.CreateBuckets(ctx)
 		require.NoError(t, err, "bucket creation is idempotent")
@@ -0,0 +1,9 @@
+package calibre
+
+import "errors"
+
+var (
+	ErrInvalidLibrary = errors.New("not a Calibre library, the 'metadata.db' file is not found")
+	ErrNotLinked      = errors.New("the Calibre book is not imported yet")
+	ErrUploadFailed   = errors.New("some book files can not be uploaded")
+)
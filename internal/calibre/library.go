@@ -0,0 +1,177 @@
+package calibre
+
+import (
+	"context"
+	"errors"
+	"github.com/jmoiron/sqlx"
+	"github.com/sdreger/lib-manager-go/internal/domain/book"
+	"github.com/sdreger/lib-manager-go/internal/ingest"
+	"github.com/sdreger/lib-manager-go/internal/isbn"
+	"html"
+	_ "modernc.org/sqlite" // the pure Go Calibre 'metadata.db' SQLite driver, so the binary is built without cgo
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	metadataFileName = "metadata.db"
+	coverFileName    = "cover.jpg"
+)
+
+// asinIdentifiers - the Calibre identifier types holding the Amazon ASIN, in the order of preference
+var asinIdentifiers = []string{"amazon", "asin", "mobi-asin"}
+
+var (
+	htmlBlockRegexp = regexp.MustCompile(`(?i)</(p|div|li|h[1-6])>`)
+	htmlBreakRegexp = regexp.MustCompile(`(?i)<br\s*/?>`)
+	htmlTagRegexp   = regexp.MustCompile(`<[^>]*>`)
+	blankLineRegexp = regexp.MustCompile(`\n\s*\n\s*`)
+)
+
+// openLibrary - opens the Calibre library database in the read-only mode, so a running Calibre is not disturbed
+func openLibrary(libraryDir string) (*sqlx.DB, error) {
+	metadataPath, err := filepath.Abs(filepath.Join(libraryDir, metadataFileName))
+	if err != nil {
+		return nil, err
+	}
+	if info, err := os.Stat(metadataPath); err != nil || !info.Mode().IsRegular() {
+		return nil, ErrInvalidLibrary
+	}
+
+	dsn := url.URL{Scheme: "file", OmitHost: true, Path: filepath.ToSlash(metadataPath), RawQuery: "mode=ro"}
+	return sqlx.Open("sqlite", dsn.String())
+}
+
+// readLibrary - reads all the Calibre books along with their relations, in the Calibre ID order
+func readLibrary(ctx context.Context, db *sqlx.DB) ([]libraryBook, error) {
+	var books []libraryBook
+	query := `SELECT id, uuid, title, path, has_cover, pubdate, last_modified FROM books ORDER BY id`
+	if err := db.SelectContext(ctx, &books, query); err != nil {
+		return nil, errors.Join(ErrInvalidLibrary, err)
+	}
+
+	index := make(map[int64]*libraryBook, len(books))
+	for i := range books {
+		books[i].LastModified = books[i].LastModified.UTC()
+		books[i].Identifiers = make(map[string]string)
+		index[books[i].ID] = &books[i]
+	}
+
+	relations := []struct {
+		query string
+		apply func(book *libraryBook, key string, value string)
+	}{
+		{query: `SELECT l.book, '' AS key, a.name AS value FROM books_authors_link l
+    JOIN authors a ON a.id = l.author ORDER BY l.book, l.id`,
+			apply: func(book *libraryBook, _ string, value string) { book.Authors = append(book.Authors, value) }},
+		{query: `SELECT l.book, '' AS key, t.name AS value FROM books_tags_link l
+    JOIN tags t ON t.id = l.tag ORDER BY l.book, l.id`,
+			apply: func(book *libraryBook, _ string, value string) { book.Tags = append(book.Tags, value) }},
+		{query: `SELECT l.book, '' AS key, s.name AS value FROM books_series_link l
+    JOIN series s ON s.id = l.series ORDER BY l.book, l.id`,
+			apply: func(book *libraryBook, _ string, value string) { book.Series = append(book.Series, value) }},
+		{query: `SELECT l.book, '' AS key, p.name AS value FROM books_publishers_link l
+    JOIN publishers p ON p.id = l.publisher ORDER BY l.book, l.id`,
+			apply: func(book *libraryBook, _ string, value string) { book.Publishers = append(book.Publishers, value) }},
+		{query: `SELECT l.book, '' AS key, lang.lang_code AS value FROM books_languages_link l
+    JOIN languages lang ON lang.id = l.lang_code ORDER BY l.book, l.item_order`,
+			apply: func(book *libraryBook, _ string, value string) { book.Languages = append(book.Languages, value) }},
+		{query: `SELECT book, LOWER(type) AS key, val AS value FROM identifiers ORDER BY book, id`,
+			apply: func(book *libraryBook, key string, value string) { book.Identifiers[key] = value }},
+		{query: `SELECT book, '' AS key, text AS value FROM comments`,
+			apply: func(book *libraryBook, _ string, value string) { book.Comment = value }},
+		{query: `SELECT book, LOWER(format) AS key, name AS value FROM data ORDER BY book, id`,
+			apply: func(book *libraryBook, key string, value string) {
+				book.Formats = append(book.Formats, libraryFormat{Format: key, Name: value})
+			}},
+	}
+	for _, relation := range relations {
+		var rows []struct {
+			Book  int64  `db:"book"`
+			Key   string `db:"key"`
+			Value string `db:"value"`
+		}
+		if err := db.SelectContext(ctx, &rows, relation.query); err != nil {
+			return nil, errors.Join(ErrInvalidLibrary, err)
+		}
+		for _, row := range rows {
+			if target, ok := index[row.Book]; ok {
+				relation.apply(target, row.Key, row.Value)
+			}
+		}
+	}
+
+	return books, nil
+}
+
+// toBook - converts the Calibre book to the book record. The schema has no series, so the series are kept as tags.
+// The invalid identifiers are dropped with a warning
+func (b libraryBook) toBook(libraryDir string) (book.Book, []string) {
+	var warnings []string
+	result := book.Book{
+		Title:       strings.TrimSpace(b.Title),
+		Description: plainText(b.Comment),
+		Authors:     b.Authors,
+		Tags:        append(append([]string(nil), b.Tags...), b.Series...),
+	}
+	// Calibre stores the unknown publication date as the year 101
+	if b.PubDate.Valid && b.PubDate.Time.Year() > 101 {
+		result.PubDate = b.PubDate.Time.UTC()
+	}
+	if len(b.Publishers) > 0 {
+		result.Publisher = b.Publishers[0]
+	}
+	if len(b.Languages) > 0 {
+		result.Language = ingest.LanguageName(b.Languages[0])
+	}
+
+	if value, ok := b.Identifiers["isbn"]; ok {
+		isbn10, isbn13, err := isbn.Both(value)
+		if err != nil {
+			warnings = append(warnings, "the invalid ISBN is ignored: "+value)
+		} else {
+			result.ISBN10 = isbn10
+			result.ISBN13, _ = strconv.ParseInt(isbn13, 10, 64)
+		}
+	}
+	for _, identifier := range asinIdentifiers {
+		if value := strings.TrimSpace(b.Identifiers[identifier]); value != "" {
+			result.ASIN = strings.ToUpper(value)
+			break
+		}
+	}
+
+	for i, format := range b.Formats {
+		result.FileTypes = append(result.FileTypes, format.Format)
+		if i == 0 {
+			result.BookFileName = format.Name + "." + format.Format
+			if info, err := os.Stat(b.formatPath(libraryDir, format)); err == nil {
+				result.BookFileSize = info.Size()
+			}
+		}
+	}
+
+	return result, warnings
+}
+
+func (b libraryBook) formatPath(libraryDir string, format libraryFormat) string {
+	return filepath.Join(libraryDir, filepath.FromSlash(b.Path), format.Name+"."+format.Format)
+}
+
+func (b libraryBook) coverPath(libraryDir string) string {
+	return filepath.Join(libraryDir, filepath.FromSlash(b.Path), coverFileName)
+}
+
+// plainText - converts the Calibre HTML comment to the plain text, keeping the paragraphs
+func plainText(comment string) string {
+	text := htmlBlockRegexp.ReplaceAllString(comment, "\n\n")
+	text = htmlBreakRegexp.ReplaceAllString(text, "\n")
+	text = html.UnescapeString(htmlTagRegexp.ReplaceAllString(text, ""))
+	text = blankLineRegexp.ReplaceAllString(text, "\n\n")
+
+	return strings.TrimSpace(text)
+}
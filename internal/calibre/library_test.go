@@ -0,0 +1,141 @@
+package calibre
+
+import (
+	"context"
+	"database/sql"
+	"github.com/jmoiron/sqlx"
+	"github.com/sdreger/lib-manager-go/internal/domain/book"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const (
+	testUUID = "8b1c2ae0-6a4c-4f0b-9f43-5a0a1b0c2d01"
+
+	testBookDir   = "William Kennedy/Go in Action (1)"
+	testBookName  = "Go in Action - William Kennedy"
+	testNotesDir  = "Unknown/Untitled Notes (2)"
+	testNotesName = "Untitled Notes - Unknown"
+)
+
+func TestReadLibrary(t *testing.T) {
+	libraryDir := createTestLibrary(t)
+	db, err := openLibrary(libraryDir)
+	require.NoError(t, err, "should open the library")
+	defer func() {
+		_ = db.Close()
+	}()
+
+	books, err := readLibrary(context.Background(), db)
+	require.NoError(t, err, "should read the library")
+	require.Len(t, books, 2)
+
+	first := books[0]
+	assert.Equal(t, int64(1), first.ID)
+	assert.Equal(t, testUUID, first.UUID)
+	assert.Equal(t, testBookDir, first.Path)
+	assert.True(t, first.HasCover)
+	assert.Equal(t, time.Date(2024, time.January, 2, 3, 4, 5, 123456000, time.UTC), first.LastModified.UTC())
+	assert.Equal(t, []string{"William Kennedy", "Brian Ketelsen"}, first.Authors, "the author order should be kept")
+	assert.Equal(t, []string{"Programming", "Go"}, first.Tags)
+	assert.Equal(t, []string{"In Action"}, first.Series)
+	assert.Equal(t, []string{"Manning"}, first.Publishers)
+	assert.Equal(t, []string{"eng"}, first.Languages)
+	assert.Equal(t, map[string]string{"isbn": "9781617291784", "amazon": "b00test123"}, first.Identifiers)
+	assert.Equal(t, []libraryFormat{{Format: "epub", Name: testBookName}, {Format: "pdf", Name: testBookName}},
+		first.Formats)
+
+	second := books[1]
+	assert.False(t, second.HasCover)
+	assert.Empty(t, second.Publishers)
+	assert.Empty(t, second.Comment)
+}
+
+func TestOpenLibrary_Invalid(t *testing.T) {
+	_, err := openLibrary(t.TempDir())
+	require.ErrorIs(t, err, ErrInvalidLibrary)
+}
+
+func TestLibraryBook_ToBook(t *testing.T) {
+	libraryDir := createTestLibrary(t)
+	libraryBook := libraryBook{
+		Title:        " Go in Action ",
+		Path:         testBookDir,
+		PubDate:      sql.NullTime{Time: time.Date(2015, time.November, 4, 0, 0, 0, 0, time.UTC), Valid: true},
+		Comment:      "<p>Go in Action introduces the Go language.</p><p>Tips &amp; tricks<br/>included.</p>",
+		Authors:      []string{"William Kennedy", "Brian Ketelsen"},
+		Tags:         []string{"Programming"},
+		Series:       []string{"In Action"},
+		Publishers:   []string{"Manning"},
+		Languages:    []string{"eng"},
+		Identifiers:  map[string]string{"isbn": "978-1-61729-178-4", "mobi-asin": "b00test123"},
+		Formats:      []libraryFormat{{Format: "epub", Name: testBookName}, {Format: "pdf", Name: testBookName}},
+		LastModified: time.Now(),
+	}
+
+	converted, warnings := libraryBook.toBook(libraryDir)
+	assert.Empty(t, warnings)
+	assert.Equal(t, book.Book{
+		Title:        "Go in Action",
+		Description:  "Go in Action introduces the Go language.\n\nTips & tricks\nincluded.",
+		ISBN10:       "1617291781",
+		ISBN13:       9781617291784,
+		ASIN:         "B00TEST123",
+		PubDate:      time.Date(2015, time.November, 4, 0, 0, 0, 0, time.UTC),
+		Language:     "English",
+		Publisher:    "Manning",
+		Authors:      []string{"William Kennedy", "Brian Ketelsen"},
+		Tags:         []string{"Programming", "In Action"},
+		FileTypes:    []string{"epub", "pdf"},
+		BookFileName: testBookName + ".epub",
+		BookFileSize: int64(len("epub content")),
+	}, converted)
+}
+
+func TestLibraryBook_ToBook_Undefined(t *testing.T) {
+	libraryBook := libraryBook{
+		Title:       "Untitled Notes",
+		PubDate:     sql.NullTime{Time: time.Date(101, time.January, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+		Identifiers: map[string]string{"isbn": "1234567890"},
+	}
+
+	converted, warnings := libraryBook.toBook(t.TempDir())
+	assert.Equal(t, []string{"the invalid ISBN is ignored: 1234567890"}, warnings)
+	assert.True(t, converted.PubDate.IsZero(), "the undefined Calibre date should be dropped")
+	assert.Empty(t, converted.ISBN13)
+	assert.Empty(t, converted.BookFileName)
+}
+
+// createTestLibrary - creates the Calibre library with two books: the first one has a cover, EPUB and PDF files,
+// the second one only has a TXT file
+func createTestLibrary(t *testing.T) string {
+	libraryDir := t.TempDir()
+	schema, err := os.ReadFile("testdata/metadata.sql")
+	require.NoError(t, err, "failed to read the library schema")
+
+	db, err := sqlx.Open("sqlite", filepath.Join(libraryDir, metadataFileName))
+	require.NoError(t, err, "failed to create the library database")
+	defer func() {
+		_ = db.Close()
+	}()
+	_, err = db.Exec(string(schema))
+	require.NoError(t, err, "failed to create the library schema")
+
+	files := map[string]string{
+		filepath.Join(testBookDir, testBookName+".epub"):  "epub content",
+		filepath.Join(testBookDir, testBookName+".pdf"):   "pdf content",
+		filepath.Join(testBookDir, coverFileName):         "cover content",
+		filepath.Join(testNotesDir, testNotesName+".txt"): "txt content",
+	}
+	for name, content := range files {
+		path := filepath.Join(libraryDir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+
+	return libraryDir
+}
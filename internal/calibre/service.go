@@ -0,0 +1,216 @@
+package calibre
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"github.com/jmoiron/sqlx"
+	"github.com/sdreger/lib-manager-go/internal/domain/book"
+	"github.com/sdreger/lib-manager-go/internal/domain/bookfile"
+	"github.com/sdreger/lib-manager-go/internal/domain/cover"
+	"io"
+	"log/slog"
+	"os"
+)
+
+// unknownPublisher - the publisher of the new books without one, the same as the inbox importer uses
+const unknownPublisher = "Unknown"
+
+type Store interface {
+	GetLink(ctx context.Context, calibreUUID string) (link, error)
+	SaveLink(ctx context.Context, entity link) error
+}
+
+type BookService interface {
+	FindBookID(ctx context.Context, identifiers book.Identifiers) (int64, error)
+	CreateBook(ctx context.Context, newBook book.Book) (int64, error)
+}
+
+type BookFileService interface {
+	UploadBookFile(ctx context.Context, request bookfile.UploadRequest) (bookfile.BookFile, error)
+}
+
+type CoverService interface {
+	UploadBookCover(ctx context.Context, bookID int64, reader io.Reader) (cover.Cover, error)
+}
+
+type BlobStore interface {
+	cover.BlobStore
+	bookfile.BlobStore
+}
+
+type Service struct {
+	logger          *slog.Logger
+	store           Store
+	bookService     BookService
+	bookFileService BookFileService
+	coverService    CoverService
+}
+
+func NewService(logger *slog.Logger, db *sqlx.DB, blobStore BlobStore) *Service {
+	return &Service{
+		logger:          logger,
+		store:           NewDBStore(db),
+		bookService:     book.NewService(logger, db),
+		bookFileService: bookfile.NewService(logger, db, blobStore),
+		coverService:    cover.NewService(logger, db, blobStore),
+	}
+}
+
+// Import - imports the Calibre library books, along with their covers and files. The import is idempotent:
+// the imported books are linked to the Calibre ones, and are skipped unless modified in Calibre since then.
+// The not linked books are matched by their identifiers, and are only created if there is no match.
+// A failed book does not stop the import, and is retried on the next run
+func (s *Service) Import(ctx context.Context, libraryDir string, options Options) (Report, error) {
+	db, err := openLibrary(libraryDir)
+	if err != nil {
+		return Report{}, err
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+
+	libraryBooks, err := readLibrary(ctx, db)
+	if err != nil {
+		return Report{}, err
+	}
+
+	report := Report{Library: libraryDir, DryRun: options.DryRun, Books: make([]BookReport, 0, len(libraryBooks))}
+	for _, libraryBook := range libraryBooks {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+
+		bookReport := s.importBook(ctx, libraryDir, libraryBook, options)
+		if bookReport.Error != "" {
+			s.logger.Warn("calibre book import failed: "+bookReport.Error, "calibreID", libraryBook.ID)
+		}
+		report.add(bookReport)
+	}
+
+	return report, nil
+}
+
+func (s *Service) importBook(ctx context.Context, libraryDir string, libraryBook libraryBook,
+	options Options) BookReport {
+
+	report := BookReport{CalibreID: libraryBook.ID, Title: libraryBook.Title}
+	fail := func(err error) BookReport {
+		report.Status, report.Error = StatusFailed, err.Error()
+		return report
+	}
+
+	bookLink, err := s.store.GetLink(ctx, libraryBook.UUID)
+	switch {
+	case err == nil && bookLink.LastModified.Equal(libraryBook.LastModified):
+		report.Status, report.BookID = StatusSkipped, bookLink.BookID
+		return report
+	case err == nil:
+		report.Status, report.BookID = StatusMatched, bookLink.BookID
+	case !errors.Is(err, ErrNotLinked):
+		return fail(err)
+	}
+
+	newBook, warnings := libraryBook.toBook(libraryDir)
+	report.Warnings = warnings
+	if report.BookID == 0 {
+		identifiers := book.Identifiers{ISBN10: newBook.ISBN10, ISBN13: newBook.ISBN13, ASIN: newBook.ASIN}
+		bookID, err := s.bookService.FindBookID(ctx, identifiers)
+		switch {
+		case err == nil:
+			report.Status, report.BookID = StatusMatched, bookID
+		case errors.Is(err, book.ErrNotFound):
+			report.Status = StatusCreated
+		default:
+			return fail(err)
+		}
+	}
+	if options.DryRun {
+		return report
+	}
+
+	if report.Status == StatusCreated {
+		if newBook.Language == "" {
+			newBook.Language = options.DefaultLanguage
+			report.Warnings = append(report.Warnings, "the language is unknown, the default one is used")
+		}
+		newBook.Publisher = cmp.Or(newBook.Publisher, unknownPublisher)
+		if report.BookID, err = s.bookService.CreateBook(ctx, newBook); err != nil {
+			return fail(err)
+		}
+		// the created book is linked at once with the zero modification time, which never matches the Calibre one,
+		// so the failed uploads are retried on the next run, without creating a duplicate book
+		if err := s.store.SaveLink(ctx, link{CalibreUUID: libraryBook.UUID, BookID: report.BookID}); err != nil {
+			return fail(err)
+		}
+	}
+
+	if libraryBook.HasCover {
+		if err := s.uploadCover(ctx, report.BookID, libraryBook.coverPath(libraryDir)); err != nil {
+			report.Warnings = append(report.Warnings, "the cover can not be uploaded: "+err.Error())
+		}
+	}
+	uploadFailed := false
+	for _, format := range libraryBook.Formats {
+		err := s.uploadFile(ctx, report.BookID, format.Format, libraryBook.formatPath(libraryDir, format))
+		switch {
+		case errors.Is(err, bookfile.ErrUnknownFileType):
+			report.Warnings = append(report.Warnings, format.Format+": unknown book file type, the file is ignored")
+		case err != nil:
+			report.Warnings = append(report.Warnings, format.Format+": "+err.Error())
+			uploadFailed = true
+		default:
+			report.Files = append(report.Files, format.Format)
+		}
+	}
+	if uploadFailed {
+		// the book link stays outdated, so the upload is retried on the next run
+		return fail(ErrUploadFailed)
+	}
+
+	err = s.store.SaveLink(ctx, link{
+		CalibreUUID:  libraryBook.UUID,
+		BookID:       report.BookID,
+		LastModified: libraryBook.LastModified,
+	})
+	if err != nil {
+		return fail(err)
+	}
+
+	return report
+}
+
+func (s *Service) uploadCover(ctx context.Context, bookID int64, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	_, err = s.coverService.UploadBookCover(ctx, bookID, file)
+	return err
+}
+
+func (s *Service) uploadFile(ctx context.Context, bookID int64, fileType string, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	_, err = s.bookFileService.UploadBookFile(ctx, bookfile.UploadRequest{
+		BookID:   bookID,
+		FileType: fileType,
+		Content:  file,
+		Size:     info.Size(),
+	})
+	return err
+}
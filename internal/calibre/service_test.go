@@ -0,0 +1,234 @@
+package calibre
+
+import (
+	"context"
+	"errors"
+	"github.com/sdreger/lib-manager-go/internal/domain/book"
+	"github.com/sdreger/lib-manager-go/internal/domain/bookfile"
+	"github.com/sdreger/lib-manager-go/internal/domain/cover"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"io"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+)
+
+const (
+	testBookID  = int64(10)
+	testNotesID = int64(20)
+	testISBN13  = int64(9781617291784)
+
+	testNotesUUID = "8b1c2ae0-6a4c-4f0b-9f43-5a0a1b0c2d02"
+)
+
+var (
+	testModified      = time.Date(2024, time.January, 2, 3, 4, 5, 123456000, time.UTC)
+	testNotesModified = time.Date(2024, time.January, 3, 0, 0, 0, 0, time.UTC)
+)
+
+type testMocks struct {
+	store           *MockStore
+	bookService     *MockBookService
+	bookFileService *MockBookFileService
+	coverService    *MockCoverService
+}
+
+func TestService_Import(t *testing.T) {
+	ctx := context.Background()
+	libraryDir := createTestLibrary(t)
+	service, mocks := getService(t)
+
+	mocks.store.EXPECT().GetLink(ctx, testUUID).Return(link{}, ErrNotLinked).Once()
+	mocks.bookService.EXPECT().FindBookID(ctx, book.Identifiers{
+		ISBN10: "1617291781", ISBN13: testISBN13, ASIN: "B00TEST123",
+	}).Return(0, book.ErrNotFound).Once()
+	mocks.bookService.EXPECT().CreateBook(ctx, mock.Anything).
+		RunAndReturn(func(_ context.Context, newBook book.Book) (int64, error) {
+			assert.Equal(t, "Go in Action", newBook.Title)
+			assert.Equal(t, []string{"William Kennedy", "Brian Ketelsen"}, newBook.Authors)
+			assert.Equal(t, []string{"Programming", "Go", "In Action"}, newBook.Tags)
+			assert.Equal(t, []string{"epub", "pdf"}, newBook.FileTypes)
+			assert.Equal(t, "English", newBook.Language)
+			assert.Equal(t, "Manning", newBook.Publisher)
+			return testBookID, nil
+		}).Once()
+	mocks.store.EXPECT().SaveLink(ctx, link{CalibreUUID: testUUID, BookID: testBookID}).Return(nil).Once()
+	mocks.coverService.EXPECT().UploadBookCover(ctx, testBookID, mock.Anything).
+		RunAndReturn(func(_ context.Context, _ int64, reader io.Reader) (cover.Cover, error) {
+			content, err := io.ReadAll(reader)
+			require.NoError(t, err)
+			assert.Equal(t, "cover content", string(content))
+			return cover.Cover{}, nil
+		}).Once()
+	mocks.bookFileService.EXPECT().UploadBookFile(ctx, mock.Anything).
+		RunAndReturn(func(_ context.Context, request bookfile.UploadRequest) (bookfile.BookFile, error) {
+			content, err := io.ReadAll(request.Content)
+			require.NoError(t, err)
+			assert.Equal(t, request.FileType+" content", string(content))
+			assert.Equal(t, int64(len(content)), request.Size)
+			if request.BookID != testBookID {
+				return bookfile.BookFile{}, bookfile.ErrUnknownFileType
+			}
+			return bookfile.BookFile{}, nil
+		}).Times(3)
+	mocks.store.EXPECT().SaveLink(ctx, link{CalibreUUID: testUUID, BookID: testBookID, LastModified: testModified}).
+		Return(nil).Once()
+
+	// the second book has no valid identifiers, so it is created as well
+	mocks.store.EXPECT().GetLink(ctx, testNotesUUID).Return(link{}, ErrNotLinked).Once()
+	mocks.bookService.EXPECT().FindBookID(ctx, book.Identifiers{}).Return(0, book.ErrNotFound).Once()
+	mocks.bookService.EXPECT().CreateBook(ctx, mock.MatchedBy(func(newBook book.Book) bool {
+		return newBook.Title == "Untitled Notes" && newBook.Language == "German" && newBook.Publisher == "Unknown"
+	})).Return(testNotesID, nil).Once()
+	mocks.store.EXPECT().SaveLink(ctx, link{CalibreUUID: testNotesUUID, BookID: testNotesID}).Return(nil).Once()
+	mocks.store.EXPECT().SaveLink(ctx, link{CalibreUUID: testNotesUUID, BookID: testNotesID,
+		LastModified: testNotesModified}).Return(nil).Once()
+
+	report, err := service.Import(ctx, libraryDir, Options{DefaultLanguage: "German"})
+	require.NoError(t, err, "should import the library")
+	assert.Equal(t, 2, report.Total)
+	assert.Equal(t, 2, report.Created)
+	assert.Equal(t, BookReport{
+		CalibreID: 1,
+		Title:     "Go in Action",
+		Status:    StatusCreated,
+		BookID:    testBookID,
+		Files:     []string{"epub", "pdf"},
+	}, report.Books[0])
+	assert.Equal(t, BookReport{
+		CalibreID: 2,
+		Title:     "Untitled Notes",
+		Status:    StatusCreated,
+		BookID:    testNotesID,
+		Warnings: []string{
+			"the invalid ISBN is ignored: 1234567890",
+			"the language is unknown, the default one is used",
+			"txt: unknown book file type, the file is ignored",
+		},
+	}, report.Books[1])
+}
+
+func TestService_Import_Linked(t *testing.T) {
+	ctx := context.Background()
+	libraryDir := createTestLibrary(t)
+	service, mocks := getService(t)
+
+	mocks.store.EXPECT().GetLink(ctx, testUUID).
+		Return(link{CalibreUUID: testUUID, BookID: testBookID, LastModified: testModified.In(time.Local)}, nil).Once()
+	// the second book is modified in Calibre since the last import
+	mocks.store.EXPECT().GetLink(ctx, testNotesUUID).
+		Return(link{CalibreUUID: testNotesUUID, BookID: testNotesID, LastModified: testModified}, nil).Once()
+	mocks.bookFileService.EXPECT().UploadBookFile(ctx, mock.Anything).Return(bookfile.BookFile{}, nil).Once()
+	mocks.store.EXPECT().SaveLink(ctx, link{CalibreUUID: testNotesUUID, BookID: testNotesID,
+		LastModified: testNotesModified}).Return(nil).Once()
+
+	report, err := service.Import(ctx, libraryDir, Options{})
+	require.NoError(t, err, "should import the library")
+	assert.Equal(t, 1, report.Skipped)
+	assert.Equal(t, 1, report.Matched)
+	assert.Equal(t, BookReport{CalibreID: 1, Title: "Go in Action", Status: StatusSkipped, BookID: testBookID},
+		report.Books[0])
+	assert.Equal(t, StatusMatched, report.Books[1].Status)
+	assert.Equal(t, []string{"txt"}, report.Books[1].Files)
+}
+
+func TestService_Import_DryRun(t *testing.T) {
+	ctx := context.Background()
+	libraryDir := createTestLibrary(t)
+	service, mocks := getService(t)
+
+	mocks.store.EXPECT().GetLink(ctx, mock.Anything).Return(link{}, ErrNotLinked).Twice()
+	mocks.bookService.EXPECT().FindBookID(ctx, mock.MatchedBy(func(identifiers book.Identifiers) bool {
+		return identifiers.ISBN13 == testISBN13
+	})).Return(testBookID, nil).Once()
+	mocks.bookService.EXPECT().FindBookID(ctx, book.Identifiers{}).Return(0, book.ErrNotFound).Once()
+
+	report, err := service.Import(ctx, libraryDir, Options{DryRun: true})
+	require.NoError(t, err, "should report the library import")
+	assert.True(t, report.DryRun)
+	assert.Equal(t, 1, report.Matched)
+	assert.Equal(t, 1, report.Created)
+	assert.Equal(t, testBookID, report.Books[0].BookID)
+	assert.Empty(t, report.Books[1].BookID, "nothing should be created")
+}
+
+func TestService_Import_Failures(t *testing.T) {
+	ctx := context.Background()
+	libraryDir := createTestLibrary(t)
+	service, mocks := getService(t)
+	storeError := errors.New("some error")
+
+	mocks.store.EXPECT().GetLink(ctx, testUUID).Return(link{}, ErrNotLinked).Once()
+	mocks.bookService.EXPECT().FindBookID(ctx, mock.Anything).Return(testBookID, nil).Once()
+	mocks.coverService.EXPECT().UploadBookCover(ctx, testBookID, mock.Anything).
+		Return(cover.Cover{}, cover.ErrUnsupportedType).Once()
+	mocks.bookFileService.EXPECT().UploadBookFile(ctx, mock.Anything).Return(bookfile.BookFile{}, nil).Once()
+	mocks.bookFileService.EXPECT().UploadBookFile(ctx, mock.Anything).Return(bookfile.BookFile{}, storeError).Once()
+	mocks.store.EXPECT().GetLink(ctx, testNotesUUID).Return(link{}, storeError).Once()
+
+	report, err := service.Import(ctx, libraryDir, Options{})
+	require.NoError(t, err, "the failed books should not fail the import")
+	assert.Equal(t, 2, report.Failed)
+	assert.Equal(t, ErrUploadFailed.Error(), report.Books[0].Error)
+	assert.Equal(t, []string{"the cover can not be uploaded: " + cover.ErrUnsupportedType.Error(),
+		"pdf: some error"}, report.Books[0].Warnings)
+	assert.Equal(t, storeError.Error(), report.Books[1].Error)
+}
+
+func TestService_Import_CreatedUploadFailed(t *testing.T) {
+	ctx := context.Background()
+	libraryDir := createTestLibrary(t)
+	service, mocks := getService(t)
+	storeError := errors.New("some error")
+
+	mocks.store.EXPECT().GetLink(ctx, testUUID).Return(link{}, ErrNotLinked).Once()
+	mocks.bookService.EXPECT().FindBookID(ctx, mock.Anything).Return(0, book.ErrNotFound).Once()
+	mocks.bookService.EXPECT().CreateBook(ctx, mock.Anything).Return(testBookID, nil).Once()
+	// the created book is linked before the uploads, with the modification time forcing the retry
+	mocks.store.EXPECT().SaveLink(ctx, link{CalibreUUID: testUUID, BookID: testBookID}).Return(nil).Once()
+	mocks.coverService.EXPECT().UploadBookCover(ctx, testBookID, mock.Anything).Return(cover.Cover{}, nil).Once()
+	mocks.bookFileService.EXPECT().UploadBookFile(ctx, mock.Anything).Return(bookfile.BookFile{}, storeError).Twice()
+	mocks.store.EXPECT().GetLink(ctx, testNotesUUID).Return(link{}, storeError).Once()
+
+	report, err := service.Import(ctx, libraryDir, Options{})
+	require.NoError(t, err, "the failed books should not fail the import")
+	assert.Equal(t, StatusFailed, report.Books[0].Status)
+	assert.Equal(t, ErrUploadFailed.Error(), report.Books[0].Error)
+	assert.Equal(t, testBookID, report.Books[0].BookID)
+}
+
+func TestService_Import_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	service, _ := getService(t)
+
+	_, err := service.Import(ctx, createTestLibrary(t), Options{})
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestService_Import_InvalidLibrary(t *testing.T) {
+	service, _ := getService(t)
+
+	_, err := service.Import(context.Background(), t.TempDir(), Options{})
+	require.ErrorIs(t, err, ErrInvalidLibrary)
+}
+
+func getService(t *testing.T) (*Service, testMocks) {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	mocks := testMocks{
+		store:           NewMockStore(t),
+		bookService:     NewMockBookService(t),
+		bookFileService: NewMockBookFileService(t),
+		coverService:    NewMockCoverService(t),
+	}
+	service := NewService(logger, nil, nil)
+	service.store = mocks.store
+	service.bookService = mocks.bookService
+	service.bookFileService = mocks.bookFileService
+	service.coverService = mocks.coverService
+
+	return service, mocks
+}
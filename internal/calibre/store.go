@@ -0,0 +1,48 @@
+package calibre
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/jmoiron/sqlx"
+)
+
+type DBStore struct {
+	db *sqlx.DB
+}
+
+func NewDBStore(db *sqlx.DB) *DBStore {
+	return &DBStore{db: db}
+}
+
+// GetLink - returns the imported Calibre book link, or ErrNotLinked. The links to the deleted (e.g. merged) books
+// are ignored, so such Calibre books are matched again
+func (s *DBStore) GetLink(ctx context.Context, calibreUUID string) (link, error) {
+	query := `SELECT calibre_uuid, book_id, last_modified
+FROM ebook.calibre_books
+         JOIN ebook.books ON books.id = calibre_books.book_id
+WHERE calibre_uuid = $1
+  AND books.deleted_at IS NULL`
+	var result link
+	if err := s.db.GetContext(ctx, &result, query, calibreUUID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return link{}, ErrNotLinked
+		}
+
+		return link{}, err
+	}
+
+	return result, nil
+}
+
+// SaveLink - stores the imported Calibre book link, replacing the existing one
+func (s *DBStore) SaveLink(ctx context.Context, entity link) error {
+	query := `INSERT INTO ebook.calibre_books (calibre_uuid, book_id, last_modified, imported_at)
+VALUES ($1, $2, $3, now())
+ON CONFLICT (calibre_uuid) DO UPDATE SET book_id       = excluded.book_id,
+                                         last_modified = excluded.last_modified,
+                                         imported_at   = excluded.imported_at`
+	_, err := s.db.ExecContext(ctx, query, entity.CalibreUUID, entity.BookID, entity.LastModified.UTC())
+
+	return err
+}
@@ -0,0 +1,85 @@
+package calibre
+
+import (
+	"context"
+	"github.com/jmoiron/sqlx"
+	"github.com/sdreger/lib-manager-go/internal/tests"
+	"github.com/stretchr/testify/suite"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"os"
+	"testing"
+	"time"
+)
+
+type TestStoreSuite struct {
+	suite.Suite
+	db            *sqlx.DB
+	testContainer *postgres.PostgresContainer
+	store         *DBStore
+}
+
+func (s *TestStoreSuite) SetupSuite() {
+	testContainer := tests.StartDBTestContainer(s.T())
+	dbConfig := tests.GetTestDBConfig(s.T(), testContainer)
+	connection := tests.SetUpTestDB(s.Suite.Require(), dbConfig, testContainer)
+
+	s.store = NewDBStore(connection)
+	s.db = connection
+	s.testContainer = testContainer
+}
+
+func (s *TestStoreSuite) SetupTest() {
+	ctx := context.Background()
+	err := s.testContainer.Restore(ctx)
+	s.Require().NoError(err)
+
+	err = prepareTestData(s.testContainer, "testdata/calibre_books.sql")
+	s.Require().NoError(err, "failed to load test SQL file")
+}
+
+func (s *TestStoreSuite) TearDownSuite() {
+	err := s.db.Close()
+	s.Require().NoError(err, "failed to close database connection")
+}
+
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(TestStoreSuite))
+}
+
+// -------------------- Tests --------------------
+
+func (s *TestStoreSuite) Test_SaveGetLink() {
+	ctx := context.Background()
+	_, err := s.store.GetLink(ctx, testUUID)
+	s.Require().ErrorIs(err, ErrNotLinked)
+
+	modified := time.Date(2024, time.January, 2, 3, 4, 5, 123456000, time.UTC)
+	s.Require().NoError(s.store.SaveLink(ctx, link{CalibreUUID: testUUID, BookID: 1, LastModified: modified}))
+	saved, err := s.store.GetLink(ctx, testUUID)
+	s.Require().NoError(err)
+	s.Equal(int64(1), saved.BookID)
+	s.True(modified.Equal(saved.LastModified), "the last modified time should be kept as is")
+
+	modified = modified.Add(time.Hour)
+	s.Require().NoError(s.store.SaveLink(ctx, link{CalibreUUID: testUUID, BookID: 1, LastModified: modified}))
+	saved, err = s.store.GetLink(ctx, testUUID)
+	s.Require().NoError(err)
+	s.True(modified.Equal(saved.LastModified), "the link should be replaced")
+}
+
+func (s *TestStoreSuite) Test_GetLink_DeletedBook() {
+	ctx := context.Background()
+	s.Require().NoError(s.store.SaveLink(ctx, link{CalibreUUID: testUUID, BookID: 2, LastModified: time.Now()}))
+
+	_, err := s.store.GetLink(ctx, testUUID)
+	s.Require().ErrorIs(err, ErrNotLinked, "the link to the deleted book should be ignored")
+}
+
+func prepareTestData(testContainer *postgres.PostgresContainer, fileName string) error {
+	file, err := os.ReadFile(fileName)
+	if err != nil {
+		return err
+	}
+
+	return tests.ExecSQL(testContainer, string(file))
+}
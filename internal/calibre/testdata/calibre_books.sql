@@ -0,0 +1,8 @@
+INSERT INTO ebook.publishers (id, name) VALUES (1, 'Manning');
+INSERT INTO ebook.languages (id, name) VALUES (1, 'English');
+
+INSERT INTO ebook.books (id, title, pages, edition, language_id, publisher_id, publisher_url, pub_date,
+                         book_file_name, book_file_size, cover_file_name)
+VALUES (1, 'Go in Action', 264, 1, 1, 1, '', '2015-11-04', 'Go in Action - William Kennedy.epub', 5192, ''),
+       (2, 'Deleted Book', 100, 1, 1, 1, '', '2015-11-04', 'Deleted Book.epub', 5192, '');
+UPDATE ebook.books SET deleted_at = now() WHERE id = 2;
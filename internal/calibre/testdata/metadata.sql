@@ -0,0 +1,124 @@
+-- the subset of the Calibre 'metadata.db' schema, used by the importer
+CREATE TABLE books
+(
+    id            INTEGER PRIMARY KEY AUTOINCREMENT,
+    title         TEXT      NOT NULL DEFAULT 'Unknown' COLLATE NOCASE,
+    sort          TEXT COLLATE NOCASE,
+    timestamp     TIMESTAMP          DEFAULT CURRENT_TIMESTAMP,
+    pubdate       TIMESTAMP          DEFAULT CURRENT_TIMESTAMP,
+    series_index  REAL      NOT NULL DEFAULT 1.0,
+    author_sort   TEXT COLLATE NOCASE,
+    path          TEXT      NOT NULL DEFAULT '',
+    uuid          TEXT,
+    has_cover     BOOL               DEFAULT 0,
+    last_modified TIMESTAMP NOT NULL DEFAULT '2000-01-01 00:00:00+00:00'
+);
+CREATE TABLE authors
+(
+    id   INTEGER PRIMARY KEY,
+    name TEXT NOT NULL COLLATE NOCASE,
+    sort TEXT COLLATE NOCASE,
+    link TEXT NOT NULL DEFAULT ''
+);
+CREATE TABLE books_authors_link
+(
+    id     INTEGER PRIMARY KEY,
+    book   INTEGER NOT NULL,
+    author INTEGER NOT NULL
+);
+CREATE TABLE tags
+(
+    id   INTEGER PRIMARY KEY,
+    name TEXT NOT NULL COLLATE NOCASE
+);
+CREATE TABLE books_tags_link
+(
+    id   INTEGER PRIMARY KEY,
+    book INTEGER NOT NULL,
+    tag  INTEGER NOT NULL
+);
+CREATE TABLE series
+(
+    id   INTEGER PRIMARY KEY,
+    name TEXT NOT NULL COLLATE NOCASE,
+    sort TEXT COLLATE NOCASE
+);
+CREATE TABLE books_series_link
+(
+    id     INTEGER PRIMARY KEY,
+    book   INTEGER NOT NULL,
+    series INTEGER NOT NULL
+);
+CREATE TABLE publishers
+(
+    id   INTEGER PRIMARY KEY,
+    name TEXT NOT NULL COLLATE NOCASE,
+    sort TEXT COLLATE NOCASE
+);
+CREATE TABLE books_publishers_link
+(
+    id        INTEGER PRIMARY KEY,
+    book      INTEGER NOT NULL,
+    publisher INTEGER NOT NULL
+);
+CREATE TABLE languages
+(
+    id        INTEGER PRIMARY KEY,
+    lang_code TEXT NOT NULL COLLATE NOCASE
+);
+CREATE TABLE books_languages_link
+(
+    id         INTEGER PRIMARY KEY,
+    book       INTEGER NOT NULL,
+    lang_code  INTEGER NOT NULL,
+    item_order INTEGER NOT NULL DEFAULT 0
+);
+CREATE TABLE identifiers
+(
+    id   INTEGER PRIMARY KEY,
+    book INTEGER NOT NULL,
+    type TEXT    NOT NULL DEFAULT 'isbn' COLLATE NOCASE,
+    val  TEXT    NOT NULL COLLATE NOCASE
+);
+CREATE TABLE comments
+(
+    id   INTEGER PRIMARY KEY,
+    book INTEGER NOT NULL,
+    text TEXT    NOT NULL COLLATE NOCASE
+);
+CREATE TABLE data
+(
+    id                INTEGER PRIMARY KEY,
+    book              INTEGER NOT NULL,
+    format            TEXT    NOT NULL COLLATE NOCASE,
+    uncompressed_size INTEGER NOT NULL,
+    name              TEXT    NOT NULL
+);
+
+INSERT INTO books (id, title, pubdate, path, uuid, has_cover, last_modified)
+VALUES (1, 'Go in Action', '2015-11-04 00:00:00+00:00', 'William Kennedy/Go in Action (1)',
+        '8b1c2ae0-6a4c-4f0b-9f43-5a0a1b0c2d01', 1, '2024-01-02 03:04:05.123456+00:00'),
+       (2, 'Untitled Notes', '0101-01-01 00:00:00+00:00', 'Unknown/Untitled Notes (2)',
+        '8b1c2ae0-6a4c-4f0b-9f43-5a0a1b0c2d02', 0, '2024-01-03 00:00:00+00:00');
+
+INSERT INTO authors (id, name, sort) VALUES (1, 'William Kennedy', 'Kennedy, William'),
+                                            (2, 'Brian Ketelsen', 'Ketelsen, Brian'),
+                                            (3, 'Unknown', 'Unknown');
+INSERT INTO books_authors_link (id, book, author) VALUES (1, 1, 1), (2, 1, 2), (3, 2, 3);
+INSERT INTO tags (id, name) VALUES (1, 'Programming'), (2, 'Go');
+INSERT INTO books_tags_link (id, book, tag) VALUES (1, 1, 1), (2, 1, 2);
+INSERT INTO series (id, name, sort) VALUES (1, 'In Action', 'In Action');
+INSERT INTO books_series_link (id, book, series) VALUES (1, 1, 1);
+INSERT INTO publishers (id, name, sort) VALUES (1, 'Manning', 'Manning');
+INSERT INTO books_publishers_link (id, book, publisher) VALUES (1, 1, 1);
+INSERT INTO languages (id, lang_code) VALUES (1, 'eng');
+INSERT INTO books_languages_link (id, book, lang_code, item_order) VALUES (1, 1, 1, 0);
+INSERT INTO identifiers (id, book, type, val) VALUES (1, 1, 'isbn', '9781617291784'),
+                                                     (2, 1, 'amazon', 'b00test123'),
+                                                     (3, 2, 'isbn', '1234567890');
+INSERT INTO comments (id, book, text)
+VALUES (1, 1, '<div><p>Go in Action introduces the Go language.</p><p>Tips &amp; tricks<br/>included.</p></div>');
+INSERT INTO data (id, book, format, uncompressed_size, name)
+VALUES (1, 1, 'EPUB', 5192, 'Go in Action - William Kennedy'),
+       (2, 1, 'PDF', 8192, 'Go in Action - William Kennedy'),
+       (3, 2, 'TXT', 12, 'Untitled Notes - Unknown');
@@ -0,0 +1,91 @@
+package calibre
+
+import (
+	"database/sql"
+	"time"
+)
+
+const (
+	StatusCreated = "created"
+	StatusMatched = "matched"
+	StatusSkipped = "skipped"
+	StatusFailed  = "failed"
+)
+
+// Options - the import options, the default language is used for the books without one
+type Options struct {
+	DryRun          bool
+	DefaultLanguage string
+}
+
+// Report - the library import result, the books are reported in the Calibre ID order
+type Report struct {
+	Library string       `json:"library"`
+	DryRun  bool         `json:"dry_run"`
+	Total   int          `json:"total"`
+	Created int          `json:"created"`
+	Matched int          `json:"matched"`
+	Skipped int          `json:"skipped"`
+	Failed  int          `json:"failed"`
+	Books   []BookReport `json:"books"`
+}
+
+// BookReport - the Calibre book import result. The 'matched' books are the existing ones, either imported before
+// or having the same identifiers, their details are never changed, only the cover and the files are uploaded
+type BookReport struct {
+	CalibreID int64    `json:"calibre_id"`
+	Title     string   `json:"title"`
+	Status    string   `json:"status"`
+	BookID    int64    `json:"book_id,omitempty"`
+	Files     []string `json:"files,omitempty"`
+	Warnings  []string `json:"warnings,omitempty"`
+	Error     string   `json:"error,omitempty"`
+}
+
+// link - the imported Calibre book, the book is imported again only if it is modified in Calibre since then
+type link struct {
+	CalibreUUID  string    `db:"calibre_uuid"`
+	BookID       int64     `db:"book_id"`
+	LastModified time.Time `db:"last_modified"`
+}
+
+// libraryBook - the Calibre book along with its relations. The book folder is relative to the library one
+type libraryBook struct {
+	ID           int64        `db:"id"`
+	UUID         string       `db:"uuid"`
+	Title        string       `db:"title"`
+	Path         string       `db:"path"`
+	HasCover     bool         `db:"has_cover"`
+	PubDate      sql.NullTime `db:"pubdate"`
+	LastModified time.Time    `db:"last_modified"`
+	Comment      string
+	Authors      []string
+	Tags         []string
+	Series       []string
+	Publishers   []string
+	Languages    []string // the ISO 639-2 codes, e.g. 'eng'
+	Identifiers  map[string]string
+	Formats      []libraryFormat
+}
+
+// libraryFormat - the Calibre book file, stored as '{book path}/{name}.{format}'
+type libraryFormat struct {
+	Format string
+	Name   string
+}
+
+// add - counts the book import result, and appends it to the report
+func (r *Report) add(bookReport BookReport) {
+	r.Total++
+	switch bookReport.Status {
+	case StatusCreated:
+		r.Created++
+	case StatusMatched:
+		r.Matched++
+	case StatusSkipped:
+		r.Skipped++
+	case StatusFailed:
+		r.Failed++
+	}
+	r.Books = append(r.Books, bookReport)
+}
@@ -0,0 +1,69 @@
+package citation
+
+import (
+	"bufio"
+	"github.com/sdreger/lib-manager-go/internal/domain/book"
+	"strconv"
+	"strings"
+)
+
+// bibtexMonths - the standard BibTeX month macros
+var bibtexMonths = []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}
+
+var bibtexEscaper = strings.NewReplacer(
+	`\`, `\textbackslash{}`, `{`, `\{`, `}`, `\}`, `&`, `\&`, `%`, `\%`, `$`, `\$`, `#`, `\#`, `_`, `\_`,
+	`~`, `\textasciitilde{}`, `^`, `\textasciicircum{}`,
+)
+
+// bibtexWriter - writes the '@book' entries, separated by empty lines
+type bibtexWriter struct {
+	buffer  *bufio.Writer
+	keys    keySet
+	written bool
+}
+
+func (w *bibtexWriter) Write(citedBook book.Book) error {
+	key := w.keys.key(citedBook)
+	if w.written {
+		_ = w.buffer.WriteByte('\n')
+	}
+	w.written = true
+	_, _ = w.buffer.WriteString("@book{" + key + ",\n")
+
+	authors := make([]string, 0, len(citedBook.Authors))
+	for _, author := range citedBook.Authors {
+		name := splitName(author)
+		if name.Given == "" {
+			// the braces keep the organization names from being split
+			authors = append(authors, "{"+bibtexEscaper.Replace(name.Family)+"}")
+			continue
+		}
+		authors = append(authors, bibtexEscaper.Replace(name.Family+", "+name.Given))
+	}
+	w.field("author", strings.Join(authors, " and "))
+	w.field("title", bibtexEscaper.Replace(fullTitle(citedBook)))
+	w.field("publisher", bibtexEscaper.Replace(citedBook.Publisher))
+	if citedBook.Edition > 1 {
+		w.field("edition", strconv.Itoa(int(citedBook.Edition)))
+	}
+	if !citedBook.PubDate.IsZero() {
+		w.field("year", strconv.Itoa(citedBook.PubDate.Year()))
+		_, _ = w.buffer.WriteString("  month = " + bibtexMonths[citedBook.PubDate.Month()-1] + ",\n")
+	}
+	w.field("isbn", isbn(citedBook))
+	w.field("url", citedBook.PublisherURL)
+
+	_, err := w.buffer.WriteString("}\n")
+	return err
+}
+
+func (w *bibtexWriter) Flush() error {
+	return w.buffer.Flush()
+}
+
+// field - writes the non-empty field value, the value is expected to be escaped
+func (w *bibtexWriter) field(name string, value string) {
+	if value != "" {
+		_, _ = w.buffer.WriteString("  " + name + " = {" + value + "},\n")
+	}
+}
@@ -0,0 +1,90 @@
+package citation
+
+import (
+	"bufio"
+	"encoding/json"
+	"github.com/sdreger/lib-manager-go/internal/domain/book"
+	"strconv"
+)
+
+// cslItem - the CSL-JSON item, see https://citeproc-js.readthedocs.io/en/latest/csl-json/markup.html
+type cslItem struct {
+	ID        string    `json:"id"`
+	Type      string    `json:"type"`
+	Title     string    `json:"title"`
+	Author    []cslName `json:"author,omitempty"`
+	Publisher string    `json:"publisher,omitempty"`
+	Edition   string    `json:"edition,omitempty"`
+	Issued    *cslDate  `json:"issued,omitempty"`
+	ISBN      string    `json:"ISBN,omitempty"`
+	URL       string    `json:"URL,omitempty"`
+}
+
+type cslName struct {
+	Family string `json:"family,omitempty"`
+	Given  string `json:"given,omitempty"`
+	// Literal - the organization name, which is not split to the name parts
+	Literal string `json:"literal,omitempty"`
+}
+
+type cslDate struct {
+	DateParts [][]int `json:"date-parts"`
+}
+
+// cslWriter - writes the CSL-JSON array of the items, the array is closed on flush
+type cslWriter struct {
+	buffer  *bufio.Writer
+	keys    keySet
+	written bool
+}
+
+func (w *cslWriter) Write(citedBook book.Book) error {
+	item := cslItem{
+		ID:        w.keys.key(citedBook),
+		Type:      "book",
+		Title:     fullTitle(citedBook),
+		Publisher: citedBook.Publisher,
+		ISBN:      isbn(citedBook),
+		URL:       citedBook.PublisherURL,
+	}
+	for _, author := range citedBook.Authors {
+		name := splitName(author)
+		if name.Given == "" {
+			item.Author = append(item.Author, cslName{Literal: name.Family})
+			continue
+		}
+		item.Author = append(item.Author, cslName{Family: name.Family, Given: name.Given})
+	}
+	if citedBook.Edition > 1 {
+		item.Edition = strconv.Itoa(int(citedBook.Edition))
+	}
+	if pubDate := citedBook.PubDate; !pubDate.IsZero() {
+		item.Issued = &cslDate{DateParts: [][]int{{pubDate.Year(), int(pubDate.Month()), pubDate.Day()}}}
+	}
+
+	data, err := json.Marshal(item)
+	if err != nil {
+		return err
+	}
+	separator := ",\n"
+	if !w.written {
+		separator = "[\n"
+	}
+	w.written = true
+	_, _ = w.buffer.WriteString(separator)
+	_, err = w.buffer.Write(data)
+
+	return err
+}
+
+func (w *cslWriter) Flush() error {
+	closing := "\n]\n"
+	if !w.written {
+		closing = "[]\n"
+	}
+	if _, err := w.buffer.WriteString(closing); err != nil {
+		return err
+	}
+
+	return w.buffer.Flush()
+}
@@ -0,0 +1,8 @@
+package citation
+
+import "errors"
+
+var (
+	ErrUnsupportedFormat = errors.New(
+		"unsupported citation format, the supported ones are 'bibtex', 'ris' and 'csl-json'")
+)
@@ -0,0 +1,98 @@
+package citation
+
+import (
+	"github.com/sdreger/lib-manager-go/internal/domain/book"
+	"golang.org/x/text/unicode/norm"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// personName - the author name parts, the organizations only have the family name
+type personName struct {
+	Family string
+	Given  string
+}
+
+// splitName - splits the author name to the family and the given names. Both 'Given Family'
+// and 'Family, Given' forms are supported
+func splitName(name string) personName {
+	name = strings.TrimSpace(name)
+	if family, given, found := strings.Cut(name, ","); found {
+		return personName{Family: strings.TrimSpace(family), Given: strings.TrimSpace(given)}
+	}
+	if index := strings.LastIndex(name, " "); index > 0 {
+		return personName{Family: name[index+1:], Given: strings.TrimSpace(name[:index])}
+	}
+
+	return personName{Family: name}
+}
+
+// fullTitle - the book title along with the subtitle, since neither BibTeX nor RIS or CSL have a subtitle field
+func fullTitle(citedBook book.Book) string {
+	if citedBook.Subtitle == "" {
+		return citedBook.Title
+	}
+
+	return citedBook.Title + ": " + citedBook.Subtitle
+}
+
+// isbn - the preferred book ISBN, the ISBN-13 one if present
+func isbn(citedBook book.Book) string {
+	if citedBook.ISBN13 != 0 {
+		return strconv.FormatInt(citedBook.ISBN13, 10)
+	}
+
+	return citedBook.ISBN10
+}
+
+// citationKey - the BibTeX key, like 'kennedy2015go': the first author family name, the publication year
+// and the first title word, in lower case ASCII letters and digits. The book ID is used, if none of them is known
+func citationKey(citedBook book.Book) string {
+	var key strings.Builder
+	if len(citedBook.Authors) > 0 {
+		key.WriteString(keyPart(splitName(citedBook.Authors[0]).Family))
+	}
+	if !citedBook.PubDate.IsZero() {
+		key.WriteString(strconv.Itoa(citedBook.PubDate.Year()))
+	}
+	for _, word := range strings.Fields(citedBook.Title) {
+		if part := keyPart(word); len(part) > 0 && !skippedKeyWords[part] {
+			key.WriteString(part)
+			break
+		}
+	}
+	if key.Len() == 0 {
+		key.WriteString("book" + strconv.FormatInt(citedBook.ID, 10))
+	}
+
+	return key.String()
+}
+
+// keySet - keeps the citation keys unique within the output, the repeated keys get the book ID suffix
+type keySet map[string]bool
+
+func (s keySet) key(citedBook book.Book) string {
+	key := citationKey(citedBook)
+	if s[key] {
+		key += "-" + strconv.FormatInt(citedBook.ID, 10)
+	}
+	s[key] = true
+
+	return key
+}
+
+// skippedKeyWords - the title words, not used in the citation key
+var skippedKeyWords = map[string]bool{"a": true, "an": true, "the": true, "on": true, "of": true}
+
+// keyPart - keeps the ASCII letters and digits of the value, the accents are removed first, e.g. 'Müller' -> 'muller'
+func keyPart(value string) string {
+	var part strings.Builder
+	for _, char := range norm.NFD.String(strings.ToLower(value)) {
+		if char < unicode.MaxASCII && (unicode.IsLetter(char) || unicode.IsDigit(char)) {
+			part.WriteRune(char)
+		}
+	}
+
+	return part.String()
+}
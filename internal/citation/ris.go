@@ -0,0 +1,51 @@
+package citation
+
+import (
+	"bufio"
+	"github.com/sdreger/lib-manager-go/internal/domain/book"
+	"strconv"
+	"strings"
+)
+
+// risWriter - writes the 'BOOK' type RIS records
+type risWriter struct {
+	buffer *bufio.Writer
+}
+
+func (w *risWriter) Write(citedBook book.Book) error {
+	w.tag("TY", "BOOK")
+	for _, author := range citedBook.Authors {
+		name := splitName(author)
+		if name.Given == "" {
+			w.tag("AU", name.Family)
+			continue
+		}
+		w.tag("AU", name.Family+", "+name.Given)
+	}
+	w.tag("TI", fullTitle(citedBook))
+	w.tag("PB", citedBook.Publisher)
+	if citedBook.Edition > 1 {
+		w.tag("ET", strconv.Itoa(int(citedBook.Edition)))
+	}
+	if !citedBook.PubDate.IsZero() {
+		w.tag("PY", strconv.Itoa(citedBook.PubDate.Year()))
+		w.tag("DA", citedBook.PubDate.Format("2006/01/02"))
+	}
+	w.tag("SN", isbn(citedBook))
+	w.tag("UR", citedBook.PublisherURL)
+	_, err := w.buffer.WriteString("ER  - \n")
+
+	return err
+}
+
+func (w *risWriter) Flush() error {
+	return w.buffer.Flush()
+}
+
+// tag - writes the non-empty tag value, the RIS values can not span several lines
+func (w *risWriter) tag(name string, value string) {
+	value = strings.Join(strings.Fields(value), " ")
+	if value != "" {
+		_, _ = w.buffer.WriteString(name + "  - " + value + "\n")
+	}
+}
@@ -0,0 +1,61 @@
+package citation
+
+import (
+	"mime"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	FormatBibTeX  = "bibtex"
+	FormatRIS     = "ris"
+	FormatCSLJSON = "csl-json"
+
+	DefaultFormat = FormatBibTeX
+)
+
+// Format - the citation format properties. The first media type is the response one, the other ones
+// are only accepted in the 'Accept' header
+type Format struct {
+	MediaTypes []string
+	Extension  string
+}
+
+// ContentType - the response content type of the format
+func (f Format) ContentType() string {
+	return f.MediaTypes[0] + "; charset=utf-8"
+}
+
+// Formats - the supported citation formats
+var Formats = map[string]Format{
+	FormatBibTeX:  {MediaTypes: []string{"application/x-bibtex", "text/x-bibtex"}, Extension: "bib"},
+	FormatRIS:     {MediaTypes: []string{"application/x-research-info-systems"}, Extension: "ris"},
+	FormatCSLJSON: {MediaTypes: []string{"application/vnd.citationstyles.csl+json"}, Extension: "json"},
+}
+
+// Negotiate - returns the citation format, preferred by the 'Accept' header value, according to the quality values.
+// The first listed format wins on the same quality. Returns false if none of the citation formats is acceptable,
+// the wildcards do not select any format
+func Negotiate(accept string) (string, bool) {
+	bestFormat, bestQuality := "", 0.0
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		quality := 1.0
+		if value, ok := params["q"]; ok {
+			if quality, err = strconv.ParseFloat(value, 64); err != nil {
+				continue
+			}
+		}
+		for name, format := range Formats {
+			if slices.Contains(format.MediaTypes, mediaType) && quality > bestQuality {
+				bestFormat, bestQuality = name, quality
+			}
+		}
+	}
+
+	return bestFormat, bestFormat != ""
+}
@@ -0,0 +1,30 @@
+package citation
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/sdreger/lib-manager-go/internal/domain/book"
+	"io"
+)
+
+// Writer - writes the book citations in a specific format. The output is buffered, so the 'Flush' method should
+// be called once all the books are written
+type Writer interface {
+	Write(book book.Book) error
+	Flush() error
+}
+
+// NewWriter - creates a new citation writer for the format
+func NewWriter(format string, w io.Writer) (Writer, error) {
+	buffer := bufio.NewWriter(w)
+	switch format {
+	case FormatBibTeX:
+		return &bibtexWriter{buffer: buffer, keys: make(keySet)}, nil
+	case FormatRIS:
+		return &risWriter{buffer: buffer}, nil
+	case FormatCSLJSON:
+		return &cslWriter{buffer: buffer, keys: make(keySet)}, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedFormat, format)
+	}
+}
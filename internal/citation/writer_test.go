@@ -0,0 +1,164 @@
+package citation
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/sdreger/lib-manager-go/internal/domain/book"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+var testBook = book.Book{
+	ID:           1,
+	Title:        "Go in Action",
+	Subtitle:     "Tips & Tricks",
+	ISBN10:       "1617291781",
+	ISBN13:       9781617291784,
+	Edition:      2,
+	PubDate:      time.Date(2015, time.November, 4, 0, 0, 0, 0, time.UTC),
+	Publisher:    "Manning",
+	PublisherURL: "https://www.manning.com/books/go-in-action",
+	Authors:      []string{"William Kennedy", "Ketelsen, Brian", "Go Team"},
+}
+
+func TestBibTeXWriter(t *testing.T) {
+	output := writeCitations(t, FormatBibTeX, testBook, book.Book{ID: 2, Title: "The Book", Authors: []string{"Gopher"}},
+		testBook)
+
+	assert.Equal(t, `@book{kennedy2015go,
+  author = {Kennedy, William and Ketelsen, Brian and Team, Go},
+  title = {Go in Action: Tips \& Tricks},
+  publisher = {Manning},
+  edition = {2},
+  year = {2015},
+  month = nov,
+  isbn = {9781617291784},
+  url = {https://www.manning.com/books/go-in-action},
+}
+
+@book{gopherbook,
+  author = {{Gopher}},
+  title = {The Book},
+}
+
+@book{kennedy2015go-1,
+  author = {Kennedy, William and Ketelsen, Brian and Team, Go},
+  title = {Go in Action: Tips \& Tricks},
+  publisher = {Manning},
+  edition = {2},
+  year = {2015},
+  month = nov,
+  isbn = {9781617291784},
+  url = {https://www.manning.com/books/go-in-action},
+}
+`, output)
+}
+
+func TestRISWriter(t *testing.T) {
+	output := writeCitations(t, FormatRIS, testBook, book.Book{Title: "Line\nbreak", ISBN10: "1617291781"})
+
+	assert.Equal(t, `TY  - BOOK
+AU  - Kennedy, William
+AU  - Ketelsen, Brian
+AU  - Team, Go
+TI  - Go in Action: Tips & Tricks
+PB  - Manning
+ET  - 2
+PY  - 2015
+DA  - 2015/11/04
+SN  - 9781617291784
+UR  - https://www.manning.com/books/go-in-action
+ER  - 
+TY  - BOOK
+TI  - Line break
+SN  - 1617291781
+ER  - 
+`, output)
+}
+
+func TestCSLWriter(t *testing.T) {
+	output := writeCitations(t, FormatCSLJSON, testBook, book.Book{ID: 2, Title: "Book", Authors: []string{"Gopher"}})
+
+	var items []map[string]any
+	require.NoError(t, json.Unmarshal([]byte(output), &items), "the output should be a JSON array")
+	require.Len(t, items, 2)
+	assert.Equal(t, map[string]any{
+		"id":    "kennedy2015go",
+		"type":  "book",
+		"title": "Go in Action: Tips & Tricks",
+		"author": []any{
+			map[string]any{"family": "Kennedy", "given": "William"},
+			map[string]any{"family": "Ketelsen", "given": "Brian"},
+			map[string]any{"family": "Team", "given": "Go"},
+		},
+		"publisher": "Manning",
+		"edition":   "2",
+		"issued":    map[string]any{"date-parts": []any{[]any{2015.0, 11.0, 4.0}}},
+		"ISBN":      "9781617291784",
+		"URL":       "https://www.manning.com/books/go-in-action",
+	}, items[0])
+	assert.Equal(t, []any{map[string]any{"literal": "Gopher"}}, items[1]["author"])
+}
+
+func TestCSLWriter_NoBooks(t *testing.T) {
+	assert.Equal(t, "[]\n", writeCitations(t, FormatCSLJSON))
+}
+
+func TestNewWriter_UnsupportedFormat(t *testing.T) {
+	_, err := NewWriter("apa", &bytes.Buffer{})
+	require.ErrorIs(t, err, ErrUnsupportedFormat)
+}
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		accept string
+		format string
+	}{
+		{accept: "application/x-bibtex", format: FormatBibTeX},
+		{accept: "text/x-bibtex; charset=utf-8", format: FormatBibTeX},
+		{accept: "application/x-research-info-systems, application/x-bibtex", format: FormatRIS},
+		{accept: "application/x-research-info-systems;q=0.5, application/vnd.citationstyles.csl+json",
+			format: FormatCSLJSON},
+		{accept: "application/x-bibtex;q=0, application/json", format: ""},
+		{accept: "*/*", format: ""},
+		{accept: "", format: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.accept, func(t *testing.T) {
+			format, ok := Negotiate(tt.accept)
+			assert.Equal(t, tt.format, format)
+			assert.Equal(t, tt.format != "", ok)
+		})
+	}
+}
+
+func TestSplitName(t *testing.T) {
+	assert.Equal(t, personName{Family: "Kennedy", Given: "William"}, splitName("William Kennedy"))
+	assert.Equal(t, personName{Family: "Rossum", Given: "Guido van"}, splitName("Guido van Rossum"))
+	assert.Equal(t, personName{Family: "Kennedy", Given: "William"}, splitName(" Kennedy, William "))
+	assert.Equal(t, personName{Family: "Gopher"}, splitName("Gopher"))
+}
+
+func TestCitationKey(t *testing.T) {
+	assert.Equal(t, "kennedy2015go", citationKey(testBook))
+	assert.Equal(t, "muller2020art", citationKey(book.Book{
+		Title:   "The Art of Go",
+		Authors: []string{"Jörg Müller"},
+		PubDate: time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC),
+	}), "the accents should be removed")
+	assert.Equal(t, "book7", citationKey(book.Book{ID: 7, Title: "—"}))
+}
+
+func writeCitations(t *testing.T, format string, books ...book.Book) string {
+	var output bytes.Buffer
+	writer, err := NewWriter(format, &output)
+	require.NoError(t, err)
+	for _, citedBook := range books {
+		require.NoError(t, writer.Write(citedBook))
+	}
+	require.NoError(t, writer.Flush())
+
+	return output.String()
+}
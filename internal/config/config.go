@@ -1,12 +1,17 @@
 package config
 
 import (
+	"errors"
+	"fmt"
 	"github.com/caarlos0/env/v11"
 	"runtime/debug"
 )
 
 const EnvPrefix = "LIB_MANAGER_"
 
+// ErrInvalidValue - the parsed config value is out of its range
+var ErrInvalidValue = errors.New("invalid config value")
+
 // For the testing purpose
 var buildInfoFunc = debug.ReadBuildInfo
 
@@ -15,12 +20,43 @@ func New() (AppConfig, error) {
 	if err := env.ParseWithOptions(&cfg, env.Options{Prefix: EnvPrefix}); err != nil {
 		return AppConfig{}, err
 	}
+	if err := validate(cfg); err != nil {
+		return AppConfig{}, err
+	}
 
 	cfg.BuildInfo = GetBuildInfo()
 
 	return cfg, nil
 }
 
+// validate - checks the values, the application cannot run with, e.g. the tickers panic on the non-positive interval
+func validate(cfg AppConfig) error {
+	if cfg.Webhooks.PollInterval <= 0 {
+		return notPositive("WEBHOOKS_POLL_INTERVAL", cfg.Webhooks.PollInterval)
+	}
+	if cfg.Inbox.PollInterval <= 0 {
+		return notPositive("INBOX_POLL_INTERVAL", cfg.Inbox.PollInterval)
+	}
+	if cfg.Ingest.MaxUploadSize <= 0 {
+		return notPositive("INGEST_MAX_UPLOAD_SIZE", cfg.Ingest.MaxUploadSize)
+	}
+	if cfg.Ingest.DraftCoverTTL <= 0 {
+		return notPositive("INGEST_DRAFT_COVER_TTL", cfg.Ingest.DraftCoverTTL)
+	}
+	if cfg.Events.HeartbeatInterval <= 0 {
+		return notPositive("EVENTS_HEARTBEAT_INTERVAL", cfg.Events.HeartbeatInterval)
+	}
+	if cfg.OAI.PageSize == 0 {
+		return notPositive("OAI_PAGE_SIZE", cfg.OAI.PageSize)
+	}
+
+	return nil
+}
+
+func notPositive(key string, value any) error {
+	return fmt.Errorf("%w: %s%s should be positive, got '%v'", ErrInvalidValue, EnvPrefix, key, value)
+}
+
 func GetBuildInfo() BuildInfo {
 	buildInfo := BuildInfo{}
 	info, ok := buildInfoFunc()
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"github.com/caarlos0/env/v11"
 	"github.com/stretchr/testify/assert"
+	"net/netip"
 	"os"
 	"runtime/debug"
 	"strconv"
@@ -20,6 +21,10 @@ var (
 	defaultHTTPIdleTimeout, _     = time.ParseDuration("120s")
 	defaultHTTPShutdownTimeout, _ = time.ParseDuration("20s")
 
+	defaultGRPCHost            = "0.0.0.0"
+	defaultGRPCPort            = 9090
+	defaultGRPCShutdownTimeout = 20 * time.Second
+
 	defaultDBDriver                = "postgres"
 	defaultDBHost                  = "127.0.0.1"
 	defaultDBPort                  = 5432
@@ -35,11 +40,40 @@ var (
 	defaultMigrationLockTimeoutSec = uint64(300)
 
 	defaultBlobStoreBookCoverBucket          = "ebook-covers"
+	defaultBlobStoreBookFileBucket           = "ebook-files"
 	defaultBlobStoreMinioEndpoint            = "127.0.0.1:9000"
 	defaultBlobStoreMinioAccessKeyID         = "minio-access-key"
 	defaultBlobStoreMinioSecretAccessKey     = "minio-secret-key"
 	defaultBlobStoreMinioUseSSL              = false
 	defaultBlobstoreMinioHealthCheckInterval = time.Duration(10000000000) // 10s
+
+	defaultInboxEnabled         = false
+	defaultInboxDir             = "/var/lib/lib-manager/inbox"
+	defaultInboxPollInterval    = 30 * time.Second
+	defaultInboxSettleTime      = 10 * time.Second
+	defaultInboxDefaultLanguage = "English"
+
+	defaultIngestMaxUploadSize = int64(256 << 20)
+	defaultIngestDraftCoverTTL = 24 * time.Hour
+
+	defaultMetadataProviderURL = ""
+	defaultMetadataTimeout     = 10 * time.Second
+	defaultMetadataCacheTTL    = 720 * time.Hour
+
+	defaultEventsHeartbeatInterval = 15 * time.Second
+
+	defaultWebhooksDispatcherEnabled = true
+	defaultWebhooksPollInterval      = 5 * time.Second
+	defaultWebhooksTimeout           = 10 * time.Second
+	defaultWebhooksMaxAttempts       = 8
+	defaultWebhooksInitialBackoff    = 30 * time.Second
+	defaultWebhooksMaxBackoff        = 6 * time.Hour
+	defaultWebhooksRetention         = 720 * time.Hour
+
+	defaultOAIRepositoryName       = "Lib Manager"
+	defaultOAIRepositoryIdentifier = "lib-manager.local"
+	defaultOAIAdminEmail           = "admin@lib-manager.local"
+	defaultOAIPageSize             = uint64(100)
 )
 
 func TestNewConfigDefaults(t *testing.T) {
@@ -53,12 +87,20 @@ func TestNewConfigDefaults(t *testing.T) {
 			assert.Equal(t, defaultHTTPWriteTimeout, config.HTTP.WriteTimeout)
 			assert.Equal(t, defaultHTTPIdleTimeout, config.HTTP.IdleTimeout)
 			assert.Equal(t, defaultHTTPShutdownTimeout, config.HTTP.ShutdownTimeout)
+			assert.Empty(t, config.HTTP.ExternalBaseURL)
+			assert.Empty(t, config.HTTP.TrustedProxies)
 
 			assert.Empty(t, config.HTTP.CORS.AllowedOrigins)
 			assert.Empty(t, config.HTTP.CORS.AllowedMethods)
 			assert.Empty(t, config.HTTP.CORS.AllowedHeaders)
 		}
 
+		if assert.NotEmpty(t, config.GRPC, "gRPC config should not be empty") {
+			assert.Equal(t, defaultGRPCHost, config.GRPC.Host)
+			assert.Equal(t, defaultGRPCPort, config.GRPC.Port)
+			assert.Equal(t, defaultGRPCShutdownTimeout, config.GRPC.ShutdownTimeout)
+		}
+
 		if assert.NotEmpty(t, config.DB, "DB config should not be empty") {
 			assert.Equal(t, defaultDBDriver, config.DB.Driver)
 			assert.Equal(t, defaultDBHost, config.DB.Host)
@@ -77,12 +119,48 @@ func TestNewConfigDefaults(t *testing.T) {
 
 		if assert.NotEmpty(t, config.BLOBStore, "BLOBStore config should not be empty") {
 			assert.Equal(t, defaultBlobStoreBookCoverBucket, config.BLOBStore.BookCoverBucket)
+			assert.Equal(t, defaultBlobStoreBookFileBucket, config.BLOBStore.BookFileBucket)
 			assert.Equal(t, defaultBlobStoreMinioEndpoint, config.BLOBStore.MinioEndpoint)
 			assert.Equal(t, defaultBlobStoreMinioAccessKeyID, config.BLOBStore.MinioAccessKeyID)
 			assert.Equal(t, defaultBlobStoreMinioSecretAccessKey, config.BLOBStore.MinioSecretAccessKey)
 			assert.Equal(t, defaultBlobStoreMinioUseSSL, config.BLOBStore.MinioUseSSL)
 			assert.Equal(t, defaultBlobstoreMinioHealthCheckInterval, config.BLOBStore.MinioHealthCheckInterval)
 		}
+
+		if assert.NotEmpty(t, config.Inbox, "Inbox config should not be empty") {
+			assert.Equal(t, defaultInboxEnabled, config.Inbox.Enabled)
+			assert.Equal(t, defaultInboxDir, config.Inbox.Dir)
+			assert.Equal(t, defaultInboxPollInterval, config.Inbox.PollInterval)
+			assert.Equal(t, defaultInboxSettleTime, config.Inbox.SettleTime)
+			assert.Equal(t, defaultInboxDefaultLanguage, config.Inbox.DefaultLanguage)
+		}
+
+		if assert.NotEmpty(t, config.Ingest, "Ingest config should not be empty") {
+			assert.Equal(t, defaultIngestMaxUploadSize, config.Ingest.MaxUploadSize)
+			assert.Equal(t, defaultIngestDraftCoverTTL, config.Ingest.DraftCoverTTL)
+		}
+
+		assert.Equal(t, defaultMetadataProviderURL, config.Metadata.ProviderURL)
+		assert.Equal(t, defaultMetadataTimeout, config.Metadata.Timeout)
+		assert.Equal(t, defaultMetadataCacheTTL, config.Metadata.CacheTTL)
+		assert.Equal(t, defaultEventsHeartbeatInterval, config.Events.HeartbeatInterval)
+
+		if assert.NotEmpty(t, config.Webhooks, "Webhooks config should not be empty") {
+			assert.Equal(t, defaultWebhooksDispatcherEnabled, config.Webhooks.DispatcherEnabled)
+			assert.Equal(t, defaultWebhooksPollInterval, config.Webhooks.PollInterval)
+			assert.Equal(t, defaultWebhooksTimeout, config.Webhooks.Timeout)
+			assert.Equal(t, defaultWebhooksMaxAttempts, config.Webhooks.MaxAttempts)
+			assert.Equal(t, defaultWebhooksInitialBackoff, config.Webhooks.InitialBackoff)
+			assert.Equal(t, defaultWebhooksMaxBackoff, config.Webhooks.MaxBackoff)
+			assert.Equal(t, defaultWebhooksRetention, config.Webhooks.Retention)
+		}
+
+		if assert.NotEmpty(t, config.OAI, "OAI config should not be empty") {
+			assert.Equal(t, defaultOAIRepositoryName, config.OAI.RepositoryName)
+			assert.Equal(t, defaultOAIRepositoryIdentifier, config.OAI.RepositoryIdentifier)
+			assert.Equal(t, defaultOAIAdminEmail, config.OAI.AdminEmail)
+			assert.Equal(t, defaultOAIPageSize, config.OAI.PageSize)
+		}
 	}
 }
 
@@ -96,6 +174,8 @@ func TestNewConfigCustomHTTPEnv(t *testing.T) {
 	customAllowedOrigins := []string{"https://example.com", "http://127.0.0.1:3000"}
 	customAllowedMethods := []string{"GET", "POST"}
 	customAllowedHeaders := []string{"Content-Type", "X-Test-Header"}
+	customExternalBaseURL := "https://books.example.com"
+	customTrustedProxies := []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8"), netip.MustParsePrefix("::1/128")}
 	_ = os.Setenv(getEnvKey("HTTP_HOST"), customHost)
 	_ = os.Setenv(getEnvKey("HTTP_PORT"), strconv.Itoa(customPort))
 	_ = os.Setenv(getEnvKey("HTTP_READ_TIMEOUT"), customReadTimeout.String())
@@ -105,6 +185,8 @@ func TestNewConfigCustomHTTPEnv(t *testing.T) {
 	_ = os.Setenv(getEnvKey("HTTP_CORS_ALLOWED_ORIGINS"), strings.Join(customAllowedOrigins, ","))
 	_ = os.Setenv(getEnvKey("HTTP_CORS_ALLOWED_METHODS"), strings.Join(customAllowedMethods, ","))
 	_ = os.Setenv(getEnvKey("HTTP_CORS_ALLOWED_HEADERS"), strings.Join(customAllowedHeaders, ","))
+	_ = os.Setenv(getEnvKey("HTTP_EXTERNAL_BASE_URL"), customExternalBaseURL)
+	_ = os.Setenv(getEnvKey("HTTP_TRUSTED_PROXIES"), "10.0.0.0/8,::1/128")
 
 	defer func() {
 		_ = os.Unsetenv(getEnvKey("HTTP_HOST"))
@@ -116,6 +198,8 @@ func TestNewConfigCustomHTTPEnv(t *testing.T) {
 		_ = os.Unsetenv(getEnvKey("HTTP_CORS_ALLOWED_ORIGINS"))
 		_ = os.Unsetenv(getEnvKey("HTTP_CORS_ALLOWED_METHODS"))
 		_ = os.Unsetenv(getEnvKey("HTTP_CORS_ALLOWED_HEADERS"))
+		_ = os.Unsetenv(getEnvKey("HTTP_EXTERNAL_BASE_URL"))
+		_ = os.Unsetenv(getEnvKey("HTTP_TRUSTED_PROXIES"))
 	}()
 
 	config, err := New()
@@ -127,6 +211,8 @@ func TestNewConfigCustomHTTPEnv(t *testing.T) {
 		assert.Equal(t, customWriteTimeout, config.HTTP.WriteTimeout)
 		assert.Equal(t, customIdleTimeout, config.HTTP.IdleTimeout)
 		assert.Equal(t, customShutdownTimeout, config.HTTP.ShutdownTimeout)
+		assert.Equal(t, customExternalBaseURL, config.HTTP.ExternalBaseURL)
+		assert.Equal(t, customTrustedProxies, config.HTTP.TrustedProxies)
 
 		assert.Equal(t, customAllowedOrigins, config.HTTP.CORS.AllowedOrigins)
 		assert.Equal(t, customAllowedMethods, config.HTTP.CORS.AllowedMethods)
@@ -134,6 +220,29 @@ func TestNewConfigCustomHTTPEnv(t *testing.T) {
 	}
 }
 
+func TestNewConfigCustomGRPCEnv(t *testing.T) {
+	customHost := "127.0.0.1"
+	customPort := 9191
+	customShutdownTimeout := 5 * time.Second
+
+	_ = os.Setenv(getEnvKey("GRPC_HOST"), customHost)
+	_ = os.Setenv(getEnvKey("GRPC_PORT"), strconv.Itoa(customPort))
+	_ = os.Setenv(getEnvKey("GRPC_SHUTDOWN_TIMEOUT"), customShutdownTimeout.String())
+
+	defer func() {
+		_ = os.Unsetenv(getEnvKey("GRPC_HOST"))
+		_ = os.Unsetenv(getEnvKey("GRPC_PORT"))
+		_ = os.Unsetenv(getEnvKey("GRPC_SHUTDOWN_TIMEOUT"))
+	}()
+
+	config, err := New()
+	if assert.NoError(t, err, "should parse custom config") {
+		assert.Equal(t, customHost, config.GRPC.Host)
+		assert.Equal(t, customPort, config.GRPC.Port)
+		assert.Equal(t, customShutdownTimeout, config.GRPC.ShutdownTimeout)
+	}
+}
+
 func TestNewConfigCustomDBEnv(t *testing.T) {
 	customDriver := "sqlite3"
 	customHost := "192.168.1.100"
@@ -200,6 +309,7 @@ func TestNewConfigCustomDBEnv(t *testing.T) {
 
 func TestNewConfigCustomBLOBStoreEnv(t *testing.T) {
 	customBlobStoreBookCoverBucket := "custom-ebook-covers"
+	customBlobStoreBookFileBucket := "custom-ebook-files"
 	customBlobStoreMinioEndpoint := "192.168.0.10:9000"
 	customBlobStoreMinioAccessKeyID := "custom-minio-access-key"
 	customBlobStoreMinioSecretAccessKey := "custom-minio-secret-key"
@@ -207,6 +317,7 @@ func TestNewConfigCustomBLOBStoreEnv(t *testing.T) {
 	customBlobStoreMinioHealthCheckInterval := time.Duration(10000000000)
 
 	_ = os.Setenv(getEnvKey("BLOB_STORE_BOOK_COVER_BUCKET"), customBlobStoreBookCoverBucket)
+	_ = os.Setenv(getEnvKey("BLOB_STORE_BOOK_FILE_BUCKET"), customBlobStoreBookFileBucket)
 	_ = os.Setenv(getEnvKey("BLOB_STORE_MINIO_ENDPOINT"), customBlobStoreMinioEndpoint)
 	_ = os.Setenv(getEnvKey("BLOB_STORE_MINIO_ACCESS_KEY_ID"), customBlobStoreMinioAccessKeyID)
 	_ = os.Setenv(getEnvKey("BLOB_STORE_MINIO_ACCESS_SECRET_KEY"), customBlobStoreMinioSecretAccessKey)
@@ -215,6 +326,7 @@ func TestNewConfigCustomBLOBStoreEnv(t *testing.T) {
 
 	defer func() {
 		_ = os.Unsetenv(getEnvKey("BLOB_STORE_BOOK_COVER_BUCKET"))
+		_ = os.Unsetenv(getEnvKey("BLOB_STORE_BOOK_FILE_BUCKET"))
 		_ = os.Unsetenv(getEnvKey("BLOB_STORE_MINIO_ENDPOINT"))
 		_ = os.Unsetenv(getEnvKey("BLOB_STORE_MINIO_ACCESS_KEY_ID"))
 		_ = os.Unsetenv(getEnvKey("BLOB_STORE_MINIO_ACCESS_SECRET_KEY"))
@@ -226,6 +338,7 @@ func TestNewConfigCustomBLOBStoreEnv(t *testing.T) {
 	if assert.NoError(t, err, "should parse custom config") {
 		assert.NotEmpty(t, config, "config should not be empty")
 		assert.Equal(t, customBlobStoreBookCoverBucket, config.BLOBStore.BookCoverBucket)
+		assert.Equal(t, customBlobStoreBookFileBucket, config.BLOBStore.BookFileBucket)
 		assert.Equal(t, customBlobStoreMinioEndpoint, config.BLOBStore.MinioEndpoint)
 		assert.Equal(t, customBlobStoreMinioAccessKeyID, config.BLOBStore.MinioAccessKeyID)
 		assert.Equal(t, customBlobStoreMinioSecretAccessKey, config.BLOBStore.MinioSecretAccessKey)
@@ -234,6 +347,161 @@ func TestNewConfigCustomBLOBStoreEnv(t *testing.T) {
 	}
 }
 
+func TestNewConfigCustomInboxEnv(t *testing.T) {
+	customInboxEnabled := true
+	customInboxDir := "/tmp/inbox"
+	customInboxPollInterval := 5 * time.Second
+	customInboxSettleTime := time.Minute
+	customInboxDefaultLanguage := "German"
+
+	_ = os.Setenv(getEnvKey("INBOX_ENABLED"), strconv.FormatBool(customInboxEnabled))
+	_ = os.Setenv(getEnvKey("INBOX_DIR"), customInboxDir)
+	_ = os.Setenv(getEnvKey("INBOX_POLL_INTERVAL"), customInboxPollInterval.String())
+	_ = os.Setenv(getEnvKey("INBOX_SETTLE_TIME"), customInboxSettleTime.String())
+	_ = os.Setenv(getEnvKey("INBOX_DEFAULT_LANGUAGE"), customInboxDefaultLanguage)
+
+	defer func() {
+		_ = os.Unsetenv(getEnvKey("INBOX_ENABLED"))
+		_ = os.Unsetenv(getEnvKey("INBOX_DIR"))
+		_ = os.Unsetenv(getEnvKey("INBOX_POLL_INTERVAL"))
+		_ = os.Unsetenv(getEnvKey("INBOX_SETTLE_TIME"))
+		_ = os.Unsetenv(getEnvKey("INBOX_DEFAULT_LANGUAGE"))
+	}()
+
+	config, err := New()
+	if assert.NoError(t, err, "should parse custom config") {
+		assert.NotEmpty(t, config, "config should not be empty")
+		assert.Equal(t, customInboxEnabled, config.Inbox.Enabled)
+		assert.Equal(t, customInboxDir, config.Inbox.Dir)
+		assert.Equal(t, customInboxPollInterval, config.Inbox.PollInterval)
+		assert.Equal(t, customInboxSettleTime, config.Inbox.SettleTime)
+		assert.Equal(t, customInboxDefaultLanguage, config.Inbox.DefaultLanguage)
+	}
+}
+
+func TestNewConfigCustomIngestEnv(t *testing.T) {
+	customIngestMaxUploadSize := int64(64 << 20)
+	customIngestDraftCoverTTL := time.Hour
+
+	_ = os.Setenv(getEnvKey("INGEST_MAX_UPLOAD_SIZE"), strconv.FormatInt(customIngestMaxUploadSize, 10))
+	_ = os.Setenv(getEnvKey("INGEST_DRAFT_COVER_TTL"), customIngestDraftCoverTTL.String())
+
+	defer func() {
+		_ = os.Unsetenv(getEnvKey("INGEST_MAX_UPLOAD_SIZE"))
+		_ = os.Unsetenv(getEnvKey("INGEST_DRAFT_COVER_TTL"))
+	}()
+
+	config, err := New()
+	if assert.NoError(t, err, "should parse custom config") {
+		assert.Equal(t, customIngestMaxUploadSize, config.Ingest.MaxUploadSize)
+		assert.Equal(t, customIngestDraftCoverTTL, config.Ingest.DraftCoverTTL)
+	}
+}
+
+func TestNewConfigCustomMetadataEnv(t *testing.T) {
+	customMetadataProviderURL := "http://127.0.0.1:8090/api"
+	customMetadataTimeout := 3 * time.Second
+	customMetadataCacheTTL := 24 * time.Hour
+
+	_ = os.Setenv(getEnvKey("METADATA_PROVIDER_URL"), customMetadataProviderURL)
+	_ = os.Setenv(getEnvKey("METADATA_TIMEOUT"), customMetadataTimeout.String())
+	_ = os.Setenv(getEnvKey("METADATA_CACHE_TTL"), customMetadataCacheTTL.String())
+
+	defer func() {
+		_ = os.Unsetenv(getEnvKey("METADATA_PROVIDER_URL"))
+		_ = os.Unsetenv(getEnvKey("METADATA_TIMEOUT"))
+		_ = os.Unsetenv(getEnvKey("METADATA_CACHE_TTL"))
+	}()
+
+	config, err := New()
+	if assert.NoError(t, err, "should parse custom config") {
+		assert.Equal(t, customMetadataProviderURL, config.Metadata.ProviderURL)
+		assert.Equal(t, customMetadataTimeout, config.Metadata.Timeout)
+		assert.Equal(t, customMetadataCacheTTL, config.Metadata.CacheTTL)
+	}
+}
+
+func TestNewConfigCustomEventsEnv(t *testing.T) {
+	customEventsHeartbeatInterval := 30 * time.Second
+
+	_ = os.Setenv(getEnvKey("EVENTS_HEARTBEAT_INTERVAL"), customEventsHeartbeatInterval.String())
+
+	defer func() {
+		_ = os.Unsetenv(getEnvKey("EVENTS_HEARTBEAT_INTERVAL"))
+	}()
+
+	config, err := New()
+	if assert.NoError(t, err, "should parse custom config") {
+		assert.Equal(t, customEventsHeartbeatInterval, config.Events.HeartbeatInterval)
+	}
+}
+
+func TestNewConfigCustomWebhooksEnv(t *testing.T) {
+	customWebhooksDispatcherEnabled := false
+	customWebhooksPollInterval := time.Second
+	customWebhooksTimeout := 3 * time.Second
+	customWebhooksMaxAttempts := 3
+	customWebhooksInitialBackoff := 5 * time.Second
+	customWebhooksMaxBackoff := time.Hour
+	customWebhooksRetention := 24 * time.Hour
+
+	_ = os.Setenv(getEnvKey("WEBHOOKS_DISPATCHER_ENABLED"), strconv.FormatBool(customWebhooksDispatcherEnabled))
+	_ = os.Setenv(getEnvKey("WEBHOOKS_POLL_INTERVAL"), customWebhooksPollInterval.String())
+	_ = os.Setenv(getEnvKey("WEBHOOKS_TIMEOUT"), customWebhooksTimeout.String())
+	_ = os.Setenv(getEnvKey("WEBHOOKS_MAX_ATTEMPTS"), strconv.Itoa(customWebhooksMaxAttempts))
+	_ = os.Setenv(getEnvKey("WEBHOOKS_INITIAL_BACKOFF"), customWebhooksInitialBackoff.String())
+	_ = os.Setenv(getEnvKey("WEBHOOKS_MAX_BACKOFF"), customWebhooksMaxBackoff.String())
+	_ = os.Setenv(getEnvKey("WEBHOOKS_RETENTION"), customWebhooksRetention.String())
+
+	defer func() {
+		_ = os.Unsetenv(getEnvKey("WEBHOOKS_DISPATCHER_ENABLED"))
+		_ = os.Unsetenv(getEnvKey("WEBHOOKS_POLL_INTERVAL"))
+		_ = os.Unsetenv(getEnvKey("WEBHOOKS_TIMEOUT"))
+		_ = os.Unsetenv(getEnvKey("WEBHOOKS_MAX_ATTEMPTS"))
+		_ = os.Unsetenv(getEnvKey("WEBHOOKS_INITIAL_BACKOFF"))
+		_ = os.Unsetenv(getEnvKey("WEBHOOKS_MAX_BACKOFF"))
+		_ = os.Unsetenv(getEnvKey("WEBHOOKS_RETENTION"))
+	}()
+
+	config, err := New()
+	if assert.NoError(t, err, "should parse custom config") {
+		assert.Equal(t, customWebhooksDispatcherEnabled, config.Webhooks.DispatcherEnabled)
+		assert.Equal(t, customWebhooksPollInterval, config.Webhooks.PollInterval)
+		assert.Equal(t, customWebhooksTimeout, config.Webhooks.Timeout)
+		assert.Equal(t, customWebhooksMaxAttempts, config.Webhooks.MaxAttempts)
+		assert.Equal(t, customWebhooksInitialBackoff, config.Webhooks.InitialBackoff)
+		assert.Equal(t, customWebhooksMaxBackoff, config.Webhooks.MaxBackoff)
+		assert.Equal(t, customWebhooksRetention, config.Webhooks.Retention)
+	}
+}
+
+func TestNewConfigCustomOAIEnv(t *testing.T) {
+	customOAIRepositoryName := "Company Library"
+	customOAIRepositoryIdentifier := "library.example.com"
+	customOAIAdminEmail := "library@example.com"
+	customOAIPageSize := uint64(25)
+
+	_ = os.Setenv(getEnvKey("OAI_REPOSITORY_NAME"), customOAIRepositoryName)
+	_ = os.Setenv(getEnvKey("OAI_REPOSITORY_IDENTIFIER"), customOAIRepositoryIdentifier)
+	_ = os.Setenv(getEnvKey("OAI_ADMIN_EMAIL"), customOAIAdminEmail)
+	_ = os.Setenv(getEnvKey("OAI_PAGE_SIZE"), strconv.FormatUint(customOAIPageSize, 10))
+
+	defer func() {
+		_ = os.Unsetenv(getEnvKey("OAI_REPOSITORY_NAME"))
+		_ = os.Unsetenv(getEnvKey("OAI_REPOSITORY_IDENTIFIER"))
+		_ = os.Unsetenv(getEnvKey("OAI_ADMIN_EMAIL"))
+		_ = os.Unsetenv(getEnvKey("OAI_PAGE_SIZE"))
+	}()
+
+	config, err := New()
+	if assert.NoError(t, err, "should parse custom config") {
+		assert.Equal(t, customOAIRepositoryName, config.OAI.RepositoryName)
+		assert.Equal(t, customOAIRepositoryIdentifier, config.OAI.RepositoryIdentifier)
+		assert.Equal(t, customOAIAdminEmail, config.OAI.AdminEmail)
+		assert.Equal(t, customOAIPageSize, config.OAI.PageSize)
+	}
+}
+
 func TestNewConfigWithEmptyEnv(t *testing.T) {
 	_ = os.Setenv(getEnvKey("HTTP_HOST"), "")
 	_ = os.Setenv(getEnvKey("HTTP_PORT"), "")
@@ -242,6 +510,10 @@ func TestNewConfigWithEmptyEnv(t *testing.T) {
 	_ = os.Setenv(getEnvKey("HTTP_IDLE_TIMEOUT"), "")
 	_ = os.Setenv(getEnvKey("HTTP_SHUTDOWN_TIMEOUT"), "")
 
+	_ = os.Setenv(getEnvKey("GRPC_HOST"), "")
+	_ = os.Setenv(getEnvKey("GRPC_PORT"), "")
+	_ = os.Setenv(getEnvKey("GRPC_SHUTDOWN_TIMEOUT"), "")
+
 	_ = os.Setenv(getEnvKey("DB_DRIVER"), "")
 	_ = os.Setenv(getEnvKey("DB_HOST"), "")
 	_ = os.Setenv(getEnvKey("DB_PORT"), "")
@@ -257,12 +529,36 @@ func TestNewConfigWithEmptyEnv(t *testing.T) {
 	_ = os.Setenv(getEnvKey("DB_MIGRATION_LOCK_TIMEOUT_SEC"), "")
 
 	_ = os.Setenv(getEnvKey("BLOB_STORE_BOOK_COVER_BUCKET"), "")
+	_ = os.Setenv(getEnvKey("BLOB_STORE_BOOK_FILE_BUCKET"), "")
 	_ = os.Setenv(getEnvKey("BLOB_STORE_MINIO_ENDPOINT"), "")
 	_ = os.Setenv(getEnvKey("BLOB_STORE_MINIO_ACCESS_KEY_ID"), "")
 	_ = os.Setenv(getEnvKey("BLOB_STORE_MINIO_ACCESS_SECRET_KEY"), "")
 	_ = os.Setenv(getEnvKey("BLOB_STORE_MINIO_USE_SSL"), "")
 	_ = os.Setenv(getEnvKey("MINIO_HEALTHCHECK_INTERVAL"), "")
 
+	_ = os.Setenv(getEnvKey("INBOX_ENABLED"), "")
+	_ = os.Setenv(getEnvKey("INBOX_DIR"), "")
+	_ = os.Setenv(getEnvKey("INBOX_POLL_INTERVAL"), "")
+	_ = os.Setenv(getEnvKey("INBOX_SETTLE_TIME"), "")
+	_ = os.Setenv(getEnvKey("INBOX_DEFAULT_LANGUAGE"), "")
+	_ = os.Setenv(getEnvKey("INGEST_MAX_UPLOAD_SIZE"), "")
+	_ = os.Setenv(getEnvKey("INGEST_DRAFT_COVER_TTL"), "")
+	_ = os.Setenv(getEnvKey("METADATA_PROVIDER_URL"), "")
+	_ = os.Setenv(getEnvKey("METADATA_TIMEOUT"), "")
+	_ = os.Setenv(getEnvKey("METADATA_CACHE_TTL"), "")
+	_ = os.Setenv(getEnvKey("EVENTS_HEARTBEAT_INTERVAL"), "")
+	_ = os.Setenv(getEnvKey("WEBHOOKS_DISPATCHER_ENABLED"), "")
+	_ = os.Setenv(getEnvKey("WEBHOOKS_POLL_INTERVAL"), "")
+	_ = os.Setenv(getEnvKey("WEBHOOKS_TIMEOUT"), "")
+	_ = os.Setenv(getEnvKey("WEBHOOKS_MAX_ATTEMPTS"), "")
+	_ = os.Setenv(getEnvKey("WEBHOOKS_INITIAL_BACKOFF"), "")
+	_ = os.Setenv(getEnvKey("WEBHOOKS_MAX_BACKOFF"), "")
+	_ = os.Setenv(getEnvKey("WEBHOOKS_RETENTION"), "")
+	_ = os.Setenv(getEnvKey("OAI_REPOSITORY_NAME"), "")
+	_ = os.Setenv(getEnvKey("OAI_REPOSITORY_IDENTIFIER"), "")
+	_ = os.Setenv(getEnvKey("OAI_ADMIN_EMAIL"), "")
+	_ = os.Setenv(getEnvKey("OAI_PAGE_SIZE"), "")
+
 	defer func() {
 		_ = os.Unsetenv(getEnvKey("HTTP_HOST"))
 		_ = os.Unsetenv(getEnvKey("HTTP_PORT"))
@@ -270,6 +566,9 @@ func TestNewConfigWithEmptyEnv(t *testing.T) {
 		_ = os.Unsetenv(getEnvKey("HTTP_WRITE_TIMEOUT"))
 		_ = os.Unsetenv(getEnvKey("HTTP_IDLE_TIMEOUT"))
 		_ = os.Unsetenv(getEnvKey("HTTP_SHUTDOWN_TIMEOUT"))
+		_ = os.Unsetenv(getEnvKey("GRPC_HOST"))
+		_ = os.Unsetenv(getEnvKey("GRPC_PORT"))
+		_ = os.Unsetenv(getEnvKey("GRPC_SHUTDOWN_TIMEOUT"))
 		_ = os.Unsetenv(getEnvKey("DB_DRIVER"))
 		_ = os.Unsetenv(getEnvKey("DB_HOST"))
 		_ = os.Unsetenv(getEnvKey("DB_PORT"))
@@ -284,11 +583,34 @@ func TestNewConfigWithEmptyEnv(t *testing.T) {
 		_ = os.Unsetenv(getEnvKey("DB_AUTO_MIGRATE"))
 		_ = os.Unsetenv(getEnvKey("DB_MIGRATION_LOCK_TIMEOUT_SEC"))
 		_ = os.Unsetenv(getEnvKey("BLOB_STORE_BOOK_COVER_BUCKET"))
+		_ = os.Unsetenv(getEnvKey("BLOB_STORE_BOOK_FILE_BUCKET"))
 		_ = os.Unsetenv(getEnvKey("BLOB_STORE_MINIO_ENDPOINT"))
 		_ = os.Unsetenv(getEnvKey("BLOB_STORE_MINIO_ACCESS_KEY_ID"))
 		_ = os.Unsetenv(getEnvKey("BLOB_STORE_MINIO_ACCESS_SECRET_KEY"))
 		_ = os.Unsetenv(getEnvKey("BLOB_STORE_MINIO_USE_SSL"))
 		_ = os.Unsetenv(getEnvKey("MINIO_HEALTHCHECK_INTERVAL"))
+		_ = os.Unsetenv(getEnvKey("INBOX_ENABLED"))
+		_ = os.Unsetenv(getEnvKey("INBOX_DIR"))
+		_ = os.Unsetenv(getEnvKey("INBOX_POLL_INTERVAL"))
+		_ = os.Unsetenv(getEnvKey("INBOX_SETTLE_TIME"))
+		_ = os.Unsetenv(getEnvKey("INBOX_DEFAULT_LANGUAGE"))
+		_ = os.Unsetenv(getEnvKey("INGEST_MAX_UPLOAD_SIZE"))
+		_ = os.Unsetenv(getEnvKey("INGEST_DRAFT_COVER_TTL"))
+		_ = os.Unsetenv(getEnvKey("METADATA_PROVIDER_URL"))
+		_ = os.Unsetenv(getEnvKey("METADATA_TIMEOUT"))
+		_ = os.Unsetenv(getEnvKey("METADATA_CACHE_TTL"))
+		_ = os.Unsetenv(getEnvKey("EVENTS_HEARTBEAT_INTERVAL"))
+		_ = os.Unsetenv(getEnvKey("WEBHOOKS_DISPATCHER_ENABLED"))
+		_ = os.Unsetenv(getEnvKey("WEBHOOKS_POLL_INTERVAL"))
+		_ = os.Unsetenv(getEnvKey("WEBHOOKS_TIMEOUT"))
+		_ = os.Unsetenv(getEnvKey("WEBHOOKS_MAX_ATTEMPTS"))
+		_ = os.Unsetenv(getEnvKey("WEBHOOKS_INITIAL_BACKOFF"))
+		_ = os.Unsetenv(getEnvKey("WEBHOOKS_MAX_BACKOFF"))
+		_ = os.Unsetenv(getEnvKey("WEBHOOKS_RETENTION"))
+		_ = os.Unsetenv(getEnvKey("OAI_REPOSITORY_NAME"))
+		_ = os.Unsetenv(getEnvKey("OAI_REPOSITORY_IDENTIFIER"))
+		_ = os.Unsetenv(getEnvKey("OAI_ADMIN_EMAIL"))
+		_ = os.Unsetenv(getEnvKey("OAI_PAGE_SIZE"))
 	}()
 
 	config, err := New()
@@ -303,6 +625,12 @@ func TestNewConfigWithEmptyEnv(t *testing.T) {
 			assert.Equal(t, defaultHTTPShutdownTimeout, http.ShutdownTimeout)
 		}
 
+		if assert.NotEmpty(t, config.GRPC, "gRPC config should not be empty") {
+			assert.Equal(t, defaultGRPCHost, config.GRPC.Host)
+			assert.Equal(t, defaultGRPCPort, config.GRPC.Port)
+			assert.Equal(t, defaultGRPCShutdownTimeout, config.GRPC.ShutdownTimeout)
+		}
+
 		if assert.NotEmpty(t, config.DB, "DB config should not be empty") {
 			assert.Equal(t, defaultDBHost, config.DB.Host)
 			assert.Equal(t, defaultDBPort, config.DB.Port)
@@ -320,12 +648,48 @@ func TestNewConfigWithEmptyEnv(t *testing.T) {
 
 		if assert.NotEmpty(t, config.BLOBStore, "BLOBStore config should not be empty") {
 			assert.Equal(t, defaultBlobStoreBookCoverBucket, config.BLOBStore.BookCoverBucket)
+			assert.Equal(t, defaultBlobStoreBookFileBucket, config.BLOBStore.BookFileBucket)
 			assert.Equal(t, defaultBlobStoreMinioEndpoint, config.BLOBStore.MinioEndpoint)
 			assert.Equal(t, defaultBlobStoreMinioAccessKeyID, config.BLOBStore.MinioAccessKeyID)
 			assert.Equal(t, defaultBlobStoreMinioSecretAccessKey, config.BLOBStore.MinioSecretAccessKey)
 			assert.Equal(t, defaultBlobStoreMinioUseSSL, config.BLOBStore.MinioUseSSL)
 			assert.Equal(t, defaultBlobstoreMinioHealthCheckInterval, config.BLOBStore.MinioHealthCheckInterval)
 		}
+
+		if assert.NotEmpty(t, config.Inbox, "Inbox config should not be empty") {
+			assert.Equal(t, defaultInboxEnabled, config.Inbox.Enabled)
+			assert.Equal(t, defaultInboxDir, config.Inbox.Dir)
+			assert.Equal(t, defaultInboxPollInterval, config.Inbox.PollInterval)
+			assert.Equal(t, defaultInboxSettleTime, config.Inbox.SettleTime)
+			assert.Equal(t, defaultInboxDefaultLanguage, config.Inbox.DefaultLanguage)
+		}
+
+		if assert.NotEmpty(t, config.Ingest, "Ingest config should not be empty") {
+			assert.Equal(t, defaultIngestMaxUploadSize, config.Ingest.MaxUploadSize)
+			assert.Equal(t, defaultIngestDraftCoverTTL, config.Ingest.DraftCoverTTL)
+		}
+
+		assert.Equal(t, defaultMetadataProviderURL, config.Metadata.ProviderURL)
+		assert.Equal(t, defaultMetadataTimeout, config.Metadata.Timeout)
+		assert.Equal(t, defaultMetadataCacheTTL, config.Metadata.CacheTTL)
+		assert.Equal(t, defaultEventsHeartbeatInterval, config.Events.HeartbeatInterval)
+
+		if assert.NotEmpty(t, config.Webhooks, "Webhooks config should not be empty") {
+			assert.Equal(t, defaultWebhooksDispatcherEnabled, config.Webhooks.DispatcherEnabled)
+			assert.Equal(t, defaultWebhooksPollInterval, config.Webhooks.PollInterval)
+			assert.Equal(t, defaultWebhooksTimeout, config.Webhooks.Timeout)
+			assert.Equal(t, defaultWebhooksMaxAttempts, config.Webhooks.MaxAttempts)
+			assert.Equal(t, defaultWebhooksInitialBackoff, config.Webhooks.InitialBackoff)
+			assert.Equal(t, defaultWebhooksMaxBackoff, config.Webhooks.MaxBackoff)
+			assert.Equal(t, defaultWebhooksRetention, config.Webhooks.Retention)
+		}
+
+		if assert.NotEmpty(t, config.OAI, "OAI config should not be empty") {
+			assert.Equal(t, defaultOAIRepositoryName, config.OAI.RepositoryName)
+			assert.Equal(t, defaultOAIRepositoryIdentifier, config.OAI.RepositoryIdentifier)
+			assert.Equal(t, defaultOAIAdminEmail, config.OAI.AdminEmail)
+			assert.Equal(t, defaultOAIPageSize, config.OAI.PageSize)
+		}
 	}
 }
 
@@ -371,6 +735,30 @@ func TestNewConfigWithWrongPort(t *testing.T) {
 	_ = os.Unsetenv(getEnvKey("HTTP_PORT"))
 }
 
+func TestNewConfigWithInvalidValue(t *testing.T) {
+	for key, value := range map[string]string{
+		"EVENTS_HEARTBEAT_INTERVAL": "0s",
+		"INBOX_POLL_INTERVAL":       "-1s",
+		"INGEST_DRAFT_COVER_TTL":    "0s",
+		"INGEST_MAX_UPLOAD_SIZE":    "0",
+		"OAI_PAGE_SIZE":             "0",
+		"WEBHOOKS_POLL_INTERVAL":    "0s",
+	} {
+		t.Run(key, func(t *testing.T) {
+			_ = os.Setenv(getEnvKey(key), value)
+			defer func() {
+				_ = os.Unsetenv(getEnvKey(key))
+			}()
+
+			config, err := New()
+			if assert.ErrorIs(t, err, ErrInvalidValue) {
+				assert.Contains(t, err.Error(), getEnvKey(key))
+				assert.Empty(t, config, "config should be empty")
+			}
+		})
+	}
+}
+
 func getEnvKey(key string) string {
 	return EnvPrefix + key
 }
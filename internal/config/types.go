@@ -1,11 +1,21 @@
 package config
 
-import "time"
+import (
+	"net/netip"
+	"time"
+)
 
 type AppConfig struct {
 	HTTP      HTTPConfig      `envPrefix:"HTTP_"`
+	GRPC      GRPCConfig      `envPrefix:"GRPC_"`
 	DB        DBConfig        `envPrefix:"DB_"`
 	BLOBStore BLOBStoreConfig `envPrefix:"BLOB_STORE_"`
+	Inbox     InboxConfig     `envPrefix:"INBOX_"`
+	Ingest    IngestConfig    `envPrefix:"INGEST_"`
+	Metadata  MetadataConfig  `envPrefix:"METADATA_"`
+	Events    EventsConfig    `envPrefix:"EVENTS_"`
+	Webhooks  WebhooksConfig  `envPrefix:"WEBHOOKS_"`
+	OAI       OAIConfig       `envPrefix:"OAI_"`
 
 	BuildInfo BuildInfo
 }
@@ -17,13 +27,27 @@ type HTTPConfig struct {
 	WriteTimeout    time.Duration `env:"WRITE_TIMEOUT" envDefault:"10s"`
 	IdleTimeout     time.Duration `env:"IDLE_TIMEOUT" envDefault:"120s"`
 	ShutdownTimeout time.Duration `env:"SHUTDOWN_TIMEOUT" envDefault:"20s"`
-	CORS            struct {
+	// ExternalBaseURL - the base URL of the absolute links, e.g. 'https://books.example.com', it takes precedence
+	// over the forwarded headers
+	ExternalBaseURL string `env:"EXTERNAL_BASE_URL"`
+	// TrustedProxies - the reverse proxy networks, e.g. '10.0.0.0/8', the 'X-Forwarded-Proto' and
+	// 'X-Forwarded-Host' headers are only honoured from. A single address is set as the '/32' network
+	TrustedProxies []netip.Prefix `env:"TRUSTED_PROXIES"`
+	CORS           struct {
 		AllowedOrigins []string `env:"ALLOWED_ORIGINS"`
 		AllowedMethods []string `env:"ALLOWED_METHODS"`
 		AllowedHeaders []string `env:"ALLOWED_HEADERS"`
 	} `envPrefix:"CORS_"`
 }
 
+// GRPCConfig - the gRPC API server settings, it listens on its own port next to the HTTP server.
+// On shutdown the in-flight calls are drained for up to the shutdown timeout, then the rest are cancelled
+type GRPCConfig struct {
+	Host            string        `env:"HOST" envDefault:"0.0.0.0"`
+	Port            int           `env:"PORT" envDefault:"9090"`
+	ShutdownTimeout time.Duration `env:"SHUTDOWN_TIMEOUT" envDefault:"20s"`
+}
+
 type DBConfig struct {
 	Driver                  string `env:"DRIVER" envDefault:"postgres"`
 	Host                    string `env:"HOST" envDefault:"127.0.0.1"`
@@ -42,6 +66,7 @@ type DBConfig struct {
 
 type BLOBStoreConfig struct {
 	BookCoverBucket          string        `env:"BOOK_COVER_BUCKET" envDefault:"ebook-covers"`
+	BookFileBucket           string        `env:"BOOK_FILE_BUCKET" envDefault:"ebook-files"`
 	MinioEndpoint            string        `env:"MINIO_ENDPOINT" envDefault:"127.0.0.1:9000"`
 	MinioAccessKeyID         string        `env:"MINIO_ACCESS_KEY_ID" envDefault:"minio-access-key"`
 	MinioSecretAccessKey     string        `env:"MINIO_ACCESS_SECRET_KEY" envDefault:"minio-secret-key"`
@@ -49,6 +74,60 @@ type BLOBStoreConfig struct {
 	MinioHealthCheckInterval time.Duration `env:"MINIO_HEALTHCHECK_INTERVAL" envDefault:"10s"`
 }
 
+// InboxConfig - the watched inbox directory importer settings. The files are only picked up
+// once they have not been modified for the settle time, so the partially copied files are skipped
+type InboxConfig struct {
+	Enabled         bool          `env:"ENABLED" envDefault:"false"`
+	Dir             string        `env:"DIR" envDefault:"/var/lib/lib-manager/inbox"`
+	PollInterval    time.Duration `env:"POLL_INTERVAL" envDefault:"30s"`
+	SettleTime      time.Duration `env:"SETTLE_TIME" envDefault:"10s"`
+	DefaultLanguage string        `env:"DEFAULT_LANGUAGE" envDefault:"English"`
+}
+
+// IngestConfig - the book file ingest settings. The uploaded files are limited by the max upload size.
+// The covers, extracted on ingest, are stored before the book is saved, the unused ones expire after the TTL
+type IngestConfig struct {
+	MaxUploadSize int64         `env:"MAX_UPLOAD_SIZE" envDefault:"268435456"` // 256 MiB
+	DraftCoverTTL time.Duration `env:"DRAFT_COVER_TTL" envDefault:"24h"`
+}
+
+// MetadataConfig - the external metadata catalog settings, the enrichment is disabled without the provider URL.
+// The provider responses, including the 'not found' ones, are cached for the cache TTL
+type MetadataConfig struct {
+	ProviderURL string        `env:"PROVIDER_URL"`
+	Timeout     time.Duration `env:"TIMEOUT" envDefault:"10s"`
+	CacheTTL    time.Duration `env:"CACHE_TTL" envDefault:"720h"`
+}
+
+// EventsConfig - the catalog change events stream settings. The heartbeat comments keep
+// the idle streams open through the proxies, which close the silent connections
+type EventsConfig struct {
+	HeartbeatInterval time.Duration `env:"HEARTBEAT_INTERVAL" envDefault:"15s"`
+}
+
+// WebhooksConfig - the webhook dispatcher settings. The failed deliveries are retried with the exponential
+// backoff, starting from the initial one, and are dead-lettered after the max attempts.
+// The finished deliveries are kept in the delivery log for the retention period
+type WebhooksConfig struct {
+	DispatcherEnabled bool          `env:"DISPATCHER_ENABLED" envDefault:"true"`
+	PollInterval      time.Duration `env:"POLL_INTERVAL" envDefault:"5s"`
+	Timeout           time.Duration `env:"TIMEOUT" envDefault:"10s"`
+	MaxAttempts       int           `env:"MAX_ATTEMPTS" envDefault:"8"`
+	InitialBackoff    time.Duration `env:"INITIAL_BACKOFF" envDefault:"30s"`
+	MaxBackoff        time.Duration `env:"MAX_BACKOFF" envDefault:"6h"`
+	Retention         time.Duration `env:"RETENTION" envDefault:"720h"`
+}
+
+// OAIConfig - the OAI-PMH metadata harvesting settings. The repository identifier is the domain name
+// the record identifiers are built from, e.g. 'oai:lib-manager.local:book/1'. The list responses have up to
+// the page size of the items, the rest are fetched with the resumption token
+type OAIConfig struct {
+	RepositoryName       string `env:"REPOSITORY_NAME" envDefault:"Lib Manager"`
+	RepositoryIdentifier string `env:"REPOSITORY_IDENTIFIER" envDefault:"lib-manager.local"`
+	AdminEmail           string `env:"ADMIN_EMAIL" envDefault:"admin@lib-manager.local"`
+	PageSize             uint64 `env:"PAGE_SIZE" envDefault:"100"`
+}
+
 type BuildInfo struct {
 	Revision string
 	Time     string
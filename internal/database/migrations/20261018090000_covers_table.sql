@@ -0,0 +1,35 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE ebook.covers
+(
+    hash       CHAR(64)     NOT NULL,
+    size       BIGINT       NOT NULL,
+    mime_type  VARCHAR(255) NOT NULL,
+    width      INTEGER   DEFAULT NULL,
+    height     INTEGER   DEFAULT NULL,
+    created_at TIMESTAMP DEFAULT now(),
+    PRIMARY KEY (hash)
+);
+
+ALTER TABLE ebook.books
+    ADD COLUMN cover_hash CHAR(64) DEFAULT NULL;
+
+ALTER TABLE ebook.books
+    ADD CONSTRAINT fk_cover
+        FOREIGN KEY (cover_hash)
+            REFERENCES ebook.covers (hash)
+            ON DELETE SET NULL
+            ON UPDATE CASCADE;
+
+CREATE INDEX IF NOT EXISTS cover_hash_idx ON ebook.books (cover_hash);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS ebook.cover_hash_idx;
+ALTER TABLE ebook.books
+    DROP CONSTRAINT fk_cover;
+ALTER TABLE ebook.books
+    DROP COLUMN cover_hash;
+DROP TABLE IF EXISTS ebook.covers;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE ebook.books
+    ADD COLUMN cover_width          INTEGER       DEFAULT NULL,
+    ADD COLUMN cover_height         INTEGER       DEFAULT NULL,
+    ADD COLUMN cover_aspect_ratio   NUMERIC(8, 4) DEFAULT NULL,
+    ADD COLUMN cover_dominant_color CHAR(7)       DEFAULT NULL,
+    ADD COLUMN cover_blurhash       VARCHAR(128)  DEFAULT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE ebook.books
+    DROP COLUMN cover_width,
+    DROP COLUMN cover_height,
+    DROP COLUMN cover_aspect_ratio,
+    DROP COLUMN cover_dominant_color,
+    DROP COLUMN cover_blurhash;
+-- +goose StatementEnd
@@ -0,0 +1,38 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE ebook.book_files
+(
+    book_id      BIGINT       NOT NULL,
+    file_type_id BIGINT       NOT NULL,
+    object_key   VARCHAR(255) NOT NULL,
+    size         BIGINT       NOT NULL,
+    sha256       CHAR(64)     NOT NULL,
+    content_type VARCHAR(255) NOT NULL,
+    created_at   TIMESTAMP DEFAULT now(),
+    updated_at   TIMESTAMP DEFAULT now(),
+    PRIMARY KEY (book_id, file_type_id)
+);
+
+ALTER TABLE ebook.book_files
+    ADD CONSTRAINT fk_book
+        FOREIGN KEY (book_id)
+            REFERENCES ebook.books (id)
+            ON DELETE CASCADE
+            ON UPDATE CASCADE;
+
+ALTER TABLE ebook.book_files
+    ADD CONSTRAINT fk_file_type
+        FOREIGN KEY (file_type_id)
+            REFERENCES ebook.file_types (id)
+            ON DELETE CASCADE
+            ON UPDATE CASCADE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE ebook.book_files
+    DROP CONSTRAINT fk_file_type;
+ALTER TABLE ebook.book_files
+    DROP CONSTRAINT fk_book;
+DROP TABLE IF EXISTS ebook.book_files;
+-- +goose StatementEnd
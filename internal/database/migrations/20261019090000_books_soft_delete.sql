@@ -0,0 +1,37 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE ebook.books
+    ADD COLUMN deleted_at  TIMESTAMP DEFAULT NULL,
+    ADD COLUMN merged_into BIGINT    DEFAULT NULL;
+
+ALTER TABLE ebook.books
+    ADD CONSTRAINT fk_merged_into
+        FOREIGN KEY (merged_into)
+            REFERENCES ebook.books (id)
+            ON DELETE SET NULL
+            ON UPDATE CASCADE;
+
+-- the identifiers of the merged (soft-deleted) books may be taken over by the remaining ones
+DROP INDEX IF EXISTS ebook.isbn10_unique;
+DROP INDEX IF EXISTS ebook.isbn13_unique;
+DROP INDEX IF EXISTS ebook.asin_unique;
+CREATE UNIQUE INDEX IF NOT EXISTS isbn10_unique ON ebook.books (isbn10) WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS isbn13_unique ON ebook.books (isbn13) WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS asin_unique ON ebook.books (asin) WHERE deleted_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS ebook.isbn10_unique;
+DROP INDEX IF EXISTS ebook.isbn13_unique;
+DROP INDEX IF EXISTS ebook.asin_unique;
+CREATE UNIQUE INDEX IF NOT EXISTS isbn10_unique ON ebook.books (isbn10);
+CREATE UNIQUE INDEX IF NOT EXISTS isbn13_unique ON ebook.books (isbn13);
+CREATE UNIQUE INDEX IF NOT EXISTS asin_unique ON ebook.books (asin);
+
+ALTER TABLE ebook.books
+    DROP CONSTRAINT fk_merged_into;
+ALTER TABLE ebook.books
+    DROP COLUMN deleted_at,
+    DROP COLUMN merged_into;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+-- the 'not found' provider responses are cached as well, with the NULL record
+CREATE TABLE ebook.metadata_cache
+(
+    provider   VARCHAR(255) NOT NULL,
+    lookup_key VARCHAR(512) NOT NULL,
+    record     JSONB     DEFAULT NULL,
+    fetched_at TIMESTAMP DEFAULT now(),
+    PRIMARY KEY (provider, lookup_key)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS ebook.metadata_cache;
+-- +goose StatementEnd
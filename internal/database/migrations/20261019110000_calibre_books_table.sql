@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+-- the books imported from the Calibre libraries, by the Calibre book UUID, to keep the import idempotent
+CREATE TABLE ebook.calibre_books
+(
+    calibre_uuid  UUID      NOT NULL PRIMARY KEY,
+    book_id       BIGINT    NOT NULL,
+    last_modified TIMESTAMP NOT NULL,
+    imported_at   TIMESTAMP DEFAULT now()
+);
+
+ALTER TABLE ebook.calibre_books
+    ADD CONSTRAINT fk_book
+        FOREIGN KEY (book_id)
+            REFERENCES ebook.books (id)
+            ON DELETE CASCADE
+            ON UPDATE CASCADE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE ebook.calibre_books
+    DROP CONSTRAINT fk_book;
+DROP TABLE IF EXISTS ebook.calibre_books;
+-- +goose StatementEnd
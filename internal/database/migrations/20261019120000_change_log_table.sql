@@ -0,0 +1,135 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE SEQUENCE ebook.change_log_id_seq AS BIGINT;
+
+-- the bounded log of the catalog changes, the subscribers resume from it by the last received event ID.
+-- The concurrent transactions commit their events out of the ID order, so the readers only read the events
+-- of the finished transactions, below the snapshot 'xmin', in the transaction ID order
+CREATE TABLE ebook.change_log
+(
+    id         BIGINT    default nextval('ebook.change_log_id_seq'::regclass) NOT NULL,
+    event_type VARCHAR(32)                                                    NOT NULL,
+    book_id    BIGINT                                                         NOT NULL,
+    tx_id      BIGINT    DEFAULT txid_current()                               NOT NULL,
+    created_at TIMESTAMP DEFAULT now(),
+    PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS change_log_tx_idx ON ebook.change_log (tx_id, book_id);
+
+-- records the change once per transaction, and notifies the listeners on commit
+CREATE OR REPLACE FUNCTION ebook.log_change(change_type VARCHAR, changed_book_id BIGINT) RETURNS VOID AS
+$$
+DECLARE
+    event_id BIGINT;
+BEGIN
+    IF EXISTS (SELECT 1
+               FROM ebook.change_log
+               WHERE tx_id = txid_current()
+                 AND book_id = changed_book_id
+                 AND (event_type = change_type OR
+                      (change_type = 'book.updated' AND event_type = 'book.created'))) THEN
+        RETURN;
+    END IF;
+
+    INSERT INTO ebook.change_log (event_type, book_id)
+    VALUES (change_type, changed_book_id)
+    RETURNING id INTO event_id;
+    DELETE FROM ebook.change_log WHERE id <= event_id - 10000;
+
+    PERFORM pg_notify('ebook_changes', '');
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION ebook.log_book_change() RETURNS TRIGGER AS
+$$
+DECLARE
+    -- the columns, which are not the book details
+    cover_columns  TEXT[] := ARRAY ['cover_file_name', 'cover_hash', 'cover_width', 'cover_height',
+        'cover_aspect_ratio', 'cover_dominant_color', 'cover_blurhash'];
+    system_columns TEXT[] := ARRAY ['updated_at', 'deleted_at', 'merged_into'];
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        PERFORM ebook.log_change('book.created', NEW.id);
+    ELSIF TG_OP = 'DELETE' THEN
+        PERFORM ebook.log_change('book.deleted', OLD.id);
+    ELSIF OLD.deleted_at IS NULL AND NEW.deleted_at IS NOT NULL THEN
+        PERFORM ebook.log_change('book.deleted', NEW.id);
+    ELSE
+        IF OLD.cover_hash IS DISTINCT FROM NEW.cover_hash OR OLD.cover_file_name IS DISTINCT FROM NEW.cover_file_name THEN
+            PERFORM ebook.log_change('cover.updated', NEW.id);
+        END IF;
+        IF (to_jsonb(OLD) - cover_columns - system_columns) IS DISTINCT FROM
+           (to_jsonb(NEW) - cover_columns - system_columns) THEN
+            PERFORM ebook.log_change('book.updated', NEW.id);
+        END IF;
+    END IF;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION ebook.log_book_relation_change() RETURNS TRIGGER AS
+$$
+DECLARE
+    changed_book_id BIGINT;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        changed_book_id := OLD.book_id;
+    ELSE
+        changed_book_id := NEW.book_id;
+    END IF;
+
+    -- the relations, removed along with the book, are not a separate change
+    IF EXISTS (SELECT 1 FROM ebook.books WHERE id = changed_book_id AND deleted_at IS NULL) THEN
+        PERFORM ebook.log_change('book.updated', changed_book_id);
+    END IF;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER books_change_log
+    AFTER INSERT OR UPDATE OR DELETE
+    ON ebook.books
+    FOR EACH ROW
+EXECUTE FUNCTION ebook.log_book_change();
+
+CREATE TRIGGER book_author_change_log
+    AFTER INSERT OR DELETE
+    ON ebook.book_author
+    FOR EACH ROW
+EXECUTE FUNCTION ebook.log_book_relation_change();
+
+CREATE TRIGGER book_category_change_log
+    AFTER INSERT OR DELETE
+    ON ebook.book_category
+    FOR EACH ROW
+EXECUTE FUNCTION ebook.log_book_relation_change();
+
+CREATE TRIGGER book_file_type_change_log
+    AFTER INSERT OR DELETE
+    ON ebook.book_file_type
+    FOR EACH ROW
+EXECUTE FUNCTION ebook.log_book_relation_change();
+
+CREATE TRIGGER book_tag_change_log
+    AFTER INSERT OR DELETE
+    ON ebook.book_tag
+    FOR EACH ROW
+EXECUTE FUNCTION ebook.log_book_relation_change();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS book_tag_change_log ON ebook.book_tag;
+DROP TRIGGER IF EXISTS book_file_type_change_log ON ebook.book_file_type;
+DROP TRIGGER IF EXISTS book_category_change_log ON ebook.book_category;
+DROP TRIGGER IF EXISTS book_author_change_log ON ebook.book_author;
+DROP TRIGGER IF EXISTS books_change_log ON ebook.books;
+DROP FUNCTION IF EXISTS ebook.log_book_relation_change();
+DROP FUNCTION IF EXISTS ebook.log_book_change();
+DROP FUNCTION IF EXISTS ebook.log_change(VARCHAR, BIGINT);
+DROP TABLE IF EXISTS ebook.change_log;
+DROP SEQUENCE IF EXISTS ebook.change_log_id_seq;
+-- +goose StatementEnd
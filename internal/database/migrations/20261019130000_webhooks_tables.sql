@@ -0,0 +1,114 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE SEQUENCE ebook.webhooks_id_seq AS BIGINT;
+
+-- the empty events list subscribes the webhook to all the event types
+CREATE TABLE ebook.webhooks
+(
+    id         BIGINT    default nextval('ebook.webhooks_id_seq'::regclass) NOT NULL,
+    url        VARCHAR(2048)                                                NOT NULL,
+    secret     VARCHAR(255)                                                 NOT NULL,
+    events     TEXT[]    DEFAULT '{}'                                       NOT NULL,
+    active     BOOLEAN   DEFAULT TRUE                                       NOT NULL,
+    created_at TIMESTAMP DEFAULT now(),
+    updated_at TIMESTAMP DEFAULT now(),
+    PRIMARY KEY (id)
+);
+
+CREATE SEQUENCE ebook.webhook_deliveries_id_seq AS BIGINT;
+
+-- the outbox of the webhook deliveries, it is also the delivery log
+CREATE TABLE ebook.webhook_deliveries
+(
+    id               BIGINT      default nextval('ebook.webhook_deliveries_id_seq'::regclass) NOT NULL,
+    webhook_id       BIGINT                                                                   NOT NULL,
+    event_id         BIGINT                                                                   NOT NULL,
+    event_type       VARCHAR(32)                                                              NOT NULL,
+    book_id          BIGINT                                                                   NOT NULL,
+    status           VARCHAR(16) DEFAULT 'pending'                                            NOT NULL,
+    attempts         INTEGER     DEFAULT 0                                                    NOT NULL,
+    last_status_code INTEGER     DEFAULT NULL,
+    last_error       TEXT        DEFAULT NULL,
+    next_attempt_at  TIMESTAMP   DEFAULT now()                                                NOT NULL,
+    delivered_at     TIMESTAMP   DEFAULT NULL,
+    created_at       TIMESTAMP   DEFAULT now(),
+    updated_at       TIMESTAMP   DEFAULT now(),
+    PRIMARY KEY (id)
+);
+
+ALTER TABLE ebook.webhook_deliveries
+    ADD CONSTRAINT fk_webhook
+        FOREIGN KEY (webhook_id)
+            REFERENCES ebook.webhooks (id)
+            ON DELETE CASCADE
+            ON UPDATE CASCADE;
+
+CREATE INDEX IF NOT EXISTS webhook_deliveries_webhook_idx ON ebook.webhook_deliveries (webhook_id, id);
+CREATE INDEX IF NOT EXISTS webhook_deliveries_pending_idx ON ebook.webhook_deliveries (next_attempt_at)
+    WHERE status = 'pending';
+
+CREATE OR REPLACE FUNCTION ebook.log_change(change_type VARCHAR, changed_book_id BIGINT) RETURNS VOID AS
+$$
+DECLARE
+    event_id BIGINT;
+BEGIN
+    IF EXISTS (SELECT 1
+               FROM ebook.change_log
+               WHERE tx_id = txid_current()
+                 AND book_id = changed_book_id
+                 AND (event_type = change_type OR
+                      (change_type = 'book.updated' AND event_type = 'book.created'))) THEN
+        RETURN;
+    END IF;
+
+    INSERT INTO ebook.change_log (event_type, book_id)
+    VALUES (change_type, changed_book_id)
+    RETURNING id INTO event_id;
+    DELETE FROM ebook.change_log WHERE id <= event_id - 10000;
+
+    -- the webhook deliveries are queued in the same transaction as the change itself
+    INSERT INTO ebook.webhook_deliveries (webhook_id, event_id, event_type, book_id)
+    SELECT id, event_id, change_type, changed_book_id
+    FROM ebook.webhooks
+    WHERE active
+      AND (cardinality(events) = 0 OR change_type = ANY (events));
+
+    PERFORM pg_notify('ebook_changes', '');
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION ebook.log_change(change_type VARCHAR, changed_book_id BIGINT) RETURNS VOID AS
+$$
+DECLARE
+    event_id BIGINT;
+BEGIN
+    IF EXISTS (SELECT 1
+               FROM ebook.change_log
+               WHERE tx_id = txid_current()
+                 AND book_id = changed_book_id
+                 AND (event_type = change_type OR
+                      (change_type = 'book.updated' AND event_type = 'book.created'))) THEN
+        RETURN;
+    END IF;
+
+    INSERT INTO ebook.change_log (event_type, book_id)
+    VALUES (change_type, changed_book_id)
+    RETURNING id INTO event_id;
+    DELETE FROM ebook.change_log WHERE id <= event_id - 10000;
+
+    PERFORM pg_notify('ebook_changes', '');
+END;
+$$ LANGUAGE plpgsql;
+
+DROP INDEX IF EXISTS ebook.webhook_deliveries_pending_idx;
+DROP INDEX IF EXISTS ebook.webhook_deliveries_webhook_idx;
+ALTER TABLE ebook.webhook_deliveries
+    DROP CONSTRAINT fk_webhook;
+DROP TABLE IF EXISTS ebook.webhook_deliveries;
+DROP SEQUENCE IF EXISTS ebook.webhook_deliveries_id_seq;
+DROP TABLE IF EXISTS ebook.webhooks;
+DROP SEQUENCE IF EXISTS ebook.webhooks_id_seq;
+-- +goose StatementEnd
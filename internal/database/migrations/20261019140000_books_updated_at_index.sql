@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- the metadata harvesting pages through the books in the update time order
+CREATE INDEX IF NOT EXISTS books_updated_at_idx ON ebook.books (updated_at, id) WHERE deleted_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS ebook.books_updated_at_idx;
+-- +goose StatementEnd
@@ -0,0 +1,104 @@
+-- +goose Up
+-- +goose StatementBegin
+-- the dictionary entries are looked up by their case-insensitive names, the duplicates are merged into the oldest
+-- entry first: the relations are moved to it, and the duplicates are deleted along with their remaining relations
+CREATE TEMPORARY TABLE dictionary_duplicates
+(
+    id           BIGINT NOT NULL,
+    canonical_id BIGINT NOT NULL
+) ON COMMIT DROP;
+
+INSERT INTO dictionary_duplicates
+SELECT id, canonical_id
+FROM (SELECT id, MIN(id) OVER (PARTITION BY LOWER(name)) AS canonical_id FROM ebook.authors) AS entries
+WHERE id <> canonical_id;
+INSERT INTO ebook.book_author (book_id, author_id)
+SELECT book_id, canonical_id
+FROM ebook.book_author
+         JOIN dictionary_duplicates ON dictionary_duplicates.id = book_author.author_id
+ON CONFLICT DO NOTHING;
+DELETE FROM ebook.authors WHERE id IN (SELECT id FROM dictionary_duplicates);
+TRUNCATE dictionary_duplicates;
+
+INSERT INTO dictionary_duplicates
+SELECT id, canonical_id
+FROM (SELECT id, MIN(id) OVER (PARTITION BY LOWER(name)) AS canonical_id FROM ebook.categories) AS entries
+WHERE id <> canonical_id;
+INSERT INTO ebook.book_category (book_id, category_id)
+SELECT book_id, canonical_id
+FROM ebook.book_category
+         JOIN dictionary_duplicates ON dictionary_duplicates.id = book_category.category_id
+ON CONFLICT DO NOTHING;
+DELETE FROM ebook.categories WHERE id IN (SELECT id FROM dictionary_duplicates);
+TRUNCATE dictionary_duplicates;
+
+INSERT INTO dictionary_duplicates
+SELECT id, canonical_id
+FROM (SELECT id, MIN(id) OVER (PARTITION BY LOWER(name)) AS canonical_id FROM ebook.tags) AS entries
+WHERE id <> canonical_id;
+INSERT INTO ebook.book_tag (book_id, tag_id)
+SELECT book_id, canonical_id
+FROM ebook.book_tag
+         JOIN dictionary_duplicates ON dictionary_duplicates.id = book_tag.tag_id
+ON CONFLICT DO NOTHING;
+DELETE FROM ebook.tags WHERE id IN (SELECT id FROM dictionary_duplicates);
+TRUNCATE dictionary_duplicates;
+
+-- the book file of a duplicate file type is kept, unless the book already has a file of the oldest one
+INSERT INTO dictionary_duplicates
+SELECT id, canonical_id
+FROM (SELECT id, MIN(id) OVER (PARTITION BY LOWER(name)) AS canonical_id FROM ebook.file_types) AS entries
+WHERE id <> canonical_id;
+INSERT INTO ebook.book_file_type (book_id, file_type_id)
+SELECT book_id, canonical_id
+FROM ebook.book_file_type
+         JOIN dictionary_duplicates ON dictionary_duplicates.id = book_file_type.file_type_id
+ON CONFLICT DO NOTHING;
+UPDATE ebook.book_files
+SET file_type_id = dictionary_duplicates.canonical_id
+FROM dictionary_duplicates
+WHERE dictionary_duplicates.id = book_files.file_type_id
+  AND NOT EXISTS (SELECT 1 FROM ebook.book_files AS existing
+                  WHERE existing.book_id = book_files.book_id
+                    AND existing.file_type_id = dictionary_duplicates.canonical_id);
+DELETE FROM ebook.file_types WHERE id IN (SELECT id FROM dictionary_duplicates);
+TRUNCATE dictionary_duplicates;
+
+INSERT INTO dictionary_duplicates
+SELECT id, canonical_id
+FROM (SELECT id, MIN(id) OVER (PARTITION BY LOWER(name)) AS canonical_id FROM ebook.languages) AS entries
+WHERE id <> canonical_id;
+UPDATE ebook.books
+SET language_id = dictionary_duplicates.canonical_id
+FROM dictionary_duplicates
+WHERE dictionary_duplicates.id = books.language_id;
+DELETE FROM ebook.languages WHERE id IN (SELECT id FROM dictionary_duplicates);
+TRUNCATE dictionary_duplicates;
+
+INSERT INTO dictionary_duplicates
+SELECT id, canonical_id
+FROM (SELECT id, MIN(id) OVER (PARTITION BY LOWER(name)) AS canonical_id FROM ebook.publishers) AS entries
+WHERE id <> canonical_id;
+UPDATE ebook.books
+SET publisher_id = dictionary_duplicates.canonical_id
+FROM dictionary_duplicates
+WHERE dictionary_duplicates.id = books.publisher_id;
+DELETE FROM ebook.publishers WHERE id IN (SELECT id FROM dictionary_duplicates);
+
+CREATE UNIQUE INDEX IF NOT EXISTS authors_name_unique ON ebook.authors (LOWER(name));
+CREATE UNIQUE INDEX IF NOT EXISTS categories_name_unique ON ebook.categories (LOWER(name));
+CREATE UNIQUE INDEX IF NOT EXISTS tags_name_unique ON ebook.tags (LOWER(name));
+CREATE UNIQUE INDEX IF NOT EXISTS file_types_name_unique ON ebook.file_types (LOWER(name));
+CREATE UNIQUE INDEX IF NOT EXISTS languages_name_unique ON ebook.languages (LOWER(name));
+CREATE UNIQUE INDEX IF NOT EXISTS publishers_name_unique ON ebook.publishers (LOWER(name));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS ebook.publishers_name_unique;
+DROP INDEX IF EXISTS ebook.languages_name_unique;
+DROP INDEX IF EXISTS ebook.file_types_name_unique;
+DROP INDEX IF EXISTS ebook.tags_name_unique;
+DROP INDEX IF EXISTS ebook.categories_name_unique;
+DROP INDEX IF EXISTS ebook.authors_name_unique;
+-- +goose StatementEnd
@@ -38,6 +38,23 @@ func (db *DB) HealthCheckID() string {
 
 // Open - opens a DB connection using provided configuration
 func Open(config config.DBConfig) (*sqlx.DB, error) {
+	db, err := sqlx.Open(config.Driver, ConnectionURL(config))
+	if err != nil {
+		return nil, err
+	}
+	db.SetMaxOpenConns(config.MaxOpen)
+	db.SetMaxIdleConns(config.MaxIdle)
+
+	if err := db.Ping(); err != nil {
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// ConnectionURL - returns the connection URL of the configured database.
+// It is also used by the dedicated connections, like the notification listener one
+func ConnectionURL(config config.DBConfig) string {
 	urlValues := make(url.Values)
 	urlValues.Set(timezoneKey, config.Timezone)
 	urlValues.Set(sslModeKey, config.SSLMode)
@@ -50,18 +67,8 @@ func Open(config config.DBConfig) (*sqlx.DB, error) {
 		Path:     config.Name,
 		RawQuery: urlValues.Encode(),
 	}
-	db, err := sqlx.Open(config.Driver, dbURL.String())
-	if err != nil {
-		return nil, err
-	}
-	db.SetMaxOpenConns(config.MaxOpen)
-	db.SetMaxIdleConns(config.MaxIdle)
 
-	if err := db.Ping(); err != nil {
-		return nil, err
-	}
-
-	return db, nil
+	return dbURL.String()
 }
 
 // Migrate - migrates the database schema to the latest revision, if enabled in the config
@@ -0,0 +1,9 @@
+package dav
+
+import "errors"
+
+var (
+	ErrNotFound = errors.New("resource not found")
+	// ErrCollection - the collection content is requested, only the files have one
+	ErrCollection = errors.New("resource is a collection")
+)
@@ -0,0 +1,180 @@
+package dav
+
+import (
+	"context"
+	"errors"
+	"github.com/jmoiron/sqlx"
+	"github.com/sdreger/lib-manager-go/internal/blobtstore"
+	"io"
+	"log/slog"
+)
+
+type Store interface {
+	GetDirectories(ctx context.Context, folder Folder) ([]Directory, error)
+	GetBookFiles(ctx context.Context, folder Folder, name string) ([]bookFileEntity, error)
+}
+
+type BlobStore interface {
+	GetBookFile(ctx context.Context, filePath string) (io.ReadSeekCloser, blobtstore.Object, error)
+}
+
+// Service - the read-only share of the book files, arranged into a virtual tree, e.g.
+// '/by-publisher/{publisher}/{title}.{fileType}'. The tree is built from the catalog on each request
+type Service struct {
+	logger    *slog.Logger
+	store     Store
+	blobStore BlobStore
+}
+
+func NewService(logger *slog.Logger, db *sqlx.DB, blobStore BlobStore) *Service {
+	return &Service{
+		logger:    logger,
+		store:     NewDBStore(db),
+		blobStore: blobStore,
+	}
+}
+
+// GetResources - returns the resource by its path, followed by its members, if the depth is greater than zero
+// and the resource is a collection. The resources are described by the catalog alone, the object store is not queried
+func (s *Service) GetResources(ctx context.Context, resourcePath string, depth int) ([]Resource, error) {
+	segments := splitPath(resourcePath)
+	switch len(segments) {
+	case 0:
+		resources := []Resource{{Path: "/", Collection: true}}
+		if depth > 0 {
+			for _, folder := range Folders {
+				resources = append(resources, Resource{Path: "/" + folder.Name + "/", Name: folder.Name, Collection: true})
+			}
+		}
+		return resources, nil
+	case 1:
+		return s.getFolderResources(ctx, segments[0], depth)
+	case 2:
+		return s.getDirectoryResources(ctx, segments[0], segments[1], depth)
+	case 3:
+		entity, filePath, err := s.findBookFile(ctx, segments)
+		if err != nil {
+			return nil, err
+		}
+		return []Resource{fileResource(filePath, segments[2], entity)}, nil
+	default:
+		return nil, ErrNotFound
+	}
+}
+
+// GetFile - returns the file content along with its description, or ErrCollection for the existing collections
+func (s *Service) GetFile(ctx context.Context, resourcePath string) (FileContent, error) {
+	segments := splitPath(resourcePath)
+	if len(segments) < 3 {
+		if _, err := s.GetResources(ctx, resourcePath, 0); err != nil {
+			return FileContent{}, err
+		}
+		return FileContent{}, ErrCollection
+	}
+
+	entity, filePath, err := s.findBookFile(ctx, segments)
+	if err != nil {
+		return FileContent{}, err
+	}
+	content, _, err := s.blobStore.GetBookFile(ctx, entity.ObjectKey)
+	if err != nil {
+		if errors.Is(err, blobtstore.ErrObjectNotFound) {
+			s.logger.Warn("book file object not found", "bookID", entity.BookID, "objectKey", entity.ObjectKey)
+			return FileContent{}, ErrNotFound
+		}
+		return FileContent{}, err
+	}
+
+	return FileContent{Resource: fileResource(filePath, segments[2], entity), Content: content}, nil
+}
+
+func (s *Service) getFolderResources(ctx context.Context, folderName string, depth int) ([]Resource, error) {
+	folder, ok := FolderByName(folderName)
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	folderPath := "/" + folder.Name + "/"
+	resources := []Resource{{Path: folderPath, Name: folder.Name, Collection: true}}
+	if depth == 0 {
+		return resources, nil
+	}
+	directories, err := s.store.GetDirectories(ctx, folder)
+	if err != nil {
+		return nil, err
+	}
+	for _, directory := range directories {
+		resources = append(resources, Resource{
+			Path:       folderPath + directory.Name + "/",
+			Name:       directory.Name,
+			Collection: true,
+			UpdatedAt:  directory.UpdatedAt,
+		})
+	}
+
+	return resources, nil
+}
+
+// getDirectoryResources - returns the relation value sub-folder resources, the sub-folder without any book files
+// does not exist
+func (s *Service) getDirectoryResources(ctx context.Context, folderName, name string, depth int) ([]Resource, error) {
+	folder, ok := FolderByName(folderName)
+	if !ok {
+		return nil, ErrNotFound
+	}
+	entities, err := s.store.GetBookFiles(ctx, folder, name)
+	if err != nil {
+		return nil, err
+	}
+	if len(entities) == 0 {
+		return nil, ErrNotFound
+	}
+
+	directory := Resource{Path: "/" + folder.Name + "/" + name + "/", Name: name, Collection: true}
+	for _, entity := range entities {
+		if entity.UpdatedAt.After(directory.UpdatedAt) {
+			directory.UpdatedAt = entity.UpdatedAt
+		}
+	}
+	resources := []Resource{directory}
+	if depth == 0 {
+		return resources, nil
+	}
+	for i, fileName := range fileNames(entities) {
+		resources = append(resources, fileResource(directory.Path+fileName, fileName, entities[i]))
+	}
+
+	return resources, nil
+}
+
+// findBookFile - returns the book file by its path segments, along with its path
+func (s *Service) findBookFile(ctx context.Context, segments []string) (bookFileEntity, string, error) {
+	folder, ok := FolderByName(segments[0])
+	if !ok {
+		return bookFileEntity{}, "", ErrNotFound
+	}
+	entities, err := s.store.GetBookFiles(ctx, folder, segments[1])
+	if err != nil {
+		return bookFileEntity{}, "", err
+	}
+	for i, fileName := range fileNames(entities) {
+		if fileName == segments[2] {
+			return entities[i], "/" + folder.Name + "/" + segments[1] + "/" + fileName, nil
+		}
+	}
+
+	return bookFileEntity{}, "", ErrNotFound
+}
+
+// fileResource - returns the file resource, the content SHA-256 is the ETag. The book file is only recorded
+// once its object is stored, so the object store is not checked
+func fileResource(filePath, fileName string, entity bookFileEntity) Resource {
+	return Resource{
+		Path:        filePath,
+		Name:        fileName,
+		Size:        entity.Size,
+		ContentType: entity.ContentType,
+		ETag:        entity.SHA256,
+		UpdatedAt:   entity.UpdatedAt,
+	}
+}
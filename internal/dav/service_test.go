@@ -0,0 +1,185 @@
+package dav
+
+import (
+	"context"
+	"errors"
+	"github.com/sdreger/lib-manager-go/internal/blobtstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+var (
+	testUpdated  = time.Date(2026, 10, 5, 8, 30, 0, 0, time.UTC)
+	testEntities = []bookFileEntity{
+		{BookID: 1, Title: "Learning Go", FileType: "epub", ObjectKey: "books/1/book.epub", Size: 4096, SHA256: "e1",
+			ContentType: "application/epub+zip", UpdatedAt: testUpdated.Add(-time.Hour)},
+		{BookID: 1, Title: "Learning Go", FileType: "pdf", ObjectKey: "books/1/book.pdf", Size: 5192, SHA256: "e3",
+			ContentType: "application/pdf", UpdatedAt: testUpdated},
+		{BookID: 2, Title: "Learning Go", FileType: "epub", ObjectKey: "books/2/book.epub", Size: 4100, SHA256: "e2",
+			ContentType: "application/epub+zip", UpdatedAt: testUpdated.Add(-2 * time.Hour)},
+	}
+)
+
+type testContent struct {
+	*strings.Reader
+}
+
+func (c testContent) Close() error {
+	return nil
+}
+
+func TestService_GetResources_Root(t *testing.T) {
+	ctx := context.Background()
+	service := getService()
+	injectMocks(service, NewMockStore(t), NewMockBlobStore(t))
+
+	resources, err := service.GetResources(ctx, "/", 0)
+	require.NoError(t, err)
+	assert.Equal(t, []Resource{{Path: "/", Collection: true}}, resources)
+
+	resources, err = service.GetResources(ctx, "", 1)
+	require.NoError(t, err)
+	require.Len(t, resources, 4)
+	assert.Equal(t, Resource{Path: "/by-publisher/", Name: "by-publisher", Collection: true}, resources[1])
+	assert.Equal(t, "/by-tag/", resources[3].Path)
+}
+
+func TestService_GetResources_Folder(t *testing.T) {
+	ctx := context.Background()
+	service := getService()
+	folder, _ := FolderByName("by-author")
+
+	store := NewMockStore(t)
+	store.EXPECT().GetDirectories(ctx, folder).Return([]Directory{{Name: "John Doe", UpdatedAt: testUpdated}}, nil).Once()
+	injectMocks(service, store, NewMockBlobStore(t))
+
+	resources, err := service.GetResources(ctx, "/by-author/", 1)
+	require.NoError(t, err)
+	assert.Equal(t, []Resource{
+		{Path: "/by-author/", Name: "by-author", Collection: true},
+		{Path: "/by-author/John Doe/", Name: "John Doe", Collection: true, UpdatedAt: testUpdated},
+	}, resources)
+
+	_, err = service.GetResources(ctx, "/by-language/", 1)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestService_GetResources_Directory(t *testing.T) {
+	ctx := context.Background()
+	service := getService()
+	folder, _ := FolderByName("by-publisher")
+
+	store := NewMockStore(t)
+	store.EXPECT().GetBookFiles(ctx, folder, "OReilly").Return(testEntities, nil).Once()
+	store.EXPECT().GetBookFiles(ctx, folder, "Manning").Return([]bookFileEntity{}, nil).Once()
+	injectMocks(service, store, NewMockBlobStore(t))
+
+	resources, err := service.GetResources(ctx, "/by-publisher/OReilly", 1)
+	require.NoError(t, err)
+	assert.Equal(t, []Resource{
+		{Path: "/by-publisher/OReilly/", Name: "OReilly", Collection: true, UpdatedAt: testUpdated},
+		{Path: "/by-publisher/OReilly/Learning Go.epub", Name: "Learning Go.epub", Size: 4096,
+			ContentType: "application/epub+zip", ETag: "e1", UpdatedAt: testUpdated.Add(-time.Hour)},
+		{Path: "/by-publisher/OReilly/Learning Go.pdf", Name: "Learning Go.pdf", Size: 5192,
+			ContentType: "application/pdf", ETag: "e3", UpdatedAt: testUpdated},
+		{Path: "/by-publisher/OReilly/Learning Go (2).epub", Name: "Learning Go (2).epub", Size: 4100,
+			ContentType: "application/epub+zip", ETag: "e2", UpdatedAt: testUpdated.Add(-2 * time.Hour)},
+	}, resources, "the files should be described without querying the object store")
+
+	_, err = service.GetResources(ctx, "/by-publisher/Manning", 0)
+	assert.ErrorIs(t, err, ErrNotFound, "the sub-folder without any book files should not exist")
+}
+
+func TestService_GetResources_File(t *testing.T) {
+	ctx := context.Background()
+	service := getService()
+	folder, _ := FolderByName("by-tag")
+
+	store := NewMockStore(t)
+	store.EXPECT().GetBookFiles(ctx, folder, "programming").Return(testEntities, nil).Twice()
+	injectMocks(service, store, NewMockBlobStore(t))
+
+	resources, err := service.GetResources(ctx, "/by-tag/programming/Learning Go.pdf", 1)
+	require.NoError(t, err)
+	assert.Equal(t, []Resource{{Path: "/by-tag/programming/Learning Go.pdf", Name: "Learning Go.pdf", Size: 5192,
+		ContentType: "application/pdf", ETag: "e3", UpdatedAt: testUpdated}}, resources)
+
+	_, err = service.GetResources(ctx, "/by-tag/programming/Learning Go.mobi", 0)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	_, err = service.GetResources(ctx, "/by-tag/programming/Learning Go.pdf/extra", 0)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestService_GetFile(t *testing.T) {
+	ctx := context.Background()
+	service := getService()
+	folder, _ := FolderByName("by-tag")
+	content := testContent{strings.NewReader("content")}
+
+	store := NewMockStore(t)
+	store.EXPECT().GetBookFiles(ctx, folder, "programming").Return(testEntities, nil).Twice()
+	blobStore := NewMockBlobStore(t)
+	blobStore.EXPECT().GetBookFile(ctx, "books/2/book.epub").Return(content,
+		blobtstore.Object{Size: 7, ETag: "e2"}, nil).Once()
+	blobStore.EXPECT().GetBookFile(ctx, "books/1/book.pdf").Return(nil, blobtstore.Object{},
+		blobtstore.ErrObjectNotFound).Once()
+	injectMocks(service, store, blobStore)
+
+	file, err := service.GetFile(ctx, "/by-tag/programming/Learning Go (2).epub")
+	require.NoError(t, err)
+	assert.Equal(t, Resource{Path: "/by-tag/programming/Learning Go (2).epub", Name: "Learning Go (2).epub",
+		Size: 4100, ContentType: "application/epub+zip", ETag: "e2", UpdatedAt: testUpdated.Add(-2 * time.Hour)},
+		file.Resource)
+	body, err := io.ReadAll(file.Content)
+	require.NoError(t, err)
+	assert.Equal(t, "content", string(body))
+
+	_, err = service.GetFile(ctx, "/by-tag/programming/Learning Go.pdf")
+	assert.ErrorIs(t, err, ErrNotFound, "the missing object should not be found")
+
+	_, err = service.GetFile(ctx, "/by-tag")
+	assert.ErrorIs(t, err, ErrCollection)
+
+	_, err = service.GetFile(ctx, "/by-year")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestService_StoreError(t *testing.T) {
+	ctx := context.Background()
+	service := getService()
+	storeError := errors.New("store error")
+
+	store := NewMockStore(t)
+	store.EXPECT().GetDirectories(ctx, mock.Anything).Return(nil, storeError).Once()
+	injectMocks(service, store, NewMockBlobStore(t))
+
+	_, err := service.GetResources(ctx, "/by-tag", 1)
+	assert.ErrorIs(t, err, storeError)
+}
+
+func TestFileNames(t *testing.T) {
+	names := fileNames([]bookFileEntity{
+		{BookID: 1, Title: "Go / Rust", FileType: "pdf"},
+		{BookID: 3, Title: "Go / Rust ", FileType: "pdf"},
+		{BookID: 4, Title: "..", FileType: "epub"},
+	})
+	assert.Equal(t, []string{"Go _ Rust.pdf", "Go _ Rust (3).pdf", "_.epub"}, names)
+}
+
+func getService() *Service {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	return NewService(logger, nil, nil)
+}
+
+func injectMocks(service *Service, store *MockStore, blobStore *MockBlobStore) {
+	service.store = store
+	service.blobStore = blobStore
+}
@@ -0,0 +1,59 @@
+package dav
+
+import (
+	"context"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+)
+
+// relationName - the SQL expression of the relation value sub-folder name, it matches the 'safeName' function
+const relationName = `CASE
+           WHEN btrim(relation.name) IN ('', '.', '..') THEN '_'
+           ELSE translate(btrim(relation.name), '/\', '__') END`
+
+type DBStore struct {
+	db *sqlx.DB
+}
+
+func NewDBStore(db *sqlx.DB) *DBStore {
+	return &DBStore{db: db}
+}
+
+// GetDirectories - returns the folder sub-folders, one per relation value name having at least one book file
+// of a non-deleted book, sorted by name
+func (s *DBStore) GetDirectories(ctx context.Context, folder Folder) ([]Directory, error) {
+	query := fmt.Sprintf(`SELECT %s AS name, MAX(book_files.updated_at) AS updated_at
+FROM %s relation
+         %s
+         JOIN ebook.book_files ON book_files.book_id = books.id
+WHERE books.deleted_at IS NULL
+GROUP BY 1
+ORDER BY 1`, relationName, folder.table, folder.join)
+	directories := make([]Directory, 0)
+	if err := s.db.SelectContext(ctx, &directories, query); err != nil {
+		return nil, err
+	}
+
+	return directories, nil
+}
+
+// GetBookFiles - returns the book files of the non-deleted books, having the relation value by its sub-folder name,
+// sorted by the book ID and the file type
+func (s *DBStore) GetBookFiles(ctx context.Context, folder Folder, name string) ([]bookFileEntity, error) {
+	query := fmt.Sprintf(`SELECT DISTINCT books.id AS book_id, books.title, LOWER(file_types.name) AS file_type,
+                book_files.object_key, book_files.size, book_files.sha256, book_files.content_type,
+                book_files.updated_at
+FROM %s relation
+         %s
+         JOIN ebook.book_files ON book_files.book_id = books.id
+         JOIN ebook.file_types ON file_types.id = book_files.file_type_id
+WHERE books.deleted_at IS NULL
+  AND %s = $1
+ORDER BY book_id, file_type`, folder.table, folder.join, relationName)
+	entities := make([]bookFileEntity, 0)
+	if err := s.db.SelectContext(ctx, &entities, query, name); err != nil {
+		return nil, err
+	}
+
+	return entities, nil
+}
@@ -0,0 +1,99 @@
+package dav
+
+import (
+	"context"
+	"github.com/jmoiron/sqlx"
+	"github.com/sdreger/lib-manager-go/internal/tests"
+	"github.com/stretchr/testify/suite"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+type TestStoreSuite struct {
+	suite.Suite
+	db            *sqlx.DB
+	testContainer *postgres.PostgresContainer
+	store         *DBStore
+}
+
+func (s *TestStoreSuite) SetupSuite() {
+	testContainer := tests.StartDBTestContainer(s.T())
+	dbConfig := tests.GetTestDBConfig(s.T(), testContainer)
+	connection := tests.SetUpTestDB(s.Suite.Require(), dbConfig, testContainer)
+
+	s.store = NewDBStore(connection)
+	s.db = connection
+	s.testContainer = testContainer
+}
+
+func (s *TestStoreSuite) SetupTest() {
+	ctx := context.Background()
+	err := s.testContainer.Restore(ctx)
+	s.Require().NoError(err)
+
+	err = prepareTestData(s.testContainer, "testdata/dav_books.sql")
+	s.Require().NoError(err, "failed to load test SQL file")
+}
+
+func (s *TestStoreSuite) TearDownSuite() {
+	err := s.db.Close()
+	s.Require().NoError(err, "failed to close database connection")
+}
+
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(TestStoreSuite))
+}
+
+// -------------------- Tests --------------------
+
+func (s *TestStoreSuite) Test_GetDirectories() {
+	folder, _ := FolderByName("by-publisher")
+	directories, err := s.store.GetDirectories(context.Background(), folder)
+	s.Require().NoError(err)
+	s.Require().Len(directories, 2, "the publishers of the deleted books only should be skipped")
+	s.Equal("Manning_Packt", directories[0].Name, "the path separators should be replaced")
+	s.Equal("OReilly", directories[1].Name)
+	s.Equal(time.Date(2026, 10, 3, 10, 0, 0, 0, time.UTC), directories[1].UpdatedAt.UTC(),
+		"the latest book file update time should be used")
+
+	folder, _ = FolderByName("by-author")
+	directories, err = s.store.GetDirectories(context.Background(), folder)
+	s.Require().NoError(err)
+	s.Require().Len(directories, 2)
+	s.Equal("Amanda Lee", directories[0].Name)
+	s.Equal("John Doe", directories[1].Name)
+}
+
+func (s *TestStoreSuite) Test_GetBookFiles() {
+	folder, _ := FolderByName("by-tag")
+	entities, err := s.store.GetBookFiles(context.Background(), folder, "programming")
+	s.Require().NoError(err)
+	s.Require().Len(entities, 3)
+	s.Equal(bookFileEntity{BookID: 1, Title: "Learning Go", FileType: "epub", ObjectKey: "books/1/book.epub",
+		Size: 4096, SHA256: strings.Repeat("b", 64), ContentType: "application/epub+zip",
+		UpdatedAt: entities[0].UpdatedAt}, entities[0])
+	s.Equal("pdf", entities[1].FileType)
+	s.Equal(int64(2), entities[2].BookID)
+
+	folder, _ = FolderByName("by-publisher")
+	entities, err = s.store.GetBookFiles(context.Background(), folder, "Manning_Packt")
+	s.Require().NoError(err)
+	s.Require().Len(entities, 1, "the publisher should be found by its sub-folder name")
+	s.Equal("books/3/book.pdf", entities[0].ObjectKey)
+
+	entities, err = s.store.GetBookFiles(context.Background(), folder, "Apress")
+	s.Require().NoError(err)
+	s.Empty(entities, "the deleted book files should be skipped")
+}
+
+func prepareTestData(testContainer *postgres.PostgresContainer, fileName string) error {
+	file, err := os.ReadFile(fileName)
+	if err != nil {
+		return err
+	}
+
+	return tests.ExecSQL(testContainer, string(file))
+}
@@ -0,0 +1,30 @@
+INSERT INTO ebook.publishers (id, name) VALUES (1, 'OReilly'), (2, 'Manning/Packt'), (3, 'Apress');
+INSERT INTO ebook.languages (id, name) VALUES (1, 'English');
+INSERT INTO ebook.authors (id, name) VALUES (1, 'John Doe'), (2, 'Amanda Lee');
+INSERT INTO ebook.file_types (id, name) VALUES (1, 'PDF'), (2, 'EPUB');
+INSERT INTO ebook.tags (id, name) VALUES (1, 'programming'), (2, 'database');
+
+INSERT INTO ebook.books (id, title, description, isbn13, pages, edition, language_id, publisher_id, publisher_url,
+                         pub_date, book_file_name, book_file_size, cover_file_name)
+VALUES (1, 'Learning Go', 'Book 01 Description', 9781111111111, 256, 1, 1, 1, 'https://amazon.com/dp/1111111111.html',
+        '2022-07-19', 'Book.01.pdf', 5192, '1111111111.jpg'),
+       (2, 'Learning Go', 'Book 02 Description', 9782222222222, 256, 2, 1, 1, 'https://amazon.com/dp/2222222222.html',
+        '2024-02-11', 'Book.02.pdf', 5192, '2222222222.jpg'),
+       (3, 'CockroachDB', 'Book 03 Description', 9783333333333, 356, 1, 1, 2, 'https://amazon.com/dp/3333333333.html',
+        '2022-05-21', 'Book.03.pdf', 5193, '3333333333.jpg');
+INSERT INTO ebook.book_author (book_id, author_id) VALUES (1, 1), (2, 1), (3, 1), (3, 2);
+INSERT INTO ebook.book_tag (book_id, tag_id) VALUES (1, 1), (2, 1), (3, 2);
+INSERT INTO ebook.book_files (book_id, file_type_id, object_key, size, sha256, content_type, updated_at)
+VALUES (1, 1, 'books/1/book.pdf', 5192, REPEAT('a', 64), 'application/pdf', '2026-10-01 10:00:00'),
+       (1, 2, 'books/1/book.epub', 4096, REPEAT('b', 64), 'application/epub+zip', '2026-10-02 10:00:00'),
+       (2, 2, 'books/2/book.epub', 4100, REPEAT('c', 64), 'application/epub+zip', '2026-10-03 10:00:00'),
+       (3, 1, 'books/3/book.pdf', 6000, REPEAT('d', 64), 'application/pdf', '2026-10-04 10:00:00');
+
+-- the deleted book and its publisher are not shared
+INSERT INTO ebook.books (id, title, description, isbn13, pages, edition, language_id, publisher_id, publisher_url,
+                         pub_date, book_file_name, book_file_size, cover_file_name, deleted_at)
+VALUES (4, 'Book 04', 'Book 04 Description', 9784444444444, 128, 1, 1, 3, 'https://amazon.com/dp/4444444444.html',
+        '2020-01-01', 'Book.04.pdf', 1024, '4444444444.jpg', '2026-10-10 00:00:00');
+INSERT INTO ebook.book_author (book_id, author_id) VALUES (4, 2);
+INSERT INTO ebook.book_files (book_id, file_type_id, object_key, size, sha256, content_type)
+VALUES (4, 1, 'books/4/book.pdf', 1024, REPEAT('e', 64), 'application/pdf');
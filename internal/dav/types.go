@@ -0,0 +1,128 @@
+package dav
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+)
+
+// Folder - the top-level share folder, it has a sub-folder per relation value, e.g. per publisher,
+// holding the book files of the books having it
+type Folder struct {
+	Name  string
+	table string
+	// join - joins the non-deleted 'ebook.books' rows to the relation table, aliased as 'relation'
+	join string
+}
+
+// Folders - the top-level share folders
+var Folders = []Folder{
+	{Name: "by-publisher", table: "ebook.publishers", join: "JOIN ebook.books ON books.publisher_id = relation.id"},
+	{Name: "by-author", table: "ebook.authors",
+		join: "JOIN ebook.book_author ON book_author.author_id = relation.id " +
+			"JOIN ebook.books ON books.id = book_author.book_id"},
+	{Name: "by-tag", table: "ebook.tags",
+		join: "JOIN ebook.book_tag ON book_tag.tag_id = relation.id JOIN ebook.books ON books.id = book_tag.book_id"},
+}
+
+// FolderByName - returns the top-level folder by its name
+func FolderByName(name string) (Folder, bool) {
+	for _, folder := range Folders {
+		if folder.Name == name {
+			return folder, true
+		}
+	}
+
+	return Folder{}, false
+}
+
+// Resource - the share collection or file. The path is relative to the share root, the collection paths
+// end with a slash, e.g. '/by-publisher/OReilly/'. The size, the content type and the ETag are only set
+// for the files, the ETag is the content SHA-256
+type Resource struct {
+	Path        string
+	Name        string
+	Collection  bool
+	Size        int64
+	ContentType string
+	ETag        string
+	UpdatedAt   time.Time
+}
+
+// Href - returns the escaped resource URL path, under the share path prefix
+func (r Resource) Href(prefix string) string {
+	segments := strings.Split(r.Path, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+
+	return prefix + strings.Join(segments, "/")
+}
+
+// FileContent - the share file along with its seekable content, which must be closed by the caller
+type FileContent struct {
+	Resource
+	Content io.ReadSeekCloser
+}
+
+// Directory - the relation value sub-folder, the update time is the one of its latest book file
+type Directory struct {
+	Name      string    `db:"name"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// bookFileEntity - the book file of the relation value sub-folder
+type bookFileEntity struct {
+	BookID      int64     `db:"book_id"`
+	Title       string    `db:"title"`
+	FileType    string    `db:"file_type"`
+	ObjectKey   string    `db:"object_key"`
+	Size        int64     `db:"size"`
+	SHA256      string    `db:"sha256"`
+	ContentType string    `db:"content_type"`
+	UpdatedAt   time.Time `db:"updated_at"`
+}
+
+// fileNames - returns the unique file names of the book files, e.g. 'Learning Go.epub'. The files of the books
+// having the same title are told apart by the book ID, except the first one, e.g. 'Learning Go (12).epub'
+func fileNames(entities []bookFileEntity) []string {
+	names := make([]string, len(entities))
+	taken := make(map[string]bool, len(entities))
+	for i, entity := range entities {
+		title := safeName(entity.Title)
+		name := title + "." + entity.FileType
+		if taken[name] {
+			name = fmt.Sprintf("%s (%d).%s", title, entity.BookID, entity.FileType)
+		}
+		taken[name] = true
+		names[i] = name
+	}
+
+	return names
+}
+
+// safeName - replaces the path separators, so the name is a single path segment. The relation value names
+// are replaced the same way by the store queries, see the 'relationName' expression
+func safeName(name string) string {
+	name = strings.NewReplacer("/", "_", "\\", "_").Replace(strings.Trim(name, " "))
+	if name == "" || name == "." || name == ".." {
+		return "_"
+	}
+
+	return name
+}
+
+// splitPath - returns the path segments, the empty ones are skipped
+func splitPath(resourcePath string) []string {
+	var segments []string
+	for _, segment := range strings.Split(path.Clean("/"+resourcePath), "/") {
+		if segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+
+	return segments
+}
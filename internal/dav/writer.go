@@ -0,0 +1,98 @@
+package dav
+
+import (
+	"encoding/xml"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+const (
+	NamespaceDAV = "DAV:"
+	// ConditionFiniteDepth - the precondition of the PROPFIND requests, the infinite depth is not supported
+	ConditionFiniteDepth = "propfind-finite-depth"
+)
+
+// davMultistatus - the WebDAV multi-status response, see https://www.rfc-editor.org/rfc/rfc4918#section-14.16
+type davMultistatus struct {
+	XMLName   xml.Name      `xml:"D:multistatus"`
+	XMLNS     string        `xml:"xmlns:D,attr"`
+	Responses []davResponse `xml:"D:response"`
+}
+
+type davResponse struct {
+	Href     string      `xml:"D:href"`
+	PropStat davPropStat `xml:"D:propstat"`
+}
+
+type davPropStat struct {
+	Prop   davProp `xml:"D:prop"`
+	Status string  `xml:"D:status"`
+}
+
+type davProp struct {
+	DisplayName      string          `xml:"D:displayname"`
+	ResourceType     davResourceType `xml:"D:resourcetype"`
+	GetContentLength string          `xml:"D:getcontentlength,omitempty"`
+	GetContentType   string          `xml:"D:getcontenttype,omitempty"`
+	GetETag          string          `xml:"D:getetag,omitempty"`
+	GetLastModified  string          `xml:"D:getlastmodified,omitempty"`
+}
+
+type davResourceType struct {
+	Collection *struct{} `xml:"D:collection"`
+}
+
+// davError - the WebDAV error response, having the failed condition, see https://www.rfc-editor.org/rfc/rfc4918#section-16
+type davError struct {
+	XMLName   xml.Name `xml:"D:error"`
+	XMLNS     string   `xml:"xmlns:D,attr"`
+	Condition struct {
+		XMLName xml.Name
+	}
+}
+
+// WriteMultistatus - writes the multi-status response, having all the live properties of the resources.
+// The resource hrefs are prefixed with the share path
+func WriteMultistatus(w io.Writer, hrefPrefix string, resources []Resource) error {
+	result := davMultistatus{XMLNS: NamespaceDAV}
+	for _, resource := range resources {
+		prop := davProp{DisplayName: resource.Name}
+		if resource.Collection {
+			prop.ResourceType.Collection = &struct{}{}
+		} else {
+			prop.GetContentLength = strconv.FormatInt(resource.Size, 10)
+			prop.GetContentType = resource.ContentType
+			if resource.ETag != "" {
+				prop.GetETag = `"` + resource.ETag + `"`
+			}
+		}
+		if !resource.UpdatedAt.IsZero() {
+			prop.GetLastModified = resource.UpdatedAt.UTC().Format(http.TimeFormat)
+		}
+		result.Responses = append(result.Responses, davResponse{
+			Href:     resource.Href(hrefPrefix),
+			PropStat: davPropStat{Prop: prop, Status: "HTTP/1.1 200 OK"},
+		})
+	}
+
+	return encode(w, result)
+}
+
+// WriteError - writes the error response, having the failed precondition or postcondition
+func WriteError(w io.Writer, condition string) error {
+	result := davError{XMLNS: NamespaceDAV}
+	result.Condition.XMLName = xml.Name{Local: "D:" + condition}
+
+	return encode(w, result)
+}
+
+func encode(w io.Writer, value any) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+
+	return encoder.Encode(value)
+}
@@ -0,0 +1,36 @@
+package dav
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestWriteMultistatus(t *testing.T) {
+	var buffer bytes.Buffer
+	err := WriteMultistatus(&buffer, "/dav", []Resource{
+		{Path: "/by-publisher/O Reilly/", Name: "O Reilly", Collection: true, UpdatedAt: testUpdated},
+		{Path: "/by-publisher/O Reilly/Go & Rust.epub", Name: "Go & Rust.epub", Size: 4096,
+			ContentType: "application/epub+zip", ETag: "e1", UpdatedAt: testUpdated},
+	})
+	require.NoError(t, err)
+
+	body := buffer.String()
+	assert.Contains(t, body, `<D:multistatus xmlns:D="DAV:">`)
+	assert.Contains(t, body, "<D:href>/dav/by-publisher/O%20Reilly/</D:href>")
+	assert.Contains(t, body, "<D:resourcetype>\n          <D:collection></D:collection>")
+	assert.Contains(t, body, "<D:href>/dav/by-publisher/O%20Reilly/Go%20&amp;%20Rust.epub</D:href>")
+	assert.Contains(t, body, "<D:displayname>Go &amp; Rust.epub</D:displayname>")
+	assert.Contains(t, body, "<D:getcontentlength>4096</D:getcontentlength>")
+	assert.Contains(t, body, "<D:getetag>&#34;e1&#34;</D:getetag>")
+	assert.Contains(t, body, "<D:getlastmodified>Mon, 05 Oct 2026 08:30:00 GMT</D:getlastmodified>")
+	assert.Contains(t, body, "<D:status>HTTP/1.1 200 OK</D:status>")
+}
+
+func TestWriteError(t *testing.T) {
+	var buffer bytes.Buffer
+	err := WriteError(&buffer, ConditionFiniteDepth)
+	require.NoError(t, err)
+	assert.Contains(t, buffer.String(), "<D:error xmlns:D=\"DAV:\">\n  <D:propfind-finite-depth></D:propfind-finite-depth>")
+}
@@ -3,5 +3,11 @@ package book
 import "errors"
 
 var (
-	ErrNotFound = errors.New("entry not found")
+	ErrNotFound     = errors.New("entry not found")
+	ErrInvalidISBN  = errors.New("invalid ISBN")
+	ErrInvalidMerge = errors.New("the books to merge should differ from the target book")
+
+	ErrNoIdentifiers      = errors.New("at least one of ISBN-13, ISBN-10 or ASIN is required")
+	ErrIdentifierConflict = errors.New("the identifiers match different books")
+	ErrIncompleteBook     = errors.New("the publisher and the language are required for a new book")
 )
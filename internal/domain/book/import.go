@@ -0,0 +1,85 @@
+package book
+
+import (
+	"slices"
+	"strings"
+)
+
+// applyImport - overrides the existing book values with the non-empty imported ones. The authors, the categories
+// and the tags are replaced if provided. Reports whether anything has changed
+func applyImport(existing Book, imported Book) (Book, bool) {
+	result := existing
+	overrideString(&result.Title, imported.Title)
+	overrideString(&result.Subtitle, imported.Subtitle)
+	overrideString(&result.Description, imported.Description)
+	overrideString(&result.ISBN10, imported.ISBN10)
+	overrideString(&result.ASIN, imported.ASIN)
+	overrideString(&result.PublisherURL, imported.PublisherURL)
+	if imported.ISBN13 != 0 {
+		result.ISBN13 = imported.ISBN13
+	}
+	if imported.Pages != 0 {
+		result.Pages = imported.Pages
+	}
+	if imported.Edition != 0 {
+		result.Edition = imported.Edition
+	}
+	if !imported.PubDate.IsZero() {
+		result.PubDate = imported.PubDate
+	}
+
+	changed := result.Title != existing.Title || result.Subtitle != existing.Subtitle ||
+		result.Description != existing.Description || result.ISBN10 != existing.ISBN10 ||
+		result.ISBN13 != existing.ISBN13 || result.ASIN != existing.ASIN ||
+		result.PublisherURL != existing.PublisherURL || result.Pages != existing.Pages ||
+		result.Edition != existing.Edition || !sameDate(result, existing)
+	if imported.Publisher != "" && !strings.EqualFold(imported.Publisher, existing.Publisher) {
+		result.Publisher, changed = imported.Publisher, true
+	}
+	if imported.Language != "" && !strings.EqualFold(imported.Language, existing.Language) {
+		result.Language, changed = imported.Language, true
+	}
+	for _, names := range []struct {
+		target   *[]string
+		imported []string
+	}{
+		{&result.Authors, imported.Authors},
+		{&result.Categories, imported.Categories},
+		{&result.Tags, imported.Tags},
+	} {
+		if len(names.imported) > 0 && !sameNames(*names.target, names.imported) {
+			*names.target, changed = names.imported, true
+		}
+	}
+
+	return result, changed
+}
+
+func overrideString(target *string, value string) {
+	if value != "" {
+		*target = value
+	}
+}
+
+// sameDate - compares the publication dates, the time part is not stored
+func sameDate(first Book, second Book) bool {
+	y1, m1, d1 := first.PubDate.Date()
+	y2, m2, d2 := second.PubDate.Date()
+
+	return y1 == y2 && m1 == m2 && d1 == d2
+}
+
+// sameNames - compares the name sets case-insensitively, the same way the related entries are matched
+func sameNames(first []string, second []string) bool {
+	normalize := func(names []string) []string {
+		result := make([]string, len(names))
+		for i, name := range names {
+			result[i] = strings.ToLower(name)
+		}
+		slices.Sort(result)
+
+		return slices.Compact(result)
+	}
+
+	return slices.Equal(normalize(first), normalize(second))
+}
@@ -0,0 +1,44 @@
+package book
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestApplyImport(t *testing.T) {
+	existing := getTestBook()
+	imported := Book{
+		Title:      bookTitle,
+		Pages:      320,
+		PubDate:    time.Date(2023, time.May, 1, 0, 0, 0, 0, time.UTC),
+		Publisher:  "oreilly",
+		Authors:    []string{"Amanda Lee", "Jane Roe"},
+		Categories: []string{"computers", "Programming", "Computer Science"},
+	}
+
+	updated, changed := applyImport(existing, imported)
+	assert.True(t, changed)
+	assert.Equal(t, uint16(320), updated.Pages)
+	assert.Equal(t, imported.PubDate, updated.PubDate)
+	assert.Equal(t, bookSubtitle, updated.Subtitle, "the empty values should be kept")
+	assert.Equal(t, int64(bookISBN13), updated.ISBN13, "the empty values should be kept")
+	assert.Equal(t, bookPublisher, updated.Publisher, "the publisher should be matched case-insensitively")
+	assert.Equal(t, []string{"Amanda Lee", "Jane Roe"}, updated.Authors)
+	assert.Equal(t, existing.Categories, updated.Categories, "the same categories should be kept")
+	assert.Equal(t, existing.Tags, updated.Tags, "the missing tags should be kept")
+}
+
+func TestApplyImport_Unchanged(t *testing.T) {
+	existing := getTestBook()
+	imported := Book{
+		Title:    bookTitle,
+		ISBN13:   bookISBN13,
+		Language: "english",
+		Authors:  []string{bookAuthor02, bookAuthor01},
+		PubDate:  existing.PubDate.Add(12 * time.Hour),
+	}
+
+	_, changed := applyImport(existing, imported)
+	assert.False(t, changed, "the same values should not change the book")
+}
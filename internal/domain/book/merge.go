@@ -0,0 +1,69 @@
+package book
+
+import (
+	"cmp"
+	"database/sql"
+)
+
+// mergeMetadata - keeps the richer metadata: the empty target values are filled in from the sources
+// in the provided order, and the longest description wins. The target cover, either hash-based or legacy, is kept.
+// Otherwise, the first source hash-based cover is taken along with its metadata, or the first source legacy cover
+// of the same publisher, since the legacy cover object path depends on the publisher
+func mergeMetadata(target mergeEntity, sources []mergeEntity) mergeEntity {
+	for _, source := range sources {
+		target.Subtitle = cmpOrNull(target.Subtitle, source.Subtitle)
+		if len(source.Description) > len(target.Description) {
+			target.Description = source.Description
+		}
+		target.ISBN10 = cmpOrNull(target.ISBN10, source.ISBN10)
+		if !target.ISBN13.Valid {
+			target.ISBN13 = source.ISBN13
+		}
+		target.ASIN = cmpOrNull(target.ASIN, source.ASIN)
+		target.Pages = cmp.Or(target.Pages, source.Pages)
+		target.PublisherURL = cmp.Or(target.PublisherURL, source.PublisherURL)
+		target.Edition = cmp.Or(target.Edition, source.Edition)
+		if target.BookFileName == "" {
+			target.BookFileName, target.BookFileSize = source.BookFileName, source.BookFileSize
+		}
+	}
+	if hasCover(target) {
+		return target
+	}
+	for _, source := range sources {
+		if source.CoverHash.Valid {
+			return withCover(target, source)
+		}
+	}
+	for _, source := range sources {
+		if source.CoverFileName != "" && source.PublisherID == target.PublisherID {
+			return withCover(target, source)
+		}
+	}
+
+	return target
+}
+
+func hasCover(entity mergeEntity) bool {
+	return entity.CoverHash.Valid || entity.CoverFileName != ""
+}
+
+func withCover(target mergeEntity, source mergeEntity) mergeEntity {
+	target.CoverFileName = source.CoverFileName
+	target.CoverHash, target.CoverWidth, target.CoverHeight = source.CoverHash, source.CoverWidth, source.CoverHeight
+	target.CoverAspectRatio = source.CoverAspectRatio
+	target.CoverDominantColor, target.CoverBlurHash = source.CoverDominantColor, source.CoverBlurHash
+
+	return target
+}
+
+func cmpOrNull(target sql.NullString, source sql.NullString) sql.NullString {
+	if target.Valid && target.String != "" {
+		return target
+	}
+	if source.Valid && source.String != "" {
+		return source
+	}
+
+	return target
+}
@@ -0,0 +1,83 @@
+package book
+
+import (
+	"database/sql"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestMergeMetadata(t *testing.T) {
+	target := mergeEntity{
+		ID:           1,
+		Subtitle:     sql.NullString{String: "", Valid: true},
+		Description:  "Short",
+		ISBN13:       sql.NullInt64{Int64: 9781617291784, Valid: true},
+		Edition:      2,
+		BookFileName: "book.pdf",
+		BookFileSize: 100,
+	}
+	sources := []mergeEntity{
+		{ID: 2, Description: "Longer description", ASIN: sql.NullString{String: "B00TEST123", Valid: true},
+			ISBN13: sql.NullInt64{Int64: 9791032305690, Valid: true}, Edition: 1, Pages: 200,
+			BookFileName: "book.epub", BookFileSize: 200},
+		{ID: 3, Subtitle: sql.NullString{String: "Subtitle", Valid: true}, Description: "Mid",
+			ISBN10: sql.NullString{String: "1617291781", Valid: true}, Pages: 300,
+			CoverFileName: "cover.jpg", CoverHash: sql.NullString{String: "abc", Valid: true},
+			CoverWidth: sql.NullInt32{Int32: 300, Valid: true}},
+	}
+
+	merged := mergeMetadata(target, sources)
+	assert.Equal(t, int64(1), merged.ID)
+	assert.Equal(t, "Subtitle", merged.Subtitle.String)
+	assert.Equal(t, "Longer description", merged.Description)
+	assert.Equal(t, int64(9781617291784), merged.ISBN13.Int64, "the target ISBN-13 should be kept")
+	assert.Equal(t, "1617291781", merged.ISBN10.String)
+	assert.Equal(t, "B00TEST123", merged.ASIN.String)
+	assert.Equal(t, uint16(200), merged.Pages, "the first source value should be taken")
+	assert.Equal(t, uint8(2), merged.Edition)
+	assert.Equal(t, "book.pdf", merged.BookFileName)
+	assert.Equal(t, int64(100), merged.BookFileSize)
+	assert.Equal(t, "abc", merged.CoverHash.String)
+	assert.Equal(t, "cover.jpg", merged.CoverFileName)
+	assert.Equal(t, int32(300), merged.CoverWidth.Int32)
+}
+
+func TestMergeMetadata_LegacyTargetCover(t *testing.T) {
+	target := mergeEntity{ID: 1, PublisherID: 1, CoverFileName: "target.jpg"}
+	sources := []mergeEntity{
+		{ID: 2, PublisherID: 1, CoverFileName: "source.jpg", CoverHash: sql.NullString{String: "abc", Valid: true},
+			CoverWidth: sql.NullInt32{Int32: 300, Valid: true}},
+	}
+
+	merged := mergeMetadata(target, sources)
+	assert.Equal(t, "target.jpg", merged.CoverFileName, "the target legacy cover should be kept")
+	assert.False(t, merged.CoverHash.Valid)
+	assert.False(t, merged.CoverWidth.Valid)
+}
+
+func TestMergeMetadata_LegacySourceCover(t *testing.T) {
+	target := mergeEntity{ID: 1, PublisherID: 1}
+	sources := []mergeEntity{
+		{ID: 2, PublisherID: 2, CoverFileName: "other.jpg"},
+		{ID: 3, PublisherID: 1, CoverFileName: "source.jpg"},
+	}
+
+	merged := mergeMetadata(target, sources)
+	assert.Equal(t, "source.jpg", merged.CoverFileName, "the same publisher legacy cover should be taken")
+	assert.False(t, merged.CoverHash.Valid)
+
+	merged = mergeMetadata(target, sources[:1])
+	assert.Empty(t, merged.CoverFileName, "the other publisher legacy cover should be skipped")
+}
+
+func TestMergeMetadata_HashCoverPreferred(t *testing.T) {
+	target := mergeEntity{ID: 1, PublisherID: 1}
+	sources := []mergeEntity{
+		{ID: 2, PublisherID: 1, CoverFileName: "legacy.jpg"},
+		{ID: 3, PublisherID: 2, CoverHash: sql.NullString{String: "abc", Valid: true}},
+	}
+
+	merged := mergeMetadata(target, sources)
+	assert.Equal(t, "abc", merged.CoverHash.String, "the hash-based cover should be preferred")
+	assert.Empty(t, merged.CoverFileName)
+}
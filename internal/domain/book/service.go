@@ -5,6 +5,7 @@ import (
 	"github.com/jmoiron/sqlx"
 	"github.com/sdreger/lib-manager-go/internal/paging"
 	"log/slog"
+	"slices"
 )
 
 type Store interface {
@@ -15,6 +16,21 @@ type Store interface {
 		sort paging.Sort,
 		filter Filter,
 	) ([]LookupItem, int64, error)
+	LookupByRelation(
+		ctx context.Context,
+		relation string,
+		itemIDs []int64,
+		page paging.PageRequest,
+		sort paging.Sort,
+	) (map[int64][]LookupItem, map[int64]int64, error)
+	FindIDByIdentifiers(ctx context.Context, identifiers Identifiers) (int64, error)
+	Create(ctx context.Context, book Book) (int64, error)
+	Merge(ctx context.Context, targetID int64, sourceIDs []int64) error
+	Update(ctx context.Context, bookID int64, update Update) error
+	UpsertBatch(ctx context.Context, books []Book, dryRun bool) ([]UpsertResult, error)
+	Export(ctx context.Context, filter Filter, fn func(book Book) error) error
+	Latest(ctx context.Context, filter Filter, limit uint64) ([]Book, error)
+	Changed(ctx context.Context, changeQuery ChangeQuery) ([]Book, error)
 }
 
 type Service struct {
@@ -45,3 +61,117 @@ func (s Service) GetBooks(ctx context.Context, pageRequest paging.PageRequest, s
 
 	return paging.NewPage(pageRequest, totalElements, lookupItems), nil
 }
+
+// GetRelationBooks - returns a requested page of books for each of the relation items, e.g. the authors.
+// The relation is one of the filter query parameters, e.g. 'author'
+func (s Service) GetRelationBooks(ctx context.Context, relation string, itemIDs []int64,
+	pageRequest paging.PageRequest, sort paging.Sort) (map[int64]paging.Page[LookupItem], error) {
+
+	lookupItems, totals, err := s.store.LookupByRelation(ctx, relation, itemIDs, pageRequest, sort)
+	if err != nil {
+		return nil, err
+	}
+
+	pages := make(map[int64]paging.Page[LookupItem], len(itemIDs))
+	for _, itemID := range itemIDs {
+		content := lookupItems[itemID]
+		if content == nil {
+			content = []LookupItem{}
+		}
+		pages[itemID] = paging.NewPage(pageRequest, totals[itemID], content)
+	}
+
+	return pages, nil
+}
+
+// ExportBooks - streams all the books, matching the filter, to the callback in the ID order
+func (s Service) ExportBooks(ctx context.Context, filter Filter, fn func(book Book) error) error {
+	return s.store.Export(ctx, filter, fn)
+}
+
+// GetLatestBooks - returns up to the limit of the most recently added books, matching the filter, the newest first
+func (s Service) GetLatestBooks(ctx context.Context, filter Filter, limit uint64) ([]Book, error) {
+	return s.store.Latest(ctx, filter, limit)
+}
+
+// GetChangedBooks - returns a page of the books, updated within the time range of the query, in the update time order
+func (s Service) GetChangedBooks(ctx context.Context, changeQuery ChangeQuery) ([]Book, error) {
+	return s.store.Changed(ctx, changeQuery)
+}
+
+// FindBookID - returns the ID of a book with any of the provided identifiers, or ErrNotFound.
+// The ISBN-10 and ISBN-13 forms of the same number are treated as equal
+func (s Service) FindBookID(ctx context.Context, identifiers Identifiers) (int64, error) {
+	return s.store.FindIDByIdentifiers(ctx, identifiers.complete())
+}
+
+// CreateBook - stores a new book along with its relations, and returns the book ID.
+// The ISBN values are validated and normalized, ErrInvalidISBN is returned for the wrong check digit
+func (s Service) CreateBook(ctx context.Context, book Book) (int64, error) {
+	book, err := normalizeISBNs(book)
+	if err != nil {
+		return 0, err
+	}
+
+	return s.store.Create(ctx, book)
+}
+
+// MergeBooks - merges the source books into the target one, and returns the resulting target book.
+// The source books are soft-deleted, and are not returned anymore
+func (s Service) MergeBooks(ctx context.Context, targetID int64, sourceIDs []int64) (Book, error) {
+	if len(sourceIDs) == 0 || slices.Contains(sourceIDs, targetID) {
+		return Book{}, ErrInvalidMerge
+	}
+	sourceIDs = slices.Compact(slices.Sorted(slices.Values(sourceIDs)))
+
+	if err := s.store.Merge(ctx, targetID, sourceIDs); err != nil {
+		return Book{}, err
+	}
+	s.logger.Info("books merged", "bookID", targetID, "mergedIDs", sourceIDs)
+
+	return s.store.GetByID(ctx, targetID)
+}
+
+// UpdateBook - applies the partial metadata update to the book, and returns the resulting book
+func (s Service) UpdateBook(ctx context.Context, bookID int64, update Update) (Book, error) {
+	if !update.isEmpty() {
+		if err := s.store.Update(ctx, bookID, update); err != nil {
+			return Book{}, err
+		}
+	}
+
+	return s.store.GetByID(ctx, bookID)
+}
+
+// ImportBooks - validates and normalizes the ISBN values, and upserts the books by their identifiers in a single
+// batch. The results are in the order of the books, the invalid books are not stored, and have the error set
+func (s Service) ImportBooks(ctx context.Context, books []Book, dryRun bool) ([]UpsertResult, error) {
+	results := make([]UpsertResult, len(books))
+	valid := make([]Book, 0, len(books))
+	validIndexes := make([]int, 0, len(books))
+	for i, book := range books {
+		normalized, err := normalizeISBNs(book)
+		if err == nil && normalized.ISBN13 == 0 && normalized.ISBN10 == "" && normalized.ASIN == "" {
+			err = ErrNoIdentifiers
+		}
+		if err != nil {
+			results[i] = UpsertResult{Err: err}
+			continue
+		}
+		valid = append(valid, normalized)
+		validIndexes = append(validIndexes, i)
+	}
+	if len(valid) == 0 {
+		return results, nil
+	}
+
+	upserted, err := s.store.UpsertBatch(ctx, valid, dryRun)
+	if err != nil {
+		return nil, err
+	}
+	for i, result := range upserted {
+		results[validIndexes[i]] = result
+	}
+
+	return results, nil
+}
@@ -5,11 +5,13 @@ import (
 	"errors"
 	"github.com/sdreger/lib-manager-go/internal/paging"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"log/slog"
 	"os"
 	"strconv"
 	"testing"
+	"time"
 )
 
 func TestService_GetById(t *testing.T) {
@@ -94,6 +96,255 @@ func TestService_GetBooks_Failure(t *testing.T) {
 	assert.Empty(t, page)
 }
 
+func TestService_GetRelationBooks(t *testing.T) {
+	ctx := context.Background()
+	service := getService()
+
+	values := map[string][]string{"page": {"1"}, "size": {"1"}}
+	pageRequest, _ := paging.NewPageRequest(values)
+	sort, _ := paging.NewSort(values, AllowedSortFields)
+
+	mockStore := NewMockStore(t)
+	lookupItem := getTestLookupItem()
+	mockStore.EXPECT().LookupByRelation(ctx, "author", []int64{1, 2}, pageRequest, sort).
+		Return(map[int64][]LookupItem{1: {lookupItem}}, map[int64]int64{1: 3}, nil).Once()
+	mockStore.EXPECT().LookupByRelation(ctx, "tag", []int64{1}, pageRequest, sort).
+		Return(nil, nil, errors.New("some error")).Once()
+	injectMocks(service, mockStore)
+
+	pages, err := service.GetRelationBooks(ctx, "author", []int64{1, 2}, pageRequest, sort)
+	require.NoError(t, err, "should find books")
+	assert.Equal(t, paging.NewPage(pageRequest, 3, []LookupItem{lookupItem}), pages[1])
+	assert.Equal(t, paging.NewPage(pageRequest, 0, []LookupItem{}), pages[2], "the author without books should be empty")
+
+	_, err = service.GetRelationBooks(ctx, "tag", []int64{1}, pageRequest, sort)
+	assert.Error(t, err, "should get an error")
+}
+
+func TestService_FindBookID(t *testing.T) {
+	ctx := context.Background()
+	service := getService()
+	identifiers := Identifiers{ISBN10: bookISBN10, ISBN13: bookISBN13}
+
+	mockStore := NewMockStore(t)
+	mockStore.EXPECT().FindIDByIdentifiers(ctx, identifiers).Return(bookID, nil).Once()
+	injectMocks(service, mockStore)
+
+	foundID, err := service.FindBookID(ctx, identifiers)
+	require.NoError(t, err, "should find book ID")
+	assert.Equal(t, bookID, foundID)
+}
+
+func TestService_FindBookID_CompleteISBN(t *testing.T) {
+	ctx := context.Background()
+	service := getService()
+
+	mockStore := NewMockStore(t)
+	mockStore.EXPECT().FindIDByIdentifiers(ctx, Identifiers{ISBN10: "1617291781", ISBN13: 9781617291784}).
+		Return(bookID, nil).Twice()
+	injectMocks(service, mockStore)
+
+	_, err := service.FindBookID(ctx, Identifiers{ISBN10: "1-61729-178-1"})
+	require.NoError(t, err, "should find book ID by ISBN-10")
+	_, err = service.FindBookID(ctx, Identifiers{ISBN13: 9781617291784})
+	require.NoError(t, err, "should find book ID by ISBN-13")
+}
+
+func TestService_CreateBook(t *testing.T) {
+	ctx := context.Background()
+	service := getService()
+	testBook := getTestBook()
+	testBook.ISBN10 = "1-61729-178-1"
+	testBook.ISBN13 = 0
+
+	expectedBook := testBook
+	expectedBook.ISBN10 = "1617291781"
+	expectedBook.ISBN13 = 9781617291784
+	mockStore := NewMockStore(t)
+	mockStore.EXPECT().Create(ctx, expectedBook).Return(bookID, nil).Once()
+	injectMocks(service, mockStore)
+
+	createdID, err := service.CreateBook(ctx, testBook)
+	require.NoError(t, err, "should create book")
+	assert.Equal(t, bookID, createdID)
+}
+
+func TestService_CreateBook_InvalidISBN(t *testing.T) {
+	ctx := context.Background()
+	service := getService()
+	injectMocks(service, NewMockStore(t))
+
+	tests := map[string]Book{
+		"ISBN-10 check digit": {Title: "Book", ISBN10: "1617291782"},
+		"ISBN-13 check digit": {Title: "Book", ISBN13: 9781617291785},
+		"ISBN-10 format":      {Title: "Book", ISBN10: "16172917"},
+	}
+	for name, testBook := range tests {
+		_, err := service.CreateBook(ctx, testBook)
+		assert.ErrorIs(t, err, ErrInvalidISBN, name)
+	}
+}
+
+func TestService_MergeBooks(t *testing.T) {
+	ctx := context.Background()
+	service := getService()
+
+	mockStore := NewMockStore(t)
+	mockStore.EXPECT().Merge(ctx, bookID, []int64{2, 3}).Return(nil).Once()
+	mockStore.EXPECT().GetByID(ctx, bookID).Return(getTestBook(), nil).Once()
+	injectMocks(service, mockStore)
+
+	merged, err := service.MergeBooks(ctx, bookID, []int64{3, 2, 3})
+	require.NoError(t, err, "should merge books")
+	assert.Equal(t, getTestBook(), merged)
+}
+
+func TestService_MergeBooks_Invalid(t *testing.T) {
+	ctx := context.Background()
+	service := getService()
+	injectMocks(service, NewMockStore(t))
+
+	_, err := service.MergeBooks(ctx, bookID, nil)
+	assert.ErrorIs(t, err, ErrInvalidMerge, "should require the books to merge")
+	_, err = service.MergeBooks(ctx, bookID, []int64{2, bookID})
+	assert.ErrorIs(t, err, ErrInvalidMerge, "should not merge the book into itself")
+}
+
+func TestService_MergeBooks_NotFound(t *testing.T) {
+	ctx := context.Background()
+	service := getService()
+
+	mockStore := NewMockStore(t)
+	mockStore.EXPECT().Merge(ctx, bookID, []int64{2}).Return(ErrNotFound).Once()
+	injectMocks(service, mockStore)
+
+	_, err := service.MergeBooks(ctx, bookID, []int64{2})
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestService_UpdateBook(t *testing.T) {
+	ctx := context.Background()
+	service := getService()
+
+	pages := uint16(512)
+	update := Update{Pages: &pages}
+	mockStore := NewMockStore(t)
+	mockStore.EXPECT().Update(ctx, bookID, update).Return(nil).Once()
+	mockStore.EXPECT().GetByID(ctx, bookID).Return(getTestBook(), nil).Once()
+	injectMocks(service, mockStore)
+
+	updated, err := service.UpdateBook(ctx, bookID, update)
+	require.NoError(t, err, "should update book")
+	assert.Equal(t, getTestBook(), updated)
+}
+
+func TestService_UpdateBook_Empty(t *testing.T) {
+	ctx := context.Background()
+	service := getService()
+
+	mockStore := NewMockStore(t)
+	mockStore.EXPECT().GetByID(ctx, bookID).Return(getTestBook(), nil).Once()
+	injectMocks(service, mockStore)
+
+	_, err := service.UpdateBook(ctx, bookID, Update{})
+	require.NoError(t, err, "should not update book without changes")
+}
+
+func TestService_ImportBooks(t *testing.T) {
+	ctx := context.Background()
+	service := getService()
+	books := []Book{
+		{Title: "Book 01", ISBN10: "1-61729-178-1"},
+		{Title: "Book 02"},
+		{Title: "Book 03", ISBN13: 9781617291785},
+		{Title: "Book 04", ASIN: "B00TEST123"},
+	}
+
+	mockStore := NewMockStore(t)
+	mockStore.EXPECT().UpsertBatch(ctx, []Book{
+		{Title: "Book 01", ISBN10: "1617291781", ISBN13: 9781617291784},
+		{Title: "Book 04", ASIN: "B00TEST123"},
+	}, true).Return([]UpsertResult{
+		{BookID: 1, Status: UpsertUpdated},
+		{Err: ErrIncompleteBook},
+	}, nil).Once()
+	injectMocks(service, mockStore)
+
+	results, err := service.ImportBooks(ctx, books, true)
+	require.NoError(t, err, "should import books")
+	require.Len(t, results, len(books))
+	assert.Equal(t, UpsertResult{BookID: 1, Status: UpsertUpdated}, results[0])
+	assert.ErrorIs(t, results[1].Err, ErrNoIdentifiers)
+	assert.ErrorIs(t, results[2].Err, ErrInvalidISBN)
+	assert.ErrorIs(t, results[3].Err, ErrIncompleteBook)
+}
+
+func TestService_ImportBooks_Failure(t *testing.T) {
+	ctx := context.Background()
+	service := getService()
+
+	mockStore := NewMockStore(t)
+	storeError := errors.New("some error")
+	mockStore.EXPECT().UpsertBatch(ctx, []Book{{Title: "Book", ASIN: "B00TEST123"}}, false).
+		Return(nil, storeError).Once()
+	injectMocks(service, mockStore)
+
+	_, err := service.ImportBooks(ctx, []Book{{Title: "Book", ASIN: "B00TEST123"}}, false)
+	require.ErrorIs(t, err, storeError)
+}
+
+func TestService_ExportBooks(t *testing.T) {
+	ctx := context.Background()
+	service := getService()
+	filter := Filter{Authors: []int64{1}}
+
+	mockStore := NewMockStore(t)
+	mockStore.EXPECT().Export(ctx, filter, mock.Anything).
+		RunAndReturn(func(ctx context.Context, filter Filter, fn func(Book) error) error {
+			return fn(Book{ID: bookID})
+		}).Once()
+	injectMocks(service, mockStore)
+
+	var exported []Book
+	err := service.ExportBooks(ctx, filter, func(book Book) error {
+		exported = append(exported, book)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []Book{{ID: bookID}}, exported)
+}
+
+func TestService_GetLatestBooks(t *testing.T) {
+	ctx := context.Background()
+	service := getService()
+	filter := Filter{Tags: []int64{1}}
+	books := []Book{{ID: 2}, {ID: bookID}}
+
+	mockStore := NewMockStore(t)
+	mockStore.EXPECT().Latest(ctx, filter, uint64(10)).Return(books, nil).Once()
+	injectMocks(service, mockStore)
+
+	result, err := service.GetLatestBooks(ctx, filter, 10)
+	require.NoError(t, err)
+	assert.Equal(t, books, result)
+}
+
+func TestService_GetChangedBooks(t *testing.T) {
+	ctx := context.Background()
+	service := getService()
+	changeQuery := ChangeQuery{Filter: Filter{Publishers: []int64{1}}, AfterID: 2, Limit: 10,
+		AfterUpdatedAt: time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)}
+	books := []Book{{ID: 3}, {ID: 4}}
+
+	mockStore := NewMockStore(t)
+	mockStore.EXPECT().Changed(ctx, changeQuery).Return(books, nil).Once()
+	injectMocks(service, mockStore)
+
+	result, err := service.GetChangedBooks(ctx, changeQuery)
+	require.NoError(t, err)
+	assert.Equal(t, books, result)
+}
+
 func getService() *Service {
 	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
 	return NewService(logger, nil)
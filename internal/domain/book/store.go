@@ -4,12 +4,47 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	sq "github.com/Masterminds/squirrel"
 	"github.com/jmoiron/sqlx"
 	"github.com/lib/pq"
+	"github.com/sdreger/lib-manager-go/internal/isbn"
 	"github.com/sdreger/lib-manager-go/internal/paging"
+	"strconv"
+	"time"
 )
 
+// exportChunkSize - the number of books fetched from the export cursor at once
+const exportChunkSize = 500
+
+// relationConditions - the conditions of the books, having the relation item, referenced as 'item.id',
+// by the filter query parameters
+var relationConditions = map[string]string{
+	queryParamLanguageFilter:  "books.language_id = item.id",
+	queryParamPublisherFilter: "books.publisher_id = item.id",
+	queryParamAuthorFilter:    "books.id IN (SELECT book_id FROM ebook.book_author WHERE author_id = item.id)",
+	queryParamCategoryFilter:  "books.id IN (SELECT book_id FROM ebook.book_category WHERE category_id = item.id)",
+	queryParamFileTypeFilter:  "books.id IN (SELECT book_id FROM ebook.book_file_type WHERE file_type_id = item.id)",
+	queryParamTagFilter:       "books.id IN (SELECT book_id FROM ebook.book_tag WHERE tag_id = item.id)",
+}
+
+// bookRelations - the many-to-many book relations, the related entries are matched by their names
+var bookRelations = []struct {
+	table     string
+	joinTable string
+	column    string
+	names     func(book Book) []string
+}{
+	{table: "ebook.authors", joinTable: "ebook.book_author", column: "author_id",
+		names: func(book Book) []string { return book.Authors }},
+	{table: "ebook.categories", joinTable: "ebook.book_category", column: "category_id",
+		names: func(book Book) []string { return book.Categories }},
+	{table: "ebook.file_types", joinTable: "ebook.book_file_type", column: "file_type_id",
+		names: func(book Book) []string { return book.FileTypes }},
+	{table: "ebook.tags", joinTable: "ebook.book_tag", column: "tag_id",
+		names: func(book Book) []string { return book.Tags }},
+}
+
 type DBStore struct {
 	db *sqlx.DB
 }
@@ -20,14 +55,19 @@ func NewDBStore(db *sqlx.DB) *DBStore {
 
 // GetByID - returns a book by its ID if present, otherwise returns ErrNotFound
 func (s *DBStore) GetByID(ctx context.Context, bookID int64) (Book, error) {
+	return s.getByID(ctx, s.db, bookID)
+}
+
+func (s *DBStore) getByID(ctx context.Context, queryer sqlx.QueryerContext, bookID int64) (Book, error) {
 	var book bookEntity
 	query := `SELECT books.id AS id, title, subtitle, description, isbn10, isbn13, asin,
        pages, publisher_url, edition, pub_date, book_file_name, book_file_size,
-       cover_file_name, books.created_at AS created_at, books.updated_at AS updated_at,
+       cover_file_name, cover_hash, cover_width, cover_height, cover_aspect_ratio, cover_dominant_color,
+       cover_blurhash, books.created_at AS created_at, books.updated_at AS updated_at,
        publishers.name                     AS publisher,
        languages.name                      AS language,
        ARRAY_AGG(DISTINCT authors.name)    AS authors,
-       ARRAY_AGG(DISTINCT categories.name) AS categories,
+       ARRAY_REMOVE(ARRAY_AGG(DISTINCT categories.name), NULL) AS categories,
        ARRAY_AGG(DISTINCT file_types.name) AS file_types,
        ARRAY_REMOVE(ARRAY_AGG(DISTINCT tags.name), NULL) AS tags
 FROM ebook.books
@@ -42,11 +82,13 @@ FROM ebook.books
          LEFT JOIN ebook.book_tag ON books.id = book_tag.book_id
          LEFT JOIN ebook.tags ON book_tag.tag_id = tags.id
 WHERE books.id = $1
+  AND books.deleted_at IS NULL
 GROUP BY books.id, title, subtitle, description, isbn10, isbn13, asin, pages, publisher_url,
-         edition, pub_date, book_file_name, book_file_size, cover_file_name, 
+         edition, pub_date, book_file_name, book_file_size, cover_file_name, cover_hash,
+         cover_width, cover_height, cover_aspect_ratio, cover_dominant_color, cover_blurhash,
          books.created_at, books.updated_at, publishers.name, languages.name
 `
-	err := s.db.GetContext(ctx, &book, query, bookID)
+	err := sqlx.GetContext(ctx, queryer, &book, query, bookID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return Book{}, ErrNotFound
@@ -62,11 +104,85 @@ GROUP BY books.id, title, subtitle, description, isbn10, isbn13, asin, pages, pu
 func (s *DBStore) Lookup(ctx context.Context, page paging.PageRequest, sort paging.Sort, filter Filter) (
 	[]LookupItem, int64, error) {
 
-	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
-	query := psql.Select(`books.id, title, subtitle, isbn10, isbn13, asin, pages, edition, pub_date, 
-       			book_file_size, cover_file_name, publishers.name as publisher, languages.name as language,
+	query := lookupQuery(sort).
+		PlaceholderFormat(sq.Dollar).
+		Limit(page.Limit()).
+		Offset(page.Offset())
+
+	sqlQuery, queryParams, err := applyFilter(query, filter).ToSql()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var rows []lookupEntity
+	err = s.db.SelectContext(ctx, &rows, sqlQuery, queryParams...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var total int64 = 0
+	if len(rows) > 0 {
+		total = rows[0].Total
+	}
+
+	lookupItems := make([]LookupItem, len(rows))
+	for i, row := range rows {
+		lookupItems[i] = s.fromLookupEntity(row)
+	}
+
+	return lookupItems, total, nil
+}
+
+// LookupByRelation - returns a page of the lookup items for each of the relation items, in a single query.
+// The relation is one of the filter query parameters, e.g. 'author', the items without books are absent
+// in the result. The totals are the numbers of the books of each relation item
+func (s *DBStore) LookupByRelation(ctx context.Context, relation string, itemIDs []int64, page paging.PageRequest,
+	sort paging.Sort) (map[int64][]LookupItem, map[int64]int64, error) {
+
+	condition, ok := relationConditions[relation]
+	if !ok {
+		return nil, nil, fmt.Errorf("unsupported book relation: %s", relation)
+	}
+	pageQuery, pageParams, err := lookupQuery(sort).
+		Where(condition).
+		Limit(page.Limit()).
+		Offset(page.Offset()).
+		ToSql()
+	if err != nil {
+		return nil, nil, err
+	}
+	// the page is selected for each item by the lateral subquery, the item ID is referenced as 'item.id'
+	sqlQuery, err := sq.Dollar.ReplacePlaceholders(
+		"SELECT item.id AS item_id, item_page.* FROM unnest(?::bigint[]) AS item(id) CROSS JOIN LATERAL (" +
+			pageQuery + ") item_page")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var rows []relationLookupEntity
+	err = s.db.SelectContext(ctx, &rows, sqlQuery, append([]any{pq.Array(itemIDs)}, pageParams...)...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	lookupItems := make(map[int64][]LookupItem)
+	totals := make(map[int64]int64)
+	for _, row := range rows {
+		lookupItems[row.ItemID] = append(lookupItems[row.ItemID], s.fromLookupEntity(row.lookupEntity))
+		totals[row.ItemID] = row.Total
+	}
+
+	return lookupItems, totals, nil
+}
+
+// lookupQuery - returns the sorted lookup items query, the relation join tables are aliased the way
+// the applyFilter expects
+func lookupQuery(sort paging.Sort) sq.SelectBuilder {
+	return sq.Select(`books.id, title, subtitle, isbn10, isbn13, asin, pages, edition, pub_date, 
+       			book_file_size, cover_file_name, cover_hash, cover_width, cover_height, cover_aspect_ratio,
+       			cover_dominant_color, cover_blurhash, publishers.name as publisher, languages.name as language,
 			 	array_agg(DISTINCT ba.author_id) as author_ids,
-			 	array_agg(DISTINCT bc.category_id) as category_ids,
+			 	array_remove(array_agg(DISTINCT bc.category_id), NULL) as category_ids,
        		    array_agg(DISTINCT bft.file_type_id) as file_types_ids,
 		        array_remove(array_agg(DISTINCT bt.tag_id), NULL) as tag_ids,
 				count(*) over() as total`).
@@ -78,68 +194,601 @@ func (s *DBStore) Lookup(ctx context.Context, page paging.PageRequest, sort pagi
 		LeftJoin("ebook.book_category bc on books.id = bc.book_id").
 		LeftJoin("ebook.book_tag bt on books.id = bt.book_id").
 		GroupBy(`books.id, title, subtitle, isbn10, isbn13, asin, pages, 
-			           pub_date, book_file_size, cover_file_name, publisher, language`).
-		OrderBy(sort.GetOrderBy("ebook.books")).
-		Limit(page.Limit()).
-		Offset(page.Offset())
+			           pub_date, book_file_size, cover_file_name, cover_hash, cover_width, cover_height,
+			           cover_aspect_ratio, cover_dominant_color, cover_blurhash, publisher, language`).
+		Where("books.deleted_at IS NULL").
+		OrderBy(sort.GetOrderBy("ebook.books"))
+}
 
+// applyFilter - adds the filter conditions to the books query, the relation join tables should be aliased as
+// 'ba' (authors), 'bc' (categories), 'bft' (file types) and 'bt' (tags), and the query grouped by the book
+func applyFilter(query sq.SelectBuilder, filter Filter) sq.SelectBuilder {
 	// filter by ISBN10/ISBN13/ASIN overrides all other filters
 	if filter.SBN != "" {
-		query = query.Where(
-			sq.Or{
-				sq.Eq{"books.ISBN10": filter.SBN},
-				sq.Eq{"books.ISBN13::varchar": filter.SBN},
-				sq.Eq{"books.ASIN": filter.SBN},
-			},
-		)
-	} else {
-		if len(filter.Languages) > 0 {
-			query = query.Where("language_id = ANY(?)", pq.Array(filter.Languages))
+		return query.Where(sbnCondition(filter.SBN))
+	}
+
+	if len(filter.Languages) > 0 {
+		query = query.Where("language_id = ANY(?)", pq.Array(filter.Languages))
+	}
+	if len(filter.Publishers) > 0 {
+		query = query.Where("publisher_id = ANY(?)", pq.Array(filter.Publishers))
+	}
+	if len(filter.Authors) > 0 {
+		query = query.Having("(array_agg(ba.author_id) && ?)", pq.Array(filter.Authors))
+	}
+	if len(filter.Categories) > 0 {
+		query = query.Having("(array_agg(bc.category_id) && ?)", pq.Array(filter.Categories))
+	}
+	if len(filter.FileTypes) > 0 {
+		query = query.Having("(array_agg(bft.file_type_id) && ?)", pq.Array(filter.FileTypes))
+	}
+
+	if len(filter.Tags) > 0 {
+		query = query.Having("(array_agg(bt.tag_id) && ?)", pq.Array(filter.Tags))
+	}
+
+	if len(filter.Query) > 0 {
+		query = query.Where(sq.ILike{"books.title": "%" + filter.Query + "%"})
+	}
+
+	return query
+}
+
+// Export - streams all the books, matching the filter, in the ID order. The books are fetched from a server-side
+// cursor in chunks, so the memory usage does not depend on the number of books. The export stops on the first
+// error, returned by the callback
+func (s *DBStore) Export(ctx context.Context, filter Filter, fn func(book Book) error) error {
+	query := booksQuery().OrderBy("books.id")
+	sqlQuery, queryParams, err := applyFilter(query, filter).ToSql()
+	if err != nil {
+		return err
+	}
+
+	// the cursor only lives within the transaction, the read-only one does not block the writers
+	tx, err := s.db.BeginTxx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if _, err = tx.ExecContext(ctx, "DECLARE book_export NO SCROLL CURSOR FOR "+sqlQuery, queryParams...); err != nil {
+		return err
+	}
+	for {
+		var chunk []bookEntity
+		if err := tx.SelectContext(ctx, &chunk, "FETCH "+strconv.Itoa(exportChunkSize)+" FROM book_export"); err != nil {
+			return err
 		}
-		if len(filter.Publishers) > 0 {
-			query = query.Where("publisher_id = ANY(?)", pq.Array(filter.Publishers))
+		for _, entity := range chunk {
+			if err := fn(s.fromEntity(entity)); err != nil {
+				return err
+			}
 		}
-		if len(filter.Authors) > 0 {
-			query = query.Having("(array_agg(ba.author_id) && ?)", pq.Array(filter.Authors))
+		if len(chunk) < exportChunkSize {
+			return nil
 		}
-		if len(filter.Categories) > 0 {
-			query = query.Having("(array_agg(bc.category_id) && ?)", pq.Array(filter.Categories))
+	}
+}
+
+// Latest - returns up to the limit of the most recently added books, matching the filter, the newest first
+func (s *DBStore) Latest(ctx context.Context, filter Filter, limit uint64) ([]Book, error) {
+	query := booksQuery().OrderBy("books.created_at DESC", "books.id DESC").Limit(limit)
+	sqlQuery, queryParams, err := applyFilter(query, filter).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []bookEntity
+	if err := s.db.SelectContext(ctx, &rows, sqlQuery, queryParams...); err != nil {
+		return nil, err
+	}
+
+	books := make([]Book, len(rows))
+	for i, row := range rows {
+		books[i] = s.fromEntity(row)
+	}
+
+	return books, nil
+}
+
+// Changed - returns up to the limit of the books, matching the change query, the least recently updated first.
+// The timestamps are passed without the time zone, so they are compared the way they are stored
+func (s *DBStore) Changed(ctx context.Context, changeQuery ChangeQuery) ([]Book, error) {
+	query := booksQuery()
+	if changeQuery.WithDeleted {
+		query = allBooksQuery()
+	}
+	query = query.OrderBy("books.updated_at", "books.id").Limit(changeQuery.Limit)
+	if !changeQuery.From.IsZero() {
+		query = query.Where("books.updated_at >= ?::timestamp", timestampParam(changeQuery.From))
+	}
+	if !changeQuery.Until.IsZero() {
+		query = query.Where("books.updated_at <= ?::timestamp", timestampParam(changeQuery.Until))
+	}
+	if !changeQuery.AfterUpdatedAt.IsZero() {
+		query = query.Where("(books.updated_at, books.id) > (?::timestamp, ?)",
+			timestampParam(changeQuery.AfterUpdatedAt), changeQuery.AfterID)
+	}
+	sqlQuery, queryParams, err := applyFilter(query, changeQuery.Filter).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []bookEntity
+	if err := s.db.SelectContext(ctx, &rows, sqlQuery, queryParams...); err != nil {
+		return nil, err
+	}
+
+	books := make([]Book, len(rows))
+	for i, row := range rows {
+		books[i] = s.fromEntity(row)
+	}
+
+	return books, nil
+}
+
+func timestampParam(value time.Time) string {
+	return value.UTC().Format("2006-01-02 15:04:05.999999")
+}
+
+// booksQuery - selects the non-deleted books with all their details, the relations are aggregated by name.
+// The relation join tables are aliased the way the filter expects them
+func booksQuery() sq.SelectBuilder {
+	return allBooksQuery().Where("books.deleted_at IS NULL")
+}
+
+// allBooksQuery - selects the books with all their details, including the soft-deleted ones
+func allBooksQuery() sq.SelectBuilder {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+
+	return psql.Select(`books.id, title, subtitle, description, isbn10, isbn13, asin, pages, publisher_url,
+       edition, pub_date, book_file_name, book_file_size, cover_file_name, cover_hash, cover_width, cover_height,
+       cover_aspect_ratio, cover_dominant_color, cover_blurhash, books.created_at, books.updated_at,
+       books.deleted_at, publishers.name AS publisher, languages.name AS language,
+       ARRAY_REMOVE(ARRAY_AGG(DISTINCT authors.name), NULL)    AS authors,
+       ARRAY_REMOVE(ARRAY_AGG(DISTINCT categories.name), NULL) AS categories,
+       ARRAY_REMOVE(ARRAY_AGG(DISTINCT file_types.name), NULL) AS file_types,
+       ARRAY_REMOVE(ARRAY_AGG(DISTINCT tags.name), NULL)       AS tags`).
+		From("ebook.books").
+		LeftJoin("ebook.publishers on books.publisher_id = publishers.id").
+		LeftJoin("ebook.languages on books.language_id = languages.id").
+		LeftJoin("ebook.book_author ba on books.id = ba.book_id").
+		LeftJoin("ebook.authors on authors.id = ba.author_id").
+		LeftJoin("ebook.book_category bc on books.id = bc.book_id").
+		LeftJoin("ebook.categories on categories.id = bc.category_id").
+		LeftJoin("ebook.book_file_type bft on books.id = bft.book_id").
+		LeftJoin("ebook.file_types on file_types.id = bft.file_type_id").
+		LeftJoin("ebook.book_tag bt on books.id = bt.book_id").
+		LeftJoin("ebook.tags on tags.id = bt.tag_id").
+		GroupBy("books.id, publishers.name, languages.name")
+}
+
+// sbnCondition - matches the exact value, as well as both forms of a valid ISBN,
+// e.g. '978-1-61729-178-4' matches a book stored with the '1617291781' ISBN-10 only
+func sbnCondition(sbn string) sq.Or {
+	condition := sq.Or{
+		sq.Eq{"books.ISBN10": sbn},
+		sq.Eq{"books.ISBN13::varchar": sbn},
+		sq.Eq{"books.ASIN": sbn},
+	}
+	if isbn10, isbn13, err := isbn.Both(sbn); err == nil {
+		if isbn10 != "" {
+			condition = append(condition, sq.Eq{"books.ISBN10": isbn10})
 		}
-		if len(filter.FileTypes) > 0 {
-			query = query.Having("(array_agg(bft.file_type_id) && ?)", pq.Array(filter.FileTypes))
+		condition = append(condition, sq.Eq{"books.ISBN13::varchar": isbn13})
+	}
+
+	return condition
+}
+
+// FindIDByIdentifiers - returns the ID of a book, matching any of the provided identifiers, otherwise returns
+// ErrNotFound. The ISBN-13 match takes precedence over the ISBN-10 one, and the ASIN match is the last resort
+func (s *DBStore) FindIDByIdentifiers(ctx context.Context, identifiers Identifiers) (int64, error) {
+	if identifiers.isEmpty() {
+		return 0, ErrNotFound
+	}
+
+	query := `SELECT id
+FROM ebook.books
+WHERE (isbn13 = $1 OR isbn10 = $2 OR asin = $3)
+  AND deleted_at IS NULL
+ORDER BY CASE WHEN isbn13 = $1 THEN 0 WHEN isbn10 = $2 THEN 1 ELSE 2 END, id
+LIMIT 1`
+	var id int64
+	err := s.db.GetContext(ctx, &id, query, sql.NullInt64{Int64: identifiers.ISBN13, Valid: identifiers.ISBN13 > 0},
+		sql.NullString{String: identifiers.ISBN10, Valid: identifiers.ISBN10 != ""},
+		sql.NullString{String: identifiers.ASIN, Valid: identifiers.ASIN != ""})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, ErrNotFound
 		}
 
-		if len(filter.Tags) > 0 {
-			query = query.Having("(array_agg(bt.tag_id) && ?)", pq.Array(filter.Tags))
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// Create - inserts a new book along with its relations, and returns the book ID. The publisher, the language
+// and the related entries are matched by their names (case-insensitively), the missing ones are created.
+// All the changes are applied in a single transaction
+func (s *DBStore) Create(ctx context.Context, book Book) (int64, error) {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	bookID, err := s.insert(ctx, tx, book)
+	if err != nil {
+		return 0, err
+	}
+
+	return bookID, tx.Commit()
+}
+
+// insert - inserts a new book along with its relations within the transaction
+func (s *DBStore) insert(ctx context.Context, tx *sqlx.Tx, book Book) (int64, error) {
+	languageID, err := s.getOrCreateID(ctx, tx, "ebook.languages", book.Language)
+	if err != nil {
+		return 0, err
+	}
+	publisherID, err := s.getOrCreateID(ctx, tx, "ebook.publishers", book.Publisher)
+	if err != nil {
+		return 0, err
+	}
+
+	query := `INSERT INTO ebook.books (title, subtitle, description, isbn10, isbn13, asin, pages, language_id,
+                         publisher_id, publisher_url, edition, pub_date, book_file_name, book_file_size,
+                         cover_file_name, cover_hash, cover_width, cover_height)
+VALUES (:title, :subtitle, :description, :isbn10, :isbn13, :asin, :pages, :language_id, :publisher_id,
+        :publisher_url, :edition, :pub_date, :book_file_name, :book_file_size, :cover_file_name, :cover_hash,
+        :cover_width, :cover_height)
+RETURNING id`
+	query, args, err := tx.BindNamed(query, newCreateEntity(book, languageID, publisherID))
+	if err != nil {
+		return 0, err
+	}
+	var bookID int64
+	if err := tx.GetContext(ctx, &bookID, query, args...); err != nil {
+		return 0, err
+	}
+
+	for _, relation := range bookRelations {
+		if err := s.addRelated(ctx, tx, bookID, relation.table, relation.joinTable, relation.column,
+			relation.names(book)); err != nil {
+			return 0, err
 		}
+	}
+
+	return bookID, nil
+}
+
+// addRelated - links the book to the related entries, matched by their names, the missing entries are created
+func (s *DBStore) addRelated(ctx context.Context, tx *sqlx.Tx, bookID int64, table string, joinTable string,
+	column string, names []string) error {
 
-		if len(filter.Query) > 0 {
-			query = query.Where(sq.ILike{"books.title": "%" + filter.Query + "%"})
+	for _, relatedName := range names {
+		relatedID, err := s.getOrCreateID(ctx, tx, table, relatedName)
+		if err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx, "INSERT INTO "+joinTable+" (book_id, "+column+")"+
+			" VALUES ($1, $2) ON CONFLICT DO NOTHING", bookID, relatedID)
+		if err != nil {
+			return err
 		}
 	}
 
-	sqlQuery, queryParams, err := query.ToSql()
+	return nil
+}
+
+// UpsertBatch - creates the new books, and updates the existing ones, matched by any of their identifiers.
+// The batch is applied in a single transaction, and a failed book only rolls its own changes back.
+// In the dry-run mode the whole transaction is rolled back, while the results stay the same
+func (s *DBStore) UpsertBatch(ctx context.Context, books []Book, dryRun bool) ([]UpsertResult, error) {
+	tx, err := s.db.BeginTxx(ctx, nil)
 	if err != nil {
-		return nil, 0, err
+		return nil, err
 	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
 
-	var rows []lookupEntity
-	err = s.db.SelectContext(ctx, &rows, sqlQuery, queryParams...)
+	results := make([]UpsertResult, len(books))
+	for i, book := range books {
+		if _, err := tx.ExecContext(ctx, "SAVEPOINT book_upsert"); err != nil {
+			return nil, err
+		}
+		results[i], err = s.upsert(ctx, tx, book)
+		if err != nil {
+			// the failed statement aborts the transaction, until it is rolled back to the savepoint
+			if _, rollbackErr := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT book_upsert"); rollbackErr != nil {
+				return nil, errors.Join(err, rollbackErr)
+			}
+			results[i] = UpsertResult{Err: err}
+			continue
+		}
+		if _, err := tx.ExecContext(ctx, "RELEASE SAVEPOINT book_upsert"); err != nil {
+			return nil, err
+		}
+	}
+	if dryRun {
+		return results, nil
+	}
+
+	return results, tx.Commit()
+}
+
+// upsert - creates the book, or updates the one with any of the same identifiers, if the imported values differ
+func (s *DBStore) upsert(ctx context.Context, tx *sqlx.Tx, book Book) (UpsertResult, error) {
+	var ids []int64
+	query := `SELECT id
+FROM ebook.books
+WHERE (isbn13 = $1 OR isbn10 = $2 OR asin = $3)
+  AND deleted_at IS NULL
+ORDER BY id
+FOR UPDATE`
+	err := tx.SelectContext(ctx, &ids, query, sql.NullInt64{Int64: book.ISBN13, Valid: book.ISBN13 > 0},
+		sql.NullString{String: book.ISBN10, Valid: book.ISBN10 != ""},
+		sql.NullString{String: book.ASIN, Valid: book.ASIN != ""})
 	if err != nil {
-		return nil, 0, err
+		return UpsertResult{}, err
 	}
 
-	var total int64 = 0
-	if len(rows) > 0 {
-		total = rows[0].Total
+	switch len(ids) {
+	case 0:
+		if book.Publisher == "" || book.Language == "" {
+			return UpsertResult{}, ErrIncompleteBook
+		}
+		bookID, err := s.insert(ctx, tx, book)
+		if err != nil {
+			return UpsertResult{}, err
+		}
+
+		return UpsertResult{BookID: bookID, Status: UpsertCreated}, nil
+	case 1:
+	default:
+		return UpsertResult{}, ErrIdentifierConflict
 	}
 
-	lookupItems := make([]LookupItem, len(rows))
-	for i, row := range rows {
-		lookupItems[i] = s.fromLookupEntity(row)
+	existing, err := s.getByID(ctx, tx, ids[0])
+	if err != nil {
+		return UpsertResult{}, err
+	}
+	updated, changed := applyImport(existing, book)
+	if !changed {
+		return UpsertResult{BookID: existing.ID, Status: UpsertSkipped}, nil
 	}
 
-	return lookupItems, total, nil
+	languageID, err := s.getOrCreateID(ctx, tx, "ebook.languages", updated.Language)
+	if err != nil {
+		return UpsertResult{}, err
+	}
+	publisherID, err := s.getOrCreateID(ctx, tx, "ebook.publishers", updated.Publisher)
+	if err != nil {
+		return UpsertResult{}, err
+	}
+	query = `UPDATE ebook.books
+SET title         = :title,
+    subtitle      = :subtitle,
+    description   = :description,
+    isbn10        = :isbn10,
+    isbn13        = :isbn13,
+    asin          = :asin,
+    pages         = :pages,
+    language_id   = :language_id,
+    publisher_id  = :publisher_id,
+    publisher_url = :publisher_url,
+    edition       = :edition,
+    pub_date      = :pub_date,
+    updated_at    = now()
+WHERE id = :id`
+	entity := updateEntity{ID: existing.ID, createEntity: newCreateEntity(updated, languageID, publisherID)}
+	if _, err = tx.NamedExecContext(ctx, query, entity); err != nil {
+		return UpsertResult{}, err
+	}
+
+	for _, relation := range bookRelations {
+		names := relation.names(book)
+		if len(names) == 0 || relation.table == "ebook.file_types" {
+			continue
+		}
+		_, err = tx.ExecContext(ctx, "DELETE FROM "+relation.joinTable+" WHERE book_id = $1", existing.ID)
+		if err != nil {
+			return UpsertResult{}, err
+		}
+		err = s.addRelated(ctx, tx, existing.ID, relation.table, relation.joinTable, relation.column, names)
+		if err != nil {
+			return UpsertResult{}, err
+		}
+	}
+
+	return UpsertResult{BookID: existing.ID, Status: UpsertUpdated}, nil
+}
+
+// Merge - merges the source books into the target one: combines the relations, fills in the missing metadata,
+// moves the book files of the file types the target book does not have, and soft-deletes the source books.
+// The moved book files keep their object keys, since the files are always addressed by the stored key.
+// All the changes are applied in a single transaction. Returns ErrNotFound if any of the books is missing
+func (s *DBStore) Merge(ctx context.Context, targetID int64, sourceIDs []int64) error {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	// the rows are locked in the ID order, so the concurrent merges of the same books do not deadlock
+	var entities []mergeEntity
+	query := `SELECT id, publisher_id, subtitle, description, isbn10, isbn13, asin, pages, publisher_url, edition,
+       book_file_name, book_file_size, cover_file_name, cover_hash, cover_width, cover_height, cover_aspect_ratio,
+       cover_dominant_color, cover_blurhash
+FROM ebook.books
+WHERE id = ANY($1)
+  AND deleted_at IS NULL
+ORDER BY id
+FOR UPDATE`
+	if err := tx.SelectContext(ctx, &entities, query, pq.Array(append([]int64{targetID}, sourceIDs...))); err != nil {
+		return err
+	}
+	if len(entities) != len(sourceIDs)+1 {
+		return ErrNotFound
+	}
+	byID := make(map[int64]mergeEntity, len(entities))
+	for _, entity := range entities {
+		byID[entity.ID] = entity
+	}
+	sources := make([]mergeEntity, len(sourceIDs))
+	for i, sourceID := range sourceIDs {
+		sources[i] = byID[sourceID]
+	}
+
+	for _, relation := range bookRelations {
+		_, err = tx.ExecContext(ctx, "INSERT INTO "+relation.joinTable+" (book_id, "+relation.column+")"+
+			" SELECT $1, "+relation.column+" FROM "+relation.joinTable+" WHERE book_id = ANY($2)"+
+			" ON CONFLICT DO NOTHING", targetID, pq.Array(sourceIDs))
+		if err != nil {
+			return err
+		}
+	}
+
+	// the most recent file of each file type, missing in the target book, is moved
+	query = `UPDATE ebook.book_files
+SET book_id = $1, updated_at = now()
+WHERE (book_id, file_type_id) IN (SELECT DISTINCT ON (file_type_id) book_id, file_type_id
+                                  FROM ebook.book_files
+                                  WHERE book_id = ANY($2)
+                                    AND file_type_id NOT IN (SELECT file_type_id FROM ebook.book_files
+                                                             WHERE book_id = $1)
+                                  ORDER BY file_type_id, updated_at DESC)`
+	if _, err = tx.ExecContext(ctx, query, targetID, pq.Array(sourceIDs)); err != nil {
+		return err
+	}
+
+	// the source books are deleted first, so the target book can take over their unique identifiers
+	query = `UPDATE ebook.books
+SET deleted_at = now(), merged_into = $1, updated_at = now()
+WHERE id = ANY($2)`
+	if _, err = tx.ExecContext(ctx, query, targetID, pq.Array(sourceIDs)); err != nil {
+		return err
+	}
+	query = "UPDATE ebook.books SET merged_into = $1 WHERE merged_into = ANY($2)"
+	if _, err = tx.ExecContext(ctx, query, targetID, pq.Array(sourceIDs)); err != nil {
+		return err
+	}
+
+	query = `UPDATE ebook.books
+SET subtitle             = :subtitle,
+    description          = :description,
+    isbn10               = :isbn10,
+    isbn13               = :isbn13,
+    asin                 = :asin,
+    pages                = :pages,
+    publisher_url        = :publisher_url,
+    edition              = :edition,
+    book_file_name       = :book_file_name,
+    book_file_size       = :book_file_size,
+    cover_file_name      = :cover_file_name,
+    cover_hash           = :cover_hash,
+    cover_width          = :cover_width,
+    cover_height         = :cover_height,
+    cover_aspect_ratio   = :cover_aspect_ratio,
+    cover_dominant_color = :cover_dominant_color,
+    cover_blurhash       = :cover_blurhash,
+    updated_at           = now()
+WHERE id = :id`
+	if _, err = tx.NamedExecContext(ctx, query, mergeMetadata(byID[targetID], sources)); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Update - applies the partial metadata update to the book, the categories are replaced if provided.
+// All the changes are applied in a single transaction. Returns ErrNotFound if the book is missing
+func (s *DBStore) Update(ctx context.Context, bookID int64, update Update) error {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	builder := psql.Update("ebook.books").
+		Set("updated_at", sq.Expr("now()")).
+		Where(sq.Eq{"id": bookID}).
+		Where("deleted_at IS NULL")
+	if update.Subtitle != nil {
+		builder = builder.Set("subtitle", sql.NullString{String: *update.Subtitle, Valid: *update.Subtitle != ""})
+	}
+	if update.Description != nil {
+		builder = builder.Set("description", *update.Description)
+	}
+	if update.Pages != nil {
+		builder = builder.Set("pages", *update.Pages)
+	}
+	if update.PubDate != nil {
+		builder = builder.Set("pub_date", *update.PubDate)
+	}
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return err
+	}
+	result, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrNotFound
+	}
+
+	if update.Categories != nil {
+		if _, err = tx.ExecContext(ctx, "DELETE FROM ebook.book_category WHERE book_id = $1", bookID); err != nil {
+			return err
+		}
+		err = s.addRelated(ctx, tx, bookID, "ebook.categories", "ebook.book_category", "category_id",
+			update.Categories)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// getOrCreateID - returns the ID of the dictionary table entry by its case-insensitive name, the missing entry
+// is created. The entry created meanwhile by a concurrent transaction is selected again, the names are unique
+func (s *DBStore) getOrCreateID(ctx context.Context, tx *sqlx.Tx, table string, entryName string) (int64, error) {
+	var id int64
+	query := "SELECT id FROM " + table + " WHERE LOWER(name) = LOWER($1)"
+	err := tx.GetContext(ctx, &id, query, entryName)
+	if err == nil {
+		return id, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return 0, err
+	}
+
+	err = tx.GetContext(ctx, &id, "INSERT INTO "+table+" (name) VALUES ($1) ON CONFLICT DO NOTHING RETURNING id",
+		entryName)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = tx.GetContext(ctx, &id, query, entryName)
+	}
+
+	return id, err
 }
 
 func (s *DBStore) fromEntity(book bookEntity) Book {
@@ -161,6 +810,7 @@ func (s *DBStore) fromEntity(book bookEntity) Book {
 		Tags:          book.Tags,
 		CreatedAt:     book.CreatedAt,
 		UpdatedAt:     book.UpdatedAt,
+		Deleted:       book.DeletedAt.Valid,
 	}
 	if book.Subtitle.Valid {
 		result.Subtitle = book.Subtitle.String
@@ -177,6 +827,24 @@ func (s *DBStore) fromEntity(book bookEntity) Book {
 	if book.ASIN.Valid {
 		result.ASIN = book.ASIN.String
 	}
+	if book.CoverHash.Valid {
+		result.CoverHash = book.CoverHash.String
+	}
+	if book.CoverWidth.Valid {
+		result.CoverWidth = int(book.CoverWidth.Int32)
+	}
+	if book.CoverHeight.Valid {
+		result.CoverHeight = int(book.CoverHeight.Int32)
+	}
+	if book.CoverAspectRatio.Valid {
+		result.CoverAspectRatio = book.CoverAspectRatio.Float64
+	}
+	if book.CoverDominantColor.Valid {
+		result.CoverDominantColor = book.CoverDominantColor.String
+	}
+	if book.CoverBlurHash.Valid {
+		result.CoverBlurHash = book.CoverBlurHash.String
+	}
 	return result
 }
 
@@ -208,6 +876,24 @@ func (s *DBStore) fromLookupEntity(book lookupEntity) LookupItem {
 	if book.ASIN.Valid {
 		result.ASIN = book.ASIN.String
 	}
+	if book.CoverHash.Valid {
+		result.CoverHash = book.CoverHash.String
+	}
+	if book.CoverWidth.Valid {
+		result.CoverWidth = int(book.CoverWidth.Int32)
+	}
+	if book.CoverHeight.Valid {
+		result.CoverHeight = int(book.CoverHeight.Int32)
+	}
+	if book.CoverAspectRatio.Valid {
+		result.CoverAspectRatio = book.CoverAspectRatio.Float64
+	}
+	if book.CoverDominantColor.Valid {
+		result.CoverDominantColor = book.CoverDominantColor.String
+	}
+	if book.CoverBlurHash.Valid {
+		result.CoverBlurHash = book.CoverBlurHash.String
+	}
 
 	return result
 }
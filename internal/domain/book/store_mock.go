@@ -40,6 +40,220 @@ func (_m *MockStore) EXPECT() *MockStore_Expecter {
 	return &MockStore_Expecter{mock: &_m.Mock}
 }
 
+// Changed provides a mock function for the type MockStore
+func (_mock *MockStore) Changed(ctx context.Context, changeQuery ChangeQuery) ([]Book, error) {
+	ret := _mock.Called(ctx, changeQuery)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Changed")
+	}
+
+	var r0 []Book
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ChangeQuery) ([]Book, error)); ok {
+		return returnFunc(ctx, changeQuery)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ChangeQuery) []Book); ok {
+		r0 = returnFunc(ctx, changeQuery)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Book)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, ChangeQuery) error); ok {
+		r1 = returnFunc(ctx, changeQuery)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStore_Changed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Changed'
+type MockStore_Changed_Call struct {
+	*mock.Call
+}
+
+// Changed is a helper method to define mock.On call
+//   - ctx
+//   - changeQuery
+func (_e *MockStore_Expecter) Changed(ctx interface{}, changeQuery interface{}) *MockStore_Changed_Call {
+	return &MockStore_Changed_Call{Call: _e.mock.On("Changed", ctx, changeQuery)}
+}
+
+func (_c *MockStore_Changed_Call) Run(run func(ctx context.Context, changeQuery ChangeQuery)) *MockStore_Changed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(ChangeQuery))
+	})
+	return _c
+}
+
+func (_c *MockStore_Changed_Call) Return(books []Book, err error) *MockStore_Changed_Call {
+	_c.Call.Return(books, err)
+	return _c
+}
+
+func (_c *MockStore_Changed_Call) RunAndReturn(run func(ctx context.Context, changeQuery ChangeQuery) ([]Book, error)) *MockStore_Changed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function for the type MockStore
+func (_mock *MockStore) Create(ctx context.Context, book Book) (int64, error) {
+	ret := _mock.Called(ctx, book)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, Book) (int64, error)); ok {
+		return returnFunc(ctx, book)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, Book) int64); ok {
+		r0 = returnFunc(ctx, book)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, Book) error); ok {
+		r1 = returnFunc(ctx, book)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStore_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockStore_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx
+//   - book
+func (_e *MockStore_Expecter) Create(ctx interface{}, book interface{}) *MockStore_Create_Call {
+	return &MockStore_Create_Call{Call: _e.mock.On("Create", ctx, book)}
+}
+
+func (_c *MockStore_Create_Call) Run(run func(ctx context.Context, book Book)) *MockStore_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(Book))
+	})
+	return _c
+}
+
+func (_c *MockStore_Create_Call) Return(n int64, err error) *MockStore_Create_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockStore_Create_Call) RunAndReturn(run func(ctx context.Context, book Book) (int64, error)) *MockStore_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Export provides a mock function for the type MockStore
+func (_mock *MockStore) Export(ctx context.Context, filter Filter, fn func(book Book) error) error {
+	ret := _mock.Called(ctx, filter, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Export")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, Filter, func(book Book) error) error); ok {
+		r0 = returnFunc(ctx, filter, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockStore_Export_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Export'
+type MockStore_Export_Call struct {
+	*mock.Call
+}
+
+// Export is a helper method to define mock.On call
+//   - ctx
+//   - filter
+//   - fn
+func (_e *MockStore_Expecter) Export(ctx interface{}, filter interface{}, fn interface{}) *MockStore_Export_Call {
+	return &MockStore_Export_Call{Call: _e.mock.On("Export", ctx, filter, fn)}
+}
+
+func (_c *MockStore_Export_Call) Run(run func(ctx context.Context, filter Filter, fn func(book Book) error)) *MockStore_Export_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(Filter), args[2].(func(book Book) error))
+	})
+	return _c
+}
+
+func (_c *MockStore_Export_Call) Return(err error) *MockStore_Export_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockStore_Export_Call) RunAndReturn(run func(ctx context.Context, filter Filter, fn func(book Book) error) error) *MockStore_Export_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindIDByIdentifiers provides a mock function for the type MockStore
+func (_mock *MockStore) FindIDByIdentifiers(ctx context.Context, identifiers Identifiers) (int64, error) {
+	ret := _mock.Called(ctx, identifiers)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindIDByIdentifiers")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, Identifiers) (int64, error)); ok {
+		return returnFunc(ctx, identifiers)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, Identifiers) int64); ok {
+		r0 = returnFunc(ctx, identifiers)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, Identifiers) error); ok {
+		r1 = returnFunc(ctx, identifiers)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStore_FindIDByIdentifiers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindIDByIdentifiers'
+type MockStore_FindIDByIdentifiers_Call struct {
+	*mock.Call
+}
+
+// FindIDByIdentifiers is a helper method to define mock.On call
+//   - ctx
+//   - identifiers
+func (_e *MockStore_Expecter) FindIDByIdentifiers(ctx interface{}, identifiers interface{}) *MockStore_FindIDByIdentifiers_Call {
+	return &MockStore_FindIDByIdentifiers_Call{Call: _e.mock.On("FindIDByIdentifiers", ctx, identifiers)}
+}
+
+func (_c *MockStore_FindIDByIdentifiers_Call) Run(run func(ctx context.Context, identifiers Identifiers)) *MockStore_FindIDByIdentifiers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(Identifiers))
+	})
+	return _c
+}
+
+func (_c *MockStore_FindIDByIdentifiers_Call) Return(n int64, err error) *MockStore_FindIDByIdentifiers_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockStore_FindIDByIdentifiers_Call) RunAndReturn(run func(ctx context.Context, identifiers Identifiers) (int64, error)) *MockStore_FindIDByIdentifiers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetByID provides a mock function for the type MockStore
 func (_mock *MockStore) GetByID(ctx context.Context, bookID int64) (Book, error) {
 	ret := _mock.Called(ctx, bookID)
@@ -95,6 +309,64 @@ func (_c *MockStore_GetByID_Call) RunAndReturn(run func(ctx context.Context, boo
 	return _c
 }
 
+// Latest provides a mock function for the type MockStore
+func (_mock *MockStore) Latest(ctx context.Context, filter Filter, limit uint64) ([]Book, error) {
+	ret := _mock.Called(ctx, filter, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Latest")
+	}
+
+	var r0 []Book
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, Filter, uint64) ([]Book, error)); ok {
+		return returnFunc(ctx, filter, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, Filter, uint64) []Book); ok {
+		r0 = returnFunc(ctx, filter, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Book)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, Filter, uint64) error); ok {
+		r1 = returnFunc(ctx, filter, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStore_Latest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Latest'
+type MockStore_Latest_Call struct {
+	*mock.Call
+}
+
+// Latest is a helper method to define mock.On call
+//   - ctx
+//   - filter
+//   - limit
+func (_e *MockStore_Expecter) Latest(ctx interface{}, filter interface{}, limit interface{}) *MockStore_Latest_Call {
+	return &MockStore_Latest_Call{Call: _e.mock.On("Latest", ctx, filter, limit)}
+}
+
+func (_c *MockStore_Latest_Call) Run(run func(ctx context.Context, filter Filter, limit uint64)) *MockStore_Latest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(Filter), args[2].(uint64))
+	})
+	return _c
+}
+
+func (_c *MockStore_Latest_Call) Return(books []Book, err error) *MockStore_Latest_Call {
+	_c.Call.Return(books, err)
+	return _c
+}
+
+func (_c *MockStore_Latest_Call) RunAndReturn(run func(ctx context.Context, filter Filter, limit uint64) ([]Book, error)) *MockStore_Latest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Lookup provides a mock function for the type MockStore
 func (_mock *MockStore) Lookup(ctx context.Context, page paging.PageRequest, sort paging.Sort, filter Filter) ([]LookupItem, int64, error) {
 	ret := _mock.Called(ctx, page, sort, filter)
@@ -159,3 +431,223 @@ func (_c *MockStore_Lookup_Call) RunAndReturn(run func(ctx context.Context, page
 	_c.Call.Return(run)
 	return _c
 }
+
+// LookupByRelation provides a mock function for the type MockStore
+func (_mock *MockStore) LookupByRelation(ctx context.Context, relation string, itemIDs []int64, page paging.PageRequest, sort paging.Sort) (map[int64][]LookupItem, map[int64]int64, error) {
+	ret := _mock.Called(ctx, relation, itemIDs, page, sort)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LookupByRelation")
+	}
+
+	var r0 map[int64][]LookupItem
+	var r1 map[int64]int64
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []int64, paging.PageRequest, paging.Sort) (map[int64][]LookupItem, map[int64]int64, error)); ok {
+		return returnFunc(ctx, relation, itemIDs, page, sort)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []int64, paging.PageRequest, paging.Sort) map[int64][]LookupItem); ok {
+		r0 = returnFunc(ctx, relation, itemIDs, page, sort)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[int64][]LookupItem)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, []int64, paging.PageRequest, paging.Sort) map[int64]int64); ok {
+		r1 = returnFunc(ctx, relation, itemIDs, page, sort)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(map[int64]int64)
+		}
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string, []int64, paging.PageRequest, paging.Sort) error); ok {
+		r2 = returnFunc(ctx, relation, itemIDs, page, sort)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockStore_LookupByRelation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LookupByRelation'
+type MockStore_LookupByRelation_Call struct {
+	*mock.Call
+}
+
+// LookupByRelation is a helper method to define mock.On call
+//   - ctx
+//   - relation
+//   - itemIDs
+//   - page
+//   - sort
+func (_e *MockStore_Expecter) LookupByRelation(ctx interface{}, relation interface{}, itemIDs interface{}, page interface{}, sort interface{}) *MockStore_LookupByRelation_Call {
+	return &MockStore_LookupByRelation_Call{Call: _e.mock.On("LookupByRelation", ctx, relation, itemIDs, page, sort)}
+}
+
+func (_c *MockStore_LookupByRelation_Call) Run(run func(ctx context.Context, relation string, itemIDs []int64, page paging.PageRequest, sort paging.Sort)) *MockStore_LookupByRelation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]int64), args[3].(paging.PageRequest), args[4].(paging.Sort))
+	})
+	return _c
+}
+
+func (_c *MockStore_LookupByRelation_Call) Return(m map[int64][]LookupItem, m1 map[int64]int64, err error) *MockStore_LookupByRelation_Call {
+	_c.Call.Return(m, m1, err)
+	return _c
+}
+
+func (_c *MockStore_LookupByRelation_Call) RunAndReturn(run func(ctx context.Context, relation string, itemIDs []int64, page paging.PageRequest, sort paging.Sort) (map[int64][]LookupItem, map[int64]int64, error)) *MockStore_LookupByRelation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Merge provides a mock function for the type MockStore
+func (_mock *MockStore) Merge(ctx context.Context, targetID int64, sourceIDs []int64) error {
+	ret := _mock.Called(ctx, targetID, sourceIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Merge")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, []int64) error); ok {
+		r0 = returnFunc(ctx, targetID, sourceIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockStore_Merge_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Merge'
+type MockStore_Merge_Call struct {
+	*mock.Call
+}
+
+// Merge is a helper method to define mock.On call
+//   - ctx
+//   - targetID
+//   - sourceIDs
+func (_e *MockStore_Expecter) Merge(ctx interface{}, targetID interface{}, sourceIDs interface{}) *MockStore_Merge_Call {
+	return &MockStore_Merge_Call{Call: _e.mock.On("Merge", ctx, targetID, sourceIDs)}
+}
+
+func (_c *MockStore_Merge_Call) Run(run func(ctx context.Context, targetID int64, sourceIDs []int64)) *MockStore_Merge_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].([]int64))
+	})
+	return _c
+}
+
+func (_c *MockStore_Merge_Call) Return(err error) *MockStore_Merge_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockStore_Merge_Call) RunAndReturn(run func(ctx context.Context, targetID int64, sourceIDs []int64) error) *MockStore_Merge_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function for the type MockStore
+func (_mock *MockStore) Update(ctx context.Context, bookID int64, update Update) error {
+	ret := _mock.Called(ctx, bookID, update)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, Update) error); ok {
+		r0 = returnFunc(ctx, bookID, update)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockStore_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockStore_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx
+//   - bookID
+//   - update
+func (_e *MockStore_Expecter) Update(ctx interface{}, bookID interface{}, update interface{}) *MockStore_Update_Call {
+	return &MockStore_Update_Call{Call: _e.mock.On("Update", ctx, bookID, update)}
+}
+
+func (_c *MockStore_Update_Call) Run(run func(ctx context.Context, bookID int64, update Update)) *MockStore_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(Update))
+	})
+	return _c
+}
+
+func (_c *MockStore_Update_Call) Return(err error) *MockStore_Update_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockStore_Update_Call) RunAndReturn(run func(ctx context.Context, bookID int64, update Update) error) *MockStore_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpsertBatch provides a mock function for the type MockStore
+func (_mock *MockStore) UpsertBatch(ctx context.Context, books []Book, dryRun bool) ([]UpsertResult, error) {
+	ret := _mock.Called(ctx, books, dryRun)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertBatch")
+	}
+
+	var r0 []UpsertResult
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []Book, bool) ([]UpsertResult, error)); ok {
+		return returnFunc(ctx, books, dryRun)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []Book, bool) []UpsertResult); ok {
+		r0 = returnFunc(ctx, books, dryRun)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]UpsertResult)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []Book, bool) error); ok {
+		r1 = returnFunc(ctx, books, dryRun)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStore_UpsertBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertBatch'
+type MockStore_UpsertBatch_Call struct {
+	*mock.Call
+}
+
+// UpsertBatch is a helper method to define mock.On call
+//   - ctx
+//   - books
+//   - dryRun
+func (_e *MockStore_Expecter) UpsertBatch(ctx interface{}, books interface{}, dryRun interface{}) *MockStore_UpsertBatch_Call {
+	return &MockStore_UpsertBatch_Call{Call: _e.mock.On("UpsertBatch", ctx, books, dryRun)}
+}
+
+func (_c *MockStore_UpsertBatch_Call) Run(run func(ctx context.Context, books []Book, dryRun bool)) *MockStore_UpsertBatch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]Book), args[2].(bool))
+	})
+	return _c
+}
+
+func (_c *MockStore_UpsertBatch_Call) Return(upsertResults []UpsertResult, err error) *MockStore_UpsertBatch_Call {
+	_c.Call.Return(upsertResults, err)
+	return _c
+}
+
+func (_c *MockStore_UpsertBatch_Call) RunAndReturn(run func(ctx context.Context, books []Book, dryRun bool) ([]UpsertResult, error)) *MockStore_UpsertBatch_Call {
+	_c.Call.Return(run)
+	return _c
+}
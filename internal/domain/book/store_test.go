@@ -2,6 +2,7 @@ package book
 
 import (
 	"context"
+	"errors"
 	"github.com/jmoiron/sqlx"
 	"github.com/sdreger/lib-manager-go/internal/paging"
 	"github.com/sdreger/lib-manager-go/internal/tests"
@@ -186,6 +187,27 @@ func (s *TestStoreSuite) Test_Lookup_AuthorFilters() {
 	s.Equal(int64(2), book02.ID)
 }
 
+func (s *TestStoreSuite) Test_LookupByRelation() {
+	err := prepareTestData(s.testContainer, "testdata/book_lookup_filter.sql")
+	s.Require().NoError(err, "failed to load test SQL file")
+	requestValues := map[string][]string{"page": {"2"}, "size": {"1"}, "sort": {"id,asc"}}
+	pageRequest, err := paging.NewPageRequest(requestValues)
+	s.Require().NoError(err, "failed to build page request")
+	sort, err := paging.NewSort(requestValues, AllowedSortFields)
+	s.Require().NoError(err, "failed to build sort")
+
+	items, totals, err := s.store.LookupByRelation(context.Background(), "author", []int64{1, 2, 99}, pageRequest, sort)
+	s.Require().NoError(err)
+	s.Equal(map[int64]int64{1: 2, 2: 2}, totals, "the author without books should be absent")
+	s.Require().Len(items[1], 1)
+	s.Equal(int64(2), items[1][0].ID, "each author should have its own page")
+	s.Require().Len(items[2], 1)
+	s.Equal(int64(3), items[2][0].ID)
+
+	_, _, err = s.store.LookupByRelation(context.Background(), "year", []int64{1}, pageRequest, sort)
+	s.Error(err, "the unsupported relation should not be queried")
+}
+
 func (s *TestStoreSuite) Test_Lookup_CategoryFilters() {
 	requestValues := map[string][]string{"page": {"1"}, "size": {"10"}, "category": {"1"}}
 	response, total, err := performLookupRequest(s, requestValues)
@@ -247,6 +269,24 @@ func (s *TestStoreSuite) Test_Lookup_Filters() {
 	s.Equal(int64(3), book03.ID)
 }
 
+func (s *TestStoreSuite) Test_Lookup_SBN_ISBNForms() {
+	ctx := context.Background()
+	err := prepareTestData(s.testContainer, "testdata/book_lookup_filter.sql")
+	s.Require().NoError(err, "failed to load test SQL file")
+	_, err = s.db.ExecContext(ctx, "UPDATE ebook.books SET isbn10 = NULL, isbn13 = 9781617291784 WHERE id = 2")
+	s.Require().NoError(err, "failed to update test book")
+
+	pageRequest, _ := paging.NewPageRequest(map[string][]string{})
+	sort, _ := paging.NewSort(map[string][]string{}, AllowedSortFields)
+	for _, sbn := range []string{"1617291781", "1-61729-178-1", "978-1-61729-178-4", "9781617291784"} {
+		response, total, err := s.store.Lookup(ctx, pageRequest, sort, Filter{SBN: sbn})
+		s.Require().NoError(err)
+		s.Equal(int64(1), total, sbn)
+		s.Require().Len(response, 1, sbn)
+		s.Equal(int64(2), response[0].ID, sbn)
+	}
+}
+
 func (s *TestStoreSuite) Test_Lookup_Error() {
 	ctx, cancel := context.WithCancel(context.Background())
 	cancel() // should cause DB query error
@@ -255,6 +295,381 @@ func (s *TestStoreSuite) Test_Lookup_Error() {
 	s.Require().Error(err, "lookup should fail")
 }
 
+func (s *TestStoreSuite) Test_Export() {
+	err := prepareTestData(s.testContainer, "testdata/book_lookup_filter.sql")
+	s.Require().NoError(err, "failed to load test SQL file")
+
+	var books []Book
+	err = s.store.Export(context.Background(), Filter{}, func(book Book) error {
+		books = append(books, book)
+		return nil
+	})
+	s.Require().NoError(err)
+	s.Require().Len(books, 3)
+	for i, book := range books {
+		s.Equal(int64(i+1), book.ID, "books should be exported in the ID order")
+		s.NotEmpty(book.Authors)
+		s.NotEmpty(book.Publisher)
+	}
+}
+
+func (s *TestStoreSuite) Test_Export_Filters() {
+	err := prepareTestData(s.testContainer, "testdata/book_lookup_filter.sql")
+	s.Require().NoError(err, "failed to load test SQL file")
+
+	filter, err := NewFilter(map[string][]string{"author": {"1"}})
+	s.Require().NoError(err, "failed to build filter")
+
+	var bookIDs []int64
+	err = s.store.Export(context.Background(), filter, func(book Book) error {
+		bookIDs = append(bookIDs, book.ID)
+		return nil
+	})
+	s.Require().NoError(err)
+	s.Equal([]int64{1, 2}, bookIDs)
+}
+
+func (s *TestStoreSuite) Test_Export_CallbackError() {
+	err := prepareTestData(s.testContainer, "testdata/book_lookup_filter.sql")
+	s.Require().NoError(err, "failed to load test SQL file")
+
+	callbackError := errors.New("write failed")
+	calls := 0
+	err = s.store.Export(context.Background(), Filter{}, func(book Book) error {
+		calls++
+		return callbackError
+	})
+	s.Require().ErrorIs(err, callbackError)
+	s.Equal(1, calls, "export should stop on the first callback error")
+}
+
+func (s *TestStoreSuite) Test_Latest() {
+	err := prepareTestData(s.testContainer, "testdata/book_lookup_filter.sql")
+	s.Require().NoError(err, "failed to load test SQL file")
+	_, err = s.db.Exec("UPDATE ebook.books SET created_at = created_at - (id * INTERVAL '1 day')")
+	s.Require().NoError(err, "failed to update the creation dates")
+
+	books, err := s.store.Latest(context.Background(), Filter{}, 2)
+	s.Require().NoError(err)
+	s.Require().Len(books, 2)
+	s.Equal(int64(1), books[0].ID, "the newest book should be the first one")
+	s.Equal(int64(2), books[1].ID)
+	s.NotEmpty(books[0].Authors)
+	s.False(books[0].UpdatedAt.IsZero())
+}
+
+func (s *TestStoreSuite) Test_Latest_Filters() {
+	err := prepareTestData(s.testContainer, "testdata/book_lookup_filter.sql")
+	s.Require().NoError(err, "failed to load test SQL file")
+
+	filter, err := NewFilter(map[string][]string{"author": {"1"}})
+	s.Require().NoError(err, "failed to build filter")
+
+	books, err := s.store.Latest(context.Background(), filter, 10)
+	s.Require().NoError(err)
+	bookIDs := make([]int64, 0, len(books))
+	for _, book := range books {
+		bookIDs = append(bookIDs, book.ID)
+	}
+	s.ElementsMatch([]int64{1, 2}, bookIDs)
+}
+
+func (s *TestStoreSuite) Test_Changed() {
+	ctx := context.Background()
+	err := prepareTestData(s.testContainer, "testdata/book_lookup_filter.sql")
+	s.Require().NoError(err, "failed to load test SQL file")
+	_, err = s.db.Exec("UPDATE ebook.books SET updated_at = '2026-10-01 10:00:00.123456' + (id * INTERVAL '1 day')")
+	s.Require().NoError(err, "failed to update the update dates")
+
+	books, err := s.store.Changed(ctx, ChangeQuery{Limit: 2})
+	s.Require().NoError(err)
+	s.Require().Len(books, 2)
+	s.Equal(int64(1), books[0].ID, "the least recently updated book should be the first one")
+	s.Equal(int64(2), books[1].ID)
+
+	books, err = s.store.Changed(ctx, ChangeQuery{AfterUpdatedAt: books[1].UpdatedAt, AfterID: books[1].ID, Limit: 2})
+	s.Require().NoError(err)
+	s.Require().NotEmpty(books)
+	s.Equal(int64(3), books[0].ID, "the next page should start after the keyset position")
+
+	books, err = s.store.Changed(ctx, ChangeQuery{
+		From:  time.Date(2026, 10, 3, 0, 0, 0, 0, time.UTC),
+		Until: time.Date(2026, 10, 3, 23, 59, 59, 0, time.UTC),
+		Limit: 10,
+	})
+	s.Require().NoError(err)
+	s.Require().Len(books, 1)
+	s.Equal(int64(2), books[0].ID, "only the books, updated within the range, should be returned")
+
+	filter, err := NewFilter(map[string][]string{"publisher": {"2"}})
+	s.Require().NoError(err, "failed to build filter")
+	books, err = s.store.Changed(ctx, ChangeQuery{Filter: filter, Limit: 10})
+	s.Require().NoError(err)
+	for _, book := range books {
+		s.Equal("Manning", book.Publisher)
+	}
+
+	_, err = s.db.Exec("UPDATE ebook.books SET deleted_at = now() WHERE id = 1")
+	s.Require().NoError(err, "failed to delete the book")
+	books, err = s.store.Changed(ctx, ChangeQuery{Limit: 1})
+	s.Require().NoError(err)
+	s.Require().Len(books, 1)
+	s.Equal(int64(2), books[0].ID, "the deleted book should be skipped")
+	books, err = s.store.Changed(ctx, ChangeQuery{WithDeleted: true, Limit: 1})
+	s.Require().NoError(err)
+	s.Require().Len(books, 1)
+	s.Equal(int64(1), books[0].ID, "the deleted book should be returned")
+	s.True(books[0].Deleted)
+}
+
+func (s *TestStoreSuite) Test_Create() {
+	ctx := context.Background()
+	err := prepareTestData(s.testContainer, "testdata/book_all_relations.sql")
+	s.Require().NoError(err, "failed to load test SQL file")
+
+	advanceSequences(s)
+
+	newBook := Book{
+		Title:        "Go in Action",
+		Description:  "An introduction to Go",
+		ISBN13:       9781617291784,
+		Pages:        264,
+		PubDate:      time.Date(2015, time.November, 4, 0, 0, 0, 0, time.UTC),
+		BookFileName: "Go.in.Action.epub",
+		BookFileSize: 1024,
+		Language:     strings.ToLower(bookLanguage),
+		Publisher:    "Manning",
+		Authors:      []string{bookAuthor01, "William Kennedy"},
+		FileTypes:    []string{bookFileType02},
+	}
+	createdID, err := s.store.Create(ctx, newBook)
+	s.Require().NoError(err, "failed to create book")
+
+	created, err := s.store.GetByID(ctx, createdID)
+	s.Require().NoError(err, "failed to get created book")
+	s.Equal(newBook.Title, created.Title)
+	s.Equal(newBook.ISBN13, created.ISBN13)
+	s.Empty(created.ISBN10)
+	s.Equal(bookLanguage, created.Language, "the existing language should be matched")
+	s.Equal("Manning", created.Publisher)
+	s.ElementsMatch(newBook.Authors, created.Authors)
+	s.Empty(created.Categories)
+	s.Equal(newBook.FileTypes, created.FileTypes)
+	s.Empty(created.Tags)
+
+	var authorCount int
+	s.Require().NoError(s.db.GetContext(ctx, &authorCount, "SELECT COUNT(*) FROM ebook.authors"))
+	s.Equal(3, authorCount, "only the missing author should be created")
+}
+
+func (s *TestStoreSuite) Test_GetOrCreateID() {
+	ctx := context.Background()
+	err := prepareTestData(s.testContainer, "testdata/book_all_relations.sql")
+	s.Require().NoError(err, "failed to load test SQL file")
+
+	advanceSequences(s)
+
+	tx, err := s.db.BeginTxx(ctx, nil)
+	s.Require().NoError(err)
+	defer func() {
+		_ = tx.Rollback()
+	}()
+	existingID, err := s.store.getOrCreateID(ctx, tx, "ebook.authors", strings.ToUpper(bookAuthor01))
+	s.Require().NoError(err, "failed to get author ID")
+	createdID, err := s.store.getOrCreateID(ctx, tx, "ebook.authors", "New Author")
+	s.Require().NoError(err, "failed to create author")
+	s.NotEqual(existingID, createdID)
+	sameID, err := s.store.getOrCreateID(ctx, tx, "ebook.authors", "new author")
+	s.Require().NoError(err, "failed to get author ID")
+	s.Equal(createdID, sameID, "the names should be matched case-insensitively")
+
+	_, err = tx.ExecContext(ctx, "INSERT INTO ebook.authors (name) VALUES ('NEW AUTHOR')")
+	s.Error(err, "the names should be unique case-insensitively")
+}
+
+func (s *TestStoreSuite) Test_Create_DuplicateISBN() {
+	ctx := context.Background()
+	err := prepareTestData(s.testContainer, "testdata/book_all_relations.sql")
+	s.Require().NoError(err, "failed to load test SQL file")
+
+	advanceSequences(s)
+
+	_, err = s.store.Create(ctx, Book{Title: "Duplicate", ISBN10: bookISBN10, Language: bookLanguage,
+		Publisher: bookPublisher, Authors: []string{bookAuthor01}})
+	s.Require().Error(err, "the ISBN-10 should be unique")
+
+	var bookCount int
+	s.Require().NoError(s.db.GetContext(ctx, &bookCount, "SELECT COUNT(*) FROM ebook.books"))
+	s.Equal(1, bookCount, "the transaction should be rolled back")
+}
+
+func (s *TestStoreSuite) Test_Merge() {
+	ctx := context.Background()
+	err := prepareTestData(s.testContainer, "testdata/book_merge.sql")
+	s.Require().NoError(err, "failed to load test SQL file")
+
+	s.Require().NoError(s.store.Merge(ctx, 1, []int64{2}), "failed to merge books")
+
+	merged, err := s.store.GetByID(ctx, 1)
+	s.Require().NoError(err, "failed to get merged book")
+	s.Equal("CockroachDB", merged.Title, "the target title should be kept")
+	s.Equal("The Definitive Guide", merged.Subtitle)
+	s.Equal("Get the lowdown on CockroachDB", merged.Description, "the longer description should win")
+	s.Equal("1617291781", merged.ISBN10, "the identifier of the deleted book should be taken over")
+	s.Equal(int64(9781617291784), merged.ISBN13)
+	s.Equal("BH34567890", merged.ASIN)
+	s.Equal(uint16(256), merged.Pages)
+	s.Equal("CockroachDB.pdf", merged.BookFileName)
+	s.Equal(strings.Repeat("c", 64), merged.CoverHash)
+	s.Equal(300, merged.CoverWidth)
+	s.ElementsMatch([]string{bookAuthor01, bookAuthor02}, merged.Authors)
+	s.ElementsMatch([]string{bookCategory01}, merged.Categories)
+	s.ElementsMatch([]string{bookFileType01, bookFileType02}, merged.FileTypes)
+	s.ElementsMatch([]string{bookTag01, bookTag02}, merged.Tags)
+
+	var objectKeys []string
+	s.Require().NoError(s.db.SelectContext(ctx, &objectKeys,
+		"SELECT object_key FROM ebook.book_files WHERE book_id = 1 ORDER BY file_type_id"))
+	s.Equal([]string{"books/1/book.pdf", "books/2/book.epub"}, objectKeys,
+		"only the missing file type should move, keeping its object key")
+
+	_, err = s.store.GetByID(ctx, 2)
+	s.Require().ErrorIs(err, ErrNotFound, "the merged book should be soft-deleted")
+	var mergedInto int64
+	s.Require().NoError(s.db.GetContext(ctx, &mergedInto,
+		"SELECT merged_into FROM ebook.books WHERE id = 2 AND deleted_at IS NOT NULL"))
+	s.Equal(int64(1), mergedInto)
+
+	foundID, err := s.store.FindIDByIdentifiers(ctx, Identifiers{ASIN: "BH34567890"})
+	s.Require().NoError(err)
+	s.Equal(int64(1), foundID, "the deleted book should not be matched")
+}
+
+func (s *TestStoreSuite) Test_Merge_NotFound() {
+	ctx := context.Background()
+	err := prepareTestData(s.testContainer, "testdata/book_merge.sql")
+	s.Require().NoError(err, "failed to load test SQL file")
+
+	err = s.store.Merge(ctx, 1, []int64{2, 3})
+	s.Require().ErrorIs(err, ErrNotFound)
+
+	_, err = s.store.GetByID(ctx, 2)
+	s.Require().NoError(err, "nothing should be merged")
+}
+
+func (s *TestStoreSuite) Test_Update() {
+	ctx := context.Background()
+	err := prepareTestData(s.testContainer, "testdata/book_all_relations.sql")
+	s.Require().NoError(err, "failed to load test SQL file")
+
+	description := "Updated description"
+	pages := uint16(512)
+	err = s.store.Update(ctx, bookID, Update{Description: &description, Pages: &pages,
+		Categories: []string{"Computer Science", "Databases"}})
+	s.Require().NoError(err)
+
+	updated, err := s.store.GetByID(ctx, bookID)
+	s.Require().NoError(err)
+	testBook := getTestBook()
+	s.Equal(description, updated.Description)
+	s.Equal(pages, updated.Pages)
+	s.Equal(testBook.Subtitle, updated.Subtitle, "the subtitle should be kept")
+	s.Equal(testBook.PubDate, updated.PubDate.In(time.UTC), "the publication date should be kept")
+	s.ElementsMatch([]string{"Computer Science", "Databases"}, updated.Categories)
+	s.ElementsMatch(testBook.Authors, updated.Authors, "the authors should be kept")
+}
+
+func (s *TestStoreSuite) Test_Update_NotFound() {
+	ctx := context.Background()
+	err := prepareTestData(s.testContainer, "testdata/book_all_relations.sql")
+	s.Require().NoError(err, "failed to load test SQL file")
+
+	pages := uint16(512)
+	err = s.store.Update(ctx, 100, Update{Pages: &pages})
+	s.Require().ErrorIs(err, ErrNotFound)
+}
+
+func (s *TestStoreSuite) Test_UpsertBatch() {
+	ctx := context.Background()
+	err := prepareTestData(s.testContainer, "testdata/book_lookup_filter.sql")
+	s.Require().NoError(err, "failed to load test SQL file")
+
+	pubDate := time.Date(2022, time.July, 19, 0, 0, 0, 0, time.UTC)
+	books := []Book{
+		{Title: "Book 01 Updated", ISBN13: 9781111111111, Pages: 300, Tags: []string{"golang"}},
+		{Title: "Book 02", ASIN: "BH22222222"},
+		{Title: "Book 04", ISBN10: "4444444444", Publisher: "Manning", Language: "English", PubDate: pubDate,
+			Authors: []string{"Jane Roe"}},
+		{Title: "Conflict", ISBN13: 9781111111111, ASIN: "BH22222222"},
+		{Title: "Book 05", ISBN10: "5555555555"},
+	}
+
+	results, err := s.store.UpsertBatch(ctx, books, false)
+	s.Require().NoError(err)
+	s.Require().Len(results, len(books))
+	s.Equal(UpsertResult{BookID: 1, Status: UpsertUpdated}, results[0])
+	s.Equal(UpsertResult{BookID: 2, Status: UpsertSkipped}, results[1])
+	s.Equal(UpsertCreated, results[2].Status)
+	s.ErrorIs(results[3].Err, ErrIdentifierConflict)
+	s.ErrorIs(results[4].Err, ErrIncompleteBook)
+
+	updated, err := s.store.GetByID(ctx, 1)
+	s.Require().NoError(err)
+	s.Equal("Book 01 Updated", updated.Title)
+	s.Equal(uint16(300), updated.Pages)
+	s.Equal("Book 01 Subtitle", updated.Subtitle, "the missing values should be kept")
+	s.Equal([]string{"golang"}, updated.Tags)
+	s.ElementsMatch([]string{"John Doe", "Amanda Lee"}, updated.Authors, "the missing relations should be kept")
+
+	created, err := s.store.GetByID(ctx, results[2].BookID)
+	s.Require().NoError(err)
+	s.Equal("Book 04", created.Title)
+	s.Equal("Manning", created.Publisher)
+	s.Equal([]string{"Jane Roe"}, created.Authors)
+}
+
+func (s *TestStoreSuite) Test_UpsertBatch_DryRun() {
+	ctx := context.Background()
+	err := prepareTestData(s.testContainer, "testdata/book_lookup_filter.sql")
+	s.Require().NoError(err, "failed to load test SQL file")
+
+	results, err := s.store.UpsertBatch(ctx, []Book{
+		{Title: "Book 01 Updated", ISBN13: 9781111111111},
+		{Title: "Book 04", ISBN10: "4444444444", Publisher: "Manning", Language: "English"},
+	}, true)
+	s.Require().NoError(err)
+	s.Equal(UpsertUpdated, results[0].Status)
+	s.Equal(UpsertCreated, results[1].Status)
+
+	book, err := s.store.GetByID(ctx, 1)
+	s.Require().NoError(err)
+	s.Equal("Book 01", book.Title, "nothing should be changed in the dry-run mode")
+	_, err = s.store.FindIDByIdentifiers(ctx, Identifiers{ISBN10: "4444444444"})
+	s.Require().ErrorIs(err, ErrNotFound, "nothing should be created in the dry-run mode")
+}
+
+func (s *TestStoreSuite) Test_FindIDByIdentifiers() {
+	ctx := context.Background()
+	err := prepareTestData(s.testContainer, "testdata/book_all_relations.sql")
+	s.Require().NoError(err, "failed to load test SQL file")
+
+	for _, identifiers := range []Identifiers{
+		{ISBN13: bookISBN13},
+		{ISBN10: bookISBN10},
+		{ASIN: bookASIN},
+		{ISBN10: "0000000000", ISBN13: bookISBN13},
+	} {
+		foundID, err := s.store.FindIDByIdentifiers(ctx, identifiers)
+		s.Require().NoError(err, "failed to find book by %v", identifiers)
+		s.Equal(bookID, foundID)
+	}
+
+	_, err = s.store.FindIDByIdentifiers(ctx, Identifiers{ISBN10: "0000000000"})
+	s.ErrorIs(err, ErrNotFound)
+	_, err = s.store.FindIDByIdentifiers(ctx, Identifiers{})
+	s.ErrorIs(err, ErrNotFound)
+}
+
 func performLookupRequest(s *TestStoreSuite, requestValues map[string][]string) (
 	[]LookupItem, int64, error) {
 
@@ -272,6 +687,16 @@ func performLookupRequest(s *TestStoreSuite, requestValues map[string][]string)
 	return s.store.Lookup(ctx, pageRequest, sort, filter)
 }
 
+// advanceSequences - moves the ID sequences past the IDs, explicitly set by the test data
+func advanceSequences(s *TestStoreSuite) {
+	for _, sequence := range []string{"books", "languages", "publishers", "authors", "categories", "file_types",
+		"tags"} {
+
+		_, err := s.db.Exec("SELECT setval('ebook." + sequence + "_id_seq', 100)")
+		s.Require().NoError(err, "failed to advance sequence")
+	}
+}
+
 func prepareTestData(testContainer *postgres.PostgresContainer, fileName string) error {
 	file, err := os.ReadFile(fileName)
 	if err != nil {
@@ -0,0 +1,33 @@
+INSERT INTO ebook.publishers (id, name) VALUES (1, 'OReilly');
+INSERT INTO ebook.languages (id, name) VALUES (1, 'English');
+INSERT INTO ebook.authors (id, name) VALUES (1, 'John Doe'), (2, 'Amanda Lee');
+INSERT INTO ebook.categories (id, name, parent_id) VALUES (1, 'Computer Science', null);
+INSERT INTO ebook.file_types (id, name) VALUES (1, 'pdf'), (2, 'epub');
+INSERT INTO ebook.tags (id, name) VALUES (1, 'programming'), (2, 'database');
+
+-- the target book: no ISBN-10, no cover, the PDF file only
+INSERT INTO ebook.books (id, title, subtitle, description, isbn10, isbn13, asin, pages, edition,
+                         language_id, publisher_id, publisher_url, pub_date, book_file_name, book_file_size, cover_file_name)
+VALUES (1, 'CockroachDB', NULL, 'Short', NULL, 9781617291784, NULL, 0, 2, 1, 1, '', '2022-07-19',
+        'CockroachDB.pdf', 5192, '');
+INSERT INTO ebook.book_author (book_id, author_id) VALUES (1, 1);
+INSERT INTO ebook.book_file_type (book_id, file_type_id) VALUES (1, 1);
+INSERT INTO ebook.book_tag (book_id, tag_id) VALUES (1, 1);
+INSERT INTO ebook.book_files (book_id, file_type_id, object_key, size, sha256, content_type)
+VALUES (1, 1, 'books/1/book.pdf', 5192, REPEAT('a', 64), 'application/pdf');
+
+-- the source book: the richer metadata, the cover, the PDF and EPUB files
+INSERT INTO ebook.covers (hash, size, mime_type, width, height) VALUES (REPEAT('c', 64), 100, 'image/jpeg', 300, 400);
+INSERT INTO ebook.books (id, title, subtitle, description, isbn10, isbn13, asin, pages, edition,
+                         language_id, publisher_id, publisher_url, pub_date, book_file_name, book_file_size,
+                         cover_file_name, cover_hash, cover_width, cover_height)
+VALUES (2, 'CockroachDB (2nd)', 'The Definitive Guide', 'Get the lowdown on CockroachDB', '1617291781', NULL,
+        'BH34567890', 256, 2, 1, 1, 'https://amazon.com/dp/1617291781.html', '2022-07-19',
+        'CockroachDB.epub', 4096, '1617291781.jpg', REPEAT('c', 64), 300, 400);
+INSERT INTO ebook.book_author (book_id, author_id) VALUES (2, 1), (2, 2);
+INSERT INTO ebook.book_category (book_id, category_id) VALUES (2, 1);
+INSERT INTO ebook.book_file_type (book_id, file_type_id) VALUES (2, 1), (2, 2);
+INSERT INTO ebook.book_tag (book_id, tag_id) VALUES (2, 1), (2, 2);
+INSERT INTO ebook.book_files (book_id, file_type_id, object_key, size, sha256, content_type)
+VALUES (2, 1, 'books/2/book.pdf', 6000, REPEAT('b', 64), 'application/pdf'),
+       (2, 2, 'books/2/book.epub', 4096, REPEAT('d', 64), 'application/epub+zip');
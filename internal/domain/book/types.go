@@ -2,10 +2,19 @@ package book
 
 import (
 	"database/sql"
+	"fmt"
 	"github.com/lib/pq"
+	"github.com/sdreger/lib-manager-go/internal/isbn"
+	"strconv"
 	"time"
 )
 
+const (
+	UpsertCreated = "created"
+	UpsertUpdated = "updated"
+	UpsertSkipped = "skipped"
+)
+
 var (
 	AllowedSortFields = []string{
 		"id", "title", "subtitle", "isbn10", "isbn13", "asin", "pages", "edition",
@@ -14,92 +23,297 @@ var (
 )
 
 type Book struct {
-	ID            int64     `json:"id"`
-	Title         string    `json:"title"`
-	Subtitle      string    `json:"subtitle"`
-	Description   string    `json:"description"`
-	ISBN10        string    `json:"isbn10"`
-	ISBN13        int64     `json:"isbn13"`
-	ASIN          string    `json:"asin"`
-	Pages         uint16    `json:"pages"`
-	PublisherURL  string    `json:"publisher_url"`
-	Edition       uint8     `json:"edition"`
-	PubDate       time.Time `json:"pub_date"`
-	BookFileName  string    `json:"book_file_name"`
-	BookFileSize  int64     `json:"book_file_size"`
-	CoverFileName string    `json:"cover_file_name"`
-	Language      string    `json:"language"`
-	Publisher     string    `json:"publisher"`
-	Authors       []string  `json:"authors"`
-	Categories    []string  `json:"categories"`
-	FileTypes     []string  `json:"file_types"`
-	Tags          []string  `json:"tags"`
-	CreatedAt     time.Time `json:"created_at"`
-	UpdatedAt     time.Time `json:"updated_at"`
+	ID                 int64     `json:"id"`
+	Title              string    `json:"title"`
+	Subtitle           string    `json:"subtitle"`
+	Description        string    `json:"description"`
+	ISBN10             string    `json:"isbn10"`
+	ISBN13             int64     `json:"isbn13"`
+	ASIN               string    `json:"asin"`
+	Pages              uint16    `json:"pages"`
+	PublisherURL       string    `json:"publisher_url"`
+	Edition            uint8     `json:"edition"`
+	PubDate            time.Time `json:"pub_date"`
+	BookFileName       string    `json:"book_file_name"`
+	BookFileSize       int64     `json:"book_file_size"`
+	CoverFileName      string    `json:"cover_file_name"`
+	CoverHash          string    `json:"cover_hash"`
+	CoverWidth         int       `json:"cover_width"`
+	CoverHeight        int       `json:"cover_height"`
+	CoverAspectRatio   float64   `json:"cover_aspect_ratio"`
+	CoverDominantColor string    `json:"cover_dominant_color"`
+	CoverBlurHash      string    `json:"cover_blurhash"`
+	Language           string    `json:"language"`
+	Publisher          string    `json:"publisher"`
+	Authors            []string  `json:"authors"`
+	Categories         []string  `json:"categories"`
+	FileTypes          []string  `json:"file_types"`
+	Tags               []string  `json:"tags"`
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
+	// Deleted - the book is soft-deleted, e.g. merged into another one, it is only returned by the change query
+	Deleted bool `json:"-"`
+}
+
+// Identifiers - the unique book identifiers, the empty ones are not matched
+type Identifiers struct {
+	ISBN10 string
+	ISBN13 int64
+	ASIN   string
+}
+
+func (i Identifiers) isEmpty() bool {
+	return i.ISBN10 == "" && i.ISBN13 == 0 && i.ASIN == ""
+}
+
+// complete - normalizes the ISBN-10, and fills in the missing ISBN form, so a book stored with either form is matched.
+// The invalid values are kept as is, they can only match exactly
+func (i Identifiers) complete() Identifiers {
+	i.ISBN10 = isbn.Normalize(i.ISBN10)
+	if i.ISBN13 == 0 && isbn.IsValid10(i.ISBN10) {
+		i.ISBN13 = isbn13Number(i.ISBN10)
+	}
+	if i.ISBN10 == "" && i.ISBN13 > 0 {
+		i.ISBN10, _ = isbn.To10(strconv.FormatInt(i.ISBN13, 10))
+	}
+
+	return i
+}
+
+// normalizeISBNs - validates the book ISBN values, and fills in the missing ISBN form, if it can be derived
+func normalizeISBNs(book Book) (Book, error) {
+	if book.ISBN10 != "" {
+		value := isbn.Normalize(book.ISBN10)
+		if !isbn.IsValid10(value) {
+			return Book{}, fmt.Errorf("%w: %q", ErrInvalidISBN, book.ISBN10)
+		}
+		book.ISBN10 = value
+	}
+	if book.ISBN13 != 0 && !isbn.IsValid13(strconv.FormatInt(book.ISBN13, 10)) {
+		return Book{}, fmt.Errorf("%w: %d", ErrInvalidISBN, book.ISBN13)
+	}
+
+	if book.ISBN13 == 0 && book.ISBN10 != "" {
+		book.ISBN13 = isbn13Number(book.ISBN10)
+	}
+	if book.ISBN10 == "" && book.ISBN13 != 0 {
+		// the '979' prefixed values have no ISBN-10 form
+		book.ISBN10, _ = isbn.To10(strconv.FormatInt(book.ISBN13, 10))
+	}
+
+	return book, nil
+}
+
+// isbn13Number - converts the valid ISBN-10 to the numeric ISBN-13, the way it is stored
+func isbn13Number(isbn10 string) int64 {
+	isbn13, err := isbn.To13(isbn10)
+	if err != nil {
+		return 0
+	}
+	number, _ := strconv.ParseInt(isbn13, 10, 64)
+
+	return number
+}
+
+// Update - the partial book metadata update, the nil fields are kept as is.
+// The categories replace the current ones when not nil
+type Update struct {
+	Subtitle    *string
+	Description *string
+	Pages       *uint16
+	PubDate     *time.Time
+	Categories  []string
+}
+
+func (u Update) isEmpty() bool {
+	return u.Subtitle == nil && u.Description == nil && u.Pages == nil && u.PubDate == nil && u.Categories == nil
+}
+
+// ChangeQuery - selects the books, matching the filter, updated within the time range, in the update time order.
+// The zero time bounds are not applied. The books after the keyset position are returned, so the consecutive
+// pages neither skip nor repeat a book, unless it is updated in between
+type ChangeQuery struct {
+	Filter Filter
+	// From, Until - the inclusive update time range
+	From  time.Time
+	Until time.Time
+	// AfterUpdatedAt, AfterID - the keyset position, the update time and the ID of the last returned book
+	AfterUpdatedAt time.Time
+	AfterID        int64
+	Limit          uint64
+	// WithDeleted - the soft-deleted books are returned as well, the deletion time is their update time
+	WithDeleted bool
+}
+
+// UpsertResult - the outcome of a single book upsert, the failed upserts have the error set
+type UpsertResult struct {
+	BookID int64
+	Status string
+	Err    error
 }
 
 type bookEntity struct {
-	ID            int64          `db:"id"`
-	Title         string         `db:"title"`
-	Subtitle      sql.NullString `db:"subtitle"`
-	Description   sql.NullString `db:"description"`
-	ISBN10        sql.NullString `db:"isbn10"`
-	ISBN13        sql.NullInt64  `db:"isbn13"`
-	ASIN          sql.NullString `db:"asin"`
-	Pages         uint16         `db:"pages"`
-	PublisherURL  string         `db:"publisher_url"`
-	Edition       uint8          `db:"edition"`
-	PubDate       time.Time      `db:"pub_date"`
-	BookFileName  string         `db:"book_file_name"`
-	BookFileSize  int64          `db:"book_file_size"`
-	CoverFileName string         `db:"cover_file_name"`
-	Language      string         `db:"language"`
-	Publisher     string         `db:"publisher"`
-	Authors       pq.StringArray `db:"authors"`
-	Categories    pq.StringArray `db:"categories"`
-	FileTypes     pq.StringArray `db:"file_types"`
-	Tags          pq.StringArray `db:"tags"`
-	CreatedAt     time.Time      `db:"created_at"`
-	UpdatedAt     time.Time      `db:"updated_at"`
+	ID                 int64           `db:"id"`
+	Title              string          `db:"title"`
+	Subtitle           sql.NullString  `db:"subtitle"`
+	Description        sql.NullString  `db:"description"`
+	ISBN10             sql.NullString  `db:"isbn10"`
+	ISBN13             sql.NullInt64   `db:"isbn13"`
+	ASIN               sql.NullString  `db:"asin"`
+	Pages              uint16          `db:"pages"`
+	PublisherURL       string          `db:"publisher_url"`
+	Edition            uint8           `db:"edition"`
+	PubDate            time.Time       `db:"pub_date"`
+	BookFileName       string          `db:"book_file_name"`
+	BookFileSize       int64           `db:"book_file_size"`
+	CoverFileName      string          `db:"cover_file_name"`
+	CoverHash          sql.NullString  `db:"cover_hash"`
+	CoverWidth         sql.NullInt32   `db:"cover_width"`
+	CoverHeight        sql.NullInt32   `db:"cover_height"`
+	CoverAspectRatio   sql.NullFloat64 `db:"cover_aspect_ratio"`
+	CoverDominantColor sql.NullString  `db:"cover_dominant_color"`
+	CoverBlurHash      sql.NullString  `db:"cover_blurhash"`
+	Language           string          `db:"language"`
+	Publisher          string          `db:"publisher"`
+	Authors            pq.StringArray  `db:"authors"`
+	Categories         pq.StringArray  `db:"categories"`
+	FileTypes          pq.StringArray  `db:"file_types"`
+	Tags               pq.StringArray  `db:"tags"`
+	CreatedAt          time.Time       `db:"created_at"`
+	UpdatedAt          time.Time       `db:"updated_at"`
+	DeletedAt          sql.NullTime    `db:"deleted_at"`
 }
 
 type LookupItem struct {
-	ID            int64     `json:"id"`
-	Title         string    `json:"title"`
-	Subtitle      string    `json:"subtitle"`
-	ISBN10        string    `json:"isbn10"`
-	ISBN13        int64     `json:"isbn13"`
-	ASIN          string    `json:"asin"`
-	Pages         uint16    `json:"pages"`
-	Edition       uint8     `json:"edition"`
-	PubDate       time.Time `json:"pub_date"`
-	BookFileSize  int64     `json:"book_file_size"`
-	CoverFileName string    `json:"cover_file_name"`
-	Publisher     string    `json:"publisher"`
-	Language      string    `json:"language"`
-	AuthorIDs     []int64   `json:"author_ids"`
-	CategoryIDs   []int64   `json:"category_ids"`
-	FileTypeIDs   []int64   `json:"file_type_ids"`
-	TagIDs        []int64   `json:"tag_ids"`
+	ID                 int64     `json:"id"`
+	Title              string    `json:"title"`
+	Subtitle           string    `json:"subtitle"`
+	ISBN10             string    `json:"isbn10"`
+	ISBN13             int64     `json:"isbn13"`
+	ASIN               string    `json:"asin"`
+	Pages              uint16    `json:"pages"`
+	Edition            uint8     `json:"edition"`
+	PubDate            time.Time `json:"pub_date"`
+	BookFileSize       int64     `json:"book_file_size"`
+	CoverFileName      string    `json:"cover_file_name"`
+	CoverHash          string    `json:"cover_hash"`
+	CoverWidth         int       `json:"cover_width"`
+	CoverHeight        int       `json:"cover_height"`
+	CoverAspectRatio   float64   `json:"cover_aspect_ratio"`
+	CoverDominantColor string    `json:"cover_dominant_color"`
+	CoverBlurHash      string    `json:"cover_blurhash"`
+	Publisher          string    `json:"publisher"`
+	Language           string    `json:"language"`
+	AuthorIDs          []int64   `json:"author_ids"`
+	CategoryIDs        []int64   `json:"category_ids"`
+	FileTypeIDs        []int64   `json:"file_type_ids"`
+	TagIDs             []int64   `json:"tag_ids"`
 }
 
 type lookupEntity struct {
-	ID            int64          `db:"id"`
+	ID                 int64           `db:"id"`
+	Title              string          `db:"title"`
+	Subtitle           sql.NullString  `db:"subtitle"`
+	ISBN10             sql.NullString  `db:"isbn10"`
+	ISBN13             sql.NullInt64   `db:"isbn13"`
+	ASIN               sql.NullString  `db:"asin"`
+	Pages              uint16          `db:"pages"`
+	Edition            uint8           `db:"edition"`
+	PubDate            time.Time       `db:"pub_date"`
+	BookFileSize       int64           `db:"book_file_size"`
+	CoverFileName      string          `db:"cover_file_name"`
+	CoverHash          sql.NullString  `db:"cover_hash"`
+	CoverWidth         sql.NullInt32   `db:"cover_width"`
+	CoverHeight        sql.NullInt32   `db:"cover_height"`
+	CoverAspectRatio   sql.NullFloat64 `db:"cover_aspect_ratio"`
+	CoverDominantColor sql.NullString  `db:"cover_dominant_color"`
+	CoverBlurHash      sql.NullString  `db:"cover_blurhash"`
+	Publisher          string          `db:"publisher"`
+	Language           string          `db:"language"`
+	AuthorIDs          pq.Int64Array   `db:"author_ids"`
+	CategoryIDs        pq.Int64Array   `db:"category_ids"`
+	FileTypeIDs        pq.Int64Array   `db:"file_types_ids"`
+	TagIDs             pq.Int64Array   `db:"tag_ids"`
+	Total              int64           `db:"total"`
+}
+
+// relationLookupEntity - the lookup item of a relation item books page
+type relationLookupEntity struct {
+	ItemID int64 `db:"item_id"`
+	lookupEntity
+}
+
+// mergeEntity - the 'ebook.books' table row columns, filled in from the merged books.
+// The title, the publication date, the language and the publisher are always kept
+type mergeEntity struct {
+	ID                 int64           `db:"id"`
+	PublisherID        int64           `db:"publisher_id"`
+	Subtitle           sql.NullString  `db:"subtitle"`
+	Description        string          `db:"description"`
+	ISBN10             sql.NullString  `db:"isbn10"`
+	ISBN13             sql.NullInt64   `db:"isbn13"`
+	ASIN               sql.NullString  `db:"asin"`
+	Pages              uint16          `db:"pages"`
+	PublisherURL       string          `db:"publisher_url"`
+	Edition            uint8           `db:"edition"`
+	BookFileName       string          `db:"book_file_name"`
+	BookFileSize       int64           `db:"book_file_size"`
+	CoverFileName      string          `db:"cover_file_name"`
+	CoverHash          sql.NullString  `db:"cover_hash"`
+	CoverWidth         sql.NullInt32   `db:"cover_width"`
+	CoverHeight        sql.NullInt32   `db:"cover_height"`
+	CoverAspectRatio   sql.NullFloat64 `db:"cover_aspect_ratio"`
+	CoverDominantColor sql.NullString  `db:"cover_dominant_color"`
+	CoverBlurHash      sql.NullString  `db:"cover_blurhash"`
+}
+
+// createEntity - the 'ebook.books' table row of a new book, the relations are stored separately
+type createEntity struct {
 	Title         string         `db:"title"`
 	Subtitle      sql.NullString `db:"subtitle"`
+	Description   string         `db:"description"`
 	ISBN10        sql.NullString `db:"isbn10"`
 	ISBN13        sql.NullInt64  `db:"isbn13"`
 	ASIN          sql.NullString `db:"asin"`
 	Pages         uint16         `db:"pages"`
+	LanguageID    int64          `db:"language_id"`
+	PublisherID   int64          `db:"publisher_id"`
+	PublisherURL  string         `db:"publisher_url"`
 	Edition       uint8          `db:"edition"`
 	PubDate       time.Time      `db:"pub_date"`
+	BookFileName  string         `db:"book_file_name"`
 	BookFileSize  int64          `db:"book_file_size"`
 	CoverFileName string         `db:"cover_file_name"`
-	Publisher     string         `db:"publisher"`
-	Language      string         `db:"language"`
-	AuthorIDs     pq.Int64Array  `db:"author_ids"`
-	CategoryIDs   pq.Int64Array  `db:"category_ids"`
-	FileTypeIDs   pq.Int64Array  `db:"file_types_ids"`
-	TagIDs        pq.Int64Array  `db:"tag_ids"`
-	Total         int64          `db:"total"`
+	CoverHash     sql.NullString `db:"cover_hash"`
+	CoverWidth    sql.NullInt32  `db:"cover_width"`
+	CoverHeight   sql.NullInt32  `db:"cover_height"`
+}
+
+// updateEntity - the 'ebook.books' table row of an imported book, the file and the cover columns are kept
+type updateEntity struct {
+	ID int64 `db:"id"`
+	createEntity
+}
+
+func newCreateEntity(book Book, languageID int64, publisherID int64) createEntity {
+	return createEntity{
+		Title:         book.Title,
+		Subtitle:      sql.NullString{String: book.Subtitle, Valid: book.Subtitle != ""},
+		Description:   book.Description,
+		ISBN10:        sql.NullString{String: book.ISBN10, Valid: book.ISBN10 != ""},
+		ISBN13:        sql.NullInt64{Int64: book.ISBN13, Valid: book.ISBN13 > 0},
+		ASIN:          sql.NullString{String: book.ASIN, Valid: book.ASIN != ""},
+		Pages:         book.Pages,
+		LanguageID:    languageID,
+		PublisherID:   publisherID,
+		PublisherURL:  book.PublisherURL,
+		Edition:       book.Edition,
+		PubDate:       book.PubDate,
+		BookFileName:  book.BookFileName,
+		BookFileSize:  book.BookFileSize,
+		CoverFileName: book.CoverFileName,
+		CoverHash:     sql.NullString{String: book.CoverHash, Valid: book.CoverHash != ""},
+		CoverWidth:    sql.NullInt32{Int32: int32(book.CoverWidth), Valid: book.CoverWidth > 0},
+		CoverHeight:   sql.NullInt32{Int32: int32(book.CoverHeight), Valid: book.CoverHeight > 0},
+	}
 }
@@ -0,0 +1,10 @@
+package bookfile
+
+import "errors"
+
+var (
+	ErrNotFound         = errors.New("entry not found")
+	ErrUnknownFileType  = errors.New("unknown book file type")
+	ErrInvalidChecksum  = errors.New("the checksum should be a hex-encoded SHA-256 digest")
+	ErrChecksumMismatch = errors.New("the uploaded file checksum does not match the provided one")
+)
@@ -0,0 +1,162 @@
+package bookfile
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"github.com/jmoiron/sqlx"
+	"github.com/sdreger/lib-manager-go/internal/blobtstore"
+	"io"
+	"log/slog"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	fileTypeRegexp = regexp.MustCompile(`^[a-z0-9]+$`)
+	checksumRegexp = regexp.MustCompile(`^[a-f0-9]{64}$`)
+)
+
+type Store interface {
+	GetFileTypeID(ctx context.Context, fileType string) (int64, error)
+	GetBookFileName(ctx context.Context, bookID int64) (string, error)
+	GetBookFile(ctx context.Context, bookID int64, fileType string) (bookFileEntity, error)
+	SaveBookFile(ctx context.Context, entity bookFileEntity) (time.Time, string, error)
+}
+
+type BlobStore interface {
+	GetBookFile(ctx context.Context, filePath string) (io.ReadSeekCloser, blobtstore.Object, error)
+	PutBookFile(ctx context.Context, filePath string, reader io.Reader, size int64, contentType string) error
+	DeleteBookFile(ctx context.Context, filePath string) error
+	MoveBookFile(ctx context.Context, srcPath string, dstPath string) error
+}
+
+// UploadRequest - the book file upload details. The checksum is optional, if provided,
+// the upload is rejected unless the received content matches it
+type UploadRequest struct {
+	BookID      int64
+	FileType    string
+	Content     io.Reader
+	Size        int64
+	ContentType string
+	Checksum    string
+}
+
+type Service struct {
+	logger    *slog.Logger
+	store     Store
+	blobStore BlobStore
+}
+
+func NewService(logger *slog.Logger, db *sqlx.DB, blobStore BlobStore) *Service {
+	return &Service{
+		logger:    logger,
+		store:     NewDBStore(db),
+		blobStore: blobStore,
+	}
+}
+
+// UploadBookFile - stores the book file content, replacing the existing one of the same type.
+// The content is uploaded to a staging path first, and is only moved to its own object once its checksum is
+// verified. The description is switched to the new object, then the replaced object is removed
+func (s *Service) UploadBookFile(ctx context.Context, request UploadRequest) (BookFile, error) {
+	fileType := strings.ToLower(request.FileType)
+	if !fileTypeRegexp.MatchString(fileType) {
+		return BookFile{}, ErrUnknownFileType
+	}
+	checksum := strings.ToLower(request.Checksum)
+	if checksum != "" && !checksumRegexp.MatchString(checksum) {
+		return BookFile{}, ErrInvalidChecksum
+	}
+
+	fileTypeID, err := s.store.GetFileTypeID(ctx, fileType)
+	if err != nil {
+		return BookFile{}, err
+	}
+	bookFileName, err := s.store.GetBookFileName(ctx, request.BookID)
+	if err != nil {
+		return BookFile{}, err
+	}
+
+	uploadID := rand.Text()
+	stagingPath := stagingObjectPath(request.BookID, fileType, uploadID)
+	hash := sha256.New()
+	counter := &countingReader{reader: io.TeeReader(request.Content, hash)}
+	entity := bookFileEntity{
+		BookID:       request.BookID,
+		FileTypeID:   fileTypeID,
+		FileType:     fileType,
+		BookFileName: bookFileName,
+		ObjectKey:    ObjectPath(request.BookID, fileType, uploadID),
+		ContentType:  contentType(fileType, request.ContentType),
+	}
+	if err := s.blobStore.PutBookFile(ctx, stagingPath, counter, request.Size, entity.ContentType); err != nil {
+		return BookFile{}, err
+	}
+
+	entity.Size = counter.count
+	entity.SHA256 = hex.EncodeToString(hash.Sum(nil))
+	if checksum != "" && checksum != entity.SHA256 {
+		s.deleteObject(ctx, stagingPath)
+		return BookFile{}, ErrChecksumMismatch
+	}
+	if err := s.blobStore.MoveBookFile(ctx, stagingPath, entity.ObjectKey); err != nil {
+		s.deleteObject(ctx, stagingPath)
+		return BookFile{}, err
+	}
+
+	var previousKey string
+	entity.UpdatedAt, previousKey, err = s.store.SaveBookFile(ctx, entity)
+	if err != nil {
+		s.deleteObject(ctx, entity.ObjectKey)
+		return BookFile{}, err
+	}
+	if previousKey != "" && previousKey != entity.ObjectKey {
+		s.deleteObject(ctx, previousKey)
+	}
+
+	return entity.toBookFile(), nil
+}
+
+// GetBookFile - returns the book file description along with its content, which must be closed by the caller
+func (s *Service) GetBookFile(ctx context.Context, bookID int64, fileType string) (BookFileContent, error) {
+	entity, err := s.store.GetBookFile(ctx, bookID, fileType)
+	if err != nil {
+		return BookFileContent{}, err
+	}
+
+	content, _, err := s.blobStore.GetBookFile(ctx, entity.ObjectKey)
+	if errors.Is(err, blobtstore.ErrObjectNotFound) {
+		s.logger.Warn("book file object is missing", "bookID", bookID, "path", entity.ObjectKey)
+		return BookFileContent{}, ErrNotFound
+	}
+	if err != nil {
+		return BookFileContent{}, err
+	}
+
+	return BookFileContent{BookFile: entity.toBookFile(), Content: content}, nil
+}
+
+// deleteObject - removes the staging, the unsaved or the replaced book file object.
+// The failure is only logged, since the stored description is consistent either way
+func (s *Service) deleteObject(ctx context.Context, filePath string) {
+	if err := s.blobStore.DeleteBookFile(ctx, filePath); err != nil {
+		s.logger.Error("book file object removal failed: "+err.Error(), "path", filePath)
+	}
+}
+
+// countingReader - counts the bytes read, since the upload size is not always known in advance
+type countingReader struct {
+	reader io.Reader
+	count  int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.count += int64(n)
+
+	return n, err
+}
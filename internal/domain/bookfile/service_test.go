@@ -0,0 +1,248 @@
+package bookfile
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"github.com/sdreger/lib-manager-go/internal/blobtstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	testBookFileContent = "PK\x03\x04 epub content"
+)
+
+func TestService_UploadBookFile(t *testing.T) {
+	ctx := context.Background()
+	sum := sha256.Sum256([]byte(testBookFileContent))
+	checksum := hex.EncodeToString(sum[:])
+	updatedAt := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	mockStore := NewMockStore(t)
+	mockStore.EXPECT().GetFileTypeID(ctx, "epub").Return(2, nil).Once()
+	mockStore.EXPECT().GetBookFileName(ctx, int64(1)).Return("OReilly.Book.01.zip", nil).Once()
+	var stagingPath, objectKey string
+	mockStore.EXPECT().SaveBookFile(ctx, mock.Anything).
+		RunAndReturn(func(_ context.Context, entity bookFileEntity) (time.Time, string, error) {
+			assert.Equal(t, bookFileEntity{
+				BookID:       1,
+				FileTypeID:   2,
+				FileType:     "epub",
+				BookFileName: "OReilly.Book.01.zip",
+				ObjectKey:    objectKey,
+				Size:         int64(len(testBookFileContent)),
+				SHA256:       checksum,
+				ContentType:  "application/epub+zip",
+			}, entity, "the description should refer to the moved object")
+			return updatedAt, "1/epub/previous", nil
+		}).Once()
+
+	mockBlobStore := NewMockBlobStore(t)
+	mockBlobStore.EXPECT().PutBookFile(ctx, mock.Anything, mock.Anything, int64(-1), "application/epub+zip").
+		RunAndReturn(func(_ context.Context, filePath string, reader io.Reader, _ int64, _ string) error {
+			stagingPath = filePath
+			_, err := io.Copy(io.Discard, reader)
+			return err
+		}).Once()
+	mockBlobStore.EXPECT().MoveBookFile(ctx, mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, srcPath string, dstPath string) error {
+			assert.Equal(t, stagingPath, srcPath)
+			objectKey = dstPath
+			return nil
+		}).Once()
+	mockBlobStore.EXPECT().DeleteBookFile(ctx, "1/epub/previous").Return(nil).Once()
+	service := getService(t, mockStore, mockBlobStore)
+
+	bookFile, err := service.UploadBookFile(ctx, UploadRequest{
+		BookID:      1,
+		FileType:    "EPUB",
+		Content:     strings.NewReader(testBookFileContent),
+		Size:        -1,
+		ContentType: "application/octet-stream",
+		Checksum:    strings.ToUpper(checksum),
+	})
+	require.NoError(t, err, "should upload book file")
+	assert.True(t, strings.HasPrefix(stagingPath, "staging/1/epub."), "should upload to a staging path")
+	assert.Equal(t, "1/epub/"+strings.TrimPrefix(stagingPath, "staging/1/epub."), objectKey,
+		"should move to the object of the upload")
+	assert.Equal(t, BookFile{
+		BookID:      1,
+		FileType:    "epub",
+		FileName:    "OReilly.Book.01.epub",
+		Size:        int64(len(testBookFileContent)),
+		SHA256:      checksum,
+		ContentType: "application/epub+zip",
+		UpdatedAt:   updatedAt,
+	}, bookFile)
+}
+
+func TestService_UploadBookFile_ChecksumMismatch(t *testing.T) {
+	ctx := context.Background()
+
+	mockStore := NewMockStore(t)
+	mockStore.EXPECT().GetFileTypeID(ctx, "pdf").Return(1, nil).Once()
+	mockStore.EXPECT().GetBookFileName(ctx, int64(1)).Return("", nil).Once()
+	mockBlobStore := NewMockBlobStore(t)
+	mockBlobStore.EXPECT().PutBookFile(ctx, mock.Anything, mock.Anything, int64(3), "application/pdf").
+		RunAndReturn(func(_ context.Context, _ string, reader io.Reader, _ int64, _ string) error {
+			_, err := io.Copy(io.Discard, reader)
+			return err
+		}).Once()
+	mockBlobStore.EXPECT().DeleteBookFile(ctx, mock.Anything).Return(nil).Once()
+	service := getService(t, mockStore, mockBlobStore)
+
+	_, err := service.UploadBookFile(ctx, UploadRequest{
+		BookID:   1,
+		FileType: "pdf",
+		Content:  strings.NewReader("pdf"),
+		Size:     3,
+		Checksum: strings.Repeat("a", 64),
+	})
+	require.ErrorIs(t, err, ErrChecksumMismatch)
+}
+
+func TestService_UploadBookFile_SaveFailed(t *testing.T) {
+	ctx := context.Background()
+	expectedError := errors.New("some error")
+
+	mockStore := NewMockStore(t)
+	mockStore.EXPECT().GetFileTypeID(ctx, "pdf").Return(1, nil).Once()
+	mockStore.EXPECT().GetBookFileName(ctx, int64(1)).Return("", nil).Once()
+	mockStore.EXPECT().SaveBookFile(ctx, mock.Anything).Return(time.Time{}, "", expectedError).Once()
+	var objectKey string
+	mockBlobStore := NewMockBlobStore(t)
+	mockBlobStore.EXPECT().PutBookFile(ctx, mock.Anything, mock.Anything, int64(3), "application/pdf").
+		RunAndReturn(func(_ context.Context, _ string, reader io.Reader, _ int64, _ string) error {
+			_, err := io.Copy(io.Discard, reader)
+			return err
+		}).Once()
+	mockBlobStore.EXPECT().MoveBookFile(ctx, mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, _ string, dstPath string) error {
+			objectKey = dstPath
+			return nil
+		}).Once()
+	mockBlobStore.EXPECT().DeleteBookFile(ctx, mock.Anything).
+		RunAndReturn(func(_ context.Context, filePath string) error {
+			assert.Equal(t, objectKey, filePath, "the unsaved object should be removed")
+			return nil
+		}).Once()
+	service := getService(t, mockStore, mockBlobStore)
+
+	_, err := service.UploadBookFile(ctx, UploadRequest{BookID: 1, FileType: "pdf", Content: strings.NewReader("pdf"),
+		Size: 3})
+	require.ErrorIs(t, err, expectedError)
+}
+
+func TestService_UploadBookFile_Validation(t *testing.T) {
+	ctx := context.Background()
+	service := getService(t, NewMockStore(t), NewMockBlobStore(t))
+
+	_, err := service.UploadBookFile(ctx, UploadRequest{BookID: 1, FileType: "../epub"})
+	assert.ErrorIs(t, err, ErrUnknownFileType)
+
+	_, err = service.UploadBookFile(ctx, UploadRequest{BookID: 1, FileType: "epub", Checksum: "abc"})
+	assert.ErrorIs(t, err, ErrInvalidChecksum)
+}
+
+func TestService_UploadBookFile_BookNotFound(t *testing.T) {
+	ctx := context.Background()
+
+	mockStore := NewMockStore(t)
+	mockStore.EXPECT().GetFileTypeID(ctx, "epub").Return(2, nil).Once()
+	mockStore.EXPECT().GetBookFileName(ctx, int64(100)).Return("", ErrNotFound).Once()
+	service := getService(t, mockStore, NewMockBlobStore(t))
+
+	_, err := service.UploadBookFile(ctx, UploadRequest{BookID: 100, FileType: "epub"})
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestService_GetBookFile(t *testing.T) {
+	ctx := context.Background()
+	content := readSeekCloser{bytes.NewReader([]byte(testBookFileContent))}
+
+	mockStore := NewMockStore(t)
+	mockStore.EXPECT().GetBookFile(ctx, int64(1), "epub").Return(bookFileEntity{
+		BookID:      1,
+		FileType:    "epub",
+		ObjectKey:   "1/epub",
+		Size:        int64(len(testBookFileContent)),
+		SHA256:      "abc",
+		ContentType: "application/epub+zip",
+	}, nil).Once()
+	mockBlobStore := NewMockBlobStore(t)
+	mockBlobStore.EXPECT().GetBookFile(ctx, "1/epub").Return(content, blobtstore.Object{}, nil).Once()
+	service := getService(t, mockStore, mockBlobStore)
+
+	bookFile, err := service.GetBookFile(ctx, 1, "epub")
+	require.NoError(t, err, "should get book file")
+	assert.Equal(t, "book-1.epub", bookFile.FileName)
+	assert.Equal(t, "application/epub+zip", bookFile.ContentType)
+	assert.Equal(t, content, bookFile.Content)
+}
+
+func TestService_GetBookFile_ObjectMissing(t *testing.T) {
+	ctx := context.Background()
+
+	mockStore := NewMockStore(t)
+	mockStore.EXPECT().GetBookFile(ctx, int64(1), "epub").Return(bookFileEntity{ObjectKey: "1/epub"}, nil).Once()
+	mockBlobStore := NewMockBlobStore(t)
+	mockBlobStore.EXPECT().GetBookFile(ctx, "1/epub").
+		Return(nil, blobtstore.Object{}, blobtstore.ErrObjectNotFound).Once()
+	service := getService(t, mockStore, mockBlobStore)
+
+	_, err := service.GetBookFile(ctx, 1, "epub")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestService_GetBookFile_Error(t *testing.T) {
+	ctx := context.Background()
+	expectedError := errors.New("some error")
+
+	mockStore := NewMockStore(t)
+	mockStore.EXPECT().GetBookFile(ctx, int64(1), "epub").Return(bookFileEntity{}, expectedError).Once()
+	service := getService(t, mockStore, NewMockBlobStore(t))
+
+	_, err := service.GetBookFile(ctx, 1, "epub")
+	assert.ErrorIs(t, err, expectedError)
+}
+
+func TestFileName(t *testing.T) {
+	assert.Equal(t, "OReilly.Book.01.pdf", fileName(1, "OReilly.Book.01.zip", "pdf"))
+	assert.Equal(t, "Book.epub", fileName(1, "Book", "epub"))
+	assert.Equal(t, "book-2.mobi", fileName(2, "", "mobi"))
+}
+
+func TestContentType(t *testing.T) {
+	assert.Equal(t, "application/pdf", contentType("pdf", "text/plain"))
+	assert.Equal(t, "application/x-custom", contentType("custom", "application/x-custom"))
+	assert.Equal(t, "application/octet-stream", contentType("custom", ""))
+	assert.Equal(t, "application/epub+zip", ContentType("epub"))
+	assert.Equal(t, "application/octet-stream", ContentType("custom"))
+}
+
+func getService(t *testing.T, store Store, blobStore BlobStore) *Service {
+	t.Helper()
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.Level(100)}))
+	service := NewService(logger, nil, blobStore)
+	service.store = store
+
+	return service
+}
+
+type readSeekCloser struct {
+	io.ReadSeeker
+}
+
+func (readSeekCloser) Close() error {
+	return nil
+}
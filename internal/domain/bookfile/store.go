@@ -0,0 +1,125 @@
+package bookfile
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/jmoiron/sqlx"
+	"time"
+)
+
+type DBStore struct {
+	db *sqlx.DB
+}
+
+func NewDBStore(db *sqlx.DB) *DBStore {
+	return &DBStore{db: db}
+}
+
+// GetFileTypeID - returns the file type ID by its case-insensitive name, or ErrUnknownFileType
+func (s *DBStore) GetFileTypeID(ctx context.Context, fileType string) (int64, error) {
+	var id int64
+	err := s.db.GetContext(ctx, &id, "SELECT id FROM ebook.file_types WHERE LOWER(name) = LOWER($1) ORDER BY id LIMIT 1",
+		fileType)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, ErrUnknownFileType
+		}
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// GetBookFileName - returns the book file name, if the book is present, otherwise returns ErrNotFound
+func (s *DBStore) GetBookFileName(ctx context.Context, bookID int64) (string, error) {
+	var bookFileName string
+	err := s.db.GetContext(ctx, &bookFileName, "SELECT book_file_name FROM ebook.books WHERE id = $1 AND deleted_at IS NULL", bookID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", ErrNotFound
+		}
+		return "", err
+	}
+
+	return bookFileName, nil
+}
+
+// GetBookFile - returns the stored book file description, or ErrNotFound
+func (s *DBStore) GetBookFile(ctx context.Context, bookID int64, fileType string) (bookFileEntity, error) {
+	query := `SELECT book_files.book_id, book_files.file_type_id, LOWER(file_types.name) AS file_type,
+       books.book_file_name, object_key, size, sha256, content_type, book_files.updated_at
+FROM ebook.book_files
+         JOIN ebook.books ON books.id = book_files.book_id
+         JOIN ebook.file_types ON file_types.id = book_files.file_type_id
+WHERE book_files.book_id = $1
+  AND LOWER(file_types.name) = LOWER($2)
+  AND books.deleted_at IS NULL`
+
+	var entity bookFileEntity
+	if err := s.db.GetContext(ctx, &entity, query, bookID, fileType); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return bookFileEntity{}, ErrNotFound
+		}
+		return bookFileEntity{}, err
+	}
+
+	return entity, nil
+}
+
+// SaveBookFile - creates or replaces the book file description, and links the file type to the book.
+// Both changes are applied in a single transaction, so the 'book_file_type' rows are always in sync.
+// Returns the update time, and the object key of the replaced description, if any. The concurrent saves
+// of the book are serialized by the book row lock, so each replaced object key is returned exactly once
+func (s *DBStore) SaveBookFile(ctx context.Context, entity bookFileEntity) (time.Time, string, error) {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return time.Time{}, "", err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	var bookID int64
+	err = tx.GetContext(ctx, &bookID, "SELECT id FROM ebook.books WHERE id = $1 AND deleted_at IS NULL FOR NO KEY UPDATE",
+		entity.BookID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return time.Time{}, "", ErrNotFound
+	}
+	if err != nil {
+		return time.Time{}, "", err
+	}
+	var previousKey string
+	err = tx.GetContext(ctx, &previousKey,
+		"SELECT object_key FROM ebook.book_files WHERE book_id = $1 AND file_type_id = $2", entity.BookID,
+		entity.FileTypeID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return time.Time{}, "", err
+	}
+
+	query := `INSERT INTO ebook.book_files (book_id, file_type_id, object_key, size, sha256, content_type)
+VALUES (:book_id, :file_type_id, :object_key, :size, :sha256, :content_type)
+ON CONFLICT (book_id, file_type_id) DO UPDATE SET object_key   = EXCLUDED.object_key,
+                                                  size         = EXCLUDED.size,
+                                                  sha256       = EXCLUDED.sha256,
+                                                  content_type = EXCLUDED.content_type,
+                                                  updated_at   = now()
+RETURNING updated_at`
+	query, args, err := tx.BindNamed(query, entity)
+	if err != nil {
+		return time.Time{}, "", err
+	}
+	var updatedAt time.Time
+	if err := tx.GetContext(ctx, &updatedAt, query, args...); err != nil {
+		return time.Time{}, "", err
+	}
+
+	_, err = tx.ExecContext(ctx, `INSERT INTO ebook.book_file_type (book_id, file_type_id)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING`, entity.BookID, entity.FileTypeID)
+	if err != nil {
+		return time.Time{}, "", err
+	}
+
+	return updatedAt, previousKey, tx.Commit()
+}
@@ -0,0 +1,137 @@
+package bookfile
+
+import (
+	"context"
+	"github.com/jmoiron/sqlx"
+	"github.com/sdreger/lib-manager-go/internal/tests"
+	"github.com/stretchr/testify/suite"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"os"
+	"strings"
+	"testing"
+)
+
+type TestStoreSuite struct {
+	suite.Suite
+	db            *sqlx.DB
+	testContainer *postgres.PostgresContainer
+	store         *DBStore
+}
+
+func (s *TestStoreSuite) SetupSuite() {
+	testContainer := tests.StartDBTestContainer(s.T())
+	dbConfig := tests.GetTestDBConfig(s.T(), testContainer)
+	connection := tests.SetUpTestDB(s.Suite.Require(), dbConfig, testContainer)
+
+	s.store = NewDBStore(connection)
+	s.db = connection
+	s.testContainer = testContainer
+}
+
+func (s *TestStoreSuite) SetupTest() {
+	ctx := context.Background()
+	err := s.testContainer.Restore(ctx)
+	s.Require().NoError(err)
+}
+
+func (s *TestStoreSuite) TearDownSuite() {
+	err := s.db.Close()
+	s.Require().NoError(err, "failed to close database connection")
+}
+
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(TestStoreSuite))
+}
+
+// -------------------- Tests --------------------
+
+func (s *TestStoreSuite) Test_GetFileTypeID() {
+	ctx := context.Background()
+	err := prepareTestData(s.testContainer, "testdata/book_files.sql")
+	s.Require().NoError(err, "failed to load test SQL file")
+
+	id, err := s.store.GetFileTypeID(ctx, "epub")
+	s.Require().NoError(err, "failed to get file type ID")
+	s.Equal(int64(2), id)
+
+	_, err = s.store.GetFileTypeID(ctx, "mobi")
+	s.ErrorIs(err, ErrUnknownFileType)
+}
+
+func (s *TestStoreSuite) Test_GetBookFileName() {
+	ctx := context.Background()
+	err := prepareTestData(s.testContainer, "testdata/book_files.sql")
+	s.Require().NoError(err, "failed to load test SQL file")
+
+	bookFileName, err := s.store.GetBookFileName(ctx, 1)
+	s.Require().NoError(err, "failed to get book file name")
+	s.Equal("OReilly.Book.01.zip", bookFileName)
+
+	_, err = s.store.GetBookFileName(ctx, 100)
+	s.ErrorIs(err, ErrNotFound)
+}
+
+func (s *TestStoreSuite) Test_SaveBookFile_GetBookFile() {
+	ctx := context.Background()
+	err := prepareTestData(s.testContainer, "testdata/book_files.sql")
+	s.Require().NoError(err, "failed to load test SQL file")
+
+	_, err = s.store.GetBookFile(ctx, 1, "epub")
+	s.ErrorIs(err, ErrNotFound)
+
+	entity := bookFileEntity{
+		BookID:      1,
+		FileTypeID:  2,
+		ObjectKey:   "1/epub/first",
+		Size:        1024,
+		SHA256:      strings.Repeat("a", 64),
+		ContentType: "application/epub+zip",
+	}
+	updatedAt, previousKey, err := s.store.SaveBookFile(ctx, entity)
+	s.Require().NoError(err, "failed to save book file")
+	s.False(updatedAt.IsZero())
+	s.Empty(previousKey)
+
+	// the second upload replaces the file description
+	entity.ObjectKey = "1/epub/second"
+	entity.Size = 2048
+	entity.SHA256 = strings.Repeat("b", 64)
+	_, previousKey, err = s.store.SaveBookFile(ctx, entity)
+	s.Require().NoError(err, "failed to replace book file")
+	s.Equal("1/epub/first", previousKey, "the replaced object key should be returned")
+
+	saved, err := s.store.GetBookFile(ctx, 1, "EPUB")
+	s.Require().NoError(err, "failed to get book file")
+	s.Equal("epub", saved.FileType)
+	s.Equal("OReilly.Book.01.zip", saved.BookFileName)
+	s.Equal(int64(2048), saved.Size)
+	s.Equal(entity.SHA256, saved.SHA256)
+	s.Equal("1/epub/second", saved.ObjectKey)
+
+	var fileTypeIDs []int64
+	err = s.db.SelectContext(ctx, &fileTypeIDs,
+		"SELECT file_type_id FROM ebook.book_file_type WHERE book_id = 1 ORDER BY file_type_id")
+	s.Require().NoError(err)
+	s.Equal([]int64{1, 2}, fileTypeIDs, "the book file type should be linked once")
+}
+
+func (s *TestStoreSuite) Test_SaveBookFile_Error() {
+	ctx := context.Background()
+
+	_, _, err := s.store.SaveBookFile(ctx, bookFileEntity{BookID: 100, FileTypeID: 100})
+	s.Require().ErrorIs(err, ErrNotFound, "should fail on the missing book")
+
+	err = prepareTestData(s.testContainer, "testdata/book_files.sql")
+	s.Require().NoError(err, "failed to load test SQL file")
+	_, _, err = s.store.SaveBookFile(ctx, bookFileEntity{BookID: 1, FileTypeID: 100})
+	s.Require().Error(err, "should fail on the foreign key violation")
+}
+
+func prepareTestData(testContainer *postgres.PostgresContainer, fileName string) error {
+	file, err := os.ReadFile(fileName)
+	if err != nil {
+		return err
+	}
+
+	return tests.ExecSQL(testContainer, string(file))
+}
@@ -0,0 +1,10 @@
+INSERT INTO ebook.publishers (id, name) VALUES (1, 'OReilly');
+INSERT INTO ebook.languages (id, name) VALUES (1, 'English');
+INSERT INTO ebook.file_types (id, name) VALUES (1, 'pdf'), (2, 'EPUB');
+
+INSERT INTO ebook.books (id, title, description, pages, edition, language_id, publisher_id, publisher_url, pub_date,
+                         book_file_name, book_file_size, cover_file_name)
+VALUES (1, 'Book 01', 'Book 01 Description', 256, 1, 1, 1, 'https://amazon.com/dp/1111111111.html', '2022-07-19',
+        'OReilly.Book.01.zip', 5192, '1111111111.jpg');
+
+INSERT INTO ebook.book_file_type (book_id, file_type_id) VALUES (1, 1);
@@ -0,0 +1,108 @@
+package bookfile
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+)
+
+const (
+	stagingPathPrefix = "staging/"
+
+	defaultContentType = "application/octet-stream"
+)
+
+// knownContentTypes - the content types of the common ebook formats, the other types are taken from the upload request
+var knownContentTypes = map[string]string{
+	"azw3": "application/vnd.amazon.ebook",
+	"cbz":  "application/vnd.comicbook+zip",
+	"djvu": "image/vnd.djvu",
+	"epub": "application/epub+zip",
+	"fb2":  "application/x-fictionbook+xml",
+	"mobi": "application/x-mobipocket-ebook",
+	"pdf":  "application/pdf",
+}
+
+// BookFile - a book file description, the file content itself is stored in the BLOB store
+type BookFile struct {
+	BookID      int64     `json:"book_id"`
+	FileType    string    `json:"file_type"`
+	FileName    string    `json:"file_name"`
+	Size        int64     `json:"size"`
+	SHA256      string    `json:"sha256"`
+	ContentType string    `json:"content_type"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// BookFileContent - the book file description along with its seekable content, which must be closed by the caller
+type BookFileContent struct {
+	BookFile
+	Content io.ReadSeekCloser
+}
+
+type bookFileEntity struct {
+	BookID       int64     `db:"book_id"`
+	FileTypeID   int64     `db:"file_type_id"`
+	FileType     string    `db:"file_type"`
+	BookFileName string    `db:"book_file_name"`
+	ObjectKey    string    `db:"object_key"`
+	Size         int64     `db:"size"`
+	SHA256       string    `db:"sha256"`
+	ContentType  string    `db:"content_type"`
+	UpdatedAt    time.Time `db:"updated_at"`
+}
+
+func (e bookFileEntity) toBookFile() BookFile {
+	return BookFile{
+		BookID:      e.BookID,
+		FileType:    e.FileType,
+		FileName:    fileName(e.BookID, e.BookFileName, e.FileType),
+		Size:        e.Size,
+		SHA256:      e.SHA256,
+		ContentType: e.ContentType,
+		UpdatedAt:   e.UpdatedAt,
+	}
+}
+
+// ObjectPath - returns the book file path inside the BLOB store bucket, one object per upload, so the stored
+// description never refers to the object, replaced by another upload. The path is only built on upload,
+// afterward the stored object key is used, since the merged book files keep the paths of the source book
+func ObjectPath(bookID int64, fileType string, uploadID string) string {
+	return fmt.Sprintf("%d/%s/%s", bookID, fileType, uploadID)
+}
+
+// stagingObjectPath - returns the path the book file is uploaded to, before its checksum is verified
+func stagingObjectPath(bookID int64, fileType string, suffix string) string {
+	return fmt.Sprintf("%s%d/%s.%s", stagingPathPrefix, bookID, fileType, suffix)
+}
+
+// fileName - returns the download file name, based on the book file name and the file type.
+// The book file name usually refers to an archive on the NAS share (e.g. 'OReilly.Book.01.zip'),
+// so its extension is replaced with the file type one
+func fileName(bookID int64, bookFileName string, fileType string) string {
+	baseName := strings.TrimSuffix(bookFileName, path.Ext(bookFileName))
+	if strings.TrimSpace(baseName) == "" {
+		baseName = fmt.Sprintf("book-%d", bookID)
+	}
+
+	return baseName + "." + fileType
+}
+
+// ContentType - returns the content type of the book file type, the unknown types are served as binary
+func ContentType(fileType string) string {
+	return contentType(fileType, "")
+}
+
+// contentType - returns the content type of the known file types, otherwise the provided one
+func contentType(fileType string, provided string) string {
+	if known, ok := knownContentTypes[fileType]; ok {
+		return known
+	}
+	if provided == "" {
+		return defaultContentType
+	}
+
+	return provided
+}
@@ -0,0 +1,276 @@
+package cover
+
+import (
+	"context"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"github.com/sdreger/lib-manager-go/cmd/api/errors"
+	"github.com/sdreger/lib-manager-go/internal/blobtstore"
+	"log/slog"
+	"path"
+	"slices"
+	"strings"
+)
+
+const (
+	FixDeleteOrphans      FixAction = "delete-orphans"
+	FixClearDangling      FixAction = "clear-dangling"
+	FixRelocateMismatched FixAction = "relocate-mismatched"
+)
+
+var (
+	AllowedFixActions = []FixAction{FixDeleteOrphans, FixClearDangling, FixRelocateMismatched}
+)
+
+type AuditBlobStore interface {
+	ListCovers(ctx context.Context, prefix string) ([]blobtstore.Object, error)
+	DeleteCover(ctx context.Context, filePath string) error
+	MoveCover(ctx context.Context, srcPath string, dstPath string) error
+}
+
+// FixAction - an action to repair an inconsistency found by the audit
+type FixAction string
+
+type AuditOptions struct {
+	Fixes []FixAction
+}
+
+// NewAuditOptions - validates the requested fix actions, an empty list means a read-only audit
+func NewAuditOptions(fixes []string) (AuditOptions, error) {
+	options := AuditOptions{}
+	for _, fix := range fixes {
+		action := FixAction(strings.TrimSpace(fix))
+		if !slices.Contains(AllowedFixActions, action) {
+			return AuditOptions{}, errors.ValidationError{
+				Field:   "fix",
+				Message: fmt.Sprintf("fix action %q is not allowed, must be one of: %v", fix, AllowedFixActions),
+			}
+		}
+		if !options.shouldFix(action) {
+			options.Fixes = append(options.Fixes, action)
+		}
+	}
+
+	return options, nil
+}
+
+func (o AuditOptions) shouldFix(action FixAction) bool {
+	return slices.Contains(o.Fixes, action)
+}
+
+type AuditReport struct {
+	BooksScanned       int64              `json:"books_scanned"`
+	ObjectsScanned     int64              `json:"objects_scanned"`
+	MissingCovers      []MissingCover     `json:"missing_covers"`
+	OrphanObjects      []OrphanObject     `json:"orphan_objects"`
+	MismatchedPrefixes []MismatchedPrefix `json:"mismatched_prefixes"`
+	Fixes              []FixResult        `json:"fixes"`
+}
+
+// MissingCover - a book, referencing a cover object which does not exist
+type MissingCover struct {
+	BookID        int64  `json:"book_id"`
+	Publisher     string `json:"publisher"`
+	CoverFileName string `json:"cover_file_name"`
+	ExpectedPath  string `json:"expected_path"`
+}
+
+// OrphanObject - a cover bucket object, not referenced by any book
+type OrphanObject struct {
+	Path string `json:"path"`
+	Size int64  `json:"size"`
+}
+
+// MismatchedPrefix - a book cover object, stored under a prefix which does not match the book publisher
+type MismatchedPrefix struct {
+	BookID       int64  `json:"book_id"`
+	ExpectedPath string `json:"expected_path"`
+	ActualPath   string `json:"actual_path"`
+}
+
+type FixResult struct {
+	Action FixAction `json:"action"`
+	BookID int64     `json:"book_id,omitempty"`
+	Path   string    `json:"path"`
+	Error  string    `json:"error,omitempty"`
+}
+
+type auditEntry struct {
+	reference Reference
+	path      string
+	found     bool
+}
+
+type Auditor struct {
+	logger    *slog.Logger
+	store     Store
+	blobStore AuditBlobStore
+}
+
+func NewAuditor(logger *slog.Logger, db *sqlx.DB, blobStore AuditBlobStore) *Auditor {
+	return &Auditor{
+		logger:    logger,
+		store:     NewDBStore(db),
+		blobStore: blobStore,
+	}
+}
+
+// Audit - cross-checks book cover references against the cover bucket content, and applies the requested fixes.
+// The fixes are applied one by one, a failed fix is reported, but does not stop the rest of them
+func (a *Auditor) Audit(ctx context.Context, options AuditOptions) (AuditReport, error) {
+	report := AuditReport{
+		MissingCovers:      []MissingCover{},
+		OrphanObjects:      []OrphanObject{},
+		MismatchedPrefixes: []MismatchedPrefix{},
+		Fixes:              []FixResult{},
+	}
+
+	var entries []*auditEntry
+	entriesByPath := make(map[string][]*auditEntry)
+	entriesByFileName := make(map[string][]*auditEntry)
+	// legacy objects of the already rehashed covers, and the stored covers no book points to, are not required,
+	// but they are not orphans either
+	keptPaths := make(map[string]struct{})
+	err := a.store.StreamReferences(ctx, func(reference Reference) error {
+		report.BooksScanned++
+		if reference.CoverFileName == "" && reference.CoverHash == "" {
+			return nil
+		}
+		entry := &auditEntry{reference: reference, path: reference.path()}
+		entries = append(entries, entry)
+		entriesByPath[entry.path] = append(entriesByPath[entry.path], entry)
+		if reference.CoverHash == "" {
+			entriesByFileName[reference.CoverFileName] = append(entriesByFileName[reference.CoverFileName], entry)
+		} else if reference.CoverFileName != "" {
+			keptPaths[ObjectPath(reference.Publisher, reference.CoverFileName)] = struct{}{}
+		}
+		return nil
+	})
+	if err != nil {
+		return AuditReport{}, fmt.Errorf("cover references streaming error: %w", err)
+	}
+	// the stored covers are deduplicated by their metadata, so the objects of those are never orphans
+	hashes, err := a.store.GetCoverHashes(ctx)
+	if err != nil {
+		return AuditReport{}, fmt.Errorf("cover hashes loading error: %w", err)
+	}
+	for _, hash := range hashes {
+		keptPaths[HashObjectPath(hash)] = struct{}{}
+	}
+
+	// objects are resolved after the whole listing, since the expected path may be listed later than a mismatched one
+	var unreferenced []blobtstore.Object
+	err = a.walk(ctx, "", func(object blobtstore.Object) {
+		report.ObjectsScanned++
+		pathEntries, ok := entriesByPath[object.Key]
+		if !ok {
+			if _, kept := keptPaths[object.Key]; kept {
+				return
+			}
+			unreferenced = append(unreferenced, object)
+			return
+		}
+		for _, entry := range pathEntries {
+			entry.found = true
+		}
+	})
+	if err != nil {
+		return AuditReport{}, fmt.Errorf("cover bucket listing error: %w", err)
+	}
+
+	for _, object := range unreferenced {
+		mismatched := false
+		for _, entry := range entriesByFileName[path.Base(object.Key)] {
+			if entry.found {
+				continue
+			}
+			entry.found = true
+			mismatched = true
+			report.MismatchedPrefixes = append(report.MismatchedPrefixes, MismatchedPrefix{
+				BookID:       entry.reference.BookID,
+				ExpectedPath: entry.path,
+				ActualPath:   object.Key,
+			})
+		}
+		if !mismatched {
+			report.OrphanObjects = append(report.OrphanObjects, OrphanObject{Path: object.Key, Size: object.Size})
+		}
+	}
+
+	for _, entry := range entries {
+		if !entry.found {
+			report.MissingCovers = append(report.MissingCovers, MissingCover{
+				BookID:        entry.reference.BookID,
+				Publisher:     entry.reference.Publisher,
+				CoverFileName: entry.reference.CoverFileName,
+				ExpectedPath:  entry.path,
+			})
+		}
+	}
+
+	a.applyFixes(ctx, options, &report)
+	a.logger.Info("cover audit complete",
+		"booksScanned", report.BooksScanned, "objectsScanned", report.ObjectsScanned,
+		"missingCovers", len(report.MissingCovers), "orphanObjects", len(report.OrphanObjects),
+		"mismatchedPrefixes", len(report.MismatchedPrefixes), "fixes", len(report.Fixes))
+
+	return report, nil
+}
+
+// walk - recursively lists the cover bucket, starting from the provided prefix
+func (a *Auditor) walk(ctx context.Context, prefix string, fn func(object blobtstore.Object)) error {
+	objects, err := a.blobStore.ListCovers(ctx, prefix)
+	if err != nil {
+		return err
+	}
+
+	for _, object := range objects {
+		// generated placeholders are a cache, which is not referenced by books
+		if object.Key == placeholderPathPrefix {
+			continue
+		}
+		if object.IsPrefix() {
+			if err := a.walk(ctx, object.Key, fn); err != nil {
+				return err
+			}
+			continue
+		}
+		fn(object)
+	}
+
+	return nil
+}
+
+func (a *Auditor) applyFixes(ctx context.Context, options AuditOptions, report *AuditReport) {
+	if options.shouldFix(FixDeleteOrphans) {
+		for _, orphan := range report.OrphanObjects {
+			err := a.blobStore.DeleteCover(ctx, orphan.Path)
+			report.Fixes = append(report.Fixes, a.fixResult(FixDeleteOrphans, 0, orphan.Path, err))
+		}
+	}
+
+	if options.shouldFix(FixClearDangling) {
+		for _, missing := range report.MissingCovers {
+			err := a.store.ClearCoverFileName(ctx, missing.BookID)
+			report.Fixes = append(report.Fixes, a.fixResult(FixClearDangling, missing.BookID, missing.ExpectedPath, err))
+		}
+	}
+
+	if options.shouldFix(FixRelocateMismatched) {
+		for _, mismatched := range report.MismatchedPrefixes {
+			err := a.blobStore.MoveCover(ctx, mismatched.ActualPath, mismatched.ExpectedPath)
+			report.Fixes = append(report.Fixes,
+				a.fixResult(FixRelocateMismatched, mismatched.BookID, mismatched.ExpectedPath, err))
+		}
+	}
+}
+
+func (a *Auditor) fixResult(action FixAction, bookID int64, path string, err error) FixResult {
+	result := FixResult{Action: action, BookID: bookID, Path: path}
+	if err != nil {
+		result.Error = err.Error()
+		a.logger.Error("cover audit fix failed: "+err.Error(), "action", action, "bookID", bookID, "path", path)
+	}
+
+	return result
+}
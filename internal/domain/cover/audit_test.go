@@ -0,0 +1,155 @@
+package cover
+
+import (
+	"context"
+	"errors"
+	apiErrors "github.com/sdreger/lib-manager-go/cmd/api/errors"
+	"github.com/sdreger/lib-manager-go/internal/blobtstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"log/slog"
+	"os"
+	"testing"
+)
+
+func TestNewAuditOptions(t *testing.T) {
+	options, err := NewAuditOptions(nil)
+	require.NoError(t, err)
+	assert.Empty(t, options.Fixes)
+
+	options, err = NewAuditOptions([]string{"delete-orphans", " clear-dangling", "delete-orphans"})
+	require.NoError(t, err)
+	assert.Equal(t, []FixAction{FixDeleteOrphans, FixClearDangling}, options.Fixes)
+
+	_, err = NewAuditOptions([]string{"delete-everything"})
+	var validationError apiErrors.ValidationError
+	require.ErrorAs(t, err, &validationError)
+	assert.Equal(t, "fix", validationError.Field)
+}
+
+func TestAuditor_Audit_ReportOnly(t *testing.T) {
+	ctx := context.Background()
+	mockStore, mockBlobStore := getAuditMocks(t)
+	auditor := getAuditor(mockStore, mockBlobStore)
+
+	report, err := auditor.Audit(ctx, AuditOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), report.BooksScanned)
+	assert.Equal(t, int64(8), report.ObjectsScanned)
+	assert.Equal(t, []MissingCover{
+		{BookID: 2, Publisher: "OReilly", CoverFileName: "2222222222.jpg", ExpectedPath: "oreilly/2222222222.jpg"},
+		{BookID: 7, Publisher: "Packt", CoverFileName: "", ExpectedPath: "sha256/def"},
+	}, report.MissingCovers)
+	assert.Equal(t, []OrphanObject{{Path: "manning/orphan.jpg", Size: 20}, {Path: "root.jpg", Size: 40}},
+		report.OrphanObjects)
+	assert.Equal(t, []MismatchedPrefix{{
+		BookID: 3, ExpectedPath: "manning/3333333333.jpg", ActualPath: "oreilly/3333333333.jpg",
+	}}, report.MismatchedPrefixes)
+	assert.Empty(t, report.Fixes)
+}
+
+func TestAuditor_Audit_Fixes(t *testing.T) {
+	ctx := context.Background()
+	mockStore, mockBlobStore := getAuditMocks(t)
+	deleteError := errors.New("some error")
+	mockBlobStore.EXPECT().DeleteCover(ctx, "manning/orphan.jpg").Return(nil).Once()
+	mockBlobStore.EXPECT().DeleteCover(ctx, "root.jpg").Return(deleteError).Once()
+	mockStore.EXPECT().ClearCoverFileName(ctx, int64(2)).Return(nil).Once()
+	mockStore.EXPECT().ClearCoverFileName(ctx, int64(7)).Return(nil).Once()
+	mockBlobStore.EXPECT().MoveCover(ctx, "oreilly/3333333333.jpg", "manning/3333333333.jpg").Return(nil).Once()
+	auditor := getAuditor(mockStore, mockBlobStore)
+
+	options, err := NewAuditOptions([]string{"delete-orphans", "clear-dangling", "relocate-mismatched"})
+	require.NoError(t, err)
+	report, err := auditor.Audit(ctx, options)
+	require.NoError(t, err)
+	assert.Equal(t, []FixResult{
+		{Action: FixDeleteOrphans, Path: "manning/orphan.jpg"},
+		{Action: FixDeleteOrphans, Path: "root.jpg", Error: deleteError.Error()},
+		{Action: FixClearDangling, BookID: 2, Path: "oreilly/2222222222.jpg"},
+		{Action: FixClearDangling, BookID: 7, Path: "sha256/def"},
+		{Action: FixRelocateMismatched, BookID: 3, Path: "manning/3333333333.jpg"},
+	}, report.Fixes)
+}
+
+func TestAuditor_Audit_StoreError(t *testing.T) {
+	ctx := context.Background()
+	storeError := errors.New("some error")
+	mockStore := NewMockStore(t)
+	mockStore.EXPECT().StreamReferences(ctx, mock.Anything).Return(storeError).Once()
+	auditor := getAuditor(mockStore, NewMockAuditBlobStore(t))
+
+	_, err := auditor.Audit(ctx, AuditOptions{})
+	require.ErrorIs(t, err, storeError)
+}
+
+func TestAuditor_Audit_BlobStoreError(t *testing.T) {
+	ctx := context.Background()
+	blobStoreError := errors.New("some error")
+	mockStore := NewMockStore(t)
+	mockStore.EXPECT().StreamReferences(ctx, mock.Anything).Return(nil).Once()
+	mockStore.EXPECT().GetCoverHashes(ctx).Return([]string{}, nil).Once()
+	mockBlobStore := NewMockAuditBlobStore(t)
+	mockBlobStore.EXPECT().ListCovers(ctx, "").Return(nil, blobStoreError).Once()
+	auditor := getAuditor(mockStore, mockBlobStore)
+
+	_, err := auditor.Audit(ctx, AuditOptions{})
+	require.ErrorIs(t, err, blobStoreError)
+}
+
+// getAuditMocks - returns the mocks with the following state:
+// book 1 - the cover exists; book 2 - the cover is missing; book 3 - the cover is stored under a wrong publisher;
+// book 4 - the cover exists; book 5 - has no cover; book 6 - the content-addressed cover exists, and the legacy one
+// is kept; book 7 - the content-addressed cover is missing; 'manning/orphan.jpg' and 'root.jpg' are not referenced;
+// 'sha256/bcd' is a stored cover no book points to, which is kept; 'placeholders/' are generated covers,
+// which are skipped
+func getAuditMocks(t *testing.T) (*MockStore, *MockAuditBlobStore) {
+	references := []Reference{
+		{BookID: 1, Publisher: "OReilly", CoverFileName: "1111111111.jpg"},
+		{BookID: 2, Publisher: "OReilly", CoverFileName: "2222222222.jpg"},
+		{BookID: 3, Publisher: "Manning", CoverFileName: "3333333333.jpg"},
+		{BookID: 4, Publisher: "Manning", CoverFileName: "shared.jpg"},
+		{BookID: 5, Publisher: "Packt", CoverFileName: ""},
+		{BookID: 6, Publisher: "Manning", CoverFileName: "6666666666.jpg", CoverHash: "abc"},
+		{BookID: 7, Publisher: "Packt", CoverFileName: "", CoverHash: "def"},
+	}
+
+	mockStore := NewMockStore(t)
+	mockStore.EXPECT().StreamReferences(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, fn func(reference Reference) error) error {
+			for _, reference := range references {
+				if err := fn(reference); err != nil {
+					return err
+				}
+			}
+			return nil
+		}).Once()
+	mockStore.EXPECT().GetCoverHashes(mock.Anything).Return([]string{"abc", "bcd"}, nil).Once()
+
+	mockBlobStore := NewMockAuditBlobStore(t)
+	mockBlobStore.EXPECT().ListCovers(mock.Anything, "").Return([]blobtstore.Object{
+		{Key: "manning/"}, {Key: "oreilly/"}, {Key: "sha256/"}, {Key: "placeholders/"},
+		{Key: "root.jpg", Size: 40},
+	}, nil).Once()
+	mockBlobStore.EXPECT().ListCovers(mock.Anything, "manning/").Return([]blobtstore.Object{
+		{Key: "manning/orphan.jpg", Size: 20}, {Key: "manning/shared.jpg", Size: 30},
+		{Key: "manning/6666666666.jpg", Size: 50},
+	}, nil).Once()
+	mockBlobStore.EXPECT().ListCovers(mock.Anything, "oreilly/").Return([]blobtstore.Object{
+		{Key: "oreilly/1111111111.jpg", Size: 10}, {Key: "oreilly/3333333333.jpg", Size: 10},
+	}, nil).Once()
+	mockBlobStore.EXPECT().ListCovers(mock.Anything, "sha256/").Return([]blobtstore.Object{
+		{Key: "sha256/abc", Size: 50}, {Key: "sha256/bcd", Size: 60},
+	}, nil).Once()
+
+	return mockStore, mockBlobStore
+}
+
+func getAuditor(store Store, blobStore AuditBlobStore) *Auditor {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.Level(100)}))
+	auditor := NewAuditor(logger, nil, blobStore)
+	auditor.store = store
+
+	return auditor
+}
@@ -86,6 +86,52 @@ func (_c *MockBlobStore_CoverExists_Call) RunAndReturn(run func(ctx context.Cont
 	return _c
 }
 
+// DeleteCover provides a mock function for the type MockBlobStore
+func (_mock *MockBlobStore) DeleteCover(ctx context.Context, filePath string) error {
+	ret := _mock.Called(ctx, filePath)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteCover")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, filePath)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockBlobStore_DeleteCover_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteCover'
+type MockBlobStore_DeleteCover_Call struct {
+	*mock.Call
+}
+
+// DeleteCover is a helper method to define mock.On call
+//   - ctx
+//   - filePath
+func (_e *MockBlobStore_Expecter) DeleteCover(ctx interface{}, filePath interface{}) *MockBlobStore_DeleteCover_Call {
+	return &MockBlobStore_DeleteCover_Call{Call: _e.mock.On("DeleteCover", ctx, filePath)}
+}
+
+func (_c *MockBlobStore_DeleteCover_Call) Run(run func(ctx context.Context, filePath string)) *MockBlobStore_DeleteCover_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockBlobStore_DeleteCover_Call) Return(err error) *MockBlobStore_DeleteCover_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockBlobStore_DeleteCover_Call) RunAndReturn(run func(ctx context.Context, filePath string) error) *MockBlobStore_DeleteCover_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetBookCover provides a mock function for the type MockBlobStore
 func (_mock *MockBlobStore) GetBookCover(ctx context.Context, filePath string) (io.Reader, error) {
 	ret := _mock.Called(ctx, filePath)
@@ -142,3 +188,52 @@ func (_c *MockBlobStore_GetBookCover_Call) RunAndReturn(run func(ctx context.Con
 	_c.Call.Return(run)
 	return _c
 }
+
+// PutCover provides a mock function for the type MockBlobStore
+func (_mock *MockBlobStore) PutCover(ctx context.Context, filePath string, reader io.Reader, size int64, contentType string) error {
+	ret := _mock.Called(ctx, filePath, reader, size, contentType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PutCover")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, io.Reader, int64, string) error); ok {
+		r0 = returnFunc(ctx, filePath, reader, size, contentType)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockBlobStore_PutCover_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PutCover'
+type MockBlobStore_PutCover_Call struct {
+	*mock.Call
+}
+
+// PutCover is a helper method to define mock.On call
+//   - ctx
+//   - filePath
+//   - reader
+//   - size
+//   - contentType
+func (_e *MockBlobStore_Expecter) PutCover(ctx interface{}, filePath interface{}, reader interface{}, size interface{}, contentType interface{}) *MockBlobStore_PutCover_Call {
+	return &MockBlobStore_PutCover_Call{Call: _e.mock.On("PutCover", ctx, filePath, reader, size, contentType)}
+}
+
+func (_c *MockBlobStore_PutCover_Call) Run(run func(ctx context.Context, filePath string, reader io.Reader, size int64, contentType string)) *MockBlobStore_PutCover_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(io.Reader), args[3].(int64), args[4].(string))
+	})
+	return _c
+}
+
+func (_c *MockBlobStore_PutCover_Call) Return(err error) *MockBlobStore_PutCover_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockBlobStore_PutCover_Call) RunAndReturn(run func(ctx context.Context, filePath string, reader io.Reader, size int64, contentType string) error) *MockBlobStore_PutCover_Call {
+	_c.Call.Return(run)
+	return _c
+}
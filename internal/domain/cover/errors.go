@@ -3,5 +3,7 @@ package cover
 import "errors"
 
 var (
-	ErrNotFound = errors.New("entry not found")
+	ErrNotFound        = errors.New("entry not found")
+	ErrUnsupportedType = errors.New("unsupported cover image type")
+	ErrTooLarge        = errors.New("cover image is too large")
 )
@@ -0,0 +1,235 @@
+package cover
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+	"image"
+	"image/color"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"math"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	MaxCoverSize = 10 << 20 // 10 MiB
+	// MaxCoverPixels - the decoded image size limit, a small compressed image can still take gigabytes once decoded
+	MaxCoverPixels = 50_000_000
+
+	mimeTypeSVG = "image/svg+xml"
+	// the amount of bytes to check for the SVG root element, the same as used by http.DetectContentType
+	sniffLength = 512
+
+	// the analyzed images are downscaled first, since neither the dominant color, nor the BlurHash need the details
+	dominantColorSampleSize = 64
+	blurHashSampleSize      = 32
+)
+
+// CoverMetadata - the cover image properties, the frontend needs to lay out and paint a cover before it is loaded.
+// The dominant color and the BlurHash are only available for raster images
+type CoverMetadata struct {
+	Width         int
+	Height        int
+	AspectRatio   float64
+	DominantColor string
+	BlurHash      string
+}
+
+// describe - computes the content-addressed metadata of a cover image
+func describe(content []byte) (Cover, error) {
+	mimeType := detectMIMEType(content)
+	if !strings.HasPrefix(mimeType, "image/") {
+		return Cover{}, ErrUnsupportedType
+	}
+
+	sum := sha256.Sum256(content)
+	width, height := imageDimensions(content, mimeType)
+
+	return Cover{
+		Hash:     hex.EncodeToString(sum[:]),
+		Size:     int64(len(content)),
+		MIMEType: mimeType,
+		Width:    width,
+		Height:   height,
+	}, nil
+}
+
+// checkUploadType - rejects the uploaded SVG covers, since the scripts they can carry would run on the API origin.
+// The SVG covers, already stored before, are still described and served
+func checkUploadType(content []byte) error {
+	if detectMIMEType(content) == mimeTypeSVG {
+		return fmt.Errorf("%w: the SVG covers are not accepted", ErrUnsupportedType)
+	}
+	return nil
+}
+
+// analyze - decodes the cover image, and computes its metadata
+func analyze(content []byte) (CoverMetadata, error) {
+	mimeType := detectMIMEType(content)
+	if !strings.HasPrefix(mimeType, "image/") {
+		return CoverMetadata{}, ErrUnsupportedType
+	}
+
+	var metadata CoverMetadata
+	if mimeType == mimeTypeSVG {
+		metadata.Width, metadata.Height = svgDimensions(content)
+	} else {
+		config, _, err := image.DecodeConfig(bytes.NewReader(content))
+		if err != nil {
+			return CoverMetadata{}, fmt.Errorf("%w: %w", ErrUnsupportedType, err)
+		}
+		if int64(config.Width)*int64(config.Height) > MaxCoverPixels {
+			return CoverMetadata{}, fmt.Errorf("%w: %dx%d pixels", ErrTooLarge, config.Width, config.Height)
+		}
+		img, _, err := image.Decode(bytes.NewReader(content))
+		if err != nil {
+			return CoverMetadata{}, fmt.Errorf("%w: %w", ErrUnsupportedType, err)
+		}
+		metadata.Width, metadata.Height = img.Bounds().Dx(), img.Bounds().Dy()
+		metadata.DominantColor = dominantColor(downscale(img, dominantColorSampleSize))
+		metadata.BlurHash = encodeBlurHash(downscale(img, blurHashSampleSize), blurHashXComponents, blurHashYComponents)
+	}
+	if metadata.Height > 0 {
+		metadata.AspectRatio = math.Round(float64(metadata.Width)/float64(metadata.Height)*10000) / 10000
+	}
+
+	return metadata, nil
+}
+
+// downscale - returns the image, scaled to fit the provided size, keeping the aspect ratio
+func downscale(img image.Image, size int) image.Image {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width <= size && height <= size {
+		return img
+	}
+
+	if width >= height {
+		width, height = size, max(1, height*size/width)
+	} else {
+		width, height = max(1, width*size/height), size
+	}
+	scaled := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.ApproxBiLinear.Scale(scaled, scaled.Bounds(), img, bounds, draw.Src, nil)
+
+	return scaled
+}
+
+// dominantColor - returns the most common color of the image as a '#rrggbb' string.
+// Similar colors are grouped together, and the group average color is returned
+func dominantColor(img image.Image) string {
+	type colorGroup struct {
+		count   int
+		r, g, b int
+	}
+
+	groups := make(map[int]*colorGroup)
+	var dominant *colorGroup
+	bounds := img.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			pixel := color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+			if pixel.A < 128 {
+				continue
+			}
+			key := int(pixel.R>>4)<<8 | int(pixel.G>>4)<<4 | int(pixel.B>>4)
+			group, ok := groups[key]
+			if !ok {
+				group = &colorGroup{}
+				groups[key] = group
+			}
+			group.count++
+			group.r += int(pixel.R)
+			group.g += int(pixel.G)
+			group.b += int(pixel.B)
+			if dominant == nil || group.count > dominant.count {
+				dominant = group
+			}
+		}
+	}
+	if dominant == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("#%02x%02x%02x", dominant.r/dominant.count, dominant.g/dominant.count, dominant.b/dominant.count)
+}
+
+func detectMIMEType(content []byte) string {
+	mediaType, _, err := mime.ParseMediaType(http.DetectContentType(content))
+	if err != nil {
+		return ""
+	}
+
+	// SVG images are detected as plain XML/text
+	if strings.HasPrefix(mediaType, "text/") && bytes.Contains(content[:min(len(content), sniffLength)], []byte("<svg")) {
+		return mimeTypeSVG
+	}
+
+	return mediaType
+}
+
+// imageDimensions - returns the image width and height, or zeros, if the image format can not be decoded
+func imageDimensions(content []byte, mimeType string) (int, int) {
+	if mimeType == mimeTypeSVG {
+		return svgDimensions(content)
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(content))
+	if err != nil {
+		return 0, 0
+	}
+
+	return config.Width, config.Height
+}
+
+// svgDimensions - returns the SVG root element 'width' and 'height' attributes, or the 'viewBox' size
+func svgDimensions(content []byte) (int, int) {
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return 0, 0
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok || start.Name.Local != "svg" {
+			continue
+		}
+
+		var width, height int
+		var viewBox []string
+		for _, attr := range start.Attr {
+			switch attr.Name.Local {
+			case "width":
+				width = parseSVGLength(attr.Value)
+			case "height":
+				height = parseSVGLength(attr.Value)
+			case "viewBox":
+				viewBox = strings.Fields(strings.ReplaceAll(attr.Value, ",", " "))
+			}
+		}
+		if (width == 0 || height == 0) && len(viewBox) == 4 {
+			width, height = parseSVGLength(viewBox[2]), parseSVGLength(viewBox[3])
+		}
+
+		return width, height
+	}
+}
+
+func parseSVGLength(value string) int {
+	value = strings.TrimSuffix(strings.TrimSpace(value), "px")
+	length, err := strconv.ParseFloat(value, 64)
+	if err != nil || length < 0 {
+		return 0
+	}
+
+	return int(length)
+}
@@ -0,0 +1,106 @@
+package cover
+
+import (
+	"bytes"
+	"encoding/binary"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"testing"
+)
+
+func TestDescribe_PNG(t *testing.T) {
+	content := bytes.Buffer{}
+	err := png.Encode(&content, image.NewRGBA(image.Rect(0, 0, 30, 40)))
+	require.NoError(t, err)
+
+	cover, err := describe(content.Bytes())
+	require.NoError(t, err)
+	assert.Len(t, cover.Hash, 64)
+	assert.Equal(t, int64(content.Len()), cover.Size)
+	assert.Equal(t, "image/png", cover.MIMEType)
+	assert.Equal(t, 30, cover.Width)
+	assert.Equal(t, 40, cover.Height)
+
+	sameCover, err := describe(content.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, cover.Hash, sameCover.Hash, "the same content should have the same hash")
+}
+
+func TestDescribe_SVG(t *testing.T) {
+	content := `<?xml version="1.0" encoding="UTF-8" standalone="no"?>
+<svg xmlns="http://www.w3.org/2000/svg" width="300px" height="400"></svg>`
+
+	cover, err := describe([]byte(content))
+	require.NoError(t, err)
+	assert.Equal(t, "image/svg+xml", cover.MIMEType)
+	assert.Equal(t, 300, cover.Width)
+	assert.Equal(t, 400, cover.Height)
+
+	cover, err = describe([]byte(`<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 120 180"></svg>`))
+	require.NoError(t, err)
+	assert.Equal(t, 120, cover.Width)
+	assert.Equal(t, 180, cover.Height)
+}
+
+func TestDescribe_UnsupportedType(t *testing.T) {
+	_, err := describe([]byte("just some text"))
+	assert.ErrorIs(t, err, ErrUnsupportedType)
+}
+
+func TestAnalyze_Raster(t *testing.T) {
+	content := bytes.Buffer{}
+	img := image.NewRGBA(image.Rect(0, 0, 300, 400))
+	draw.Draw(img, img.Bounds(), image.NewUniform(color.RGBA{R: 16, G: 32, B: 200, A: 255}), image.Point{}, draw.Src)
+	// a small stripe should not affect the dominant color
+	draw.Draw(img, image.Rect(0, 0, 300, 40), image.NewUniform(color.White), image.Point{}, draw.Src)
+	require.NoError(t, png.Encode(&content, img))
+
+	metadata, err := analyze(content.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, 300, metadata.Width)
+	assert.Equal(t, 400, metadata.Height)
+	assert.Equal(t, 0.75, metadata.AspectRatio)
+	assert.Equal(t, "#1020c8", metadata.DominantColor)
+	assert.Len(t, metadata.BlurHash, 28, "4x3 components BlurHash should have 28 characters")
+	assert.Equal(t, "L", metadata.BlurHash[:1], "should encode 4x3 components")
+}
+
+func TestAnalyze_SVG(t *testing.T) {
+	metadata, err := analyze([]byte(`<svg xmlns="http://www.w3.org/2000/svg" width="600" height="900"></svg>`))
+	require.NoError(t, err)
+	assert.Equal(t, CoverMetadata{Width: 600, Height: 900, AspectRatio: 0.6667}, metadata)
+}
+
+func TestAnalyze_Broken(t *testing.T) {
+	_, err := analyze([]byte("\x89PNG\r\n\x1a\nbroken"))
+	assert.ErrorIs(t, err, ErrUnsupportedType)
+}
+
+func TestAnalyze_TooManyPixels(t *testing.T) {
+	// the PNG header declares a 10000x10000 image, the decoding should not even be attempted
+	content := bytes.Buffer{}
+	require.NoError(t, png.Encode(&content, image.NewGray(image.Rect(0, 0, 1, 1))))
+	header := content.Bytes()[:33]
+	binary.BigEndian.PutUint32(header[16:], 10000)
+	binary.BigEndian.PutUint32(header[20:], 10000)
+	binary.BigEndian.PutUint32(header[29:], crc32.ChecksumIEEE(header[12:29]))
+
+	_, err := analyze(header)
+	assert.ErrorIs(t, err, ErrTooLarge)
+}
+
+func TestEncodeBlurHash_Uniform(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 8, 8))
+	draw.Draw(img, img.Bounds(), image.NewUniform(color.RGBA{R: 255, G: 0, B: 0, A: 255}), image.Point{}, draw.Src)
+
+	hash := encodeBlurHash(img, 4, 3)
+	require.Len(t, hash, 28)
+	assert.Equal(t, "L", hash[:1], "should encode the 4x3 components size flag")
+	assert.Equal(t, "TI:j", hash[2:6], "should encode the '#ff0000' average color")
+	assert.Empty(t, encodeBlurHash(image.NewRGBA(image.Rectangle{}), 4, 3))
+}
@@ -0,0 +1,367 @@
+package cover
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"github.com/sdreger/lib-manager-go/cmd/api/errors"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+)
+
+const (
+	PlaceholderSVG PlaceholderFormat = "svg"
+	PlaceholderPNG PlaceholderFormat = "png"
+
+	fallbackQueryParam = "fallback"
+	fallbackGenerated  = "generated"
+	formatQueryParam   = "format"
+
+	// placeholderVersion - must be changed along with the placeholder layout, to invalidate the cached placeholders
+	placeholderVersion    = "v1"
+	placeholderPathPrefix = "placeholders/"
+
+	placeholderWidth      = 600
+	placeholderHeight     = 900
+	placeholderMargin     = 40
+	placeholderMaxAuthors = 3
+
+	titleFontSize     = 48
+	titleMaxLines     = 6
+	authorsFontSize   = 30
+	authorsMaxLines   = 2
+	publisherFontSize = 26
+	// the average glyph width relative to the font size, used to wrap the SVG text, which is measured by a browser
+	svgGlyphWidthRatio = 0.55
+)
+
+var (
+	AllowedPlaceholderFormats = []PlaceholderFormat{PlaceholderSVG, PlaceholderPNG}
+
+	placeholderFonts struct {
+		regular *opentype.Font
+		bold    *opentype.Font
+		err     error
+	}
+	placeholderFontsOnce sync.Once
+)
+
+// PlaceholderFormat - the generated placeholder cover image format
+type PlaceholderFormat string
+
+// NewPlaceholderFormat - returns the requested placeholder format, if the generated fallback is requested
+// by the 'fallback=generated' query parameter, otherwise returns an empty format.
+// The format is set by the optional 'format' query parameter, the default one is SVG
+func NewPlaceholderFormat(values url.Values) (PlaceholderFormat, error) {
+	fallback := values.Get(fallbackQueryParam)
+	if fallback == "" {
+		return "", nil
+	}
+	if fallback != fallbackGenerated {
+		return "", errors.ValidationError{
+			Field:   fallbackQueryParam,
+			Message: fmt.Sprintf("the fallback %q is not allowed, must be: %s", fallback, fallbackGenerated),
+		}
+	}
+
+	format := PlaceholderFormat(values.Get(formatQueryParam))
+	if format == "" {
+		return PlaceholderSVG, nil
+	}
+	if !slices.Contains(AllowedPlaceholderFormats, format) {
+		return "", errors.ValidationError{
+			Field: formatQueryParam,
+			Message: fmt.Sprintf("the format %q is not allowed, must be one of: %v",
+				format, AllowedPlaceholderFormats),
+		}
+	}
+
+	return format, nil
+}
+
+// ContentType - returns the MIME type of the placeholder format
+func (f PlaceholderFormat) ContentType() string {
+	if f == PlaceholderPNG {
+		return "image/png"
+	}
+
+	return mimeTypeSVG
+}
+
+// Placeholder - the book details, a placeholder cover is generated from.
+// The same details always produce the same placeholder
+type Placeholder struct {
+	Title     string
+	Authors   []string
+	Publisher string
+}
+
+// key - returns the placeholder content key, which changes when any of the rendered details change
+func (p Placeholder) key(format PlaceholderFormat) string {
+	sum := sha256.Sum256([]byte(strings.Join([]string{
+		placeholderVersion, string(format), p.Title, strings.Join(p.Authors, "\x1f"), p.Publisher,
+	}, "\x1e")))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// authorsLine - returns the first authors, joined in a single line
+func (p Placeholder) authorsLine() string {
+	if len(p.Authors) > placeholderMaxAuthors {
+		return strings.Join(p.Authors[:placeholderMaxAuthors], ", ") + " et al."
+	}
+
+	return strings.Join(p.Authors, ", ")
+}
+
+// colors - returns the background gradient colors, derived from the book details hash
+func (p Placeholder) colors() (color.RGBA, color.RGBA) {
+	sum := sha256.Sum256([]byte(p.Title + "\x1e" + strings.Join(p.Authors, "\x1f") + "\x1e" + p.Publisher))
+	hue := float64(int(sum[0])<<8|int(sum[1])) / 65536 * 360
+	shift := 30 + float64(sum[2])/255*60
+
+	return hslToRGB(hue, 0.55, 0.38), hslToRGB(hue+shift, 0.6, 0.22)
+}
+
+// PlaceholderObjectPath - returns the cover bucket object path, the generated placeholder is cached at
+func PlaceholderObjectPath(key string, format PlaceholderFormat) string {
+	return placeholderPathPrefix + key + "." + string(format)
+}
+
+// renderPlaceholder - renders the placeholder cover image in the requested format
+func renderPlaceholder(placeholder Placeholder, format PlaceholderFormat) ([]byte, error) {
+	switch format {
+	case PlaceholderSVG:
+		return renderPlaceholderSVG(placeholder)
+	case PlaceholderPNG:
+		return renderPlaceholderPNG(placeholder)
+	default:
+		return nil, fmt.Errorf("unsupported placeholder format: %s", format)
+	}
+}
+
+func renderPlaceholderSVG(placeholder Placeholder) ([]byte, error) {
+	topColor, bottomColor := placeholder.colors()
+	fits := func(fontSize float64) func(string) bool {
+		return func(line string) bool {
+			return float64(len([]rune(line)))*fontSize*svgGlyphWidthRatio <= placeholderWidth-2*placeholderMargin
+		}
+	}
+
+	buf := bytes.Buffer{}
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`,
+		placeholderWidth, placeholderHeight, placeholderWidth, placeholderHeight)
+	fmt.Fprintf(&buf, `<defs><linearGradient id="bg" x1="0" y1="0" x2="0" y2="1">`+
+		`<stop offset="0" stop-color="%s"/><stop offset="1" stop-color="%s"/></linearGradient></defs>`,
+		hexColor(topColor), hexColor(bottomColor))
+	fmt.Fprintf(&buf, `<rect width="%d" height="%d" fill="url(#bg)"/>`, placeholderWidth, placeholderHeight)
+	fmt.Fprintf(&buf, `<rect x="%d" y="%d" width="%d" height="4" fill="#ffffff" fill-opacity="0.6"/>`,
+		placeholderMargin, placeholderMargin*3, placeholderWidth-2*placeholderMargin)
+
+	y := placeholderMargin*3 + 90
+	for _, line := range wrapText(placeholder.Title, titleMaxLines, fits(titleFontSize)) {
+		writeSVGText(&buf, line, y, titleFontSize, "bold")
+		y += titleFontSize * 5 / 4
+	}
+	y += authorsFontSize
+	for _, line := range wrapText(placeholder.authorsLine(), authorsMaxLines, fits(authorsFontSize)) {
+		writeSVGText(&buf, line, y, authorsFontSize, "normal")
+		y += authorsFontSize * 5 / 4
+	}
+	for _, line := range wrapText(strings.ToUpper(placeholder.Publisher), 1, fits(publisherFontSize)) {
+		writeSVGText(&buf, line, placeholderHeight-placeholderMargin*2, publisherFontSize, "normal")
+	}
+	buf.WriteString(`</svg>`)
+
+	return buf.Bytes(), nil
+}
+
+func writeSVGText(buf *bytes.Buffer, text string, y int, fontSize int, fontWeight string) {
+	fmt.Fprintf(buf, `<text x="%d" y="%d" fill="#ffffff" font-family="sans-serif" font-size="%d" font-weight="%s">`,
+		placeholderMargin, y, fontSize, fontWeight)
+	_ = xml.EscapeText(buf, []byte(text))
+	buf.WriteString(`</text>`)
+}
+
+func renderPlaceholderPNG(placeholder Placeholder) ([]byte, error) {
+	faces, err := newPlaceholderFaces()
+	if err != nil {
+		return nil, err
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, placeholderWidth, placeholderHeight))
+	topColor, bottomColor := placeholder.colors()
+	for y := 0; y < placeholderHeight; y++ {
+		rowColor := blend(topColor, bottomColor, float64(y)/float64(placeholderHeight-1))
+		for x := 0; x < placeholderWidth; x++ {
+			img.SetRGBA(x, y, rowColor)
+		}
+	}
+	bandColor := blend(bottomColor, color.RGBA{R: 255, G: 255, B: 255, A: 255}, 0.6)
+	for y := placeholderMargin * 3; y < placeholderMargin*3+4; y++ {
+		for x := placeholderMargin; x < placeholderWidth-placeholderMargin; x++ {
+			img.SetRGBA(x, y, bandColor)
+		}
+	}
+
+	drawer := &font.Drawer{Dst: img, Src: image.White}
+	fits := func(face font.Face) func(string) bool {
+		return func(line string) bool {
+			return font.MeasureString(face, line).Ceil() <= placeholderWidth-2*placeholderMargin
+		}
+	}
+	drawLine := func(face font.Face, line string, y int) {
+		drawer.Face = face
+		drawer.Dot = fixed.P(placeholderMargin, y)
+		drawer.DrawString(line)
+	}
+
+	y := placeholderMargin*3 + 90
+	for _, line := range wrapText(placeholder.Title, titleMaxLines, fits(faces.title)) {
+		drawLine(faces.title, line, y)
+		y += titleFontSize * 5 / 4
+	}
+	y += authorsFontSize
+	for _, line := range wrapText(placeholder.authorsLine(), authorsMaxLines, fits(faces.authors)) {
+		drawLine(faces.authors, line, y)
+		y += authorsFontSize * 5 / 4
+	}
+	for _, line := range wrapText(strings.ToUpper(placeholder.Publisher), 1, fits(faces.publisher)) {
+		drawLine(faces.publisher, line, placeholderHeight-placeholderMargin*2)
+	}
+
+	buf := bytes.Buffer{}
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+type placeholderFaces struct {
+	title     font.Face
+	authors   font.Face
+	publisher font.Face
+}
+
+// newPlaceholderFaces - returns new font faces, since a face is not safe for concurrent use.
+// The embedded fonts are parsed only once, the parsed fonts are safe to share
+func newPlaceholderFaces() (placeholderFaces, error) {
+	placeholderFontsOnce.Do(func() {
+		placeholderFonts.regular, placeholderFonts.err = opentype.Parse(goregular.TTF)
+		if placeholderFonts.err != nil {
+			return
+		}
+		placeholderFonts.bold, placeholderFonts.err = opentype.Parse(gobold.TTF)
+	})
+	if placeholderFonts.err != nil {
+		return placeholderFaces{}, placeholderFonts.err
+	}
+
+	title, err := opentype.NewFace(placeholderFonts.bold, &opentype.FaceOptions{Size: titleFontSize, DPI: 72})
+	if err != nil {
+		return placeholderFaces{}, err
+	}
+	authors, err := opentype.NewFace(placeholderFonts.regular, &opentype.FaceOptions{Size: authorsFontSize, DPI: 72})
+	if err != nil {
+		return placeholderFaces{}, err
+	}
+	publisher, err := opentype.NewFace(placeholderFonts.regular,
+		&opentype.FaceOptions{Size: publisherFontSize, DPI: 72})
+	if err != nil {
+		return placeholderFaces{}, err
+	}
+
+	return placeholderFaces{title: title, authors: authors, publisher: publisher}, nil
+}
+
+// wrapText - splits the text into lines, which fit the available width. The last line is truncated with
+// an ellipsis, if the text does not fit into the maximum number of lines
+func wrapText(text string, maxLines int, fits func(line string) bool) []string {
+	var lines []string
+	line := ""
+	for _, word := range strings.Fields(text) {
+		candidate := word
+		if line != "" {
+			candidate = line + " " + word
+		}
+		if fits(candidate) || line == "" {
+			line = candidate
+			continue
+		}
+		lines = append(lines, line)
+		line = word
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+
+	truncated := len(lines) > maxLines
+	if truncated {
+		lines = lines[:maxLines]
+	}
+	for i, current := range lines {
+		last := i == len(lines)-1
+		if fits(current) && !(last && truncated) {
+			continue
+		}
+		runes := []rune(current)
+		for len(runes) > 0 && !fits(string(runes)+"…") {
+			runes = runes[:len(runes)-1]
+		}
+		lines[i] = strings.TrimSpace(string(runes)) + "…"
+	}
+
+	return lines
+}
+
+func blend(from color.RGBA, to color.RGBA, ratio float64) color.RGBA {
+	mix := func(a uint8, b uint8) uint8 {
+		return uint8(float64(a) + (float64(b)-float64(a))*ratio)
+	}
+
+	return color.RGBA{R: mix(from.R, to.R), G: mix(from.G, to.G), B: mix(from.B, to.B), A: 255}
+}
+
+func hexColor(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+// hslToRGB - converts the hue (degrees), saturation and lightness (0..1) to an RGB color
+func hslToRGB(hue float64, saturation float64, lightness float64) color.RGBA {
+	hue = math.Mod(hue, 360)
+	chroma := (1 - math.Abs(2*lightness-1)) * saturation
+	x := chroma * (1 - math.Abs(math.Mod(hue/60, 2)-1))
+	m := lightness - chroma/2
+
+	var r, g, b float64
+	switch {
+	case hue < 60:
+		r, g, b = chroma, x, 0
+	case hue < 120:
+		r, g, b = x, chroma, 0
+	case hue < 180:
+		r, g, b = 0, chroma, x
+	case hue < 240:
+		r, g, b = 0, x, chroma
+	case hue < 300:
+		r, g, b = x, 0, chroma
+	default:
+		r, g, b = chroma, 0, x
+	}
+
+	return color.RGBA{R: uint8((r + m) * 255), G: uint8((g + m) * 255), B: uint8((b + m) * 255), A: 255}
+}
@@ -0,0 +1,100 @@
+package cover
+
+import (
+	"bytes"
+	apiErrors "github.com/sdreger/lib-manager-go/cmd/api/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"image/png"
+	"net/url"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestNewPlaceholderFormat(t *testing.T) {
+	format, err := NewPlaceholderFormat(url.Values{})
+	require.NoError(t, err)
+	assert.Empty(t, format, "the placeholder should not be requested")
+
+	format, err = NewPlaceholderFormat(url.Values{"fallback": {"generated"}})
+	require.NoError(t, err)
+	assert.Equal(t, PlaceholderSVG, format, "should default to SVG")
+
+	format, err = NewPlaceholderFormat(url.Values{"fallback": {"generated"}, "format": {"png"}})
+	require.NoError(t, err)
+	assert.Equal(t, PlaceholderPNG, format)
+
+	var validationError apiErrors.ValidationError
+	_, err = NewPlaceholderFormat(url.Values{"fallback": {"default"}})
+	require.ErrorAs(t, err, &validationError)
+	assert.Equal(t, "fallback", validationError.Field)
+
+	_, err = NewPlaceholderFormat(url.Values{"fallback": {"generated"}, "format": {"gif"}})
+	require.ErrorAs(t, err, &validationError)
+	assert.Equal(t, "format", validationError.Field)
+}
+
+func TestRenderPlaceholder_SVG(t *testing.T) {
+	placeholder := Placeholder{
+		Title:     "Learning Go <An Idiomatic Approach>",
+		Authors:   []string{"Jon Bodner"},
+		Publisher: "OReilly",
+	}
+
+	content, err := renderPlaceholder(placeholder, PlaceholderSVG)
+	require.NoError(t, err)
+	sameContent, err := renderPlaceholder(placeholder, PlaceholderSVG)
+	require.NoError(t, err)
+	assert.Equal(t, content, sameContent, "the placeholder should be deterministic")
+
+	assert.Contains(t, string(content), "Learning Go &lt;An")
+	assert.Contains(t, string(content), "Jon Bodner")
+	assert.Contains(t, string(content), "OREILLY")
+
+	cover, err := describe(content)
+	require.NoError(t, err, "the placeholder should be a valid cover image")
+	assert.Equal(t, "image/svg+xml", cover.MIMEType)
+	assert.Equal(t, placeholderWidth, cover.Width)
+	assert.Equal(t, placeholderHeight, cover.Height)
+}
+
+func TestRenderPlaceholder_PNG(t *testing.T) {
+	placeholder := Placeholder{Title: "Learning Go", Authors: []string{"Jon Bodner"}, Publisher: "OReilly"}
+
+	content, err := renderPlaceholder(placeholder, PlaceholderPNG)
+	require.NoError(t, err)
+	sameContent, err := renderPlaceholder(placeholder, PlaceholderPNG)
+	require.NoError(t, err)
+	assert.Equal(t, content, sameContent, "the placeholder should be deterministic")
+
+	config, err := png.DecodeConfig(bytes.NewReader(content))
+	require.NoError(t, err)
+	assert.Equal(t, placeholderWidth, config.Width)
+	assert.Equal(t, placeholderHeight, config.Height)
+}
+
+func TestPlaceholder_ColorsAndKey(t *testing.T) {
+	first := Placeholder{Title: "Learning Go", Authors: []string{"Jon Bodner"}, Publisher: "OReilly"}
+	second := Placeholder{Title: "Learning Rust", Authors: []string{"Jon Bodner"}, Publisher: "OReilly"}
+
+	firstTop, firstBottom := first.colors()
+	secondTop, _ := second.colors()
+	assert.NotEqual(t, firstTop, firstBottom)
+	assert.NotEqual(t, firstTop, secondTop, "different books should get different colors")
+
+	assert.Equal(t, first.key(PlaceholderSVG), first.key(PlaceholderSVG))
+	assert.NotEqual(t, first.key(PlaceholderSVG), first.key(PlaceholderPNG))
+	assert.NotEqual(t, first.key(PlaceholderSVG), second.key(PlaceholderSVG))
+}
+
+func TestWrapText(t *testing.T) {
+	fits := func(line string) bool { return utf8.RuneCountInString(line) <= 10 }
+
+	assert.Equal(t, []string{"Learning", "Go"}, wrapText("Learning Go", 3, fits))
+	assert.Equal(t, []string{"The Go", "Programmi…"}, wrapText("The Go Programming Language", 2, fits))
+	assert.Equal(t, []string{"Internati…"}, wrapText("Internationalization", 2, fits))
+	assert.Empty(t, wrapText("  ", 2, fits))
+	assert.Equal(t, "Jon Bodner, Jane Doe, John Doe et al.",
+		Placeholder{Authors: strings.Split("Jon Bodner,Jane Doe,John Doe,Amanda Lee", ",")}.authorsLine())
+}
@@ -1,25 +1,80 @@
 package cover
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
 	"io"
 	"log/slog"
+	"time"
 )
 
+type Store interface {
+	StreamReferences(ctx context.Context, fn func(reference Reference) error) error
+	ClearCoverFileName(ctx context.Context, bookID int64) error
+	GetCover(ctx context.Context, hash string) (Cover, error)
+	GetCoverHashes(ctx context.Context) ([]string, error)
+	SaveCover(ctx context.Context, cover Cover) error
+	DeleteUnusedCover(ctx context.Context, hash string) (bool, error)
+	DeleteExpiredCovers(ctx context.Context, olderThan time.Duration) ([]string, error)
+	TouchCover(ctx context.Context, hash string) error
+	SetBookCover(ctx context.Context, bookID int64, hash string) error
+	GetBookCoverReference(ctx context.Context, bookID int64) (BookCover, error)
+	SetBookCoverMetadata(ctx context.Context, bookID int64, metadata CoverMetadata) error
+}
+
 type BlobStore interface {
 	CoverExists(ctx context.Context, filePath string) bool
 	GetBookCover(ctx context.Context, filePath string) (io.Reader, error)
+	PutCover(ctx context.Context, filePath string, reader io.Reader, size int64, contentType string) error
+	DeleteCover(ctx context.Context, filePath string) error
+}
+
+// CoverFile - the cover image content, either stored or generated.
+// The ETag is only set when the content is addressed by its hash, and never changes
+type CoverFile struct {
+	Content     io.Reader
+	ContentType string
+	ETag        string
+}
+
+// RehashReport - the result of the legacy covers migration to the content-addressed storage
+type RehashReport struct {
+	BooksScanned   int64          `json:"books_scanned"`
+	Rehashed       int64          `json:"rehashed"`
+	Deduplicated   int64          `json:"deduplicated"`
+	LegacyDeleted  int64          `json:"legacy_deleted"`
+	MissingCovers  []MissingCover `json:"missing_covers"`
+	RehashFailures []CoverFailure `json:"rehash_failures"`
+}
+
+// BackfillReport - the result of the cover metadata backfill
+type BackfillReport struct {
+	BooksScanned  int64          `json:"books_scanned"`
+	Updated       int64          `json:"updated"`
+	MissingCovers []MissingCover `json:"missing_covers"`
+	Failures      []CoverFailure `json:"failures"`
+}
+
+type CoverFailure struct {
+	BookID int64  `json:"book_id"`
+	Path   string `json:"path"`
+	Error  string `json:"error"`
 }
 
 type Service struct {
 	logger    *slog.Logger
+	store     Store
 	blobStore BlobStore
 }
 
-func NewService(logger *slog.Logger, store BlobStore) *Service {
+func NewService(logger *slog.Logger, db *sqlx.DB, blobStore BlobStore) *Service {
 	return &Service{
 		logger:    logger,
-		blobStore: store,
+		store:     NewDBStore(db),
+		blobStore: blobStore,
 	}
 }
 
@@ -30,3 +85,371 @@ func (s *Service) GetBookCover(ctx context.Context, filePath string) (io.Reader,
 
 	return s.blobStore.GetBookCover(ctx, filePath)
 }
+
+// GetCoverByBookID - returns the book cover image. If the book has no cover, and the placeholder format is provided,
+// returns a placeholder, generated from the book details. The generated placeholders are cached in the BLOB store
+func (s *Service) GetCoverByBookID(ctx context.Context, bookID int64, placeholderFormat PlaceholderFormat) (
+	CoverFile, error) {
+
+	bookCover, err := s.store.GetBookCoverReference(ctx, bookID)
+	if err != nil {
+		return CoverFile{}, err
+	}
+
+	if bookCover.hasCover() && s.blobStore.CoverExists(ctx, bookCover.path()) {
+		return s.getStoredCover(ctx, bookCover.Reference)
+	}
+	if placeholderFormat == "" {
+		return CoverFile{}, ErrNotFound
+	}
+
+	return s.getPlaceholder(ctx, bookCover.placeholder(), placeholderFormat)
+}
+
+func (s *Service) getStoredCover(ctx context.Context, reference Reference) (CoverFile, error) {
+	file := CoverFile{ContentType: "application/octet-stream"}
+	if reference.CoverHash != "" {
+		cover, err := s.store.GetCover(ctx, reference.CoverHash)
+		if err != nil {
+			return CoverFile{}, err
+		}
+		file.ContentType = cover.MIMEType
+		file.ETag = cover.Hash
+	}
+
+	content, err := s.blobStore.GetBookCover(ctx, reference.path())
+	if err != nil {
+		return CoverFile{}, err
+	}
+	file.Content = content
+
+	return file, nil
+}
+
+func (s *Service) getPlaceholder(ctx context.Context, placeholder Placeholder, format PlaceholderFormat) (
+	CoverFile, error) {
+
+	key := placeholder.key(format)
+	filePath := PlaceholderObjectPath(key, format)
+	file := CoverFile{ContentType: format.ContentType(), ETag: key}
+	if s.blobStore.CoverExists(ctx, filePath) {
+		content, err := s.blobStore.GetBookCover(ctx, filePath)
+		if err != nil {
+			return CoverFile{}, err
+		}
+		file.Content = content
+
+		return file, nil
+	}
+
+	content, err := renderPlaceholder(placeholder, format)
+	if err != nil {
+		return CoverFile{}, err
+	}
+	// a failed caching should not fail the request, since the placeholder can be rendered again
+	err = s.blobStore.PutCover(ctx, filePath, bytes.NewReader(content), int64(len(content)), file.ContentType)
+	if err != nil {
+		s.logger.Error("placeholder cover caching failed: "+err.Error(), "path", filePath)
+	}
+	file.Content = bytes.NewReader(content)
+
+	return file, nil
+}
+
+// UploadBookCover - stores the cover image by its content hash, and points the book to it.
+// The same image is stored only once, no matter how many books use it. Returns ErrNotFound if the book is missing,
+// the newly stored cover is discarded if the book can not be pointed to it
+func (s *Service) UploadBookCover(ctx context.Context, bookID int64, reader io.Reader) (Cover, error) {
+	content, err := readCover(reader)
+	if err != nil {
+		return Cover{}, err
+	}
+	if err := checkUploadType(content); err != nil {
+		return Cover{}, err
+	}
+
+	metadata, err := analyze(content)
+	if err != nil {
+		return Cover{}, err
+	}
+
+	if _, err := s.store.GetBookCoverReference(ctx, bookID); err != nil {
+		return Cover{}, err
+	}
+	cover, deduplicated, err := s.storeCover(ctx, content)
+	if err != nil {
+		return Cover{}, err
+	}
+
+	if err := s.store.SetBookCover(ctx, bookID, cover.Hash); err != nil {
+		if !deduplicated {
+			if discardErr := s.DiscardCover(ctx, cover.Hash); discardErr != nil {
+				s.logger.Error("cover discard failed: "+discardErr.Error(), "bookID", bookID, "hash", cover.Hash)
+			}
+		}
+		return Cover{}, err
+	}
+	if err := s.store.SetBookCoverMetadata(ctx, bookID, metadata); err != nil {
+		return Cover{}, err
+	}
+
+	return cover, nil
+}
+
+// BackfillCoverMetadata - decodes the stored book covers, and records their metadata.
+// Only the books without the metadata are processed, unless all of them are requested
+func (s *Service) BackfillCoverMetadata(ctx context.Context, all bool) (BackfillReport, error) {
+	report := BackfillReport{MissingCovers: []MissingCover{}, Failures: []CoverFailure{}}
+
+	// the references are collected first, since the DB connection is busy with streaming until it is done
+	var references []Reference
+	err := s.store.StreamReferences(ctx, func(reference Reference) error {
+		report.BooksScanned++
+		if reference.hasCover() && (all || !reference.HasMetadata) {
+			references = append(references, reference)
+		}
+		return nil
+	})
+	if err != nil {
+		return BackfillReport{}, fmt.Errorf("cover references streaming error: %w", err)
+	}
+
+	for _, reference := range references {
+		filePath := reference.path()
+		if !s.blobStore.CoverExists(ctx, filePath) {
+			report.MissingCovers = append(report.MissingCovers, MissingCover{
+				BookID:        reference.BookID,
+				Publisher:     reference.Publisher,
+				CoverFileName: reference.CoverFileName,
+				ExpectedPath:  filePath,
+			})
+			continue
+		}
+
+		err := s.backfillMetadata(ctx, reference.BookID, filePath)
+		if err != nil {
+			s.logger.Error("cover metadata backfill failed: "+err.Error(), "bookID", reference.BookID, "path", filePath)
+			report.Failures = append(report.Failures, CoverFailure{
+				BookID: reference.BookID, Path: filePath, Error: err.Error(),
+			})
+			continue
+		}
+		report.Updated++
+	}
+
+	s.logger.Info("cover metadata backfill complete", "booksScanned", report.BooksScanned,
+		"updated", report.Updated, "missingCovers", len(report.MissingCovers), "failures", len(report.Failures))
+
+	return report, nil
+}
+
+func (s *Service) backfillMetadata(ctx context.Context, bookID int64, filePath string) error {
+	content, err := s.readStoredCover(ctx, filePath)
+	if err != nil {
+		return err
+	}
+
+	metadata, err := analyze(content)
+	if err != nil {
+		return err
+	}
+
+	return s.store.SetBookCoverMetadata(ctx, bookID, metadata)
+}
+
+// RehashLegacyCovers - migrates the existing '{publisher}/{file}' covers to the content-addressed storage.
+// The migration is idempotent: already rehashed books are skipped, so it can be safely re-run after a failure.
+// The legacy objects are deleted only if requested, and only after the whole migration
+func (s *Service) RehashLegacyCovers(ctx context.Context, deleteLegacy bool) (RehashReport, error) {
+	report := RehashReport{MissingCovers: []MissingCover{}, RehashFailures: []CoverFailure{}}
+
+	// the references are collected first, since the DB connection is busy with streaming until it is done
+	var references []Reference
+	err := s.store.StreamReferences(ctx, func(reference Reference) error {
+		report.BooksScanned++
+		if reference.CoverHash == "" && reference.CoverFileName != "" {
+			references = append(references, reference)
+		}
+		return nil
+	})
+	if err != nil {
+		return RehashReport{}, fmt.Errorf("cover references streaming error: %w", err)
+	}
+
+	var legacyPaths []string
+	for _, reference := range references {
+		legacyPath := reference.path()
+		if !s.blobStore.CoverExists(ctx, legacyPath) {
+			report.MissingCovers = append(report.MissingCovers, MissingCover{
+				BookID:        reference.BookID,
+				Publisher:     reference.Publisher,
+				CoverFileName: reference.CoverFileName,
+				ExpectedPath:  legacyPath,
+			})
+			continue
+		}
+
+		deduplicated, err := s.rehashCover(ctx, reference.BookID, legacyPath)
+		if err != nil {
+			s.logger.Error("cover rehash failed: "+err.Error(), "bookID", reference.BookID, "path", legacyPath)
+			report.RehashFailures = append(report.RehashFailures, CoverFailure{
+				BookID: reference.BookID, Path: legacyPath, Error: err.Error(),
+			})
+			continue
+		}
+
+		report.Rehashed++
+		if deduplicated {
+			report.Deduplicated++
+		}
+		legacyPaths = append(legacyPaths, legacyPath)
+	}
+
+	if deleteLegacy {
+		for _, legacyPath := range legacyPaths {
+			if err := s.blobStore.DeleteCover(ctx, legacyPath); err != nil {
+				s.logger.Error("legacy cover removal failed: "+err.Error(), "path", legacyPath)
+				continue
+			}
+			report.LegacyDeleted++
+		}
+	}
+
+	s.logger.Info("cover rehash complete", "booksScanned", report.BooksScanned,
+		"rehashed", report.Rehashed, "deduplicated", report.Deduplicated, "legacyDeleted", report.LegacyDeleted,
+		"missingCovers", len(report.MissingCovers), "failures", len(report.RehashFailures))
+
+	return report, nil
+}
+
+func (s *Service) rehashCover(ctx context.Context, bookID int64, legacyPath string) (bool, error) {
+	content, err := s.readStoredCover(ctx, legacyPath)
+	if err != nil {
+		return false, err
+	}
+
+	cover, deduplicated, err := s.storeCover(ctx, content)
+	if err != nil {
+		return false, err
+	}
+
+	return deduplicated, s.store.SetBookCover(ctx, bookID, cover.Hash)
+}
+
+// StoreCover - stores the cover image by its content hash, without pointing any book to it.
+// Used for the covers of not yet committed books, e.g. the ones extracted on ingest. The already stored cover
+// is touched, so it does not expire before the book is committed
+func (s *Service) StoreCover(ctx context.Context, reader io.Reader) (Cover, error) {
+	content, err := readCover(reader)
+	if err != nil {
+		return Cover{}, err
+	}
+	if err := checkUploadType(content); err != nil {
+		return Cover{}, err
+	}
+
+	cover, deduplicated, err := s.storeCover(ctx, content)
+	if err != nil {
+		return Cover{}, err
+	}
+	if deduplicated {
+		if err := s.store.TouchCover(ctx, cover.Hash); err != nil {
+			return Cover{}, err
+		}
+	}
+
+	return cover, nil
+}
+
+// ExpireUnusedCovers - deletes the stored covers, no book points to, stored longer than the given time ago,
+// e.g. the ones extracted on ingest, whose books were never committed. Returns the number of the deleted covers.
+// The objects are deleted after the metadata, so the objects left behind on a failure are reported by the audit
+func (s *Service) ExpireUnusedCovers(ctx context.Context, olderThan time.Duration) (int, error) {
+	hashes, err := s.store.DeleteExpiredCovers(ctx, olderThan)
+	if err != nil {
+		return 0, err
+	}
+	for _, hash := range hashes {
+		if err := s.blobStore.DeleteCover(ctx, HashObjectPath(hash)); err != nil {
+			s.logger.Error("expired cover removal failed: "+err.Error(), "hash", hash)
+		}
+	}
+
+	return len(hashes), nil
+}
+
+// AssignBookCover - points the book to the already stored content-addressed cover, and records the cover metadata
+func (s *Service) AssignBookCover(ctx context.Context, bookID int64, hash string) error {
+	if err := s.store.SetBookCover(ctx, bookID, hash); err != nil {
+		return err
+	}
+
+	return s.backfillMetadata(ctx, bookID, HashObjectPath(hash))
+}
+
+// DiscardCover - deletes the stored cover, unless some book points to it, e.g. the cover extracted on ingest,
+// which is not used by the matched book. The object is deleted after the metadata, so the object left behind
+// on a failure is reported by the audit as an orphan
+func (s *Service) DiscardCover(ctx context.Context, hash string) error {
+	deleted, err := s.store.DeleteUnusedCover(ctx, hash)
+	if err != nil || !deleted {
+		return err
+	}
+
+	return s.blobStore.DeleteCover(ctx, HashObjectPath(hash))
+}
+
+// storeCover - uploads the cover content, unless the cover with the same hash is already stored.
+// The cover, having the metadata but no object, is uploaded again. Returns 'true' if the cover was deduplicated
+func (s *Service) storeCover(ctx context.Context, content []byte) (Cover, bool, error) {
+	cover, err := describe(content)
+	if err != nil {
+		return Cover{}, false, err
+	}
+
+	storedCover, err := s.store.GetCover(ctx, cover.Hash)
+	if err == nil && s.blobStore.CoverExists(ctx, HashObjectPath(cover.Hash)) {
+		return storedCover, true, nil
+	}
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return Cover{}, false, err
+	}
+
+	err = s.blobStore.PutCover(ctx, HashObjectPath(cover.Hash), bytes.NewReader(content), cover.Size, cover.MIMEType)
+	if err != nil {
+		return Cover{}, false, err
+	}
+	if err := s.store.SaveCover(ctx, cover); err != nil {
+		return Cover{}, false, err
+	}
+
+	return cover, false, nil
+}
+
+// readStoredCover - reads the whole cover content from the BLOB store
+func (s *Service) readStoredCover(ctx context.Context, filePath string) ([]byte, error) {
+	reader, err := s.blobStore.GetBookCover(ctx, filePath)
+	if err != nil {
+		return nil, err
+	}
+	if closer, ok := reader.(io.Closer); ok {
+		defer func() {
+			_ = closer.Close()
+		}()
+	}
+
+	return readCover(reader)
+}
+
+// readCover - reads the whole cover content, which is limited by MaxCoverSize
+func readCover(reader io.Reader) ([]byte, error) {
+	content, err := io.ReadAll(io.LimitReader(reader, MaxCoverSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(content) > MaxCoverSize {
+		return nil, ErrTooLarge
+	}
+
+	return content, nil
+}
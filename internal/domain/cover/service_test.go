@@ -3,11 +3,19 @@ package cover
 import (
 	"bytes"
 	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
 	"io"
 	"log/slog"
 	"os"
 	"testing"
+	"time"
 )
 
 func TestMockBlobStore_GetBoolCover(t *testing.T) {
@@ -20,7 +28,7 @@ func TestMockBlobStore_GetBoolCover(t *testing.T) {
 	mockBlobStore.EXPECT().CoverExists(ctx, filePathExists).Return(true).Once()
 	mockBlobStore.EXPECT().GetBookCover(ctx, filePathExists).
 		Return(bytes.NewBufferString(existingContent), nil).Once()
-	service := NewService(logger, mockBlobStore)
+	service := NewService(logger, nil, mockBlobStore)
 
 	cover, err := service.GetBookCover(ctx, filePathExists)
 	require.NoError(t, err, "should return book cover")
@@ -36,9 +44,444 @@ func TestMockBlobStore_GetBoolCover_NotExist(t *testing.T) {
 
 	mockBlobStore := NewMockBlobStore(t)
 	mockBlobStore.EXPECT().CoverExists(ctx, filePathNotExist).Return(false).Once()
-	service := NewService(logger, mockBlobStore)
+	service := NewService(logger, nil, mockBlobStore)
 
 	nonExistingCover, err := service.GetBookCover(ctx, filePathNotExist)
 	require.ErrorIs(t, err, ErrNotFound)
 	require.Nil(t, nonExistingCover)
 }
+
+func TestService_UploadBookCover(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.Level(100)}))
+	ctx := context.Background()
+	content := getTestPNG(t)
+	expectedCover, err := describe(content)
+	require.NoError(t, err)
+
+	mockStore := NewMockStore(t)
+	mockStore.EXPECT().GetBookCoverReference(ctx, int64(1)).Return(BookCover{}, nil).Once()
+	mockStore.EXPECT().GetCover(ctx, expectedCover.Hash).Return(Cover{}, ErrNotFound).Once()
+	mockStore.EXPECT().SaveCover(ctx, expectedCover).Return(nil).Once()
+	mockStore.EXPECT().SetBookCover(ctx, int64(1), expectedCover.Hash).Return(nil).Once()
+	expectedMetadata, err := analyze(content)
+	require.NoError(t, err)
+	mockStore.EXPECT().SetBookCoverMetadata(ctx, int64(1), expectedMetadata).Return(nil).Once()
+	mockBlobStore := NewMockBlobStore(t)
+	mockBlobStore.EXPECT().
+		PutCover(ctx, "sha256/"+expectedCover.Hash, mock.Anything, int64(len(content)), "image/png").
+		Return(nil).Once()
+	service := NewService(logger, nil, mockBlobStore)
+	service.store = mockStore
+
+	uploadedCover, err := service.UploadBookCover(ctx, 1, bytes.NewReader(content))
+	require.NoError(t, err, "should upload book cover")
+	assert.Equal(t, expectedCover, uploadedCover)
+
+	svgContent := []byte(`<svg xmlns="http://www.w3.org/2000/svg" width="300" height="400"></svg>`)
+	_, err = service.UploadBookCover(ctx, 1, bytes.NewReader(svgContent))
+	assert.ErrorIs(t, err, ErrUnsupportedType, "the SVG covers should not be accepted")
+}
+
+func TestService_UploadBookCover_Deduplicated(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.Level(100)}))
+	ctx := context.Background()
+	content := getTestPNG(t)
+	expectedCover, err := describe(content)
+	require.NoError(t, err)
+
+	mockStore := NewMockStore(t)
+	mockStore.EXPECT().GetBookCoverReference(ctx, int64(2)).Return(BookCover{}, nil).Once()
+	mockStore.EXPECT().GetCover(ctx, expectedCover.Hash).Return(expectedCover, nil).Once()
+	mockStore.EXPECT().SetBookCover(ctx, int64(2), expectedCover.Hash).Return(nil).Once()
+	mockStore.EXPECT().SetBookCoverMetadata(ctx, int64(2), mock.Anything).Return(nil).Once()
+	mockBlobStore := NewMockBlobStore(t) // the content should not be uploaded again
+	mockBlobStore.EXPECT().CoverExists(ctx, "sha256/"+expectedCover.Hash).Return(true).Once()
+	service := NewService(logger, nil, mockBlobStore)
+	service.store = mockStore
+
+	uploadedCover, err := service.UploadBookCover(ctx, 2, bytes.NewReader(content))
+	require.NoError(t, err, "should reuse the stored cover")
+	assert.Equal(t, expectedCover, uploadedCover)
+}
+
+func TestService_UploadBookCover_BookNotFound(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.Level(100)}))
+	ctx := context.Background()
+
+	mockStore := NewMockStore(t) // the cover should not be stored
+	mockStore.EXPECT().GetBookCoverReference(ctx, int64(100)).Return(BookCover{}, ErrNotFound).Once()
+	service := NewService(logger, nil, NewMockBlobStore(t))
+	service.store = mockStore
+
+	_, err := service.UploadBookCover(ctx, 100, bytes.NewReader(getTestPNG(t)))
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestService_UploadBookCover_SetBookCoverFailed(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.Level(100)}))
+	ctx := context.Background()
+	content := getTestPNG(t)
+	expectedCover, err := describe(content)
+	require.NoError(t, err)
+
+	mockStore := NewMockStore(t)
+	mockStore.EXPECT().GetBookCoverReference(ctx, int64(1)).Return(BookCover{}, nil).Once()
+	mockStore.EXPECT().GetCover(ctx, expectedCover.Hash).Return(Cover{}, ErrNotFound).Once()
+	mockStore.EXPECT().SaveCover(ctx, expectedCover).Return(nil).Once()
+	mockStore.EXPECT().SetBookCover(ctx, int64(1), expectedCover.Hash).Return(ErrNotFound).Once()
+	mockStore.EXPECT().DeleteUnusedCover(ctx, expectedCover.Hash).Return(true, nil).Once()
+	mockBlobStore := NewMockBlobStore(t)
+	mockBlobStore.EXPECT().
+		PutCover(ctx, "sha256/"+expectedCover.Hash, mock.Anything, int64(len(content)), "image/png").
+		Return(nil).Once()
+	mockBlobStore.EXPECT().DeleteCover(ctx, "sha256/"+expectedCover.Hash).Return(nil).Once()
+	service := NewService(logger, nil, mockBlobStore)
+	service.store = mockStore
+
+	_, err = service.UploadBookCover(ctx, 1, bytes.NewReader(content))
+	assert.ErrorIs(t, err, ErrNotFound, "the book deleted meanwhile should not be found")
+}
+
+func TestService_StoreCover_MissingObject(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.Level(100)}))
+	ctx := context.Background()
+	content := []byte(`<svg xmlns="http://www.w3.org/2000/svg" width="300" height="400"></svg>`)
+	expectedCover, err := describe(content)
+	require.NoError(t, err)
+
+	mockStore := NewMockStore(t)
+	mockStore.EXPECT().GetCover(ctx, expectedCover.Hash).Return(expectedCover, nil).Once()
+	mockStore.EXPECT().SaveCover(ctx, expectedCover).Return(nil).Once()
+	mockBlobStore := NewMockBlobStore(t)
+	mockBlobStore.EXPECT().CoverExists(ctx, "sha256/"+expectedCover.Hash).Return(false).Once()
+	mockBlobStore.EXPECT().
+		PutCover(ctx, "sha256/"+expectedCover.Hash, mock.Anything, expectedCover.Size, expectedCover.MIMEType).
+		Return(nil).Once()
+	service := NewService(logger, nil, mockBlobStore)
+	service.store = mockStore
+
+	storedCover, deduplicated, err := service.storeCover(ctx, content)
+	require.NoError(t, err, "should upload the cover, having the metadata but no object")
+	assert.False(t, deduplicated)
+	assert.Equal(t, expectedCover, storedCover)
+}
+
+func TestService_StoreCover(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.Level(100)}))
+	ctx := context.Background()
+	content := getTestPNG(t)
+	expectedCover, err := describe(content)
+	require.NoError(t, err)
+
+	mockStore := NewMockStore(t)
+	mockStore.EXPECT().GetCover(ctx, expectedCover.Hash).Return(Cover{}, ErrNotFound).Once()
+	mockStore.EXPECT().SaveCover(ctx, expectedCover).Return(nil).Once()
+	mockBlobStore := NewMockBlobStore(t)
+	mockBlobStore.EXPECT().
+		PutCover(ctx, "sha256/"+expectedCover.Hash, mock.Anything, int64(len(content)), "image/png").
+		Return(nil).Once()
+	service := NewService(logger, nil, mockBlobStore)
+	service.store = mockStore
+
+	storedCover, err := service.StoreCover(ctx, bytes.NewReader(content))
+	require.NoError(t, err, "should store cover")
+	assert.Equal(t, expectedCover, storedCover)
+
+	_, err = service.StoreCover(ctx, bytes.NewReader([]byte("plain text")))
+	assert.ErrorIs(t, err, ErrUnsupportedType)
+	_, err = service.StoreCover(ctx, bytes.NewReader([]byte(`<svg xmlns="http://www.w3.org/2000/svg"></svg>`)))
+	assert.ErrorIs(t, err, ErrUnsupportedType, "the SVG covers should not be accepted")
+}
+
+func TestService_StoreCover_Deduplicated(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.Level(100)}))
+	ctx := context.Background()
+	content := getTestPNG(t)
+	expectedCover, err := describe(content)
+	require.NoError(t, err)
+
+	mockStore := NewMockStore(t)
+	mockStore.EXPECT().GetCover(ctx, expectedCover.Hash).Return(expectedCover, nil).Once()
+	mockStore.EXPECT().TouchCover(ctx, expectedCover.Hash).Return(nil).Once()
+	mockBlobStore := NewMockBlobStore(t)
+	mockBlobStore.EXPECT().CoverExists(ctx, "sha256/"+expectedCover.Hash).Return(true).Once()
+	service := NewService(logger, nil, mockBlobStore)
+	service.store = mockStore
+
+	storedCover, err := service.StoreCover(ctx, bytes.NewReader(content))
+	require.NoError(t, err, "should touch the stored cover, so it does not expire")
+	assert.Equal(t, expectedCover, storedCover)
+}
+
+func TestService_ExpireUnusedCovers(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.Level(100)}))
+	ctx := context.Background()
+
+	mockStore := NewMockStore(t)
+	mockStore.EXPECT().DeleteExpiredCovers(ctx, time.Hour).Return([]string{"abc", "def"}, nil).Once()
+	mockBlobStore := NewMockBlobStore(t)
+	mockBlobStore.EXPECT().DeleteCover(ctx, "sha256/abc").Return(errors.New("blob store error")).Once()
+	mockBlobStore.EXPECT().DeleteCover(ctx, "sha256/def").Return(nil).Once()
+	service := NewService(logger, nil, mockBlobStore)
+	service.store = mockStore
+
+	expired, err := service.ExpireUnusedCovers(ctx, time.Hour)
+	require.NoError(t, err, "the object removal failure should not fail the expiry")
+	assert.Equal(t, 2, expired)
+
+	mockStore.EXPECT().DeleteExpiredCovers(ctx, time.Hour).Return(nil, errors.New("db error")).Once()
+	_, err = service.ExpireUnusedCovers(ctx, time.Hour)
+	assert.Error(t, err)
+}
+
+func TestService_AssignBookCover(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.Level(100)}))
+	ctx := context.Background()
+	content := []byte(`<svg xmlns="http://www.w3.org/2000/svg" width="300" height="400"></svg>`)
+
+	mockStore := NewMockStore(t)
+	mockStore.EXPECT().SetBookCover(ctx, int64(1), "abc").Return(nil).Once()
+	mockStore.EXPECT().SetBookCoverMetadata(ctx, int64(1), mock.Anything).
+		RunAndReturn(func(ctx context.Context, bookID int64, metadata CoverMetadata) error {
+			assert.Equal(t, 300, metadata.Width)
+			assert.Equal(t, 400, metadata.Height)
+			return nil
+		}).Once()
+	mockBlobStore := NewMockBlobStore(t)
+	mockBlobStore.EXPECT().GetBookCover(ctx, "sha256/abc").Return(bytes.NewReader(content), nil).Once()
+	service := NewService(logger, nil, mockBlobStore)
+	service.store = mockStore
+
+	err := service.AssignBookCover(ctx, 1, "abc")
+	require.NoError(t, err, "should assign cover")
+
+	mockStore.EXPECT().SetBookCover(ctx, int64(2), "abc").Return(ErrNotFound).Once()
+	err = service.AssignBookCover(ctx, 2, "abc")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestService_DiscardCover(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.Level(100)}))
+	ctx := context.Background()
+
+	mockStore := NewMockStore(t)
+	mockStore.EXPECT().DeleteUnusedCover(ctx, "abc").Return(true, nil).Once()
+	mockStore.EXPECT().DeleteUnusedCover(ctx, "def").Return(false, nil).Once()
+	mockBlobStore := NewMockBlobStore(t)
+	mockBlobStore.EXPECT().DeleteCover(ctx, "sha256/abc").Return(nil).Once()
+	service := NewService(logger, nil, mockBlobStore)
+	service.store = mockStore
+
+	require.NoError(t, service.DiscardCover(ctx, "abc"), "should discard cover")
+	require.NoError(t, service.DiscardCover(ctx, "def"), "the cover in use should be kept")
+}
+
+func TestService_UploadBookCover_Invalid(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.Level(100)}))
+	ctx := context.Background()
+	service := NewService(logger, nil, NewMockBlobStore(t))
+	service.store = NewMockStore(t)
+
+	_, err := service.UploadBookCover(ctx, 1, bytes.NewBufferString("not an image"))
+	assert.ErrorIs(t, err, ErrUnsupportedType)
+
+	_, err = service.UploadBookCover(ctx, 1, bytes.NewReader(make([]byte, MaxCoverSize+1)))
+	assert.ErrorIs(t, err, ErrTooLarge)
+}
+
+func TestService_RehashLegacyCovers(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.Level(100)}))
+	ctx := context.Background()
+	content := []byte(`<svg xmlns="http://www.w3.org/2000/svg" width="300" height="400"></svg>`)
+	expectedCover, err := describe(content)
+	require.NoError(t, err)
+	references := []Reference{
+		{BookID: 1, Publisher: "OReilly", CoverFileName: "1111111111.svg"},
+		{BookID: 2, Publisher: "Manning", CoverFileName: "2222222222.svg"},
+		{BookID: 3, Publisher: "Manning", CoverFileName: "3333333333.svg"},
+		{BookID: 4, Publisher: "Manning", CoverFileName: "4444444444.svg", CoverHash: expectedCover.Hash},
+		{BookID: 5, Publisher: "Packt", CoverFileName: ""},
+	}
+
+	mockStore := NewMockStore(t)
+	mockStore.EXPECT().StreamReferences(ctx, mock.Anything).
+		RunAndReturn(func(ctx context.Context, fn func(reference Reference) error) error {
+			for _, reference := range references {
+				if err := fn(reference); err != nil {
+					return err
+				}
+			}
+			return nil
+		}).Once()
+	mockStore.EXPECT().GetCover(ctx, expectedCover.Hash).Return(Cover{}, ErrNotFound).Once()
+	mockStore.EXPECT().SaveCover(ctx, expectedCover).Return(nil).Once()
+	mockStore.EXPECT().GetCover(ctx, expectedCover.Hash).Return(expectedCover, nil).Once()
+	mockStore.EXPECT().SetBookCover(ctx, int64(1), expectedCover.Hash).Return(nil).Once()
+	mockStore.EXPECT().SetBookCover(ctx, int64(2), expectedCover.Hash).Return(nil).Once()
+
+	mockBlobStore := NewMockBlobStore(t)
+	mockBlobStore.EXPECT().CoverExists(ctx, "oreilly/1111111111.svg").Return(true).Once()
+	mockBlobStore.EXPECT().CoverExists(ctx, "manning/2222222222.svg").Return(true).Once()
+	mockBlobStore.EXPECT().CoverExists(ctx, "manning/3333333333.svg").Return(false).Once()
+	mockBlobStore.EXPECT().CoverExists(ctx, "sha256/"+expectedCover.Hash).Return(true).Once()
+	mockBlobStore.EXPECT().GetBookCover(ctx, "oreilly/1111111111.svg").Return(bytes.NewReader(content), nil).Once()
+	mockBlobStore.EXPECT().GetBookCover(ctx, "manning/2222222222.svg").Return(bytes.NewReader(content), nil).Once()
+	mockBlobStore.EXPECT().
+		PutCover(ctx, "sha256/"+expectedCover.Hash, mock.Anything, expectedCover.Size, expectedCover.MIMEType).
+		Return(nil).Once()
+	mockBlobStore.EXPECT().DeleteCover(ctx, "oreilly/1111111111.svg").Return(nil).Once()
+	mockBlobStore.EXPECT().DeleteCover(ctx, "manning/2222222222.svg").Return(nil).Once()
+	service := NewService(logger, nil, mockBlobStore)
+	service.store = mockStore
+
+	report, err := service.RehashLegacyCovers(ctx, true)
+	require.NoError(t, err, "should rehash legacy covers")
+	assert.Equal(t, RehashReport{
+		BooksScanned:  5,
+		Rehashed:      2,
+		Deduplicated:  1,
+		LegacyDeleted: 2,
+		MissingCovers: []MissingCover{{
+			BookID: 3, Publisher: "Manning", CoverFileName: "3333333333.svg", ExpectedPath: "manning/3333333333.svg",
+		}},
+		RehashFailures: []CoverFailure{},
+	}, report)
+}
+
+func TestService_GetCoverByBookID_Stored(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.Level(100)}))
+	ctx := context.Background()
+	storedCover := Cover{Hash: "abc", Size: 3, MIMEType: "image/jpeg"}
+	bookCover := BookCover{Reference: Reference{BookID: 1, Publisher: "OReilly", CoverHash: storedCover.Hash}}
+
+	mockStore := NewMockStore(t)
+	mockStore.EXPECT().GetBookCoverReference(ctx, int64(1)).Return(bookCover, nil).Once()
+	mockStore.EXPECT().GetCover(ctx, storedCover.Hash).Return(storedCover, nil).Once()
+	mockBlobStore := NewMockBlobStore(t)
+	mockBlobStore.EXPECT().CoverExists(ctx, "sha256/abc").Return(true).Once()
+	mockBlobStore.EXPECT().GetBookCover(ctx, "sha256/abc").Return(bytes.NewBufferString("abc"), nil).Once()
+	service := NewService(logger, nil, mockBlobStore)
+	service.store = mockStore
+
+	file, err := service.GetCoverByBookID(ctx, 1, PlaceholderSVG)
+	require.NoError(t, err, "should return the stored cover")
+	assert.Equal(t, "image/jpeg", file.ContentType)
+	assert.Equal(t, "abc", file.ETag)
+	content, err := io.ReadAll(file.Content)
+	require.NoError(t, err)
+	assert.Equal(t, "abc", string(content))
+}
+
+func TestService_GetCoverByBookID_NoFallback(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.Level(100)}))
+	ctx := context.Background()
+	bookCover := BookCover{Reference: Reference{BookID: 1, Publisher: "OReilly", CoverFileName: "1111111111.jpg"}}
+
+	mockStore := NewMockStore(t)
+	mockStore.EXPECT().GetBookCoverReference(ctx, int64(1)).Return(bookCover, nil).Once()
+	mockBlobStore := NewMockBlobStore(t)
+	mockBlobStore.EXPECT().CoverExists(ctx, "oreilly/1111111111.jpg").Return(false).Once()
+	service := NewService(logger, nil, mockBlobStore)
+	service.store = mockStore
+
+	_, err := service.GetCoverByBookID(ctx, 1, "")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestService_GetCoverByBookID_Placeholder(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.Level(100)}))
+	ctx := context.Background()
+	bookCover := BookCover{
+		Reference: Reference{BookID: 1, Publisher: "OReilly"},
+		Title:     "Learning Go",
+		Authors:   []string{"Jon Bodner"},
+	}
+	key := bookCover.placeholder().key(PlaceholderSVG)
+	placeholderPath := "placeholders/" + key + ".svg"
+	expectedContent, err := renderPlaceholder(bookCover.placeholder(), PlaceholderSVG)
+	require.NoError(t, err)
+
+	mockStore := NewMockStore(t)
+	mockStore.EXPECT().GetBookCoverReference(ctx, int64(1)).Return(bookCover, nil).Twice()
+	mockBlobStore := NewMockBlobStore(t)
+	mockBlobStore.EXPECT().CoverExists(ctx, placeholderPath).Return(false).Once()
+	mockBlobStore.EXPECT().PutCover(ctx, placeholderPath, mock.Anything, int64(len(expectedContent)), "image/svg+xml").
+		Return(nil).Once()
+	mockBlobStore.EXPECT().CoverExists(ctx, placeholderPath).Return(true).Once()
+	mockBlobStore.EXPECT().GetBookCover(ctx, placeholderPath).Return(bytes.NewReader(expectedContent), nil).Once()
+	service := NewService(logger, nil, mockBlobStore)
+	service.store = mockStore
+
+	for _, description := range []string{"generated", "cached"} {
+		file, err := service.GetCoverByBookID(ctx, 1, PlaceholderSVG)
+		require.NoError(t, err, "should return the %s placeholder", description)
+		assert.Equal(t, "image/svg+xml", file.ContentType)
+		assert.Equal(t, key, file.ETag)
+		content, err := io.ReadAll(file.Content)
+		require.NoError(t, err)
+		assert.Equal(t, expectedContent, content)
+	}
+}
+
+func TestService_BackfillCoverMetadata(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.Level(100)}))
+	ctx := context.Background()
+	content := bytes.Buffer{}
+	img := image.NewRGBA(image.Rect(0, 0, 40, 60))
+	draw.Draw(img, img.Bounds(), image.NewUniform(color.RGBA{R: 200, G: 16, B: 32, A: 255}), image.Point{}, draw.Src)
+	require.NoError(t, png.Encode(&content, img))
+	references := []Reference{
+		{BookID: 1, Publisher: "OReilly", CoverFileName: "1111111111.png"},
+		{BookID: 2, Publisher: "Manning", CoverFileName: "2222222222.png", HasMetadata: true},
+		{BookID: 3, Publisher: "Manning", CoverFileName: "3333333333.png"},
+		{BookID: 4, Publisher: "Manning", CoverHash: "abc"},
+		{BookID: 5, Publisher: "Packt", CoverFileName: ""},
+	}
+
+	mockStore := NewMockStore(t)
+	mockStore.EXPECT().StreamReferences(ctx, mock.Anything).
+		RunAndReturn(func(ctx context.Context, fn func(reference Reference) error) error {
+			for _, reference := range references {
+				if err := fn(reference); err != nil {
+					return err
+				}
+			}
+			return nil
+		}).Once()
+	mockStore.EXPECT().SetBookCoverMetadata(ctx, int64(1), mock.Anything).
+		RunAndReturn(func(ctx context.Context, bookID int64, metadata CoverMetadata) error {
+			assert.Equal(t, 40, metadata.Width)
+			assert.Equal(t, 60, metadata.Height)
+			assert.Equal(t, 0.6667, metadata.AspectRatio)
+			assert.Equal(t, "#c81020", metadata.DominantColor)
+			assert.Len(t, metadata.BlurHash, 28)
+			return nil
+		}).Once()
+
+	mockBlobStore := NewMockBlobStore(t)
+	mockBlobStore.EXPECT().CoverExists(ctx, "oreilly/1111111111.png").Return(true).Once()
+	mockBlobStore.EXPECT().CoverExists(ctx, "manning/3333333333.png").Return(false).Once()
+	mockBlobStore.EXPECT().CoverExists(ctx, "sha256/abc").Return(true).Once()
+	mockBlobStore.EXPECT().GetBookCover(ctx, "oreilly/1111111111.png").
+		Return(bytes.NewReader(content.Bytes()), nil).Once()
+	mockBlobStore.EXPECT().GetBookCover(ctx, "sha256/abc").Return(bytes.NewBufferString("not an image"), nil).Once()
+	service := NewService(logger, nil, mockBlobStore)
+	service.store = mockStore
+
+	report, err := service.BackfillCoverMetadata(ctx, false)
+	require.NoError(t, err, "should backfill cover metadata")
+	assert.Equal(t, int64(5), report.BooksScanned)
+	assert.Equal(t, int64(1), report.Updated)
+	assert.Equal(t, []MissingCover{{
+		BookID: 3, Publisher: "Manning", CoverFileName: "3333333333.png", ExpectedPath: "manning/3333333333.png",
+	}}, report.MissingCovers)
+	require.Len(t, report.Failures, 1)
+	assert.Equal(t, int64(4), report.Failures[0].BookID)
+	assert.Equal(t, "sha256/abc", report.Failures[0].Path)
+}
+
+func getTestPNG(t *testing.T) []byte {
+	content := bytes.Buffer{}
+	require.NoError(t, png.Encode(&content, image.NewRGBA(image.Rect(0, 0, 30, 40))))
+	return content.Bytes()
+}
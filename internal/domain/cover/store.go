@@ -0,0 +1,225 @@
+package cover
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/jmoiron/sqlx"
+	"time"
+)
+
+type DBStore struct {
+	db *sqlx.DB
+}
+
+func NewDBStore(db *sqlx.DB) *DBStore {
+	return &DBStore{db: db}
+}
+
+// StreamReferences - calls the provided function for each book cover reference, ordered by book ID.
+// The rows are read one by one, so the whole book table is never loaded into memory
+func (s *DBStore) StreamReferences(ctx context.Context, fn func(reference Reference) error) error {
+	query := `SELECT books.id AS id, COALESCE(publishers.name, '') AS publisher, cover_file_name, cover_hash,
+       cover_width IS NOT NULL AS has_metadata
+FROM ebook.books
+         LEFT JOIN ebook.publishers ON books.publisher_id = publishers.id
+ORDER BY books.id`
+
+	rows, err := s.db.QueryxContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	for rows.Next() {
+		var row referenceEntity
+		if err := rows.StructScan(&row); err != nil {
+			return err
+		}
+		if err := fn(row.toReference()); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// GetBookCoverReference - returns the book cover reference along with the book details,
+// if the book is present, otherwise returns ErrNotFound
+func (s *DBStore) GetBookCoverReference(ctx context.Context, bookID int64) (BookCover, error) {
+	query := `SELECT books.id AS id, title, COALESCE(publishers.name, '') AS publisher, cover_file_name, cover_hash,
+       cover_width IS NOT NULL AS has_metadata,
+       ARRAY_REMOVE(ARRAY_AGG(authors.name ORDER BY authors.name), NULL) AS authors
+FROM ebook.books
+         LEFT JOIN ebook.publishers ON books.publisher_id = publishers.id
+         LEFT JOIN ebook.book_author ON books.id = book_author.book_id
+         LEFT JOIN ebook.authors ON authors.id = book_author.author_id
+WHERE books.id = $1
+  AND books.deleted_at IS NULL
+GROUP BY books.id, title, publishers.name, cover_file_name, cover_hash`
+
+	var entity bookCoverEntity
+	if err := s.db.GetContext(ctx, &entity, query, bookID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return BookCover{}, ErrNotFound
+		}
+		return BookCover{}, err
+	}
+
+	return entity.toBookCover(), nil
+}
+
+// SetBookCoverMetadata - records the book cover image metadata, the unknown values are set to NULL
+func (s *DBStore) SetBookCoverMetadata(ctx context.Context, bookID int64, metadata CoverMetadata) error {
+	query := `UPDATE ebook.books
+SET cover_width          = :cover_width,
+    cover_height         = :cover_height,
+    cover_aspect_ratio   = :cover_aspect_ratio,
+    cover_dominant_color = :cover_dominant_color,
+    cover_blurhash       = :cover_blurhash,
+    updated_at           = now()
+WHERE id = :id`
+	result, err := s.db.NamedExecContext(ctx, query, newCoverMetadataEntity(bookID, metadata))
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// ClearCoverFileName - removes a dangling cover reference from a book, both the legacy and the content-addressed one,
+// along with the cover metadata
+func (s *DBStore) ClearCoverFileName(ctx context.Context, bookID int64) error {
+	query := `UPDATE ebook.books
+SET cover_file_name      = '',
+    cover_hash           = NULL,
+    cover_width          = NULL,
+    cover_height         = NULL,
+    cover_aspect_ratio   = NULL,
+    cover_dominant_color = NULL,
+    cover_blurhash       = NULL,
+    updated_at           = now()
+WHERE id = $1`
+	result, err := s.db.ExecContext(ctx, query, bookID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// GetCover - returns the content-addressed cover metadata by the cover hash
+func (s *DBStore) GetCover(ctx context.Context, hash string) (Cover, error) {
+	query := "SELECT hash, size, mime_type, width, height FROM ebook.covers WHERE hash = $1"
+
+	var entity coverEntity
+	if err := s.db.GetContext(ctx, &entity, query, hash); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Cover{}, ErrNotFound
+		}
+		return Cover{}, err
+	}
+
+	return entity.toCover(), nil
+}
+
+// GetCoverHashes - returns the hashes of all the stored content-addressed covers, including the ones no book
+// points to, e.g. the replaced covers and the covers of the ingest drafts
+func (s *DBStore) GetCoverHashes(ctx context.Context) ([]string, error) {
+	hashes := make([]string, 0)
+	if err := s.db.SelectContext(ctx, &hashes, "SELECT hash FROM ebook.covers ORDER BY hash"); err != nil {
+		return nil, err
+	}
+
+	return hashes, nil
+}
+
+// SaveCover - stores the content-addressed cover metadata. Saving the same cover twice is a no-op
+func (s *DBStore) SaveCover(ctx context.Context, cover Cover) error {
+	query := `INSERT INTO ebook.covers (hash, size, mime_type, width, height)
+VALUES (:hash, :size, :mime_type, :width, :height)
+ON CONFLICT (hash) DO NOTHING`
+
+	_, err := s.db.NamedExecContext(ctx, query, newCoverEntity(cover))
+
+	return err
+}
+
+// DeleteUnusedCover - deletes the content-addressed cover metadata, unless some book points to the cover.
+// Returns 'false' if the cover is in use, or does not exist
+func (s *DBStore) DeleteUnusedCover(ctx context.Context, hash string) (bool, error) {
+	query := `DELETE FROM ebook.covers c
+WHERE c.hash = $1
+  AND NOT EXISTS (SELECT 1 FROM ebook.books b WHERE b.cover_hash = c.hash)`
+	result, err := s.db.ExecContext(ctx, query, hash)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+// DeleteExpiredCovers - deletes the content-addressed covers metadata, no book points to, stored longer than
+// the given time ago. Returns the hashes of the deleted covers
+func (s *DBStore) DeleteExpiredCovers(ctx context.Context, olderThan time.Duration) ([]string, error) {
+	query := `DELETE FROM ebook.covers c
+WHERE c.created_at < now() - make_interval(secs => $1)
+  AND NOT EXISTS (SELECT 1 FROM ebook.books b WHERE b.cover_hash = c.hash)
+RETURNING c.hash`
+
+	hashes := make([]string, 0)
+	if err := s.db.SelectContext(ctx, &hashes, query, olderThan.Seconds()); err != nil {
+		return nil, err
+	}
+
+	return hashes, nil
+}
+
+// TouchCover - resets the content-addressed cover creation time, so the unused cover expiry starts over
+func (s *DBStore) TouchCover(ctx context.Context, hash string) error {
+	_, err := s.db.ExecContext(ctx, "UPDATE ebook.covers SET created_at = now() WHERE hash = $1", hash)
+
+	return err
+}
+
+// SetBookCover - points a book to the content-addressed cover
+func (s *DBStore) SetBookCover(ctx context.Context, bookID int64, hash string) error {
+	query := "UPDATE ebook.books SET cover_hash = $1, updated_at = now() WHERE id = $2"
+	result, err := s.db.ExecContext(ctx, query, hash, bookID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
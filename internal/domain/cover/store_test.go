@@ -0,0 +1,229 @@
+package cover
+
+import (
+	"context"
+	"github.com/jmoiron/sqlx"
+	"github.com/sdreger/lib-manager-go/internal/tests"
+	"github.com/stretchr/testify/suite"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"os"
+	"testing"
+	"time"
+)
+
+const (
+	testCoverHash = "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+)
+
+type TestStoreSuite struct {
+	suite.Suite
+	db            *sqlx.DB
+	testContainer *postgres.PostgresContainer
+	store         *DBStore
+}
+
+func (s *TestStoreSuite) SetupSuite() {
+	testContainer := tests.StartDBTestContainer(s.T())
+	dbConfig := tests.GetTestDBConfig(s.T(), testContainer)
+	connection := tests.SetUpTestDB(s.Suite.Require(), dbConfig, testContainer)
+
+	s.store = NewDBStore(connection)
+	s.db = connection
+	s.testContainer = testContainer
+}
+
+func (s *TestStoreSuite) SetupTest() {
+	ctx := context.Background()
+	err := s.testContainer.Restore(ctx)
+	s.Require().NoError(err)
+}
+
+func (s *TestStoreSuite) TearDownSuite() {
+	err := s.db.Close()
+	s.Require().NoError(err, "failed to close database connection")
+}
+
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(TestStoreSuite))
+}
+
+// -------------------- Tests --------------------
+
+func (s *TestStoreSuite) Test_StreamReferences() {
+	err := prepareTestData(s.testContainer, "testdata/cover_references.sql")
+	s.Require().NoError(err, "failed to load test SQL file")
+
+	var references []Reference
+	err = s.store.StreamReferences(context.Background(), func(reference Reference) error {
+		references = append(references, reference)
+		return nil
+	})
+	s.Require().NoError(err, "failed to stream cover references")
+	s.Equal([]Reference{
+		{BookID: 1, Publisher: "OReilly", CoverFileName: "1111111111.jpg"},
+		{BookID: 2, Publisher: "Manning", CoverFileName: "2222222222.jpg", CoverHash: testCoverHash},
+		{BookID: 3, Publisher: "Manning", CoverFileName: ""},
+	}, references)
+}
+
+func (s *TestStoreSuite) Test_StreamReferences_Error() {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel() // should cause DB query error
+
+	err := s.store.StreamReferences(ctx, func(reference Reference) error { return nil })
+	s.Require().Error(err, "streaming should fail")
+}
+
+func (s *TestStoreSuite) Test_ClearCoverFileName() {
+	ctx := context.Background()
+	err := prepareTestData(s.testContainer, "testdata/cover_references.sql")
+	s.Require().NoError(err, "failed to load test SQL file")
+
+	err = s.store.ClearCoverFileName(ctx, 2)
+	s.Require().NoError(err, "failed to clear cover file name")
+
+	var reference referenceEntity
+	err = s.db.GetContext(ctx, &reference,
+		"SELECT id, '' AS publisher, cover_file_name, cover_hash FROM ebook.books WHERE id = 2")
+	s.Require().NoError(err)
+	s.Empty(reference.CoverFileName)
+	s.False(reference.CoverHash.Valid, "the content-addressed cover reference should be cleared")
+
+	err = s.store.ClearCoverFileName(ctx, 100)
+	s.ErrorIs(err, ErrNotFound)
+}
+
+func (s *TestStoreSuite) Test_GetBookCoverReference() {
+	ctx := context.Background()
+	err := prepareTestData(s.testContainer, "testdata/cover_references.sql")
+	s.Require().NoError(err, "failed to load test SQL file")
+
+	bookCover, err := s.store.GetBookCoverReference(ctx, 1)
+	s.Require().NoError(err, "failed to get book cover reference")
+	s.Equal(BookCover{
+		Reference: Reference{BookID: 1, Publisher: "OReilly", CoverFileName: "1111111111.jpg"},
+		Title:     "Book 01",
+		Authors:   []string{"Amanda Lee", "John Doe"},
+	}, bookCover)
+
+	bookCover, err = s.store.GetBookCoverReference(ctx, 3)
+	s.Require().NoError(err, "failed to get book cover reference")
+	s.Empty(bookCover.Authors)
+
+	_, err = s.store.GetBookCoverReference(ctx, 100)
+	s.ErrorIs(err, ErrNotFound)
+}
+
+func (s *TestStoreSuite) Test_SetBookCoverMetadata() {
+	ctx := context.Background()
+	err := prepareTestData(s.testContainer, "testdata/cover_references.sql")
+	s.Require().NoError(err, "failed to load test SQL file")
+
+	metadata := CoverMetadata{
+		Width: 300, Height: 400, AspectRatio: 0.75, DominantColor: "#1020c8", BlurHash: "LfTI:j|cfQ|c|csUfQsUfQfQfQfQ",
+	}
+	err = s.store.SetBookCoverMetadata(ctx, 1, metadata)
+	s.Require().NoError(err, "failed to set book cover metadata")
+
+	var entity coverMetadataEntity
+	err = s.db.GetContext(ctx, &entity, `SELECT id, cover_width, cover_height, cover_aspect_ratio,
+       cover_dominant_color, cover_blurhash FROM ebook.books WHERE id = 1`)
+	s.Require().NoError(err)
+	s.Equal(newCoverMetadataEntity(1, metadata), entity)
+
+	var references []Reference
+	err = s.store.StreamReferences(ctx, func(reference Reference) error {
+		references = append(references, reference)
+		return nil
+	})
+	s.Require().NoError(err, "failed to stream cover references")
+	s.True(references[0].HasMetadata, "the book should have the cover metadata")
+	s.False(references[1].HasMetadata, "the book should not have the cover metadata")
+
+	err = s.store.SetBookCoverMetadata(ctx, 100, metadata)
+	s.ErrorIs(err, ErrNotFound)
+}
+
+func (s *TestStoreSuite) Test_GetCover() {
+	ctx := context.Background()
+	err := prepareTestData(s.testContainer, "testdata/cover_references.sql")
+	s.Require().NoError(err, "failed to load test SQL file")
+
+	cover, err := s.store.GetCover(ctx, testCoverHash)
+	s.Require().NoError(err, "failed to get cover")
+	s.Equal(Cover{Hash: testCoverHash, Size: 1024, MIMEType: "image/jpeg", Width: 300, Height: 400}, cover)
+
+	_, err = s.store.GetCover(ctx, "unknown")
+	s.ErrorIs(err, ErrNotFound)
+
+	hashes, err := s.store.GetCoverHashes(ctx)
+	s.Require().NoError(err, "failed to get cover hashes")
+	s.Equal([]string{testCoverHash}, hashes)
+}
+
+func (s *TestStoreSuite) Test_SaveCover_SetBookCover() {
+	ctx := context.Background()
+	err := prepareTestData(s.testContainer, "testdata/cover_references.sql")
+	s.Require().NoError(err, "failed to load test SQL file")
+
+	newCover := Cover{
+		Hash:     "bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb",
+		Size:     2048,
+		MIMEType: "image/svg+xml",
+	}
+	s.Require().NoError(s.store.SaveCover(ctx, newCover), "failed to save cover")
+	s.Require().NoError(s.store.SaveCover(ctx, newCover), "saving the same cover should be a no-op")
+
+	savedCover, err := s.store.GetCover(ctx, newCover.Hash)
+	s.Require().NoError(err, "failed to get cover")
+	s.Equal(newCover, savedCover)
+
+	err = s.store.SetBookCover(ctx, 1, newCover.Hash)
+	s.Require().NoError(err, "failed to set book cover")
+	var coverHash string
+	err = s.db.GetContext(ctx, &coverHash, "SELECT cover_hash FROM ebook.books WHERE id = 1")
+	s.Require().NoError(err)
+	s.Equal(newCover.Hash, coverHash)
+
+	err = s.store.SetBookCover(ctx, 100, newCover.Hash)
+	s.ErrorIs(err, ErrNotFound)
+
+	deleted, err := s.store.DeleteUnusedCover(ctx, newCover.Hash)
+	s.Require().NoError(err, "failed to delete cover")
+	s.False(deleted, "the cover in use should be kept")
+	s.Require().NoError(s.store.SetBookCover(ctx, 1, testCoverHash))
+	deleted, err = s.store.DeleteUnusedCover(ctx, newCover.Hash)
+	s.Require().NoError(err, "failed to delete cover")
+	s.True(deleted, "the unused cover should be deleted")
+	_, err = s.store.GetCover(ctx, newCover.Hash)
+	s.ErrorIs(err, ErrNotFound)
+}
+
+func (s *TestStoreSuite) Test_DeleteExpiredCovers_TouchCover() {
+	ctx := context.Background()
+	err := prepareTestData(s.testContainer, "testdata/cover_references.sql")
+	s.Require().NoError(err, "failed to load test SQL file")
+
+	unusedHash := "bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"
+	s.Require().NoError(s.store.SaveCover(ctx, Cover{Hash: unusedHash, Size: 2048, MIMEType: "image/png"}))
+	_, err = s.db.ExecContext(ctx, "UPDATE ebook.covers SET created_at = now() - INTERVAL '2 hours'")
+	s.Require().NoError(err)
+
+	s.Require().NoError(s.store.TouchCover(ctx, unusedHash), "failed to touch cover")
+	hashes, err := s.store.DeleteExpiredCovers(ctx, time.Hour)
+	s.Require().NoError(err, "failed to delete expired covers")
+	s.Empty(hashes, "the touched and the used covers should be kept")
+
+	hashes, err = s.store.DeleteExpiredCovers(ctx, 0)
+	s.Require().NoError(err, "failed to delete expired covers")
+	s.Equal([]string{unusedHash}, hashes, "only the unused cover should be deleted")
+}
+
+func prepareTestData(testContainer *postgres.PostgresContainer, fileName string) error {
+	file, err := os.ReadFile(fileName)
+	if err != nil {
+		return err
+	}
+
+	return tests.ExecSQL(testContainer, string(file))
+}
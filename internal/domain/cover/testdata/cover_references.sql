@@ -0,0 +1,15 @@
+INSERT INTO ebook.publishers (id, name) VALUES (1, 'OReilly'), (2, 'Manning');
+INSERT INTO ebook.languages (id, name) VALUES (1, 'English');
+INSERT INTO ebook.covers (hash, size, mime_type, width, height) VALUES ('aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa', 1024, 'image/jpeg', 300, 400);
+
+INSERT INTO ebook.books (id, title, description, pages, edition, language_id, publisher_id, publisher_url, pub_date,
+                         book_file_name, book_file_size, cover_file_name, cover_hash)
+VALUES (1, 'Book 01', 'Book 01 Description', 256, 1, 1, 1, 'https://amazon.com/dp/1111111111.html', '2022-07-19',
+        'OReilly.Book.01.zip', 5192, '1111111111.jpg', NULL),
+       (2, 'Book 02', 'Book 02 Description', 256, 1, 1, 2, 'https://amazon.com/dp/2222222222.html', '2022-07-19',
+        'Manning.Book.02.zip', 5192, '2222222222.jpg', 'aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa'),
+       (3, 'Book 03', 'Book 03 Description', 256, 1, 1, 2, 'https://amazon.com/dp/3333333333.html', '2022-07-19',
+        'Manning.Book.03.zip', 5192, '', NULL);
+
+INSERT INTO ebook.authors (id, name) VALUES (1, 'John Doe'), (2, 'Amanda Lee');
+INSERT INTO ebook.book_author (book_id, author_id) VALUES (1, 1), (1, 2);
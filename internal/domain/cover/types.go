@@ -0,0 +1,148 @@
+package cover
+
+import (
+	"database/sql"
+	"github.com/lib/pq"
+	"strings"
+)
+
+const (
+	hashPathPrefix = "sha256/"
+)
+
+// Cover - a content-addressed cover image, shared by all the books with the same artwork
+type Cover struct {
+	Hash     string `json:"hash"`
+	Size     int64  `json:"size"`
+	MIMEType string `json:"mime_type"`
+	Width    int    `json:"width"`
+	Height   int    `json:"height"`
+}
+
+type coverEntity struct {
+	Hash     string        `db:"hash"`
+	Size     int64         `db:"size"`
+	MIMEType string        `db:"mime_type"`
+	Width    sql.NullInt32 `db:"width"`
+	Height   sql.NullInt32 `db:"height"`
+}
+
+func newCoverEntity(cover Cover) coverEntity {
+	return coverEntity{
+		Hash:     cover.Hash,
+		Size:     cover.Size,
+		MIMEType: cover.MIMEType,
+		Width:    sql.NullInt32{Int32: int32(cover.Width), Valid: cover.Width > 0},
+		Height:   sql.NullInt32{Int32: int32(cover.Height), Valid: cover.Height > 0},
+	}
+}
+
+func (e coverEntity) toCover() Cover {
+	return Cover{
+		Hash:     e.Hash,
+		Size:     e.Size,
+		MIMEType: e.MIMEType,
+		Width:    int(e.Width.Int32),
+		Height:   int(e.Height.Int32),
+	}
+}
+
+// Reference - a book cover reference, stored in the database.
+// The CoverHash is set for content-addressed covers, legacy covers only have the CoverFileName
+type Reference struct {
+	BookID        int64
+	Publisher     string
+	CoverFileName string
+	CoverHash     string
+	HasMetadata   bool
+}
+
+type referenceEntity struct {
+	BookID        int64          `db:"id"`
+	Publisher     string         `db:"publisher"`
+	CoverFileName string         `db:"cover_file_name"`
+	CoverHash     sql.NullString `db:"cover_hash"`
+	HasMetadata   bool           `db:"has_metadata"`
+}
+
+type coverMetadataEntity struct {
+	BookID        int64           `db:"id"`
+	Width         sql.NullInt32   `db:"cover_width"`
+	Height        sql.NullInt32   `db:"cover_height"`
+	AspectRatio   sql.NullFloat64 `db:"cover_aspect_ratio"`
+	DominantColor sql.NullString  `db:"cover_dominant_color"`
+	BlurHash      sql.NullString  `db:"cover_blurhash"`
+}
+
+func newCoverMetadataEntity(bookID int64, metadata CoverMetadata) coverMetadataEntity {
+	return coverMetadataEntity{
+		BookID:        bookID,
+		Width:         sql.NullInt32{Int32: int32(metadata.Width), Valid: metadata.Width > 0},
+		Height:        sql.NullInt32{Int32: int32(metadata.Height), Valid: metadata.Height > 0},
+		AspectRatio:   sql.NullFloat64{Float64: metadata.AspectRatio, Valid: metadata.AspectRatio > 0},
+		DominantColor: sql.NullString{String: metadata.DominantColor, Valid: metadata.DominantColor != ""},
+		BlurHash:      sql.NullString{String: metadata.BlurHash, Valid: metadata.BlurHash != ""},
+	}
+}
+
+func (e referenceEntity) toReference() Reference {
+	return Reference{
+		BookID:        e.BookID,
+		Publisher:     e.Publisher,
+		CoverFileName: e.CoverFileName,
+		CoverHash:     e.CoverHash.String,
+		HasMetadata:   e.HasMetadata,
+	}
+}
+
+// BookCover - the book cover reference along with the book details, used to generate a placeholder cover
+type BookCover struct {
+	Reference
+	Title   string
+	Authors []string
+}
+
+type bookCoverEntity struct {
+	referenceEntity
+	Title   string         `db:"title"`
+	Authors pq.StringArray `db:"authors"`
+}
+
+func (e bookCoverEntity) toBookCover() BookCover {
+	return BookCover{
+		Reference: e.referenceEntity.toReference(),
+		Title:     e.Title,
+		Authors:   e.Authors,
+	}
+}
+
+// placeholder - returns the book details, the placeholder cover is generated from
+func (c BookCover) placeholder() Placeholder {
+	return Placeholder{Title: c.Title, Authors: c.Authors, Publisher: c.Publisher}
+}
+
+// hasCover - returns 'true' if the book references any cover, either legacy or content-addressed
+func (r Reference) hasCover() bool {
+	return r.CoverHash != "" || r.CoverFileName != ""
+}
+
+// ObjectPath - returns the legacy cover bucket object path for the given publisher and cover file name,
+// which is '{publisher}/{cover_file_name}', where the publisher name is lowercase
+func ObjectPath(publisher string, coverFileName string) string {
+	return strings.ToLower(publisher) + "/" + coverFileName
+}
+
+// HashObjectPath - returns the content-addressed cover bucket object path, which is 'sha256/{hash}'.
+// It is also resolvable by the legacy '/v1/covers/{publisher}/{file}' route
+func HashObjectPath(hash string) string {
+	return hashPathPrefix + hash
+}
+
+// path - returns the object path the reference points to
+func (r Reference) path() string {
+	if r.CoverHash != "" {
+		return HashObjectPath(r.CoverHash)
+	}
+
+	return ObjectPath(r.Publisher, r.CoverFileName)
+}
@@ -0,0 +1,129 @@
+package duplicate
+
+import (
+	"cmp"
+	"context"
+	"github.com/jmoiron/sqlx"
+	"github.com/sdreger/lib-manager-go/internal/paging"
+	"log/slog"
+	"slices"
+)
+
+const (
+	DefaultMinScore = 0.75
+)
+
+type Store interface {
+	GetCandidates(ctx context.Context) ([]Candidate, error)
+}
+
+type Service struct {
+	logger *slog.Logger
+	store  Store
+}
+
+func NewService(logger *slog.Logger, db *sqlx.DB) *Service {
+	return &Service{
+		logger: logger,
+		store:  NewDBStore(db),
+	}
+}
+
+// GetDuplicates - returns a requested page of the likely duplicate book clusters, with the score of at least
+// the provided one, from the most certain cluster. The whole catalog is compared on each request,
+// the pairs of books are only compared if they share an identifier, or the first title word
+func (s Service) GetDuplicates(ctx context.Context, pageRequest paging.PageRequest, minScore float64) (
+	paging.Page[Cluster], error) {
+
+	candidates, err := s.store.GetCandidates(ctx)
+	if err != nil {
+		return paging.Page[Cluster]{}, err
+	}
+
+	clusters := findClusters(candidates, minScore)
+	start := min(int(pageRequest.Offset()), len(clusters))
+	end := min(start+int(pageRequest.Limit()), len(clusters))
+
+	return paging.NewPage(pageRequest, int64(len(clusters)), clusters[start:end]), nil
+}
+
+// findClusters - compares the candidate pairs, and joins the matching ones into clusters
+func findClusters(candidates []Candidate, minScore float64) []Cluster {
+	signatures := make([]signature, len(candidates))
+	blocks := make(map[string][]int)
+	for i, candidate := range candidates {
+		signatures[i] = newSignature(candidate)
+		keys := slices.Clone(signatures[i].identifiers)
+		if len(signatures[i].titleTokens) > 0 {
+			keys = append(keys, "title:"+signatures[i].titleTokens[0])
+		}
+		for _, key := range keys {
+			blocks[key] = append(blocks[key], i)
+		}
+	}
+
+	parents := make([]int, len(candidates))
+	for i := range parents {
+		parents[i] = i
+	}
+	var find func(i int) int
+	find = func(i int) int {
+		if parents[i] != i {
+			parents[i] = find(parents[i])
+		}
+		return parents[i]
+	}
+
+	type pair struct{ a, b int }
+	compared := make(map[pair]bool)
+	matches := make(map[pair]match)
+	for _, block := range blocks {
+		for i, a := range block {
+			for _, b := range block[i+1:] {
+				key := pair{a: min(a, b), b: max(a, b)}
+				if compared[key] {
+					continue
+				}
+				compared[key] = true
+				if result, ok := compare(signatures[a], signatures[b]); ok && result.score >= minScore {
+					matches[key] = result
+					parents[find(b)] = find(a)
+				}
+			}
+		}
+	}
+
+	byRoot := make(map[int]*Cluster)
+	for key, result := range matches {
+		root := find(key.a)
+		cluster := byRoot[root]
+		if cluster == nil {
+			cluster = &Cluster{}
+			byRoot[root] = cluster
+		}
+		cluster.Score = max(cluster.Score, result.score)
+		for _, reason := range result.reasons {
+			if !slices.Contains(cluster.Reasons, reason) {
+				cluster.Reasons = append(cluster.Reasons, reason)
+			}
+		}
+	}
+	for i, candidate := range candidates {
+		if cluster := byRoot[find(i)]; cluster != nil {
+			cluster.Books = append(cluster.Books, candidate)
+		}
+	}
+
+	clusters := make([]Cluster, 0, len(byRoot))
+	for _, cluster := range byRoot {
+		slices.SortFunc(cluster.Reasons, func(a, b string) int {
+			return slices.Index(reasonOrder, a) - slices.Index(reasonOrder, b)
+		})
+		clusters = append(clusters, *cluster)
+	}
+	slices.SortFunc(clusters, func(a, b Cluster) int {
+		return cmp.Or(cmp.Compare(b.Score, a.Score), cmp.Compare(a.Books[0].ID, b.Books[0].ID))
+	})
+
+	return clusters
+}
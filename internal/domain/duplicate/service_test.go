@@ -0,0 +1,109 @@
+package duplicate
+
+import (
+	"context"
+	"errors"
+	"github.com/sdreger/lib-manager-go/internal/paging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"log/slog"
+	"os"
+	"testing"
+)
+
+func TestService_GetDuplicates(t *testing.T) {
+	ctx := context.Background()
+	service := getService()
+
+	mockStore := NewMockStore(t)
+	mockStore.EXPECT().GetCandidates(ctx).Return(getTestCandidates(), nil).Once()
+	service.store = mockStore
+
+	pageRequest, _ := paging.NewPageRequest(map[string][]string{"page": {"1"}, "size": {"1"}})
+	page, err := service.GetDuplicates(ctx, pageRequest, DefaultMinScore)
+	require.NoError(t, err, "should find duplicates")
+	assert.Equal(t, int64(2), page.TotalItems)
+	require.Len(t, page.Content, 1)
+
+	cluster := page.Content[0]
+	assert.Equal(t, 1.0, cluster.Score)
+	assert.Equal(t, []string{ReasonIdentifier, ReasonTitle, ReasonAuthors}, cluster.Reasons)
+	assert.Equal(t, []int64{1, 2, 3}, candidateIDs(cluster.Books))
+}
+
+func TestService_GetDuplicates_LastPage(t *testing.T) {
+	ctx := context.Background()
+	service := getService()
+
+	mockStore := NewMockStore(t)
+	mockStore.EXPECT().GetCandidates(ctx).Return(getTestCandidates(), nil).Once()
+	service.store = mockStore
+
+	pageRequest, _ := paging.NewPageRequest(map[string][]string{"page": {"2"}, "size": {"1"}})
+	page, err := service.GetDuplicates(ctx, pageRequest, DefaultMinScore)
+	require.NoError(t, err, "should find duplicates")
+	require.Len(t, page.Content, 1)
+
+	cluster := page.Content[0]
+	assert.Equal(t, 0.9, cluster.Score)
+	assert.Equal(t, []string{ReasonTitle, ReasonAuthors}, cluster.Reasons)
+	assert.Equal(t, []int64{4, 5}, candidateIDs(cluster.Books))
+}
+
+func TestService_GetDuplicates_MinScore(t *testing.T) {
+	ctx := context.Background()
+	service := getService()
+
+	mockStore := NewMockStore(t)
+	mockStore.EXPECT().GetCandidates(ctx).Return(getTestCandidates(), nil).Once()
+	service.store = mockStore
+
+	pageRequest, _ := paging.NewPageRequest(map[string][]string{})
+	page, err := service.GetDuplicates(ctx, pageRequest, 0.95)
+	require.NoError(t, err, "should find duplicates")
+	assert.Equal(t, int64(1), page.TotalItems, "only the identifier match should be left")
+}
+
+func TestService_GetDuplicates_Failure(t *testing.T) {
+	ctx := context.Background()
+	service := getService()
+
+	storeError := errors.New("some error")
+	mockStore := NewMockStore(t)
+	mockStore.EXPECT().GetCandidates(ctx).Return(nil, storeError).Once()
+	service.store = mockStore
+
+	_, err := service.GetDuplicates(ctx, paging.PageRequest{}, DefaultMinScore)
+	require.ErrorIs(t, err, storeError)
+}
+
+func getService() *Service {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	return NewService(logger, nil)
+}
+
+func getTestCandidates() []Candidate {
+	return []Candidate{
+		// the ISBN-10, the ISBN-13 and the ASIN forms of the same number
+		{ID: 1, Title: "Go in Action", ISBN10: "1617291781", Authors: []string{"William Kennedy"}},
+		{ID: 2, Title: "Go in Action, 2nd Edition", ISBN13: 9781617291784, Authors: []string{"William Kennedy"}},
+		{ID: 3, Title: "Unrelated title", ASIN: "1617291781"},
+		// the same title and authors, the co-author is added
+		{ID: 4, Title: "The Go Programming Language", BookFileSize: 1000,
+			Authors: []string{"Alan Donovan", "Brian Kernighan"}},
+		{ID: 5, Title: "Go Programming Language", BookFileSize: 2000, Authors: []string{"alan  donovan"}},
+		// the same title, the different authors
+		{ID: 6, Title: "Go Programming Language", Authors: []string{"John Doe"}},
+		// the different title
+		{ID: 7, Title: "Go Programming Blueprints", Authors: []string{"Alan Donovan"}},
+	}
+}
+
+func candidateIDs(candidates []Candidate) []int64 {
+	ids := make([]int64, len(candidates))
+	for i, candidate := range candidates {
+		ids[i] = candidate.ID
+	}
+
+	return ids
+}
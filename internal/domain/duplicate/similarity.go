@@ -0,0 +1,175 @@
+package duplicate
+
+import (
+	"github.com/sdreger/lib-manager-go/internal/isbn"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const (
+	// the title similarity, a pair of books without the shared identifiers should have at least
+	minTitleSimilarity = 0.8
+	// the relative file size difference, the files are considered equal within
+	maxFileSizeDifference = 0.01
+
+	titleWeight    = 0.6
+	authorsWeight  = 0.3
+	fileSizeWeight = 0.1
+)
+
+var (
+	// the words, which do not tell the books apart: the articles and the edition markers,
+	// so 'The Go Programming Language, 2nd Edition' matches 'Go Programming Language'
+	titleStopWords = []string{"a", "an", "the", "and", "of", "edition", "ed"}
+	ordinalRegexp  = regexp.MustCompile(`^\d+(st|nd|rd|th)$`)
+)
+
+// match - the similarity of a pair of books
+type match struct {
+	score   float64
+	reasons []string
+}
+
+// signature - the normalized book details, prepared once per book
+type signature struct {
+	titleTokens []string
+	authors     []string
+	identifiers []string
+	fileSize    int64
+}
+
+func newSignature(candidate Candidate) signature {
+	result := signature{titleTokens: titleTokens(candidate.Title), fileSize: candidate.BookFileSize}
+	for _, author := range candidate.Authors {
+		if name := strings.Join(strings.Fields(strings.ToLower(author)), " "); name != "" {
+			result.authors = append(result.authors, name)
+		}
+	}
+
+	// the ISBN-10 and ISBN-13 forms of the same number, as well as the ASIN equal to the ISBN-10, are the same key
+	addIdentifier := func(key string) {
+		if !slices.Contains(result.identifiers, key) {
+			result.identifiers = append(result.identifiers, key)
+		}
+	}
+	if candidate.ISBN13 > 0 {
+		addIdentifier(isbnKey(strconv.FormatInt(candidate.ISBN13, 10)))
+	}
+	if candidate.ISBN10 != "" {
+		addIdentifier(isbnKey(candidate.ISBN10))
+	}
+	if candidate.ASIN != "" {
+		if isbn.IsValid10(candidate.ASIN) {
+			addIdentifier(isbnKey(candidate.ASIN))
+		} else {
+			addIdentifier("asin:" + strings.ToUpper(candidate.ASIN))
+		}
+	}
+
+	return result
+}
+
+// compare - returns the similarity of a pair of books, the shared identifier is a certain match.
+// Otherwise, the titles should be similar, and the authors should overlap, unless some of them are unknown
+func compare(a signature, b signature) (match, bool) {
+	titleSimilarity := jaccard(a.titleTokens, b.titleTokens)
+	authorsOverlap := overlap(a.authors, b.authors)
+	sameFileSize := false
+	if a.fileSize > 0 && b.fileSize > 0 {
+		difference := float64(max(a.fileSize, b.fileSize)-min(a.fileSize, b.fileSize)) / float64(max(a.fileSize, b.fileSize))
+		sameFileSize = difference <= maxFileSizeDifference
+	}
+
+	var reasons []string
+	sharedIdentifier := slices.ContainsFunc(a.identifiers, func(identifier string) bool {
+		return slices.Contains(b.identifiers, identifier)
+	})
+	if sharedIdentifier {
+		reasons = append(reasons, ReasonIdentifier)
+	}
+	if titleSimilarity >= minTitleSimilarity {
+		reasons = append(reasons, ReasonTitle)
+	}
+	if authorsOverlap > 0 {
+		reasons = append(reasons, ReasonAuthors)
+	}
+	if sameFileSize {
+		reasons = append(reasons, ReasonFileSize)
+	}
+
+	if sharedIdentifier {
+		return match{score: 1, reasons: reasons}, true
+	}
+	authorsUnknown := len(a.authors) == 0 || len(b.authors) == 0
+	if titleSimilarity < minTitleSimilarity || (authorsOverlap == 0 && !authorsUnknown) {
+		return match{}, false
+	}
+
+	score := titleWeight*titleSimilarity + authorsWeight*authorsOverlap
+	if sameFileSize {
+		score += fileSizeWeight
+	}
+
+	return match{score: math.Round(score*100) / 100, reasons: reasons}, true
+}
+
+// isbnKey - returns the ISBN-13 form of the valid ISBN, the invalid values only match exactly
+func isbnKey(value string) string {
+	if isbn13, err := isbn.To13(value); err == nil {
+		return "isbn:" + isbn13
+	}
+
+	return "isbn:" + isbn.Normalize(value)
+}
+
+// titleTokens - returns the distinct lower case title words, except the stop words
+func titleTokens(title string) []string {
+	words := strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	tokens := make([]string, 0, len(words))
+	for _, word := range words {
+		if slices.Contains(titleStopWords, word) || ordinalRegexp.MatchString(word) || slices.Contains(tokens, word) {
+			continue
+		}
+		tokens = append(tokens, word)
+	}
+
+	return tokens
+}
+
+// jaccard - the size of the intersection divided by the size of the union of the distinct values
+func jaccard(a []string, b []string) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	shared := countShared(a, b)
+
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
+
+// overlap - the size of the intersection divided by the size of the smaller set,
+// so the book with a co-author added in the later edition still matches
+func overlap(a []string, b []string) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+
+	return float64(countShared(a, b)) / float64(min(len(a), len(b)))
+}
+
+func countShared(a []string, b []string) int {
+	shared := 0
+	for _, value := range a {
+		if slices.Contains(b, value) {
+			shared++
+		}
+	}
+
+	return shared
+}
@@ -0,0 +1,45 @@
+package duplicate
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestTitleTokens(t *testing.T) {
+	assert.Equal(t, []string{"go", "programming", "language"}, titleTokens("The Go Programming Language, 2nd ed."))
+	assert.Equal(t, []string{"c", "book"}, titleTokens("C & C: A Book of C"))
+	assert.Empty(t, titleTokens("The 3rd Edition"))
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name    string
+		a       Candidate
+		b       Candidate
+		ok      bool
+		score   float64
+		reasons []string
+	}{
+		{name: "ASIN equal to ISBN-10", ok: true, score: 1, reasons: []string{ReasonIdentifier},
+			a: Candidate{Title: "A", ISBN13: 9781617291784}, b: Candidate{Title: "B", ASIN: "1617291781"}},
+		{name: "same ASIN", ok: true, score: 1, reasons: []string{ReasonIdentifier},
+			a: Candidate{Title: "A", ASIN: "b00abc1234"}, b: Candidate{Title: "B", ASIN: "B00ABC1234"}},
+		{name: "same file size", ok: true, score: 1, reasons: []string{ReasonTitle, ReasonAuthors, ReasonFileSize},
+			a: Candidate{Title: "Book", Authors: []string{"A"}, BookFileSize: 1000},
+			b: Candidate{Title: "Book", Authors: []string{"A"}, BookFileSize: 1005}},
+		{name: "unknown authors", ok: true, score: 0.6, reasons: []string{ReasonTitle},
+			a: Candidate{Title: "Book"}, b: Candidate{Title: "Book", Authors: []string{"A"}}},
+		{name: "different authors",
+			a: Candidate{Title: "Book", Authors: []string{"A"}}, b: Candidate{Title: "Book", Authors: []string{"B"}}},
+		{name: "different titles",
+			a: Candidate{Title: "Book One", Authors: []string{"A"}}, b: Candidate{Title: "Book Two", Authors: []string{"A"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, ok := compare(newSignature(tt.a), newSignature(tt.b))
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.score, result.score)
+			assert.Equal(t, tt.reasons, result.reasons)
+		})
+	}
+}
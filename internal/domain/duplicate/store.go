@@ -0,0 +1,38 @@
+package duplicate
+
+import (
+	"context"
+	"github.com/jmoiron/sqlx"
+)
+
+type DBStore struct {
+	db *sqlx.DB
+}
+
+func NewDBStore(db *sqlx.DB) *DBStore {
+	return &DBStore{db: db}
+}
+
+// GetCandidates - returns the details of all the books, except the deleted ones, ordered by ID
+func (s *DBStore) GetCandidates(ctx context.Context) ([]Candidate, error) {
+	query := `SELECT books.id AS id, title, subtitle, isbn10, isbn13, asin, edition, pub_date, book_file_size,
+       ARRAY_REMOVE(ARRAY_AGG(DISTINCT authors.name), NULL) AS authors
+FROM ebook.books
+         LEFT JOIN ebook.book_author ON books.id = book_author.book_id
+         LEFT JOIN ebook.authors ON authors.id = book_author.author_id
+WHERE books.deleted_at IS NULL
+GROUP BY books.id, title, subtitle, isbn10, isbn13, asin, edition, pub_date, book_file_size
+ORDER BY books.id`
+
+	var rows []candidateEntity
+	if err := s.db.SelectContext(ctx, &rows, query); err != nil {
+		return nil, err
+	}
+
+	candidates := make([]Candidate, 0, len(rows))
+	for _, row := range rows {
+		candidates = append(candidates, row.toCandidate())
+	}
+
+	return candidates, nil
+}
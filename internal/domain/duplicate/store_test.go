@@ -0,0 +1,81 @@
+package duplicate
+
+import (
+	"context"
+	"github.com/jmoiron/sqlx"
+	"github.com/sdreger/lib-manager-go/internal/tests"
+	"github.com/stretchr/testify/suite"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"os"
+	"testing"
+)
+
+type TestStoreSuite struct {
+	suite.Suite
+	db            *sqlx.DB
+	testContainer *postgres.PostgresContainer
+	store         *DBStore
+}
+
+func (s *TestStoreSuite) SetupSuite() {
+	testContainer := tests.StartDBTestContainer(s.T())
+	dbConfig := tests.GetTestDBConfig(s.T(), testContainer)
+	connection := tests.SetUpTestDB(s.Suite.Require(), dbConfig, testContainer)
+
+	s.store = NewDBStore(connection)
+	s.db = connection
+	s.testContainer = testContainer
+}
+
+func (s *TestStoreSuite) SetupTest() {
+	ctx := context.Background()
+	err := s.testContainer.Restore(ctx)
+	s.Require().NoError(err)
+}
+
+func (s *TestStoreSuite) TearDownSuite() {
+	err := s.db.Close()
+	s.Require().NoError(err, "failed to close database connection")
+}
+
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(TestStoreSuite))
+}
+
+// -------------------- Tests --------------------
+
+func (s *TestStoreSuite) Test_GetCandidates() {
+	err := prepareTestData(s.testContainer, "testdata/duplicate_candidates.sql")
+	s.Require().NoError(err, "failed to load test SQL file")
+
+	candidates, err := s.store.GetCandidates(context.Background())
+	s.Require().NoError(err, "failed to get candidates")
+	s.Require().Len(candidates, 2, "the deleted book should be skipped")
+
+	s.Equal(int64(1), candidates[0].ID)
+	s.Equal("The Definitive Guide", candidates[0].Subtitle)
+	s.Equal("1617291781", candidates[0].ISBN10)
+	s.Zero(candidates[0].ISBN13)
+	s.ElementsMatch([]string{"John Doe", "Amanda Lee"}, candidates[0].Authors)
+	s.Equal(int64(2), candidates[1].ID)
+	s.Equal(int64(9781617291784), candidates[1].ISBN13)
+	s.Equal("BH34567890", candidates[1].ASIN)
+	s.Equal([]string{"John Doe"}, []string(candidates[1].Authors))
+}
+
+func (s *TestStoreSuite) Test_GetCandidates_Error() {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel() // should cause DB query error
+
+	_, err := s.store.GetCandidates(ctx)
+	s.Require().Error(err, "candidates query should fail")
+}
+
+func prepareTestData(testContainer *postgres.PostgresContainer, fileName string) error {
+	file, err := os.ReadFile(fileName)
+	if err != nil {
+		return err
+	}
+
+	return tests.ExecSQL(testContainer, string(file))
+}
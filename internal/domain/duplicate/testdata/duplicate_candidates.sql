@@ -0,0 +1,15 @@
+INSERT INTO ebook.languages (id, name) VALUES (1, 'English');
+INSERT INTO ebook.publishers (id, name) VALUES (1, 'OReilly');
+INSERT INTO ebook.authors (id, name) VALUES (1, 'John Doe'), (2, 'Amanda Lee');
+
+INSERT INTO ebook.books (id, title, subtitle, description, isbn10, isbn13, asin, pages, edition, language_id,
+                         publisher_id, publisher_url, pub_date, book_file_name, book_file_size, cover_file_name)
+VALUES (1, 'CockroachDB', 'The Definitive Guide', 'Description', '1617291781', NULL, NULL, 256, 1, 1, 1, '',
+        '2022-07-19', 'CockroachDB.pdf', 5192, ''),
+       (2, 'CockroachDB', NULL, 'Description', NULL, 9781617291784, 'BH34567890', 256, 2, 1, 1, '',
+        '2023-07-19', 'CockroachDB.epub', 4096, '');
+INSERT INTO ebook.book_author (book_id, author_id) VALUES (1, 1), (1, 2), (2, 1);
+
+INSERT INTO ebook.books (id, title, description, pages, edition, language_id, publisher_id, publisher_url, pub_date,
+                         book_file_name, book_file_size, cover_file_name, deleted_at)
+VALUES (3, 'CockroachDB', 'Deleted', 256, 1, 1, 1, '', '2022-07-19', 'CockroachDB.zip', 5192, '', now());
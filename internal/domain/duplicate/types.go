@@ -0,0 +1,67 @@
+package duplicate
+
+import (
+	"database/sql"
+	"github.com/lib/pq"
+	"time"
+)
+
+const (
+	ReasonIdentifier = "identifier"
+	ReasonTitle      = "title"
+	ReasonAuthors    = "authors"
+	ReasonFileSize   = "file_size"
+)
+
+// reasonOrder - the reasons are reported in the order of their significance
+var reasonOrder = []string{ReasonIdentifier, ReasonTitle, ReasonAuthors, ReasonFileSize}
+
+// Candidate - the book details, the duplicates are detected by
+type Candidate struct {
+	ID           int64     `json:"id"`
+	Title        string    `json:"title"`
+	Subtitle     string    `json:"subtitle"`
+	ISBN10       string    `json:"isbn10"`
+	ISBN13       int64     `json:"isbn13"`
+	ASIN         string    `json:"asin"`
+	Edition      uint8     `json:"edition"`
+	PubDate      time.Time `json:"pub_date"`
+	BookFileSize int64     `json:"book_file_size"`
+	Authors      []string  `json:"authors"`
+}
+
+// Cluster - the group of likely duplicate books. The score is the highest pairwise similarity within the group,
+// from 0 to 1, and the reasons are all the signals, the books are matched by
+type Cluster struct {
+	Score   float64     `json:"score"`
+	Reasons []string    `json:"reasons"`
+	Books   []Candidate `json:"books"`
+}
+
+type candidateEntity struct {
+	ID           int64          `db:"id"`
+	Title        string         `db:"title"`
+	Subtitle     sql.NullString `db:"subtitle"`
+	ISBN10       sql.NullString `db:"isbn10"`
+	ISBN13       sql.NullInt64  `db:"isbn13"`
+	ASIN         sql.NullString `db:"asin"`
+	Edition      uint8          `db:"edition"`
+	PubDate      time.Time      `db:"pub_date"`
+	BookFileSize int64          `db:"book_file_size"`
+	Authors      pq.StringArray `db:"authors"`
+}
+
+func (e candidateEntity) toCandidate() Candidate {
+	return Candidate{
+		ID:           e.ID,
+		Title:        e.Title,
+		Subtitle:     e.Subtitle.String,
+		ISBN10:       e.ISBN10.String,
+		ISBN13:       e.ISBN13.Int64,
+		ASIN:         e.ASIN.String,
+		Edition:      e.Edition,
+		PubDate:      e.PubDate,
+		BookFileSize: e.BookFileSize,
+		Authors:      e.Authors,
+	}
+}
@@ -0,0 +1,56 @@
+package relation
+
+import (
+	"context"
+	"github.com/jmoiron/sqlx"
+	"github.com/sdreger/lib-manager-go/internal/paging"
+	"log/slog"
+)
+
+type Store interface {
+	Lookup(ctx context.Context, relation Relation, page paging.PageRequest, sort paging.Sort) ([]Item, int64, error)
+	GetByBookIDs(ctx context.Context, relation Relation, bookIDs []int64) (map[int64][]Item, error)
+}
+
+type Service struct {
+	logger *slog.Logger
+	store  Store
+}
+
+func NewService(logger *slog.Logger, db *sqlx.DB) *Service {
+	return &Service{
+		logger: logger,
+		store:  NewDBStore(db),
+	}
+}
+
+// GetItems - returns a requested page of the relation items
+func (s *Service) GetItems(ctx context.Context, relation Relation, pageRequest paging.PageRequest,
+	sort paging.Sort) (paging.Page[Item], error) {
+
+	items, totalElements, err := s.store.Lookup(ctx, relation, pageRequest, sort)
+	if err != nil {
+		return paging.Page[Item]{}, err
+	}
+
+	return paging.NewPage(pageRequest, totalElements, items), nil
+}
+
+// GetBookItems - returns the relation items of each of the books, the books without items have an empty slice
+func (s *Service) GetBookItems(ctx context.Context, relation Relation, bookIDs []int64) (map[int64][]Item, error) {
+	if len(bookIDs) == 0 {
+		return map[int64][]Item{}, nil
+	}
+
+	items, err := s.store.GetByBookIDs(ctx, relation, bookIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, bookID := range bookIDs {
+		if _, ok := items[bookID]; !ok {
+			items[bookID] = []Item{}
+		}
+	}
+
+	return items, nil
+}
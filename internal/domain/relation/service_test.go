@@ -0,0 +1,91 @@
+package relation
+
+import (
+	"context"
+	"errors"
+	"github.com/sdreger/lib-manager-go/internal/paging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"log/slog"
+	"os"
+	"testing"
+)
+
+func TestService_GetItems(t *testing.T) {
+	ctx := context.Background()
+	service := getService()
+	values := map[string][]string{"page": {"1"}, "size": {"1"}, "sort": {"name,asc"}}
+	pageRequest, _ := paging.NewPageRequest(values)
+	sort, _ := paging.NewSort(values, AllowedSortFields)
+	items := []Item{{ID: 1, Name: "golang"}}
+
+	mockStore := NewMockStore(t)
+	mockStore.EXPECT().Lookup(ctx, Tags, pageRequest, sort).Return(items, 2, nil).Once()
+	injectMocks(service, mockStore)
+
+	page, err := service.GetItems(ctx, Tags, pageRequest, sort)
+	require.NoError(t, err)
+	assert.Equal(t, items, page.Content)
+	assert.Equal(t, int64(2), page.TotalItems)
+	assert.Equal(t, int64(2), page.TotalPages)
+}
+
+func TestService_GetItems_StoreError(t *testing.T) {
+	ctx := context.Background()
+	service := getService()
+	storeError := errors.New("store error")
+
+	mockStore := NewMockStore(t)
+	mockStore.EXPECT().Lookup(ctx, Authors, mock.Anything, mock.Anything).Return(nil, 0, storeError).Once()
+	injectMocks(service, mockStore)
+
+	_, err := service.GetItems(ctx, Authors, paging.PageRequest{}, paging.Sort{})
+	assert.ErrorIs(t, err, storeError)
+}
+
+func TestService_GetBookItems(t *testing.T) {
+	ctx := context.Background()
+	service := getService()
+	authors := []Item{{ID: 1, Name: "William Kennedy"}}
+
+	mockStore := NewMockStore(t)
+	mockStore.EXPECT().GetByBookIDs(ctx, Authors, []int64{1, 2}).
+		Return(map[int64][]Item{1: authors}, nil).Once()
+	injectMocks(service, mockStore)
+
+	items, err := service.GetBookItems(ctx, Authors, []int64{1, 2})
+	require.NoError(t, err)
+	assert.Equal(t, map[int64][]Item{1: authors, 2: {}}, items, "the books without items should have an empty slice")
+}
+
+func TestService_GetBookItems_NoBooks(t *testing.T) {
+	service := getService()
+	injectMocks(service, NewMockStore(t))
+
+	items, err := service.GetBookItems(context.Background(), Authors, nil)
+	require.NoError(t, err)
+	assert.Empty(t, items)
+}
+
+func TestService_GetBookItems_StoreError(t *testing.T) {
+	ctx := context.Background()
+	service := getService()
+	storeError := errors.New("store error")
+
+	mockStore := NewMockStore(t)
+	mockStore.EXPECT().GetByBookIDs(ctx, Tags, mock.Anything).Return(nil, storeError).Once()
+	injectMocks(service, mockStore)
+
+	_, err := service.GetBookItems(ctx, Tags, []int64{1})
+	assert.ErrorIs(t, err, storeError)
+}
+
+func getService() *Service {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	return NewService(logger, nil)
+}
+
+func injectMocks(service *Service, store *MockStore) {
+	service.store = store
+}
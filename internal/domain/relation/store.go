@@ -0,0 +1,66 @@
+package relation
+
+import (
+	"context"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/sdreger/lib-manager-go/internal/paging"
+)
+
+type DBStore struct {
+	db *sqlx.DB
+}
+
+func NewDBStore(db *sqlx.DB) *DBStore {
+	return &DBStore{db: db}
+}
+
+// Lookup - returns a page of the relation items, along with the total number of them
+func (s *DBStore) Lookup(ctx context.Context, relation Relation, page paging.PageRequest, sort paging.Sort) (
+	[]Item, int64, error) {
+
+	query := fmt.Sprintf("SELECT id, name FROM %s ORDER BY %s LIMIT $1 OFFSET $2",
+		relation.table, sort.GetOrderBy(relation.table))
+
+	var rows []itemEntity
+	err := s.db.SelectContext(ctx, &rows, query, page.Limit(), page.Offset())
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var total int64 = 0
+	err = s.db.GetContext(ctx, &total, "SELECT count(id) FROM "+relation.table)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	items := make([]Item, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, Item(row))
+	}
+
+	return items, total, nil
+}
+
+// GetByBookIDs - returns the relation items of the books, sorted by name, in a single query.
+// The books without the relation items are absent in the result
+func (s *DBStore) GetByBookIDs(ctx context.Context, relation Relation, bookIDs []int64) (map[int64][]Item, error) {
+	query := fmt.Sprintf(`SELECT %[1]s AS book_id, item.id, item.name
+FROM %[2]s item
+         %[3]s
+WHERE %[1]s = ANY($1)
+ORDER BY item.name, item.id`, relation.bookColumn, relation.table, relation.bookJoin)
+
+	var rows []bookItemEntity
+	if err := s.db.SelectContext(ctx, &rows, query, pq.Array(bookIDs)); err != nil {
+		return nil, err
+	}
+
+	items := make(map[int64][]Item)
+	for _, row := range rows {
+		items[row.BookID] = append(items[row.BookID], Item{ID: row.ID, Name: row.Name})
+	}
+
+	return items, nil
+}
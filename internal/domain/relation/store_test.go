@@ -0,0 +1,99 @@
+package relation
+
+import (
+	"context"
+	"github.com/jmoiron/sqlx"
+	"github.com/sdreger/lib-manager-go/internal/paging"
+	"github.com/sdreger/lib-manager-go/internal/tests"
+	"github.com/stretchr/testify/suite"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"os"
+	"testing"
+)
+
+type TestStoreSuite struct {
+	suite.Suite
+	db            *sqlx.DB
+	testContainer *postgres.PostgresContainer
+	store         *DBStore
+}
+
+func (s *TestStoreSuite) SetupSuite() {
+	testContainer := tests.StartDBTestContainer(s.T())
+	dbConfig := tests.GetTestDBConfig(s.T(), testContainer)
+	connection := tests.SetUpTestDB(s.Suite.Require(), dbConfig, testContainer)
+
+	s.store = NewDBStore(connection)
+	s.db = connection
+	s.testContainer = testContainer
+}
+
+func (s *TestStoreSuite) SetupTest() {
+	ctx := context.Background()
+	err := s.testContainer.Restore(ctx)
+	s.Require().NoError(err)
+
+	err = prepareTestData(s.testContainer, "testdata/book_relations.sql")
+	s.Require().NoError(err, "failed to load test SQL file")
+}
+
+func (s *TestStoreSuite) TearDownSuite() {
+	err := s.db.Close()
+	s.Require().NoError(err, "failed to close database connection")
+}
+
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(TestStoreSuite))
+}
+
+// -------------------- Tests --------------------
+
+func (s *TestStoreSuite) Test_Lookup() {
+	ctx := context.Background()
+	values := map[string][]string{"page": {"1"}, "size": {"2"}, "sort": {"name,asc"}}
+	pageRequest, _ := paging.NewPageRequest(values)
+	sort, _ := paging.NewSort(values, AllowedSortFields)
+
+	items, total, err := s.store.Lookup(ctx, Authors, pageRequest, sort)
+	s.Require().NoError(err)
+	s.Equal(int64(3), total)
+	s.Equal([]Item{{ID: 2, Name: "Brian Ketelsen"}, {ID: 3, Name: "Jon Bodner"}}, items)
+}
+
+func (s *TestStoreSuite) Test_GetByBookIDs() {
+	ctx := context.Background()
+	bookIDs := []int64{1, 2, 3}
+
+	authors, err := s.store.GetByBookIDs(ctx, Authors, bookIDs)
+	s.Require().NoError(err)
+	s.Equal(map[int64][]Item{
+		1: {{ID: 2, Name: "Brian Ketelsen"}, {ID: 1, Name: "William Kennedy"}},
+		2: {{ID: 3, Name: "Jon Bodner"}},
+		3: {{ID: 3, Name: "Jon Bodner"}},
+	}, authors)
+
+	tags, err := s.store.GetByBookIDs(ctx, Tags, bookIDs)
+	s.Require().NoError(err)
+	s.Equal(map[int64][]Item{
+		1: {{ID: 2, Name: "concurrency"}, {ID: 1, Name: "golang"}},
+		2: {{ID: 1, Name: "golang"}},
+	}, tags, "the books without tags should be absent")
+
+	publishers, err := s.store.GetByBookIDs(ctx, Publishers, []int64{1, 2})
+	s.Require().NoError(err)
+	s.Equal(map[int64][]Item{1: {{ID: 1, Name: "Manning"}}, 2: {{ID: 2, Name: "OReilly"}}}, publishers)
+
+	for _, relation := range []Relation{Categories, FileTypes, Languages} {
+		_, err = s.store.GetByBookIDs(ctx, relation, bookIDs)
+		s.Require().NoError(err, "the %q query should be valid", relation.Name)
+	}
+}
+
+func prepareTestData(testContainer *postgres.PostgresContainer, fileName string) error {
+	file, err := os.ReadFile(fileName)
+	if err != nil {
+		return err
+	}
+
+	return tests.ExecSQL(testContainer, string(file))
+}
@@ -0,0 +1,12 @@
+INSERT INTO ebook.publishers (id, name) VALUES (1, 'Manning'), (2, 'OReilly');
+INSERT INTO ebook.languages (id, name) VALUES (1, 'English');
+INSERT INTO ebook.books (id, title, pages, edition, language_id, publisher_id, publisher_url, pub_date,
+                         book_file_name, book_file_size, cover_file_name)
+VALUES (1, 'Go in Action', 264, 1, 1, 1, '', '2015-11-04', 'Go in Action.epub', 5192, ''),
+       (2, 'Learning Go', 375, 1, 1, 2, '', '2021-03-02', 'Learning Go.pdf', 5192, ''),
+       (3, 'Untagged Book', 100, 1, 1, 2, '', '2020-01-01', 'Untagged Book.pdf', 5192, '');
+
+INSERT INTO ebook.authors (id, name) VALUES (1, 'William Kennedy'), (2, 'Brian Ketelsen'), (3, 'Jon Bodner');
+INSERT INTO ebook.book_author (book_id, author_id) VALUES (1, 1), (1, 2), (2, 3), (3, 3);
+INSERT INTO ebook.tags (id, name) VALUES (1, 'golang'), (2, 'concurrency');
+INSERT INTO ebook.book_tag (book_id, tag_id) VALUES (1, 1), (1, 2), (2, 1);
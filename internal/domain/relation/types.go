@@ -0,0 +1,46 @@
+package relation
+
+var (
+	AllowedSortFields = []string{"id", "name"}
+)
+
+// Relation - the named book attribute, stored in a separate table, e.g. the authors or the tags
+type Relation struct {
+	Name  string
+	table string
+	// bookJoin - joins the relation table, aliased as 'item', to the book IDs, selected as 'bookColumn'
+	bookJoin   string
+	bookColumn string
+}
+
+var (
+	Authors = Relation{Name: "authors", table: "ebook.authors",
+		bookJoin: "JOIN ebook.book_author link ON link.author_id = item.id", bookColumn: "link.book_id"}
+	Categories = Relation{Name: "categories", table: "ebook.categories",
+		bookJoin: "JOIN ebook.book_category link ON link.category_id = item.id", bookColumn: "link.book_id"}
+	FileTypes = Relation{Name: "file_types", table: "ebook.file_types",
+		bookJoin: "JOIN ebook.book_file_type link ON link.file_type_id = item.id", bookColumn: "link.book_id"}
+	Languages = Relation{Name: "languages", table: "ebook.languages",
+		bookJoin: "JOIN ebook.books link ON link.language_id = item.id", bookColumn: "link.id"}
+	Publishers = Relation{Name: "publishers", table: "ebook.publishers",
+		bookJoin: "JOIN ebook.books link ON link.publisher_id = item.id", bookColumn: "link.id"}
+	Tags = Relation{Name: "tags", table: "ebook.tags",
+		bookJoin: "JOIN ebook.book_tag link ON link.tag_id = item.id", bookColumn: "link.book_id"}
+)
+
+type Item struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+type itemEntity struct {
+	ID   int64  `db:"id"`
+	Name string `db:"name"`
+}
+
+// bookItemEntity - the relation item of a book
+type bookItemEntity struct {
+	BookID int64  `db:"book_id"`
+	ID     int64  `db:"id"`
+	Name   string `db:"name"`
+}
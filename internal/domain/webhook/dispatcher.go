@@ -0,0 +1,202 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"github.com/sdreger/lib-manager-go/internal/config"
+	"golang.org/x/sync/errgroup"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	dispatchBatchSize   = 20
+	dispatchConcurrency = 4
+	// claimLeaseMargin - the claimed deliveries are held for the request timeout and the margin,
+	// the ones of the crashed dispatcher are claimed again, once the lease expires
+	claimLeaseMargin = time.Minute
+	pruneInterval    = time.Hour
+	maxErrorLength   = 1024
+	// maxResponseDrain - the response body is drained up to the limit, so the connection may be reused
+	maxResponseDrain = 64 << 10
+	userAgent        = "lib-manager-webhooks"
+)
+
+type DispatchStore interface {
+	ClaimDeliveries(ctx context.Context, limit uint64, lease time.Duration) ([]Dispatch, error)
+	SaveResult(ctx context.Context, deliveryID int64, status string, result Result, retryAfter time.Duration) error
+	PruneDeliveries(ctx context.Context, olderThan time.Duration) (int64, error)
+}
+
+// Dispatcher - delivers the queued webhook deliveries. The deliveries are claimed with the row locks,
+// skipping the locked ones, so several dispatchers, one per replica, may share the same outbox.
+// Each delivery is made at least once, the receivers should deduplicate them by the delivery ID header
+type Dispatcher struct {
+	logger *slog.Logger
+	config config.WebhooksConfig
+	store  DispatchStore
+	client *http.Client
+	now    func() time.Time
+}
+
+func NewDispatcher(logger *slog.Logger, webhooksConfig config.WebhooksConfig, db *sqlx.DB) *Dispatcher {
+	return &Dispatcher{
+		logger: logger,
+		config: webhooksConfig,
+		store:  NewDBStore(db),
+		client: &http.Client{},
+		now:    time.Now,
+	}
+}
+
+// Run - polls the outbox until the context is canceled, the finished deliveries past the retention are pruned
+// along the way. The deliveries in flight are completed before the dispatcher stops
+func (d *Dispatcher) Run(ctx context.Context) error {
+	d.logger.Info("webhook dispatcher started", "pollInterval", d.config.PollInterval.String())
+	pollTicker := time.NewTicker(d.config.PollInterval)
+	defer pollTicker.Stop()
+	pruneTicker := time.NewTicker(pruneInterval)
+	defer pruneTicker.Stop()
+
+	d.prune(ctx)
+	for {
+		d.dispatch(ctx)
+
+		select {
+		case <-ctx.Done():
+			d.logger.Info("webhook dispatcher stopped")
+			return nil
+		case <-pruneTicker.C:
+			d.prune(ctx)
+		case <-pollTicker.C:
+		}
+	}
+}
+
+// dispatch - delivers the due deliveries, batch by batch, until the outbox has no more of them
+func (d *Dispatcher) dispatch(ctx context.Context) {
+	deliveryCtx := context.WithoutCancel(ctx)
+	for ctx.Err() == nil {
+		dispatches, err := d.store.ClaimDeliveries(ctx, dispatchBatchSize, d.config.Timeout+claimLeaseMargin)
+		if err != nil {
+			d.logger.Error("webhook deliveries claim error", "error", err.Error())
+			return
+		}
+
+		group := errgroup.Group{}
+		group.SetLimit(dispatchConcurrency)
+		for _, dispatch := range dispatches {
+			group.Go(func() error {
+				d.deliver(deliveryCtx, dispatch)
+				return nil
+			})
+		}
+		_ = group.Wait()
+
+		if len(dispatches) < dispatchBatchSize {
+			return
+		}
+	}
+}
+
+// deliver - posts the delivery, and records the result. The failed one is retried with the exponential backoff,
+// until it runs out of the attempts, then it is dead-lettered
+func (d *Dispatcher) deliver(ctx context.Context, dispatch Dispatch) {
+	result := d.post(ctx, dispatch)
+	status := StatusDelivered
+	var retryAfter time.Duration
+	if result.Error != "" {
+		attempts := dispatch.Attempts + 1
+		if attempts >= d.config.MaxAttempts {
+			status = StatusDead
+			d.logger.Warn("webhook delivery dead-lettered", "deliveryID", dispatch.ID,
+				"webhookID", dispatch.WebhookID, "attempts", attempts, "error", result.Error)
+		} else {
+			status = StatusPending
+			retryAfter = d.backoff(attempts)
+		}
+	}
+
+	if err := d.store.SaveResult(ctx, dispatch.ID, status, result, retryAfter); err != nil {
+		d.logger.Error("webhook delivery result saving error", "deliveryID", dispatch.ID, "error", err.Error())
+	}
+}
+
+// post - sends the signed delivery payload, the response with a non-2xx status code is a failure
+func (d *Dispatcher) post(ctx context.Context, dispatch Dispatch) Result {
+	body, err := json.Marshal(Payload{
+		DeliveryID: dispatch.ID,
+		EventID:    dispatch.EventID,
+		Type:       dispatch.EventType,
+		BookID:     dispatch.BookID,
+		CreatedAt:  dispatch.CreatedAt,
+	})
+	if err != nil {
+		return Result{Error: err.Error()}
+	}
+
+	requestCtx, cancel := context.WithTimeout(ctx, d.config.Timeout)
+	defer cancel()
+	request, err := http.NewRequestWithContext(requestCtx, http.MethodPost, dispatch.URL, bytes.NewReader(body))
+	if err != nil {
+		return Result{Error: truncate(err.Error())}
+	}
+	timestamp := d.now().Unix()
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", userAgent)
+	request.Header.Set(HeaderDeliveryID, strconv.FormatInt(dispatch.ID, 10))
+	request.Header.Set(HeaderEvent, dispatch.EventType)
+	request.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	request.Header.Set(HeaderSignature, Sign(dispatch.Secret, timestamp, body))
+
+	response, err := d.client.Do(request)
+	if err != nil {
+		return Result{Error: truncate(err.Error())}
+	}
+	defer response.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(response.Body, maxResponseDrain))
+
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
+		return Result{
+			StatusCode: response.StatusCode,
+			Error:      fmt.Sprintf("unexpected response status: %s", response.Status),
+		}
+	}
+
+	return Result{StatusCode: response.StatusCode}
+}
+
+// backoff - returns the delay before the next attempt, it is doubled after each failed one, up to the max backoff
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	delay := d.config.InitialBackoff
+	for i := 1; i < attempts && delay < d.config.MaxBackoff; i++ {
+		delay *= 2
+	}
+
+	return min(delay, d.config.MaxBackoff)
+}
+
+func (d *Dispatcher) prune(ctx context.Context) {
+	removed, err := d.store.PruneDeliveries(ctx, d.config.Retention)
+	if err != nil {
+		d.logger.Error("webhook delivery log pruning error", "error", err.Error())
+		return
+	}
+	if removed > 0 {
+		d.logger.Info("webhook delivery log pruned", "removed", removed)
+	}
+}
+
+func truncate(message string) string {
+	if len(message) > maxErrorLength {
+		return message[:maxErrorLength]
+	}
+
+	return message
+}
@@ -0,0 +1,182 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/sdreger/lib-manager-go/internal/config"
+	"github.com/sdreger/lib-manager-go/internal/event"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDispatcher_Dispatch_Delivered(t *testing.T) {
+	ctx := context.Background()
+	createdAt := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	var received *http.Request
+	var receivedBody []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		receivedBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	dispatcher := getDispatcher()
+	dispatch := getTestDispatch(server.URL, 0)
+	dispatch.CreatedAt = createdAt
+	mockStore := NewMockDispatchStore(t)
+	mockStore.EXPECT().ClaimDeliveries(ctx, uint64(dispatchBatchSize), 10*time.Second+claimLeaseMargin).
+		Return([]Dispatch{dispatch}, nil).Once()
+	mockStore.EXPECT().SaveResult(mock.Anything, deliveryID, StatusDelivered,
+		Result{StatusCode: http.StatusNoContent}, time.Duration(0)).Return(nil).Once()
+	dispatcher.store = mockStore
+
+	dispatcher.dispatch(ctx)
+
+	require.NotNil(t, received, "the delivery should be posted")
+	assert.Equal(t, http.MethodPost, received.Method)
+	assert.Equal(t, "application/json", received.Header.Get("Content-Type"))
+	assert.Equal(t, "10", received.Header.Get(HeaderDeliveryID))
+	assert.Equal(t, event.TypeBookCreated, received.Header.Get(HeaderEvent))
+	assert.Equal(t, "1792400000", received.Header.Get(HeaderTimestamp))
+	assert.True(t, Verify(secretValue, 1792400000, receivedBody, received.Header.Get(HeaderSignature)),
+		"the signature should match the payload")
+
+	var payload Payload
+	require.NoError(t, json.Unmarshal(receivedBody, &payload))
+	assert.Equal(t, Payload{DeliveryID: deliveryID, EventID: 5, Type: event.TypeBookCreated, BookID: 3,
+		CreatedAt: createdAt}, payload)
+}
+
+func TestDispatcher_Dispatch_Retried(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	dispatcher := getDispatcher()
+	mockStore := NewMockDispatchStore(t)
+	mockStore.EXPECT().ClaimDeliveries(ctx, mock.Anything, mock.Anything).
+		Return([]Dispatch{getTestDispatch(server.URL, 2)}, nil).Once()
+	mockStore.EXPECT().SaveResult(mock.Anything, deliveryID, StatusPending, Result{
+		StatusCode: http.StatusServiceUnavailable,
+		Error:      "unexpected response status: 503 Service Unavailable",
+	}, 2*time.Minute).Return(nil).Once()
+	dispatcher.store = mockStore
+
+	dispatcher.dispatch(ctx)
+}
+
+func TestDispatcher_Dispatch_DeadLettered(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	// the closed server refuses the connection
+	server.Close()
+
+	dispatcher := getDispatcher()
+	mockStore := NewMockDispatchStore(t)
+	mockStore.EXPECT().ClaimDeliveries(ctx, mock.Anything, mock.Anything).
+		Return([]Dispatch{getTestDispatch(server.URL, 7)}, nil).Once()
+	mockStore.EXPECT().SaveResult(mock.Anything, deliveryID, StatusDead, mock.MatchedBy(func(result Result) bool {
+		return result.StatusCode == 0 && strings.Contains(result.Error, "connection refused")
+	}), time.Duration(0)).Return(nil).Once()
+	dispatcher.store = mockStore
+
+	dispatcher.dispatch(ctx)
+}
+
+func TestDispatcher_Dispatch_Batches(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	batch := make([]Dispatch, dispatchBatchSize)
+	for i := range batch {
+		batch[i] = getTestDispatch(server.URL, 0)
+	}
+	dispatcher := getDispatcher()
+	mockStore := NewMockDispatchStore(t)
+	mockStore.EXPECT().ClaimDeliveries(ctx, mock.Anything, mock.Anything).Return(batch, nil).Once()
+	mockStore.EXPECT().ClaimDeliveries(ctx, mock.Anything, mock.Anything).Return([]Dispatch{}, nil).Once()
+	mockStore.EXPECT().SaveResult(mock.Anything, deliveryID, StatusDelivered, mock.Anything, time.Duration(0)).
+		Return(nil).Times(dispatchBatchSize)
+	dispatcher.store = mockStore
+
+	dispatcher.dispatch(ctx)
+}
+
+func TestDispatcher_Run(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	dispatcher := getDispatcher()
+	mockStore := NewMockDispatchStore(t)
+	mockStore.EXPECT().PruneDeliveries(ctx, 720*time.Hour).Return(3, nil).Once()
+	mockStore.EXPECT().ClaimDeliveries(ctx, mock.Anything, mock.Anything).
+		RunAndReturn(func(context.Context, uint64, time.Duration) ([]Dispatch, error) {
+			cancel()
+			return []Dispatch{}, nil
+		}).Once()
+	dispatcher.store = mockStore
+
+	assert.NoError(t, dispatcher.Run(ctx), "should stop, once the context is canceled")
+}
+
+func TestDispatcher_Backoff(t *testing.T) {
+	dispatcher := getDispatcher()
+
+	assert.Equal(t, 30*time.Second, dispatcher.backoff(1))
+	assert.Equal(t, time.Minute, dispatcher.backoff(2))
+	assert.Equal(t, 4*time.Minute, dispatcher.backoff(4))
+	assert.Equal(t, time.Hour, dispatcher.backoff(20), "should be capped at the max backoff")
+}
+
+func TestSign(t *testing.T) {
+	body := []byte(`{"delivery_id":1}`)
+	signature := Sign(secretValue, 1792400000, body)
+
+	assert.True(t, strings.HasPrefix(signature, "sha256="))
+	assert.True(t, Verify(secretValue, 1792400000, body, signature))
+	assert.False(t, Verify(secretValue, 1792400001, body, signature), "the timestamp should be signed")
+	assert.False(t, Verify("fedcba9876543210", 1792400000, body, signature))
+	assert.False(t, Verify(secretValue, 1792400000, []byte(`{"delivery_id":2}`), signature))
+}
+
+func getTestDispatch(url string, attempts int) Dispatch {
+	return Dispatch{
+		Delivery: Delivery{
+			ID:        deliveryID,
+			WebhookID: webhookID,
+			EventID:   5,
+			EventType: event.TypeBookCreated,
+			BookID:    3,
+			Status:    StatusPending,
+			Attempts:  attempts,
+		},
+		URL:    url,
+		Secret: secretValue,
+	}
+}
+
+func getDispatcher() *Dispatcher {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	dispatcher := NewDispatcher(logger, config.WebhooksConfig{
+		PollInterval:   time.Minute,
+		Timeout:        10 * time.Second,
+		MaxAttempts:    8,
+		InitialBackoff: 30 * time.Second,
+		MaxBackoff:     time.Hour,
+		Retention:      720 * time.Hour,
+	}, nil)
+	dispatcher.now = func() time.Time { return time.Unix(1792400000, 0) }
+
+	return dispatcher
+}
@@ -0,0 +1,12 @@
+package webhook
+
+import "errors"
+
+var (
+	ErrNotFound      = errors.New("entry not found")
+	ErrInvalidURL    = errors.New("the webhook URL should be an absolute HTTP or HTTPS URL")
+	ErrInvalidEvent  = errors.New("unsupported event type")
+	ErrShortSecret   = errors.New("the webhook secret should be at least 16 characters long")
+	ErrInvalidStatus = errors.New("unsupported delivery status")
+	ErrNotDead       = errors.New("only the dead-lettered deliveries may be retried")
+)
@@ -0,0 +1,170 @@
+package webhook
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"github.com/sdreger/lib-manager-go/internal/paging"
+	"log/slog"
+	"net/url"
+	"slices"
+)
+
+const (
+	minSecretLength = 16
+	// generatedSecretSize - the random bytes of the generated secret, it is hex-encoded
+	generatedSecretSize = 32
+)
+
+type Store interface {
+	Lookup(ctx context.Context, page paging.PageRequest, sort paging.Sort) ([]Webhook, int64, error)
+	GetByID(ctx context.Context, webhookID int64) (Webhook, error)
+	Create(ctx context.Context, webhook Webhook) (Webhook, error)
+	Update(ctx context.Context, webhook Webhook) (Webhook, error)
+	Delete(ctx context.Context, webhookID int64) error
+	LookupDeliveries(ctx context.Context, webhookID int64, status string, page paging.PageRequest) (
+		[]Delivery, int64, error)
+	RetryDelivery(ctx context.Context, webhookID int64, deliveryID int64) (Delivery, error)
+}
+
+type Service struct {
+	logger *slog.Logger
+	store  Store
+}
+
+func NewService(logger *slog.Logger, db *sqlx.DB) *Service {
+	return &Service{
+		logger: logger,
+		store:  NewDBStore(db),
+	}
+}
+
+// GetWebhooks - returns a requested page of the webhooks
+func (s *Service) GetWebhooks(ctx context.Context, pageRequest paging.PageRequest, sort paging.Sort) (
+	paging.Page[Webhook], error) {
+
+	webhooks, totalItems, err := s.store.Lookup(ctx, pageRequest, sort)
+	if err != nil {
+		return paging.Page[Webhook]{}, err
+	}
+
+	return paging.NewPage(pageRequest, totalItems, webhooks), nil
+}
+
+func (s *Service) GetWebhook(ctx context.Context, webhookID int64) (Webhook, error) {
+	return s.store.GetByID(ctx, webhookID)
+}
+
+// CreateWebhook - creates the webhook, and returns it along with the secret.
+// The random secret is generated, unless it is provided
+func (s *Service) CreateWebhook(ctx context.Context, webhook Webhook) (Webhook, error) {
+	if err := validate(webhook); err != nil {
+		return Webhook{}, err
+	}
+
+	secret := webhook.Secret
+	if secret == "" {
+		generated, err := generateSecret()
+		if err != nil {
+			return Webhook{}, err
+		}
+		webhook.Secret = generated
+		secret = generated
+	}
+
+	created, err := s.store.Create(ctx, normalize(webhook))
+	if err != nil {
+		return Webhook{}, err
+	}
+	created.Secret = secret
+	s.logger.Info("webhook created", "webhookID", created.ID, "url", created.URL)
+
+	return created, nil
+}
+
+// UpdateWebhook - replaces the webhook settings, the secret is only replaced when it is provided
+func (s *Service) UpdateWebhook(ctx context.Context, webhook Webhook) (Webhook, error) {
+	if err := validate(webhook); err != nil {
+		return Webhook{}, err
+	}
+
+	return s.store.Update(ctx, normalize(webhook))
+}
+
+// DeleteWebhook - removes the webhook, its pending deliveries are dropped as well
+func (s *Service) DeleteWebhook(ctx context.Context, webhookID int64) error {
+	if err := s.store.Delete(ctx, webhookID); err != nil {
+		return err
+	}
+	s.logger.Info("webhook deleted", "webhookID", webhookID)
+
+	return nil
+}
+
+// GetDeliveries - returns a requested page of the webhook delivery log, the latest deliveries first
+func (s *Service) GetDeliveries(ctx context.Context, webhookID int64, status string, pageRequest paging.PageRequest) (
+	paging.Page[Delivery], error) {
+
+	if status != "" && !slices.Contains(allowedStatuses, status) {
+		return paging.Page[Delivery]{}, fmt.Errorf("%w: %q", ErrInvalidStatus, status)
+	}
+	// the unknown webhook is reported, rather than its empty delivery log
+	if _, err := s.store.GetByID(ctx, webhookID); err != nil {
+		return paging.Page[Delivery]{}, err
+	}
+
+	deliveries, totalItems, err := s.store.LookupDeliveries(ctx, webhookID, status, pageRequest)
+	if err != nil {
+		return paging.Page[Delivery]{}, err
+	}
+
+	return paging.NewPage(pageRequest, totalItems, deliveries), nil
+}
+
+// RetryDelivery - queues the dead-lettered delivery again, the other ones are rejected
+func (s *Service) RetryDelivery(ctx context.Context, webhookID int64, deliveryID int64) (Delivery, error) {
+	return s.store.RetryDelivery(ctx, webhookID, deliveryID)
+}
+
+func validate(webhook Webhook) error {
+	receiverURL, err := url.Parse(webhook.URL)
+	if err != nil || !receiverURL.IsAbs() || receiverURL.Host == "" ||
+		(receiverURL.Scheme != "http" && receiverURL.Scheme != "https") {
+		return ErrInvalidURL
+	}
+
+	for _, eventType := range webhook.Events {
+		if !slices.Contains(AllowedEvents, eventType) {
+			return fmt.Errorf("%w: %q", ErrInvalidEvent, eventType)
+		}
+	}
+
+	if webhook.Secret != "" && len(webhook.Secret) < minSecretLength {
+		return ErrShortSecret
+	}
+
+	return nil
+}
+
+// normalize - returns the webhook with the sorted unique events, the missing list subscribes to all of them
+func normalize(webhook Webhook) Webhook {
+	events := slices.Clone(webhook.Events)
+	slices.Sort(events)
+	webhook.Events = slices.Compact(events)
+	if webhook.Events == nil {
+		webhook.Events = []string{}
+	}
+
+	return webhook
+}
+
+func generateSecret() (string, error) {
+	secret := make([]byte, generatedSecretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(secret), nil
+}
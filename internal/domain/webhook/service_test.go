@@ -0,0 +1,194 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"github.com/sdreger/lib-manager-go/internal/event"
+	"github.com/sdreger/lib-manager-go/internal/paging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"log/slog"
+	"os"
+	"testing"
+)
+
+const (
+	webhookID   = int64(1)
+	deliveryID  = int64(10)
+	webhookURL  = "https://hooks.example.com/catalog"
+	secretValue = "0123456789abcdef"
+)
+
+func TestService_GetWebhooks(t *testing.T) {
+	ctx := context.Background()
+	service := getService()
+	values := map[string][]string{"page": {"1"}, "size": {"10"}, "sort": {"url,asc"}}
+	pageRequest, _ := paging.NewPageRequest(values)
+	sort, _ := paging.NewSort(values, AllowedSortFields)
+
+	mockStore := NewMockStore(t)
+	mockStore.EXPECT().Lookup(ctx, pageRequest, sort).Return([]Webhook{getTestWebhook()}, int64(1), nil).Once()
+	injectMocks(service, mockStore)
+
+	page, err := service.GetWebhooks(ctx, pageRequest, sort)
+	require.NoError(t, err)
+	assert.Equal(t, []Webhook{getTestWebhook()}, page.Content)
+	assert.Equal(t, int64(1), page.TotalItems)
+}
+
+func TestService_CreateWebhook(t *testing.T) {
+	ctx := context.Background()
+	service := getService()
+	request := Webhook{
+		URL:    webhookURL,
+		Events: []string{event.TypeBookUpdated, event.TypeBookCreated, event.TypeBookUpdated},
+		Active: true,
+	}
+
+	mockStore := NewMockStore(t)
+	mockStore.EXPECT().Create(ctx, mock.MatchedBy(func(webhook Webhook) bool {
+		return len(webhook.Secret) == 2*generatedSecretSize &&
+			assert.ObjectsAreEqual([]string{event.TypeBookCreated, event.TypeBookUpdated}, webhook.Events)
+	})).Return(getTestWebhook(), nil).Once()
+	injectMocks(service, mockStore)
+
+	created, err := service.CreateWebhook(ctx, request)
+	require.NoError(t, err)
+	assert.Equal(t, webhookID, created.ID)
+	assert.Len(t, created.Secret, 2*generatedSecretSize, "the generated secret should be returned once")
+}
+
+func TestService_CreateWebhook_ProvidedSecret(t *testing.T) {
+	ctx := context.Background()
+	service := getService()
+	request := Webhook{URL: webhookURL, Secret: secretValue, Active: true}
+
+	mockStore := NewMockStore(t)
+	mockStore.EXPECT().Create(ctx, Webhook{URL: webhookURL, Secret: secretValue, Events: []string{}, Active: true}).
+		Return(getTestWebhook(), nil).Once()
+	injectMocks(service, mockStore)
+
+	created, err := service.CreateWebhook(ctx, request)
+	require.NoError(t, err)
+	assert.Equal(t, secretValue, created.Secret)
+}
+
+func TestService_CreateWebhook_Validation(t *testing.T) {
+	testCases := []struct {
+		name     string
+		webhook  Webhook
+		expected error
+	}{
+		{name: "relative URL", webhook: Webhook{URL: "/catalog"}, expected: ErrInvalidURL},
+		{name: "unsupported scheme", webhook: Webhook{URL: "ftp://hooks.example.com"}, expected: ErrInvalidURL},
+		{name: "unknown event", webhook: Webhook{URL: webhookURL, Events: []string{"book.read"}},
+			expected: ErrInvalidEvent},
+		{name: "short secret", webhook: Webhook{URL: webhookURL, Secret: "secret"}, expected: ErrShortSecret},
+	}
+
+	service := getService()
+	injectMocks(service, NewMockStore(t))
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := service.CreateWebhook(context.Background(), tc.webhook)
+			assert.ErrorIs(t, err, tc.expected)
+		})
+	}
+}
+
+func TestService_UpdateWebhook(t *testing.T) {
+	ctx := context.Background()
+	service := getService()
+	request := Webhook{ID: webhookID, URL: webhookURL, Active: false}
+
+	mockStore := NewMockStore(t)
+	mockStore.EXPECT().Update(ctx, Webhook{ID: webhookID, URL: webhookURL, Events: []string{}}).
+		Return(getTestWebhook(), nil).Once()
+	injectMocks(service, mockStore)
+
+	updated, err := service.UpdateWebhook(ctx, request)
+	require.NoError(t, err)
+	assert.Equal(t, getTestWebhook(), updated)
+
+	_, err = service.UpdateWebhook(ctx, Webhook{ID: webhookID, URL: "hooks"})
+	assert.ErrorIs(t, err, ErrInvalidURL)
+}
+
+func TestService_DeleteWebhook(t *testing.T) {
+	ctx := context.Background()
+	service := getService()
+
+	mockStore := NewMockStore(t)
+	mockStore.EXPECT().Delete(ctx, webhookID).Return(nil).Once()
+	mockStore.EXPECT().Delete(ctx, int64(2)).Return(ErrNotFound).Once()
+	injectMocks(service, mockStore)
+
+	assert.NoError(t, service.DeleteWebhook(ctx, webhookID))
+	assert.ErrorIs(t, service.DeleteWebhook(ctx, 2), ErrNotFound)
+}
+
+func TestService_GetDeliveries(t *testing.T) {
+	ctx := context.Background()
+	service := getService()
+	pageRequest, _ := paging.NewPageRequest(map[string][]string{})
+	deliveries := []Delivery{{ID: deliveryID, WebhookID: webhookID, Status: StatusDead}}
+
+	mockStore := NewMockStore(t)
+	mockStore.EXPECT().GetByID(ctx, webhookID).Return(getTestWebhook(), nil).Once()
+	mockStore.EXPECT().LookupDeliveries(ctx, webhookID, StatusDead, pageRequest).
+		Return(deliveries, int64(1), nil).Once()
+	injectMocks(service, mockStore)
+
+	page, err := service.GetDeliveries(ctx, webhookID, StatusDead, pageRequest)
+	require.NoError(t, err)
+	assert.Equal(t, deliveries, page.Content)
+
+	_, err = service.GetDeliveries(ctx, webhookID, "failed", pageRequest)
+	assert.ErrorIs(t, err, ErrInvalidStatus)
+}
+
+func TestService_GetDeliveries_UnknownWebhook(t *testing.T) {
+	ctx := context.Background()
+	service := getService()
+	pageRequest, _ := paging.NewPageRequest(map[string][]string{})
+
+	mockStore := NewMockStore(t)
+	mockStore.EXPECT().GetByID(ctx, webhookID).Return(Webhook{}, ErrNotFound).Once()
+	injectMocks(service, mockStore)
+
+	_, err := service.GetDeliveries(ctx, webhookID, "", pageRequest)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestService_RetryDelivery(t *testing.T) {
+	ctx := context.Background()
+	service := getService()
+	storeError := errors.New("store error")
+	delivery := Delivery{ID: deliveryID, WebhookID: webhookID, Status: StatusPending}
+
+	mockStore := NewMockStore(t)
+	mockStore.EXPECT().RetryDelivery(ctx, webhookID, deliveryID).Return(delivery, nil).Once()
+	mockStore.EXPECT().RetryDelivery(ctx, webhookID, int64(11)).Return(Delivery{}, storeError).Once()
+	injectMocks(service, mockStore)
+
+	retried, err := service.RetryDelivery(ctx, webhookID, deliveryID)
+	require.NoError(t, err)
+	assert.Equal(t, delivery, retried)
+
+	_, err = service.RetryDelivery(ctx, webhookID, 11)
+	assert.ErrorIs(t, err, storeError)
+}
+
+func getTestWebhook() Webhook {
+	return Webhook{ID: webhookID, URL: webhookURL, Events: []string{}, Active: true}
+}
+
+func getService() *Service {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	return NewService(logger, nil)
+}
+
+func injectMocks(service *Service, store Store) {
+	service.store = store
+}
@@ -0,0 +1,232 @@
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/sdreger/lib-manager-go/internal/paging"
+	"time"
+)
+
+const (
+	webhookColumns  = "id, url, secret, events, active, created_at, updated_at"
+	deliveryColumns = `id, webhook_id, event_id, event_type, book_id, status, attempts, last_status_code, last_error,
+       next_attempt_at, delivered_at, created_at`
+)
+
+type DBStore struct {
+	db *sqlx.DB
+}
+
+func NewDBStore(db *sqlx.DB) *DBStore {
+	return &DBStore{db: db}
+}
+
+func (s *DBStore) Lookup(ctx context.Context, page paging.PageRequest, sort paging.Sort) ([]Webhook, int64, error) {
+	query := fmt.Sprintf("SELECT %s FROM ebook.webhooks ORDER BY %s LIMIT $1 OFFSET $2",
+		webhookColumns, sort.GetOrderBy("ebook.webhooks"))
+
+	var rows []webhookEntity
+	if err := s.db.SelectContext(ctx, &rows, query, page.Limit(), page.Offset()); err != nil {
+		return nil, 0, err
+	}
+
+	var total int64 = 0
+	if err := s.db.GetContext(ctx, &total, "SELECT count(id) FROM ebook.webhooks"); err != nil {
+		return nil, 0, err
+	}
+
+	items := make([]Webhook, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, row.toWebhook())
+	}
+
+	return items, total, nil
+}
+
+func (s *DBStore) GetByID(ctx context.Context, webhookID int64) (Webhook, error) {
+	query := fmt.Sprintf("SELECT %s FROM ebook.webhooks WHERE id = $1", webhookColumns)
+
+	var entity webhookEntity
+	if err := s.db.GetContext(ctx, &entity, query, webhookID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Webhook{}, ErrNotFound
+		}
+		return Webhook{}, err
+	}
+
+	return entity.toWebhook(), nil
+}
+
+func (s *DBStore) Create(ctx context.Context, webhook Webhook) (Webhook, error) {
+	query := fmt.Sprintf(`INSERT INTO ebook.webhooks (url, secret, events, active)
+VALUES ($1, $2, $3, $4)
+RETURNING %s`, webhookColumns)
+
+	var entity webhookEntity
+	if err := s.db.GetContext(ctx, &entity, query,
+		webhook.URL, webhook.Secret, pq.StringArray(webhook.Events), webhook.Active); err != nil {
+		return Webhook{}, err
+	}
+
+	return entity.toWebhook(), nil
+}
+
+// Update - replaces the webhook settings, the empty secret keeps the current one
+func (s *DBStore) Update(ctx context.Context, webhook Webhook) (Webhook, error) {
+	query := fmt.Sprintf(`UPDATE ebook.webhooks
+SET url = $2, secret = COALESCE(NULLIF($3, ''), secret), events = $4, active = $5, updated_at = now()
+WHERE id = $1
+RETURNING %s`, webhookColumns)
+
+	var entity webhookEntity
+	if err := s.db.GetContext(ctx, &entity, query,
+		webhook.ID, webhook.URL, webhook.Secret, pq.StringArray(webhook.Events), webhook.Active); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Webhook{}, ErrNotFound
+		}
+		return Webhook{}, err
+	}
+
+	return entity.toWebhook(), nil
+}
+
+// Delete - removes the webhook, along with its delivery log
+func (s *DBStore) Delete(ctx context.Context, webhookID int64) error {
+	result, err := s.db.ExecContext(ctx, "DELETE FROM ebook.webhooks WHERE id = $1", webhookID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// LookupDeliveries - returns a page of the webhook deliveries, the latest first, the empty status matches all of them
+func (s *DBStore) LookupDeliveries(ctx context.Context, webhookID int64, status string, page paging.PageRequest) (
+	[]Delivery, int64, error) {
+
+	query := fmt.Sprintf(`SELECT %s FROM ebook.webhook_deliveries
+WHERE webhook_id = $1 AND ($2 = '' OR status = $2)
+ORDER BY id DESC LIMIT $3 OFFSET $4`, deliveryColumns)
+
+	var rows []deliveryEntity
+	if err := s.db.SelectContext(ctx, &rows, query, webhookID, status, page.Limit(), page.Offset()); err != nil {
+		return nil, 0, err
+	}
+
+	var total int64 = 0
+	if err := s.db.GetContext(ctx, &total,
+		"SELECT count(id) FROM ebook.webhook_deliveries WHERE webhook_id = $1 AND ($2 = '' OR status = $2)",
+		webhookID, status); err != nil {
+		return nil, 0, err
+	}
+
+	items := make([]Delivery, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, row.toDelivery())
+	}
+
+	return items, total, nil
+}
+
+// RetryDelivery - puts the dead-lettered delivery back to the outbox, with the attempts counted from scratch
+func (s *DBStore) RetryDelivery(ctx context.Context, webhookID int64, deliveryID int64) (Delivery, error) {
+	query := fmt.Sprintf(`UPDATE ebook.webhook_deliveries
+SET status = $3, attempts = 0, next_attempt_at = now(), updated_at = now()
+WHERE id = $1 AND webhook_id = $2 AND status = $4
+RETURNING %s`, deliveryColumns)
+
+	var entity deliveryEntity
+	err := s.db.GetContext(ctx, &entity, query, deliveryID, webhookID, StatusPending, StatusDead)
+	if errors.Is(err, sql.ErrNoRows) {
+		var exists bool
+		if err := s.db.GetContext(ctx, &exists,
+			"SELECT EXISTS (SELECT 1 FROM ebook.webhook_deliveries WHERE id = $1 AND webhook_id = $2)",
+			deliveryID, webhookID); err != nil {
+			return Delivery{}, err
+		}
+		if !exists {
+			return Delivery{}, ErrNotFound
+		}
+		return Delivery{}, ErrNotDead
+	}
+	if err != nil {
+		return Delivery{}, err
+	}
+
+	return entity.toDelivery(), nil
+}
+
+// ClaimDeliveries - returns up to the limit of the due deliveries of the active webhooks. The claimed deliveries
+// are postponed for the lease time, so the other dispatchers skip them, while they are being delivered
+func (s *DBStore) ClaimDeliveries(ctx context.Context, limit uint64, lease time.Duration) ([]Dispatch, error) {
+	query := `UPDATE ebook.webhook_deliveries AS d
+SET next_attempt_at = now() + make_interval(secs => $2), updated_at = now()
+FROM ebook.webhooks AS w
+WHERE d.webhook_id = w.id
+  AND d.id IN (SELECT pending.id
+               FROM ebook.webhook_deliveries AS pending
+                        JOIN ebook.webhooks AS receiver ON receiver.id = pending.webhook_id
+               WHERE pending.status = $3
+                 AND pending.next_attempt_at <= now()
+                 AND receiver.active
+               ORDER BY pending.next_attempt_at, pending.id
+               LIMIT $1 FOR UPDATE OF pending SKIP LOCKED)
+RETURNING d.id, d.webhook_id, d.event_id, d.event_type, d.book_id, d.status, d.attempts, d.last_status_code,
+    d.last_error, d.next_attempt_at, d.delivered_at, d.created_at, w.url, w.secret`
+
+	var rows []dispatchEntity
+	if err := s.db.SelectContext(ctx, &rows, query, limit, lease.Seconds(), StatusPending); err != nil {
+		return nil, err
+	}
+
+	dispatches := make([]Dispatch, 0, len(rows))
+	for _, row := range rows {
+		dispatches = append(dispatches, Dispatch{Delivery: row.toDelivery(), URL: row.URL, Secret: row.Secret})
+	}
+
+	return dispatches, nil
+}
+
+// SaveResult - records the delivery attempt. The pending delivery is retried after the given delay
+func (s *DBStore) SaveResult(ctx context.Context, deliveryID int64, status string, result Result,
+	retryAfter time.Duration) error {
+
+	query := `UPDATE ebook.webhook_deliveries
+SET status           = $2,
+    attempts         = attempts + 1,
+    last_status_code = NULLIF($3, 0),
+    last_error       = NULLIF($4, ''),
+    next_attempt_at  = now() + make_interval(secs => $5),
+    delivered_at     = CASE WHEN $6 THEN now() END,
+    updated_at       = now()
+WHERE id = $1`
+
+	_, err := s.db.ExecContext(ctx, query,
+		deliveryID, status, result.StatusCode, result.Error, retryAfter.Seconds(), status == StatusDelivered)
+
+	return err
+}
+
+// PruneDeliveries - removes the delivered and the dead-lettered deliveries, finished longer than the given time ago
+func (s *DBStore) PruneDeliveries(ctx context.Context, olderThan time.Duration) (int64, error) {
+	result, err := s.db.ExecContext(ctx,
+		"DELETE FROM ebook.webhook_deliveries WHERE status <> $1 AND updated_at < now() - make_interval(secs => $2)",
+		StatusPending, olderThan.Seconds())
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
@@ -0,0 +1,193 @@
+package webhook
+
+import (
+	"context"
+	"github.com/jmoiron/sqlx"
+	"github.com/sdreger/lib-manager-go/internal/event"
+	"github.com/sdreger/lib-manager-go/internal/paging"
+	"github.com/sdreger/lib-manager-go/internal/tests"
+	"github.com/stretchr/testify/suite"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"os"
+	"testing"
+	"time"
+)
+
+const insertBookQuery = `INSERT INTO ebook.books (id, title, description, pages, edition, language_id, publisher_id,
+                         publisher_url, pub_date, book_file_name, book_file_size, cover_file_name)
+VALUES (1, 'CockroachDB', 'Get the lowdown on CockroachDB', 256, 2, 1, 1, 'https://amazon.com/dp/1234567890.html',
+        '2022-07-19', 'OReilly.CockroachDB.2nd.Edition.1234567890.zip', 5192, '1234567890.jpg')`
+
+type TestStoreSuite struct {
+	suite.Suite
+	db            *sqlx.DB
+	testContainer *postgres.PostgresContainer
+	store         *DBStore
+}
+
+func (s *TestStoreSuite) SetupSuite() {
+	testContainer := tests.StartDBTestContainer(s.T())
+	dbConfig := tests.GetTestDBConfig(s.T(), testContainer)
+	connection := tests.SetUpTestDB(s.Suite.Require(), dbConfig, testContainer)
+
+	s.store = NewDBStore(connection)
+	s.db = connection
+	s.testContainer = testContainer
+}
+
+func (s *TestStoreSuite) SetupTest() {
+	ctx := context.Background()
+	err := s.testContainer.Restore(ctx)
+	s.Require().NoError(err)
+
+	err = prepareTestData(s.testContainer, "testdata/webhooks.sql")
+	s.Require().NoError(err, "failed to load test SQL file")
+}
+
+func (s *TestStoreSuite) TearDownSuite() {
+	err := s.db.Close()
+	s.Require().NoError(err, "failed to close database connection")
+}
+
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(TestStoreSuite))
+}
+
+// -------------------- Tests --------------------
+
+func (s *TestStoreSuite) Test_Lookup() {
+	values := map[string][]string{"page": {"1"}, "size": {"2"}, "sort": {"url,asc"}}
+	pageRequest, _ := paging.NewPageRequest(values)
+	sort, _ := paging.NewSort(values, AllowedSortFields)
+
+	webhooks, total, err := s.store.Lookup(context.Background(), pageRequest, sort)
+	s.Require().NoError(err)
+	s.Equal(int64(3), total)
+	s.Require().Len(webhooks, 2)
+	s.Equal("https://archive.example.com/hook", webhooks[0].URL)
+	s.Equal("https://hooks.example.com/catalog", webhooks[1].URL)
+	s.Empty(webhooks[0].Secret, "the secret should not be returned")
+}
+
+func (s *TestStoreSuite) Test_CreateUpdateDelete() {
+	ctx := context.Background()
+	created, err := s.store.Create(ctx, Webhook{URL: "https://new.example.com", Secret: "0000111122223333",
+		Events: []string{event.TypeBookCreated}, Active: true})
+	s.Require().NoError(err)
+	s.Equal(int64(4), created.ID)
+	s.Equal([]string{event.TypeBookCreated}, created.Events)
+	s.NotZero(created.CreatedAt)
+
+	updated, err := s.store.Update(ctx, Webhook{ID: created.ID, URL: "https://updated.example.com",
+		Events: []string{}, Active: false})
+	s.Require().NoError(err)
+	s.Equal("https://updated.example.com", updated.URL)
+	s.False(updated.Active)
+	var secret string
+	s.Require().NoError(s.db.Get(&secret, "SELECT secret FROM ebook.webhooks WHERE id = $1", created.ID))
+	s.Equal("0000111122223333", secret, "the empty secret should keep the current one")
+
+	s.Require().NoError(s.store.Delete(ctx, created.ID))
+	_, err = s.store.GetByID(ctx, created.ID)
+	s.ErrorIs(err, ErrNotFound)
+	s.ErrorIs(s.store.Delete(ctx, created.ID), ErrNotFound)
+	_, err = s.store.Update(ctx, Webhook{ID: created.ID, Events: []string{}})
+	s.ErrorIs(err, ErrNotFound)
+}
+
+func (s *TestStoreSuite) Test_Outbox() {
+	ctx := context.Background()
+	_, err := s.db.Exec(insertBookQuery)
+	s.Require().NoError(err)
+	_, err = s.db.Exec("UPDATE ebook.books SET deleted_at = now() WHERE id = 1")
+	s.Require().NoError(err)
+
+	pageRequest, _ := paging.NewPageRequest(map[string][]string{})
+	deliveries, total, err := s.store.LookupDeliveries(ctx, 1, "", pageRequest)
+	s.Require().NoError(err)
+	s.Equal(int64(2), total, "the webhook without the event filter should receive all the events")
+	s.Equal(event.TypeBookDeleted, deliveries[0].EventType, "the latest delivery should come first")
+	s.Equal(StatusPending, deliveries[0].Status)
+
+	deliveries, total, err = s.store.LookupDeliveries(ctx, 2, "", pageRequest)
+	s.Require().NoError(err)
+	s.Equal(int64(1), total, "the webhook should only receive the subscribed events")
+	s.Equal(event.TypeBookDeleted, deliveries[0].EventType)
+
+	_, total, err = s.store.LookupDeliveries(ctx, 3, "", pageRequest)
+	s.Require().NoError(err)
+	s.Zero(total, "the inactive webhook should not receive the events")
+}
+
+func (s *TestStoreSuite) Test_ClaimAndSaveResult() {
+	ctx := context.Background()
+	_, err := s.db.Exec(insertBookQuery)
+	s.Require().NoError(err)
+
+	dispatches, err := s.store.ClaimDeliveries(ctx, 10, time.Minute)
+	s.Require().NoError(err)
+	s.Require().Len(dispatches, 1)
+	s.Equal("https://hooks.example.com/catalog", dispatches[0].URL)
+	s.Equal("0123456789abcdef", dispatches[0].Secret)
+
+	dispatches, err = s.store.ClaimDeliveries(ctx, 10, time.Minute)
+	s.Require().NoError(err)
+	s.Empty(dispatches, "the claimed delivery should be leased")
+
+	deliveryID := s.getDeliveryID(1)
+	s.Require().NoError(s.store.SaveResult(ctx, deliveryID, StatusDead, Result{StatusCode: 500, Error: "failed"}, 0))
+	pageRequest, _ := paging.NewPageRequest(map[string][]string{})
+	deliveries, _, err := s.store.LookupDeliveries(ctx, 1, StatusDead, pageRequest)
+	s.Require().NoError(err)
+	s.Require().Len(deliveries, 1)
+	s.Equal(1, deliveries[0].Attempts)
+	s.Equal(500, deliveries[0].LastStatusCode)
+	s.Equal("failed", deliveries[0].LastError)
+	s.Nil(deliveries[0].DeliveredAt)
+
+	retried, err := s.store.RetryDelivery(ctx, 1, deliveryID)
+	s.Require().NoError(err)
+	s.Equal(StatusPending, retried.Status)
+	s.Zero(retried.Attempts)
+	_, err = s.store.RetryDelivery(ctx, 1, deliveryID)
+	s.ErrorIs(err, ErrNotDead)
+	_, err = s.store.RetryDelivery(ctx, 2, deliveryID)
+	s.ErrorIs(err, ErrNotFound)
+
+	s.Require().NoError(s.store.SaveResult(ctx, deliveryID, StatusDelivered, Result{StatusCode: 200}, 0))
+	deliveries, _, err = s.store.LookupDeliveries(ctx, 1, StatusDelivered, pageRequest)
+	s.Require().NoError(err)
+	s.Require().Len(deliveries, 1)
+	s.NotNil(deliveries[0].DeliveredAt)
+	s.Empty(deliveries[0].LastError)
+}
+
+func (s *TestStoreSuite) Test_PruneDeliveries() {
+	ctx := context.Background()
+	_, err := s.db.Exec(insertBookQuery)
+	s.Require().NoError(err)
+	_, err = s.db.Exec("UPDATE ebook.webhook_deliveries SET status = $1, updated_at = now() - interval '31 days'",
+		StatusDelivered)
+	s.Require().NoError(err)
+
+	removed, err := s.store.PruneDeliveries(ctx, 720*time.Hour)
+	s.Require().NoError(err)
+	s.Equal(int64(1), removed)
+}
+
+func (s *TestStoreSuite) getDeliveryID(webhookID int64) int64 {
+	var deliveryID int64
+	s.Require().NoError(s.db.Get(&deliveryID,
+		"SELECT id FROM ebook.webhook_deliveries WHERE webhook_id = $1 ORDER BY id DESC LIMIT 1", webhookID))
+
+	return deliveryID
+}
+
+func prepareTestData(testContainer *postgres.PostgresContainer, fileName string) error {
+	file, err := os.ReadFile(fileName)
+	if err != nil {
+		return err
+	}
+
+	return tests.ExecSQL(testContainer, string(file))
+}
@@ -0,0 +1,8 @@
+INSERT INTO ebook.publishers (id, name) VALUES (1, 'OReilly');
+INSERT INTO ebook.languages (id, name) VALUES (1, 'English');
+
+INSERT INTO ebook.webhooks (id, url, secret, events, active, created_at)
+VALUES (1, 'https://hooks.example.com/catalog', '0123456789abcdef', '{}', TRUE, '2026-10-01 10:00:00'),
+       (2, 'https://search.example.com/reindex', 'fedcba9876543210', '{book.deleted}', TRUE, '2026-10-02 10:00:00'),
+       (3, 'https://archive.example.com/hook', 'abcdef0123456789', '{}', FALSE, '2026-10-03 10:00:00');
+SELECT setval('ebook.webhooks_id_seq', 3);
@@ -0,0 +1,143 @@
+package webhook
+
+import (
+	"database/sql"
+	"github.com/lib/pq"
+	"github.com/sdreger/lib-manager-go/internal/event"
+	"time"
+)
+
+const (
+	// StatusPending - the delivery is waiting for the first or the next attempt
+	StatusPending = "pending"
+	// StatusDelivered - the receiver has responded with a 2xx status code
+	StatusDelivered = "delivered"
+	// StatusDead - the delivery has failed all the attempts, it is only retried on request
+	StatusDead = "dead"
+)
+
+var (
+	AllowedSortFields = []string{"id", "url", "created_at"}
+	// AllowedEvents - the event types, the webhooks may subscribe to
+	AllowedEvents = []string{event.TypeBookCreated, event.TypeBookUpdated, event.TypeBookDeleted,
+		event.TypeCoverUpdated}
+	allowedStatuses = []string{StatusPending, StatusDelivered, StatusDead}
+)
+
+// Webhook - the subscription of the receiver URL to the catalog change events, the empty events list
+// subscribes to all of them. The secret signs the deliveries, it is only returned once the webhook is created
+type Webhook struct {
+	ID        int64     `json:"id"`
+	URL       string    `json:"url"`
+	Secret    string    `json:"secret,omitempty"`
+	Events    []string  `json:"events"`
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Delivery - the delivery of the change event to the webhook, the outbox entry and the delivery log record at once
+type Delivery struct {
+	ID             int64      `json:"id"`
+	WebhookID      int64      `json:"webhook_id"`
+	EventID        int64      `json:"event_id"`
+	EventType      string     `json:"event_type"`
+	BookID         int64      `json:"book_id"`
+	Status         string     `json:"status"`
+	Attempts       int        `json:"attempts"`
+	LastStatusCode int        `json:"last_status_code,omitempty"`
+	LastError      string     `json:"last_error,omitempty"`
+	NextAttemptAt  time.Time  `json:"next_attempt_at"`
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// Dispatch - the claimed delivery, along with the receiver details
+type Dispatch struct {
+	Delivery
+	URL    string
+	Secret string
+}
+
+// Payload - the JSON body, posted to the webhook receiver
+type Payload struct {
+	DeliveryID int64     `json:"delivery_id"`
+	EventID    int64     `json:"event_id"`
+	Type       string    `json:"type"`
+	BookID     int64     `json:"book_id"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// Result - the outcome of the delivery attempt, the zero status code means no response was received
+type Result struct {
+	StatusCode int
+	Error      string
+}
+
+type webhookEntity struct {
+	ID        int64          `db:"id"`
+	URL       string         `db:"url"`
+	Secret    string         `db:"secret"`
+	Events    pq.StringArray `db:"events"`
+	Active    bool           `db:"active"`
+	CreatedAt time.Time      `db:"created_at"`
+	UpdatedAt time.Time      `db:"updated_at"`
+}
+
+func (e webhookEntity) toWebhook() Webhook {
+	events := []string(e.Events)
+	if events == nil {
+		events = []string{}
+	}
+
+	return Webhook{
+		ID:        e.ID,
+		URL:       e.URL,
+		Events:    events,
+		Active:    e.Active,
+		CreatedAt: e.CreatedAt,
+		UpdatedAt: e.UpdatedAt,
+	}
+}
+
+type deliveryEntity struct {
+	ID             int64          `db:"id"`
+	WebhookID      int64          `db:"webhook_id"`
+	EventID        int64          `db:"event_id"`
+	EventType      string         `db:"event_type"`
+	BookID         int64          `db:"book_id"`
+	Status         string         `db:"status"`
+	Attempts       int            `db:"attempts"`
+	LastStatusCode sql.NullInt32  `db:"last_status_code"`
+	LastError      sql.NullString `db:"last_error"`
+	NextAttemptAt  time.Time      `db:"next_attempt_at"`
+	DeliveredAt    sql.NullTime   `db:"delivered_at"`
+	CreatedAt      time.Time      `db:"created_at"`
+}
+
+func (e deliveryEntity) toDelivery() Delivery {
+	delivery := Delivery{
+		ID:             e.ID,
+		WebhookID:      e.WebhookID,
+		EventID:        e.EventID,
+		EventType:      e.EventType,
+		BookID:         e.BookID,
+		Status:         e.Status,
+		Attempts:       e.Attempts,
+		LastStatusCode: int(e.LastStatusCode.Int32),
+		LastError:      e.LastError.String,
+		NextAttemptAt:  e.NextAttemptAt,
+		CreatedAt:      e.CreatedAt,
+	}
+	if e.DeliveredAt.Valid {
+		delivery.DeliveredAt = &e.DeliveredAt.Time
+	}
+
+	return delivery
+}
+
+type dispatchEntity struct {
+	deliveryEntity
+	URL    string `db:"url"`
+	Secret string `db:"secret"`
+}
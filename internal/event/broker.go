@@ -0,0 +1,234 @@
+package event
+
+import (
+	"context"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/sdreger/lib-manager-go/internal/config"
+	"github.com/sdreger/lib-manager-go/internal/database"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+const (
+	subscriptionBufferSize = 64
+	syncBatchSize          = 100
+	minReconnectInterval   = time.Second
+	maxReconnectInterval   = time.Minute
+	// pingInterval - the idle listener connection is checked, and the possibly missed events are read
+	pingInterval = 90 * time.Second
+	// pendingInterval - the events, held back by an older running transaction, are read again shortly,
+	// since the transaction may not notify on commit
+	pendingInterval = time.Second
+)
+
+type Store interface {
+	GetLastEventID(ctx context.Context) (int64, error)
+	GetEventsAfter(ctx context.Context, afterID int64, limit uint64) ([]Event, error)
+	HasPendingEvents(ctx context.Context) (bool, error)
+}
+
+// Listener - the Postgres notifications listener, implemented by 'pq.Listener'
+type Listener interface {
+	Listen(channel string) error
+	NotificationChannel() <-chan *pq.Notification
+	Ping() error
+	Close() error
+}
+
+// Broker - delivers the catalog change events to the subscribers. Each replica listens to the change
+// notifications on its own dedicated connection, and reads the new events from the change log,
+// so every replica delivers every change
+type Broker struct {
+	logger        *slog.Logger
+	store         Store
+	newListener   func() Listener
+	mu            sync.Mutex
+	subscriptions map[chan Event]struct{}
+	closed        bool
+	// the last published event, only accessed by the running broker
+	lastID int64
+	synced bool
+}
+
+func NewBroker(logger *slog.Logger, dbConfig config.DBConfig, db *sqlx.DB) *Broker {
+	broker := &Broker{
+		logger:        logger,
+		store:         NewDBStore(db),
+		subscriptions: make(map[chan Event]struct{}),
+	}
+	broker.newListener = func() Listener {
+		return pq.NewListener(database.ConnectionURL(dbConfig), minReconnectInterval, maxReconnectInterval,
+			broker.reportListenerEvent)
+	}
+
+	return broker
+}
+
+// Run - listens to the change notifications until the context is canceled, then closes all the subscriptions.
+// The listener reconnects on its own, the events committed in the meantime are read from the change log
+func (b *Broker) Run(ctx context.Context) error {
+	defer b.close()
+
+	listener := b.newListener()
+	// the listen call blocks until the connection is established, closing the listener releases it
+	closeListener := sync.OnceValue(listener.Close)
+	stopClose := context.AfterFunc(ctx, func() {
+		_ = closeListener()
+	})
+	defer func() {
+		stopClose()
+		_ = closeListener()
+	}()
+
+	if err := listener.Listen(notificationChannel); err != nil {
+		if ctx.Err() != nil {
+			return nil
+		}
+		return fmt.Errorf("change notifications listen error: %w", err)
+	}
+	b.logger.Info("listening to the catalog change notifications")
+	b.sync(ctx)
+
+	ticker := time.NewTicker(pingInterval)
+	defer ticker.Stop()
+	pending := time.NewTimer(pendingInterval)
+	pending.Stop()
+	defer pending.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case _, ok := <-listener.NotificationChannel():
+			if !ok {
+				return nil
+			}
+			// the notification carries no payload, and the nil one is sent after the reconnect
+			b.syncPending(ctx, pending)
+		case <-pending.C:
+			b.syncPending(ctx, pending)
+		case <-ticker.C:
+			if err := listener.Ping(); err != nil {
+				b.logger.Warn("change notifications listener ping error", "error", err.Error())
+			}
+			b.syncPending(ctx, pending)
+		}
+	}
+}
+
+// Subscribe - returns the channel of the events, published from now on, and the function to unsubscribe.
+// The channel is closed when the broker stops, or when the subscriber falls behind
+func (b *Broker) Subscribe() (<-chan Event, func()) {
+	events := make(chan Event, subscriptionBufferSize)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		close(events)
+	} else {
+		b.subscriptions[events] = struct{}{}
+	}
+
+	return events, func() {
+		b.unsubscribe(events)
+	}
+}
+
+// Replay - returns up to the limit of the events, recorded after the given one, the oldest first.
+// The change log is bounded, so the events missed by a long-disconnected subscriber may be pruned already
+func (b *Broker) Replay(ctx context.Context, afterID int64, limit uint64) ([]Event, error) {
+	return b.store.GetEventsAfter(ctx, afterID, limit)
+}
+
+// syncPending - syncs the events, and schedules the next sync, if some events are held back
+// by an older running transaction
+func (b *Broker) syncPending(ctx context.Context, pending *time.Timer) {
+	b.sync(ctx)
+
+	hasPending, err := b.store.HasPendingEvents(ctx)
+	if err != nil {
+		b.logger.Error("change log read error", "error", err.Error())
+		return
+	}
+	if hasPending {
+		pending.Reset(pendingInterval)
+	}
+}
+
+// sync - reads the events after the last published one, and publishes them to the subscribers.
+// The first sync only finds the latest event, the earlier ones are replayed on request
+func (b *Broker) sync(ctx context.Context) {
+	if !b.synced {
+		lastID, err := b.store.GetLastEventID(ctx)
+		if err != nil {
+			b.logger.Error("change log read error", "error", err.Error())
+			return
+		}
+		b.lastID = lastID
+		b.synced = true
+		return
+	}
+
+	for {
+		events, err := b.store.GetEventsAfter(ctx, b.lastID, syncBatchSize)
+		if err != nil {
+			b.logger.Error("change log read error", "error", err.Error(), "afterID", b.lastID)
+			return
+		}
+		for _, event := range events {
+			b.publish(event)
+			b.lastID = event.ID
+		}
+		if len(events) < syncBatchSize {
+			return
+		}
+	}
+}
+
+func (b *Broker) publish(event Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for events := range b.subscriptions {
+		select {
+		case events <- event:
+		default:
+			// the lagging subscriber is dropped, it resumes from the change log after reconnecting
+			delete(b.subscriptions, events)
+			close(events)
+		}
+	}
+}
+
+func (b *Broker) unsubscribe(events chan Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.subscriptions[events]; ok {
+		delete(b.subscriptions, events)
+		close(events)
+	}
+}
+
+func (b *Broker) close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+	for events := range b.subscriptions {
+		delete(b.subscriptions, events)
+		close(events)
+	}
+}
+
+func (b *Broker) reportListenerEvent(eventType pq.ListenerEventType, err error) {
+	switch eventType {
+	case pq.ListenerEventConnected:
+		b.logger.Info("change notifications listener connected")
+	case pq.ListenerEventReconnected:
+		b.logger.Info("change notifications listener reconnected")
+	case pq.ListenerEventDisconnected:
+		b.logger.Warn("change notifications listener disconnected", "error", err.Error())
+	case pq.ListenerEventConnectionAttemptFailed:
+		b.logger.Warn("change notifications listener connection error", "error", err.Error())
+	}
+}
@@ -0,0 +1,195 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"github.com/lib/pq"
+	"github.com/sdreger/lib-manager-go/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+)
+
+const receiveTimeout = time.Second
+
+func TestBroker_Run(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	notifications := make(chan *pq.Notification)
+	createdEvent := Event{ID: 6, Type: TypeBookCreated, BookID: 2}
+	coverEvent := Event{ID: 7, Type: TypeCoverUpdated, BookID: 2}
+
+	mockListener := NewMockListener(t)
+	mockListener.EXPECT().Listen(notificationChannel).Return(nil).Once()
+	mockListener.EXPECT().NotificationChannel().Return(notifications)
+	mockListener.EXPECT().Close().Return(nil).Once()
+	mockStore := NewMockStore(t)
+	mockStore.EXPECT().GetLastEventID(mock.Anything).Return(int64(5), nil).Once()
+	mockStore.EXPECT().GetEventsAfter(mock.Anything, int64(5), uint64(syncBatchSize)).
+		Return([]Event{createdEvent, coverEvent}, nil).Once()
+	mockStore.EXPECT().GetEventsAfter(mock.Anything, int64(7), uint64(syncBatchSize)).Return([]Event{}, nil).Once()
+	mockStore.EXPECT().HasPendingEvents(mock.Anything).Return(false, nil).Twice()
+	broker := getBroker(mockStore, mockListener)
+
+	events, unsubscribe := broker.Subscribe()
+	defer unsubscribe()
+	done := make(chan error, 1)
+	go func() {
+		done <- broker.Run(ctx)
+	}()
+
+	notifications <- &pq.Notification{Channel: notificationChannel}
+	assert.Equal(t, createdEvent, receive(t, events))
+	assert.Equal(t, coverEvent, receive(t, events))
+	// the reconnect is reported by the nil notification
+	notifications <- nil
+
+	cancel()
+	require.NoError(t, <-done)
+	_, ok := <-events
+	assert.False(t, ok, "the subscriptions should be closed, once the broker stops")
+}
+
+func TestBroker_Run_PendingEvents(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	notifications := make(chan *pq.Notification)
+	updatedEvent := Event{ID: 4, Type: TypeBookUpdated, BookID: 1}
+
+	mockListener := NewMockListener(t)
+	mockListener.EXPECT().Listen(notificationChannel).Return(nil).Once()
+	mockListener.EXPECT().NotificationChannel().Return(notifications)
+	mockListener.EXPECT().Close().Return(nil).Once()
+	mockStore := NewMockStore(t)
+	mockStore.EXPECT().GetLastEventID(mock.Anything).Return(int64(3), nil).Once()
+	// the event is held back by an older running transaction on the first read
+	mockStore.EXPECT().GetEventsAfter(mock.Anything, int64(3), uint64(syncBatchSize)).Return([]Event{}, nil).Once()
+	mockStore.EXPECT().HasPendingEvents(mock.Anything).Return(true, nil).Once()
+	mockStore.EXPECT().GetEventsAfter(mock.Anything, int64(3), uint64(syncBatchSize)).
+		Return([]Event{updatedEvent}, nil).Once()
+	mockStore.EXPECT().HasPendingEvents(mock.Anything).Return(false, nil).Once()
+	broker := getBroker(mockStore, mockListener)
+
+	events, unsubscribe := broker.Subscribe()
+	defer unsubscribe()
+	done := make(chan error, 1)
+	go func() {
+		done <- broker.Run(ctx)
+	}()
+
+	notifications <- &pq.Notification{Channel: notificationChannel}
+	select {
+	case event := <-events:
+		assert.Equal(t, updatedEvent, event, "the pending event should be read again without a notification")
+	case <-time.After(pendingInterval + receiveTimeout):
+		require.Fail(t, "no event received")
+	}
+
+	cancel()
+	require.NoError(t, <-done)
+}
+
+func TestBroker_Run_ListenError(t *testing.T) {
+	listenError := errors.New("listen error")
+
+	mockListener := NewMockListener(t)
+	mockListener.EXPECT().Listen(notificationChannel).Return(listenError).Once()
+	mockListener.EXPECT().Close().Return(nil).Once()
+	broker := getBroker(NewMockStore(t), mockListener)
+
+	err := broker.Run(context.Background())
+	assert.ErrorIs(t, err, listenError)
+}
+
+func TestBroker_Run_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	mockListener := NewMockListener(t)
+	mockListener.EXPECT().Listen(notificationChannel).RunAndReturn(func(string) error {
+		cancel()
+		return errors.New("pq: Listener has been closed")
+	}).Once()
+	mockListener.EXPECT().Close().Return(nil).Once()
+	broker := getBroker(NewMockStore(t), mockListener)
+
+	assert.NoError(t, broker.Run(ctx), "the listener, closed on shutdown, should not fail the broker")
+}
+
+func TestBroker_Publish_LaggingSubscriber(t *testing.T) {
+	broker := getBroker(NewMockStore(t), NewMockListener(t))
+	events, unsubscribe := broker.Subscribe()
+	defer unsubscribe()
+
+	for i := range subscriptionBufferSize + 1 {
+		broker.publish(Event{ID: int64(i + 1)})
+	}
+
+	received := 0
+	for range events {
+		received++
+	}
+	assert.Equal(t, subscriptionBufferSize, received, "the lagging subscriber should be dropped")
+}
+
+func TestBroker_Unsubscribe(t *testing.T) {
+	broker := getBroker(NewMockStore(t), NewMockListener(t))
+	events, unsubscribe := broker.Subscribe()
+
+	unsubscribe()
+	unsubscribe()
+	broker.publish(Event{ID: 1})
+
+	_, ok := <-events
+	assert.False(t, ok, "the subscription should be closed")
+}
+
+func TestBroker_Subscribe_Closed(t *testing.T) {
+	broker := getBroker(NewMockStore(t), NewMockListener(t))
+	broker.close()
+
+	events, unsubscribe := broker.Subscribe()
+	defer unsubscribe()
+
+	_, ok := <-events
+	assert.False(t, ok, "the subscription to the stopped broker should be closed")
+}
+
+func TestBroker_Replay(t *testing.T) {
+	ctx := context.Background()
+	expected := []Event{{ID: 3, Type: TypeBookUpdated, BookID: 1}}
+
+	mockStore := NewMockStore(t)
+	mockStore.EXPECT().GetEventsAfter(ctx, int64(2), uint64(10)).Return(expected, nil).Once()
+	broker := getBroker(mockStore, NewMockListener(t))
+
+	events, err := broker.Replay(ctx, 2, 10)
+	require.NoError(t, err)
+	assert.Equal(t, expected, events)
+}
+
+func receive(t *testing.T, events <-chan Event) Event {
+	t.Helper()
+	select {
+	case event, ok := <-events:
+		require.True(t, ok, "the subscription should not be closed")
+		return event
+	case <-time.After(receiveTimeout):
+		require.Fail(t, "no event received")
+		return Event{}
+	}
+}
+
+func getBroker(store Store, listener Listener) *Broker {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	broker := NewBroker(logger, config.DBConfig{}, nil)
+	broker.store = store
+	broker.newListener = func() Listener {
+		return listener
+	}
+
+	return broker
+}
@@ -0,0 +1,66 @@
+package event
+
+import (
+	"context"
+	"github.com/jmoiron/sqlx"
+)
+
+type DBStore struct {
+	db *sqlx.DB
+}
+
+func NewDBStore(db *sqlx.DB) *DBStore {
+	return &DBStore{db: db}
+}
+
+// GetLastEventID - returns the ID of the latest readable event, or zero if there is no such event
+func (s *DBStore) GetLastEventID(ctx context.Context) (int64, error) {
+	var lastID int64
+	query := `SELECT COALESCE((SELECT id
+                  FROM ebook.change_log
+                  WHERE tx_id < txid_snapshot_xmin(txid_current_snapshot())
+                  ORDER BY tx_id DESC, id DESC
+                  LIMIT 1), 0)`
+	if err := s.db.GetContext(ctx, &lastID, query); err != nil {
+		return 0, err
+	}
+
+	return lastID, nil
+}
+
+// GetEventsAfter - returns up to the limit of the readable events, recorded after the given one, in the
+// transaction order. Only the events of the transactions older than any running one are readable, so an event,
+// committed later, is never ordered before the already read ones. The events after the pruned one are
+// the events with the greater IDs
+func (s *DBStore) GetEventsAfter(ctx context.Context, afterID int64, limit uint64) ([]Event, error) {
+	events := make([]Event, 0)
+	query := `WITH last_read AS (SELECT tx_id, id FROM ebook.change_log WHERE id = $1)
+SELECT id, event_type, book_id, created_at
+FROM ebook.change_log
+WHERE tx_id < txid_snapshot_xmin(txid_current_snapshot())
+  AND CASE
+          WHEN EXISTS (SELECT 1 FROM last_read) THEN (tx_id, id) > (SELECT tx_id, id FROM last_read)
+          ELSE id > $1
+    END
+ORDER BY tx_id, id
+LIMIT $2`
+	if err := s.db.SelectContext(ctx, &events, query, afterID, limit); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// HasPendingEvents - reports whether there are the committed events, which are not readable yet,
+// since an older transaction is still running
+func (s *DBStore) HasPendingEvents(ctx context.Context) (bool, error) {
+	var pending bool
+	query := `SELECT EXISTS (SELECT 1
+              FROM ebook.change_log
+              WHERE tx_id >= txid_snapshot_xmin(txid_current_snapshot()))`
+	if err := s.db.GetContext(ctx, &pending, query); err != nil {
+		return false, err
+	}
+
+	return pending, nil
+}
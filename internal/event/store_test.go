@@ -0,0 +1,165 @@
+package event
+
+import (
+	"context"
+	"github.com/jmoiron/sqlx"
+	"github.com/sdreger/lib-manager-go/internal/tests"
+	"github.com/stretchr/testify/suite"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"os"
+	"strings"
+	"testing"
+)
+
+const insertBookQuery = `INSERT INTO ebook.books (id, title, description, pages, edition, language_id, publisher_id,
+                         publisher_url, pub_date, book_file_name, book_file_size, cover_file_name)
+VALUES (1, 'CockroachDB', 'Get the lowdown on CockroachDB', 256, 2, 1, 1, 'https://amazon.com/dp/1234567890.html',
+        '2022-07-19', 'OReilly.CockroachDB.2nd.Edition.1234567890.zip', 5192, '1234567890.jpg')`
+
+type TestStoreSuite struct {
+	suite.Suite
+	db            *sqlx.DB
+	testContainer *postgres.PostgresContainer
+	store         *DBStore
+}
+
+func (s *TestStoreSuite) SetupSuite() {
+	testContainer := tests.StartDBTestContainer(s.T())
+	dbConfig := tests.GetTestDBConfig(s.T(), testContainer)
+	connection := tests.SetUpTestDB(s.Suite.Require(), dbConfig, testContainer)
+
+	s.store = NewDBStore(connection)
+	s.db = connection
+	s.testContainer = testContainer
+}
+
+func (s *TestStoreSuite) SetupTest() {
+	ctx := context.Background()
+	err := s.testContainer.Restore(ctx)
+	s.Require().NoError(err)
+
+	err = prepareTestData(s.testContainer, "testdata/change_log.sql")
+	s.Require().NoError(err, "failed to load test SQL file")
+}
+
+func (s *TestStoreSuite) TearDownSuite() {
+	err := s.db.Close()
+	s.Require().NoError(err, "failed to close database connection")
+}
+
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(TestStoreSuite))
+}
+
+// -------------------- Tests --------------------
+
+func (s *TestStoreSuite) Test_GetLastEventID_Empty() {
+	lastID, err := s.store.GetLastEventID(context.Background())
+	s.Require().NoError(err)
+	s.Equal(int64(0), lastID)
+}
+
+func (s *TestStoreSuite) Test_GetEventsAfter() {
+	ctx := context.Background()
+	s.execInTx(insertBookQuery, "INSERT INTO ebook.book_tag (book_id, tag_id) VALUES (1, 1)")
+	s.execInTx("UPDATE ebook.books SET title = 'CockroachDB 2' WHERE id = 1")
+	s.execInTx("UPDATE ebook.books SET cover_hash = '" + strings.Repeat("a", 64) + "' WHERE id = 1")
+	s.execInTx("UPDATE ebook.books SET updated_at = now() WHERE id = 1")
+	s.execInTx("INSERT INTO ebook.book_tag (book_id, tag_id) VALUES (1, 2)")
+	s.execInTx("UPDATE ebook.books SET deleted_at = now() WHERE id = 1")
+
+	events, err := s.store.GetEventsAfter(ctx, 0, 10)
+	s.Require().NoError(err)
+	s.Require().Len(events, 5, "the changes should be recorded once per transaction")
+	s.Equal([]string{TypeBookCreated, TypeBookUpdated, TypeCoverUpdated, TypeBookUpdated, TypeBookDeleted},
+		eventTypes(events))
+	for _, event := range events {
+		s.Equal(int64(1), event.BookID)
+		s.NotZero(event.CreatedAt)
+	}
+
+	lastID, err := s.store.GetLastEventID(ctx)
+	s.Require().NoError(err)
+	s.Equal(events[4].ID, lastID)
+
+	events, err = s.store.GetEventsAfter(ctx, events[1].ID, 2)
+	s.Require().NoError(err)
+	s.Equal([]string{TypeCoverUpdated, TypeBookUpdated}, eventTypes(events), "should return the limited events")
+}
+
+func (s *TestStoreSuite) Test_GetEventsAfter_HardDelete() {
+	ctx := context.Background()
+	s.execInTx(insertBookQuery, "INSERT INTO ebook.book_tag (book_id, tag_id) VALUES (1, 1)")
+	s.execInTx("DELETE FROM ebook.books WHERE id = 1")
+
+	events, err := s.store.GetEventsAfter(ctx, 0, 10)
+	s.Require().NoError(err)
+	s.Equal([]string{TypeBookCreated, TypeBookDeleted}, eventTypes(events),
+		"the cascaded relations removal should not be recorded")
+}
+
+func (s *TestStoreSuite) Test_GetEventsAfter_ConcurrentTransactions() {
+	ctx := context.Background()
+	older, err := s.db.Beginx()
+	s.Require().NoError(err)
+	defer func() {
+		_ = older.Rollback()
+	}()
+	// the older transaction records its event first, but commits last
+	_, err = older.Exec(insertBookQuery)
+	s.Require().NoError(err)
+	s.execInTx(strings.NewReplacer("VALUES (1,", "VALUES (2,", "1234567890.zip", "0987654321.zip").
+		Replace(insertBookQuery))
+
+	events, err := s.store.GetEventsAfter(ctx, 0, 10)
+	s.Require().NoError(err)
+	s.Empty(events, "the events should be held back, while an older transaction is running")
+	pending, err := s.store.HasPendingEvents(ctx)
+	s.Require().NoError(err)
+	s.True(pending)
+	lastID, err := s.store.GetLastEventID(ctx)
+	s.Require().NoError(err)
+	s.Equal(int64(0), lastID)
+
+	s.Require().NoError(older.Commit())
+	events, err = s.store.GetEventsAfter(ctx, 0, 10)
+	s.Require().NoError(err)
+	s.Require().Len(events, 2)
+	s.Equal([]int64{1, 2}, []int64{events[0].BookID, events[1].BookID}, "should be in the transaction order")
+	pending, err = s.store.HasPendingEvents(ctx)
+	s.Require().NoError(err)
+	s.False(pending)
+
+	events, err = s.store.GetEventsAfter(ctx, events[0].ID, 10)
+	s.Require().NoError(err)
+	s.Require().Len(events, 1, "should resume after the event of the older transaction")
+	s.Equal(int64(2), events[0].BookID)
+}
+
+func (s *TestStoreSuite) execInTx(queries ...string) {
+	tx, err := s.db.Beginx()
+	s.Require().NoError(err)
+	for _, query := range queries {
+		_, err = tx.Exec(query)
+		s.Require().NoError(err)
+	}
+	s.Require().NoError(tx.Commit())
+}
+
+func eventTypes(events []Event) []string {
+	types := make([]string, len(events))
+	for i, event := range events {
+		types[i] = event.Type
+	}
+
+	return types
+}
+
+func prepareTestData(testContainer *postgres.PostgresContainer, fileName string) error {
+	file, err := os.ReadFile(fileName)
+	if err != nil {
+		return err
+	}
+
+	return tests.ExecSQL(testContainer, string(file))
+}
@@ -0,0 +1,5 @@
+INSERT INTO ebook.publishers (id, name) VALUES (1, 'OReilly');
+INSERT INTO ebook.languages (id, name) VALUES (1, 'English');
+INSERT INTO ebook.tags (id, name) VALUES (1, 'go'), (2, 'databases');
+INSERT INTO ebook.covers (hash, size, mime_type)
+VALUES ('aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa', 52814, 'image/jpeg');
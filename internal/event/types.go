@@ -0,0 +1,23 @@
+package event
+
+import "time"
+
+const (
+	TypeBookCreated  = "book.created"
+	TypeBookUpdated  = "book.updated"
+	TypeBookDeleted  = "book.deleted"
+	TypeCoverUpdated = "cover.updated"
+
+	// notificationChannel - the Postgres channel, the change log triggers notify on the transaction commit
+	notificationChannel = "ebook_changes"
+)
+
+// Event - the catalog change, recorded in the change log by the database triggers.
+// The events are delivered in the transaction order, which may differ from the ID order of the concurrent
+// transactions, the subscribers resume after the last received one by its ID
+type Event struct {
+	ID        int64     `json:"id" db:"id"`
+	Type      string    `json:"type" db:"event_type"`
+	BookID    int64     `json:"book_id" db:"book_id"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
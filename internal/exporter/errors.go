@@ -0,0 +1,7 @@
+package exporter
+
+import "errors"
+
+var (
+	ErrUnsupportedFormat = errors.New("unsupported export format, the supported ones are 'csv' and 'ndjson'")
+)
@@ -0,0 +1,26 @@
+package exporter
+
+const (
+	FormatCSV    = "csv"
+	FormatNDJSON = "ndjson"
+)
+
+// Format - the export format properties, needed to serve the export as a file download
+type Format struct {
+	ContentType string
+	Extension   string
+}
+
+// Formats - the supported export formats
+var Formats = map[string]Format{
+	FormatCSV:    {ContentType: "text/csv; charset=utf-8", Extension: "csv"},
+	FormatNDJSON: {ContentType: "application/x-ndjson", Extension: "ndjson"},
+}
+
+// Columns - the CSV export columns. The importable ones are named after the import fields, so the exported
+// file can be imported back as is
+var Columns = []string{
+	"id", "title", "subtitle", "description", "isbn10", "isbn13", "asin", "pages", "edition", "pub_date",
+	"publisher", "publisher_url", "language", "authors", "categories", "tags", "file_types", "created_at",
+	"updated_at",
+}
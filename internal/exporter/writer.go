@@ -0,0 +1,115 @@
+package exporter
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"github.com/sdreger/lib-manager-go/internal/domain/book"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// listSeparator - separates the authors, the categories, the tags and the file types in a CSV cell
+const listSeparator = ";"
+
+// Writer - writes the exported books in a specific format. The output is buffered, so the 'Flush' method should
+// be called once all the books are written
+type Writer interface {
+	Write(book book.Book) error
+	Flush() error
+}
+
+// NewWriter - creates a new export writer for the format
+func NewWriter(format string, w io.Writer) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return &csvWriter{writer: csv.NewWriter(w)}, nil
+	case FormatNDJSON:
+		buffer := bufio.NewWriter(w)
+		return &ndjsonWriter{buffer: buffer, encoder: json.NewEncoder(buffer)}, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedFormat, format)
+	}
+}
+
+// csvWriter - writes the header on the first write, or on flush when no books were exported
+type csvWriter struct {
+	writer        *csv.Writer
+	headerWritten bool
+}
+
+func (w *csvWriter) Write(book book.Book) error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+
+	return w.writer.Write(csvRecord(book))
+}
+
+func (w *csvWriter) Flush() error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+	w.writer.Flush()
+
+	return w.writer.Error()
+}
+
+func (w *csvWriter) writeHeader() error {
+	if w.headerWritten {
+		return nil
+	}
+	w.headerWritten = true
+
+	return w.writer.Write(Columns)
+}
+
+// csvRecord - converts the book to a CSV record, matching the 'Columns' order
+func csvRecord(book book.Book) []string {
+	var isbn13, pubDate string
+	if book.ISBN13 != 0 {
+		isbn13 = strconv.FormatInt(book.ISBN13, 10)
+	}
+	if !book.PubDate.IsZero() {
+		pubDate = book.PubDate.Format(time.DateOnly)
+	}
+
+	return []string{
+		strconv.FormatInt(book.ID, 10),
+		book.Title,
+		book.Subtitle,
+		book.Description,
+		book.ISBN10,
+		isbn13,
+		book.ASIN,
+		strconv.FormatUint(uint64(book.Pages), 10),
+		strconv.FormatUint(uint64(book.Edition), 10),
+		pubDate,
+		book.Publisher,
+		book.PublisherURL,
+		book.Language,
+		strings.Join(book.Authors, listSeparator),
+		strings.Join(book.Categories, listSeparator),
+		strings.Join(book.Tags, listSeparator),
+		strings.Join(book.FileTypes, listSeparator),
+		book.CreatedAt.UTC().Format(time.RFC3339),
+		book.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+// ndjsonWriter - writes a book JSON object per line, the same one as returned by the book API
+type ndjsonWriter struct {
+	buffer  *bufio.Writer
+	encoder *json.Encoder
+}
+
+func (w *ndjsonWriter) Write(book book.Book) error {
+	return w.encoder.Encode(book)
+}
+
+func (w *ndjsonWriter) Flush() error {
+	return w.buffer.Flush()
+}
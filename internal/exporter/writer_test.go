@@ -0,0 +1,88 @@
+package exporter
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"github.com/sdreger/lib-manager-go/internal/domain/book"
+	"github.com/sdreger/lib-manager-go/internal/importer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testBook = book.Book{
+	ID:           1,
+	Title:        "Go in Action",
+	Description:  "Line one,\n\"quoted\" line two",
+	ISBN10:       "1617291781",
+	ISBN13:       9781617291784,
+	Pages:        264,
+	Edition:      1,
+	PubDate:      time.Date(2015, time.November, 4, 0, 0, 0, 0, time.UTC),
+	Publisher:    "Manning",
+	PublisherURL: "https://www.manning.com/books/go-in-action",
+	Language:     "English",
+	Authors:      []string{"William Kennedy", "Brian Ketelsen"},
+	Categories:   []string{"Programming"},
+	FileTypes:    []string{"epub", "pdf"},
+	CreatedAt:    time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC),
+	UpdatedAt:    time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC),
+}
+
+func TestCSVWriter(t *testing.T) {
+	var output bytes.Buffer
+	writer, err := NewWriter(FormatCSV, &output)
+	require.NoError(t, err)
+	require.NoError(t, writer.Write(testBook))
+	require.NoError(t, writer.Flush())
+
+	records, err := csv.NewReader(&output).ReadAll()
+	require.NoError(t, err, "the output should be a valid CSV")
+	require.Len(t, records, 2)
+	assert.Equal(t, Columns, records[0])
+	assert.Equal(t, []string{
+		"1", "Go in Action", "", "Line one,\n\"quoted\" line two", "1617291781", "9781617291784", "", "264", "1",
+		"2015-11-04", "Manning", "https://www.manning.com/books/go-in-action", "English",
+		"William Kennedy;Brian Ketelsen", "Programming", "", "epub;pdf", "2024-01-02T03:04:05Z",
+		"2024-01-02T03:04:05Z",
+	}, records[1])
+}
+
+func TestCSVWriter_NoBooks(t *testing.T) {
+	var output bytes.Buffer
+	writer, err := NewWriter(FormatCSV, &output)
+	require.NoError(t, err)
+	require.NoError(t, writer.Flush())
+
+	assert.Equal(t, strings.Join(Columns, ",")+"\n", output.String(), "the header should be written anyway")
+}
+
+func TestCSVWriter_ImportableColumns(t *testing.T) {
+	for _, field := range importer.Fields {
+		assert.Contains(t, Columns, field, "the exported CSV should be importable")
+	}
+}
+
+func TestNDJSONWriter(t *testing.T) {
+	var output bytes.Buffer
+	writer, err := NewWriter(FormatNDJSON, &output)
+	require.NoError(t, err)
+	require.NoError(t, writer.Write(testBook))
+	require.NoError(t, writer.Write(book.Book{ID: 2, Title: "Book 02"}))
+	assert.Empty(t, output.String(), "the output should be buffered")
+	require.NoError(t, writer.Flush())
+
+	lines := strings.Split(strings.TrimSuffix(output.String(), "\n"), "\n")
+	require.Len(t, lines, 2)
+	var decoded book.Book
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &decoded))
+	assert.Equal(t, testBook, decoded)
+}
+
+func TestNewWriter_UnsupportedFormat(t *testing.T) {
+	_, err := NewWriter("xml", &bytes.Buffer{})
+	require.ErrorIs(t, err, ErrUnsupportedFormat)
+}
@@ -0,0 +1,101 @@
+package feed
+
+import (
+	"encoding/xml"
+	"io"
+	"strconv"
+	"time"
+)
+
+// atomFeed - the Atom 1.0 feed, see https://www.rfc-editor.org/rfc/rfc4287
+type atomFeed struct {
+	XMLName  xml.Name    `xml:"feed"`
+	XMLNS    string      `xml:"xmlns,attr"`
+	ID       string      `xml:"id"`
+	Title    string      `xml:"title"`
+	Subtitle string      `xml:"subtitle,omitempty"`
+	Updated  string      `xml:"updated"`
+	Links    []atomLink  `xml:"link"`
+	Entries  []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Rel    string `xml:"rel,attr,omitempty"`
+	Href   string `xml:"href,attr"`
+	Type   string `xml:"type,attr,omitempty"`
+	Length string `xml:"length,attr,omitempty"`
+}
+
+type atomEntry struct {
+	ID         string         `xml:"id"`
+	Title      string         `xml:"title"`
+	Published  string         `xml:"published,omitempty"`
+	Updated    string         `xml:"updated"`
+	Authors    []atomAuthor   `xml:"author"`
+	Categories []atomCategory `xml:"category"`
+	Summary    string         `xml:"summary,omitempty"`
+	Links      []atomLink     `xml:"link"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+// WriteAtom - writes the Atom 1.0 feed
+func WriteAtom(w io.Writer, feed Feed) error {
+	result := atomFeed{
+		XMLNS:    "http://www.w3.org/2005/Atom",
+		ID:       feed.ID,
+		Title:    feed.Title,
+		Subtitle: feed.Description,
+		Updated:  atomTime(feed.Updated),
+		Links: []atomLink{
+			{Rel: "self", Href: feed.SelfLink, Type: MediaTypeAtom},
+			{Rel: "alternate", Href: feed.Link},
+		},
+		Entries: []atomEntry{},
+	}
+
+	for _, entry := range feed.Entries {
+		item := atomEntry{
+			ID:      entry.ID,
+			Title:   entry.Title,
+			Updated: atomTime(entry.Updated),
+			Summary: entry.Summary,
+			Links:   []atomLink{{Rel: "alternate", Href: entry.Link}},
+		}
+		if !entry.Published.IsZero() {
+			item.Published = atomTime(entry.Published)
+		}
+		for _, author := range entry.Authors {
+			item.Authors = append(item.Authors, atomAuthor{Name: author})
+		}
+		for _, category := range entry.Categories {
+			item.Categories = append(item.Categories, atomCategory{Term: category})
+		}
+		if entry.Enclosure != nil {
+			link := atomLink{Rel: "enclosure", Href: entry.Enclosure.URL, Type: entry.Enclosure.Type}
+			if entry.Enclosure.Length > 0 {
+				link.Length = strconv.FormatInt(entry.Enclosure.Length, 10)
+			}
+			item.Links = append(item.Links, link)
+		}
+		result.Entries = append(result.Entries, item)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+
+	return encoder.Encode(result)
+}
+
+func atomTime(value time.Time) string {
+	return value.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,7 @@
+package feed
+
+import "errors"
+
+var (
+	ErrUnsupportedFormat = errors.New("unsupported feed format, the supported ones are 'atom' and 'rss'")
+)
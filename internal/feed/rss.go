@@ -0,0 +1,109 @@
+package feed
+
+import (
+	"encoding/xml"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// rssFeed - the RSS 2.0 feed, see https://www.rssboard.org/rss-specification.
+// The Atom 'self' link is added, as recommended by https://www.rssboard.org/rss-profile
+type rssFeed struct {
+	XMLName   xml.Name   `xml:"rss"`
+	Version   string     `xml:"version,attr"`
+	XMLNSAtom string     `xml:"xmlns:atom,attr"`
+	XMLNSDC   string     `xml:"xmlns:dc,attr"`
+	Channel   rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	AtomLink      rssLink   `xml:"atom:link"`
+	LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssLink struct {
+	Rel  string `xml:"rel,attr"`
+	Href string `xml:"href,attr"`
+	Type string `xml:"type,attr"`
+}
+
+type rssItem struct {
+	Title       string        `xml:"title"`
+	Link        string        `xml:"link"`
+	Description string        `xml:"description,omitempty"`
+	Creator     string        `xml:"dc:creator,omitempty"`
+	Categories  []string      `xml:"category"`
+	GUID        rssGUID       `xml:"guid"`
+	PubDate     string        `xml:"pubDate,omitempty"`
+	Enclosure   *rssEnclosure `xml:"enclosure"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type rssEnclosure struct {
+	URL    string `xml:"url,attr"`
+	Length string `xml:"length,attr"`
+	Type   string `xml:"type,attr"`
+}
+
+// WriteRSS - writes the RSS 2.0 feed. The RSS items have no update time, so the feed build date is the latest one
+func WriteRSS(w io.Writer, feed Feed) error {
+	result := rssFeed{
+		Version:   "2.0",
+		XMLNSAtom: "http://www.w3.org/2005/Atom",
+		XMLNSDC:   "http://purl.org/dc/elements/1.1/",
+		Channel: rssChannel{
+			Title:       feed.Title,
+			Link:        feed.Link,
+			Description: feed.Description,
+			AtomLink:    rssLink{Rel: "self", Href: feed.SelfLink, Type: MediaTypeRSS},
+			Items:       []rssItem{},
+		},
+	}
+	if !feed.Updated.IsZero() {
+		result.Channel.LastBuildDate = rssTime(feed.Updated)
+	}
+
+	for _, entry := range feed.Entries {
+		item := rssItem{
+			Title:       entry.Title,
+			Link:        entry.Link,
+			Description: entry.Summary,
+			Creator:     strings.Join(entry.Authors, ", "),
+			Categories:  entry.Categories,
+			GUID:        rssGUID{Value: entry.ID},
+		}
+		if !entry.Published.IsZero() {
+			item.PubDate = rssTime(entry.Published)
+		}
+		if entry.Enclosure != nil {
+			item.Enclosure = &rssEnclosure{
+				URL:    entry.Enclosure.URL,
+				Length: strconv.FormatInt(entry.Enclosure.Length, 10),
+				Type:   entry.Enclosure.Type,
+			}
+		}
+		result.Channel.Items = append(result.Channel.Items, item)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+
+	return encoder.Encode(result)
+}
+
+func rssTime(value time.Time) string {
+	return value.UTC().Format(time.RFC1123Z)
+}
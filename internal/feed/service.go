@@ -0,0 +1,65 @@
+package feed
+
+import (
+	"context"
+	"github.com/jmoiron/sqlx"
+	"github.com/sdreger/lib-manager-go/internal/domain/book"
+	"log/slog"
+)
+
+type Store interface {
+	GetCovers(ctx context.Context, hashes []string) (map[string]Cover, error)
+}
+
+type BookService interface {
+	GetLatestBooks(ctx context.Context, filter book.Filter, limit uint64) ([]book.Book, error)
+}
+
+type Service struct {
+	logger      *slog.Logger
+	store       Store
+	bookService BookService
+}
+
+func NewService(logger *slog.Logger, db *sqlx.DB) *Service {
+	return &Service{
+		logger:      logger,
+		store:       NewDBStore(db),
+		bookService: book.NewService(logger, db),
+	}
+}
+
+// GetNewBooks - returns up to the limit of the most recently added books, matching the filter,
+// along with their cover details
+func (s *Service) GetNewBooks(ctx context.Context, filter book.Filter, limit uint64) ([]Item, error) {
+	books, err := s.bookService.GetLatestBooks(ctx, filter, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	var hashes []string
+	for _, entry := range books {
+		if entry.CoverHash != "" {
+			hashes = append(hashes, entry.CoverHash)
+		}
+	}
+	covers := map[string]Cover{}
+	if len(hashes) > 0 {
+		if covers, err = s.store.GetCovers(ctx, hashes); err != nil {
+			return nil, err
+		}
+	}
+
+	items := make([]Item, 0, len(books))
+	for _, entry := range books {
+		item := Item{Book: entry}
+		if cover, ok := covers[entry.CoverHash]; ok {
+			item.Cover = &cover
+		} else if entry.CoverHash == "" && entry.CoverFileName != "" {
+			item.Cover = legacyCover(entry.CoverFileName)
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+}
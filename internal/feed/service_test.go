@@ -0,0 +1,83 @@
+package feed
+
+import (
+	"context"
+	"errors"
+	"github.com/sdreger/lib-manager-go/internal/domain/book"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"log/slog"
+	"os"
+	"testing"
+)
+
+func TestService_GetNewBooks(t *testing.T) {
+	ctx := context.Background()
+	service := getService()
+	filter := book.Filter{Tags: []int64{1}}
+	books := []book.Book{
+		{ID: 3, CoverHash: "hash-3"},
+		{ID: 2, CoverFileName: "cover.png"},
+		{ID: 1},
+	}
+
+	bookService := NewMockBookService(t)
+	bookService.EXPECT().GetLatestBooks(ctx, filter, uint64(20)).Return(books, nil).Once()
+	store := NewMockStore(t)
+	store.EXPECT().GetCovers(ctx, []string{"hash-3"}).
+		Return(map[string]Cover{"hash-3": {Hash: "hash-3", MIMEType: "image/jpeg", Size: 1024}}, nil).Once()
+	injectMocks(service, store, bookService)
+
+	items, err := service.GetNewBooks(ctx, filter, 20)
+	require.NoError(t, err)
+	require.Len(t, items, 3)
+	assert.Equal(t, &Cover{Hash: "hash-3", MIMEType: "image/jpeg", Size: 1024}, items[0].Cover)
+	assert.Equal(t, &Cover{MIMEType: "image/png"}, items[1].Cover, "the legacy cover type should be guessed")
+	assert.Nil(t, items[2].Cover, "the book without a cover should have no cover")
+}
+
+func TestService_GetNewBooks_NoCovers(t *testing.T) {
+	ctx := context.Background()
+	service := getService()
+
+	bookService := NewMockBookService(t)
+	bookService.EXPECT().GetLatestBooks(ctx, book.Filter{}, uint64(20)).Return([]book.Book{{ID: 1}}, nil).Once()
+	injectMocks(service, NewMockStore(t), bookService)
+
+	items, err := service.GetNewBooks(ctx, book.Filter{}, 20)
+	require.NoError(t, err)
+	assert.Len(t, items, 1)
+}
+
+func TestService_GetNewBooks_Errors(t *testing.T) {
+	ctx := context.Background()
+	service := getService()
+	storeError := errors.New("store error")
+
+	bookService := NewMockBookService(t)
+	bookService.EXPECT().GetLatestBooks(ctx, book.Filter{}, uint64(20)).Return(nil, storeError).Once()
+	injectMocks(service, NewMockStore(t), bookService)
+
+	_, err := service.GetNewBooks(ctx, book.Filter{}, 20)
+	require.ErrorIs(t, err, storeError)
+
+	bookService = NewMockBookService(t)
+	bookService.EXPECT().GetLatestBooks(ctx, book.Filter{}, uint64(20)).
+		Return([]book.Book{{ID: 1, CoverHash: "hash-1"}}, nil).Once()
+	store := NewMockStore(t)
+	store.EXPECT().GetCovers(ctx, []string{"hash-1"}).Return(nil, storeError).Once()
+	injectMocks(service, store, bookService)
+
+	_, err = service.GetNewBooks(ctx, book.Filter{}, 20)
+	require.ErrorIs(t, err, storeError)
+}
+
+func getService() *Service {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	return NewService(logger, nil)
+}
+
+func injectMocks(service *Service, store *MockStore, bookService *MockBookService) {
+	service.store = store
+	service.bookService = bookService
+}
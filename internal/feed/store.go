@@ -0,0 +1,31 @@
+package feed
+
+import (
+	"context"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type DBStore struct {
+	db *sqlx.DB
+}
+
+func NewDBStore(db *sqlx.DB) *DBStore {
+	return &DBStore{db: db}
+}
+
+// GetCovers - returns the content-addressed covers by their hashes, the unknown hashes are skipped
+func (s *DBStore) GetCovers(ctx context.Context, hashes []string) (map[string]Cover, error) {
+	var rows []Cover
+	if err := s.db.SelectContext(ctx, &rows, "SELECT hash, mime_type, size FROM ebook.covers WHERE hash = ANY($1)",
+		pq.Array(hashes)); err != nil {
+		return nil, err
+	}
+
+	covers := make(map[string]Cover, len(rows))
+	for _, row := range rows {
+		covers[row.Hash] = row
+	}
+
+	return covers, nil
+}
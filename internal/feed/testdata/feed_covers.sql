@@ -0,0 +1,3 @@
+INSERT INTO ebook.covers (hash, size, mime_type, width, height)
+VALUES ('aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa', 52814, 'image/jpeg', 600, 800),
+       ('bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb', 1024, 'image/png', NULL, NULL);
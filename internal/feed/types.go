@@ -0,0 +1,102 @@
+package feed
+
+import (
+	"github.com/sdreger/lib-manager-go/internal/domain/book"
+	"mime"
+	"path"
+	"strconv"
+	"time"
+)
+
+const (
+	// FormatAtom - the Atom 1.0 feed, see https://www.rfc-editor.org/rfc/rfc4287
+	FormatAtom = "atom"
+	// FormatRSS - the RSS 2.0 feed, see https://www.rssboard.org/rss-specification
+	FormatRSS = "rss"
+
+	MediaTypeAtom = "application/atom+xml"
+	MediaTypeRSS  = "application/rss+xml"
+
+	defaultCoverType = "application/octet-stream"
+	idPrefix         = "urn:lib-manager:"
+)
+
+// Feed - the syndication feed, written in either format. The links are absolute, as the feed readers require
+type Feed struct {
+	ID          string
+	Title       string
+	Description string
+	Link        string
+	SelfLink    string
+	Updated     time.Time
+	Entries     []Entry
+}
+
+// Entry - the feed entry, the published time is the one the book was added at
+type Entry struct {
+	ID         string
+	Title      string
+	Summary    string
+	Link       string
+	Authors    []string
+	Categories []string
+	Published  time.Time
+	Updated    time.Time
+	Enclosure  *Enclosure
+}
+
+// Enclosure - the media file attached to the entry. The zero length means it is unknown
+type Enclosure struct {
+	URL    string
+	Type   string
+	Length int64
+}
+
+// Item - the newly added book, along with its cover details, the cover is nil if the book has none
+type Item struct {
+	book.Book
+	Cover *Cover
+}
+
+// Cover - the stored cover details, the size is only known for the content-addressed covers
+type Cover struct {
+	Hash     string `db:"hash"`
+	MIMEType string `db:"mime_type"`
+	Size     int64  `db:"size"`
+}
+
+// legacyCover - the cover details of the legacy cover, guessed by the file extension
+func legacyCover(coverFileName string) *Cover {
+	mimeType := mime.TypeByExtension(path.Ext(coverFileName))
+	if mimeType == "" {
+		mimeType = defaultCoverType
+	}
+
+	return &Cover{MIMEType: mimeType}
+}
+
+// BookID - the book URN, the same one the OPDS catalog uses
+func BookID(bookID int64) string {
+	return idPrefix + "book:" + strconv.FormatInt(bookID, 10)
+}
+
+// ContentType - the media type of the feed format
+func ContentType(format string) string {
+	if format == FormatRSS {
+		return MediaTypeRSS
+	}
+
+	return MediaTypeAtom
+}
+
+// Updated - returns the latest update time of the entries, or the zero time if there are none
+func Updated(entries []Entry) time.Time {
+	var updated time.Time
+	for _, entry := range entries {
+		if entry.Updated.After(updated) {
+			updated = entry.Updated
+		}
+	}
+
+	return updated
+}
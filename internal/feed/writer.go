@@ -0,0 +1,15 @@
+package feed
+
+import "io"
+
+// WriteFeed - writes the feed in the requested format
+func WriteFeed(w io.Writer, format string, feed Feed) error {
+	switch format {
+	case FormatAtom:
+		return WriteAtom(w, feed)
+	case FormatRSS:
+		return WriteRSS(w, feed)
+	default:
+		return ErrUnsupportedFormat
+	}
+}
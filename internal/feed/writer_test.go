@@ -0,0 +1,168 @@
+package feed
+
+import (
+	"bytes"
+	"encoding/xml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+var (
+	testAdded   = time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	testUpdated = time.Date(2026, 10, 5, 8, 30, 0, 0, time.UTC)
+)
+
+func TestContentType(t *testing.T) {
+	assert.Equal(t, MediaTypeAtom, ContentType(FormatAtom))
+	assert.Equal(t, MediaTypeRSS, ContentType(FormatRSS))
+}
+
+func TestUpdated(t *testing.T) {
+	assert.True(t, Updated(nil).IsZero())
+	assert.Equal(t, testUpdated, Updated(getTestFeed().Entries))
+}
+
+func TestWriteAtom(t *testing.T) {
+	var buffer bytes.Buffer
+	require.NoError(t, WriteFeed(&buffer, FormatAtom, getTestFeed()))
+
+	var result struct {
+		ID      string `xml:"id"`
+		Updated string `xml:"updated"`
+		Links   []struct {
+			Rel  string `xml:"rel,attr"`
+			Href string `xml:"href,attr"`
+		} `xml:"link"`
+		Entries []struct {
+			ID        string `xml:"id"`
+			Title     string `xml:"title"`
+			Published string `xml:"published"`
+			Updated   string `xml:"updated"`
+			Authors   []struct {
+				Name string `xml:"name"`
+			} `xml:"author"`
+			Categories []struct {
+				Term string `xml:"term,attr"`
+			} `xml:"category"`
+			Links []struct {
+				Rel    string `xml:"rel,attr"`
+				Href   string `xml:"href,attr"`
+				Type   string `xml:"type,attr"`
+				Length string `xml:"length,attr"`
+			} `xml:"link"`
+		} `xml:"entry"`
+	}
+	require.NoError(t, xml.Unmarshal(buffer.Bytes(), &result))
+
+	assert.Equal(t, "urn:lib-manager:feeds:new", result.ID)
+	assert.Equal(t, "2026-10-05T08:30:00Z", result.Updated)
+	if assert.Len(t, result.Links, 2) {
+		assert.Equal(t, "self", result.Links[0].Rel)
+		assert.Equal(t, "https://books.example.com/feeds/new.atom", result.Links[0].Href)
+	}
+	require.Len(t, result.Entries, 2)
+	entry := result.Entries[0]
+	assert.Equal(t, "urn:lib-manager:book:2", entry.ID)
+	assert.Equal(t, "2026-10-01T12:00:00Z", entry.Published)
+	assert.Equal(t, "2026-10-05T08:30:00Z", entry.Updated)
+	if assert.Len(t, entry.Authors, 2) {
+		assert.Equal(t, "Jon Bodner", entry.Authors[0].Name)
+	}
+	if assert.Len(t, entry.Categories, 1) {
+		assert.Equal(t, "go", entry.Categories[0].Term)
+	}
+	if assert.Len(t, entry.Links, 2) {
+		assert.Equal(t, "enclosure", entry.Links[1].Rel)
+		assert.Equal(t, "https://books.example.com/v1/books/2/cover", entry.Links[1].Href)
+		assert.Equal(t, "image/jpeg", entry.Links[1].Type)
+		assert.Equal(t, "1024", entry.Links[1].Length)
+	}
+	assert.Len(t, result.Entries[1].Links, 1, "the entry without a cover should have no enclosure")
+}
+
+func TestWriteRSS(t *testing.T) {
+	var buffer bytes.Buffer
+	require.NoError(t, WriteFeed(&buffer, FormatRSS, getTestFeed()))
+
+	var result struct {
+		Version string `xml:"version,attr"`
+		Channel struct {
+			Title         string `xml:"title"`
+			Link          string `xml:"link"`
+			LastBuildDate string `xml:"lastBuildDate"`
+			Items         []struct {
+				Title      string   `xml:"title"`
+				GUID       string   `xml:"guid"`
+				PubDate    string   `xml:"pubDate"`
+				Creator    string   `xml:"creator"`
+				Categories []string `xml:"category"`
+				Enclosure  *struct {
+					URL    string `xml:"url,attr"`
+					Length string `xml:"length,attr"`
+					Type   string `xml:"type,attr"`
+				} `xml:"enclosure"`
+			} `xml:"item"`
+		} `xml:"channel"`
+	}
+	require.NoError(t, xml.Unmarshal(buffer.Bytes(), &result))
+
+	assert.Equal(t, "2.0", result.Version)
+	assert.Equal(t, "New books", result.Channel.Title)
+	assert.Equal(t, "Mon, 05 Oct 2026 08:30:00 +0000", result.Channel.LastBuildDate)
+	require.Len(t, result.Channel.Items, 2)
+	item := result.Channel.Items[0]
+	assert.Equal(t, "urn:lib-manager:book:2", item.GUID)
+	assert.Equal(t, "Thu, 01 Oct 2026 12:00:00 +0000", item.PubDate)
+	assert.Equal(t, "Jon Bodner, Mat Ryer", item.Creator)
+	assert.Equal(t, []string{"go"}, item.Categories)
+	if assert.NotNil(t, item.Enclosure) {
+		assert.Equal(t, "https://books.example.com/v1/books/2/cover", item.Enclosure.URL)
+		assert.Equal(t, "1024", item.Enclosure.Length)
+		assert.Equal(t, "image/jpeg", item.Enclosure.Type)
+	}
+	assert.Nil(t, result.Channel.Items[1].Enclosure)
+}
+
+func TestWriteFeed_UnsupportedFormat(t *testing.T) {
+	var buffer bytes.Buffer
+	assert.ErrorIs(t, WriteFeed(&buffer, "json", getTestFeed()), ErrUnsupportedFormat)
+}
+
+func getTestFeed() Feed {
+	entries := []Entry{
+		{
+			ID:         BookID(2),
+			Title:      "Learning Go",
+			Summary:    "An idiomatic approach to real-world Go programming",
+			Link:       "https://books.example.com/v1/books/2",
+			Authors:    []string{"Jon Bodner", "Mat Ryer"},
+			Categories: []string{"go"},
+			Published:  testAdded,
+			Updated:    testUpdated,
+			Enclosure: &Enclosure{
+				URL:    "https://books.example.com/v1/books/2/cover",
+				Type:   "image/jpeg",
+				Length: 1024,
+			},
+		},
+		{
+			ID:        BookID(1),
+			Title:     "Go in Action",
+			Link:      "https://books.example.com/v1/books/1",
+			Published: testAdded.Add(-time.Hour),
+			Updated:   testAdded.Add(-time.Hour),
+		},
+	}
+
+	return Feed{
+		ID:          "urn:lib-manager:feeds:new",
+		Title:       "New books",
+		Description: "The books recently added to the library",
+		Link:        "https://books.example.com/v1/books",
+		SelfLink:    "https://books.example.com/feeds/new.atom",
+		Updated:     Updated(entries),
+		Entries:     entries,
+	}
+}
@@ -0,0 +1,9 @@
+package importer
+
+import "errors"
+
+var (
+	ErrUnsupportedFormat = errors.New("unsupported import format, the supported ones are 'csv' and 'ndjson'")
+	ErrInvalidMapping    = errors.New("invalid column mapping")
+	ErrInvalidFile       = errors.New("invalid import file")
+)
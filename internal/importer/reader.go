@@ -0,0 +1,259 @@
+package importer
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/sdreger/lib-manager-go/internal/domain/book"
+	"github.com/sdreger/lib-manager-go/internal/isbn"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// listSeparator - separates the authors, the categories and the tags in a CSV cell
+	listSeparator = ";"
+
+	maxLineSize = 1 << 20
+)
+
+var pubDateLayouts = []string{time.DateOnly, time.RFC3339, "2006-01", "2006"}
+
+// row - the parsed input row, the rows with errors are not imported
+type row struct {
+	line   int
+	book   book.Book
+	errors []string
+}
+
+// rowReader - reads the input rows one by one, returns io.EOF after the last one
+type rowReader interface {
+	next() (row, error)
+}
+
+func newRowReader(input io.Reader, options Options) (rowReader, error) {
+	switch options.Format {
+	case FormatCSV:
+		return newCSVReader(input, options.Mapping)
+	case FormatNDJSON:
+		scanner := bufio.NewScanner(input)
+		scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+		return &ndjsonReader{scanner: scanner}, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedFormat, options.Format)
+	}
+}
+
+// csvReader - reads the CSV rows, the first row is the header with the column names
+type csvReader struct {
+	reader  *csv.Reader
+	columns map[string]int
+}
+
+func newCSVReader(input io.Reader, mapping Mapping) (*csvReader, error) {
+	reader := csv.NewReader(input)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err != nil {
+		return nil, errors.Join(ErrInvalidFile, err)
+	}
+
+	indexes := make(map[string]int, len(header))
+	for i, name := range header {
+		if i == 0 {
+			// the spreadsheet exports often start with the byte order mark
+			name = strings.TrimPrefix(name, "\uFEFF")
+		}
+		name = strings.ToLower(strings.TrimSpace(name))
+		if _, exists := indexes[name]; !exists {
+			indexes[name] = i
+		}
+	}
+	columns := make(map[string]int, len(Fields))
+	for _, field := range Fields {
+		column, mapped := mapping[field]
+		if !mapped {
+			column = field
+		}
+		index, found := indexes[strings.ToLower(column)]
+		if !found && mapped {
+			return nil, fmt.Errorf("%w: the column %q of the field %q is not found", ErrInvalidMapping, column, field)
+		}
+		if found {
+			columns[field] = index
+		}
+	}
+	if _, found := columns["title"]; !found {
+		return nil, fmt.Errorf("%w: the title column is required", ErrInvalidMapping)
+	}
+
+	return &csvReader{reader: reader, columns: columns}, nil
+}
+
+func (r *csvReader) next() (row, error) {
+	record, err := r.reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return row{}, err
+		}
+
+		return row{}, errors.Join(ErrInvalidFile, err)
+	}
+
+	line, _ := r.reader.FieldPos(0)
+	values := make(map[string]string, len(r.columns))
+	for field, index := range r.columns {
+		if index < len(record) {
+			values[field] = strings.TrimSpace(record[index])
+		}
+	}
+	parsed, errs := parseValues(values)
+
+	return row{line: line, book: parsed, errors: append(errs, validate(parsed)...)}, nil
+}
+
+// ndjsonReader - reads the book JSON objects, one per line, the empty lines are skipped
+type ndjsonReader struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+func (r *ndjsonReader) next() (row, error) {
+	for r.scanner.Scan() {
+		r.line++
+		data := strings.TrimSpace(r.scanner.Text())
+		if data == "" {
+			continue
+		}
+
+		var parsed book.Book
+		if err := json.Unmarshal([]byte(data), &parsed); err != nil {
+			return row{line: r.line, errors: []string{"invalid JSON: " + err.Error()}}, nil
+		}
+		parsed = importable(parsed)
+
+		return row{line: r.line, book: parsed, errors: validate(parsed)}, nil
+	}
+	if err := r.scanner.Err(); err != nil {
+		return row{}, errors.Join(ErrInvalidFile, err)
+	}
+
+	return row{}, io.EOF
+}
+
+// parseValues - converts the CSV cell values to a book, the list values are separated by semicolons
+func parseValues(values map[string]string) (book.Book, []string) {
+	var errs []string
+	result := book.Book{
+		Title:        values["title"],
+		Subtitle:     values["subtitle"],
+		Description:  values["description"],
+		ISBN10:       values["isbn10"],
+		ASIN:         values["asin"],
+		Publisher:    values["publisher"],
+		PublisherURL: values["publisher_url"],
+		Language:     values["language"],
+		Authors:      splitList(values["authors"]),
+		Categories:   splitList(values["categories"]),
+		Tags:         splitList(values["tags"]),
+	}
+	if value := isbn.Normalize(values["isbn13"]); value != "" {
+		number, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			errs = append(errs, "isbn13: should be a number: "+values["isbn13"])
+		}
+		result.ISBN13 = number
+	}
+	if value := values["pages"]; value != "" {
+		pages, err := strconv.ParseUint(value, 10, 16)
+		if err != nil {
+			errs = append(errs, "pages: should be a number from 0 to 65535: "+value)
+		}
+		result.Pages = uint16(pages)
+	}
+	if value := values["edition"]; value != "" {
+		edition, err := strconv.ParseUint(value, 10, 8)
+		if err != nil {
+			errs = append(errs, "edition: should be a number from 0 to 255: "+value)
+		}
+		result.Edition = uint8(edition)
+	}
+	if value := values["pub_date"]; value != "" {
+		pubDate, err := parsePubDate(value)
+		if err != nil {
+			errs = append(errs, "pub_date: should be a 'YYYY-MM-DD', 'YYYY-MM' or 'YYYY' date: "+value)
+		}
+		result.PubDate = pubDate
+	}
+
+	return result, errs
+}
+
+func parsePubDate(value string) (time.Time, error) {
+	var err error
+	for _, layout := range pubDateLayouts {
+		var pubDate time.Time
+		if pubDate, err = time.Parse(layout, value); err == nil {
+			return pubDate, nil
+		}
+	}
+
+	return time.Time{}, err
+}
+
+func splitList(value string) []string {
+	var result []string
+	for _, item := range strings.Split(value, listSeparator) {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+
+	return result
+}
+
+// importable - keeps only the importable book fields, the files and the covers are not imported
+func importable(source book.Book) book.Book {
+	return book.Book{
+		Title:        strings.TrimSpace(source.Title),
+		Subtitle:     strings.TrimSpace(source.Subtitle),
+		Description:  strings.TrimSpace(source.Description),
+		ISBN10:       strings.TrimSpace(source.ISBN10),
+		ISBN13:       source.ISBN13,
+		ASIN:         strings.TrimSpace(source.ASIN),
+		Pages:        source.Pages,
+		Edition:      source.Edition,
+		PubDate:      source.PubDate,
+		Publisher:    strings.TrimSpace(source.Publisher),
+		PublisherURL: strings.TrimSpace(source.PublisherURL),
+		Language:     strings.TrimSpace(source.Language),
+		Authors:      source.Authors,
+		Categories:   source.Categories,
+		Tags:         source.Tags,
+	}
+}
+
+// validate - checks the row values, the identifiers and the ISBN forms are checked by the book service as well
+func validate(value book.Book) []string {
+	var errs []string
+	if value.Title == "" {
+		errs = append(errs, "title: is required")
+	}
+	if value.ISBN10 != "" && !isbn.IsValid10(isbn.Normalize(value.ISBN10)) {
+		errs = append(errs, "isbn10: invalid ISBN-10: "+value.ISBN10)
+	}
+	if value.ISBN13 != 0 && !isbn.IsValid13(strconv.FormatInt(value.ISBN13, 10)) {
+		errs = append(errs, "isbn13: invalid ISBN-13: "+strconv.FormatInt(value.ISBN13, 10))
+	}
+	if value.ISBN10 == "" && value.ISBN13 == 0 && value.ASIN == "" {
+		errs = append(errs, book.ErrNoIdentifiers.Error())
+	}
+
+	return errs
+}
@@ -0,0 +1,120 @@
+package importer
+
+import (
+	"github.com/sdreger/lib-manager-go/internal/domain/book"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCSVReader(t *testing.T) {
+	input := "\uFEFFBook Title,ISBN,isbn10,Pages,pub_date,Authors,Publisher,Ignored\n" +
+		"Go in Action,978-1-61729-178-4,,264,2015-11,\"William Kennedy; Brian Ketelsen\",Manning,x\n" +
+		"\n" +
+		"Broken,not-a-number,1617291782,-1,11/2015,,,\n" +
+		"Short row\n"
+	mapping := Mapping{"title": "book title", "isbn13": "ISBN", "authors": "Authors"}
+	reader, err := newRowReader(strings.NewReader(input), Options{Format: FormatCSV, Mapping: mapping})
+	require.NoError(t, err, "should read the header")
+
+	first, err := reader.next()
+	require.NoError(t, err)
+	assert.Equal(t, 2, first.line)
+	assert.Empty(t, first.errors)
+	assert.Equal(t, book.Book{
+		Title:     "Go in Action",
+		ISBN13:    9781617291784,
+		Pages:     264,
+		PubDate:   time.Date(2015, time.November, 1, 0, 0, 0, 0, time.UTC),
+		Authors:   []string{"William Kennedy", "Brian Ketelsen"},
+		Publisher: "Manning",
+	}, first.book)
+
+	second, err := reader.next()
+	require.NoError(t, err)
+	assert.Equal(t, 4, second.line, "the empty line should be skipped")
+	assert.Equal(t, []string{
+		"isbn13: should be a number: not-a-number",
+		"pages: should be a number from 0 to 65535: -1",
+		"pub_date: should be a 'YYYY-MM-DD', 'YYYY-MM' or 'YYYY' date: 11/2015",
+		"isbn10: invalid ISBN-10: 1617291782",
+	}, second.errors)
+
+	third, err := reader.next()
+	require.NoError(t, err)
+	assert.Equal(t, "Short row", third.book.Title, "the missing cells should be empty")
+	assert.Contains(t, third.errors, book.ErrNoIdentifiers.Error())
+
+	_, err = reader.next()
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestCSVReader_InvalidMapping(t *testing.T) {
+	_, err := newRowReader(strings.NewReader("title,isbn13\n"),
+		Options{Format: FormatCSV, Mapping: Mapping{"asin": "ASIN"}})
+	assert.ErrorIs(t, err, ErrInvalidMapping, "the mapped column should exist")
+
+	_, err = newRowReader(strings.NewReader("name,isbn13\n"), Options{Format: FormatCSV})
+	assert.ErrorIs(t, err, ErrInvalidMapping, "the title column should be required")
+
+	_, err = newRowReader(strings.NewReader(""), Options{Format: FormatCSV})
+	assert.ErrorIs(t, err, ErrInvalidFile, "the header should be required")
+}
+
+func TestNDJSONReader(t *testing.T) {
+	input := `{"id": 10, "title": "Go in Action", "isbn10": "1617291781", "book_file_name": "book.pdf",` +
+		` "file_types": ["pdf"], "pub_date": "2015-11-01T00:00:00Z"}` + "\n\n" +
+		`{"title": "Broken", "pages": "many"}` + "\n" +
+		`{"asin": "B00TEST123"}` + "\n"
+	reader, err := newRowReader(strings.NewReader(input), Options{Format: FormatNDJSON})
+	require.NoError(t, err)
+
+	first, err := reader.next()
+	require.NoError(t, err)
+	assert.Equal(t, 1, first.line)
+	assert.Empty(t, first.errors)
+	assert.Equal(t, book.Book{
+		Title:   "Go in Action",
+		ISBN10:  "1617291781",
+		PubDate: time.Date(2015, time.November, 1, 0, 0, 0, 0, time.UTC),
+	}, first.book, "only the importable fields should be kept")
+
+	second, err := reader.next()
+	require.NoError(t, err)
+	assert.Equal(t, 3, second.line)
+	require.Len(t, second.errors, 1)
+	assert.Contains(t, second.errors[0], "invalid JSON")
+
+	third, err := reader.next()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"title: is required"}, third.errors)
+
+	_, err = reader.next()
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestNewRowReader_UnsupportedFormat(t *testing.T) {
+	_, err := newRowReader(strings.NewReader(""), Options{Format: "xlsx"})
+	assert.ErrorIs(t, err, ErrUnsupportedFormat)
+}
+
+func TestFormatByFileName(t *testing.T) {
+	assert.Equal(t, FormatCSV, FormatByFileName("/tmp/catalog.CSV"))
+	assert.Equal(t, FormatNDJSON, FormatByFileName("catalog.ndjson"))
+	assert.Equal(t, FormatNDJSON, FormatByFileName("catalog.jsonl"))
+	assert.Equal(t, "xlsx", FormatByFileName("catalog.xlsx"))
+}
+
+func TestParseMapping(t *testing.T) {
+	mapping, err := ParseMapping([]string{"title:Book Title", " isbn13 : ISBN:13 "})
+	require.NoError(t, err)
+	assert.Equal(t, Mapping{"title": "Book Title", "isbn13": "ISBN:13"}, mapping)
+
+	_, err = ParseMapping([]string{"name:Book Title"})
+	assert.ErrorIs(t, err, ErrInvalidMapping, "should reject the unknown field")
+	_, err = ParseMapping([]string{"title"})
+	assert.ErrorIs(t, err, ErrInvalidMapping, "should reject the missing column")
+}
@@ -0,0 +1,114 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"github.com/jmoiron/sqlx"
+	"github.com/sdreger/lib-manager-go/internal/domain/book"
+	"io"
+	"log/slog"
+)
+
+type BookService interface {
+	ImportBooks(ctx context.Context, books []book.Book, dryRun bool) ([]book.UpsertResult, error)
+}
+
+type Service struct {
+	logger      *slog.Logger
+	bookService BookService
+}
+
+func NewService(logger *slog.Logger, db *sqlx.DB) *Service {
+	return &Service{
+		logger:      logger,
+		bookService: book.NewService(logger, db),
+	}
+}
+
+// Import - validates the input rows, and upserts the valid ones by their identifiers in transactional batches.
+// A failed batch fails its rows only, the following batches are still imported
+func (s *Service) Import(ctx context.Context, input io.Reader, options Options) (Report, error) {
+	reader, err := newRowReader(input, options)
+	if err != nil {
+		return Report{}, err
+	}
+	batchSize := options.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+	batchSize = min(batchSize, MaxBatchSize)
+
+	report := Report{DryRun: options.DryRun, Rows: make([]RowResult, 0)}
+	pending := make([]row, 0, batchSize)
+	validRows := 0
+	for {
+		next, err := reader.next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return Report{}, err
+		}
+
+		pending = append(pending, next)
+		if len(next.errors) == 0 {
+			validRows++
+		}
+		if validRows == batchSize {
+			if err := s.importBatch(ctx, pending, options.DryRun, &report); err != nil {
+				return Report{}, err
+			}
+			pending, validRows = pending[:0], 0
+		}
+	}
+	if err := s.importBatch(ctx, pending, options.DryRun, &report); err != nil {
+		return Report{}, err
+	}
+
+	s.logger.Info("books imported", "format", options.Format, "dryRun", options.DryRun, "total", report.Total,
+		"created", report.Created, "updated", report.Updated, "skipped", report.Skipped, "failed", report.Failed)
+	return report, nil
+}
+
+// importBatch - imports the valid rows, and reports all the rows in the input order
+func (s *Service) importBatch(ctx context.Context, rows []row, dryRun bool, report *Report) error {
+	books := make([]book.Book, 0, len(rows))
+	for _, pending := range rows {
+		if len(pending.errors) == 0 {
+			books = append(books, pending.book)
+		}
+	}
+
+	var results []book.UpsertResult
+	var batchErr error
+	if len(books) > 0 {
+		results, batchErr = s.bookService.ImportBooks(ctx, books, dryRun)
+		if batchErr != nil {
+			if ctx.Err() != nil {
+				return batchErr
+			}
+			s.logger.Error("import batch failed", "rows", len(books), "error", batchErr)
+		}
+	}
+
+	for _, pending := range rows {
+		result := RowResult{Line: pending.line, Title: pending.book.Title, Errors: pending.errors}
+		switch {
+		case len(pending.errors) > 0:
+			result.Status = StatusFailed
+		case batchErr != nil:
+			result.Status, result.Errors = StatusFailed, []string{"the batch failed: " + batchErr.Error()}
+		default:
+			upserted := results[0]
+			results = results[1:]
+			if upserted.Err != nil {
+				result.Status, result.Errors = StatusFailed, []string{upserted.Err.Error()}
+			} else {
+				result.Status, result.BookID = upserted.Status, upserted.BookID
+			}
+		}
+		report.add(result)
+	}
+
+	return nil
+}
@@ -0,0 +1,106 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"github.com/sdreger/lib-manager-go/internal/domain/book"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+)
+
+const testCSV = "title,isbn13,asin,publisher,language\n" +
+	"Book 01,9781617291784,,Manning,English\n" +
+	"Book 02,,B00TEST002,Manning,English\n" +
+	",,B00TEST003,,\n" +
+	"Book 04,,B00TEST004,,\n" +
+	"Book 05,,B00TEST005,Manning,English\n"
+
+func TestService_Import(t *testing.T) {
+	ctx := context.Background()
+	service := getService()
+
+	mockBookService := NewMockBookService(t)
+	mockBookService.EXPECT().ImportBooks(ctx, []book.Book{
+		{Title: "Book 01", ISBN13: 9781617291784, Publisher: "Manning", Language: "English"},
+		{Title: "Book 02", ASIN: "B00TEST002", Publisher: "Manning", Language: "English"},
+	}, true).Return([]book.UpsertResult{
+		{BookID: 1, Status: book.UpsertCreated},
+		{BookID: 2, Status: book.UpsertSkipped},
+	}, nil).Once()
+	mockBookService.EXPECT().ImportBooks(ctx, []book.Book{
+		{Title: "Book 04", ASIN: "B00TEST004"},
+		{Title: "Book 05", ASIN: "B00TEST005", Publisher: "Manning", Language: "English"},
+	}, true).Return([]book.UpsertResult{
+		{Err: book.ErrIncompleteBook},
+		{BookID: 5, Status: book.UpsertUpdated},
+	}, nil).Once()
+	service.bookService = mockBookService
+
+	report, err := service.Import(ctx, strings.NewReader(testCSV), Options{Format: FormatCSV, DryRun: true, BatchSize: 2})
+	require.NoError(t, err, "should import the rows")
+	assert.Equal(t, Report{
+		DryRun:  true,
+		Total:   5,
+		Created: 1,
+		Updated: 1,
+		Skipped: 1,
+		Failed:  2,
+		Rows: []RowResult{
+			{Line: 2, Status: StatusCreated, BookID: 1, Title: "Book 01"},
+			{Line: 3, Status: StatusSkipped, BookID: 2, Title: "Book 02"},
+			{Line: 4, Status: StatusFailed, Errors: []string{"title: is required"}},
+			{Line: 5, Status: StatusFailed, Title: "Book 04", Errors: []string{book.ErrIncompleteBook.Error()}},
+			{Line: 6, Status: StatusUpdated, BookID: 5, Title: "Book 05"},
+		},
+	}, report, "the rows should be reported in the input order")
+}
+
+func TestService_Import_BatchFailure(t *testing.T) {
+	ctx := context.Background()
+	service := getService()
+
+	mockBookService := NewMockBookService(t)
+	mockBookService.EXPECT().ImportBooks(ctx, []book.Book{{Title: "Book", ASIN: "B00TEST001"}}, false).
+		Return(nil, errors.New("connection reset")).Once()
+	service.bookService = mockBookService
+
+	input := `{"title": "Book", "asin": "B00TEST001"}`
+	report, err := service.Import(ctx, strings.NewReader(input), Options{Format: FormatNDJSON})
+	require.NoError(t, err, "the failed batch should be reported")
+	assert.Equal(t, 1, report.Failed)
+	assert.Equal(t, []string{"the batch failed: connection reset"}, report.Rows[0].Errors)
+}
+
+func TestService_Import_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	service := getService()
+
+	mockBookService := NewMockBookService(t)
+	mockBookService.EXPECT().ImportBooks(ctx, []book.Book{{Title: "Book", ASIN: "B00TEST001"}}, false).
+		Return(nil, context.Canceled).Once()
+	service.bookService = mockBookService
+
+	input := `{"title": "Book", "asin": "B00TEST001"}`
+	_, err := service.Import(ctx, strings.NewReader(input), Options{Format: FormatNDJSON})
+	require.ErrorIs(t, err, context.Canceled, "the canceled import should stop")
+}
+
+func TestService_Import_Empty(t *testing.T) {
+	service := getService()
+	service.bookService = NewMockBookService(t)
+
+	report, err := service.Import(context.Background(), strings.NewReader("title,isbn13\n"), Options{Format: FormatCSV})
+	require.NoError(t, err)
+	assert.Zero(t, report.Total)
+	assert.NotNil(t, report.Rows, "the rows should be rendered as an empty list")
+}
+
+func getService() *Service {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	return NewService(logger, nil)
+}
@@ -0,0 +1,103 @@
+package importer
+
+import (
+	"fmt"
+	"github.com/sdreger/lib-manager-go/internal/domain/book"
+	"path"
+	"slices"
+	"strings"
+)
+
+const (
+	FormatCSV    = "csv"
+	FormatNDJSON = "ndjson"
+
+	StatusCreated = book.UpsertCreated
+	StatusUpdated = book.UpsertUpdated
+	StatusSkipped = book.UpsertSkipped
+	StatusFailed  = "failed"
+
+	DefaultBatchSize = 100
+	MaxBatchSize     = 1000
+)
+
+// Fields - the importable book fields, named after the book JSON fields. The CSV columns are mapped to them
+// by their names, unless a custom mapping is provided
+var Fields = []string{
+	"title", "subtitle", "description", "isbn10", "isbn13", "asin", "pages", "edition", "pub_date",
+	"publisher", "publisher_url", "language", "authors", "categories", "tags",
+}
+
+// FormatByFileName - detects the import format by the file extension, the '.jsonl' files are NDJSON ones
+func FormatByFileName(fileName string) string {
+	extension := strings.TrimPrefix(strings.ToLower(path.Ext(fileName)), ".")
+	if extension == "jsonl" {
+		return FormatNDJSON
+	}
+
+	return extension
+}
+
+// Mapping - the book field to the CSV column name mapping
+type Mapping map[string]string
+
+// ParseMapping - parses the 'field:column' pairs, the column names may contain colons
+func ParseMapping(pairs []string) (Mapping, error) {
+	mapping := make(Mapping, len(pairs))
+	for _, pair := range pairs {
+		field, column, found := strings.Cut(pair, ":")
+		field, column = strings.TrimSpace(field), strings.TrimSpace(column)
+		if !found || column == "" {
+			return nil, fmt.Errorf("%w: %q should be 'field:column'", ErrInvalidMapping, pair)
+		}
+		if !slices.Contains(Fields, field) {
+			return nil, fmt.Errorf("%w: unknown field %q, the supported ones are %v", ErrInvalidMapping, field, Fields)
+		}
+		mapping[field] = column
+	}
+
+	return mapping, nil
+}
+
+// Options - the import options, the zero batch size means the default one
+type Options struct {
+	Format    string
+	DryRun    bool
+	BatchSize int
+	Mapping   Mapping
+}
+
+// Report - the import result, the rows are reported in the input order
+type Report struct {
+	DryRun  bool        `json:"dry_run"`
+	Total   int         `json:"total"`
+	Created int         `json:"created"`
+	Updated int         `json:"updated"`
+	Skipped int         `json:"skipped"`
+	Failed  int         `json:"failed"`
+	Rows    []RowResult `json:"rows"`
+}
+
+// RowResult - the row import result. The line is the input line, the CSV header is the first one
+type RowResult struct {
+	Line   int      `json:"line"`
+	Status string   `json:"status"`
+	BookID int64    `json:"book_id,omitempty"`
+	Title  string   `json:"title,omitempty"`
+	Errors []string `json:"errors,omitempty"`
+}
+
+func (r *Report) add(result RowResult) {
+	r.Total++
+	switch result.Status {
+	case StatusCreated:
+		r.Created++
+	case StatusUpdated:
+		r.Updated++
+	case StatusSkipped:
+		r.Skipped++
+	default:
+		r.Failed++
+	}
+	r.Rows = append(r.Rows, result)
+}
@@ -0,0 +1,11 @@
+package inbox
+
+import "errors"
+
+var (
+	ErrNoMetadataSource = errors.New("the group has no EPUB or PDF file to extract the metadata from")
+	ErrNoMetadata       = errors.New("the metadata can not be extracted from any of the book files")
+	ErrMissingTitle     = errors.New("the book title is missing")
+	ErrMissingAuthors   = errors.New("the book authors are missing")
+	ErrUploadFailed     = errors.New("some of the book files can not be uploaded")
+)
@@ -0,0 +1,144 @@
+package inbox
+
+import (
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	dirDone       = "done"
+	dirFailed     = "failed"
+	dirProcessing = "processing"
+)
+
+// coverExtensions - the image files of a group are treated as the book cover, rather than the book files
+var coverExtensions = []string{".jpg", ".jpeg", ".png", ".gif", ".webp", ".svg"}
+
+// scan - returns the inbox groups, which have not been modified for the settle time.
+// The hidden files and the worker directories are skipped. A top-level image, having no book file with the same
+// basename, is attached to the only top-level book group, e.g. 'cover.jpg' dropped along with 'book.epub'.
+// If there are several top-level book groups, the image can not be matched, and stays a group on its own
+func scan(dir string, settleTime time.Duration, now time.Time) ([]group, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	settled := func(entry os.DirEntry) bool {
+		info, err := entry.Info()
+		return err == nil && now.Sub(info.ModTime()) >= settleTime
+	}
+
+	var groups []group
+	byBasename := make(map[string]*group)
+	unsettled := make(map[string]bool)
+	books := make(map[string]bool) // the basenames having some files other than the images
+	for _, entry := range entries {
+		entryName := entry.Name()
+		if strings.HasPrefix(entryName, ".") {
+			continue
+		}
+
+		if entry.IsDir() {
+			if slices.Contains([]string{dirDone, dirFailed, dirProcessing}, entryName) {
+				continue
+			}
+			dirGroup, ok := scanDir(filepath.Join(dir, entryName), settleTime, now)
+			if ok && settled(entry) {
+				groups = append(groups, dirGroup)
+			}
+			continue
+		}
+		if !entry.Type().IsRegular() {
+			continue
+		}
+
+		basename := strings.TrimSuffix(entryName, filepath.Ext(entryName))
+		if !isCover(entryName) {
+			books[basename] = true
+		}
+		if !settled(entry) {
+			unsettled[basename] = true
+			continue
+		}
+		if byBasename[basename] == nil {
+			byBasename[basename] = &group{name: basename}
+		}
+		byBasename[basename].paths = append(byBasename[basename].paths, filepath.Join(dir, entryName))
+	}
+	if len(books) == 1 {
+		attachImages(slices.Collect(maps.Keys(books))[0], byBasename, unsettled)
+	}
+
+	for _, basename := range slices.Sorted(maps.Keys(byBasename)) {
+		// the group is only processed, once all of its files are settled
+		if !unsettled[basename] {
+			groups = append(groups, *byBasename[basename])
+		}
+	}
+	slices.SortFunc(groups, func(a, b group) int {
+		return strings.Compare(a.name, b.name)
+	})
+
+	return groups, nil
+}
+
+// attachImages - moves the image-only groups to the book group. The book group waits for the unsettled images
+func attachImages(bookName string, byBasename map[string]*group, unsettled map[string]bool) {
+	bookGroup := byBasename[bookName]
+	if bookGroup == nil {
+		bookGroup = &group{name: bookName}
+	}
+	for basename, imageGroup := range byBasename {
+		if basename == bookName {
+			continue
+		}
+		bookGroup.paths = append(bookGroup.paths, imageGroup.paths...)
+		delete(byBasename, basename)
+	}
+	for basename := range unsettled {
+		if basename != bookName {
+			unsettled[bookName] = true
+			delete(unsettled, basename)
+		}
+	}
+	if len(bookGroup.paths) > 0 {
+		slices.Sort(bookGroup.paths)
+		byBasename[bookName] = bookGroup
+	}
+}
+
+// scanDir - returns the directory group, unless it is empty, or has some of the files not settled yet
+func scanDir(dir string, settleTime time.Duration, now time.Time) (group, bool) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return group{}, false
+	}
+
+	dirGroup := group{name: filepath.Base(dir), dir: dir}
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), ".") || !entry.Type().IsRegular() {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || now.Sub(info.ModTime()) < settleTime {
+			return group{}, false
+		}
+		dirGroup.paths = append(dirGroup.paths, filepath.Join(dir, entry.Name()))
+	}
+
+	return dirGroup, len(dirGroup.paths) > 0
+}
+
+func isCover(path string) bool {
+	return slices.Contains(coverExtensions, strings.ToLower(filepath.Ext(path)))
+}
+
+// fileType - returns the book file type by its extension, e.g. 'epub'
+func fileType(path string) string {
+	return strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+}
@@ -0,0 +1,97 @@
+package inbox
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestScan(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	settled := now.Add(-time.Minute)
+	writeTestFile(t, filepath.Join(dir, "go.epub"), settled)
+	writeTestFile(t, filepath.Join(dir, "go.pdf"), settled)
+	writeTestFile(t, filepath.Join(dir, "go.jpg"), settled)
+	writeTestFile(t, filepath.Join(dir, "rust.epub"), settled)
+	writeTestFile(t, filepath.Join(dir, "rust.pdf"), now) // still being copied
+	writeTestFile(t, filepath.Join(dir, ".hidden.epub"), settled)
+	writeTestFile(t, filepath.Join(dir, "java", "book.epub"), settled)
+	writeTestFile(t, filepath.Join(dir, "java", "cover.png"), settled)
+	writeTestFile(t, filepath.Join(dir, "kotlin", "book.epub"), now)
+	writeTestFile(t, filepath.Join(dir, dirDone, "done.epub"), settled)
+	require.NoError(t, os.Chtimes(filepath.Join(dir, "java"), settled, settled))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "empty"), 0o755))
+
+	groups, err := scan(dir, 10*time.Second, now)
+	require.NoError(t, err, "should scan inbox")
+	assert.Equal(t, []group{
+		{name: "go", paths: []string{
+			filepath.Join(dir, "go.epub"), filepath.Join(dir, "go.jpg"), filepath.Join(dir, "go.pdf"),
+		}},
+		{name: "java", dir: filepath.Join(dir, "java"), paths: []string{
+			filepath.Join(dir, "java", "book.epub"), filepath.Join(dir, "java", "cover.png"),
+		}},
+	}, groups)
+}
+
+func TestScan_LoneImage(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	settled := now.Add(-time.Minute)
+	writeTestFile(t, filepath.Join(dir, "go.epub"), settled)
+	writeTestFile(t, filepath.Join(dir, "go.pdf"), settled)
+	writeTestFile(t, filepath.Join(dir, "cover.jpg"), settled)
+	writeTestFile(t, filepath.Join(dir, "java", "book.epub"), settled)
+	require.NoError(t, os.Chtimes(filepath.Join(dir, "java"), settled, settled))
+
+	groups, err := scan(dir, 10*time.Second, now)
+	require.NoError(t, err, "should scan inbox")
+	require.Len(t, groups, 2)
+	assert.Equal(t, group{name: "go", paths: []string{
+		filepath.Join(dir, "cover.jpg"), filepath.Join(dir, "go.epub"), filepath.Join(dir, "go.pdf"),
+	}}, groups[0], "the image should be attached to the only top-level book group")
+
+	// the book group waits for the image being copied
+	writeTestFile(t, filepath.Join(dir, "back.png"), now)
+	groups, err = scan(dir, 10*time.Second, now)
+	require.NoError(t, err, "should scan inbox")
+	assert.Equal(t, []string{"java"}, groupNames(groups))
+
+	// the image can not be matched to one of several book groups
+	writeTestFile(t, filepath.Join(dir, "back.png"), settled)
+	writeTestFile(t, filepath.Join(dir, "rust.epub"), settled)
+	groups, err = scan(dir, 10*time.Second, now)
+	require.NoError(t, err, "should scan inbox")
+	assert.Equal(t, []string{"back", "cover", "go", "java", "rust"}, groupNames(groups))
+}
+
+func TestScan_MissingDir(t *testing.T) {
+	_, err := scan(filepath.Join(t.TempDir(), "missing"), 0, time.Now())
+	assert.Error(t, err, "should fail on missing directory")
+}
+
+func TestFileType(t *testing.T) {
+	assert.Equal(t, "epub", fileType("/inbox/Go.In.Action.EPUB"))
+	assert.Equal(t, "", fileType("/inbox/README"))
+	assert.True(t, isCover("/inbox/cover.JPG"))
+	assert.False(t, isCover("/inbox/book.pdf"))
+}
+
+func groupNames(groups []group) []string {
+	names := make([]string, 0, len(groups))
+	for _, g := range groups {
+		names = append(names, g.name)
+	}
+
+	return names
+}
+
+func writeTestFile(t *testing.T, path string, modTime time.Time) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(filepath.Base(path)), 0o644))
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+}
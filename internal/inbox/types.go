@@ -0,0 +1,44 @@
+package inbox
+
+import (
+	"time"
+)
+
+const (
+	StatusDone   = "done"
+	StatusFailed = "failed"
+
+	RoleBook    = "book"
+	RoleCover   = "cover"
+	RoleIgnored = "ignored"
+)
+
+// group - the inbox files of the same book: either the top-level files with the same basename,
+// e.g. 'book.pdf', 'book.epub' and 'book.jpg', or all the files of a top-level directory.
+// The top-level image with another basename only joins the group, if it is the only top-level book group
+type group struct {
+	name  string
+	dir   string // the group directory, empty for the top-level files
+	paths []string
+}
+
+// Report - the group processing result, stored as 'report.json' along with the processed files
+type Report struct {
+	Group      string       `json:"group"`
+	Status     string       `json:"status"`
+	BookID     int64        `json:"book_id,omitempty"`
+	Created    bool         `json:"created"`
+	Files      []FileReport `json:"files"`
+	Warnings   []string     `json:"warnings"`
+	Error      string       `json:"error,omitempty"`
+	StartedAt  time.Time    `json:"started_at"`
+	FinishedAt time.Time    `json:"finished_at"`
+}
+
+type FileReport struct {
+	Name   string `json:"name"`
+	Role   string `json:"role"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
@@ -0,0 +1,445 @@
+package inbox
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"github.com/sdreger/lib-manager-go/internal/config"
+	"github.com/sdreger/lib-manager-go/internal/domain/book"
+	"github.com/sdreger/lib-manager-go/internal/domain/bookfile"
+	"github.com/sdreger/lib-manager-go/internal/domain/cover"
+	"github.com/sdreger/lib-manager-go/internal/ingest"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	reportFileName   = "report.json"
+	unknownPublisher = "Unknown"
+	// the claimed group directory suffix, keeps the names unique when the same group is dropped again
+	claimTimeLayout = "20060102T150405.000"
+)
+
+// metadataSources - the book file types, the metadata is extracted from, in the order of preference
+var metadataSources = []string{ingest.FormatEPUB, ingest.FormatPDF}
+
+type Ingester interface {
+	Ingest(ctx context.Context, fileName string, content io.ReaderAt, size int64) (ingest.Draft, error)
+}
+
+type BookService interface {
+	FindBookID(ctx context.Context, identifiers book.Identifiers) (int64, error)
+	CreateBook(ctx context.Context, newBook book.Book) (int64, error)
+}
+
+type BookFileService interface {
+	UploadBookFile(ctx context.Context, request bookfile.UploadRequest) (bookfile.BookFile, error)
+}
+
+type CoverService interface {
+	UploadBookCover(ctx context.Context, bookID int64, reader io.Reader) (cover.Cover, error)
+	AssignBookCover(ctx context.Context, bookID int64, hash string) error
+	DiscardCover(ctx context.Context, hash string) error
+}
+
+type BlobStore interface {
+	cover.BlobStore
+	bookfile.BlobStore
+}
+
+// Worker - the watched inbox directory importer. Each group of the settled files is claimed by moving it
+// to the 'processing' directory, so several workers may share the same inbox. Once the group is imported,
+// it is moved to the 'done' or 'failed' directory, along with the JSON report
+type Worker struct {
+	logger          *slog.Logger
+	config          config.InboxConfig
+	ingester        Ingester
+	bookService     BookService
+	bookFileService BookFileService
+	coverService    CoverService
+	now             func() time.Time
+}
+
+func NewWorker(logger *slog.Logger, inboxConfig config.InboxConfig, ingestConfig config.IngestConfig, db *sqlx.DB,
+	blobStore BlobStore) *Worker {
+	return &Worker{
+		logger:          logger,
+		config:          inboxConfig,
+		ingester:        ingest.NewService(logger, ingestConfig, db, blobStore),
+		bookService:     book.NewService(logger, db),
+		bookFileService: bookfile.NewService(logger, db, blobStore),
+		coverService:    cover.NewService(logger, db, blobStore),
+		now:             time.Now,
+	}
+}
+
+// Run - polls the inbox directory until the context is canceled.
+// The group being imported at the moment is completed before the worker stops
+func (w *Worker) Run(ctx context.Context) error {
+	for _, dir := range []string{dirProcessing, dirDone, dirFailed} {
+		if err := os.MkdirAll(filepath.Join(w.config.Dir, dir), 0o755); err != nil {
+			return fmt.Errorf("inbox directory creation error: %w", err)
+		}
+	}
+
+	w.logger.Info("inbox importer started", "dir", w.config.Dir, "pollInterval", w.config.PollInterval)
+	ticker := time.NewTicker(w.config.PollInterval)
+	defer ticker.Stop()
+	for {
+		w.Poll(ctx)
+		select {
+		case <-ctx.Done():
+			w.logger.Info("inbox importer stopped")
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll - imports all the settled inbox groups, and returns their reports. No new group is started,
+// once the context is canceled
+func (w *Worker) Poll(ctx context.Context) []Report {
+	groups, err := scan(w.config.Dir, w.config.SettleTime, w.now())
+	if err != nil {
+		w.logger.Error("inbox scan failed: "+err.Error(), "dir", w.config.Dir)
+		return nil
+	}
+
+	var reports []Report
+	for _, g := range groups {
+		if ctx.Err() != nil {
+			break
+		}
+		if report, ok := w.process(context.WithoutCancel(ctx), g); ok {
+			reports = append(reports, report)
+		}
+	}
+
+	return reports
+}
+
+// process - claims and imports the group, returns 'false' if the group is claimed by another worker
+func (w *Worker) process(ctx context.Context, g group) (Report, bool) {
+	startedAt := w.now()
+	claimName := g.name + "-" + startedAt.UTC().Format(claimTimeLayout)
+	workDir := filepath.Join(w.config.Dir, dirProcessing, claimName)
+	if !w.claim(g, workDir) {
+		return Report{}, false
+	}
+
+	report := Report{Group: g.name, Files: []FileReport{}, Warnings: []string{}, StartedAt: startedAt}
+	err := w.importGroup(ctx, workDir, &report)
+	report.FinishedAt = w.now()
+	targetDir := dirDone
+	if err != nil {
+		report.Status, report.Error, targetDir = StatusFailed, err.Error(), dirFailed
+		w.logger.Warn("inbox group import failed: "+err.Error(), "group", g.name, "bookID", report.BookID)
+	} else {
+		report.Status = StatusDone
+		w.logger.Info("inbox group imported", "group", g.name, "bookID", report.BookID, "created", report.Created)
+	}
+
+	w.finish(workDir, filepath.Join(w.config.Dir, targetDir, claimName), report)
+
+	return report, true
+}
+
+// claim - moves the group files to the work directory. The rename is atomic,
+// so only one of the workers sharing the inbox succeeds
+func (w *Worker) claim(g group, workDir string) bool {
+	if g.dir != "" {
+		return os.Rename(g.dir, workDir) == nil
+	}
+
+	if err := os.Mkdir(workDir, 0o755); err != nil {
+		w.logger.Error("inbox group claim failed: "+err.Error(), "group", g.name)
+		return false
+	}
+	claimed := 0
+	for _, path := range g.paths {
+		if err := os.Rename(path, filepath.Join(workDir, filepath.Base(path))); err == nil {
+			claimed++
+		}
+	}
+	if claimed == 0 {
+		_ = os.Remove(workDir)
+		return false
+	}
+
+	return true
+}
+
+// importGroup - extracts the metadata, creates or matches the book, and uploads the book files and the cover
+func (w *Worker) importGroup(ctx context.Context, workDir string, report *Report) error {
+	entries, err := os.ReadDir(workDir)
+	if err != nil {
+		return err
+	}
+
+	var sources, bookFiles, covers []int // the report file indexes
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		fileReport := FileReport{Name: entry.Name(), Role: RoleBook, Size: info.Size()}
+		index := len(report.Files)
+		switch {
+		case isCover(entry.Name()):
+			fileReport.Role = RoleCover
+			covers = append(covers, index)
+		case slices.Contains(metadataSources, fileType(entry.Name())):
+			sources = append(sources, index)
+			bookFiles = append(bookFiles, index)
+		default:
+			bookFiles = append(bookFiles, index)
+		}
+		report.Files = append(report.Files, fileReport)
+	}
+	if len(sources) == 0 {
+		return ErrNoMetadataSource
+	}
+	slices.SortStableFunc(sources, func(a, b int) int {
+		return slices.Index(metadataSources, fileType(report.Files[a].Name)) -
+			slices.Index(metadataSources, fileType(report.Files[b].Name))
+	})
+
+	var drafts []ingest.Draft
+	for _, index := range sources {
+		fileReport := &report.Files[index]
+		draft, err := w.ingestFile(ctx, filepath.Join(workDir, fileReport.Name))
+		if err != nil {
+			fileReport.Error = err.Error()
+			continue
+		}
+		for _, warning := range draft.Warnings {
+			report.Warnings = append(report.Warnings, fileReport.Name+": "+warning)
+		}
+		drafts = append(drafts, draft)
+	}
+	if len(drafts) == 0 {
+		return ErrNoMetadata
+	}
+	draft := mergeDrafts(drafts)
+	// the extracted covers are stored on ingest, so the ones not assigned to the created book are discarded,
+	// e.g. when the book is matched, or the group image is uploaded instead
+	assignedHash := ""
+	defer func() {
+		w.discardCovers(ctx, drafts, assignedHash, report)
+	}()
+
+	report.BookID, report.Created, err = w.matchOrCreate(ctx, draft.Book, report)
+	if err != nil {
+		return err
+	}
+
+	if len(covers) > 0 {
+		w.uploadCover(ctx, workDir, report, covers)
+	} else if report.Created && draft.Cover != nil {
+		if err := w.coverService.AssignBookCover(ctx, report.BookID, draft.Cover.Hash); err != nil {
+			report.Warnings = append(report.Warnings, "the extracted cover can not be assigned: "+err.Error())
+		} else {
+			assignedHash = draft.Cover.Hash
+		}
+	}
+
+	uploadFailed := false
+	for _, index := range bookFiles {
+		fileReport := &report.Files[index]
+		bookFile, err := w.uploadFile(ctx, report.BookID, filepath.Join(workDir, fileReport.Name))
+		switch {
+		case errors.Is(err, bookfile.ErrUnknownFileType):
+			fileReport.Role = RoleIgnored
+			report.Warnings = append(report.Warnings, fileReport.Name+": unknown book file type, the file is ignored")
+		case err != nil:
+			fileReport.Error = err.Error()
+			uploadFailed = true
+		default:
+			fileReport.SHA256 = bookFile.SHA256
+		}
+	}
+	if uploadFailed {
+		return ErrUploadFailed
+	}
+
+	return nil
+}
+
+func (w *Worker) ingestFile(ctx context.Context, path string) (ingest.Draft, error) {
+	file, size, err := openFile(path)
+	if err != nil {
+		return ingest.Draft{}, err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	return w.ingester.Ingest(ctx, filepath.Base(path), file, size)
+}
+
+// matchOrCreate - returns the ID of the existing book with the same identifiers, otherwise creates a new book.
+// The existing book details are never changed
+func (w *Worker) matchOrCreate(ctx context.Context, draftBook book.Book, report *Report) (int64, bool, error) {
+	identifiers := book.Identifiers{ISBN10: draftBook.ISBN10, ISBN13: draftBook.ISBN13, ASIN: draftBook.ASIN}
+	bookID, err := w.bookService.FindBookID(ctx, identifiers)
+	if err == nil {
+		return bookID, false, nil
+	}
+	if !errors.Is(err, book.ErrNotFound) {
+		return 0, false, err
+	}
+
+	if draftBook.Title == "" {
+		return 0, false, ErrMissingTitle
+	}
+	if len(draftBook.Authors) == 0 {
+		return 0, false, ErrMissingAuthors
+	}
+	if draftBook.Language == "" {
+		draftBook.Language = w.config.DefaultLanguage
+		report.Warnings = append(report.Warnings, "the language is unknown, the default one is used")
+	}
+	if draftBook.Publisher == "" {
+		draftBook.Publisher = unknownPublisher
+		report.Warnings = append(report.Warnings, "the publisher is unknown")
+	}
+
+	bookID, err = w.bookService.CreateBook(ctx, draftBook)
+	if err != nil {
+		return 0, false, err
+	}
+
+	return bookID, true, nil
+}
+
+// uploadCover - uploads the group cover image, the one named 'cover' is preferred if there are several images.
+// A failed cover upload does not fail the import
+func (w *Worker) uploadCover(ctx context.Context, workDir string, report *Report, covers []int) {
+	index := covers[0]
+	for _, coverIndex := range covers {
+		fileName := report.Files[coverIndex].Name
+		if strings.EqualFold(strings.TrimSuffix(fileName, filepath.Ext(fileName)), "cover") {
+			index = coverIndex
+			break
+		}
+	}
+
+	fileReport := &report.Files[index]
+	file, _, err := openFile(filepath.Join(workDir, fileReport.Name))
+	if err == nil {
+		_, err = w.coverService.UploadBookCover(ctx, report.BookID, file)
+		_ = file.Close()
+	}
+	if err != nil {
+		fileReport.Error = err.Error()
+		report.Warnings = append(report.Warnings, fileReport.Name+": the cover can not be uploaded")
+	}
+}
+
+// discardCovers - discards the covers extracted from the drafts, except the assigned one.
+// A failed discard does not fail the import
+func (w *Worker) discardCovers(ctx context.Context, drafts []ingest.Draft, assignedHash string, report *Report) {
+	handled := map[string]bool{assignedHash: true}
+	for _, draft := range drafts {
+		if draft.Cover == nil || handled[draft.Cover.Hash] {
+			continue
+		}
+		handled[draft.Cover.Hash] = true
+		if err := w.coverService.DiscardCover(ctx, draft.Cover.Hash); err != nil {
+			report.Warnings = append(report.Warnings, "the extracted cover can not be discarded: "+err.Error())
+		}
+	}
+}
+
+func (w *Worker) uploadFile(ctx context.Context, bookID int64, path string) (bookfile.BookFile, error) {
+	file, size, err := openFile(path)
+	if err != nil {
+		return bookfile.BookFile{}, err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	return w.bookFileService.UploadBookFile(ctx, bookfile.UploadRequest{
+		BookID:   bookID,
+		FileType: fileType(path),
+		Content:  file,
+		Size:     size,
+	})
+}
+
+// finish - stores the report along with the group files, and moves them to the target directory
+func (w *Worker) finish(workDir string, targetDir string, report Report) {
+	content, err := json.MarshalIndent(report, "", "  ")
+	if err == nil {
+		err = os.WriteFile(filepath.Join(workDir, reportFileName), content, 0o644)
+	}
+	if err != nil {
+		w.logger.Error("inbox report writing failed: "+err.Error(), "group", report.Group)
+	}
+
+	if err := os.Rename(workDir, targetDir); err != nil {
+		w.logger.Error("inbox group moving failed: "+err.Error(), "group", report.Group, "dir", workDir)
+	}
+}
+
+// mergeDrafts - fills in the details, missing in the preferred draft, from the other ones
+func mergeDrafts(drafts []ingest.Draft) ingest.Draft {
+	merged := drafts[0]
+	for _, draft := range drafts[1:] {
+		target, source := &merged.Book, draft.Book
+		target.Title = cmp.Or(target.Title, source.Title)
+		target.Subtitle = cmp.Or(target.Subtitle, source.Subtitle)
+		target.Description = cmp.Or(target.Description, source.Description)
+		target.ISBN10 = cmp.Or(target.ISBN10, source.ISBN10)
+		target.ISBN13 = cmp.Or(target.ISBN13, source.ISBN13)
+		target.Pages = cmp.Or(target.Pages, source.Pages)
+		target.Language = cmp.Or(target.Language, source.Language)
+		target.Publisher = cmp.Or(target.Publisher, source.Publisher)
+		if target.PubDate.IsZero() {
+			target.PubDate = source.PubDate
+		}
+		if len(target.Authors) == 0 {
+			target.Authors = source.Authors
+		}
+		if len(target.Categories) == 0 {
+			target.Categories = source.Categories
+		}
+		if len(target.Tags) == 0 {
+			target.Tags = source.Tags
+		}
+		for _, sourceType := range source.FileTypes {
+			if !slices.Contains(target.FileTypes, sourceType) {
+				target.FileTypes = append(target.FileTypes, sourceType)
+			}
+		}
+		if merged.Cover == nil && draft.Cover != nil {
+			merged.Cover = draft.Cover
+			target.CoverHash, target.CoverWidth, target.CoverHeight = source.CoverHash, source.CoverWidth, source.CoverHeight
+		}
+	}
+
+	return merged
+}
+
+func openFile(path string) (*os.File, int64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, 0, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return nil, 0, err
+	}
+
+	return file, info.Size(), nil
+}
@@ -0,0 +1,262 @@
+package inbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/sdreger/lib-manager-go/internal/config"
+	"github.com/sdreger/lib-manager-go/internal/domain/book"
+	"github.com/sdreger/lib-manager-go/internal/domain/bookfile"
+	"github.com/sdreger/lib-manager-go/internal/domain/cover"
+	"github.com/sdreger/lib-manager-go/internal/ingest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const (
+	testBookID    = int64(10)
+	testISBN13    = int64(9781617291784)
+	testCoverHash = "abc"
+)
+
+var testTime = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+type testMocks struct {
+	ingester        *MockIngester
+	bookService     *MockBookService
+	bookFileService *MockBookFileService
+	coverService    *MockCoverService
+}
+
+func TestWorker_Poll_CreateBook(t *testing.T) {
+	ctx := context.Background()
+	worker, mocks := getWorker(t)
+	writeTestFile(t, filepath.Join(worker.config.Dir, "go.epub"), testTime)
+	writeTestFile(t, filepath.Join(worker.config.Dir, "go.pdf"), testTime)
+
+	epubDraft := getTestDraft(ingest.FormatEPUB)
+	epubDraft.Book.Pages = 0
+	epubDraft.Cover = &cover.Cover{Hash: testCoverHash}
+	pdfDraft := getTestDraft(ingest.FormatPDF)
+	pdfDraft.Book.Title = "Other title"
+	pdfDraft.Warnings = []string{"the document is encrypted"}
+	mocks.ingester.EXPECT().Ingest(mock.Anything, "go.epub", mock.Anything, mock.Anything).Return(epubDraft, nil).Once()
+	mocks.ingester.EXPECT().Ingest(mock.Anything, "go.pdf", mock.Anything, mock.Anything).Return(pdfDraft, nil).Once()
+
+	mocks.bookService.EXPECT().FindBookID(mock.Anything, book.Identifiers{ISBN13: testISBN13}).
+		Return(0, book.ErrNotFound).Once()
+	mocks.bookService.EXPECT().CreateBook(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, newBook book.Book) (int64, error) {
+			assert.Equal(t, "Go in Action", newBook.Title)
+			assert.Equal(t, uint16(250), newBook.Pages, "should fill pages from the PDF draft")
+			assert.Equal(t, []string{"epub", "pdf"}, newBook.FileTypes)
+			assert.Equal(t, "English", newBook.Language, "should use the default language")
+			assert.Equal(t, unknownPublisher, newBook.Publisher)
+			return testBookID, nil
+		}).Once()
+	mocks.coverService.EXPECT().AssignBookCover(mock.Anything, testBookID, testCoverHash).Return(nil).Once()
+	mocks.bookFileService.EXPECT().UploadBookFile(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, request bookfile.UploadRequest) (bookfile.BookFile, error) {
+			assert.Equal(t, testBookID, request.BookID)
+			return bookfile.BookFile{SHA256: request.FileType + "-hash"}, nil
+		}).Twice()
+
+	reports := worker.Poll(ctx)
+	require.Len(t, reports, 1)
+	report := reports[0]
+	assert.Equal(t, StatusDone, report.Status)
+	assert.Equal(t, testBookID, report.BookID)
+	assert.True(t, report.Created)
+	assert.Empty(t, report.Error)
+	assert.Equal(t, []FileReport{
+		{Name: "go.epub", Role: RoleBook, Size: 7, SHA256: "epub-hash"},
+		{Name: "go.pdf", Role: RoleBook, Size: 6, SHA256: "pdf-hash"},
+	}, report.Files)
+	assert.Equal(t, []string{
+		"go.pdf: the document is encrypted",
+		"the language is unknown, the default one is used",
+		"the publisher is unknown",
+	}, report.Warnings)
+
+	storedReport := readTestReport(t, filepath.Join(worker.config.Dir, dirDone, "go-20250101T120100.000"))
+	assert.Equal(t, report.Files, storedReport.Files)
+	assert.FileExists(t, filepath.Join(worker.config.Dir, dirDone, "go-20250101T120100.000", "go.epub"))
+	assert.NoFileExists(t, filepath.Join(worker.config.Dir, "go.epub"))
+}
+
+func TestWorker_Poll_ExistingBook(t *testing.T) {
+	ctx := context.Background()
+	worker, mocks := getWorker(t)
+	writeTestFile(t, filepath.Join(worker.config.Dir, "java", "book.pdf"), testTime)
+	writeTestFile(t, filepath.Join(worker.config.Dir, "java", "book.mobi"), testTime)
+	writeTestFile(t, filepath.Join(worker.config.Dir, "java", "notes.txt"), testTime)
+	writeTestFile(t, filepath.Join(worker.config.Dir, "java", "back.png"), testTime)
+	writeTestFile(t, filepath.Join(worker.config.Dir, "java", "cover.jpg"), testTime)
+	require.NoError(t, os.Chtimes(filepath.Join(worker.config.Dir, "java"), testTime, testTime))
+
+	pdfDraft := getTestDraft(ingest.FormatPDF)
+	pdfDraft.Cover = &cover.Cover{Hash: testCoverHash}
+	mocks.ingester.EXPECT().Ingest(mock.Anything, "book.pdf", mock.Anything, mock.Anything).Return(pdfDraft, nil).Once()
+	mocks.bookService.EXPECT().FindBookID(mock.Anything, book.Identifiers{ISBN13: testISBN13}).
+		Return(testBookID, nil).Once()
+	// the extracted cover is not used by the matched book
+	mocks.coverService.EXPECT().DiscardCover(mock.Anything, testCoverHash).Return(nil).Once()
+	mocks.coverService.EXPECT().UploadBookCover(mock.Anything, testBookID, mock.Anything).
+		Return(cover.Cover{}, errors.New("cover error")).Once()
+	mocks.bookFileService.EXPECT().UploadBookFile(mock.Anything, mock.MatchedBy(func(request bookfile.UploadRequest) bool {
+		return request.FileType == "txt"
+	})).Return(bookfile.BookFile{}, bookfile.ErrUnknownFileType).Once()
+	mocks.bookFileService.EXPECT().UploadBookFile(mock.Anything, mock.MatchedBy(func(request bookfile.UploadRequest) bool {
+		return request.FileType == "mobi"
+	})).Return(bookfile.BookFile{}, errors.New("upload error")).Once()
+	mocks.bookFileService.EXPECT().UploadBookFile(mock.Anything, mock.MatchedBy(func(request bookfile.UploadRequest) bool {
+		return request.FileType == "pdf"
+	})).Return(bookfile.BookFile{SHA256: "pdf-hash"}, nil).Once()
+
+	reports := worker.Poll(ctx)
+	require.Len(t, reports, 1)
+	report := reports[0]
+	assert.Equal(t, StatusFailed, report.Status)
+	assert.Equal(t, ErrUploadFailed.Error(), report.Error)
+	assert.Equal(t, testBookID, report.BookID)
+	assert.False(t, report.Created)
+	assert.Equal(t, []FileReport{
+		{Name: "back.png", Role: RoleCover, Size: 8},
+		{Name: "book.mobi", Role: RoleBook, Size: 9, Error: "upload error"},
+		{Name: "book.pdf", Role: RoleBook, Size: 8, SHA256: "pdf-hash"},
+		{Name: "cover.jpg", Role: RoleCover, Size: 9, Error: "cover error"},
+		{Name: "notes.txt", Role: RoleIgnored, Size: 9},
+	}, report.Files)
+	assert.Equal(t, []string{
+		"cover.jpg: the cover can not be uploaded",
+		"notes.txt: unknown book file type, the file is ignored",
+	}, report.Warnings)
+	assert.DirExists(t, filepath.Join(worker.config.Dir, dirFailed, "java-20250101T120100.000"))
+	assert.NoDirExists(t, filepath.Join(worker.config.Dir, "java"))
+}
+
+func TestWorker_Poll_Failures(t *testing.T) {
+	tests := []struct {
+		name      string
+		fileName  string
+		draft     func() ingest.Draft
+		ingestErr error
+		wantErr   error
+	}{
+		{name: "no metadata source", fileName: "book.mobi", wantErr: ErrNoMetadataSource},
+		{name: "ingest error", fileName: "book.epub", ingestErr: ingest.ErrInvalidFile, wantErr: ErrNoMetadata},
+		{name: "missing title", fileName: "book.epub", wantErr: ErrMissingTitle, draft: func() ingest.Draft {
+			draft := getTestDraft(ingest.FormatEPUB)
+			draft.Book.Title = ""
+			return draft
+		}},
+		{name: "missing authors", fileName: "book.epub", wantErr: ErrMissingAuthors, draft: func() ingest.Draft {
+			draft := getTestDraft(ingest.FormatEPUB)
+			draft.Book.Authors = nil
+			return draft
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			worker, mocks := getWorker(t)
+			writeTestFile(t, filepath.Join(worker.config.Dir, tt.fileName), testTime)
+			if tt.draft != nil || tt.ingestErr != nil {
+				draft := ingest.Draft{}
+				if tt.draft != nil {
+					draft = tt.draft()
+				}
+				mocks.ingester.EXPECT().Ingest(mock.Anything, tt.fileName, mock.Anything, mock.Anything).
+					Return(draft, tt.ingestErr).Once()
+			}
+			if tt.draft != nil {
+				mocks.bookService.EXPECT().FindBookID(mock.Anything, mock.Anything).Return(0, book.ErrNotFound).Once()
+			}
+
+			reports := worker.Poll(context.Background())
+			require.Len(t, reports, 1)
+			assert.Equal(t, StatusFailed, reports[0].Status)
+			assert.Equal(t, tt.wantErr.Error(), reports[0].Error)
+			assert.FileExists(t, filepath.Join(worker.config.Dir, dirFailed, "book-20250101T120100.000", tt.fileName))
+		})
+	}
+}
+
+func TestWorker_Poll_Canceled(t *testing.T) {
+	worker, _ := getWorker(t)
+	writeTestFile(t, filepath.Join(worker.config.Dir, "go.epub"), testTime)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	assert.Empty(t, worker.Poll(ctx), "should not start new groups")
+	assert.FileExists(t, filepath.Join(worker.config.Dir, "go.epub"))
+}
+
+func TestWorker_Run(t *testing.T) {
+	worker, _ := getWorker(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	require.NoError(t, worker.Run(ctx), "should stop on context cancellation")
+	assert.DirExists(t, filepath.Join(worker.config.Dir, dirProcessing))
+	assert.DirExists(t, filepath.Join(worker.config.Dir, dirDone))
+	assert.DirExists(t, filepath.Join(worker.config.Dir, dirFailed))
+}
+
+func getWorker(t *testing.T) (*Worker, testMocks) {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	inboxConfig := config.InboxConfig{
+		Dir:             t.TempDir(),
+		PollInterval:    time.Minute,
+		SettleTime:      10 * time.Second,
+		DefaultLanguage: "English",
+	}
+	for _, dir := range []string{dirProcessing, dirDone, dirFailed} {
+		require.NoError(t, os.MkdirAll(filepath.Join(inboxConfig.Dir, dir), 0o755))
+	}
+
+	mocks := testMocks{
+		ingester:        NewMockIngester(t),
+		bookService:     NewMockBookService(t),
+		bookFileService: NewMockBookFileService(t),
+		coverService:    NewMockCoverService(t),
+	}
+	worker := NewWorker(logger, inboxConfig, config.IngestConfig{}, nil, nil)
+	worker.ingester = mocks.ingester
+	worker.bookService = mocks.bookService
+	worker.bookFileService = mocks.bookFileService
+	worker.coverService = mocks.coverService
+	worker.now = func() time.Time {
+		return testTime.Add(time.Minute)
+	}
+
+	return worker, mocks
+}
+
+func getTestDraft(format string) ingest.Draft {
+	return ingest.Draft{
+		Format: format,
+		Book: book.Book{
+			Title:     "Go in Action",
+			Authors:   []string{"William Kennedy"},
+			ISBN13:    testISBN13,
+			Pages:     250,
+			FileTypes: []string{format},
+		},
+	}
+}
+
+func readTestReport(t *testing.T, dir string) Report {
+	content, err := os.ReadFile(filepath.Join(dir, reportFileName))
+	require.NoError(t, err, "should read report")
+	var report Report
+	require.NoError(t, json.Unmarshal(content, &report), "should parse report")
+
+	return report
+}
@@ -0,0 +1,274 @@
+package epub
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"github.com/sdreger/lib-manager-go/internal/isbn"
+	"html"
+	"io"
+	"net/url"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	containerPath = "META-INF/container.xml"
+	opfMediaType  = "application/oebps-package+xml"
+
+	// the OPF document is small, the limit protects from the malicious archives only
+	maxOPFSize   = 4 << 20  // 4 MiB
+	maxCoverSize = 10 << 20 // 10 MiB
+
+	roleAuthor       = "aut"
+	titleTypeMain    = "main"
+	titleTypeSub     = "subtitle"
+	eventPublication = "publication"
+)
+
+var (
+	ErrInvalidEPUB = errors.New("invalid EPUB file")
+
+	tagRegexp   = regexp.MustCompile(`<[^>]*>`)
+	spaceRegexp = regexp.MustCompile(`\s+`)
+
+	// the date formats, allowed by the EPUB specification, from the most precise one
+	dateLayouts = []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02", "2006-01", "2006"}
+)
+
+// Parse - reads the EPUB container, and returns the OPF metadata along with the embedded cover image.
+// The cover is omitted, if it is missing in the container, or is too large
+func Parse(reader io.ReaderAt, size int64) (Document, error) {
+	archive, err := zip.NewReader(reader, size)
+	if err != nil {
+		return Document{}, fmt.Errorf("%w: %w", ErrInvalidEPUB, err)
+	}
+
+	opfPath, err := findPackagePath(archive)
+	if err != nil {
+		return Document{}, err
+	}
+
+	var pkg opfPackage
+	if err := decodeXML(archive, opfPath, &pkg); err != nil {
+		return Document{}, err
+	}
+
+	document := Document{Metadata: pkg.metadata()}
+	if coverItem, ok := pkg.coverItem(); ok {
+		// a broken cover reference should not prevent the metadata extraction, the cover is just skipped
+		coverPath := resolveHref(opfPath, coverItem.Href)
+		if content, err := readFile(archive, coverPath, maxCoverSize); err == nil {
+			document.Cover = &Cover{Path: coverPath, MediaType: coverItem.MediaType, Content: content}
+		}
+	}
+
+	return document, nil
+}
+
+// findPackagePath - returns the OPF package document path, declared by the container file
+func findPackagePath(archive *zip.Reader) (string, error) {
+	var epubContainer container
+	if err := decodeXML(archive, containerPath, &epubContainer); err != nil {
+		return "", err
+	}
+
+	for _, rootFile := range epubContainer.RootFiles {
+		if rootFile.FullPath != "" && (rootFile.MediaType == "" || rootFile.MediaType == opfMediaType) {
+			return rootFile.FullPath, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: no package document declared", ErrInvalidEPUB)
+}
+
+func decodeXML(archive *zip.Reader, filePath string, v any) error {
+	content, err := readFile(archive, filePath, maxOPFSize)
+	if err != nil {
+		return err
+	}
+	if err := xml.Unmarshal(content, v); err != nil {
+		return fmt.Errorf("%w: %s: %w", ErrInvalidEPUB, filePath, err)
+	}
+
+	return nil
+}
+
+func readFile(archive *zip.Reader, filePath string, limit int64) ([]byte, error) {
+	file, err := archive.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidEPUB, err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	content, err := io.ReadAll(io.LimitReader(file, limit+1))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrInvalidEPUB, filePath, err)
+	}
+	if int64(len(content)) > limit {
+		return nil, fmt.Errorf("%w: %s is too large", ErrInvalidEPUB, filePath)
+	}
+
+	return content, nil
+}
+
+// resolveHref - returns the archive path of a manifest item, the hrefs are relative to the OPF document
+func resolveHref(opfPath string, href string) string {
+	if unescaped, err := url.PathUnescape(href); err == nil {
+		href = unescaped
+	}
+
+	return path.Join(path.Dir(opfPath), href)
+}
+
+func (p opfPackage) metadata() Metadata {
+	refinements := p.refinements()
+	metadata := Metadata{
+		Publisher:   firstValue(p.Metadata.Publishers),
+		Language:    firstValue(p.Metadata.Languages),
+		PubDate:     p.pubDate(),
+		Description: cleanDescription(firstValue(p.Metadata.Descriptions)),
+	}
+
+	for _, title := range p.Metadata.Titles {
+		value := normalizeSpace(title.Value)
+		switch refinements[title.ID]["title-type"] {
+		case titleTypeSub:
+			if metadata.Subtitle == "" {
+				metadata.Subtitle = value
+			}
+		case titleTypeMain:
+			metadata.Title = value
+		default:
+			if metadata.Title == "" {
+				metadata.Title = value
+			}
+		}
+	}
+
+	for _, creator := range p.Metadata.Creators {
+		role := creator.Role
+		if refinedRole, ok := refinements[creator.ID]["role"]; ok {
+			role = refinedRole
+		}
+		name := normalizeSpace(creator.Value)
+		if name != "" && (role == "" || role == roleAuthor) && !slices.Contains(metadata.Authors, name) {
+			metadata.Authors = append(metadata.Authors, name)
+		}
+	}
+
+	for _, identifier := range p.Metadata.Identifiers {
+		value := isbn.Normalize(identifier.Value)
+		switch {
+		case isbn.IsValid13(value) && metadata.ISBN13 == "":
+			metadata.ISBN13 = value
+		case isbn.IsValid10(value) && metadata.ISBN10 == "":
+			metadata.ISBN10 = value
+		}
+	}
+
+	for _, subject := range p.Metadata.Subjects {
+		if value := normalizeSpace(subject.Value); value != "" && !slices.Contains(metadata.Subjects, value) {
+			metadata.Subjects = append(metadata.Subjects, value)
+		}
+	}
+
+	return metadata
+}
+
+// refinements - returns the EPUB 3 refining properties, grouped by the refined element ID
+func (p opfPackage) refinements() map[string]map[string]string {
+	refinements := make(map[string]map[string]string)
+	for _, meta := range p.Metadata.Metas {
+		if meta.Refines == "" || meta.Property == "" {
+			continue
+		}
+		id := strings.TrimPrefix(meta.Refines, "#")
+		if refinements[id] == nil {
+			refinements[id] = make(map[string]string)
+		}
+		refinements[id][meta.Property] = strings.TrimSpace(meta.Value)
+	}
+
+	return refinements
+}
+
+// pubDate - returns the publication date, the EPUB 2 files may have several dates for different events
+func (p opfPackage) pubDate() time.Time {
+	var pubDate time.Time
+	for _, date := range p.Metadata.Dates {
+		if date.Event != "" && date.Event != eventPublication {
+			continue
+		}
+		if parsed, ok := parseDate(date.Value); ok {
+			if date.Event == eventPublication {
+				return parsed
+			}
+			if pubDate.IsZero() {
+				pubDate = parsed
+			}
+		}
+	}
+
+	return pubDate
+}
+
+// coverItem - returns the cover image manifest item, declared either the EPUB 3 way (the 'cover-image' property),
+// or the EPUB 2 way (the 'cover' meta element)
+func (p opfPackage) coverItem() (opfItem, bool) {
+	for _, item := range p.Manifest {
+		if slices.Contains(strings.Fields(item.Properties), "cover-image") {
+			return item, true
+		}
+	}
+
+	for _, meta := range p.Metadata.Metas {
+		if meta.Name != "cover" {
+			continue
+		}
+		for _, item := range p.Manifest {
+			// some publishers put the file path into the meta content, instead of the item ID
+			if (item.ID == meta.Content || item.Href == meta.Content) && strings.HasPrefix(item.MediaType, "image/") {
+				return item, true
+			}
+		}
+	}
+
+	return opfItem{}, false
+}
+
+func firstValue(elements []opfElement) string {
+	for _, element := range elements {
+		if value := normalizeSpace(element.Value); value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
+
+func parseDate(value string) (time.Time, bool) {
+	value = strings.TrimSpace(value)
+	for _, layout := range dateLayouts {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed.UTC(), true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// cleanDescription - removes the HTML markup, some publishers put into the description
+func cleanDescription(value string) string {
+	return normalizeSpace(html.UnescapeString(tagRegexp.ReplaceAllString(value, " ")))
+}
+
+func normalizeSpace(value string) string {
+	return strings.TrimSpace(spaceRegexp.ReplaceAllString(value, " "))
+}
@@ -0,0 +1,165 @@
+package epub
+
+import (
+	"archive/zip"
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+const (
+	testContainer = `<?xml version="1.0"?>
+<container version="1.0" xmlns="urn:oasis:names:tc:opendocument:xmlns:container">
+  <rootfiles>
+    <rootfile full-path="OEBPS/content.opf" media-type="application/oebps-package+xml"/>
+  </rootfiles>
+</container>`
+
+	testEPUB3Package = `<?xml version="1.0" encoding="UTF-8"?>
+<package xmlns="http://www.idpf.org/2007/opf" version="3.0" unique-identifier="uid">
+  <metadata xmlns:dc="http://purl.org/dc/elements/1.1/">
+    <dc:identifier id="uid">urn:uuid:2b4c1d3e-0000-0000-0000-000000000000</dc:identifier>
+    <dc:identifier>urn:isbn:978-1-61729-178-4</dc:identifier>
+    <dc:title id="t1">Go in Action</dc:title>
+    <meta refines="#t1" property="title-type">main</meta>
+    <dc:title id="t2">Second   Edition</dc:title>
+    <meta refines="#t2" property="title-type">subtitle</meta>
+    <dc:creator id="c1">William Kennedy</dc:creator>
+    <meta refines="#c1" property="role" scheme="marc:relators">aut</meta>
+    <dc:creator id="c2">Brian Ketelsen</dc:creator>
+    <dc:creator id="c3">Jane Editor</dc:creator>
+    <meta refines="#c3" property="role" scheme="marc:relators">edt</meta>
+    <dc:publisher>Manning</dc:publisher>
+    <dc:language>en</dc:language>
+    <dc:date>2015-11-04T00:00:00Z</dc:date>
+    <dc:description>&lt;p&gt;Go in Action &lt;b&gt;introduces&lt;/b&gt; the Go language&lt;/p&gt;</dc:description>
+    <dc:subject>Computers</dc:subject>
+    <dc:subject>Programming Languages</dc:subject>
+  </metadata>
+  <manifest>
+    <item id="cover-img" href="images/cover%20image.jpg" media-type="image/jpeg" properties="cover-image"/>
+    <item id="chapter" href="chapter.xhtml" media-type="application/xhtml+xml"/>
+  </manifest>
+</package>`
+
+	testEPUB2Package = `<?xml version="1.0" encoding="UTF-8"?>
+<package xmlns="http://www.idpf.org/2007/opf" xmlns:opf="http://www.idpf.org/2007/opf" version="2.0">
+  <metadata xmlns:dc="http://purl.org/dc/elements/1.1/">
+    <dc:title>Learning Go</dc:title>
+    <dc:creator opf:role="aut">Jon Bodner</dc:creator>
+    <dc:creator opf:role="ill">Some Illustrator</dc:creator>
+    <dc:identifier opf:scheme="ISBN">1492077216</dc:identifier>
+    <dc:publisher>O'Reilly Media</dc:publisher>
+    <dc:language>en-US</dc:language>
+    <dc:date opf:event="modification">2022-01-01</dc:date>
+    <dc:date opf:event="publication">2021-03</dc:date>
+    <meta name="cover" content="cover"/>
+  </metadata>
+  <manifest>
+    <item id="cover" href="cover.png" media-type="image/png"/>
+  </manifest>
+</package>`
+)
+
+func TestParse_EPUB3(t *testing.T) {
+	content := buildTestEPUB(t, map[string]string{
+		"META-INF/container.xml":       testContainer,
+		"OEBPS/content.opf":            testEPUB3Package,
+		"OEBPS/images/cover image.jpg": "jpeg content",
+	})
+
+	document, err := Parse(bytes.NewReader(content), int64(len(content)))
+	require.NoError(t, err, "should parse EPUB")
+	assert.Equal(t, Metadata{
+		Title:       "Go in Action",
+		Subtitle:    "Second Edition",
+		Authors:     []string{"William Kennedy", "Brian Ketelsen"},
+		ISBN13:      "9781617291784",
+		Publisher:   "Manning",
+		Language:    "en",
+		PubDate:     time.Date(2015, 11, 4, 0, 0, 0, 0, time.UTC),
+		Description: "Go in Action introduces the Go language",
+		Subjects:    []string{"Computers", "Programming Languages"},
+	}, document.Metadata)
+	require.NotNil(t, document.Cover, "should extract cover")
+	assert.Equal(t, Cover{Path: "OEBPS/images/cover image.jpg", MediaType: "image/jpeg", Content: []byte("jpeg content")},
+		*document.Cover)
+}
+
+func TestParse_EPUB2(t *testing.T) {
+	content := buildTestEPUB(t, map[string]string{
+		"META-INF/container.xml": testContainer,
+		"OEBPS/content.opf":      testEPUB2Package,
+		"OEBPS/cover.png":        "png content",
+	})
+
+	document, err := Parse(bytes.NewReader(content), int64(len(content)))
+	require.NoError(t, err, "should parse EPUB")
+	assert.Equal(t, "Learning Go", document.Metadata.Title)
+	assert.Equal(t, []string{"Jon Bodner"}, document.Metadata.Authors)
+	assert.Equal(t, "1492077216", document.Metadata.ISBN10)
+	assert.Empty(t, document.Metadata.ISBN13)
+	assert.Equal(t, "en-US", document.Metadata.Language)
+	assert.Equal(t, time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC), document.Metadata.PubDate)
+	require.NotNil(t, document.Cover, "should extract cover")
+	assert.Equal(t, "OEBPS/cover.png", document.Cover.Path)
+	assert.Equal(t, []byte("png content"), document.Cover.Content)
+}
+
+func TestParse_NoCover(t *testing.T) {
+	content := buildTestEPUB(t, map[string]string{
+		"META-INF/container.xml": testContainer,
+		"OEBPS/content.opf":      `<package version="3.0"><metadata><title>Book</title></metadata></package>`,
+	})
+
+	document, err := Parse(bytes.NewReader(content), int64(len(content)))
+	require.NoError(t, err, "should parse EPUB")
+	assert.Equal(t, "Book", document.Metadata.Title)
+	assert.Nil(t, document.Cover)
+}
+
+func TestParse_MissingCoverFile(t *testing.T) {
+	content := buildTestEPUB(t, map[string]string{
+		"META-INF/container.xml": testContainer,
+		"OEBPS/content.opf":      testEPUB2Package,
+	})
+
+	document, err := Parse(bytes.NewReader(content), int64(len(content)))
+	require.NoError(t, err, "should parse EPUB without the declared cover file")
+	assert.Equal(t, "Learning Go", document.Metadata.Title)
+	assert.Nil(t, document.Cover)
+}
+
+func TestParse_Invalid(t *testing.T) {
+	_, err := Parse(bytes.NewReader([]byte("not a zip")), 9)
+	assert.ErrorIs(t, err, ErrInvalidEPUB, "should reject a non-zip file")
+
+	content := buildTestEPUB(t, map[string]string{"mimetype": "application/epub+zip"})
+	_, err = Parse(bytes.NewReader(content), int64(len(content)))
+	assert.ErrorIs(t, err, ErrInvalidEPUB, "should reject a file without the container")
+
+	content = buildTestEPUB(t, map[string]string{
+		"META-INF/container.xml": testContainer,
+		"OEBPS/content.opf":      `<package version="3.0"><metadata>`,
+	})
+	_, err = Parse(bytes.NewReader(content), int64(len(content)))
+	assert.ErrorIs(t, err, ErrInvalidEPUB, "should reject a malformed package document")
+
+}
+
+func buildTestEPUB(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	buffer := bytes.Buffer{}
+	writer := zip.NewWriter(&buffer)
+	for name, content := range files {
+		fileWriter, err := writer.Create(name)
+		require.NoError(t, err)
+		_, err = fileWriter.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+
+	return buffer.Bytes()
+}
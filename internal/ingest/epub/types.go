@@ -0,0 +1,86 @@
+package epub
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+// Metadata - the book details, declared in the OPF package document with the Dublin Core elements
+type Metadata struct {
+	Title       string
+	Subtitle    string
+	Authors     []string
+	ISBN10      string
+	ISBN13      string
+	Publisher   string
+	Language    string
+	PubDate     time.Time
+	Description string
+	Subjects    []string
+}
+
+// Cover - the cover image, embedded into the EPUB container
+type Cover struct {
+	Path      string
+	MediaType string
+	Content   []byte
+}
+
+// Document - the parsed EPUB file. The Cover is nil, if the file has no cover image declared
+type Document struct {
+	Metadata Metadata
+	Cover    *Cover
+}
+
+// container - the 'META-INF/container.xml' file, pointing to the OPF package document
+type container struct {
+	RootFiles []struct {
+		FullPath  string `xml:"full-path,attr"`
+		MediaType string `xml:"media-type,attr"`
+	} `xml:"rootfiles>rootfile"`
+}
+
+// opfPackage - the OPF package document. The namespaces are ignored, since the prefixes vary between publishers
+type opfPackage struct {
+	XMLName  xml.Name    `xml:"package"`
+	Version  string      `xml:"version,attr"`
+	Metadata opfMetadata `xml:"metadata"`
+	Manifest []opfItem   `xml:"manifest>item"`
+}
+
+type opfMetadata struct {
+	Titles       []opfElement `xml:"title"`
+	Creators     []opfElement `xml:"creator"`
+	Identifiers  []opfElement `xml:"identifier"`
+	Publishers   []opfElement `xml:"publisher"`
+	Languages    []opfElement `xml:"language"`
+	Dates        []opfElement `xml:"date"`
+	Descriptions []opfElement `xml:"description"`
+	Subjects     []opfElement `xml:"subject"`
+	Metas        []opfMeta    `xml:"meta"`
+}
+
+// opfElement - a Dublin Core element. The 'scheme', 'role' and 'event' attributes are only used by EPUB 2,
+// EPUB 3 declares them with the refining 'meta' elements
+type opfElement struct {
+	ID     string `xml:"id,attr"`
+	Scheme string `xml:"scheme,attr"`
+	Role   string `xml:"role,attr"`
+	Event  string `xml:"event,attr"`
+	Value  string `xml:",chardata"`
+}
+
+type opfMeta struct {
+	Name     string `xml:"name,attr"`
+	Content  string `xml:"content,attr"`
+	Refines  string `xml:"refines,attr"`
+	Property string `xml:"property,attr"`
+	Value    string `xml:",chardata"`
+}
+
+type opfItem struct {
+	ID         string `xml:"id,attr"`
+	Href       string `xml:"href,attr"`
+	MediaType  string `xml:"media-type,attr"`
+	Properties string `xml:"properties,attr"`
+}
@@ -0,0 +1,8 @@
+package ingest
+
+import "errors"
+
+var (
+	ErrUnsupportedFormat = errors.New("unsupported book file format")
+	ErrInvalidFile       = errors.New("the book file can not be parsed")
+)
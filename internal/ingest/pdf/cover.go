@@ -0,0 +1,123 @@
+package pdf
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+)
+
+const (
+	mediaTypeJPEG = "image/jpeg"
+	mediaTypePNG  = "image/png"
+
+	// the smaller images are likely logos, rather than the cover artwork
+	minCoverSide = 300
+	// the larger images are rejected before their samples are decoded, the bound also keeps the area from overflowing
+	maxCoverSide = 1 << 14
+	// the limit matches the maximum cover size, the larger images are rejected by the cover storage anyway
+	maxCoverSize = 10 << 20 // 10 MiB
+)
+
+type imageCandidate struct {
+	stream stream
+	width  int64
+	height int64
+}
+
+// firstPageCover - returns the largest portrait image of the page, if it is large enough to be the cover artwork.
+// The JPEG images are returned as is, the 8-bit RGB and Gray ones are converted to PNG
+func (f *file) firstPageCover(p page) *Cover {
+	var best *imageCandidate
+	for _, obj := range f.dict(p.resources["XObject"]) {
+		imageStream, ok := f.resolve(obj).(stream)
+		if !ok || imageStream.dict["Subtype"] != name("Image") {
+			continue
+		}
+		width, _ := f.int(imageStream.dict["Width"])
+		height, _ := f.int(imageStream.dict["Height"])
+		if width < minCoverSide || height < width || height > maxCoverSide {
+			continue
+		}
+		if best == nil || width*height > best.width*best.height {
+			best = &imageCandidate{stream: imageStream, width: width, height: height}
+		}
+	}
+	if best == nil {
+		return nil
+	}
+
+	filters := f.names(best.stream.dict["Filter"])
+	if len(filters) == 1 && (filters[0] == "DCTDecode" || filters[0] == "DCT") {
+		content, err := f.rawStreamData(best.stream)
+		if err != nil || len(content) > maxCoverSize {
+			return nil
+		}
+		return &Cover{MediaType: mediaTypeJPEG, Content: content}
+	}
+
+	return f.rasterCover(*best)
+}
+
+// rasterCover - converts the decoded image samples to PNG
+func (f *file) rasterCover(candidate imageCandidate) *Cover {
+	if bitsPerComponent, _ := f.int(candidate.stream.dict["BitsPerComponent"]); bitsPerComponent != 8 {
+		return nil
+	}
+	components := f.colorComponents(candidate.stream.dict["ColorSpace"])
+	if components != 1 && components != 3 {
+		return nil
+	}
+	if candidate.width > maxCoverSide || candidate.height > maxCoverSide ||
+		candidate.width*candidate.height*components > maxStreamSize {
+		return nil
+	}
+	samples, err := f.streamData(candidate.stream)
+	if err != nil || int64(len(samples)) < candidate.width*candidate.height*components {
+		return nil
+	}
+
+	width, height := int(candidate.width), int(candidate.height)
+	var img image.Image
+	if components == 1 {
+		gray := image.NewGray(image.Rect(0, 0, width, height))
+		copy(gray.Pix, samples)
+		img = gray
+	} else {
+		rgba := image.NewNRGBA(image.Rect(0, 0, width, height))
+		for i := 0; i < width*height; i++ {
+			rgba.SetNRGBA(i%width, i/width, color.NRGBA{R: samples[i*3], G: samples[i*3+1], B: samples[i*3+2], A: 255})
+		}
+		img = rgba
+	}
+
+	var content bytes.Buffer
+	if err := png.Encode(&content, img); err != nil || content.Len() > maxCoverSize {
+		return nil
+	}
+
+	return &Cover{MediaType: mediaTypePNG, Content: content.Bytes()}
+}
+
+// colorComponents - returns the number of the color space components, or zero for the unsupported color spaces
+func (f *file) colorComponents(obj object) int64 {
+	switch value := f.resolve(obj).(type) {
+	case name:
+		switch value {
+		case "DeviceGray", "G", "CalGray":
+			return 1
+		case "DeviceRGB", "RGB", "CalRGB":
+			return 3
+		}
+	case array:
+		if len(value) == 2 && f.resolve(value[0]) == name("ICCBased") {
+			components, _ := f.int(f.dict(value[1])["N"])
+			return components
+		}
+		if len(value) == 2 && (f.resolve(value[0]) == name("CalRGB") || f.resolve(value[0]) == name("CalGray")) {
+			return f.colorComponents(value[0])
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,712 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/zlib"
+	"encoding/ascii85"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strconv"
+)
+
+const (
+	headerSearchSize  = 1024
+	trailerSearchSize = 2048
+	// the endstream keyword is searched for, when the stream length is missing or wrong
+	endStreamSearchSize = 64
+	reconstructChunk    = 1 << 20 // 1 MiB
+	reconstructOverlap  = 64
+
+	maxResolveDepth = 32
+	maxStreamSize   = 64 << 20 // 64 MiB, both raw and decoded
+)
+
+var (
+	ErrInvalidPDF = errors.New("invalid PDF file")
+
+	errUnsupportedFilter = errors.New("unsupported stream filter")
+
+	objectRegexp  = regexp.MustCompile(`(?:^|[\x00\t\n\f\r ])(\d+)[\x00\t\n\f\r ]+(\d+)[\x00\t\n\f\r ]+obj\b`)
+	trailerRegexp = regexp.MustCompile(`trailer[\x00\t\n\f\r ]*<<`)
+)
+
+type xrefEntry struct {
+	offset     int64
+	compressed bool
+	stream     int
+}
+
+// objectStream - the decoded object stream, holding the compressed objects
+type objectStream struct {
+	data    []byte
+	offsets map[int]int
+}
+
+// file - a random access PDF file reader. The objects are loaded lazily, through the cross-reference table
+type file struct {
+	reader        io.ReaderAt
+	size          int64
+	xref          map[int]xrefEntry
+	trailer       dict
+	cache         map[int]object
+	objectStreams map[int]*objectStream
+}
+
+func open(reader io.ReaderAt, size int64) (*file, error) {
+	header := make([]byte, min(size, headerSearchSize))
+	if _, err := reader.ReadAt(header, 0); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPDF, err)
+	}
+	if !bytes.Contains(header, []byte("%PDF-")) {
+		return nil, fmt.Errorf("%w: no PDF header", ErrInvalidPDF)
+	}
+
+	f := &file{reader: reader, size: size}
+	f.reset()
+	if err := f.readXRef(); err != nil || f.catalog() == nil {
+		// the cross-reference table is often broken by the editing tools, so the objects are located by scanning
+		if err := f.reconstruct(); err != nil {
+			return nil, err
+		}
+	}
+
+	return f, nil
+}
+
+func (f *file) reset() {
+	f.xref = make(map[int]xrefEntry)
+	f.trailer = dict{}
+	f.cache = make(map[int]object)
+	f.objectStreams = make(map[int]*objectStream)
+}
+
+func (f *file) catalog() dict {
+	catalog := f.dict(f.trailer["Root"])
+	if catalog == nil || catalog["Pages"] == nil {
+		return nil
+	}
+
+	return catalog
+}
+
+func (f *file) lexerAt(offset int64) *lexer {
+	return newLexer(io.NewSectionReader(f.reader, offset, f.size-offset), offset)
+}
+
+// readXRef - reads the cross-reference sections, starting from the last one, and following the previous ones.
+// The newer entries take precedence over the older ones
+func (f *file) readXRef() error {
+	offset, err := f.findStartXRef()
+	if err != nil {
+		return err
+	}
+
+	visited := make(map[int64]bool)
+	for !visited[offset] {
+		visited[offset] = true
+		trailer, err := f.readXRefSection(offset)
+		if err != nil {
+			return err
+		}
+		f.mergeTrailer(trailer)
+
+		// the hybrid files have the compressed objects listed in the additional cross-reference stream
+		if streamOffset, ok := trailer["XRefStm"].(int64); ok && !visited[streamOffset] {
+			visited[streamOffset] = true
+			if _, err := f.readXRefSection(streamOffset); err != nil {
+				return err
+			}
+		}
+
+		previous, ok := trailer["Prev"].(int64)
+		if !ok {
+			break
+		}
+		offset = previous
+	}
+
+	return nil
+}
+
+func (f *file) findStartXRef() (int64, error) {
+	tailSize := min(f.size, trailerSearchSize)
+	tail := make([]byte, tailSize)
+	if _, err := f.reader.ReadAt(tail, f.size-tailSize); err != nil && !errors.Is(err, io.EOF) {
+		return 0, err
+	}
+
+	index := bytes.LastIndex(tail, []byte("startxref"))
+	if index < 0 {
+		return 0, fmt.Errorf("%w: no startxref", ErrInvalidPDF)
+	}
+	t := newLexer(bytes.NewReader(tail[index+len("startxref"):]), 0).next()
+	if t.kind != tokenInt || t.int <= 0 || t.int >= f.size {
+		return 0, fmt.Errorf("%w: invalid startxref", ErrInvalidPDF)
+	}
+
+	return t.int, nil
+}
+
+func (f *file) readXRefSection(offset int64) (dict, error) {
+	l := f.lexerAt(offset)
+	t := l.next()
+	if t.kind == tokenKeyword && t.text == "xref" {
+		return f.readXRefTable(l)
+	}
+	l.unread(t)
+
+	_, obj, err := f.readIndirect(l)
+	if err != nil {
+		return nil, err
+	}
+	xrefStream, ok := obj.(stream)
+	if !ok || xrefStream.dict["Type"] != name("XRef") {
+		return nil, fmt.Errorf("%w: no cross-reference section at %d", ErrInvalidPDF, offset)
+	}
+
+	return xrefStream.dict, f.readXRefStream(xrefStream)
+}
+
+func (f *file) readXRefTable(l *lexer) (dict, error) {
+	for {
+		t := l.next()
+		if t.kind == tokenKeyword && t.text == "trailer" {
+			obj, err := l.readObject()
+			if err != nil {
+				return nil, err
+			}
+			trailer, ok := obj.(dict)
+			if !ok {
+				return nil, fmt.Errorf("%w: invalid trailer", ErrInvalidPDF)
+			}
+			return trailer, nil
+		}
+
+		count := l.next()
+		if t.kind != tokenInt || count.kind != tokenInt {
+			return nil, fmt.Errorf("%w: invalid cross-reference table", ErrInvalidPDF)
+		}
+		for i := range int(count.int) {
+			offset, generation, kind := l.next(), l.next(), l.next()
+			if offset.kind != tokenInt || generation.kind != tokenInt || kind.kind != tokenKeyword {
+				return nil, fmt.Errorf("%w: invalid cross-reference entry", ErrInvalidPDF)
+			}
+			number := int(t.int) + i
+			if _, ok := f.xref[number]; !ok && kind.text == "n" {
+				f.xref[number] = xrefEntry{offset: offset.int}
+			}
+		}
+	}
+}
+
+func (f *file) readXRefStream(xrefStream stream) error {
+	data, err := f.streamData(xrefStream)
+	if err != nil {
+		return err
+	}
+
+	widths := f.ints(xrefStream.dict["W"])
+	if len(widths) != 3 || slices.ContainsFunc(widths, func(width int) bool { return width < 0 || width > 8 }) {
+		return fmt.Errorf("%w: invalid cross-reference stream widths", ErrInvalidPDF)
+	}
+	index := f.ints(xrefStream.dict["Index"])
+	if len(index) == 0 {
+		size, _ := f.int(xrefStream.dict["Size"])
+		index = []int{0, int(size)}
+	}
+
+	entrySize := widths[0] + widths[1] + widths[2]
+	if entrySize == 0 {
+		return fmt.Errorf("%w: invalid cross-reference stream widths", ErrInvalidPDF)
+	}
+	position := 0
+	for i := 0; i+1 < len(index); i += 2 {
+		// the subsection can not have more entries than the rest of the stream data holds
+		count := min(index[i+1], (len(data)-position)/entrySize)
+		for number := index[i]; number < index[i]+count; number++ {
+			fields := make([]int64, 3)
+			for field, width := range widths {
+				for _, b := range data[position : position+width] {
+					fields[field] = fields[field]<<8 | int64(b)
+				}
+				position += width
+			}
+			if widths[0] == 0 {
+				fields[0] = 1
+			}
+			if _, ok := f.xref[number]; ok {
+				continue
+			}
+			switch fields[0] {
+			case 1:
+				f.xref[number] = xrefEntry{offset: fields[1]}
+			case 2:
+				f.xref[number] = xrefEntry{compressed: true, stream: int(fields[1])}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (f *file) mergeTrailer(trailer dict) {
+	for key, value := range trailer {
+		if _, ok := f.trailer[key]; !ok {
+			f.trailer[key] = value
+		}
+	}
+}
+
+// reconstruct - rebuilds the cross-reference table by scanning the whole file for the object definitions
+func (f *file) reconstruct() error {
+	f.reset()
+
+	var trailerOffsets []int64
+	for start := int64(0); start < f.size; start += reconstructChunk {
+		bufferStart := max(0, start-reconstructOverlap)
+		buffer := make([]byte, min(start+reconstructChunk+reconstructOverlap, f.size)-bufferStart)
+		if _, err := f.reader.ReadAt(buffer, bufferStart); err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("%w: %w", ErrInvalidPDF, err)
+		}
+		inChunk := func(offset int64) bool {
+			return offset >= start && offset < start+reconstructChunk
+		}
+
+		for _, match := range objectRegexp.FindAllSubmatchIndex(buffer, -1) {
+			offset := bufferStart + int64(match[2])
+			number, err := strconv.Atoi(string(buffer[match[2]:match[3]]))
+			if err == nil && inChunk(offset) {
+				// the later definitions replace the earlier ones, the same way the incremental updates do
+				f.xref[number] = xrefEntry{offset: offset}
+			}
+		}
+		for _, match := range trailerRegexp.FindAllIndex(buffer, -1) {
+			if offset := bufferStart + int64(match[0]); inChunk(offset) {
+				trailerOffsets = append(trailerOffsets, offset)
+			}
+		}
+	}
+
+	for i := len(trailerOffsets) - 1; i >= 0; i-- {
+		l := f.lexerAt(trailerOffsets[i] + int64(len("trailer")))
+		if trailer, err := l.readObject(); err == nil {
+			if trailerDict, ok := trailer.(dict); ok {
+				f.mergeTrailer(trailerDict)
+			}
+		}
+	}
+
+	// the cross-reference streams hold the trailer entries, and the object streams hold the compressed objects
+	numbers := make([]int, 0, len(f.xref))
+	for number := range f.xref {
+		numbers = append(numbers, number)
+	}
+	slices.Sort(numbers)
+	for _, number := range numbers {
+		obj, err := f.loadObject(number)
+		if err != nil {
+			continue
+		}
+		objStream, ok := obj.(stream)
+		if !ok {
+			continue
+		}
+		switch objStream.dict["Type"] {
+		case name("XRef"):
+			f.mergeTrailer(objStream.dict)
+		case name("ObjStm"):
+			if compressed, err := f.objectStream(number); err == nil {
+				for compressedNumber := range compressed.offsets {
+					if _, ok := f.xref[compressedNumber]; !ok {
+						f.xref[compressedNumber] = xrefEntry{compressed: true, stream: number}
+					}
+				}
+			}
+		}
+	}
+
+	if f.catalog() == nil {
+		delete(f.trailer, "Root")
+		for _, number := range numbers {
+			if catalog, ok := f.resolve(ref{number: number}).(dict); ok && catalog["Type"] == name("Catalog") {
+				f.trailer["Root"] = ref{number: number}
+				break
+			}
+		}
+	}
+	if f.catalog() == nil {
+		return fmt.Errorf("%w: no document catalog", ErrInvalidPDF)
+	}
+
+	return nil
+}
+
+// readIndirect - reads an indirect object definition: '12 0 obj ... endobj'
+func (f *file) readIndirect(l *lexer) (int, object, error) {
+	number, generation, operator := l.next(), l.next(), l.next()
+	if number.kind != tokenInt || generation.kind != tokenInt || operator.kind != tokenKeyword || operator.text != "obj" {
+		return 0, nil, fmt.Errorf("%w: invalid object definition", ErrInvalidPDF)
+	}
+
+	obj, err := l.readObject()
+	if err != nil {
+		return 0, nil, fmt.Errorf("%w: object %d: %w", ErrInvalidPDF, number.int, err)
+	}
+	streamDict, ok := obj.(dict)
+	if !ok {
+		return int(number.int), obj, nil
+	}
+
+	t := l.next()
+	if t.kind != tokenKeyword || t.text != "stream" {
+		return int(number.int), obj, nil
+	}
+	// the stream data starts after the end-of-line marker, following the 'stream' keyword
+	if b, ok := l.readByte(); ok {
+		if b == '\r' {
+			if next, ok := l.readByte(); ok && next != '\n' {
+				l.unreadByte()
+			}
+		} else if b != '\n' {
+			l.unreadByte()
+		}
+	}
+
+	return int(number.int), stream{dict: streamDict, offset: l.pos}, nil
+}
+
+func (f *file) loadObject(number int) (object, error) {
+	if obj, ok := f.cache[number]; ok {
+		return obj, nil
+	}
+	entry, ok := f.xref[number]
+	if !ok {
+		return nil, nil // the missing objects are treated as null ones
+	}
+	// a reference cycle resolves to null
+	f.cache[number] = nil
+
+	var obj object
+	if entry.compressed {
+		compressed, err := f.objectStream(entry.stream)
+		if err != nil {
+			return nil, err
+		}
+		offset, ok := compressed.offsets[number]
+		if !ok || offset >= len(compressed.data) {
+			return nil, nil
+		}
+		obj, err = newLexer(bytes.NewReader(compressed.data[offset:]), 0).readObject()
+		if err != nil {
+			return nil, fmt.Errorf("%w: object %d: %w", ErrInvalidPDF, number, err)
+		}
+	} else {
+		definedNumber, definedObj, err := f.readIndirect(f.lexerAt(entry.offset))
+		if err != nil {
+			return nil, err
+		}
+		if definedNumber != number {
+			return nil, fmt.Errorf("%w: object %d is not found at %d", ErrInvalidPDF, number, entry.offset)
+		}
+		obj = definedObj
+	}
+	f.cache[number] = obj
+
+	return obj, nil
+}
+
+func (f *file) objectStream(number int) (*objectStream, error) {
+	if compressed, ok := f.objectStreams[number]; ok {
+		return compressed, nil
+	}
+
+	obj, err := f.loadObject(number)
+	if err != nil {
+		return nil, err
+	}
+	objStream, ok := obj.(stream)
+	if !ok {
+		return nil, fmt.Errorf("%w: object %d is not a stream", ErrInvalidPDF, number)
+	}
+	data, err := f.streamData(objStream)
+	if err != nil {
+		return nil, err
+	}
+
+	count, _ := f.int(objStream.dict["N"])
+	first, _ := f.int(objStream.dict["First"])
+	compressed := &objectStream{data: data, offsets: make(map[int]int)}
+	l := newLexer(bytes.NewReader(data), 0)
+	for range count {
+		objectNumber, offset := l.next(), l.next()
+		if objectNumber.kind != tokenInt || offset.kind != tokenInt {
+			break
+		}
+		compressed.offsets[int(objectNumber.int)] = int(first + offset.int)
+	}
+	f.objectStreams[number] = compressed
+
+	return compressed, nil
+}
+
+// resolve - returns the referenced object, the unresolvable references are treated as null ones
+func (f *file) resolve(obj object) object {
+	for range maxResolveDepth {
+		reference, ok := obj.(ref)
+		if !ok {
+			return obj
+		}
+		loaded, err := f.loadObject(reference.number)
+		if err != nil {
+			return nil
+		}
+		obj = loaded
+	}
+
+	return nil
+}
+
+func (f *file) dict(obj object) dict {
+	switch value := f.resolve(obj).(type) {
+	case dict:
+		return value
+	case stream:
+		return value.dict
+	}
+
+	return nil
+}
+
+func (f *file) array(obj object) array {
+	value, _ := f.resolve(obj).(array)
+	return value
+}
+
+func (f *file) int(obj object) (int64, bool) {
+	switch value := f.resolve(obj).(type) {
+	case int64:
+		return value, true
+	case float64:
+		return int64(value), true
+	}
+
+	return 0, false
+}
+
+func (f *file) ints(obj object) []int {
+	var values []int
+	for _, item := range f.array(obj) {
+		value, ok := f.int(item)
+		if !ok {
+			return nil
+		}
+		values = append(values, int(value))
+	}
+
+	return values
+}
+
+func (f *file) names(obj object) []name {
+	switch value := f.resolve(obj).(type) {
+	case name:
+		return []name{value}
+	case array:
+		names := make([]name, 0, len(value))
+		for _, item := range value {
+			if itemName, ok := f.resolve(item).(name); ok {
+				names = append(names, itemName)
+			}
+		}
+		return names
+	}
+
+	return nil
+}
+
+// rawStreamData - reads the encoded stream data. If the declared length does not point to the 'endstream' keyword,
+// the keyword is searched for
+func (f *file) rawStreamData(s stream) ([]byte, error) {
+	if length, ok := f.int(s.dict["Length"]); ok && length >= 0 && length <= maxStreamSize &&
+		s.offset+length <= f.size {
+
+		data := make([]byte, length+endStreamSearchSize)
+		n, err := f.reader.ReadAt(data, s.offset)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+		if int64(n) >= length && bytes.HasPrefix(bytes.TrimLeft(data[length:n], "\x00\t\n\f\r "), []byte("endstream")) {
+			return data[:length], nil
+		}
+	}
+
+	var data []byte
+	for offset := s.offset; offset < f.size && len(data) <= maxStreamSize; offset += reconstructChunk {
+		chunk := make([]byte, min(reconstructChunk, f.size-offset))
+		if _, err := f.reader.ReadAt(chunk, offset); err != nil && !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+		data = append(data, chunk...)
+		if index := bytes.Index(data, []byte("endstream")); index >= 0 {
+			return bytes.TrimRight(data[:index], "\r\n"), nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: unterminated stream", ErrInvalidPDF)
+}
+
+// streamData - reads and decodes the stream data
+func (f *file) streamData(s stream) ([]byte, error) {
+	data, err := f.rawStreamData(s)
+	if err != nil {
+		return nil, err
+	}
+
+	filters := f.names(s.dict["Filter"])
+	var params []dict
+	if paramsDict := f.dict(s.dict["DecodeParms"]); paramsDict != nil {
+		params = []dict{paramsDict}
+	} else {
+		for _, item := range f.array(s.dict["DecodeParms"]) {
+			params = append(params, f.dict(item))
+		}
+	}
+
+	for i, filter := range filters {
+		var filterParams dict
+		if i < len(params) {
+			filterParams = params[i]
+		}
+		data, err = f.decode(filter, filterParams, data)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return data, nil
+}
+
+func (f *file) decode(filter name, params dict, data []byte) ([]byte, error) {
+	switch filter {
+	case "FlateDecode", "Fl":
+		decoded, err := inflate(data)
+		if err != nil {
+			return nil, err
+		}
+		return f.unpredict(params, decoded)
+	case "ASCIIHexDecode", "AHx":
+		return []byte(newLexer(bytes.NewReader(data), 0).readHexString()), nil
+	case "ASCII85Decode", "A85":
+		data = bytes.TrimPrefix(bytes.TrimSpace(data), []byte("<~"))
+		if index := bytes.Index(data, []byte("~>")); index >= 0 {
+			data = data[:index]
+		}
+		decoded := make([]byte, 4*len(data)/5+4)
+		n, _, err := ascii85.Decode(decoded, data, true)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidPDF, err)
+		}
+		return decoded[:n], nil
+	}
+
+	return nil, fmt.Errorf("%w: %s", errUnsupportedFilter, filter)
+}
+
+// inflate - decompresses the zlib data. The truncated streams are common, so the data read before an error is kept
+func inflate(data []byte) ([]byte, error) {
+	var reader io.ReadCloser
+	reader, err := zlib.NewReader(bytes.NewReader(data))
+	if err != nil {
+		// some writers omit the zlib header
+		reader = flate.NewReader(bytes.NewReader(data))
+	}
+	defer func() {
+		_ = reader.Close()
+	}()
+
+	decoded, err := io.ReadAll(io.LimitReader(reader, maxStreamSize+1))
+	if len(decoded) > maxStreamSize {
+		return nil, fmt.Errorf("%w: the decoded stream is too large", ErrInvalidPDF)
+	}
+	if err != nil && len(decoded) == 0 {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPDF, err)
+	}
+
+	return decoded, nil
+}
+
+// unpredict - reverses the PNG predictors, used mostly by the cross-reference streams
+func (f *file) unpredict(params dict, data []byte) ([]byte, error) {
+	predictor, _ := f.int(params["Predictor"])
+	if predictor < 10 {
+		return data, nil
+	}
+
+	colors, bitsPerComponent, columns := int64(1), int64(8), int64(1)
+	if value, ok := f.int(params["Colors"]); ok && value > 0 {
+		colors = value
+	}
+	if value, ok := f.int(params["BitsPerComponent"]); ok && value > 0 {
+		bitsPerComponent = value
+	}
+	if value, ok := f.int(params["Columns"]); ok && value > 0 {
+		columns = value
+	}
+	bytesPerPixel := int(max(1, colors*bitsPerComponent/8))
+	rowSize := int((colors*bitsPerComponent*columns + 7) / 8)
+	if rowSize <= 0 || rowSize > maxStreamSize {
+		return nil, fmt.Errorf("%w: invalid predictor parameters", ErrInvalidPDF)
+	}
+
+	decoded := make([]byte, 0, len(data))
+	previous := make([]byte, rowSize)
+	for position := 0; position+rowSize+1 <= len(data); position += rowSize + 1 {
+		filterType := data[position]
+		row := slices.Clone(data[position+1 : position+1+rowSize])
+		for i := range row {
+			var left, upLeft byte
+			if i >= bytesPerPixel {
+				left, upLeft = row[i-bytesPerPixel], previous[i-bytesPerPixel]
+			}
+			up := previous[i]
+			switch filterType {
+			case 1:
+				row[i] += left
+			case 2:
+				row[i] += up
+			case 3:
+				row[i] += byte((int(left) + int(up)) / 2)
+			case 4:
+				row[i] += paeth(left, up, upLeft)
+			}
+		}
+		decoded = append(decoded, row...)
+		previous = row
+	}
+
+	return decoded, nil
+}
+
+func paeth(a, b, c byte) byte {
+	p := int(a) + int(b) - int(c)
+	pa, pb, pc := abs(p-int(a)), abs(p-int(b)), abs(p-int(c))
+	if pa <= pb && pa <= pc {
+		return a
+	}
+	if pb <= pc {
+		return b
+	}
+
+	return c
+}
+
+func abs(value int) int {
+	if value < 0 {
+		return -value
+	}
+
+	return value
+}
@@ -0,0 +1,430 @@
+package pdf
+
+import (
+	"bufio"
+	"encoding/hex"
+	"errors"
+	"io"
+	"strconv"
+)
+
+const (
+	// the nesting limit protects from the stack overflow on malicious files
+	maxObjectDepth = 64
+)
+
+var (
+	errUnexpectedEOF   = errors.New("unexpected end of data")
+	errMalformedObject = errors.New("malformed object")
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenInt
+	tokenReal
+	tokenName
+	tokenString
+	tokenKeyword
+	tokenArrayStart
+	tokenArrayEnd
+	tokenDictStart
+	tokenDictEnd
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	int  int64
+	real float64
+}
+
+// lexer - splits the PDF syntax into tokens, and reads the objects built from them.
+// The position is tracked to locate the stream data, which follows the stream dictionary
+type lexer struct {
+	reader  *bufio.Reader
+	pos     int64
+	pending []token
+}
+
+func newLexer(reader io.Reader, pos int64) *lexer {
+	return &lexer{reader: bufio.NewReader(reader), pos: pos}
+}
+
+// readObject - reads a direct object, the indirect references are returned unresolved
+func (l *lexer) readObject() (object, error) {
+	return l.readObjectFrom(l.next(), 0)
+}
+
+func (l *lexer) readObjectFrom(t token, depth int) (object, error) {
+	if depth > maxObjectDepth {
+		return nil, errMalformedObject
+	}
+
+	switch t.kind {
+	case tokenEOF:
+		return nil, errUnexpectedEOF
+	case tokenInt:
+		// the integer may be the start of an indirect reference: '12 0 R'
+		generation := l.next()
+		if generation.kind == tokenInt {
+			operator := l.next()
+			if operator.kind == tokenKeyword && operator.text == "R" {
+				return ref{number: int(t.int), generation: int(generation.int)}, nil
+			}
+			l.unread(operator)
+		}
+		l.unread(generation)
+		return t.int, nil
+	case tokenReal:
+		return t.real, nil
+	case tokenName:
+		return name(t.text), nil
+	case tokenString:
+		return t.text, nil
+	case tokenArrayStart:
+		items := array{}
+		for {
+			item := l.next()
+			if item.kind == tokenArrayEnd {
+				return items, nil
+			}
+			value, err := l.readObjectFrom(item, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, value)
+		}
+	case tokenDictStart:
+		entries := dict{}
+		for {
+			key := l.next()
+			if key.kind == tokenDictEnd {
+				return entries, nil
+			}
+			if key.kind == tokenEOF {
+				return nil, errUnexpectedEOF
+			}
+			if key.kind != tokenName {
+				continue // a malformed entry is skipped
+			}
+			valueToken := l.next()
+			if valueToken.kind == tokenDictEnd {
+				return entries, nil
+			}
+			value, err := l.readObjectFrom(valueToken, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			entries[name(key.text)] = value
+		}
+	case tokenKeyword:
+		switch t.text {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		case "null":
+			return nil, nil
+		default:
+			return keyword(t.text), nil
+		}
+	default:
+		return nil, errMalformedObject
+	}
+}
+
+func (l *lexer) unread(t token) {
+	l.pending = append(l.pending, t)
+}
+
+func (l *lexer) next() token {
+	if n := len(l.pending); n > 0 {
+		t := l.pending[n-1]
+		l.pending = l.pending[:n-1]
+		return t
+	}
+
+	b, ok := l.skipSpace()
+	if !ok {
+		return token{kind: tokenEOF}
+	}
+	switch b {
+	case '/':
+		return token{kind: tokenName, text: l.readName()}
+	case '(':
+		return token{kind: tokenString, text: l.readLiteralString()}
+	case '<':
+		if c, ok := l.readByte(); ok {
+			if c == '<' {
+				return token{kind: tokenDictStart}
+			}
+			l.unreadByte()
+		}
+		return token{kind: tokenString, text: l.readHexString()}
+	case '>':
+		// a single '>' is tolerated as the dictionary end
+		if c, ok := l.readByte(); ok && c != '>' {
+			l.unreadByte()
+		}
+		return token{kind: tokenDictEnd}
+	case '[':
+		return token{kind: tokenArrayStart}
+	case ']':
+		return token{kind: tokenArrayEnd}
+	case '{', '}', ')':
+		return token{kind: tokenKeyword, text: string(b)}
+	}
+
+	l.unreadByte()
+	word := l.readRegular()
+	if len(word) > 0 && (isDigit(word[0]) || word[0] == '-' || word[0] == '+' || word[0] == '.') {
+		if value, err := strconv.ParseInt(word, 10, 64); err == nil {
+			return token{kind: tokenInt, int: value}
+		}
+		if value, err := strconv.ParseFloat(word, 64); err == nil {
+			return token{kind: tokenReal, real: value}
+		}
+	}
+
+	return token{kind: tokenKeyword, text: word}
+}
+
+// skipInlineImage - skips the inline image data, which follows the 'ID' operator up to the 'EI' one
+func (l *lexer) skipInlineImage() {
+	for {
+		t := l.next()
+		if t.kind == tokenEOF || (t.kind == tokenKeyword && t.text == "ID") {
+			break
+		}
+	}
+	l.readByte() // a single white-space character after the 'ID' operator
+
+	// the data ends with the 'EI' operator, surrounded by white-space characters
+	var beforeLast, last byte = ' ', ' '
+	for {
+		b, ok := l.readByte()
+		if !ok {
+			return
+		}
+		if b == 'I' && last == 'E' && isWhitespace(beforeLast) {
+			next, ok := l.readByte()
+			if !ok || isWhitespace(next) {
+				return
+			}
+			l.unreadByte()
+		}
+		beforeLast, last = last, b
+	}
+}
+
+func (l *lexer) readByte() (byte, bool) {
+	b, err := l.reader.ReadByte()
+	if err != nil {
+		return 0, false
+	}
+	l.pos++
+
+	return b, true
+}
+
+func (l *lexer) unreadByte() {
+	if l.reader.UnreadByte() == nil {
+		l.pos--
+	}
+}
+
+func (l *lexer) skipSpace() (byte, bool) {
+	for {
+		b, ok := l.readByte()
+		if !ok {
+			return 0, false
+		}
+		if b == '%' {
+			for ok && b != '\r' && b != '\n' {
+				b, ok = l.readByte()
+			}
+			continue
+		}
+		if !isWhitespace(b) {
+			return b, true
+		}
+	}
+}
+
+func (l *lexer) readRegular() string {
+	var word []byte
+	for {
+		b, ok := l.readByte()
+		if !ok {
+			break
+		}
+		if isWhitespace(b) || isDelimiter(b) {
+			l.unreadByte()
+			break
+		}
+		word = append(word, b)
+	}
+	if len(word) == 0 {
+		// an unexpected delimiter is consumed, so the lexer always moves forward
+		if b, ok := l.readByte(); ok {
+			word = append(word, b)
+		}
+	}
+
+	return string(word)
+}
+
+// readName - reads a name, decoding the '#xx' escape sequences
+func (l *lexer) readName() string {
+	raw := []byte(l.readNameChars())
+	decoded := make([]byte, 0, len(raw))
+	for i := 0; i < len(raw); i++ {
+		if raw[i] == '#' && i+2 < len(raw) {
+			if value, err := hex.DecodeString(string(raw[i+1 : i+3])); err == nil {
+				decoded = append(decoded, value[0])
+				i += 2
+				continue
+			}
+		}
+		decoded = append(decoded, raw[i])
+	}
+
+	return string(decoded)
+}
+
+func (l *lexer) readNameChars() string {
+	var chars []byte
+	for {
+		b, ok := l.readByte()
+		if !ok {
+			break
+		}
+		if isWhitespace(b) || isDelimiter(b) {
+			l.unreadByte()
+			break
+		}
+		chars = append(chars, b)
+	}
+
+	return string(chars)
+}
+
+func (l *lexer) readLiteralString() string {
+	var value []byte
+	depth := 1
+	for {
+		b, ok := l.readByte()
+		if !ok {
+			return string(value)
+		}
+		switch b {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return string(value)
+			}
+		case '\r':
+			// the end-of-line markers are always read as a single line feed
+			if next, ok := l.readByte(); ok && next != '\n' {
+				l.unreadByte()
+			}
+			b = '\n'
+		case '\\':
+			escaped, ok := l.readEscape()
+			if !ok {
+				continue
+			}
+			b = escaped
+		}
+		value = append(value, b)
+	}
+}
+
+// readEscape - reads a literal string escape sequence, returns false for the line continuation
+func (l *lexer) readEscape() (byte, bool) {
+	b, ok := l.readByte()
+	if !ok {
+		return 0, false
+	}
+	switch b {
+	case 'n':
+		return '\n', true
+	case 'r':
+		return '\r', true
+	case 't':
+		return '\t', true
+	case 'b':
+		return '\b', true
+	case 'f':
+		return '\f', true
+	case '\r':
+		if next, ok := l.readByte(); ok && next != '\n' {
+			l.unreadByte()
+		}
+		return 0, false
+	case '\n':
+		return 0, false
+	}
+	if b < '0' || b > '7' {
+		return b, true
+	}
+
+	value := int(b - '0')
+	for range 2 {
+		next, ok := l.readByte()
+		if !ok {
+			break
+		}
+		if next < '0' || next > '7' {
+			l.unreadByte()
+			break
+		}
+		value = value*8 + int(next-'0')
+	}
+
+	return byte(value), true
+}
+
+func (l *lexer) readHexString() string {
+	var digits []byte
+	for {
+		b, ok := l.readByte()
+		if !ok || b == '>' {
+			break
+		}
+		if isHexDigit(b) {
+			digits = append(digits, b)
+		}
+	}
+	if len(digits)%2 == 1 {
+		digits = append(digits, '0')
+	}
+	value, _ := hex.DecodeString(string(digits))
+
+	return string(value)
+}
+
+func isWhitespace(b byte) bool {
+	return b == 0 || b == '\t' || b == '\n' || b == '\f' || b == '\r' || b == ' '
+}
+
+func isDelimiter(b byte) bool {
+	switch b {
+	case '(', ')', '<', '>', '[', ']', '{', '}', '/', '%':
+		return true
+	}
+
+	return false
+}
+
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
+
+func isHexDigit(b byte) bool {
+	return isDigit(b) || (b >= 'a' && b <= 'f') || (b >= 'A' && b <= 'F')
+}
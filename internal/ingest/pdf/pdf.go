@@ -0,0 +1,156 @@
+package pdf
+
+import (
+	"cmp"
+	"github.com/sdreger/lib-manager-go/internal/isbn"
+	"io"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+const (
+	// the ISBN is usually printed on the copyright page, which is one of the first ones
+	isbnSearchPages = 10
+)
+
+var (
+	isbnTextRegexp = regexp.MustCompile(`(?i)ISBN(?:-1[03])?(?:\s*\(\w+\))?[:\s]*((?:97[89][-\s]?)?(?:\d[-\s]?){9}[\dX])\b`)
+	authorsRegexp  = regexp.MustCompile(`\s*(?:;|&|\band\b)\s*`)
+	keywordsRegexp = regexp.MustCompile(`\s*[,;]\s*`)
+)
+
+// Parse - reads the PDF metadata from the XMP packet and the document information dictionary (the XMP values win),
+// looks for the ISBN in the text of the first pages, and extracts the cover from the first page.
+// Only the page count is read from the encrypted documents
+func Parse(reader io.ReaderAt, size int64) (Document, error) {
+	f, err := open(reader, size)
+	if err != nil {
+		return Document{}, err
+	}
+	catalog := f.catalog()
+	if catalog == nil {
+		return Document{}, ErrInvalidPDF
+	}
+
+	document := Document{Metadata: Metadata{PageCount: f.pageCount(catalog)}}
+	if f.trailer["Encrypt"] != nil {
+		document.Encrypted = true
+		return document, nil
+	}
+
+	var xmp xmpProperties
+	if metadataStream, ok := f.resolve(catalog["Metadata"]).(stream); ok {
+		if data, err := f.streamData(metadataStream); err == nil {
+			xmp = parseXMP(data)
+		}
+	}
+	document.Metadata = readMetadata(f.dict(f.trailer["Info"]), xmp, document.Metadata)
+
+	pages := f.pages(catalog, isbnSearchPages)
+	if document.Metadata.ISBN10 == "" && document.Metadata.ISBN13 == "" {
+		for _, p := range pages {
+			isbn10, isbn13 := findISBNs(f.pageText(p))
+			document.Metadata.ISBN10 = cmp.Or(document.Metadata.ISBN10, isbn10)
+			document.Metadata.ISBN13 = cmp.Or(document.Metadata.ISBN13, isbn13)
+			if document.Metadata.ISBN10 != "" && document.Metadata.ISBN13 != "" {
+				break
+			}
+		}
+	}
+	if len(pages) > 0 {
+		document.Cover = f.firstPageCover(pages[0])
+	}
+
+	return document, nil
+}
+
+func readMetadata(info dict, xmp xmpProperties, metadata Metadata) Metadata {
+	infoString := func(key name) string {
+		value, _ := info[key].(string)
+		return normalizeSpace(decodeTextString(value))
+	}
+
+	metadata.Title = cmp.Or(xmp.first("dc:title"), infoString("Title"))
+	metadata.Subject = cmp.Or(xmp.first("dc:description"), infoString("Subject"))
+	metadata.Publisher = xmp.first("dc:publisher")
+
+	for _, creator := range xmp["dc:creator"] {
+		metadata.Authors = appendUnique(metadata.Authors, normalizeSpace(creator))
+	}
+	if len(metadata.Authors) == 0 {
+		metadata.Authors = splitAuthors(infoString("Author"))
+	}
+
+	for _, subject := range xmp["dc:subject"] {
+		metadata.Keywords = appendUnique(metadata.Keywords, normalizeSpace(subject))
+	}
+	if len(metadata.Keywords) == 0 {
+		keywords := cmp.Or(xmp.first("pdf:Keywords"), infoString("Keywords"))
+		for _, keyword := range keywordsRegexp.Split(keywords, -1) {
+			metadata.Keywords = appendUnique(metadata.Keywords, keyword)
+		}
+	}
+
+	for _, key := range []string{"prism:publicationDate", "prism:coverDate", "dc:date"} {
+		if pubDate, ok := parseDate(xmp.first(key)); ok {
+			metadata.PubDate = pubDate
+			break
+		}
+	}
+
+	for _, identifier := range append(slices.Clone(xmp["prism:isbn"]), xmp["dc:identifier"]...) {
+		value := isbn.Normalize(identifier)
+		switch {
+		case isbn.IsValid13(value) && metadata.ISBN13 == "":
+			metadata.ISBN13 = value
+		case isbn.IsValid10(value) && metadata.ISBN10 == "":
+			metadata.ISBN10 = value
+		}
+	}
+
+	return metadata
+}
+
+// splitAuthors - splits the information dictionary author list. The commas are only treated as separators,
+// if every part looks like a full name, since the 'Last, First' form is common as well
+func splitAuthors(value string) []string {
+	var authors []string
+	for _, part := range authorsRegexp.Split(value, -1) {
+		names := strings.Split(part, ",")
+		if len(names) > 1 && !slices.ContainsFunc(names, func(n string) bool {
+			return !strings.Contains(strings.TrimSpace(n), " ")
+		}) {
+			for _, n := range names {
+				authors = appendUnique(authors, normalizeSpace(n))
+			}
+			continue
+		}
+		authors = appendUnique(authors, normalizeSpace(part))
+	}
+
+	return authors
+}
+
+// findISBNs - returns the first valid ISBN-10 and ISBN-13, labeled with 'ISBN' in the text
+func findISBNs(text string) (isbn10 string, isbn13 string) {
+	for _, match := range isbnTextRegexp.FindAllStringSubmatch(text, -1) {
+		value := isbn.Normalize(match[1])
+		switch {
+		case isbn.IsValid13(value) && isbn13 == "":
+			isbn13 = value
+		case isbn.IsValid10(value) && isbn10 == "":
+			isbn10 = value
+		}
+	}
+
+	return isbn10, isbn13
+}
+
+func appendUnique(values []string, value string) []string {
+	if value == "" || slices.Contains(values, value) {
+		return values
+	}
+
+	return append(values, value)
+}
@@ -0,0 +1,374 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testXMP = `<?xpacket begin="" id="W5M0MpCehiHzreSzNTczkc9d"?>
+<x:xmpmeta xmlns:x="adobe:ns:meta/">
+  <rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">
+    <rdf:Description rdf:about="" xmlns:dc="http://purl.org/dc/elements/1.1/"
+        xmlns:prism="http://prismstandard.org/namespaces/basic/3.0/" xmlns:pdf="http://ns.adobe.com/pdf/1.3/"
+        pdf:Keywords="ignored, keywords" prism:publicationDate="2015-11-04">
+      <dc:title><rdf:Alt><rdf:li xml:lang="x-default">Go in   Action</rdf:li></rdf:Alt></dc:title>
+      <dc:creator><rdf:Seq><rdf:li>William Kennedy</rdf:li><rdf:li>Brian Ketelsen</rdf:li></rdf:Seq></dc:creator>
+      <dc:description><rdf:Alt><rdf:li xml:lang="x-default">An introduction to Go</rdf:li></rdf:Alt></dc:description>
+      <dc:subject><rdf:Bag><rdf:li>Go</rdf:li><rdf:li>Programming</rdf:li></rdf:Bag></dc:subject>
+      <dc:publisher><rdf:Bag><rdf:li>Manning</rdf:li></rdf:Bag></dc:publisher>
+      <prism:isbn>978-1-61729-178-4</prism:isbn>
+    </rdf:Description>
+  </rdf:RDF>
+</x:xmpmeta>
+<?xpacket end="w"?>`
+
+	testToUnicode = `/CIDInit /ProcSet findresource begin
+12 dict begin
+begincmap
+1 begincodespacerange
+<0000> <FFFF>
+endcodespacerange
+1 beginbfrange
+<0020> <007E> <0020>
+endbfrange
+endcmap
+CMapName currentdict /CMap defineresource pop
+end
+end`
+)
+
+type xrefMode int
+
+const (
+	xrefTable xrefMode = iota
+	xrefStream
+	xrefBroken
+)
+
+// testPDF - builds the minimal PDF files, the object numbers start from 1 in the order of addition
+type testPDF struct {
+	objects    []string
+	compressed map[int]bool
+	trailer    string
+}
+
+func (p *testPDF) add(body string) int {
+	p.objects = append(p.objects, body)
+	return len(p.objects)
+}
+
+// addCompressed - adds an object, which is stored in an object stream, if the cross-reference stream is used
+func (p *testPDF) addCompressed(body string) int {
+	if p.compressed == nil {
+		p.compressed = make(map[int]bool)
+	}
+	number := p.add(body)
+	p.compressed[number] = true
+
+	return number
+}
+
+func (p *testPDF) build(t *testing.T, mode xrefMode) []byte {
+	t.Helper()
+
+	var content bytes.Buffer
+	content.WriteString("%PDF-1.7\n%\xe2\xe3\xcf\xd3\n")
+	offsets := make(map[int]int)
+	writeObject := func(number int, body string) {
+		offsets[number] = content.Len()
+		_, _ = fmt.Fprintf(&content, "%d 0 obj\n%s\nendobj\n", number, body)
+	}
+
+	// the object stream number is the one after the last object, the cross-reference stream number is the next one
+	objStreamNumber, xrefStreamNumber := len(p.objects)+1, len(p.objects)+2
+	var objStreamHeader, objStreamBody strings.Builder
+	compressedIndex := make(map[int]int)
+	for i, body := range p.objects {
+		number := i + 1
+		if mode == xrefStream && p.compressed[number] {
+			compressedIndex[number] = len(compressedIndex)
+			_, _ = fmt.Fprintf(&objStreamHeader, "%d %d ", number, objStreamBody.Len())
+			objStreamBody.WriteString(body + "\n")
+			continue
+		}
+		writeObject(number, body)
+	}
+
+	xrefOffset := content.Len()
+	switch mode {
+	case xrefTable, xrefBroken:
+		_, _ = fmt.Fprintf(&content, "xref\n0 %d\n0000000000 65535 f \n", len(p.objects)+1)
+		for number := 1; number <= len(p.objects); number++ {
+			_, _ = fmt.Fprintf(&content, "%010d 00000 n \n", offsets[number])
+		}
+		_, _ = fmt.Fprintf(&content, "trailer\n<< /Size %d %s >>\n", len(p.objects)+1, p.trailer)
+		if mode == xrefBroken {
+			xrefOffset += 5
+		}
+	case xrefStream:
+		header := objStreamHeader.String()
+		writeObject(objStreamNumber, streamObject(
+			fmt.Sprintf("/Type /ObjStm /N %d /First %d /Filter /FlateDecode", len(compressedIndex), len(header)),
+			deflate(t, []byte(header+objStreamBody.String()))))
+
+		xrefOffset = content.Len()
+		var entries bytes.Buffer
+		entries.Write([]byte{0, 0, 0, 0, 0, 0xff, 0xff})
+		for number := 1; number <= xrefStreamNumber; number++ {
+			entry := make([]byte, 7)
+			switch index, ok := compressedIndex[number]; {
+			case ok:
+				entry[0] = 2
+				binary.BigEndian.PutUint32(entry[1:], uint32(objStreamNumber))
+				binary.BigEndian.PutUint16(entry[5:], uint16(index))
+			case number == xrefStreamNumber:
+				entry[0] = 1
+				binary.BigEndian.PutUint32(entry[1:], uint32(xrefOffset))
+			default:
+				entry[0] = 1
+				binary.BigEndian.PutUint32(entry[1:], uint32(offsets[number]))
+			}
+			entries.Write(entry)
+		}
+		writeObject(xrefStreamNumber, streamObject(
+			fmt.Sprintf("/Type /XRef /Size %d /W [1 4 2] /Filter /FlateDecode %s", xrefStreamNumber+1, p.trailer),
+			deflate(t, entries.Bytes())))
+	}
+	_, _ = fmt.Fprintf(&content, "startxref\n%d\n%%%%EOF\n", xrefOffset)
+
+	return content.Bytes()
+}
+
+func TestParse(t *testing.T) {
+	for _, mode := range []xrefMode{xrefTable, xrefStream, xrefBroken} {
+		t.Run(fmt.Sprintf("XRefMode%d", mode), func(t *testing.T) {
+			coverJPEG := encodeTestJPEG(t, 300, 450)
+			pdf := testPDF{}
+			pdf.trailer = "/Root 1 0 R /Info 2 0 R"
+			pdf.addCompressed("<< /Type /Catalog /Pages 3 0 R /Metadata 4 0 R >>")
+			pdf.addCompressed("<< /Title (Ignored Title) /Author (Somebody Else) /Producer (test) >>")
+			pdf.addCompressed("<< /Type /Pages /Kids [5 0 R 6 0 R] /Count 2 >>")
+			pdf.add(streamObject("/Type /Metadata /Subtype /XML", []byte(testXMP)))
+			pdf.addCompressed("<< /Type /Page /Parent 3 0 R /Resources << /XObject << /Im1 7 0 R /Im2 8 0 R >> >> >>")
+			pdf.addCompressed("<< /Type /Page /Parent 3 0 R >>")
+			pdf.add(streamObject(
+				"/Type /XObject /Subtype /Image /Width 300 /Height 450 /ColorSpace /DeviceRGB "+
+					"/BitsPerComponent 8 /Filter /DCTDecode", coverJPEG))
+			pdf.add(streamObject("/Type /XObject /Subtype /Image /Width 10 /Height 10 /ColorSpace /DeviceGray "+
+				"/BitsPerComponent 8", make([]byte, 100)))
+			content := pdf.build(t, mode)
+
+			document, err := Parse(bytes.NewReader(content), int64(len(content)))
+			require.NoError(t, err, "should parse PDF")
+			assert.Equal(t, Metadata{
+				Title:     "Go in Action",
+				Authors:   []string{"William Kennedy", "Brian Ketelsen"},
+				Subject:   "An introduction to Go",
+				Keywords:  []string{"Go", "Programming"},
+				Publisher: "Manning",
+				PubDate:   time.Date(2015, 11, 4, 0, 0, 0, 0, time.UTC),
+				PageCount: 2,
+				ISBN13:    "9781617291784",
+			}, document.Metadata)
+			assert.False(t, document.Encrypted)
+			require.NotNil(t, document.Cover, "should extract cover")
+			assert.Equal(t, "image/jpeg", document.Cover.MediaType)
+			assert.Equal(t, coverJPEG, document.Cover.Content)
+		})
+	}
+}
+
+func TestParse_InfoAndText(t *testing.T) {
+	pageText := "BT /F1 10 Tf 72 700 Td " + hexText("Copyright (c) 2021") + " Tj 0 -12 Td " +
+		"[" + hexText("ISBN: 978-1-4920-") + " -100 " + hexText("7721-3") + "] TJ T* " +
+		hexText("ISBN-10 1492077216") + " Tj ET"
+	pdf := testPDF{trailer: "/Root 1 0 R /Info 2 0 R"}
+	pdf.add("<< /Type /Catalog /Pages 3 0 R >>")
+	pdf.add("<< /Title <FEFF004C006500610072006E0069006E006700200047006F> /Author (Bodner, Jon) " +
+		"/Subject (Idiomatic\\r\\nGo) /Keywords (go; programming, idioms) >>")
+	pdf.add("<< /Type /Pages /Kids [4 0 R] /Count 1 /Resources << /Font << /F1 5 0 R >> >> >>")
+	pdf.add("<< /Type /Page /Parent 3 0 R /Contents 6 0 R >>")
+	pdf.add("<< /Type /Font /Subtype /Type0 /BaseFont /Test /Encoding /Identity-H /ToUnicode 7 0 R >>")
+	pdf.add(streamObject("/Filter /FlateDecode", deflate(t, []byte(pageText))))
+	pdf.add(streamObject("", []byte(testToUnicode)))
+	content := pdf.build(t, xrefTable)
+
+	document, err := Parse(bytes.NewReader(content), int64(len(content)))
+	require.NoError(t, err, "should parse PDF")
+	assert.Equal(t, Metadata{
+		Title:     "Learning Go",
+		Authors:   []string{"Bodner, Jon"},
+		Subject:   "Idiomatic Go",
+		Keywords:  []string{"go", "programming", "idioms"},
+		PageCount: 1,
+		ISBN10:    "1492077216",
+		ISBN13:    "9781492077213",
+	}, document.Metadata)
+	assert.Nil(t, document.Cover, "should not extract cover")
+}
+
+func TestParse_RasterCover(t *testing.T) {
+	width, height := 300, 400
+	samples := make([]byte, width*height*3)
+	for i := range width * height {
+		samples[i*3], samples[i*3+1], samples[i*3+2] = 0x10, 0x20, 0x30
+	}
+	// the PNG 'Up' predictor is applied to every row
+	var predicted []byte
+	for row := range height {
+		predicted = append(predicted, 2)
+		if row == 0 {
+			predicted = append(predicted, samples[:width*3]...)
+		} else {
+			predicted = append(predicted, make([]byte, width*3)...)
+		}
+	}
+
+	pdf := testPDF{trailer: "/Root 1 0 R"}
+	pdf.add("<< /Type /Catalog /Pages 2 0 R >>")
+	pdf.add("<< /Type /Pages /Kids [3 0 R] /Count 1 >>")
+	pdf.add("<< /Type /Page /Parent 2 0 R /Resources << /XObject << /Im1 4 0 R >> >> >>")
+	pdf.add(streamObject(fmt.Sprintf("/Subtype /Image /Width %d /Height %d /ColorSpace [/ICCBased 5 0 R] "+
+		"/BitsPerComponent 8 /Filter /FlateDecode /DecodeParms << /Predictor 15 /Colors 3 /Columns %d >>",
+		width, height, width), deflate(t, predicted)))
+	pdf.add(streamObject("/N 3", nil))
+	content := pdf.build(t, xrefTable)
+
+	document, err := Parse(bytes.NewReader(content), int64(len(content)))
+	require.NoError(t, err, "should parse PDF")
+	require.NotNil(t, document.Cover, "should extract cover")
+	assert.Equal(t, "image/png", document.Cover.MediaType)
+
+	img, err := png.Decode(bytes.NewReader(document.Cover.Content))
+	require.NoError(t, err, "should encode cover as PNG")
+	assert.Equal(t, image.Rect(0, 0, width, height), img.Bounds())
+	assert.Equal(t, color.NRGBA{R: 0x10, G: 0x20, B: 0x30, A: 0xff}, color.NRGBAModel.Convert(img.At(150, 399)))
+}
+
+func TestParse_OversizedImage(t *testing.T) {
+	pdf := testPDF{trailer: "/Root 1 0 R"}
+	pdf.add("<< /Type /Catalog /Pages 2 0 R >>")
+	pdf.add("<< /Type /Pages /Kids [3 0 R] /Count 1 >>")
+	pdf.add("<< /Type /Page /Parent 2 0 R /Resources << /XObject << /Im1 4 0 R >> >> >>")
+	pdf.add(streamObject("/Subtype /Image /Width 4294967296 /Height 4294967296 /ColorSpace /DeviceRGB "+
+		"/BitsPerComponent 8", []byte{0, 0, 0}))
+	content := pdf.build(t, xrefTable)
+
+	document, err := Parse(bytes.NewReader(content), int64(len(content)))
+	require.NoError(t, err, "should parse PDF")
+	assert.Nil(t, document.Cover, "the oversized image should be skipped")
+}
+
+func TestParse_Encrypted(t *testing.T) {
+	pdf := testPDF{trailer: "/Root 1 0 R /Info 3 0 R /Encrypt 4 0 R"}
+	pdf.add("<< /Type /Catalog /Pages 2 0 R >>")
+	pdf.add("<< /Type /Pages /Kids [] /Count 12 >>")
+	pdf.add("<< /Title (\x8f\x12\xa1) >>")
+	pdf.add("<< /Filter /Standard /V 2 /R 3 >>")
+	content := pdf.build(t, xrefTable)
+
+	document, err := Parse(bytes.NewReader(content), int64(len(content)))
+	require.NoError(t, err, "should parse encrypted PDF")
+	assert.True(t, document.Encrypted)
+	assert.Equal(t, Metadata{PageCount: 12}, document.Metadata)
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "NotPDF", content: "PK\x03\x04 not a PDF"},
+		{name: "NoCatalog", content: "%PDF-1.4\n1 0 obj\n<< /Type /Pages >>\nendobj\nstartxref\n999\n%%EOF"},
+		{name: "Truncated", content: "%PDF-1.4\n1 0 obj\n<< /Type /Catalog /Pages"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Parse(strings.NewReader(test.content), int64(len(test.content)))
+			assert.ErrorIs(t, err, ErrInvalidPDF)
+		})
+	}
+}
+
+func TestParse_HostileXRefStream(t *testing.T) {
+	tests := []struct {
+		name       string
+		dictionary string
+	}{
+		{name: "ZeroWidths", dictionary: "/W [0 0 0] /Index [0 2147483647]"},
+		{name: "HugeIndex", dictionary: "/W [1 4 2] /Index [0 2147483647 2147483647 2147483647]"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var content bytes.Buffer
+			content.WriteString("%PDF-1.7\n1 0 obj\n<< /Type /Catalog /Pages 2 0 R >>\nendobj\n")
+			content.WriteString("2 0 obj\n<< /Type /Pages /Kids [] /Count 3 >>\nendobj\n")
+			xrefOffset := content.Len()
+			_, _ = fmt.Fprintf(&content, "3 0 obj\n%s\nendobj\nstartxref\n%d\n%%%%EOF\n", streamObject(
+				"/Type /XRef /Size 4 /Root 1 0 R "+test.dictionary, []byte{1, 0, 0, 0, 9, 0, 0}), xrefOffset)
+
+			// the parsing should neither hang, nor exhaust the memory
+			document, err := Parse(bytes.NewReader(content.Bytes()), int64(content.Len()))
+			require.NoError(t, err, "should parse PDF")
+			assert.Equal(t, 3, document.Metadata.PageCount)
+		})
+	}
+}
+
+func TestSplitAuthors(t *testing.T) {
+	assert.Equal(t, []string{"Alan Donovan", "Brian Kernighan"}, splitAuthors("Alan Donovan, Brian Kernighan"))
+	assert.Equal(t, []string{"Alan Donovan", "Brian Kernighan"}, splitAuthors("Alan Donovan and Brian Kernighan"))
+	assert.Equal(t, []string{"Kernighan, Brian", "Pike, Rob"}, splitAuthors("Kernighan, Brian; Pike, Rob"))
+	assert.Equal(t, []string{"A. Author", "B. Author"}, splitAuthors("A. Author & B. Author & A. Author"))
+	assert.Empty(t, splitAuthors(""))
+}
+
+func streamObject(dictionary string, data []byte) string {
+	return fmt.Sprintf("<< %s /Length %d >>\nstream\n%s\nendstream", dictionary, len(data), data)
+}
+
+// hexText - encodes the text with the 2-byte codes of the test Type0 font
+func hexText(text string) string {
+	var encoded strings.Builder
+	encoded.WriteString("<")
+	for _, char := range text {
+		_, _ = fmt.Fprintf(&encoded, "%04X", char)
+	}
+	encoded.WriteString(">")
+
+	return encoded.String()
+}
+
+func deflate(t *testing.T, data []byte) []byte {
+	var compressed bytes.Buffer
+	writer := zlib.NewWriter(&compressed)
+	_, err := writer.Write(data)
+	require.NoError(t, err, "failed to compress data")
+	require.NoError(t, writer.Close(), "failed to compress data")
+
+	return compressed.Bytes()
+}
+
+func encodeTestJPEG(t *testing.T, width int, height int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for x := range width {
+		img.Set(x, x%height, color.RGBA{R: 200, A: 255})
+	}
+	var content bytes.Buffer
+	require.NoError(t, jpeg.Encode(&content, img, nil), "failed to encode JPEG")
+
+	return content.Bytes()
+}
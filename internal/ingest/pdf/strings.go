@@ -0,0 +1,67 @@
+package pdf
+
+import (
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+var spaceRegexp = regexp.MustCompile(`\s+`)
+
+// pdfDocEncoding - the PDFDocEncoding characters, which differ from Latin-1
+var pdfDocEncoding = map[byte]rune{
+	0x80: '•', 0x81: '†', 0x82: '‡', 0x83: '…', 0x84: '—', 0x85: '–', 0x86: 'ƒ',
+	0x87: '⁄', 0x88: '‹', 0x89: '›', 0x8a: '−', 0x8b: '‰', 0x8c: '„', 0x8d: '“',
+	0x8e: '”', 0x8f: '‘', 0x90: '’', 0x91: '‚', 0x92: '™', 0x93: 'ﬁ', 0x94: 'ﬂ',
+	0x95: 'Ł', 0x96: 'Œ', 0x97: 'Š', 0x98: 'Ÿ', 0x99: 'Ž', 0x9a: 'ı', 0x9b: 'ł',
+	0x9c: 'œ', 0x9d: 'š', 0x9e: 'ž', 0xa0: '€',
+}
+
+// decodeTextString - decodes a PDF text string, which is either UTF-16BE or UTF-8 with the byte order mark,
+// or PDFDocEncoding otherwise
+func decodeTextString(value string) string {
+	switch {
+	case strings.HasPrefix(value, "\xfe\xff"):
+		return decodeUTF16(value[2:])
+	case strings.HasPrefix(value, "\xef\xbb\xbf"):
+		return strings.ToValidUTF8(value[3:], "")
+	}
+
+	return decodePDFDocEncoding([]byte(value))
+}
+
+func decodePDFDocEncoding(value []byte) string {
+	var text strings.Builder
+	text.Grow(len(value))
+	for _, b := range value {
+		if r, ok := pdfDocEncoding[b]; ok {
+			text.WriteRune(r)
+		} else if b < utf8.RuneSelf {
+			text.WriteByte(b)
+		} else {
+			text.WriteRune(rune(b))
+		}
+	}
+
+	return text.String()
+}
+
+// parseDate - parses the XMP date, which is a subset of ISO 8601, all the parts after the year are optional
+func parseDate(value string) (time.Time, bool) {
+	value = strings.TrimSpace(value)
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05", "2006-01-02T15:04Z07:00", "2006-01-02",
+		"2006-01", "2006"} {
+
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed.UTC(), true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// normalizeSpace - collapses the whitespace sequences, the PDF producers often keep the line breaks in the values
+func normalizeSpace(value string) string {
+	return strings.TrimSpace(spaceRegexp.ReplaceAllString(value, " "))
+}
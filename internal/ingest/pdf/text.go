@@ -0,0 +1,324 @@
+package pdf
+
+import (
+	"bytes"
+	"strings"
+	"unicode/utf16"
+)
+
+const (
+	// the page tree limits protect from the malicious files
+	maxPageTreeDepth = 64
+	maxPageTreeNodes = 100000
+	maxOperands      = 64
+	maxCMapRange     = 1 << 16
+
+	// the TJ operator adjustment (in thousandths of the text space unit), treated as a word break
+	wordSpacingAdjustment = -200
+)
+
+// page - a page dictionary, along with the resources inherited from the page tree
+type page struct {
+	dict      dict
+	resources dict
+}
+
+// font - the character code to Unicode mapping of a font, used by the text extraction
+type font struct {
+	toUnicode   map[string]string
+	codeLengths []int
+	composite   bool
+}
+
+// pages - returns up to the limit first pages in the document order
+func (f *file) pages(catalog dict, limit int) []page {
+	var pages []page
+	visited := 0
+	var walk func(node dict, resources dict, depth int)
+	walk = func(node dict, resources dict, depth int) {
+		visited++
+		if len(pages) >= limit || depth > maxPageTreeDepth || visited > maxPageTreeNodes {
+			return
+		}
+		if nodeResources := f.dict(node["Resources"]); nodeResources != nil {
+			resources = nodeResources
+		}
+		kids := f.array(node["Kids"])
+		if node["Type"] == name("Page") || (node["Type"] == nil && kids == nil) {
+			pages = append(pages, page{dict: node, resources: resources})
+			return
+		}
+		for _, kid := range kids {
+			if kidDict := f.dict(kid); kidDict != nil {
+				walk(kidDict, resources, depth+1)
+			}
+		}
+	}
+	if root := f.dict(catalog["Pages"]); root != nil {
+		walk(root, nil, 0)
+	}
+
+	return pages
+}
+
+// pageCount - returns the page count, declared by the page tree root, or counts the pages otherwise
+func (f *file) pageCount(catalog dict) int {
+	if count, ok := f.int(f.dict(catalog["Pages"])["Count"]); ok && count > 0 {
+		return int(count)
+	}
+
+	return len(f.pages(catalog, maxPageTreeNodes))
+}
+
+// pageContent - returns the decoded page content, which may be split into several streams
+func (f *file) pageContent(p page) []byte {
+	var contents []object
+	switch value := f.resolve(p.dict["Contents"]).(type) {
+	case stream:
+		contents = []object{value}
+	case array:
+		contents = value
+	}
+
+	var content []byte
+	for _, item := range contents {
+		contentStream, ok := f.resolve(item).(stream)
+		if !ok {
+			continue
+		}
+		data, err := f.streamData(contentStream)
+		if err != nil {
+			continue
+		}
+		content = append(content, data...)
+		content = append(content, '\n')
+	}
+
+	return content
+}
+
+// pageText - extracts the page text. The text is only searched for the identifiers,
+// so the layout is approximated with spaces and line breaks
+func (f *file) pageText(p page) string {
+	fonts := make(map[name]*font)
+	fontResources := f.dict(p.resources["Font"])
+	var current *font
+	var operands []object
+	var text strings.Builder
+
+	l := newLexer(bytes.NewReader(f.pageContent(p)), 0)
+	for {
+		t := l.next()
+		if t.kind == tokenEOF {
+			break
+		}
+		if t.kind != tokenKeyword || t.text == "true" || t.text == "false" || t.text == "null" {
+			operand, err := l.readObjectFrom(t, 0)
+			if err != nil {
+				break
+			}
+			if len(operands) < maxOperands {
+				operands = append(operands, operand)
+			}
+			continue
+		}
+
+		switch t.text {
+		case "Tf":
+			if len(operands) > 0 {
+				if fontName, ok := operands[0].(name); ok {
+					if _, ok := fonts[fontName]; !ok {
+						fonts[fontName] = f.font(fontResources[fontName])
+					}
+					current = fonts[fontName]
+				}
+			}
+		case "Tj", "'", "\"":
+			if t.text != "Tj" {
+				text.WriteByte('\n')
+			}
+			if len(operands) > 0 {
+				if value, ok := operands[len(operands)-1].(string); ok {
+					text.WriteString(current.decode(value))
+				}
+			}
+		case "TJ":
+			if len(operands) > 0 {
+				items, _ := operands[len(operands)-1].(array)
+				for _, item := range items {
+					switch value := item.(type) {
+					case string:
+						text.WriteString(current.decode(value))
+					case int64, float64:
+						if adjustment, _ := f.int(value); adjustment < wordSpacingAdjustment {
+							text.WriteByte(' ')
+						}
+					}
+				}
+			}
+		case "Td", "TD", "T*", "Tm", "BT", "ET":
+			text.WriteByte('\n')
+		case "BI":
+			l.skipInlineImage()
+		}
+		operands = operands[:0]
+	}
+
+	return text.String()
+}
+
+func (f *file) font(obj object) *font {
+	fontDict := f.dict(obj)
+	if fontDict == nil {
+		return nil
+	}
+
+	result := &font{composite: fontDict["Subtype"] == name("Type0")}
+	if toUnicode, ok := f.resolve(fontDict["ToUnicode"]).(stream); ok {
+		if data, err := f.streamData(toUnicode); err == nil {
+			result.toUnicode, result.codeLengths = parseCMap(data)
+		}
+	}
+
+	return result
+}
+
+// decode - converts the character codes to text. The simple fonts without the Unicode mapping are assumed
+// to use the standard Latin encoding, the composite ones can not be decoded without the mapping
+func (fnt *font) decode(codes string) string {
+	if fnt == nil || len(fnt.toUnicode) == 0 {
+		if fnt != nil && fnt.composite {
+			return ""
+		}
+		return decodePDFDocEncoding([]byte(codes))
+	}
+
+	var text strings.Builder
+	for len(codes) > 0 {
+		matched := false
+		for _, length := range fnt.codeLengths {
+			if length > len(codes) {
+				continue
+			}
+			if value, ok := fnt.toUnicode[codes[:length]]; ok {
+				text.WriteString(value)
+				codes = codes[length:]
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			codes = codes[1:]
+		}
+	}
+
+	return text.String()
+}
+
+// parseCMap - reads the 'bfchar' and 'bfrange' mappings of a ToUnicode CMap,
+// returns the mapping along with the code lengths, from the longest one
+func parseCMap(data []byte) (map[string]string, []int) {
+	mapping := make(map[string]string)
+	lengths := make(map[int]bool)
+	add := func(code string, value string) {
+		mapping[code] = value
+		lengths[len(code)] = true
+	}
+
+	l := newLexer(bytes.NewReader(data), 0)
+	var operands []token
+	for {
+		t := l.next()
+		if t.kind == tokenEOF {
+			break
+		}
+		if t.kind != tokenKeyword {
+			if t.kind == tokenArrayStart {
+				// the range destination array is flattened, the array end token marks its end
+				for item := l.next(); item.kind != tokenArrayEnd && item.kind != tokenEOF; item = l.next() {
+					operands = append(operands, item)
+				}
+				operands = append(operands, token{kind: tokenArrayEnd})
+				continue
+			}
+			operands = append(operands, t)
+			continue
+		}
+
+		switch t.text {
+		case "endbfchar":
+			for i := 0; i+1 < len(operands); i += 2 {
+				if operands[i].kind == tokenString && operands[i+1].kind == tokenString {
+					add(operands[i].text, decodeUTF16(operands[i+1].text))
+				}
+			}
+		case "endbfrange":
+			for i := 0; i+2 < len(operands); {
+				low, high, destination := operands[i], operands[i+1], operands[i+2]
+				i += 3
+				if low.kind != tokenString || high.kind != tokenString || len(low.text) != len(high.text) {
+					continue
+				}
+				from, to := codeValue(low.text), codeValue(high.text)
+				if to < from || to-from >= maxCMapRange {
+					continue
+				}
+				if destination.kind == tokenString {
+					base := []rune(decodeUTF16(destination.text))
+					for code := from; code <= to && len(base) > 0; code++ {
+						value := append([]rune{}, base...)
+						value[len(value)-1] += rune(code - from)
+						add(codeString(code, len(low.text)), string(value))
+					}
+					continue
+				}
+				// the array destination lists the values for each code of the range
+				for code := from; i < len(operands) && operands[i].kind != tokenArrayEnd; code++ {
+					if code <= to && operands[i].kind == tokenString {
+						add(codeString(code, len(low.text)), decodeUTF16(operands[i].text))
+					}
+					i++
+				}
+				i++
+			}
+		}
+		operands = operands[:0]
+	}
+
+	var codeLengths []int
+	for length := 4; length >= 1; length-- {
+		if lengths[length] {
+			codeLengths = append(codeLengths, length)
+		}
+	}
+
+	return mapping, codeLengths
+}
+
+func codeValue(code string) int {
+	value := 0
+	for i := 0; i < len(code); i++ {
+		value = value<<8 | int(code[i])
+	}
+
+	return value
+}
+
+func codeString(value int, length int) string {
+	code := make([]byte, length)
+	for i := length - 1; i >= 0; i-- {
+		code[i] = byte(value)
+		value >>= 8
+	}
+
+	return string(code)
+}
+
+func decodeUTF16(value string) string {
+	units := make([]uint16, 0, len(value)/2)
+	for i := 0; i+1 < len(value); i += 2 {
+		units = append(units, uint16(value[i])<<8|uint16(value[i+1]))
+	}
+
+	return string(utf16.Decode(units))
+}
@@ -0,0 +1,52 @@
+package pdf
+
+import "time"
+
+// Metadata - the book details, collected from the document information dictionary, the XMP metadata,
+// and the text of the first pages
+type Metadata struct {
+	Title     string
+	Authors   []string
+	Subject   string
+	Keywords  []string
+	Publisher string
+	PubDate   time.Time
+	PageCount int
+	ISBN10    string
+	ISBN13    string
+}
+
+// Cover - the largest image of the first page, only JPEG and 8-bit RGB/Gray images are extracted
+type Cover struct {
+	MediaType string
+	Content   []byte
+}
+
+// Document - the parsed PDF file. The encrypted documents only have the page count extracted,
+// and the Cover is nil, if the first page has no suitable image
+type Document struct {
+	Metadata  Metadata
+	Cover     *Cover
+	Encrypted bool
+}
+
+// the PDF object model: null (nil), bool, int64, float64, string, name, array, dict, stream and ref
+type (
+	object  any
+	name    string
+	keyword string
+	array   []object
+	dict    map[name]object
+)
+
+// ref - an indirect object reference
+type ref struct {
+	number     int
+	generation int
+}
+
+// stream - a stream object, the data is read from the file on demand
+type stream struct {
+	dict   dict
+	offset int64
+}
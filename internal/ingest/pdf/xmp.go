@@ -0,0 +1,108 @@
+package pdf
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+)
+
+const (
+	nsRDF         = "http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+	nsPRISMPrefix = "http://prismstandard.org/namespaces/"
+)
+
+// xmpPrefixes - the prefixes of the namespaces, the properties are read from. The documents may declare
+// their own prefixes, so the properties are keyed by the well-known ones
+var xmpPrefixes = map[string]string{
+	"http://purl.org/dc/elements/1.1/": "dc",
+	"http://ns.adobe.com/pdf/1.3/":     "pdf",
+	"http://ns.adobe.com/xap/1.0/":     "xmp",
+}
+
+// xmpProperties - the XMP property values, keyed by the '{prefix}:{name}', e.g. 'dc:title'
+type xmpProperties map[string][]string
+
+func (p xmpProperties) first(key string) string {
+	for _, value := range p[key] {
+		if value = normalizeSpace(value); value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
+
+// parseXMP - reads the simple properties, and the array (rdf:Seq, rdf:Bag, rdf:Alt) items of the XMP packet.
+// The parsing is lenient, the properties read before a syntax error are returned
+func parseXMP(data []byte) xmpProperties {
+	properties := make(xmpProperties)
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.Strict = false
+
+	var property string // the current property key, empty if outside a known property
+	var depth, propertyDepth int
+	var text strings.Builder
+	hasItems := false
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			break
+		}
+
+		switch element := token.(type) {
+		case xml.StartElement:
+			depth++
+			if property == "" {
+				if key := xmpKey(element.Name); key != "" {
+					property, propertyDepth, hasItems = key, depth, false
+					text.Reset()
+					continue
+				}
+				// the simple properties may be declared as the rdf:Description attributes
+				if element.Name.Space == nsRDF && element.Name.Local == "Description" {
+					for _, attr := range element.Attr {
+						if key := xmpKey(attr.Name); key != "" {
+							properties[key] = append(properties[key], attr.Value)
+						}
+					}
+				}
+				continue
+			}
+			if element.Name.Space == nsRDF && element.Name.Local == "li" {
+				text.Reset()
+			}
+		case xml.CharData:
+			if property != "" {
+				text.Write(element)
+			}
+		case xml.EndElement:
+			if property != "" {
+				if element.Name.Space == nsRDF && element.Name.Local == "li" {
+					properties[property] = append(properties[property], strings.TrimSpace(text.String()))
+					hasItems = true
+					text.Reset()
+				} else if depth == propertyDepth {
+					if value := strings.TrimSpace(text.String()); !hasItems && value != "" {
+						properties[property] = append(properties[property], value)
+					}
+					property = ""
+				}
+			}
+			depth--
+		}
+	}
+
+	return properties
+}
+
+func xmpKey(elementName xml.Name) string {
+	prefix, ok := xmpPrefixes[elementName.Space]
+	if !ok && strings.HasPrefix(elementName.Space, nsPRISMPrefix) {
+		prefix, ok = "prism", true
+	}
+	if !ok {
+		return ""
+	}
+
+	return prefix + ":" + elementName.Local
+}
@@ -0,0 +1,207 @@
+package ingest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/jmoiron/sqlx"
+	"github.com/sdreger/lib-manager-go/internal/config"
+	"github.com/sdreger/lib-manager-go/internal/domain/book"
+	"github.com/sdreger/lib-manager-go/internal/domain/cover"
+	"github.com/sdreger/lib-manager-go/internal/ingest/epub"
+	"github.com/sdreger/lib-manager-go/internal/ingest/pdf"
+	"golang.org/x/text/language"
+	"golang.org/x/text/language/display"
+	"io"
+	"log/slog"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	FormatEPUB = "epub"
+	FormatPDF  = "pdf"
+
+	// the books 'pages' column is a SMALLINT
+	maxPages = 1<<15 - 1
+)
+
+type CoverService interface {
+	StoreCover(ctx context.Context, reader io.Reader) (cover.Cover, error)
+	ExpireUnusedCovers(ctx context.Context, olderThan time.Duration) (int, error)
+}
+
+// Draft - the proposed book record, extracted from a book file. Nothing is committed to the database,
+// except the content-addressed cover, which stays unreferenced until the book is saved, or it expires
+type Draft struct {
+	Format   string       `json:"format"`
+	Book     book.Book    `json:"book"`
+	Cover    *cover.Cover `json:"cover"`
+	Warnings []string     `json:"warnings"`
+}
+
+type Service struct {
+	logger       *slog.Logger
+	config       config.IngestConfig
+	coverService CoverService
+}
+
+func NewService(logger *slog.Logger, ingestConfig config.IngestConfig, db *sqlx.DB,
+	blobStore cover.BlobStore) *Service {
+	return &Service{
+		logger:       logger,
+		config:       ingestConfig,
+		coverService: cover.NewService(logger, db, blobStore),
+	}
+}
+
+// Ingest - extracts the book metadata and the cover from the book file, the format is detected by the file extension
+func (s *Service) Ingest(ctx context.Context, fileName string, content io.ReaderAt, size int64) (Draft, error) {
+	format := strings.TrimPrefix(strings.ToLower(path.Ext(fileName)), ".")
+	switch format {
+	case FormatEPUB:
+		document, err := epub.Parse(content, size)
+		if err != nil {
+			return Draft{}, errors.Join(ErrInvalidFile, err)
+		}
+		draft := newEPUBDraft(document.Metadata)
+		draft.Book.BookFileName = path.Base(fileName)
+		draft.Book.BookFileSize = size
+		if document.Cover != nil {
+			s.storeCover(ctx, &draft, document.Cover.Content)
+		}
+
+		return draft.withWarnings(), nil
+	case FormatPDF:
+		document, err := pdf.Parse(content, size)
+		if err != nil {
+			return Draft{}, errors.Join(ErrInvalidFile, err)
+		}
+		draft := newPDFDraft(document.Metadata)
+		draft.Book.BookFileName = path.Base(fileName)
+		draft.Book.BookFileSize = size
+		if document.Encrypted {
+			draft.Warnings = append(draft.Warnings, "the document is encrypted, only the page count is extracted")
+		}
+		if document.Cover != nil {
+			s.storeCover(ctx, &draft, document.Cover.Content)
+		}
+
+		return draft.withWarnings(), nil
+	default:
+		return Draft{}, ErrUnsupportedFormat
+	}
+}
+
+// storeCover - stores the extracted cover, and points the draft book to it. The draft covers of the books,
+// which were not committed within the TTL, are expired first. An invalid cover does not fail the ingest,
+// since it can be uploaded later
+func (s *Service) storeCover(ctx context.Context, draft *Draft, content []byte) {
+	expired, err := s.coverService.ExpireUnusedCovers(ctx, s.config.DraftCoverTTL)
+	if err != nil {
+		s.logger.Error("draft covers expiry failed: " + err.Error())
+	} else if expired > 0 {
+		s.logger.Info("draft covers expired", "count", expired)
+	}
+
+	storedCover, err := s.coverService.StoreCover(ctx, bytes.NewReader(content))
+	if err != nil {
+		s.logger.Warn("ingested book cover storing failed: "+err.Error(), "fileName", draft.Book.BookFileName)
+		draft.Warnings = append(draft.Warnings, "the embedded cover can not be stored: "+err.Error())
+		return
+	}
+
+	draft.Cover = &storedCover
+	draft.Book.CoverHash = storedCover.Hash
+	draft.Book.CoverWidth = storedCover.Width
+	draft.Book.CoverHeight = storedCover.Height
+}
+
+func newEPUBDraft(metadata epub.Metadata) Draft {
+	draft := Draft{
+		Format: FormatEPUB,
+		Book: book.Book{
+			Title:       metadata.Title,
+			Subtitle:    metadata.Subtitle,
+			Description: metadata.Description,
+			ISBN10:      metadata.ISBN10,
+			PubDate:     metadata.PubDate,
+			Language:    LanguageName(metadata.Language),
+			Publisher:   metadata.Publisher,
+			Authors:     metadata.Authors,
+			Categories:  metadata.Subjects,
+			FileTypes:   []string{FormatEPUB},
+		},
+	}
+	if metadata.ISBN13 != "" {
+		draft.Book.ISBN13, _ = strconv.ParseInt(metadata.ISBN13, 10, 64)
+	}
+
+	return draft
+}
+
+func newPDFDraft(metadata pdf.Metadata) Draft {
+	draft := Draft{
+		Format: FormatPDF,
+		Book: book.Book{
+			Title:       metadata.Title,
+			Description: metadata.Subject,
+			ISBN10:      metadata.ISBN10,
+			Pages:       uint16(max(min(metadata.PageCount, maxPages), 0)),
+			PubDate:     metadata.PubDate,
+			Publisher:   metadata.Publisher,
+			Authors:     metadata.Authors,
+			Tags:        metadata.Keywords,
+			FileTypes:   []string{FormatPDF},
+		},
+	}
+	if metadata.ISBN13 != "" {
+		draft.Book.ISBN13, _ = strconv.ParseInt(metadata.ISBN13, 10, 64)
+	}
+
+	return draft
+}
+
+// withWarnings - reports the missing required book details, which should be filled in during the review
+func (d Draft) withWarnings() Draft {
+	if d.Book.Title == "" {
+		d.Warnings = append(d.Warnings, "the title is missing")
+	}
+	if len(d.Book.Authors) == 0 {
+		d.Warnings = append(d.Warnings, "the authors are missing")
+	}
+	if d.Book.ISBN10 == "" && d.Book.ISBN13 == 0 {
+		d.Warnings = append(d.Warnings, "the ISBN is missing")
+	}
+	if d.Book.Publisher == "" {
+		d.Warnings = append(d.Warnings, "the publisher is missing")
+	}
+	if d.Book.PubDate.IsZero() {
+		d.Warnings = append(d.Warnings, "the publication date is missing")
+	}
+	if d.Cover == nil {
+		d.Warnings = append(d.Warnings, "the cover is missing")
+	}
+	if d.Warnings == nil {
+		d.Warnings = []string{}
+	}
+
+	return d
+}
+
+// LanguageName - converts the BCP 47 language tag to the English language name, e.g. 'en-US' -> 'English',
+// since the languages are stored by their names
+func LanguageName(code string) string {
+	if code == "" {
+		return ""
+	}
+	tag, err := language.Parse(code)
+	if err != nil {
+		return code
+	}
+	base, _ := tag.Base()
+
+	return display.English.Languages().Name(base)
+}
@@ -0,0 +1,229 @@
+package ingest
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/sdreger/lib-manager-go/internal/config"
+	"github.com/sdreger/lib-manager-go/internal/domain/cover"
+	"github.com/sdreger/lib-manager-go/internal/ingest/pdf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+)
+
+const (
+	testContainer = `<container><rootfiles><rootfile full-path="content.opf"/></rootfiles></container>`
+	testPackage   = `<package version="3.0" xmlns:dc="http://purl.org/dc/elements/1.1/">
+  <metadata>
+    <dc:title>Go in Action</dc:title>
+    <dc:creator>William Kennedy</dc:creator>
+    <dc:identifier>9781617291784</dc:identifier>
+    <dc:publisher>Manning</dc:publisher>
+    <dc:language>en-US</dc:language>
+    <dc:date>2015-11-04</dc:date>
+    <dc:description>A Go book</dc:description>
+    <dc:subject>Programming</dc:subject>
+  </metadata>
+  <manifest>
+    <item id="cover" href="cover.svg" media-type="image/svg+xml" properties="cover-image"/>
+  </manifest>
+</package>`
+	testCover = `<svg xmlns="http://www.w3.org/2000/svg" width="300" height="400"></svg>`
+)
+
+func TestService_Ingest_EPUB(t *testing.T) {
+	ctx := context.Background()
+	content := buildTestEPUB(t, map[string]string{
+		"META-INF/container.xml": testContainer,
+		"content.opf":            testPackage,
+		"cover.svg":              testCover,
+	})
+	storedCover := cover.Cover{Hash: "abc", Size: int64(len(testCover)), MIMEType: "image/svg+xml", Width: 300, Height: 400}
+
+	mockCoverService := NewMockCoverService(t)
+	mockCoverService.EXPECT().ExpireUnusedCovers(ctx, time.Hour).Return(1, nil).Once()
+	mockCoverService.EXPECT().StoreCover(ctx, mock.Anything).Return(storedCover, nil).Once()
+	service := getService(mockCoverService)
+
+	draft, err := service.Ingest(ctx, "/tmp/upload/Go.In.Action.EPUB", bytes.NewReader(content), int64(len(content)))
+	require.NoError(t, err, "should ingest EPUB")
+	assert.Equal(t, "epub", draft.Format)
+	assert.Equal(t, &storedCover, draft.Cover)
+	assert.Empty(t, draft.Warnings)
+
+	assert.Equal(t, "Go in Action", draft.Book.Title)
+	assert.Equal(t, []string{"William Kennedy"}, draft.Book.Authors)
+	assert.Equal(t, int64(9781617291784), draft.Book.ISBN13)
+	assert.Equal(t, "Manning", draft.Book.Publisher)
+	assert.Equal(t, "English", draft.Book.Language)
+	assert.Equal(t, time.Date(2015, 11, 4, 0, 0, 0, 0, time.UTC), draft.Book.PubDate)
+	assert.Equal(t, "A Go book", draft.Book.Description)
+	assert.Equal(t, []string{"Programming"}, draft.Book.Categories)
+	assert.Equal(t, []string{"epub"}, draft.Book.FileTypes)
+	assert.Equal(t, "Go.In.Action.EPUB", draft.Book.BookFileName)
+	assert.Equal(t, int64(len(content)), draft.Book.BookFileSize)
+	assert.Equal(t, "abc", draft.Book.CoverHash)
+	assert.Equal(t, 300, draft.Book.CoverWidth)
+	assert.Equal(t, 400, draft.Book.CoverHeight)
+}
+
+func TestService_Ingest_EPUB_Warnings(t *testing.T) {
+	ctx := context.Background()
+	content := buildTestEPUB(t, map[string]string{
+		"META-INF/container.xml": testContainer,
+		"content.opf":            `<package version="3.0"><metadata><title>Book</title></metadata></package>`,
+	})
+	service := getService(NewMockCoverService(t))
+
+	draft, err := service.Ingest(ctx, "book.epub", bytes.NewReader(content), int64(len(content)))
+	require.NoError(t, err, "should ingest EPUB")
+	assert.Nil(t, draft.Cover)
+	assert.Equal(t, []string{
+		"the authors are missing",
+		"the ISBN is missing",
+		"the publisher is missing",
+		"the publication date is missing",
+		"the cover is missing",
+	}, draft.Warnings)
+}
+
+func TestService_Ingest_InvalidCover(t *testing.T) {
+	ctx := context.Background()
+	content := buildTestEPUB(t, map[string]string{
+		"META-INF/container.xml": testContainer,
+		"content.opf":            testPackage,
+		"cover.svg":              "not an image",
+	})
+
+	mockCoverService := NewMockCoverService(t)
+	mockCoverService.EXPECT().ExpireUnusedCovers(ctx, time.Hour).Return(0, errors.New("db error")).Once()
+	mockCoverService.EXPECT().StoreCover(ctx, mock.Anything).Return(cover.Cover{}, cover.ErrUnsupportedType).Once()
+	service := getService(mockCoverService)
+
+	draft, err := service.Ingest(ctx, "book.epub", bytes.NewReader(content), int64(len(content)))
+	require.NoError(t, err, "an invalid cover should not fail the ingest")
+	assert.Nil(t, draft.Cover)
+	assert.Contains(t, draft.Warnings, "the embedded cover can not be stored: unsupported cover image type")
+}
+
+func TestService_Ingest_PDF(t *testing.T) {
+	ctx := context.Background()
+	content := buildTestPDF(t, "/Title (Learning Go) /Author (Jon Bodner) /Subject (Idiomatic Go) /Keywords (go, idioms)",
+		"")
+	service := getService(NewMockCoverService(t))
+
+	draft, err := service.Ingest(ctx, "Learning.Go.pdf", bytes.NewReader(content), int64(len(content)))
+	require.NoError(t, err, "should ingest PDF")
+	assert.Equal(t, "pdf", draft.Format)
+	assert.Equal(t, "Learning Go", draft.Book.Title)
+	assert.Equal(t, []string{"Jon Bodner"}, draft.Book.Authors)
+	assert.Equal(t, "Idiomatic Go", draft.Book.Description)
+	assert.Equal(t, []string{"go", "idioms"}, draft.Book.Tags)
+	assert.Equal(t, uint16(3), draft.Book.Pages)
+	assert.Equal(t, []string{"pdf"}, draft.Book.FileTypes)
+	assert.Equal(t, "Learning.Go.pdf", draft.Book.BookFileName)
+	assert.Equal(t, int64(len(content)), draft.Book.BookFileSize)
+	assert.Equal(t, []string{
+		"the ISBN is missing",
+		"the publisher is missing",
+		"the publication date is missing",
+		"the cover is missing",
+	}, draft.Warnings)
+}
+
+func TestService_Ingest_EncryptedPDF(t *testing.T) {
+	ctx := context.Background()
+	content := buildTestPDF(t, "/Title (encrypted)", "/Encrypt << /Filter /Standard >>")
+	service := getService(NewMockCoverService(t))
+
+	draft, err := service.Ingest(ctx, "book.pdf", bytes.NewReader(content), int64(len(content)))
+	require.NoError(t, err, "should ingest encrypted PDF")
+	assert.Empty(t, draft.Book.Title)
+	assert.Equal(t, uint16(3), draft.Book.Pages)
+	assert.Contains(t, draft.Warnings, "the document is encrypted, only the page count is extracted")
+}
+
+func TestService_Ingest_Errors(t *testing.T) {
+	ctx := context.Background()
+	service := getService(NewMockCoverService(t))
+
+	_, err := service.Ingest(ctx, "book.txt", bytes.NewReader(nil), 0)
+	assert.ErrorIs(t, err, ErrUnsupportedFormat)
+
+	_, err = service.Ingest(ctx, "book.epub", bytes.NewReader([]byte("text")), 4)
+	assert.ErrorIs(t, err, ErrInvalidFile)
+
+	_, err = service.Ingest(ctx, "book.pdf", bytes.NewReader([]byte("text")), 4)
+	assert.ErrorIs(t, err, ErrInvalidFile)
+}
+
+func TestNewPDFDraft_Pages(t *testing.T) {
+	assert.Equal(t, uint16(320), newPDFDraft(pdf.Metadata{PageCount: 320}).Book.Pages)
+	assert.Equal(t, uint16(32767), newPDFDraft(pdf.Metadata{PageCount: 40000}).Book.Pages)
+	assert.Equal(t, uint16(0), newPDFDraft(pdf.Metadata{PageCount: -1}).Book.Pages)
+}
+
+func TestLanguageName(t *testing.T) {
+	assert.Equal(t, "English", LanguageName("en"))
+	assert.Equal(t, "English", LanguageName("en-GB"))
+	assert.Equal(t, "German", LanguageName("de"))
+	assert.Equal(t, "", LanguageName(""))
+	assert.Equal(t, "not a language", LanguageName("not a language"))
+}
+
+func getService(coverService CoverService) *Service {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.Level(100)}))
+	service := NewService(logger, config.IngestConfig{DraftCoverTTL: time.Hour}, nil, nil)
+	service.coverService = coverService
+
+	return service
+}
+
+func buildTestEPUB(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	buffer := bytes.Buffer{}
+	writer := zip.NewWriter(&buffer)
+	for name, content := range files {
+		fileWriter, err := writer.Create(name)
+		require.NoError(t, err)
+		_, err = fileWriter.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+
+	return buffer.Bytes()
+}
+
+// buildTestPDF - builds a three-page PDF file with the provided document information and trailer entries
+func buildTestPDF(t *testing.T, info string, trailer string) []byte {
+	t.Helper()
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [] /Count 3 >>",
+		"<< " + info + " >>",
+	}
+	buffer := bytes.Buffer{}
+	buffer.WriteString("%PDF-1.4\n")
+	offsets := make([]int, 0, len(objects))
+	for i, object := range objects {
+		offsets = append(offsets, buffer.Len())
+		_, err := fmt.Fprintf(&buffer, "%d 0 obj\n%s\nendobj\n", i+1, object)
+		require.NoError(t, err)
+	}
+	xrefOffset := buffer.Len()
+	_, _ = fmt.Fprintf(&buffer, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		_, _ = fmt.Fprintf(&buffer, "%010d 00000 n \n", offset)
+	}
+	_, _ = fmt.Fprintf(&buffer, "trailer\n<< /Size %d /Root 1 0 R /Info 3 0 R %s >>\nstartxref\n%d\n%%%%EOF\n",
+		len(objects)+1, trailer, xrefOffset)
+
+	return buffer.Bytes()
+}
@@ -0,0 +1,8 @@
+package isbn
+
+import "errors"
+
+var (
+	ErrInvalid        = errors.New("invalid ISBN")
+	ErrNotConvertible = errors.New("the ISBN-13 has no ISBN-10 form")
+)
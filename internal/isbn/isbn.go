@@ -0,0 +1,141 @@
+// Package isbn validates, normalizes and converts the International Standard Book Numbers
+package isbn
+
+import (
+	"errors"
+	"strings"
+)
+
+const (
+	// bookland - the ISBN-13 prefix, the ISBN-10 values are converted with
+	bookland = "978"
+)
+
+var separatorReplacer = strings.NewReplacer("-", "", " ", "", "‐", "", "‑", "")
+
+// Normalize - removes the 'ISBN' / 'urn:isbn:' prefix and the separators,
+// e.g. 'ISBN-13: 978-1-61729-178-4' -> '9781617291784'. The value is not validated
+func Normalize(value string) string {
+	value = strings.ToUpper(strings.TrimSpace(value))
+	value = strings.TrimPrefix(value, "URN:")
+	if rest, ok := strings.CutPrefix(value, "ISBN"); ok {
+		rest = strings.TrimPrefix(rest, "-10")
+		rest = strings.TrimPrefix(rest, "-13")
+		value = strings.TrimLeft(rest, ": ")
+	}
+
+	return separatorReplacer.Replace(value)
+}
+
+// IsValid - reports whether the value is a valid ISBN-10 or ISBN-13, the value is normalized first
+func IsValid(value string) bool {
+	value = Normalize(value)
+	return IsValid10(value) || IsValid13(value)
+}
+
+// IsValid10 - verifies the format and the check digit of the normalized ISBN-10
+func IsValid10(isbn string) bool {
+	if len(isbn) != 10 {
+		return false
+	}
+
+	sum := 0
+	for i := 0; i < 10; i++ {
+		value, ok := digitValue(isbn[i], i == 9)
+		if !ok {
+			return false
+		}
+		sum += value * (10 - i)
+	}
+
+	return sum%11 == 0
+}
+
+// IsValid13 - verifies the format, the prefix and the check digit of the normalized ISBN-13
+func IsValid13(isbn string) bool {
+	if len(isbn) != 13 || !(strings.HasPrefix(isbn, "978") || strings.HasPrefix(isbn, "979")) {
+		return false
+	}
+
+	sum := 0
+	for i := 0; i < 13; i++ {
+		value, ok := digitValue(isbn[i], false)
+		if !ok {
+			return false
+		}
+		sum += value * (1 + 2*(i%2))
+	}
+
+	return sum%10 == 0
+}
+
+// To13 - converts the ISBN-10 to ISBN-13, a valid ISBN-13 is returned as is, e.g. '1617291781' -> '9781617291784'
+func To13(value string) (string, error) {
+	isbn := Normalize(value)
+	if IsValid13(isbn) {
+		return isbn, nil
+	}
+	if !IsValid10(isbn) {
+		return "", ErrInvalid
+	}
+
+	isbn = bookland + isbn[:9]
+	sum := 0
+	for i := 0; i < 12; i++ {
+		sum += int(isbn[i]-'0') * (1 + 2*(i%2))
+	}
+
+	return isbn + string(rune('0'+(10-sum%10)%10)), nil
+}
+
+// To10 - converts the ISBN-13 to ISBN-10, a valid ISBN-10 is returned as is, e.g. '9781617291784' -> '1617291781'.
+// Only the '978' prefixed values have the ISBN-10 form, ErrNotConvertible is returned for the other ones
+func To10(value string) (string, error) {
+	isbn := Normalize(value)
+	if IsValid10(isbn) {
+		return isbn, nil
+	}
+	if !IsValid13(isbn) {
+		return "", ErrInvalid
+	}
+	if !strings.HasPrefix(isbn, bookland) {
+		return "", ErrNotConvertible
+	}
+
+	isbn = isbn[3:12]
+	sum := 0
+	for i := 0; i < 9; i++ {
+		sum += int(isbn[i]-'0') * (10 - i)
+	}
+	check := (11 - sum%11) % 11
+	if check == 10 {
+		return isbn + "X", nil
+	}
+
+	return isbn + string(rune('0'+check)), nil
+}
+
+// Both - returns both forms of the value: the ISBN-10 one is empty for the '979' prefixed ISBN-13
+func Both(value string) (isbn10 string, isbn13 string, err error) {
+	isbn13, err = To13(value)
+	if err != nil {
+		return "", "", err
+	}
+	isbn10, err = To10(isbn13)
+	if err != nil && !errors.Is(err, ErrNotConvertible) {
+		return "", "", err
+	}
+
+	return isbn10, isbn13, nil
+}
+
+func digitValue(char byte, checkDigit bool) (int, bool) {
+	switch {
+	case char >= '0' && char <= '9':
+		return int(char - '0'), true
+	case char == 'X' && checkDigit:
+		return 10, true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,93 @@
+package isbn
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := map[string]string{
+		"978-1-61729-178-4":          "9781617291784",
+		"urn:isbn:978-1-61729-178-4": "9781617291784",
+		"ISBN-13: 978 1 61729 178 4": "9781617291784",
+		"ISBN-10: 0-8044-2957-x":     "080442957X",
+		" isbn 1617291781 ":          "1617291781",
+		"B00TEST123":                 "B00TEST123",
+	}
+	for value, expected := range tests {
+		assert.Equal(t, expected, Normalize(value), value)
+	}
+}
+
+func TestIsValid(t *testing.T) {
+	tests := map[string]bool{
+		"9781617291784":     true,
+		"978-1-61729-178-4": true,
+		"9791032305690":     true,
+		"1617291781":        true,
+		"080442957X":        true,
+		"0-8044-2957-x":     true,
+		"9781617291785":     false, // wrong check digit
+		"1617291782":        false, // wrong check digit
+		"9771617291784":     false, // not a book prefix
+		"X617291781":        false, // 'X' is the check digit only
+		"161729178":         false,
+		"":                  false,
+	}
+	for value, expected := range tests {
+		assert.Equal(t, expected, IsValid(value), value)
+	}
+}
+
+func TestTo13(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected string
+		err      error
+	}{
+		{value: "1617291781", expected: "9781617291784"},
+		{value: "0-8044-2957-X", expected: "9780804429573"},
+		{value: "1492077216", expected: "9781492077213"},
+		{value: "9781617291784", expected: "9781617291784"},
+		{value: "1617291782", err: ErrInvalid},
+	}
+	for _, tt := range tests {
+		isbn13, err := To13(tt.value)
+		assert.ErrorIs(t, err, tt.err, tt.value)
+		assert.Equal(t, tt.expected, isbn13, tt.value)
+	}
+}
+
+func TestTo10(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected string
+		err      error
+	}{
+		{value: "9781617291784", expected: "1617291781"},
+		{value: "978-0-8044-2957-3", expected: "080442957X"},
+		{value: "1617291781", expected: "1617291781"},
+		{value: "9791032305690", err: ErrNotConvertible},
+		{value: "9781617291785", err: ErrInvalid},
+	}
+	for _, tt := range tests {
+		isbn10, err := To10(tt.value)
+		assert.ErrorIs(t, err, tt.err, tt.value)
+		assert.Equal(t, tt.expected, isbn10, tt.value)
+	}
+}
+
+func TestBoth(t *testing.T) {
+	isbn10, isbn13, err := Both("161729178-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "1617291781", isbn10)
+	assert.Equal(t, "9781617291784", isbn13)
+
+	isbn10, isbn13, err = Both("9791032305690")
+	assert.NoError(t, err)
+	assert.Empty(t, isbn10)
+	assert.Equal(t, "9791032305690", isbn13)
+
+	_, _, err = Both("123")
+	assert.ErrorIs(t, err, ErrInvalid)
+}
@@ -0,0 +1,11 @@
+package metadata
+
+import "errors"
+
+var (
+	ErrNotFound         = errors.New("no metadata found")
+	ErrProviderFailure  = errors.New("metadata provider failure")
+	ErrProviderDisabled = errors.New("metadata provider is not configured")
+	ErrUnknownField     = errors.New("unknown metadata field")
+	ErrCacheMiss        = errors.New("metadata cache miss")
+)
@@ -0,0 +1,137 @@
+package metadata
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const maxResponseSize = 1 << 20
+
+// Provider - the external book metadata catalog
+type Provider interface {
+	// Name - the provider name, the cache entries are kept per provider
+	Name() string
+	// Lookup - returns the best matching record, or ErrNotFound
+	Lookup(ctx context.Context, query Query) (Record, error)
+}
+
+// HTTPProvider - the JSON catalog client, it requests the 'GET {baseURL}/books' endpoint with one of the 'isbn',
+// 'asin' or 'title' and 'author' query parameters. The endpoint responds with a single Record object,
+// or with the 404 status if nothing is found
+type HTTPProvider struct {
+	baseURL string
+	client  *http.Client
+}
+
+func NewHTTPProvider(baseURL string, timeout time.Duration) *HTTPProvider {
+	return &HTTPProvider{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		client:  &http.Client{Timeout: timeout},
+	}
+}
+
+func (p *HTTPProvider) Name() string {
+	return p.baseURL
+}
+
+func (p *HTTPProvider) Lookup(ctx context.Context, query Query) (Record, error) {
+	values := url.Values{}
+	switch {
+	case query.ISBN != "":
+		values.Set("isbn", query.ISBN)
+	case query.ASIN != "":
+		values.Set("asin", query.ASIN)
+	default:
+		values.Set("title", query.Title)
+		if query.Author != "" {
+			values.Set("author", query.Author)
+		}
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, p.baseURL+"/books?"+values.Encode(), nil)
+	if err != nil {
+		return Record{}, err
+	}
+	request.Header.Set("Accept", "application/json")
+
+	response, err := p.client.Do(request)
+	if err != nil {
+		return Record{}, errors.Join(ErrProviderFailure, err)
+	}
+	defer response.Body.Close()
+
+	switch response.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return Record{}, ErrNotFound
+	default:
+		return Record{}, fmt.Errorf("%w: unexpected response status %d", ErrProviderFailure, response.StatusCode)
+	}
+
+	var record Record
+	if err := json.NewDecoder(io.LimitReader(response.Body, maxResponseSize)).Decode(&record); err != nil {
+		return Record{}, errors.Join(ErrProviderFailure, err)
+	}
+
+	return record, nil
+}
+
+// Cache - the provider response cache
+type Cache interface {
+	Get(ctx context.Context, provider string, lookupKey string, maxAge time.Duration) (*Record, error)
+	Put(ctx context.Context, provider string, lookupKey string, record *Record) error
+}
+
+// CachedProvider - caches the provider records and the 'not found' responses for the TTL. The provider failures
+// are not cached, and the cache failures only disable the caching
+type CachedProvider struct {
+	logger   *slog.Logger
+	provider Provider
+	cache    Cache
+	ttl      time.Duration
+}
+
+func NewCachedProvider(logger *slog.Logger, provider Provider, cache Cache, ttl time.Duration) *CachedProvider {
+	return &CachedProvider{logger: logger, provider: provider, cache: cache, ttl: ttl}
+}
+
+func (p *CachedProvider) Name() string {
+	return p.provider.Name()
+}
+
+func (p *CachedProvider) Lookup(ctx context.Context, query Query) (Record, error) {
+	name, key := p.provider.Name(), query.key()
+	cached, err := p.cache.Get(ctx, name, key, p.ttl)
+	switch {
+	case err == nil && cached == nil:
+		return Record{}, ErrNotFound
+	case err == nil:
+		return *cached, nil
+	case !errors.Is(err, ErrCacheMiss):
+		p.logger.Warn("metadata cache read failed", "provider", name, "key", key, "error", err)
+	}
+
+	record, err := p.provider.Lookup(ctx, query)
+	switch {
+	case err == nil:
+		p.put(ctx, name, key, &record)
+	case errors.Is(err, ErrNotFound):
+		p.put(ctx, name, key, nil)
+	}
+
+	return record, err
+}
+
+func (p *CachedProvider) put(ctx context.Context, name string, key string, record *Record) {
+	if err := p.cache.Put(ctx, name, key, record); err != nil {
+		p.logger.Warn("metadata cache write failed", "provider", name, "key", key, "error", err)
+	}
+}
@@ -0,0 +1,151 @@
+package metadata
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+)
+
+const (
+	testISBN13   = "9781617291784"
+	testProvider = "http://catalog.local"
+	testTTL      = time.Hour
+)
+
+// newTestCatalog - the local catalog stand-in, it knows a single book by its ISBN, ASIN, and title with author
+func newTestCatalog(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		switch {
+		case r.URL.Path != "/api/books":
+			w.WriteHeader(http.StatusBadRequest)
+		case query.Get("isbn") == "0000000000000":
+			w.WriteHeader(http.StatusInternalServerError)
+		case query.Get("isbn") == testISBN13, query.Get("asin") == "B00TEST000",
+			query.Get("title") == "Go in Action" && query.Get("author") == "John Doe":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"title": "Go in Action", "subtitle": "The Definitive Guide",
+				"pages": 264, "pub_date": "2015-11", "categories": ["Programming", "Go"], "isbn13": "9781617291784"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestHTTPProvider_Lookup(t *testing.T) {
+	server := newTestCatalog(t)
+	provider := NewHTTPProvider(server.URL+"/api/", time.Second)
+	assert.Equal(t, server.URL+"/api", provider.Name())
+
+	for _, query := range []Query{
+		{ISBN: testISBN13},
+		{ASIN: "B00TEST000"},
+		{Title: "Go in Action", Author: "John Doe"},
+	} {
+		record, err := provider.Lookup(context.Background(), query)
+		require.NoError(t, err, "should find record by %v", query)
+		assert.Equal(t, Record{Title: "Go in Action", Subtitle: "The Definitive Guide", Pages: 264,
+			PubDate: "2015-11", Categories: []string{"Programming", "Go"}, ISBN13: testISBN13}, record)
+	}
+}
+
+func TestHTTPProvider_Lookup_NotFound(t *testing.T) {
+	provider := NewHTTPProvider(newTestCatalog(t).URL+"/api", time.Second)
+
+	_, err := provider.Lookup(context.Background(), Query{ISBN: "9781492077213"})
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestHTTPProvider_Lookup_Failure(t *testing.T) {
+	provider := NewHTTPProvider(newTestCatalog(t).URL+"/api", time.Second)
+	_, err := provider.Lookup(context.Background(), Query{ISBN: "0000000000000"})
+	assert.ErrorIs(t, err, ErrProviderFailure, "should fail on the unexpected status")
+
+	provider = NewHTTPProvider("http://127.0.0.1:0", time.Second)
+	_, err = provider.Lookup(context.Background(), Query{ISBN: testISBN13})
+	assert.ErrorIs(t, err, ErrProviderFailure, "should fail on the connection error")
+}
+
+func TestCachedProvider_Lookup_Hit(t *testing.T) {
+	ctx := context.Background()
+	record := Record{Title: "Go in Action"}
+	mockProvider := NewMockProvider(t)
+	mockProvider.EXPECT().Name().Return(testProvider)
+	mockCache := NewMockCache(t)
+	mockCache.EXPECT().Get(ctx, testProvider, "isbn:"+testISBN13, testTTL).Return(&record, nil).Once()
+
+	found, err := getCachedProvider(mockProvider, mockCache).Lookup(ctx, Query{ISBN: testISBN13})
+	require.NoError(t, err, "should return the cached record")
+	assert.Equal(t, record, found)
+}
+
+func TestCachedProvider_Lookup_CachedNotFound(t *testing.T) {
+	ctx := context.Background()
+	mockProvider := NewMockProvider(t)
+	mockProvider.EXPECT().Name().Return(testProvider)
+	mockCache := NewMockCache(t)
+	mockCache.EXPECT().Get(ctx, testProvider, "asin:B00TEST000", testTTL).Return(nil, nil).Once()
+
+	_, err := getCachedProvider(mockProvider, mockCache).Lookup(ctx, Query{ASIN: "b00test000"})
+	assert.ErrorIs(t, err, ErrNotFound, "should return the cached 'not found' response")
+}
+
+func TestCachedProvider_Lookup_Miss(t *testing.T) {
+	ctx := context.Background()
+	record := Record{Title: "Go in Action"}
+	query := Query{Title: "Go in Action", Author: "John Doe"}
+	lookupKey := "title:go in action|author:john doe"
+	mockProvider := NewMockProvider(t)
+	mockProvider.EXPECT().Name().Return(testProvider)
+	mockProvider.EXPECT().Lookup(ctx, query).Return(record, nil).Once()
+	mockCache := NewMockCache(t)
+	mockCache.EXPECT().Get(ctx, testProvider, lookupKey, testTTL).Return(nil, ErrCacheMiss).Once()
+	mockCache.EXPECT().Put(ctx, testProvider, lookupKey, &record).Return(nil).Once()
+
+	found, err := getCachedProvider(mockProvider, mockCache).Lookup(ctx, query)
+	require.NoError(t, err, "should return the provider record")
+	assert.Equal(t, record, found)
+}
+
+func TestCachedProvider_Lookup_MissNotFound(t *testing.T) {
+	ctx := context.Background()
+	query := Query{ISBN: testISBN13}
+	mockProvider := NewMockProvider(t)
+	mockProvider.EXPECT().Name().Return(testProvider)
+	mockProvider.EXPECT().Lookup(ctx, query).Return(Record{}, ErrNotFound).Once()
+	mockCache := NewMockCache(t)
+	mockCache.EXPECT().Get(ctx, testProvider, "isbn:"+testISBN13, testTTL).Return(nil, ErrCacheMiss).Once()
+	mockCache.EXPECT().Put(ctx, testProvider, "isbn:"+testISBN13, (*Record)(nil)).Return(nil).Once()
+
+	_, err := getCachedProvider(mockProvider, mockCache).Lookup(ctx, query)
+	assert.ErrorIs(t, err, ErrNotFound, "the 'not found' response should be cached")
+}
+
+func TestCachedProvider_Lookup_Failure(t *testing.T) {
+	ctx := context.Background()
+	query := Query{ISBN: testISBN13}
+	mockProvider := NewMockProvider(t)
+	mockProvider.EXPECT().Name().Return(testProvider)
+	mockProvider.EXPECT().Lookup(ctx, query).Return(Record{}, ErrProviderFailure).Once()
+	mockCache := NewMockCache(t)
+	mockCache.EXPECT().Get(ctx, testProvider, "isbn:"+testISBN13, testTTL).
+		Return(nil, errors.New("connection refused")).Once()
+
+	_, err := getCachedProvider(mockProvider, mockCache).Lookup(ctx, query)
+	assert.ErrorIs(t, err, ErrProviderFailure, "the provider failure should not be cached")
+}
+
+func getCachedProvider(provider Provider, cache Cache) *CachedProvider {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	return NewCachedProvider(logger, provider, cache, testTTL)
+}
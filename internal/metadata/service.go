@@ -0,0 +1,214 @@
+package metadata
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"github.com/sdreger/lib-manager-go/internal/config"
+	"github.com/sdreger/lib-manager-go/internal/domain/book"
+	"github.com/sdreger/lib-manager-go/internal/isbn"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const dateLayout = time.DateOnly
+
+type BookService interface {
+	GetBookByID(ctx context.Context, bookID int64) (book.Book, error)
+	UpdateBook(ctx context.Context, bookID int64, update book.Update) (book.Book, error)
+}
+
+type Service struct {
+	logger      *slog.Logger
+	provider    Provider
+	bookService BookService
+}
+
+// NewService - creates the enrichment service, backed by the cached HTTP provider.
+// The enrichment is disabled if the provider URL is not configured
+func NewService(logger *slog.Logger, db *sqlx.DB, cfg config.MetadataConfig) *Service {
+	service := &Service{
+		logger:      logger,
+		bookService: book.NewService(logger, db),
+	}
+	if cfg.ProviderURL != "" {
+		service.provider = NewCachedProvider(logger, NewHTTPProvider(cfg.ProviderURL, cfg.Timeout),
+			NewDBStore(db), cfg.CacheTTL)
+	}
+
+	return service
+}
+
+// Enrich - looks the book up in the provider, and returns the field-by-field diff between the book and the found
+// record. Only the accepted fields are applied to the book, so the empty accept list just previews the diff
+func (s *Service) Enrich(ctx context.Context, bookID int64, accept []string) (Enrichment, error) {
+	for _, field := range accept {
+		if !slices.Contains(Fields, field) {
+			return Enrichment{}, fmt.Errorf("%w: %q", ErrUnknownField, field)
+		}
+	}
+	if s.provider == nil {
+		return Enrichment{}, ErrProviderDisabled
+	}
+
+	bookEntry, err := s.bookService.GetBookByID(ctx, bookID)
+	if err != nil {
+		return Enrichment{}, err
+	}
+	record, err := s.lookup(ctx, bookEntry)
+	if err != nil {
+		return Enrichment{}, err
+	}
+
+	changes := diff(bookEntry, record)
+	var update book.Update
+	var applied []string
+	for i, change := range changes {
+		if slices.Contains(accept, change.Field) {
+			applyChange(&update, record, change.Field)
+			changes[i].Applied = true
+			applied = append(applied, change.Field)
+		}
+	}
+	if len(applied) > 0 {
+		bookEntry, err = s.bookService.UpdateBook(ctx, bookID, update)
+		if err != nil {
+			return Enrichment{}, err
+		}
+		s.logger.Info("book enriched", "bookID", bookID, "provider", s.provider.Name(), "fields", applied)
+	}
+
+	return Enrichment{Provider: s.provider.Name(), Record: record, Changes: changes, Book: bookEntry}, nil
+}
+
+// lookup - tries the book identifiers first, then the title with the first author
+func (s *Service) lookup(ctx context.Context, bookEntry book.Book) (Record, error) {
+	for _, query := range queries(bookEntry) {
+		record, err := s.provider.Lookup(ctx, query)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+
+		return record, err
+	}
+
+	return Record{}, ErrNotFound
+}
+
+func queries(bookEntry book.Book) []Query {
+	var result []Query
+	if bookEntry.ISBN13 != 0 {
+		result = append(result, Query{ISBN: strconv.FormatInt(bookEntry.ISBN13, 10)})
+	} else if isbn13, err := isbn.To13(bookEntry.ISBN10); err == nil {
+		result = append(result, Query{ISBN: isbn13})
+	}
+	if bookEntry.ASIN != "" {
+		result = append(result, Query{ASIN: bookEntry.ASIN})
+	}
+	if bookEntry.Title != "" {
+		query := Query{Title: bookEntry.Title}
+		if len(bookEntry.Authors) > 0 {
+			query.Author = bookEntry.Authors[0]
+		}
+		result = append(result, query)
+	}
+
+	return result
+}
+
+// diff - returns the changes of the fields the record provides, and which differ from the book ones
+func diff(bookEntry book.Book, record Record) []Change {
+	changes := make([]Change, 0, len(Fields))
+	subtitle, description := strings.TrimSpace(record.Subtitle), strings.TrimSpace(record.Description)
+	if subtitle != "" && subtitle != bookEntry.Subtitle {
+		changes = append(changes, Change{Field: FieldSubtitle, Current: bookEntry.Subtitle, Proposed: subtitle})
+	}
+	if description != "" && description != bookEntry.Description {
+		changes = append(changes,
+			Change{Field: FieldDescription, Current: bookEntry.Description, Proposed: description})
+	}
+	if pages, ok := recordPages(record); ok && pages != bookEntry.Pages {
+		changes = append(changes, Change{Field: FieldPages, Current: bookEntry.Pages, Proposed: pages})
+	}
+	if pubDate, ok := recordPubDate(record); ok && pubDate.Format(dateLayout) != bookEntry.PubDate.Format(dateLayout) {
+		changes = append(changes, Change{Field: FieldPubDate, Current: bookEntry.PubDate.Format(dateLayout),
+			Proposed: pubDate.Format(dateLayout)})
+	}
+	if categories := recordCategories(record); len(categories) > 0 && !sameNames(categories, bookEntry.Categories) {
+		changes = append(changes,
+			Change{Field: FieldCategories, Current: bookEntry.Categories, Proposed: categories})
+	}
+
+	return changes
+}
+
+func applyChange(update *book.Update, record Record, field string) {
+	switch field {
+	case FieldSubtitle:
+		subtitle := strings.TrimSpace(record.Subtitle)
+		update.Subtitle = &subtitle
+	case FieldDescription:
+		description := strings.TrimSpace(record.Description)
+		update.Description = &description
+	case FieldPages:
+		pages, _ := recordPages(record)
+		update.Pages = &pages
+	case FieldPubDate:
+		pubDate, _ := recordPubDate(record)
+		update.PubDate = &pubDate
+	case FieldCategories:
+		update.Categories = recordCategories(record)
+	}
+}
+
+func recordPages(record Record) (uint16, bool) {
+	if record.Pages <= 0 || record.Pages > 1<<16-1 {
+		return 0, false
+	}
+
+	return uint16(record.Pages), true
+}
+
+// recordPubDate - parses the full or the partial publication date, the missing parts default to the first ones
+func recordPubDate(record Record) (time.Time, bool) {
+	for _, layout := range []string{time.DateOnly, "2006-01", "2006"} {
+		if pubDate, err := time.Parse(layout, strings.TrimSpace(record.PubDate)); err == nil {
+			return pubDate, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+func recordCategories(record Record) []string {
+	categories := make([]string, 0, len(record.Categories))
+	for _, category := range record.Categories {
+		category = strings.TrimSpace(category)
+		if category != "" && !slices.ContainsFunc(categories, func(name string) bool {
+			return strings.EqualFold(name, category)
+		}) {
+			categories = append(categories, category)
+		}
+	}
+
+	return categories
+}
+
+// sameNames - compares the name sets case-insensitively
+func sameNames(first []string, second []string) bool {
+	normalize := func(names []string) []string {
+		result := make([]string, len(names))
+		for i, name := range names {
+			result[i] = strings.ToLower(name)
+		}
+		slices.Sort(result)
+
+		return slices.Compact(result)
+	}
+
+	return slices.Equal(normalize(first), normalize(second))
+}
@@ -0,0 +1,185 @@
+package metadata
+
+import (
+	"context"
+	"github.com/sdreger/lib-manager-go/internal/config"
+	"github.com/sdreger/lib-manager-go/internal/domain/book"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+)
+
+const testBookID = int64(1)
+
+func TestService_Enrich_Preview(t *testing.T) {
+	ctx := context.Background()
+	service := getService()
+	testBook := getTestBook()
+
+	mockBookService := NewMockBookService(t)
+	mockBookService.EXPECT().GetBookByID(ctx, testBookID).Return(testBook, nil).Once()
+	mockProvider := NewMockProvider(t)
+	mockProvider.EXPECT().Name().Return(testProvider)
+	mockProvider.EXPECT().Lookup(ctx, Query{ISBN: testISBN13}).Return(getTestRecord(), nil).Once()
+	injectMocks(service, mockProvider, mockBookService)
+
+	enrichment, err := service.Enrich(ctx, testBookID, nil)
+	require.NoError(t, err, "should preview the enrichment")
+	assert.Equal(t, testProvider, enrichment.Provider)
+	assert.Equal(t, getTestRecord(), enrichment.Record)
+	assert.Equal(t, testBook, enrichment.Book, "the book should not be changed")
+	assert.Equal(t, []Change{
+		{Field: FieldSubtitle, Current: "", Proposed: "The Definitive Guide"},
+		{Field: FieldPages, Current: uint16(250), Proposed: uint16(264)},
+		{Field: FieldPubDate, Current: "2015-01-01", Proposed: "2015-11-01"},
+		{Field: FieldCategories, Current: []string{"Programming"}, Proposed: []string{"Programming", "Go"}},
+	}, enrichment.Changes, "the equal and the missing fields should be skipped")
+}
+
+func TestService_Enrich_Accept(t *testing.T) {
+	ctx := context.Background()
+	service := getService()
+	testBook := getTestBook()
+	subtitle := "The Definitive Guide"
+	pages := uint16(264)
+	updatedBook := testBook
+	updatedBook.Subtitle = subtitle
+	updatedBook.Pages = pages
+
+	mockBookService := NewMockBookService(t)
+	mockBookService.EXPECT().GetBookByID(ctx, testBookID).Return(testBook, nil).Once()
+	mockBookService.EXPECT().UpdateBook(ctx, testBookID, book.Update{Subtitle: &subtitle, Pages: &pages}).
+		Return(updatedBook, nil).Once()
+	mockProvider := NewMockProvider(t)
+	mockProvider.EXPECT().Name().Return(testProvider)
+	mockProvider.EXPECT().Lookup(ctx, Query{ISBN: testISBN13}).Return(getTestRecord(), nil).Once()
+	injectMocks(service, mockProvider, mockBookService)
+
+	enrichment, err := service.Enrich(ctx, testBookID, []string{FieldSubtitle, FieldPages, FieldDescription})
+	require.NoError(t, err, "should apply the accepted fields")
+	assert.Equal(t, updatedBook, enrichment.Book)
+	for _, change := range enrichment.Changes {
+		assert.Equal(t, change.Field == FieldSubtitle || change.Field == FieldPages, change.Applied, change.Field)
+	}
+}
+
+func TestService_Enrich_Fallback(t *testing.T) {
+	ctx := context.Background()
+	service := getService()
+	testBook := getTestBook()
+	testBook.ISBN13 = 0
+	testBook.ISBN10 = "1617291781"
+	testBook.ASIN = "B00TEST000"
+
+	mockBookService := NewMockBookService(t)
+	mockBookService.EXPECT().GetBookByID(ctx, testBookID).Return(testBook, nil).Once()
+	mockProvider := NewMockProvider(t)
+	mockProvider.EXPECT().Name().Return(testProvider)
+	mockProvider.EXPECT().Lookup(ctx, Query{ISBN: testISBN13}).Return(Record{}, ErrNotFound).Once()
+	mockProvider.EXPECT().Lookup(ctx, Query{ASIN: "B00TEST000"}).Return(Record{}, ErrNotFound).Once()
+	mockProvider.EXPECT().Lookup(ctx, Query{Title: "Go in Action", Author: "John Doe"}).
+		Return(getTestRecord(), nil).Once()
+	injectMocks(service, mockProvider, mockBookService)
+
+	enrichment, err := service.Enrich(ctx, testBookID, nil)
+	require.NoError(t, err, "should find the record by the title")
+	assert.NotEmpty(t, enrichment.Changes)
+}
+
+func TestService_Enrich_NotFound(t *testing.T) {
+	ctx := context.Background()
+	service := getService()
+
+	mockBookService := NewMockBookService(t)
+	mockBookService.EXPECT().GetBookByID(ctx, testBookID).Return(getTestBook(), nil).Once()
+	mockProvider := NewMockProvider(t)
+	mockProvider.EXPECT().Lookup(ctx, mock.Anything).Return(Record{}, ErrNotFound).Twice()
+	injectMocks(service, mockProvider, mockBookService)
+
+	_, err := service.Enrich(ctx, testBookID, nil)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestService_Enrich_ProviderFailure(t *testing.T) {
+	ctx := context.Background()
+	service := getService()
+
+	mockBookService := NewMockBookService(t)
+	mockBookService.EXPECT().GetBookByID(ctx, testBookID).Return(getTestBook(), nil).Once()
+	mockProvider := NewMockProvider(t)
+	mockProvider.EXPECT().Lookup(ctx, Query{ISBN: testISBN13}).Return(Record{}, ErrProviderFailure).Once()
+	injectMocks(service, mockProvider, mockBookService)
+
+	_, err := service.Enrich(ctx, testBookID, nil)
+	assert.ErrorIs(t, err, ErrProviderFailure, "should not fall back on the provider failure")
+}
+
+func TestService_Enrich_UnknownField(t *testing.T) {
+	service := getService()
+	injectMocks(service, NewMockProvider(t), NewMockBookService(t))
+
+	_, err := service.Enrich(context.Background(), testBookID, []string{FieldPages, "title"})
+	assert.ErrorIs(t, err, ErrUnknownField)
+}
+
+func TestService_Enrich_Disabled(t *testing.T) {
+	service := getService()
+
+	_, err := service.Enrich(context.Background(), testBookID, nil)
+	assert.ErrorIs(t, err, ErrProviderDisabled)
+}
+
+func TestRecordPubDate(t *testing.T) {
+	tests := map[string]time.Time{
+		"2015-11-20": time.Date(2015, 11, 20, 0, 0, 0, 0, time.UTC),
+		"2015-11":    time.Date(2015, 11, 1, 0, 0, 0, 0, time.UTC),
+		"2015":       time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	for value, expected := range tests {
+		pubDate, ok := recordPubDate(Record{PubDate: value})
+		assert.True(t, ok, value)
+		assert.Equal(t, expected, pubDate, value)
+	}
+
+	_, ok := recordPubDate(Record{PubDate: "November 2015"})
+	assert.False(t, ok, "should skip the unknown date format")
+}
+
+func getService() *Service {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	return NewService(logger, nil, config.MetadataConfig{})
+}
+
+func injectMocks(service *Service, provider Provider, bookService BookService) {
+	service.provider = provider
+	service.bookService = bookService
+}
+
+func getTestBook() book.Book {
+	return book.Book{
+		ID:          testBookID,
+		Title:       "Go in Action",
+		Description: "Go in Action introduces the Go language",
+		ISBN13:      9781617291784,
+		Pages:       250,
+		PubDate:     time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC),
+		Authors:     []string{"John Doe", "Amanda Lee"},
+		Categories:  []string{"Programming"},
+	}
+}
+
+func getTestRecord() Record {
+	return Record{
+		Title:       "Go in Action",
+		Subtitle:    "The Definitive Guide",
+		Description: "Go in Action introduces the Go language",
+		Pages:       264,
+		PubDate:     "2015-11",
+		Categories:  []string{"Programming", "Go", "programming"},
+		ISBN13:      testISBN13,
+	}
+}
@@ -0,0 +1,66 @@
+package metadata
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"github.com/jmoiron/sqlx"
+	"time"
+)
+
+type DBStore struct {
+	db *sqlx.DB
+}
+
+func NewDBStore(db *sqlx.DB) *DBStore {
+	return &DBStore{db: db}
+}
+
+// Get - returns the cached provider record, fetched within the max age. The nil record means the cached 'not found'
+// response. Returns ErrCacheMiss if there is no fresh cache entry
+func (s *DBStore) Get(ctx context.Context, provider string, lookupKey string, maxAge time.Duration) (*Record, error) {
+	query := `SELECT record
+FROM ebook.metadata_cache
+WHERE provider = $1
+  AND lookup_key = $2
+  AND fetched_at > now() - make_interval(secs => $3)`
+	var data []byte
+	if err := s.db.GetContext(ctx, &data, query, provider, lookupKey, maxAge.Seconds()); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrCacheMiss
+		}
+
+		return nil, err
+	}
+	if data == nil {
+		return nil, nil
+	}
+
+	var record Record
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, err
+	}
+
+	return &record, nil
+}
+
+// Put - stores the provider record in the cache, the nil record stands for the 'not found' response
+func (s *DBStore) Put(ctx context.Context, provider string, lookupKey string, record *Record) error {
+	var data sql.NullString
+	if record != nil {
+		encoded, err := json.Marshal(record)
+		if err != nil {
+			return err
+		}
+		data = sql.NullString{String: string(encoded), Valid: true}
+	}
+
+	query := `INSERT INTO ebook.metadata_cache (provider, lookup_key, record, fetched_at)
+VALUES ($1, $2, $3, now())
+ON CONFLICT (provider, lookup_key) DO UPDATE SET record     = excluded.record,
+                                                 fetched_at = excluded.fetched_at`
+	_, err := s.db.ExecContext(ctx, query, provider, lookupKey, data)
+
+	return err
+}
@@ -0,0 +1,80 @@
+package metadata
+
+import (
+	"context"
+	"github.com/jmoiron/sqlx"
+	"github.com/sdreger/lib-manager-go/internal/tests"
+	"github.com/stretchr/testify/suite"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"testing"
+)
+
+type TestStoreSuite struct {
+	suite.Suite
+	db            *sqlx.DB
+	testContainer *postgres.PostgresContainer
+	store         *DBStore
+}
+
+func (s *TestStoreSuite) SetupSuite() {
+	testContainer := tests.StartDBTestContainer(s.T())
+	dbConfig := tests.GetTestDBConfig(s.T(), testContainer)
+	connection := tests.SetUpTestDB(s.Suite.Require(), dbConfig, testContainer)
+
+	s.store = NewDBStore(connection)
+	s.db = connection
+	s.testContainer = testContainer
+}
+
+func (s *TestStoreSuite) SetupTest() {
+	ctx := context.Background()
+	err := s.testContainer.Restore(ctx)
+	s.Require().NoError(err)
+}
+
+func (s *TestStoreSuite) TearDownSuite() {
+	err := s.db.Close()
+	s.Require().NoError(err, "failed to close database connection")
+}
+
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(TestStoreSuite))
+}
+
+// -------------------- Tests --------------------
+
+func (s *TestStoreSuite) Test_PutGet() {
+	ctx := context.Background()
+	record := Record{Title: "Go in Action", Pages: 264, Categories: []string{"Programming"}}
+
+	_, err := s.store.Get(ctx, testProvider, "isbn:"+testISBN13, testTTL)
+	s.Require().ErrorIs(err, ErrCacheMiss)
+
+	s.Require().NoError(s.store.Put(ctx, testProvider, "isbn:"+testISBN13, &record))
+	cached, err := s.store.Get(ctx, testProvider, "isbn:"+testISBN13, testTTL)
+	s.Require().NoError(err)
+	s.Equal(&record, cached)
+
+	_, err = s.store.Get(ctx, "http://other.local", "isbn:"+testISBN13, testTTL)
+	s.Require().ErrorIs(err, ErrCacheMiss, "the entries should be kept per provider")
+}
+
+func (s *TestStoreSuite) Test_PutGet_NotFound() {
+	ctx := context.Background()
+
+	s.Require().NoError(s.store.Put(ctx, testProvider, "asin:B00TEST000", &Record{Title: "Outdated"}))
+	s.Require().NoError(s.store.Put(ctx, testProvider, "asin:B00TEST000", nil))
+	cached, err := s.store.Get(ctx, testProvider, "asin:B00TEST000", testTTL)
+	s.Require().NoError(err)
+	s.Nil(cached, "the 'not found' response should replace the record")
+}
+
+func (s *TestStoreSuite) Test_Get_Expired() {
+	ctx := context.Background()
+	s.Require().NoError(s.store.Put(ctx, testProvider, "isbn:"+testISBN13, &Record{Title: "Go in Action"}))
+	_, err := s.db.ExecContext(ctx, "UPDATE ebook.metadata_cache SET fetched_at = now() - INTERVAL '2 hours'")
+	s.Require().NoError(err)
+
+	_, err = s.store.Get(ctx, testProvider, "isbn:"+testISBN13, testTTL)
+	s.Require().ErrorIs(err, ErrCacheMiss, "the expired entry should be skipped")
+}
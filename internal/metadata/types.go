@@ -0,0 +1,70 @@
+package metadata
+
+import (
+	"github.com/sdreger/lib-manager-go/internal/domain/book"
+	"strings"
+)
+
+const (
+	FieldSubtitle    = "subtitle"
+	FieldDescription = "description"
+	FieldPages       = "pages"
+	FieldPubDate     = "pub_date"
+	FieldCategories  = "categories"
+)
+
+// Fields - the book fields, which can be enriched, in the diff order
+var Fields = []string{FieldSubtitle, FieldDescription, FieldPages, FieldPubDate, FieldCategories}
+
+// Query - the provider lookup parameters, the first non-empty identifier is used, then the title and the author
+type Query struct {
+	ISBN   string
+	ASIN   string
+	Title  string
+	Author string
+}
+
+// key - the provider-independent cache key of the query
+func (q Query) key() string {
+	switch {
+	case q.ISBN != "":
+		return "isbn:" + q.ISBN
+	case q.ASIN != "":
+		return "asin:" + strings.ToUpper(q.ASIN)
+	default:
+		return "title:" + strings.ToLower(q.Title) + "|author:" + strings.ToLower(q.Author)
+	}
+}
+
+// Record - the book metadata, returned by a provider. The publication date is a 'YYYY-MM-DD', 'YYYY-MM' or 'YYYY'
+// string, the way the catalogs usually provide it
+type Record struct {
+	Title       string   `json:"title"`
+	Subtitle    string   `json:"subtitle"`
+	Description string   `json:"description"`
+	Pages       int      `json:"pages"`
+	Publisher   string   `json:"publisher"`
+	PubDate     string   `json:"pub_date"`
+	Language    string   `json:"language"`
+	Authors     []string `json:"authors"`
+	Categories  []string `json:"categories"`
+	ISBN10      string   `json:"isbn10"`
+	ISBN13      string   `json:"isbn13"`
+	ASIN        string   `json:"asin"`
+}
+
+// Change - the difference between the current book field value and the one proposed by the provider
+type Change struct {
+	Field    string `json:"field"`
+	Current  any    `json:"current"`
+	Proposed any    `json:"proposed"`
+	Applied  bool   `json:"applied"`
+}
+
+// Enrichment - the enrichment result: the provider record, the field-by-field diff, and the resulting book
+type Enrichment struct {
+	Provider string    `json:"provider"`
+	Record   Record    `json:"record"`
+	Changes  []Change  `json:"changes"`
+	Book     book.Book `json:"book"`
+}
@@ -0,0 +1,81 @@
+package middleware
+
+import (
+	"context"
+	"github.com/sdreger/lib-manager-go/cmd/api/handlers"
+	"github.com/sdreger/lib-manager-go/internal/config"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+const (
+	headerForwardedProto = "X-Forwarded-Proto"
+	headerForwardedHost  = "X-Forwarded-Host"
+)
+
+// BaseURL - resolves the external base URL of the request for the absolute links. The configured one takes
+// precedence, otherwise the forwarded headers are only honoured from the trusted proxies, so a client cannot
+// spoof the links of the publicly cached responses
+func BaseURL(config config.HTTPConfig) handlers.Middleware {
+	externalBaseURL := strings.TrimSuffix(config.ExternalBaseURL, "/")
+
+	return func(next handlers.HTTPHandler) handlers.HTTPHandler {
+		if externalBaseURL == "" && len(config.TrustedProxies) == 0 {
+			return next
+		}
+
+		return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+			switch {
+			case externalBaseURL != "":
+				r = handlers.WithBaseURL(r, externalBaseURL)
+			case isTrustedProxy(config.TrustedProxies, r.RemoteAddr):
+				r = handlers.WithBaseURL(r, forwardedBaseURL(r))
+			}
+
+			return next(r.Context(), w, r)
+		}
+	}
+}
+
+func isTrustedProxy(trustedProxies []netip.Prefix, remoteAddr string) bool {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return false
+	}
+
+	addr = addr.Unmap()
+	for _, prefix := range trustedProxies {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// forwardedBaseURL - the forwarded headers take precedence over the URL the server is requested with
+func forwardedBaseURL(r *http.Request) string {
+	baseURL := handlers.BaseURL(r)
+	scheme, host, _ := strings.Cut(baseURL, "://")
+	if proto := forwardedValue(r, headerForwardedProto); proto != "" {
+		scheme = proto
+	}
+	if forwardedHost := forwardedValue(r, headerForwardedHost); forwardedHost != "" {
+		host = forwardedHost
+	}
+
+	return scheme + "://" + host
+}
+
+// forwardedValue - returns the first value of the forwarded header, set by the closest client proxy
+func forwardedValue(r *http.Request, header string) string {
+	value, _, _ := strings.Cut(r.Header.Get(header), ",")
+
+	return strings.TrimSpace(value)
+}
@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"context"
+	"github.com/sdreger/lib-manager-go/cmd/api/handlers"
+	"github.com/sdreger/lib-manager-go/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+)
+
+func TestBaseURL_Untrusted(t *testing.T) {
+	httpConfig := config.HTTPConfig{TrustedProxies: []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")}}
+
+	request := getForwardedRequest("192.168.0.10:41000")
+	assert.Equal(t, "http://lib-manager:8070", resolveBaseURL(t, config.HTTPConfig{}, request),
+		"the forwarded headers should be ignored without the trusted proxies")
+	assert.Equal(t, "http://lib-manager:8070", resolveBaseURL(t, httpConfig, request),
+		"the forwarded headers should be ignored from the untrusted client")
+}
+
+func TestBaseURL_TrustedProxy(t *testing.T) {
+	httpConfig := config.HTTPConfig{TrustedProxies: []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8"),
+		netip.MustParsePrefix("::1/128")}}
+
+	assert.Equal(t, "https://books.example.com", resolveBaseURL(t, httpConfig, getForwardedRequest("10.0.0.5:41000")))
+	assert.Equal(t, "https://books.example.com", resolveBaseURL(t, httpConfig, getForwardedRequest("[::1]:41000")))
+
+	request := httptest.NewRequest(http.MethodGet, "http://lib-manager:8070/feeds/new.atom", nil)
+	request.RemoteAddr = "10.0.0.5:41000"
+	assert.Equal(t, "http://lib-manager:8070", resolveBaseURL(t, httpConfig, request),
+		"the request URL should be kept without the forwarded headers")
+}
+
+func TestBaseURL_External(t *testing.T) {
+	httpConfig := config.HTTPConfig{ExternalBaseURL: "https://library.example.com/",
+		TrustedProxies: []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")}}
+
+	assert.Equal(t, "https://library.example.com", resolveBaseURL(t, httpConfig, getForwardedRequest("10.0.0.5:41000")),
+		"the configured base URL should take precedence")
+}
+
+func getForwardedRequest(remoteAddr string) *http.Request {
+	request := httptest.NewRequest(http.MethodGet, "http://lib-manager:8070/feeds/new.atom", nil)
+	request.RemoteAddr = remoteAddr
+	request.Header.Set("X-Forwarded-Proto", "https")
+	request.Header.Set("X-Forwarded-Host", "books.example.com, proxy.internal")
+
+	return request
+}
+
+func resolveBaseURL(t *testing.T, httpConfig config.HTTPConfig, request *http.Request) string {
+	var baseURL string
+	handler := BaseURL(httpConfig)(func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		baseURL = handlers.BaseURL(r)
+		return nil
+	})
+	require.NoError(t, handler(context.Background(), httptest.NewRecorder(), request))
+
+	return baseURL
+}
@@ -29,6 +29,9 @@ func Errors(logger *slog.Logger) handlers.Middleware {
 				case errors.Is(err, apiErrors.ErrNotFound):
 					renderingError = response.RenderErrorJSON(w, http.StatusNotFound,
 						[]response.APIError{{Message: err.Error()}})
+				case errors.Is(err, apiErrors.ErrUnavailable):
+					renderingError = response.RenderErrorJSON(w, http.StatusServiceUnavailable,
+						[]response.APIError{{Message: apiErrors.ErrUnavailable.Error()}})
 				default:
 					renderingError = response.RenderErrorJSON(w, http.StatusInternalServerError,
 						[]response.APIError{{Message: http.StatusText(http.StatusInternalServerError)}})
@@ -3,6 +3,7 @@ package middleware
 import (
 	"context"
 	"errors"
+	"fmt"
 	apiErrors "github.com/sdreger/lib-manager-go/cmd/api/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -84,6 +85,25 @@ func TestErrors_NotFoundError(t *testing.T) {
 	}
 }
 
+func TestErrors_UnavailableError(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	middleware := Errors(logger)
+	handler := middleware(func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		return fmt.Errorf("%w: connection refused", apiErrors.ErrUnavailable)
+	})
+
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	recorder := httptest.NewRecorder()
+	err := handler(context.Background(), recorder, request)
+	require.NoError(t, err, "error should be handled by middleware")
+	require.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+
+	body, err := io.ReadAll(recorder.Result().Body)
+	if assert.NoError(t, err, "body reading error") {
+		assert.JSONEq(t, `{"errors":[{"message":"the upstream service is unavailable"}]}`, string(body))
+	}
+}
+
 func TestErrors_UnexpectedError(t *testing.T) {
 	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
 	middleware := Errors(logger)
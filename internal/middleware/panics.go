@@ -8,13 +8,17 @@ import (
 )
 
 // Panics - middleware for capturing panics and converting them to errors.
-// Should be registered as the last application-wide middleware, before any handler-specific middlewares
+// Should be registered as the last application-wide middleware, before any handler-specific middlewares.
+// The 'http.ErrAbortHandler' panic is propagated, to let the server abort a partially written response
 func Panics() handlers.Middleware {
 	return func(next handlers.HTTPHandler) handlers.HTTPHandler {
 		return func(ctx context.Context, w http.ResponseWriter, r *http.Request) (err error) {
 			// recover from a potential panic and set the err value
 			defer func() {
 				if rec := recover(); rec != nil {
+					if rec == http.ErrAbortHandler {
+						panic(rec)
+					}
 					err = fmt.Errorf("recover from panic: %v", rec)
 				}
 			}()
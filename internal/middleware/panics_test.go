@@ -34,3 +34,15 @@ func TestPanics_Panic(t *testing.T) {
 	require.Error(t, err)
 	require.ErrorContains(t, err, "recover from panic: some error")
 }
+
+func TestPanics_AbortHandler(t *testing.T) {
+	middleware := Panics()
+	handler := middleware(func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		panic(http.ErrAbortHandler)
+	})
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	require.PanicsWithValue(t, http.ErrAbortHandler, func() {
+		_ = handler(context.Background(), recorder, request)
+	}, "the abort panic should be propagated to the server")
+}
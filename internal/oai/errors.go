@@ -0,0 +1,25 @@
+package oai
+
+// the OAI-PMH error codes, see https://www.openarchives.org/OAI/openarchivesprotocol.html#ErrorConditions
+const (
+	CodeBadArgument             = "badArgument"
+	CodeBadResumptionToken      = "badResumptionToken"
+	CodeBadVerb                 = "badVerb"
+	CodeCannotDisseminateFormat = "cannotDisseminateFormat"
+	CodeIDDoesNotExist          = "idDoesNotExist"
+	CodeNoRecordsMatch          = "noRecordsMatch"
+)
+
+// Error - the protocol error, it is reported in the response body, rather than by the HTTP status
+type Error struct {
+	Code    string
+	Message string
+}
+
+func (e Error) Error() string {
+	return e.Code + ": " + e.Message
+}
+
+func badArgument(message string) Error {
+	return Error{Code: CodeBadArgument, Message: message}
+}
@@ -0,0 +1,132 @@
+package oai
+
+import (
+	"net/url"
+	"slices"
+	"time"
+)
+
+// verbArguments - the required and the optional arguments of the verbs. The resumption token is exclusive,
+// the verbs accepting it have no other arguments along with it
+var verbArguments = map[string]struct {
+	required []string
+	optional []string
+}{
+	VerbIdentify:            {},
+	VerbListMetadataFormats: {optional: []string{argIdentifier}},
+	VerbListSets:            {optional: []string{argResumptionToken}},
+	VerbListIdentifiers: {required: []string{argMetadataPrefix},
+		optional: []string{argFrom, argUntil, argSet, argResumptionToken}},
+	VerbListRecords: {required: []string{argMetadataPrefix},
+		optional: []string{argFrom, argUntil, argSet, argResumptionToken}},
+	VerbGetRecord: {required: []string{argIdentifier, argMetadataPrefix}},
+}
+
+// Request - the validated request arguments. The arguments are echoed in the response as they were sent.
+// The until time is inclusive, extended to the end of its second, or to the end of the day for the dates,
+// since the update times are stored with a sub-second precision
+type Request struct {
+	Verb            string
+	Arguments       map[string]string
+	Identifier      string
+	MetadataPrefix  string
+	Set             string
+	From            time.Time
+	Until           time.Time
+	ResumptionToken string
+}
+
+// ParseRequest - returns the request of the query or the form values, or the 'badVerb' and the 'badArgument'
+// protocol errors
+func ParseRequest(values url.Values) (Request, error) {
+	verbs := values["verb"]
+	if len(verbs) != 1 {
+		return Request{}, Error{Code: CodeBadVerb, Message: "the verb argument should be provided exactly once"}
+	}
+	arguments, ok := verbArguments[verbs[0]]
+	if !ok {
+		return Request{}, Error{Code: CodeBadVerb, Message: "the verb '" + verbs[0] + "' is not supported"}
+	}
+
+	request := Request{Verb: verbs[0], Arguments: map[string]string{}}
+	for name, list := range values {
+		if name == "verb" {
+			continue
+		}
+		if !slices.Contains(arguments.required, name) && !slices.Contains(arguments.optional, name) {
+			return Request{}, badArgument("the argument '" + name + "' is not allowed for the " + request.Verb)
+		}
+		if len(list) != 1 {
+			return Request{}, badArgument("the argument '" + name + "' should be provided once")
+		}
+		request.Arguments[name] = list[0]
+	}
+
+	if token, ok := request.Arguments[argResumptionToken]; ok {
+		if len(request.Arguments) > 1 {
+			return Request{}, badArgument("the resumptionToken is an exclusive argument")
+		}
+		request.ResumptionToken = token
+		return request, nil
+	}
+	for _, name := range arguments.required {
+		if _, ok := request.Arguments[name]; !ok {
+			return Request{}, badArgument("the argument '" + name + "' is required for the " + request.Verb)
+		}
+	}
+
+	request.Identifier = request.Arguments[argIdentifier]
+	request.MetadataPrefix = request.Arguments[argMetadataPrefix]
+	request.Set = request.Arguments[argSet]
+	if err := request.parseRange(); err != nil {
+		return Request{}, err
+	}
+
+	return request, nil
+}
+
+// parseRange - parses the from and the until dates, both of them should have the same granularity
+func (r *Request) parseRange() error {
+	from, fromLayout, err := parseDatestamp(r.Arguments[argFrom])
+	if err != nil {
+		return err
+	}
+	until, untilLayout, err := parseDatestamp(r.Arguments[argUntil])
+	if err != nil {
+		return err
+	}
+	if fromLayout != "" && untilLayout != "" && fromLayout != untilLayout {
+		return badArgument("the from and the until arguments should have the same granularity")
+	}
+	if !from.IsZero() && !until.IsZero() && from.After(until) {
+		return badArgument("the from argument should not be later than the until one")
+	}
+
+	r.From = from
+	if !until.IsZero() {
+		if untilLayout == dateLayout {
+			r.Until = until.AddDate(0, 0, 1).Add(-time.Microsecond)
+		} else {
+			r.Until = until.Add(time.Second - time.Microsecond)
+		}
+	}
+
+	return nil
+}
+
+// parseDatestamp - parses the date or the datestamp, and returns the layout it matched
+func parseDatestamp(value string) (time.Time, string, error) {
+	if value == "" {
+		return time.Time{}, "", nil
+	}
+	for _, layout := range []string{dateLayout, datestampLayout} {
+		if len(value) != len(layout) {
+			continue
+		}
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed, layout, nil
+		}
+	}
+
+	return time.Time{}, "", badArgument("the date '" + value + "' should be in the " + Granularity + " format")
+}
@@ -0,0 +1,89 @@
+package oai
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestParseRequest(t *testing.T) {
+	request, err := ParseRequest(map[string][]string{"verb": {VerbListRecords}, "metadataPrefix": {"oai_dc"},
+		"set": {"category-3"}, "from": {"2026-10-01"}, "until": {"2026-10-05"}})
+	require.NoError(t, err)
+	assert.Equal(t, Request{
+		Verb: VerbListRecords,
+		Arguments: map[string]string{"metadataPrefix": "oai_dc", "set": "category-3", "from": "2026-10-01",
+			"until": "2026-10-05"},
+		MetadataPrefix: "oai_dc",
+		Set:            "category-3",
+		From:           time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+		Until:          time.Date(2026, 10, 5, 23, 59, 59, 999999000, time.UTC),
+	}, request, "the until date should be extended to the end of the day")
+
+	request, err = ParseRequest(map[string][]string{"verb": {VerbListIdentifiers}, "metadataPrefix": {"oai_dc"},
+		"until": {"2026-10-05T08:30:00Z"}})
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 10, 5, 8, 30, 0, 999999000, time.UTC), request.Until,
+		"the until datestamp should be extended to the end of the second")
+
+	request, err = ParseRequest(map[string][]string{"verb": {VerbListRecords}, "resumptionToken": {"token"}})
+	require.NoError(t, err)
+	assert.Equal(t, "token", request.ResumptionToken)
+
+	request, err = ParseRequest(map[string][]string{"verb": {VerbIdentify}})
+	require.NoError(t, err)
+	assert.Equal(t, Request{Verb: VerbIdentify, Arguments: map[string]string{}}, request)
+}
+
+func TestParseRequest_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		values map[string][]string
+		code   string
+	}{
+		{name: "missing verb", values: map[string][]string{}, code: CodeBadVerb},
+		{name: "unknown verb", values: map[string][]string{"verb": {"ListBooks"}}, code: CodeBadVerb},
+		{name: "repeated verb", values: map[string][]string{"verb": {VerbIdentify, VerbIdentify}}, code: CodeBadVerb},
+		{name: "illegal argument", values: map[string][]string{"verb": {VerbIdentify}, "set": {"category-1"}},
+			code: CodeBadArgument},
+		{name: "repeated argument", values: map[string][]string{"verb": {VerbGetRecord},
+			"identifier": {"a", "b"}, "metadataPrefix": {"oai_dc"}}, code: CodeBadArgument},
+		{name: "missing argument", values: map[string][]string{"verb": {VerbGetRecord}, "metadataPrefix": {"oai_dc"}},
+			code: CodeBadArgument},
+		{name: "exclusive token", values: map[string][]string{"verb": {VerbListRecords},
+			"resumptionToken": {"token"}, "metadataPrefix": {"oai_dc"}}, code: CodeBadArgument},
+		{name: "invalid date", values: map[string][]string{"verb": {VerbListRecords}, "metadataPrefix": {"oai_dc"},
+			"from": {"2026-10-01T08:30Z"}}, code: CodeBadArgument},
+		{name: "mixed granularity", values: map[string][]string{"verb": {VerbListRecords},
+			"metadataPrefix": {"oai_dc"}, "from": {"2026-10-01"}, "until": {"2026-10-05T08:30:00Z"}},
+			code: CodeBadArgument},
+		{name: "reversed range", values: map[string][]string{"verb": {VerbListRecords}, "metadataPrefix": {"oai_dc"},
+			"from": {"2026-10-05"}, "until": {"2026-10-01"}}, code: CodeBadArgument},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ParseRequest(test.values)
+			assertProtocolError(t, test.code, err)
+		})
+	}
+}
+
+func TestResumptionToken(t *testing.T) {
+	state := listState{MetadataPrefix: MetadataPrefixDC, Set: "publisher-1", UpdatedAt: testUpdated, BookID: 2,
+		Cursor: 100}
+	token, err := encodeToken(state)
+	require.NoError(t, err)
+
+	decoded, err := decodeToken(token)
+	require.NoError(t, err)
+	assert.True(t, testUpdated.Equal(decoded.UpdatedAt), "the update time should keep the microseconds")
+	decoded.UpdatedAt = state.UpdatedAt
+	assert.Equal(t, state, decoded)
+
+	for _, invalid := range []string{"%%%", "bm90LWpzb24", "e30"} {
+		_, err = decodeToken(invalid)
+		assertProtocolError(t, CodeBadResumptionToken, err)
+	}
+}
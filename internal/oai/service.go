@@ -0,0 +1,228 @@
+package oai
+
+import (
+	"context"
+	"errors"
+	"github.com/jmoiron/sqlx"
+	"github.com/sdreger/lib-manager-go/internal/config"
+	"github.com/sdreger/lib-manager-go/internal/domain/book"
+	"log/slog"
+	"strconv"
+	"time"
+)
+
+type Store interface {
+	GetSets(ctx context.Context) ([]Set, error)
+	GetSetSpecs(ctx context.Context, bookIDs []int64) (map[int64][]string, error)
+	GetEarliestDatestamp(ctx context.Context) (time.Time, error)
+	GetDeletedDatestamp(ctx context.Context, bookID int64) (time.Time, error)
+}
+
+type BookService interface {
+	GetBookByID(ctx context.Context, bookID int64) (book.Book, error)
+	GetChangedBooks(ctx context.Context, changeQuery book.ChangeQuery) ([]book.Book, error)
+}
+
+type Service struct {
+	logger      *slog.Logger
+	store       Store
+	bookService BookService
+	config      config.OAIConfig
+}
+
+func NewService(logger *slog.Logger, db *sqlx.DB, cfg config.OAIConfig) *Service {
+	return &Service{
+		logger:      logger,
+		store:       NewDBStore(db),
+		bookService: book.NewService(logger, db),
+		config:      cfg,
+	}
+}
+
+// Identify - returns the repository description. The earliest datestamp of the empty repository is the Unix epoch
+func (s *Service) Identify(ctx context.Context) (Repository, error) {
+	earliest, err := s.store.GetEarliestDatestamp(ctx)
+	if err != nil {
+		return Repository{}, err
+	}
+	if earliest.IsZero() {
+		earliest = time.Unix(0, 0)
+	}
+
+	return Repository{
+		Name:              s.config.RepositoryName,
+		Identifier:        s.config.RepositoryIdentifier,
+		AdminEmail:        s.config.AdminEmail,
+		EarliestDatestamp: earliest,
+	}, nil
+}
+
+// ListMetadataFormats - returns the metadata formats of the repository, or of the record, if the identifier is set.
+// All the records are available in all the formats
+func (s *Service) ListMetadataFormats(ctx context.Context, identifier string) ([]MetadataFormat, error) {
+	if identifier != "" {
+		if _, err := s.getBook(ctx, identifier); err != nil {
+			return nil, err
+		}
+	}
+
+	return MetadataFormats, nil
+}
+
+// ListSets - returns all the sets in a single response, so any resumption token is not the one the repository issued
+func (s *Service) ListSets(ctx context.Context, resumptionToken string) ([]Set, error) {
+	if resumptionToken != "" {
+		return nil, Error{Code: CodeBadResumptionToken, Message: "the set list is complete, it cannot be resumed"}
+	}
+
+	return s.store.GetSets(ctx)
+}
+
+// ListRecords - returns a page of the records, updated within the request date range, the least recently updated
+// first. The next page is resumed after the last record of this one, so the books updated during the harvest are
+// moved to its end, rather than shifting the pages. The deleted books are listed as the deleted records.
+// The base URL is the one the book URLs are built with
+func (s *Service) ListRecords(ctx context.Context, request Request, baseURL string) (RecordList, error) {
+	state := listState{MetadataPrefix: request.MetadataPrefix, Set: request.Set, From: request.From,
+		Until: request.Until}
+	if request.ResumptionToken != "" {
+		var err error
+		if state, err = decodeToken(request.ResumptionToken); err != nil {
+			return RecordList{}, err
+		}
+	}
+	if err := checkMetadataPrefix(state.MetadataPrefix); err != nil {
+		return RecordList{}, err
+	}
+	filter, err := setFilter(state.Set)
+	if err != nil {
+		return RecordList{}, err
+	}
+
+	// one more book is fetched, to tell whether the list is complete
+	books, err := s.bookService.GetChangedBooks(ctx, book.ChangeQuery{
+		Filter:         filter,
+		From:           state.From,
+		Until:          state.Until,
+		AfterUpdatedAt: state.UpdatedAt,
+		AfterID:        state.BookID,
+		Limit:          s.config.PageSize + 1,
+		WithDeleted:    true,
+	})
+	if err != nil {
+		return RecordList{}, err
+	}
+	if len(books) == 0 {
+		return RecordList{}, Error{Code: CodeNoRecordsMatch, Message: "no records match the request arguments"}
+	}
+	complete := uint64(len(books)) <= s.config.PageSize
+	if !complete {
+		books = books[:s.config.PageSize]
+	}
+
+	records, err := s.newRecords(ctx, books, baseURL)
+	if err != nil {
+		return RecordList{}, err
+	}
+
+	list := RecordList{Records: records}
+	switch {
+	case !complete:
+		last := books[len(books)-1]
+		next := state
+		next.UpdatedAt, next.BookID, next.Cursor = last.UpdatedAt, last.ID, state.Cursor+int64(len(books))
+		value, err := encodeToken(next)
+		if err != nil {
+			return RecordList{}, err
+		}
+		list.ResumptionToken = &ResumptionToken{Value: value, Cursor: state.Cursor}
+	case state.Cursor > 0:
+		// the last page of the resumed list has the empty token
+		list.ResumptionToken = &ResumptionToken{Cursor: state.Cursor}
+	}
+
+	return list, nil
+}
+
+// GetRecord - returns the record by its identifier, the deleted book is returned as the deleted record
+func (s *Service) GetRecord(ctx context.Context, identifier string, metadataPrefix string, baseURL string) (
+	Record, error) {
+
+	if err := checkMetadataPrefix(metadataPrefix); err != nil {
+		return Record{}, err
+	}
+	bookEntry, err := s.getBook(ctx, identifier)
+	if err != nil {
+		return Record{}, err
+	}
+
+	records, err := s.newRecords(ctx, []book.Book{bookEntry}, baseURL)
+	if err != nil {
+		return Record{}, err
+	}
+
+	return records[0], nil
+}
+
+// getBook - returns the book of the record identifier, or the 'idDoesNotExist' protocol error.
+// The deleted book has the ID and the deletion time only
+func (s *Service) getBook(ctx context.Context, identifier string) (book.Book, error) {
+	notFound := Error{Code: CodeIDDoesNotExist, Message: "the identifier '" + identifier + "' is unknown"}
+	bookID, ok := parseIdentifier(s.config.RepositoryIdentifier, identifier)
+	if !ok {
+		return book.Book{}, notFound
+	}
+
+	bookEntry, err := s.bookService.GetBookByID(ctx, bookID)
+	if !errors.Is(err, book.ErrNotFound) {
+		return bookEntry, err
+	}
+
+	deletedAt, err := s.store.GetDeletedDatestamp(ctx, bookID)
+	if err != nil {
+		return book.Book{}, err
+	}
+	if deletedAt.IsZero() {
+		return book.Book{}, notFound
+	}
+
+	return book.Book{ID: bookID, UpdatedAt: deletedAt, Deleted: true}, nil
+}
+
+func (s *Service) newRecords(ctx context.Context, books []book.Book, baseURL string) ([]Record, error) {
+	bookIDs := make([]int64, 0, len(books))
+	for _, entry := range books {
+		bookIDs = append(bookIDs, entry.ID)
+	}
+	setSpecs, err := s.store.GetSetSpecs(ctx, bookIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]Record, 0, len(books))
+	for _, entry := range books {
+		record := Record{Header: Header{
+			Identifier: Identifier(s.config.RepositoryIdentifier, entry.ID),
+			Datestamp:  entry.UpdatedAt,
+			SetSpecs:   setSpecs[entry.ID],
+			Deleted:    entry.Deleted,
+		}}
+		if !entry.Deleted {
+			record.Metadata = NewDublinCore(entry, baseURL+"/v1/books/"+strconv.FormatInt(entry.ID, 10))
+		}
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+func checkMetadataPrefix(metadataPrefix string) error {
+	for _, format := range MetadataFormats {
+		if format.Prefix == metadataPrefix {
+			return nil
+		}
+	}
+
+	return Error{Code: CodeCannotDisseminateFormat, Message: "the metadata format '" + metadataPrefix +
+		"' is not supported, the supported one is '" + MetadataPrefixDC + "'"}
+}
@@ -0,0 +1,235 @@
+package oai
+
+import (
+	"context"
+	"errors"
+	"github.com/sdreger/lib-manager-go/internal/config"
+	"github.com/sdreger/lib-manager-go/internal/domain/book"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+)
+
+const (
+	testRepositoryIdentifier = "lib-manager.local"
+	testBaseURL              = "https://library.example.com"
+	testPageSize             = 2
+)
+
+var testUpdated = time.Date(2026, 10, 5, 8, 30, 0, 123456000, time.UTC)
+
+func TestService_Identify(t *testing.T) {
+	ctx := context.Background()
+	service := getService()
+
+	store := NewMockStore(t)
+	store.EXPECT().GetEarliestDatestamp(ctx).Return(testUpdated, nil).Once()
+	store.EXPECT().GetEarliestDatestamp(ctx).Return(time.Time{}, nil).Once()
+	injectMocks(service, store, NewMockBookService(t))
+
+	repository, err := service.Identify(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, Repository{Name: "Library", Identifier: testRepositoryIdentifier, AdminEmail: "admin@example.com",
+		EarliestDatestamp: testUpdated}, repository)
+
+	repository, err = service.Identify(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, time.Unix(0, 0), repository.EarliestDatestamp, "the empty repository should start at the epoch")
+}
+
+func TestService_ListMetadataFormats(t *testing.T) {
+	ctx := context.Background()
+	service := getService()
+
+	bookService := NewMockBookService(t)
+	bookService.EXPECT().GetBookByID(ctx, int64(1)).Return(book.Book{ID: 1}, nil).Once()
+	bookService.EXPECT().GetBookByID(ctx, int64(2)).Return(book.Book{}, book.ErrNotFound).Once()
+	store := NewMockStore(t)
+	store.EXPECT().GetDeletedDatestamp(ctx, int64(2)).Return(time.Time{}, nil).Once()
+	injectMocks(service, store, bookService)
+
+	formats, err := service.ListMetadataFormats(ctx, "")
+	require.NoError(t, err)
+	assert.Equal(t, MetadataFormats, formats)
+
+	formats, err = service.ListMetadataFormats(ctx, Identifier(testRepositoryIdentifier, 1))
+	require.NoError(t, err)
+	assert.Equal(t, MetadataFormats, formats)
+
+	for _, identifier := range []string{Identifier(testRepositoryIdentifier, 2), "oai:other.org:book/1",
+		"oai:lib-manager.local:book/one"} {
+		_, err = service.ListMetadataFormats(ctx, identifier)
+		assertProtocolError(t, CodeIDDoesNotExist, err)
+	}
+}
+
+func TestService_ListSets(t *testing.T) {
+	ctx := context.Background()
+	service := getService()
+	sets := []Set{{Spec: "category-1", Name: "Programming"}, {Spec: "publisher-1", Name: "OReilly"}}
+
+	store := NewMockStore(t)
+	store.EXPECT().GetSets(ctx).Return(sets, nil).Once()
+	injectMocks(service, store, NewMockBookService(t))
+
+	result, err := service.ListSets(ctx, "")
+	require.NoError(t, err)
+	assert.Equal(t, sets, result)
+
+	_, err = service.ListSets(ctx, "token")
+	assertProtocolError(t, CodeBadResumptionToken, err)
+}
+
+func TestService_ListRecords(t *testing.T) {
+	ctx := context.Background()
+	service := getService()
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	request := Request{Verb: VerbListRecords, MetadataPrefix: MetadataPrefixDC, Set: "publisher-1", From: from}
+	filter := book.Filter{Publishers: []int64{1}}
+	firstPage := []book.Book{
+		{ID: 1, Title: "Book 01", UpdatedAt: testUpdated},
+		{ID: 2, Title: "Book 02", UpdatedAt: testUpdated, Deleted: true},
+		{ID: 3, Title: "Book 03", UpdatedAt: testUpdated.Add(time.Hour)},
+	}
+
+	bookService := NewMockBookService(t)
+	bookService.EXPECT().GetChangedBooks(ctx, book.ChangeQuery{Filter: filter, From: from, Limit: testPageSize + 1,
+		WithDeleted: true}).Return(firstPage, nil).Once()
+	bookService.EXPECT().GetChangedBooks(ctx, book.ChangeQuery{Filter: filter, From: from, AfterUpdatedAt: testUpdated,
+		AfterID: 2, Limit: testPageSize + 1, WithDeleted: true}).Return(firstPage[2:], nil).Once()
+	store := NewMockStore(t)
+	store.EXPECT().GetSetSpecs(ctx, []int64{1, 2}).
+		Return(map[int64][]string{1: {"category-3", "publisher-1"}}, nil).Once()
+	store.EXPECT().GetSetSpecs(ctx, []int64{3}).Return(map[int64][]string{}, nil).Once()
+	injectMocks(service, store, bookService)
+
+	list, err := service.ListRecords(ctx, request, testBaseURL)
+	require.NoError(t, err)
+	require.Len(t, list.Records, testPageSize, "the extra book should only tell, that the list is incomplete")
+	assert.Equal(t, Header{Identifier: "oai:lib-manager.local:book/1", Datestamp: testUpdated,
+		SetSpecs: []string{"category-3", "publisher-1"}}, list.Records[0].Header)
+	assert.Equal(t, []string{"Book 01"}, list.Records[0].Metadata.Titles)
+	assert.Equal(t, Record{Header: Header{Identifier: "oai:lib-manager.local:book/2", Datestamp: testUpdated,
+		Deleted: true}}, list.Records[1], "the deleted book should have the header only")
+	require.NotNil(t, list.ResumptionToken)
+	assert.NotEmpty(t, list.ResumptionToken.Value)
+	assert.Equal(t, int64(0), list.ResumptionToken.Cursor)
+
+	list, err = service.ListRecords(ctx, Request{Verb: VerbListRecords,
+		ResumptionToken: list.ResumptionToken.Value}, testBaseURL)
+	require.NoError(t, err)
+	require.Len(t, list.Records, 1)
+	assert.Equal(t, "oai:lib-manager.local:book/3", list.Records[0].Header.Identifier)
+	assert.Equal(t, &ResumptionToken{Cursor: testPageSize}, list.ResumptionToken,
+		"the last page should have the empty token")
+}
+
+func TestService_ListRecords_Errors(t *testing.T) {
+	ctx := context.Background()
+	service := getService()
+	serviceError := errors.New("service error")
+
+	bookService := NewMockBookService(t)
+	bookService.EXPECT().GetChangedBooks(ctx, book.ChangeQuery{Limit: testPageSize + 1, WithDeleted: true}).
+		Return(nil, nil).Once()
+	bookService.EXPECT().GetChangedBooks(ctx, mock.Anything).Return(nil, serviceError).Once()
+	injectMocks(service, NewMockStore(t), bookService)
+
+	_, err := service.ListRecords(ctx, Request{MetadataPrefix: MetadataPrefixDC}, testBaseURL)
+	assertProtocolError(t, CodeNoRecordsMatch, err)
+
+	_, err = service.ListRecords(ctx, Request{MetadataPrefix: MetadataPrefixDC}, testBaseURL)
+	assert.ErrorIs(t, err, serviceError)
+
+	_, err = service.ListRecords(ctx, Request{MetadataPrefix: "marc21"}, testBaseURL)
+	assertProtocolError(t, CodeCannotDisseminateFormat, err)
+
+	_, err = service.ListRecords(ctx, Request{MetadataPrefix: MetadataPrefixDC, Set: "shelf-1"}, testBaseURL)
+	assertProtocolError(t, CodeBadArgument, err)
+
+	_, err = service.ListRecords(ctx, Request{ResumptionToken: "not-a-token"}, testBaseURL)
+	assertProtocolError(t, CodeBadResumptionToken, err)
+}
+
+func TestService_GetRecord(t *testing.T) {
+	ctx := context.Background()
+	service := getService()
+	bookEntry := book.Book{ID: 1, Title: "CockroachDB", Publisher: "OReilly", UpdatedAt: testUpdated}
+
+	bookService := NewMockBookService(t)
+	bookService.EXPECT().GetBookByID(ctx, int64(1)).Return(bookEntry, nil).Once()
+	bookService.EXPECT().GetBookByID(ctx, int64(2)).Return(book.Book{}, book.ErrNotFound).Once()
+	store := NewMockStore(t)
+	store.EXPECT().GetSetSpecs(ctx, []int64{1}).Return(map[int64][]string{1: {"publisher-1"}}, nil).Once()
+	store.EXPECT().GetDeletedDatestamp(ctx, int64(2)).Return(testUpdated, nil).Once()
+	store.EXPECT().GetSetSpecs(ctx, []int64{2}).Return(map[int64][]string{}, nil).Once()
+	injectMocks(service, store, bookService)
+
+	record, err := service.GetRecord(ctx, Identifier(testRepositoryIdentifier, 1), MetadataPrefixDC, testBaseURL)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"publisher-1"}, record.Header.SetSpecs)
+	assert.Equal(t, []string{"OReilly"}, record.Metadata.Publishers)
+	assert.Equal(t, []string{testBaseURL + "/v1/books/1"}, record.Metadata.Identifiers)
+
+	record, err = service.GetRecord(ctx, Identifier(testRepositoryIdentifier, 2), MetadataPrefixDC, testBaseURL)
+	require.NoError(t, err)
+	assert.Equal(t, Record{Header: Header{Identifier: "oai:lib-manager.local:book/2", Datestamp: testUpdated,
+		Deleted: true}}, record, "the deleted book should be returned as the deleted record")
+
+	_, err = service.GetRecord(ctx, Identifier(testRepositoryIdentifier, 1), "marc21", testBaseURL)
+	assertProtocolError(t, CodeCannotDisseminateFormat, err)
+}
+
+func TestNewDublinCore(t *testing.T) {
+	entry := book.Book{
+		ID:          1,
+		Title:       "CockroachDB",
+		Subtitle:    "The Definitive Guide",
+		Description: "Distributed SQL",
+		ISBN10:      "1234567890",
+		ISBN13:      9781234567890,
+		PubDate:     time.Date(2022, 7, 19, 0, 0, 0, 0, time.UTC),
+		Language:    "english",
+		Publisher:   "OReilly",
+		Authors:     []string{"John Doe", "Amanda Lee"},
+		Categories:  []string{"Databases"},
+		Tags:        []string{"sql"},
+		FileTypes:   []string{"pdf", "epub"},
+	}
+
+	assert.Equal(t, DublinCore{
+		Titles:       []string{"CockroachDB: The Definitive Guide"},
+		Creators:     []string{"John Doe", "Amanda Lee"},
+		Subjects:     []string{"Databases", "sql"},
+		Descriptions: []string{"Distributed SQL"},
+		Publishers:   []string{"OReilly"},
+		Dates:        []string{"2022-07-19"},
+		Types:        []string{"Text"},
+		Formats:      []string{"application/pdf", "application/epub+zip"},
+		Identifiers:  []string{"urn:isbn:9781234567890", "urn:isbn:1234567890", testBaseURL + "/v1/books/1"},
+		Languages:    []string{"english"},
+	}, NewDublinCore(entry, testBaseURL+"/v1/books/1"))
+}
+
+func assertProtocolError(t *testing.T, code string, err error) {
+	t.Helper()
+	var protocolError Error
+	if assert.ErrorAs(t, err, &protocolError) {
+		assert.Equal(t, code, protocolError.Code)
+	}
+}
+
+func getService() *Service {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	return NewService(logger, nil, config.OAIConfig{RepositoryName: "Library",
+		RepositoryIdentifier: testRepositoryIdentifier, AdminEmail: "admin@example.com", PageSize: testPageSize})
+}
+
+func injectMocks(service *Service, store *MockStore, bookService *MockBookService) {
+	service.store = store
+	service.bookService = bookService
+}
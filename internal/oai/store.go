@@ -0,0 +1,95 @@
+package oai
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"time"
+)
+
+type DBStore struct {
+	db *sqlx.DB
+}
+
+func NewDBStore(db *sqlx.DB) *DBStore {
+	return &DBStore{db: db}
+}
+
+// GetSets - returns the categories and the publishers, having at least one non-deleted book, sorted by name
+func (s *DBStore) GetSets(ctx context.Context) ([]Set, error) {
+	query := `SELECT spec, name
+FROM (SELECT 1 AS kind, $1::text || categories.id AS spec, categories.name
+      FROM ebook.categories
+      WHERE EXISTS (SELECT 1
+                    FROM ebook.book_category
+                             JOIN ebook.books ON books.id = book_category.book_id
+                    WHERE book_category.category_id = categories.id
+                      AND books.deleted_at IS NULL)
+      UNION ALL
+      SELECT 2 AS kind, $2::text || publishers.id AS spec, publishers.name
+      FROM ebook.publishers
+      WHERE EXISTS (SELECT 1 FROM ebook.books WHERE books.publisher_id = publishers.id AND books.deleted_at IS NULL)
+     ) sets
+ORDER BY kind, name, spec`
+	sets := make([]Set, 0)
+	if err := s.db.SelectContext(ctx, &sets, query, setCategoryPrefix, setPublisherPrefix); err != nil {
+		return nil, err
+	}
+
+	return sets, nil
+}
+
+// GetSetSpecs - returns the set specs of the books by their IDs, the books without any are skipped
+func (s *DBStore) GetSetSpecs(ctx context.Context, bookIDs []int64) (map[int64][]string, error) {
+	query := `SELECT book_id, spec
+FROM (SELECT book_category.book_id, $2::text || book_category.category_id AS spec
+      FROM ebook.book_category
+      WHERE book_category.book_id = ANY ($1)
+      UNION ALL
+      SELECT books.id AS book_id, $3::text || books.publisher_id AS spec
+      FROM ebook.books
+      WHERE books.id = ANY ($1)
+        AND books.publisher_id IS NOT NULL) specs
+ORDER BY book_id, spec`
+	var rows []struct {
+		BookID int64  `db:"book_id"`
+		Spec   string `db:"spec"`
+	}
+	if err := s.db.SelectContext(ctx, &rows, query, pq.Array(bookIDs), setCategoryPrefix,
+		setPublisherPrefix); err != nil {
+		return nil, err
+	}
+
+	specs := make(map[int64][]string, len(bookIDs))
+	for _, row := range rows {
+		specs[row.BookID] = append(specs[row.BookID], row.Spec)
+	}
+
+	return specs, nil
+}
+
+// GetEarliestDatestamp - returns the earliest update time of the books, including the deleted ones, since those are
+// harvested as the deleted records, or the zero time if there are none
+func (s *DBStore) GetEarliestDatestamp(ctx context.Context) (time.Time, error) {
+	var earliest sql.NullTime
+	if err := s.db.GetContext(ctx, &earliest, "SELECT MIN(updated_at) FROM ebook.books"); err != nil {
+		return time.Time{}, err
+	}
+
+	return earliest.Time, nil
+}
+
+// GetDeletedDatestamp - returns the update time of the deleted book, which is its deletion time,
+// or the zero time if the book is either not deleted or unknown
+func (s *DBStore) GetDeletedDatestamp(ctx context.Context, bookID int64) (time.Time, error) {
+	var datestamp time.Time
+	err := s.db.GetContext(ctx, &datestamp,
+		"SELECT updated_at FROM ebook.books WHERE id = $1 AND deleted_at IS NOT NULL", bookID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return time.Time{}, nil
+	}
+
+	return datestamp, err
+}
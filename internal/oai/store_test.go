@@ -0,0 +1,103 @@
+package oai
+
+import (
+	"context"
+	"github.com/jmoiron/sqlx"
+	"github.com/sdreger/lib-manager-go/internal/tests"
+	"github.com/stretchr/testify/suite"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"os"
+	"testing"
+	"time"
+)
+
+type TestStoreSuite struct {
+	suite.Suite
+	db            *sqlx.DB
+	testContainer *postgres.PostgresContainer
+	store         *DBStore
+}
+
+func (s *TestStoreSuite) SetupSuite() {
+	testContainer := tests.StartDBTestContainer(s.T())
+	dbConfig := tests.GetTestDBConfig(s.T(), testContainer)
+	connection := tests.SetUpTestDB(s.Suite.Require(), dbConfig, testContainer)
+
+	s.store = NewDBStore(connection)
+	s.db = connection
+	s.testContainer = testContainer
+}
+
+func (s *TestStoreSuite) SetupTest() {
+	ctx := context.Background()
+	err := s.testContainer.Restore(ctx)
+	s.Require().NoError(err)
+
+	err = prepareTestData(s.testContainer, "testdata/oai_books.sql")
+	s.Require().NoError(err, "failed to load test SQL file")
+}
+
+func (s *TestStoreSuite) TearDownSuite() {
+	err := s.db.Close()
+	s.Require().NoError(err, "failed to close database connection")
+}
+
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(TestStoreSuite))
+}
+
+// -------------------- Tests --------------------
+
+func (s *TestStoreSuite) Test_GetSets() {
+	sets, err := s.store.GetSets(context.Background())
+	s.Require().NoError(err)
+	s.Equal([]Set{
+		{Spec: "category-2", Name: "Databases"},
+		{Spec: "category-1", Name: "Programming"},
+		{Spec: "publisher-2", Name: "Manning"},
+		{Spec: "publisher-1", Name: "OReilly"},
+	}, sets, "the publishers of the deleted books only should be skipped")
+}
+
+func (s *TestStoreSuite) Test_GetSetSpecs() {
+	specs, err := s.store.GetSetSpecs(context.Background(), []int64{1, 3, 5})
+	s.Require().NoError(err)
+	s.Equal(map[int64][]string{
+		1: {"category-1", "category-2", "publisher-1"},
+		3: {"publisher-2"},
+	}, specs)
+}
+
+func (s *TestStoreSuite) Test_GetEarliestDatestamp() {
+	earliest, err := s.store.GetEarliestDatestamp(context.Background())
+	s.Require().NoError(err)
+	s.Equal(time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC), earliest.UTC(),
+		"the deleted books should be counted, since those are harvested")
+
+	_, err = s.db.Exec("TRUNCATE ebook.books CASCADE")
+	s.Require().NoError(err, "failed to remove the books")
+	earliest, err = s.store.GetEarliestDatestamp(context.Background())
+	s.Require().NoError(err)
+	s.True(earliest.IsZero())
+}
+
+func (s *TestStoreSuite) Test_GetDeletedDatestamp() {
+	datestamp, err := s.store.GetDeletedDatestamp(context.Background(), 4)
+	s.Require().NoError(err)
+	s.Equal(time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC), datestamp.UTC())
+
+	for _, bookID := range []int64{1, 100} {
+		datestamp, err = s.store.GetDeletedDatestamp(context.Background(), bookID)
+		s.Require().NoError(err)
+		s.True(datestamp.IsZero(), "neither the non-deleted nor the unknown book should have the datestamp")
+	}
+}
+
+func prepareTestData(testContainer *postgres.PostgresContainer, fileName string) error {
+	file, err := os.ReadFile(fileName)
+	if err != nil {
+		return err
+	}
+
+	return tests.ExecSQL(testContainer, string(file))
+}
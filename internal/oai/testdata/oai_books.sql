@@ -0,0 +1,19 @@
+INSERT INTO ebook.publishers (id, name) VALUES (1, 'OReilly'), (2, 'Manning'), (3, 'Packt');
+INSERT INTO ebook.languages (id, name) VALUES (1, 'English');
+INSERT INTO ebook.categories (id, name, parent_id) VALUES (1, 'Programming', null), (2, 'Databases', null);
+
+INSERT INTO ebook.books (id, title, description, isbn13, pages, edition, language_id, publisher_id, publisher_url,
+                         pub_date, book_file_name, book_file_size, cover_file_name, updated_at)
+VALUES (1, 'Book 01', 'Book 01 Description', 9781111111111, 256, 1, 1, 1, 'https://amazon.com/dp/1111111111.html',
+        '2022-07-19', 'Book.01.pdf', 5192, '1111111111.jpg', '2026-10-05 08:30:00.123456'),
+       (2, 'Book 02', 'Book 02 Description', 9782222222222, 256, 1, 1, 2, 'https://amazon.com/dp/2222222222.html',
+        '2021-02-11', 'Book.02.pdf', 5192, '2222222222.jpg', '2026-10-03 10:00:00'),
+       (3, 'Book 03', 'Book 03 Description', 9783333333333, 356, 2, 1, 2, 'https://amazon.com/dp/3333333333.html',
+        '2022-05-21', 'Book.03.pdf', 5193, '3333333333.jpg', '2026-10-01 12:00:00');
+INSERT INTO ebook.book_category (book_id, category_id) VALUES (1, 1), (1, 2), (2, 1);
+
+-- the deleted book is harvested as the deleted record, its publisher is not a set
+INSERT INTO ebook.books (id, title, description, isbn13, pages, edition, language_id, publisher_id, publisher_url,
+                         pub_date, book_file_name, book_file_size, cover_file_name, updated_at, deleted_at)
+VALUES (4, 'Book 04', 'Book 04 Description', 9784444444444, 128, 1, 1, 3, 'https://amazon.com/dp/4444444444.html',
+        '2020-01-01', 'Book.04.pdf', 1024, '4444444444.jpg', '2026-09-01 00:00:00', '2026-10-10 00:00:00');
@@ -0,0 +1,43 @@
+package oai
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+)
+
+// listState - the list request, along with the keyset position of its last returned record. The resumption tokens
+// are the encoded states, so the lists are resumed without keeping any state on the server
+type listState struct {
+	MetadataPrefix string    `json:"p"`
+	Set            string    `json:"s,omitempty"`
+	From           time.Time `json:"f,omitzero"`
+	Until          time.Time `json:"u,omitzero"`
+	UpdatedAt      time.Time `json:"t"`
+	BookID         int64     `json:"i"`
+	Cursor         int64     `json:"c"`
+}
+
+func encodeToken(state listState) (string, error) {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// decodeToken - returns the list state of the token, or the 'badResumptionToken' protocol error
+func decodeToken(token string) (listState, error) {
+	badToken := Error{Code: CodeBadResumptionToken, Message: "the resumptionToken is invalid"}
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return listState{}, badToken
+	}
+	var state listState
+	if err := json.Unmarshal(data, &state); err != nil || state.UpdatedAt.IsZero() || state.BookID <= 0 {
+		return listState{}, badToken
+	}
+
+	return state, nil
+}
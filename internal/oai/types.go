@@ -0,0 +1,212 @@
+package oai
+
+import (
+	"github.com/sdreger/lib-manager-go/internal/domain/book"
+	"github.com/sdreger/lib-manager-go/internal/domain/bookfile"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	VerbIdentify            = "Identify"
+	VerbListMetadataFormats = "ListMetadataFormats"
+	VerbListSets            = "ListSets"
+	VerbListIdentifiers     = "ListIdentifiers"
+	VerbListRecords         = "ListRecords"
+	VerbGetRecord           = "GetRecord"
+
+	// MetadataPrefixDC - the unqualified Dublin Core, the only supported metadata format
+	MetadataPrefixDC = "oai_dc"
+
+	ProtocolVersion = "2.0"
+	// Granularity - the datestamps are in UTC, to the second
+	Granularity = "YYYY-MM-DDThh:mm:ssZ"
+	// DeletedRecord - the deleted books are kept soft-deleted, e.g. the merged ones, so those are harvested as the
+	// deleted records, with the header only
+	DeletedRecord = "persistent"
+
+	NamespaceOAI   = "http://www.openarchives.org/OAI/2.0/"
+	SchemaOAI      = "http://www.openarchives.org/OAI/2.0/OAI-PMH.xsd"
+	NamespaceOAIDC = "http://www.openarchives.org/OAI/2.0/oai_dc/"
+	SchemaOAIDC    = "http://www.openarchives.org/OAI/2.0/oai_dc.xsd"
+	NamespaceDC    = "http://purl.org/dc/elements/1.1/"
+	NamespaceXSI   = "http://www.w3.org/2001/XMLSchema-instance"
+
+	argIdentifier      = "identifier"
+	argMetadataPrefix  = "metadataPrefix"
+	argFrom            = "from"
+	argUntil           = "until"
+	argSet             = "set"
+	argResumptionToken = "resumptionToken"
+
+	dateLayout      = "2006-01-02"
+	datestampLayout = "2006-01-02T15:04:05Z"
+
+	setCategoryPrefix  = "category-"
+	setPublisherPrefix = "publisher-"
+	bookIDPrefix       = "book/"
+	dcTypeText         = "Text"
+)
+
+// Repository - the repository description, returned by the Identify verb
+type Repository struct {
+	Name              string
+	Identifier        string
+	AdminEmail        string
+	EarliestDatestamp time.Time
+}
+
+// MetadataFormat - the metadata format the records are disseminated in
+type MetadataFormat struct {
+	Prefix    string
+	Schema    string
+	Namespace string
+}
+
+// MetadataFormats - the supported metadata formats
+var MetadataFormats = []MetadataFormat{
+	{Prefix: MetadataPrefixDC, Schema: SchemaOAIDC, Namespace: NamespaceOAIDC},
+}
+
+// Set - the selective harvesting set, either a category or a publisher, e.g. 'category-3'
+type Set struct {
+	Spec string `db:"spec"`
+	Name string `db:"name"`
+}
+
+// Header - the record header, the datestamp is the book update time, which is the deletion time of a deleted book
+type Header struct {
+	Identifier string
+	Datestamp  time.Time
+	SetSpecs   []string
+	Deleted    bool
+}
+
+// Record - the book metadata in the Dublin Core format, along with its header. The deleted record has no metadata
+type Record struct {
+	Header   Header
+	Metadata DublinCore
+}
+
+// RecordList - a page of the records, the resumption token is nil if the list is complete in a single response.
+// The last page of an incomplete list has the token with the empty value
+type RecordList struct {
+	Records         []Record
+	ResumptionToken *ResumptionToken
+}
+
+// ResumptionToken - the token to fetch the next page of the list with, the cursor is the number of the records
+// returned before this page
+type ResumptionToken struct {
+	Value  string
+	Cursor int64
+}
+
+// DublinCore - the unqualified Dublin Core elements, see https://www.dublincore.org/specifications/dublin-core/dces/
+type DublinCore struct {
+	Titles       []string
+	Creators     []string
+	Subjects     []string
+	Descriptions []string
+	Publishers   []string
+	Dates        []string
+	Types        []string
+	Formats      []string
+	Identifiers  []string
+	Languages    []string
+}
+
+// NewDublinCore - maps the book to the Dublin Core elements. The subjects are the categories and the tags,
+// the formats are the media types of the book files, and the identifiers are the ISBNs and the book URL
+func NewDublinCore(entry book.Book, bookURL string) DublinCore {
+	title := entry.Title
+	if entry.Subtitle != "" {
+		title += ": " + entry.Subtitle
+	}
+	dc := DublinCore{
+		Titles:   []string{title},
+		Creators: entry.Authors,
+		Subjects: append(append([]string{}, entry.Categories...), entry.Tags...),
+		Types:    []string{dcTypeText},
+	}
+	if entry.Description != "" {
+		dc.Descriptions = []string{entry.Description}
+	}
+	if entry.Publisher != "" {
+		dc.Publishers = []string{entry.Publisher}
+	}
+	if !entry.PubDate.IsZero() {
+		dc.Dates = []string{entry.PubDate.Format(dateLayout)}
+	}
+	for _, fileType := range entry.FileTypes {
+		dc.Formats = append(dc.Formats, bookfile.ContentType(fileType))
+	}
+	if entry.ISBN13 != 0 {
+		dc.Identifiers = append(dc.Identifiers, "urn:isbn:"+strconv.FormatInt(entry.ISBN13, 10))
+	}
+	if entry.ISBN10 != "" {
+		dc.Identifiers = append(dc.Identifiers, "urn:isbn:"+entry.ISBN10)
+	}
+	dc.Identifiers = append(dc.Identifiers, bookURL)
+	if entry.Language != "" {
+		dc.Languages = []string{entry.Language}
+	}
+
+	return dc
+}
+
+// Identifier - returns the record identifier of the book, e.g. 'oai:lib-manager.local:book/1'
+func Identifier(repositoryIdentifier string, bookID int64) string {
+	return "oai:" + repositoryIdentifier + ":" + bookIDPrefix + strconv.FormatInt(bookID, 10)
+}
+
+// parseIdentifier - returns the book ID of the record identifier, false if it is not one of the repository
+func parseIdentifier(repositoryIdentifier string, identifier string) (int64, bool) {
+	localIdentifier, ok := strings.CutPrefix(identifier, "oai:"+repositoryIdentifier+":"+bookIDPrefix)
+	if !ok {
+		return 0, false
+	}
+	bookID, err := strconv.ParseInt(localIdentifier, 10, 64)
+	if err != nil || bookID <= 0 {
+		return 0, false
+	}
+
+	return bookID, true
+}
+
+// CategorySpec, PublisherSpec - return the set specs of the category and the publisher
+func CategorySpec(categoryID int64) string {
+	return setCategoryPrefix + strconv.FormatInt(categoryID, 10)
+}
+
+func PublisherSpec(publisherID int64) string {
+	return setPublisherPrefix + strconv.FormatInt(publisherID, 10)
+}
+
+// setFilter - returns the book filter, selecting the books of the set
+func setFilter(setSpec string) (book.Filter, error) {
+	if setSpec == "" {
+		return book.Filter{}, nil
+	}
+
+	for prefix, filter := range map[string]func(int64) book.Filter{
+		setCategoryPrefix:  func(id int64) book.Filter { return book.Filter{Categories: []int64{id}} },
+		setPublisherPrefix: func(id int64) book.Filter { return book.Filter{Publishers: []int64{id}} },
+	} {
+		if value, ok := strings.CutPrefix(setSpec, prefix); ok {
+			id, err := strconv.ParseInt(value, 10, 64)
+			if err != nil || id <= 0 {
+				break
+			}
+			return filter(id), nil
+		}
+	}
+
+	return book.Filter{}, badArgument("the set '" + setSpec + "' is not one of the repository sets")
+}
+
+// Datestamp - returns the UTC datestamp in the repository granularity
+func Datestamp(value time.Time) string {
+	return value.UTC().Format(datestampLayout)
+}
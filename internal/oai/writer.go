@@ -0,0 +1,285 @@
+package oai
+
+import (
+	"encoding/xml"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"time"
+)
+
+const (
+	namespaceOAIIdentifier = "http://www.openarchives.org/OAI/2.0/oai-identifier"
+	schemaOAIIdentifier    = "http://www.openarchives.org/OAI/2.0/oai-identifier.xsd"
+)
+
+// Response - the OAI-PMH response, either the protocol error or the result of the request verb.
+// The request arguments are only echoed, if those are valid
+type Response struct {
+	Date            time.Time
+	BaseURL         string
+	Request         Request
+	Error           *Error
+	Repository      *Repository
+	MetadataFormats []MetadataFormat
+	Sets            []Set
+	// Records - the result of the ListIdentifiers, only the headers are written, and of the ListRecords verbs
+	Records *RecordList
+	Record  *Record
+}
+
+// oaiPMH - the OAI-PMH 2.0 response, see https://www.openarchives.org/OAI/openarchivesprotocol.html
+type oaiPMH struct {
+	XMLName             xml.Name            `xml:"OAI-PMH"`
+	XMLNS               string              `xml:"xmlns,attr"`
+	XMLNSXSI            string              `xml:"xmlns:xsi,attr"`
+	SchemaLocation      string              `xml:"xsi:schemaLocation,attr"`
+	ResponseDate        string              `xml:"responseDate"`
+	Request             oaiRequest          `xml:"request"`
+	Error               *oaiError           `xml:"error"`
+	Identify            *oaiIdentify        `xml:"Identify"`
+	ListMetadataFormats *oaiMetadataFormats `xml:"ListMetadataFormats"`
+	ListSets            *oaiSets            `xml:"ListSets"`
+	ListIdentifiers     *oaiIdentifiers     `xml:"ListIdentifiers"`
+	ListRecords         *oaiRecords         `xml:"ListRecords"`
+	GetRecord           *oaiGetRecord       `xml:"GetRecord"`
+}
+
+type oaiRequest struct {
+	Attributes []xml.Attr `xml:",any,attr"`
+	BaseURL    string     `xml:",chardata"`
+}
+
+type oaiError struct {
+	Code    string `xml:"code,attr"`
+	Message string `xml:",chardata"`
+}
+
+type oaiIdentify struct {
+	RepositoryName    string         `xml:"repositoryName"`
+	BaseURL           string         `xml:"baseURL"`
+	ProtocolVersion   string         `xml:"protocolVersion"`
+	AdminEmail        string         `xml:"adminEmail"`
+	EarliestDatestamp string         `xml:"earliestDatestamp"`
+	DeletedRecord     string         `xml:"deletedRecord"`
+	Granularity       string         `xml:"granularity"`
+	Description       oaiDescription `xml:"description"`
+}
+
+type oaiDescription struct {
+	Identifier oaiIdentifierDescription `xml:"oai-identifier"`
+}
+
+// oaiIdentifierDescription - describes the record identifiers, see
+// https://www.openarchives.org/OAI/2.0/guidelines-oai-identifier.htm
+type oaiIdentifierDescription struct {
+	XMLNS                string `xml:"xmlns,attr"`
+	XMLNSXSI             string `xml:"xmlns:xsi,attr"`
+	SchemaLocation       string `xml:"xsi:schemaLocation,attr"`
+	Scheme               string `xml:"scheme"`
+	RepositoryIdentifier string `xml:"repositoryIdentifier"`
+	Delimiter            string `xml:"delimiter"`
+	SampleIdentifier     string `xml:"sampleIdentifier"`
+}
+
+type oaiMetadataFormats struct {
+	Formats []oaiMetadataFormat `xml:"metadataFormat"`
+}
+
+type oaiMetadataFormat struct {
+	Prefix    string `xml:"metadataPrefix"`
+	Schema    string `xml:"schema"`
+	Namespace string `xml:"metadataNamespace"`
+}
+
+type oaiSets struct {
+	Sets []oaiSet `xml:"set"`
+}
+
+type oaiSet struct {
+	Spec string `xml:"setSpec"`
+	Name string `xml:"setName"`
+}
+
+type oaiIdentifiers struct {
+	Headers         []oaiHeader         `xml:"header"`
+	ResumptionToken *oaiResumptionToken `xml:"resumptionToken"`
+}
+
+type oaiRecords struct {
+	Records         []oaiRecord         `xml:"record"`
+	ResumptionToken *oaiResumptionToken `xml:"resumptionToken"`
+}
+
+type oaiGetRecord struct {
+	Record oaiRecord `xml:"record"`
+}
+
+type oaiResumptionToken struct {
+	Cursor string `xml:"cursor,attr"`
+	Value  string `xml:",chardata"`
+}
+
+type oaiHeader struct {
+	Status     string   `xml:"status,attr,omitempty"`
+	Identifier string   `xml:"identifier"`
+	Datestamp  string   `xml:"datestamp"`
+	SetSpecs   []string `xml:"setSpec"`
+}
+
+type oaiRecord struct {
+	Header   oaiHeader    `xml:"header"`
+	Metadata *oaiMetadata `xml:"metadata"`
+}
+
+type oaiMetadata struct {
+	DC oaiDC `xml:"oai_dc:dc"`
+}
+
+type oaiDC struct {
+	XMLNSOAIDC     string   `xml:"xmlns:oai_dc,attr"`
+	XMLNSDC        string   `xml:"xmlns:dc,attr"`
+	XMLNSXSI       string   `xml:"xmlns:xsi,attr"`
+	SchemaLocation string   `xml:"xsi:schemaLocation,attr"`
+	Titles         []string `xml:"dc:title"`
+	Creators       []string `xml:"dc:creator"`
+	Subjects       []string `xml:"dc:subject"`
+	Descriptions   []string `xml:"dc:description"`
+	Publishers     []string `xml:"dc:publisher"`
+	Dates          []string `xml:"dc:date"`
+	Types          []string `xml:"dc:type"`
+	Formats        []string `xml:"dc:format"`
+	Identifiers    []string `xml:"dc:identifier"`
+	Languages      []string `xml:"dc:language"`
+}
+
+// WriteResponse - writes the OAI-PMH response XML
+func WriteResponse(w io.Writer, response Response) error {
+	result := oaiPMH{
+		XMLNS:          NamespaceOAI,
+		XMLNSXSI:       NamespaceXSI,
+		SchemaLocation: NamespaceOAI + " " + SchemaOAI,
+		ResponseDate:   Datestamp(response.Date),
+		Request:        oaiRequest{BaseURL: response.BaseURL},
+	}
+	if response.Request.Verb != "" {
+		result.Request.Attributes = append(result.Request.Attributes,
+			xml.Attr{Name: xml.Name{Local: "verb"}, Value: response.Request.Verb})
+		for _, name := range slices.Sorted(maps.Keys(response.Request.Arguments)) {
+			result.Request.Attributes = append(result.Request.Attributes,
+				xml.Attr{Name: xml.Name{Local: name}, Value: response.Request.Arguments[name]})
+		}
+	}
+
+	switch {
+	case response.Error != nil:
+		result.Error = &oaiError{Code: response.Error.Code, Message: response.Error.Message}
+	case response.Repository != nil:
+		result.Identify = newIdentify(response.BaseURL, *response.Repository)
+	case response.MetadataFormats != nil:
+		result.ListMetadataFormats = &oaiMetadataFormats{}
+		for _, format := range response.MetadataFormats {
+			result.ListMetadataFormats.Formats = append(result.ListMetadataFormats.Formats,
+				oaiMetadataFormat(format))
+		}
+	case response.Sets != nil:
+		result.ListSets = &oaiSets{}
+		for _, set := range response.Sets {
+			result.ListSets.Sets = append(result.ListSets.Sets, oaiSet(set))
+		}
+	case response.Records != nil && response.Request.Verb == VerbListIdentifiers:
+		result.ListIdentifiers = &oaiIdentifiers{ResumptionToken: newResumptionToken(response.Records)}
+		for _, record := range response.Records.Records {
+			result.ListIdentifiers.Headers = append(result.ListIdentifiers.Headers, newHeader(record.Header))
+		}
+	case response.Records != nil:
+		result.ListRecords = &oaiRecords{ResumptionToken: newResumptionToken(response.Records)}
+		for _, record := range response.Records.Records {
+			result.ListRecords.Records = append(result.ListRecords.Records, newRecord(record))
+		}
+	case response.Record != nil:
+		result.GetRecord = &oaiGetRecord{Record: newRecord(*response.Record)}
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+
+	return encoder.Encode(result)
+}
+
+func newIdentify(baseURL string, repository Repository) *oaiIdentify {
+	return &oaiIdentify{
+		RepositoryName:    repository.Name,
+		BaseURL:           baseURL,
+		ProtocolVersion:   ProtocolVersion,
+		AdminEmail:        repository.AdminEmail,
+		EarliestDatestamp: Datestamp(repository.EarliestDatestamp),
+		DeletedRecord:     DeletedRecord,
+		Granularity:       Granularity,
+		Description: oaiDescription{Identifier: oaiIdentifierDescription{
+			XMLNS:                namespaceOAIIdentifier,
+			XMLNSXSI:             NamespaceXSI,
+			SchemaLocation:       namespaceOAIIdentifier + " " + schemaOAIIdentifier,
+			Scheme:               "oai",
+			RepositoryIdentifier: repository.Identifier,
+			Delimiter:            ":",
+			SampleIdentifier:     Identifier(repository.Identifier, 1),
+		}},
+	}
+}
+
+func newResumptionToken(list *RecordList) *oaiResumptionToken {
+	if list.ResumptionToken == nil {
+		return nil
+	}
+
+	return &oaiResumptionToken{
+		Cursor: strconv.FormatInt(list.ResumptionToken.Cursor, 10),
+		Value:  list.ResumptionToken.Value,
+	}
+}
+
+func newHeader(header Header) oaiHeader {
+	result := oaiHeader{
+		Identifier: header.Identifier,
+		Datestamp:  Datestamp(header.Datestamp),
+		SetSpecs:   header.SetSpecs,
+	}
+	if header.Deleted {
+		result.Status = "deleted"
+	}
+
+	return result
+}
+
+// newRecord - the deleted record is written with the header only
+func newRecord(record Record) oaiRecord {
+	if record.Header.Deleted {
+		return oaiRecord{Header: newHeader(record.Header)}
+	}
+
+	dc := record.Metadata
+	return oaiRecord{
+		Header: newHeader(record.Header),
+		Metadata: &oaiMetadata{DC: oaiDC{
+			XMLNSOAIDC:     NamespaceOAIDC,
+			XMLNSDC:        NamespaceDC,
+			XMLNSXSI:       NamespaceXSI,
+			SchemaLocation: NamespaceOAIDC + " " + SchemaOAIDC,
+			Titles:         dc.Titles,
+			Creators:       dc.Creators,
+			Subjects:       dc.Subjects,
+			Descriptions:   dc.Descriptions,
+			Publishers:     dc.Publishers,
+			Dates:          dc.Dates,
+			Types:          dc.Types,
+			Formats:        dc.Formats,
+			Identifiers:    dc.Identifiers,
+			Languages:      dc.Languages,
+		}},
+	}
+}
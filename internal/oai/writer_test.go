@@ -0,0 +1,119 @@
+package oai
+
+import (
+	"bytes"
+	"encoding/xml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+var testResponseDate = time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+func TestWriteResponse_ListRecords(t *testing.T) {
+	request := Request{Verb: VerbListRecords, Arguments: map[string]string{"metadataPrefix": "oai_dc",
+		"set": "publisher-1"}}
+	list := &RecordList{
+		Records: []Record{{
+			Header: Header{Identifier: "oai:lib-manager.local:book/1", Datestamp: testUpdated,
+				SetSpecs: []string{"category-3", "publisher-1"}},
+			Metadata: DublinCore{Titles: []string{"CockroachDB"}, Creators: []string{"John Doe", "Amanda Lee"}},
+		}},
+		ResumptionToken: &ResumptionToken{Value: "next", Cursor: 100},
+	}
+
+	var buffer bytes.Buffer
+	require.NoError(t, WriteResponse(&buffer, Response{Date: testResponseDate, BaseURL: testBaseURL + "/oai",
+		Request: request, Records: list}))
+
+	body := buffer.String()
+	assert.Contains(t, body, `<OAI-PMH xmlns="http://www.openarchives.org/OAI/2.0/"`)
+	assert.Contains(t, body, "<responseDate>2026-10-19T12:00:00Z</responseDate>")
+	assert.Contains(t, body, `<request verb="ListRecords" metadataPrefix="oai_dc" set="publisher-1">`+
+		testBaseURL+"/oai</request>")
+	assert.Contains(t, body, `<oai_dc:dc xmlns:oai_dc="http://www.openarchives.org/OAI/2.0/oai_dc/"`)
+	assert.Contains(t, body, `<resumptionToken cursor="100">next</resumptionToken>`)
+
+	var result struct {
+		ListRecords struct {
+			Records []struct {
+				Identifier string   `xml:"header>identifier"`
+				Datestamp  string   `xml:"header>datestamp"`
+				SetSpecs   []string `xml:"header>setSpec"`
+				Creators   []string `xml:"http://purl.org/dc/elements/1.1/ creator"`
+			} `xml:"record"`
+		}
+	}
+	require.NoError(t, xml.Unmarshal(buffer.Bytes(), &result))
+	require.Len(t, result.ListRecords.Records, 1)
+	record := result.ListRecords.Records[0]
+	assert.Equal(t, "oai:lib-manager.local:book/1", record.Identifier)
+	assert.Equal(t, "2026-10-05T08:30:00Z", record.Datestamp, "the datestamp should be truncated to the second")
+	assert.Equal(t, []string{"category-3", "publisher-1"}, record.SetSpecs)
+}
+
+func TestWriteResponse_ListIdentifiers(t *testing.T) {
+	list := &RecordList{
+		Records:         []Record{{Header: Header{Identifier: "oai:lib-manager.local:book/1", Datestamp: testUpdated}}},
+		ResumptionToken: &ResumptionToken{Cursor: 100},
+	}
+
+	var buffer bytes.Buffer
+	require.NoError(t, WriteResponse(&buffer, Response{Date: testResponseDate, BaseURL: testBaseURL + "/oai",
+		Request: Request{Verb: VerbListIdentifiers}, Records: list}))
+
+	body := buffer.String()
+	assert.Contains(t, body, "<ListIdentifiers>")
+	assert.Contains(t, body, "<identifier>oai:lib-manager.local:book/1</identifier>")
+	assert.NotContains(t, body, "<metadata>", "only the headers should be listed")
+	assert.Contains(t, body, `<resumptionToken cursor="100"></resumptionToken>`)
+}
+
+func TestWriteResponse_DeletedRecord(t *testing.T) {
+	record := &Record{Header: Header{Identifier: "oai:lib-manager.local:book/2", Datestamp: testUpdated, Deleted: true}}
+
+	var buffer bytes.Buffer
+	require.NoError(t, WriteResponse(&buffer, Response{Date: testResponseDate, BaseURL: testBaseURL + "/oai",
+		Request: Request{Verb: VerbGetRecord}, Record: record}))
+
+	body := buffer.String()
+	assert.Contains(t, body, `<header status="deleted">`)
+	assert.Contains(t, body, "<identifier>oai:lib-manager.local:book/2</identifier>")
+	assert.NotContains(t, body, "<metadata>", "the deleted record should have the header only")
+}
+
+func TestWriteResponse_Identify(t *testing.T) {
+	repository := &Repository{Name: "Library", Identifier: testRepositoryIdentifier, AdminEmail: "admin@example.com",
+		EarliestDatestamp: testUpdated}
+
+	var buffer bytes.Buffer
+	require.NoError(t, WriteResponse(&buffer, Response{Date: testResponseDate, BaseURL: testBaseURL + "/oai",
+		Request: Request{Verb: VerbIdentify, Arguments: map[string]string{}}, Repository: repository}))
+
+	var result struct {
+		Identify struct {
+			RepositoryName    string `xml:"repositoryName"`
+			BaseURL           string `xml:"baseURL"`
+			EarliestDatestamp string `xml:"earliestDatestamp"`
+			Granularity       string `xml:"granularity"`
+			SampleIdentifier  string `xml:"description>oai-identifier>sampleIdentifier"`
+		}
+	}
+	require.NoError(t, xml.Unmarshal(buffer.Bytes(), &result))
+	assert.Equal(t, "Library", result.Identify.RepositoryName)
+	assert.Equal(t, testBaseURL+"/oai", result.Identify.BaseURL)
+	assert.Equal(t, "2026-10-05T08:30:00Z", result.Identify.EarliestDatestamp)
+	assert.Equal(t, Granularity, result.Identify.Granularity)
+	assert.Equal(t, "oai:lib-manager.local:book/1", result.Identify.SampleIdentifier)
+}
+
+func TestWriteResponse_Error(t *testing.T) {
+	var buffer bytes.Buffer
+	require.NoError(t, WriteResponse(&buffer, Response{Date: testResponseDate, BaseURL: testBaseURL + "/oai",
+		Error: &Error{Code: CodeBadVerb, Message: "the verb 'ListBooks' is not supported"}}))
+
+	body := buffer.String()
+	assert.Contains(t, body, "<request>"+testBaseURL+"/oai</request>", "the invalid arguments should not be echoed")
+	assert.Contains(t, body, `<error code="badVerb">the verb &#39;ListBooks&#39; is not supported</error>`)
+}
@@ -0,0 +1,153 @@
+package opds
+
+import (
+	"encoding/xml"
+	"io"
+	"strconv"
+	"time"
+)
+
+// atomFeed - the OPDS 1.2 catalog feed, see https://specs.opds.io/opds-1.2
+type atomFeed struct {
+	XMLName      xml.Name    `xml:"feed"`
+	XMLNS        string      `xml:"xmlns,attr"`
+	XMLNSDC      string      `xml:"xmlns:dc,attr"`
+	XMLNSOPDS    string      `xml:"xmlns:opds,attr"`
+	XMLNSSearch  string      `xml:"xmlns:opensearch,attr"`
+	ID           string      `xml:"id"`
+	Title        string      `xml:"title"`
+	Updated      string      `xml:"updated"`
+	TotalResults int64       `xml:"opensearch:totalResults,omitempty"`
+	ItemsPerPage int64       `xml:"opensearch:itemsPerPage,omitempty"`
+	StartIndex   int64       `xml:"opensearch:startIndex,omitempty"`
+	Links        []atomLink  `xml:"link"`
+	Entries      []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Rel   string `xml:"rel,attr,omitempty"`
+	Href  string `xml:"href,attr"`
+	Type  string `xml:"type,attr,omitempty"`
+	Title string `xml:"title,attr,omitempty"`
+}
+
+type atomEntry struct {
+	ID          string         `xml:"id"`
+	Title       string         `xml:"title"`
+	Updated     string         `xml:"updated"`
+	Authors     []atomAuthor   `xml:"author"`
+	Language    string         `xml:"dc:language,omitempty"`
+	Publisher   string         `xml:"dc:publisher,omitempty"`
+	Issued      string         `xml:"dc:issued,omitempty"`
+	Identifiers []string       `xml:"dc:identifier"`
+	Categories  []atomCategory `xml:"category"`
+	Content     *atomContent   `xml:"content"`
+	Links       []atomLink     `xml:"link"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomCategory struct {
+	Term  string `xml:"term,attr"`
+	Label string `xml:"label,attr"`
+}
+
+type atomContent struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+// WriteAtom - writes the OPDS 1.2 Atom feed
+func WriteAtom(w io.Writer, feed Feed) error {
+	updated := feed.Updated.UTC().Format(time.RFC3339)
+	result := atomFeed{
+		XMLNS:       "http://www.w3.org/2005/Atom",
+		XMLNSDC:     "http://purl.org/dc/terms/",
+		XMLNSOPDS:   "http://opds-spec.org/2010/catalog",
+		XMLNSSearch: "http://a9.com/-/spec/opensearch/1.1/",
+		ID:          feed.ID,
+		Title:       feed.Title,
+		Updated:     updated,
+		Links:       []atomLink{},
+		Entries:     []atomEntry{},
+	}
+	if feed.CurrentPage > 0 {
+		result.TotalResults = feed.TotalItems
+		result.ItemsPerPage = feed.ItemsPerPage
+		result.StartIndex = (feed.CurrentPage-1)*feed.ItemsPerPage + 1
+	}
+	for _, link := range feed.Links {
+		result.Links = append(result.Links, newAtomLink(link))
+	}
+	if feed.SearchDescription != "" {
+		result.Links = append(result.Links, atomLink{Rel: "search", Href: feed.SearchDescription,
+			Type: MediaTypeOpenSearch, Title: "Search"})
+	}
+
+	for _, navigation := range feed.Navigation {
+		entry := atomEntry{
+			ID:      navigation.ID,
+			Title:   navigation.Title,
+			Updated: updated,
+			Links:   []atomLink{newAtomLink(navigation.Link)},
+		}
+		if navigation.BookCount > 0 {
+			entry.Content = &atomContent{Type: "text", Value: bookCount(navigation.BookCount)}
+		}
+		result.Entries = append(result.Entries, entry)
+	}
+
+	for _, publication := range feed.Publications {
+		entry := atomEntry{
+			ID:          BookID(publication.ID),
+			Title:       publication.Title,
+			Updated:     updated,
+			Language:    publication.Language,
+			Publisher:   publication.Publisher,
+			Identifiers: []string{publication.Identifier()},
+		}
+		if !publication.PubDate.IsZero() {
+			entry.Issued = publication.PubDate.Format(time.DateOnly)
+		}
+		if publication.Subtitle != "" {
+			entry.Content = &atomContent{Type: "text", Value: publication.Subtitle}
+		}
+		for _, author := range publication.Authors {
+			entry.Authors = append(entry.Authors, atomAuthor{Name: author})
+		}
+		for _, category := range append(publication.Categories, publication.Tags...) {
+			entry.Categories = append(entry.Categories, atomCategory{Term: category, Label: category})
+		}
+		for _, link := range publication.Links {
+			entry.Links = append(entry.Links, newAtomLink(link))
+		}
+		result.Entries = append(result.Entries, entry)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+
+	return encoder.Encode(result)
+}
+
+func newAtomLink(link Link) atomLink {
+	linkType := link.Type
+	if link.Kind != "" {
+		linkType = AtomType(link.Kind)
+	}
+
+	return atomLink{Rel: link.Rel, Href: link.Href, Type: linkType, Title: link.Title}
+}
+
+func bookCount(count int64) string {
+	if count == 1 {
+		return "1 book"
+	}
+
+	return strconv.FormatInt(count, 10) + " books"
+}
@@ -0,0 +1,7 @@
+package opds
+
+import "errors"
+
+var (
+	ErrUnsupportedFormat = errors.New("unsupported OPDS feed format, the supported ones are 'atom' and 'json'")
+)
@@ -0,0 +1,126 @@
+package opds
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+)
+
+// jsonFeed - the OPDS 2.0 catalog feed, see https://drafts.opds.io/opds-2.0
+type jsonFeed struct {
+	Metadata     jsonFeedMetadata  `json:"metadata"`
+	Links        []jsonLink        `json:"links"`
+	Navigation   []jsonLink        `json:"navigation,omitempty"`
+	Publications []jsonPublication `json:"publications,omitempty"`
+}
+
+type jsonFeedMetadata struct {
+	Title         string `json:"title"`
+	NumberOfItems int64  `json:"numberOfItems,omitempty"`
+	ItemsPerPage  int64  `json:"itemsPerPage,omitempty"`
+	CurrentPage   int64  `json:"currentPage,omitempty"`
+}
+
+type jsonLink struct {
+	Rel        string              `json:"rel,omitempty"`
+	Href       string              `json:"href"`
+	Type       string              `json:"type,omitempty"`
+	Title      string              `json:"title,omitempty"`
+	Templated  bool                `json:"templated,omitempty"`
+	Properties *jsonLinkProperties `json:"properties,omitempty"`
+}
+
+type jsonLinkProperties struct {
+	NumberOfItems int64 `json:"numberOfItems"`
+}
+
+type jsonPublication struct {
+	Metadata jsonPublicationMetadata `json:"metadata"`
+	Links    []jsonLink              `json:"links"`
+	Images   []jsonLink              `json:"images,omitempty"`
+}
+
+type jsonPublicationMetadata struct {
+	Type          string   `json:"@type"`
+	Identifier    string   `json:"identifier"`
+	Title         string   `json:"title"`
+	Subtitle      string   `json:"subtitle,omitempty"`
+	Author        []string `json:"author,omitempty"`
+	Publisher     string   `json:"publisher,omitempty"`
+	Language      string   `json:"language,omitempty"`
+	Published     string   `json:"published,omitempty"`
+	NumberOfPages uint16   `json:"numberOfPages,omitempty"`
+	Subject       []string `json:"subject,omitempty"`
+}
+
+// WriteJSON - writes the OPDS 2.0 JSON feed
+func WriteJSON(w io.Writer, feed Feed) error {
+	result := jsonFeed{
+		Metadata: jsonFeedMetadata{
+			Title:         feed.Title,
+			NumberOfItems: feed.TotalItems,
+			ItemsPerPage:  feed.ItemsPerPage,
+			CurrentPage:   feed.CurrentPage,
+		},
+		Links: []jsonLink{},
+	}
+	for _, link := range feed.Links {
+		result.Links = append(result.Links, newJSONLink(link))
+	}
+	if feed.SearchTemplate != "" {
+		result.Links = append(result.Links, jsonLink{Rel: "search", Href: feed.SearchTemplate,
+			Type: MediaTypeJSON, Title: "Search", Templated: true})
+	}
+
+	for _, navigation := range feed.Navigation {
+		link := newJSONLink(navigation.Link)
+		link.Title = navigation.Title
+		if navigation.BookCount > 0 {
+			link.Properties = &jsonLinkProperties{NumberOfItems: navigation.BookCount}
+		}
+		result.Navigation = append(result.Navigation, link)
+	}
+
+	for _, publication := range feed.Publications {
+		item := jsonPublication{
+			Metadata: jsonPublicationMetadata{
+				Type:          "http://schema.org/Book",
+				Identifier:    publication.Identifier(),
+				Title:         publication.Title,
+				Subtitle:      publication.Subtitle,
+				Author:        publication.Authors,
+				Publisher:     publication.Publisher,
+				Language:      publication.Language,
+				NumberOfPages: publication.Pages,
+				Subject:       append(append([]string{}, publication.Categories...), publication.Tags...),
+			},
+			Links: []jsonLink{},
+		}
+		if !publication.PubDate.IsZero() {
+			item.Metadata.Published = publication.PubDate.Format(time.DateOnly)
+		}
+		for _, link := range publication.Links {
+			// the thumbnail is the same cover image, so only the full size one is listed
+			if link.Rel == RelImage {
+				item.Images = append(item.Images, jsonLink{Href: link.Href, Type: link.Type})
+				continue
+			}
+			if link.Rel == RelThumbnail {
+				continue
+			}
+			item.Links = append(item.Links, newJSONLink(link))
+		}
+		result.Publications = append(result.Publications, item)
+	}
+
+	return json.NewEncoder(w).Encode(result)
+}
+
+func newJSONLink(link Link) jsonLink {
+	linkType := link.Type
+	if link.Kind != "" {
+		linkType = MediaTypeJSON
+	}
+
+	return jsonLink{Rel: link.Rel, Href: link.Href, Type: linkType, Title: link.Title}
+}
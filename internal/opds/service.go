@@ -0,0 +1,130 @@
+package opds
+
+import (
+	"context"
+	"github.com/jmoiron/sqlx"
+	"github.com/sdreger/lib-manager-go/internal/domain/book"
+	"github.com/sdreger/lib-manager-go/internal/paging"
+	"log/slog"
+)
+
+type Store interface {
+	GetFacetItems(ctx context.Context, facet Facet, page paging.PageRequest) ([]FacetItem, int64, error)
+	GetNames(ctx context.Context, table string, ids []int64) (map[int64]string, error)
+	GetBookFileTypes(ctx context.Context, bookIDs []int64) (map[int64][]string, error)
+}
+
+type BookService interface {
+	GetBooks(ctx context.Context, pageRequest paging.PageRequest, sort paging.Sort, filter book.Filter) (
+		paging.Page[book.LookupItem], error)
+}
+
+type Service struct {
+	logger      *slog.Logger
+	store       Store
+	bookService BookService
+}
+
+func NewService(logger *slog.Logger, db *sqlx.DB) *Service {
+	return &Service{
+		logger:      logger,
+		store:       NewDBStore(db),
+		bookService: book.NewService(logger, db),
+	}
+}
+
+// GetFacetItems - returns a requested page of the navigation facet items
+func (s *Service) GetFacetItems(ctx context.Context, facet Facet, pageRequest paging.PageRequest) (
+	paging.Page[FacetItem], error) {
+
+	items, total, err := s.store.GetFacetItems(ctx, facet, pageRequest)
+	if err != nil {
+		return paging.Page[FacetItem]{}, err
+	}
+
+	return paging.NewPage(pageRequest, total, items), nil
+}
+
+// GetPublications - returns a requested page of the books, along with their author, category and tag names,
+// and the types of their stored book files
+func (s *Service) GetPublications(ctx context.Context, pageRequest paging.PageRequest, sort paging.Sort,
+	filter book.Filter) (paging.Page[Publication], error) {
+
+	books, err := s.bookService.GetBooks(ctx, pageRequest, sort, filter)
+	if err != nil {
+		return paging.Page[Publication]{}, err
+	}
+
+	var bookIDs, authorIDs, categoryIDs, tagIDs []int64
+	for _, item := range books.Content {
+		bookIDs = append(bookIDs, item.ID)
+		authorIDs = append(authorIDs, item.AuthorIDs...)
+		categoryIDs = append(categoryIDs, item.CategoryIDs...)
+		tagIDs = append(tagIDs, item.TagIDs...)
+	}
+	authors, err := s.getNames(ctx, tableAuthors, authorIDs)
+	if err != nil {
+		return paging.Page[Publication]{}, err
+	}
+	categories, err := s.getNames(ctx, tableCategories, categoryIDs)
+	if err != nil {
+		return paging.Page[Publication]{}, err
+	}
+	tags, err := s.getNames(ctx, tableTags, tagIDs)
+	if err != nil {
+		return paging.Page[Publication]{}, err
+	}
+	fileTypes, err := s.GetBookFileTypes(ctx, bookIDs)
+	if err != nil {
+		return paging.Page[Publication]{}, err
+	}
+
+	publications := make([]Publication, 0, len(books.Content))
+	for _, item := range books.Content {
+		publications = append(publications, Publication{
+			LookupItem: item,
+			Authors:    lookupNames(authors, item.AuthorIDs),
+			Categories: lookupNames(categories, item.CategoryIDs),
+			Tags:       lookupNames(tags, item.TagIDs),
+			FileTypes:  fileTypes[item.ID],
+		})
+	}
+
+	return paging.Page[Publication]{
+		Page:       books.Page,
+		Size:       books.Size,
+		TotalPages: books.TotalPages,
+		TotalItems: books.TotalItems,
+		Content:    publications,
+	}, nil
+}
+
+// GetBookFileTypes - returns the types of the stored book files by the book ID, e.g. 'epub'. The file types
+// of the book relation may be listed without the file being stored, so the downloads are based on the stored ones
+func (s *Service) GetBookFileTypes(ctx context.Context, bookIDs []int64) (map[int64][]string, error) {
+	if len(bookIDs) == 0 {
+		return map[int64][]string{}, nil
+	}
+
+	return s.store.GetBookFileTypes(ctx, bookIDs)
+}
+
+func (s *Service) getNames(ctx context.Context, table string, ids []int64) (map[int64]string, error) {
+	if len(ids) == 0 {
+		return map[int64]string{}, nil
+	}
+
+	return s.store.GetNames(ctx, table, ids)
+}
+
+// lookupNames - returns the names of the IDs in the same order, the unknown IDs are skipped
+func lookupNames(names map[int64]string, ids []int64) []string {
+	result := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if name, ok := names[id]; ok {
+			result = append(result, name)
+		}
+	}
+
+	return result
+}
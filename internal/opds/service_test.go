@@ -0,0 +1,123 @@
+package opds
+
+import (
+	"context"
+	"errors"
+	"github.com/sdreger/lib-manager-go/internal/domain/book"
+	"github.com/sdreger/lib-manager-go/internal/paging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"log/slog"
+	"os"
+	"testing"
+)
+
+func TestService_GetFacetItems(t *testing.T) {
+	ctx := context.Background()
+	service := getService()
+	facet, _ := FacetByName("authors")
+	pageRequest, _ := paging.NewPageRequest(map[string][]string{"page": {"1"}, "size": {"2"}})
+	items := []FacetItem{{ID: 1, Name: "Amanda Lee", BookCount: 2}, {ID: 2, Name: "John Doe", BookCount: 1}}
+
+	mockStore := NewMockStore(t)
+	mockStore.EXPECT().GetFacetItems(ctx, facet, pageRequest).Return(items, 3, nil).Once()
+	injectMocks(service, mockStore, NewMockBookService(t))
+
+	page, err := service.GetFacetItems(ctx, facet, pageRequest)
+	require.NoError(t, err)
+	assert.Equal(t, items, page.Content)
+	assert.Equal(t, int64(3), page.TotalItems)
+	assert.Equal(t, int64(2), page.TotalPages)
+}
+
+func TestService_GetFacetItems_StoreError(t *testing.T) {
+	ctx := context.Background()
+	service := getService()
+	storeError := errors.New("store error")
+
+	mockStore := NewMockStore(t)
+	mockStore.EXPECT().GetFacetItems(ctx, mock.Anything, mock.Anything).Return(nil, 0, storeError).Once()
+	injectMocks(service, mockStore, NewMockBookService(t))
+
+	_, err := service.GetFacetItems(ctx, Facets[0], paging.PageRequest{})
+	assert.ErrorIs(t, err, storeError)
+}
+
+func TestService_GetPublications(t *testing.T) {
+	ctx := context.Background()
+	service := getService()
+	pageRequest, _ := paging.NewPageRequest(map[string][]string{})
+	sort, _ := paging.NewSort(map[string][]string{}, book.AllowedSortFields)
+	filter := book.Filter{Query: "go"}
+	items := []book.LookupItem{
+		{ID: 1, Title: "Go in Action", AuthorIDs: []int64{1, 2}, CategoryIDs: []int64{1}, FileTypeIDs: []int64{2}},
+		{ID: 2, Title: "Learning Go", AuthorIDs: []int64{3}, FileTypeIDs: []int64{1, 2}},
+	}
+
+	mockBookService := NewMockBookService(t)
+	mockBookService.EXPECT().GetBooks(ctx, pageRequest, sort, filter).
+		Return(paging.NewPage(pageRequest, 2, items), nil).Once()
+	mockStore := NewMockStore(t)
+	mockStore.EXPECT().GetNames(ctx, tableAuthors, []int64{1, 2, 3}).
+		Return(map[int64]string{1: "William Kennedy", 2: "Brian Ketelsen"}, nil).Once()
+	mockStore.EXPECT().GetNames(ctx, tableCategories, []int64{1}).
+		Return(map[int64]string{1: "Programming"}, nil).Once()
+	mockStore.EXPECT().GetBookFileTypes(ctx, []int64{1, 2}).
+		Return(map[int64][]string{2: {"epub", "pdf"}}, nil).Once()
+	injectMocks(service, mockStore, mockBookService)
+
+	page, err := service.GetPublications(ctx, pageRequest, sort, filter)
+	require.NoError(t, err)
+	require.Len(t, page.Content, 2)
+	assert.Equal(t, int64(2), page.TotalItems)
+	assert.Equal(t, items[0], page.Content[0].LookupItem)
+	assert.Equal(t, []string{"William Kennedy", "Brian Ketelsen"}, page.Content[0].Authors)
+	assert.Equal(t, []string{"Programming"}, page.Content[0].Categories)
+	assert.Empty(t, page.Content[0].Tags)
+	assert.Empty(t, page.Content[0].FileTypes, "the file types without the stored files should be skipped")
+	assert.Empty(t, page.Content[1].Authors, "the unknown IDs should be skipped")
+	assert.Equal(t, []string{"epub", "pdf"}, page.Content[1].FileTypes)
+}
+
+func TestService_GetPublications_BookServiceError(t *testing.T) {
+	ctx := context.Background()
+	service := getService()
+	serviceError := errors.New("service error")
+
+	mockBookService := NewMockBookService(t)
+	mockBookService.EXPECT().GetBooks(ctx, mock.Anything, mock.Anything, mock.Anything).
+		Return(paging.Page[book.LookupItem]{}, serviceError).Once()
+	injectMocks(service, NewMockStore(t), mockBookService)
+
+	_, err := service.GetPublications(ctx, paging.PageRequest{}, paging.Sort{}, book.Filter{})
+	assert.ErrorIs(t, err, serviceError)
+}
+
+func TestService_GetPublications_StoreError(t *testing.T) {
+	ctx := context.Background()
+	service := getService()
+	storeError := errors.New("store error")
+	pageRequest, _ := paging.NewPageRequest(map[string][]string{})
+	items := []book.LookupItem{{ID: 1, AuthorIDs: []int64{1}}}
+
+	mockBookService := NewMockBookService(t)
+	mockBookService.EXPECT().GetBooks(ctx, mock.Anything, mock.Anything, mock.Anything).
+		Return(paging.NewPage(pageRequest, 1, items), nil).Once()
+	mockStore := NewMockStore(t)
+	mockStore.EXPECT().GetNames(ctx, tableAuthors, []int64{1}).Return(nil, storeError).Once()
+	injectMocks(service, mockStore, mockBookService)
+
+	_, err := service.GetPublications(ctx, pageRequest, paging.Sort{}, book.Filter{})
+	assert.ErrorIs(t, err, storeError)
+}
+
+func getService() *Service {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	return NewService(logger, nil)
+}
+
+func injectMocks(service *Service, store Store, bookService BookService) {
+	service.store = store
+	service.bookService = bookService
+}
@@ -0,0 +1,92 @@
+package opds
+
+import (
+	"context"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/sdreger/lib-manager-go/internal/paging"
+)
+
+const (
+	tableAuthors    = "ebook.authors"
+	tableCategories = "ebook.categories"
+	tableFileTypes  = "ebook.file_types"
+	tableTags       = "ebook.tags"
+)
+
+type DBStore struct {
+	db *sqlx.DB
+}
+
+func NewDBStore(db *sqlx.DB) *DBStore {
+	return &DBStore{db: db}
+}
+
+// GetFacetItems - returns a page of the facet items, having at least one non-deleted book, sorted by name
+func (s *DBStore) GetFacetItems(ctx context.Context, facet Facet, page paging.PageRequest) (
+	[]FacetItem, int64, error) {
+
+	query := `SELECT facet.id, facet.name, COUNT(DISTINCT books.id) AS book_count
+FROM ` + facet.table + ` facet
+         ` + facet.join + `
+WHERE books.deleted_at IS NULL
+GROUP BY facet.id, facet.name
+ORDER BY facet.name, facet.id
+LIMIT $1 OFFSET $2`
+	items := make([]FacetItem, 0)
+	if err := s.db.SelectContext(ctx, &items, query, page.Limit(), page.Offset()); err != nil {
+		return nil, 0, err
+	}
+
+	var total int64 = 0
+	countQuery := "SELECT COUNT(DISTINCT facet.id) FROM " + facet.table + " facet " + facet.join +
+		" WHERE books.deleted_at IS NULL"
+	if err := s.db.GetContext(ctx, &total, countQuery); err != nil {
+		return nil, 0, err
+	}
+
+	return items, total, nil
+}
+
+// GetNames - returns the names of the relation table rows by their IDs
+func (s *DBStore) GetNames(ctx context.Context, table string, ids []int64) (map[int64]string, error) {
+	var rows []struct {
+		ID   int64  `db:"id"`
+		Name string `db:"name"`
+	}
+	if err := s.db.SelectContext(ctx, &rows, "SELECT id, name FROM "+table+" WHERE id = ANY($1)",
+		pq.Array(ids)); err != nil {
+		return nil, err
+	}
+
+	names := make(map[int64]string, len(rows))
+	for _, row := range rows {
+		names[row.ID] = row.Name
+	}
+
+	return names, nil
+}
+
+// GetBookFileTypes - returns the lowercase file type names of the stored book files by the book ID,
+// sorted by name. The books without any stored file are missing
+func (s *DBStore) GetBookFileTypes(ctx context.Context, bookIDs []int64) (map[int64][]string, error) {
+	query := `SELECT book_files.book_id, LOWER(file_types.name) AS file_type
+FROM ebook.book_files
+         JOIN ebook.file_types ON file_types.id = book_files.file_type_id
+WHERE book_files.book_id = ANY($1)
+ORDER BY book_files.book_id, file_type`
+	var rows []struct {
+		BookID   int64  `db:"book_id"`
+		FileType string `db:"file_type"`
+	}
+	if err := s.db.SelectContext(ctx, &rows, query, pq.Array(bookIDs)); err != nil {
+		return nil, err
+	}
+
+	fileTypes := make(map[int64][]string)
+	for _, row := range rows {
+		fileTypes[row.BookID] = append(fileTypes[row.BookID], row.FileType)
+	}
+
+	return fileTypes, nil
+}
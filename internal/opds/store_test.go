@@ -0,0 +1,106 @@
+package opds
+
+import (
+	"context"
+	"github.com/jmoiron/sqlx"
+	"github.com/sdreger/lib-manager-go/internal/paging"
+	"github.com/sdreger/lib-manager-go/internal/tests"
+	"github.com/stretchr/testify/suite"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"os"
+	"testing"
+)
+
+type TestStoreSuite struct {
+	suite.Suite
+	db            *sqlx.DB
+	testContainer *postgres.PostgresContainer
+	store         *DBStore
+}
+
+func (s *TestStoreSuite) SetupSuite() {
+	testContainer := tests.StartDBTestContainer(s.T())
+	dbConfig := tests.GetTestDBConfig(s.T(), testContainer)
+	connection := tests.SetUpTestDB(s.Suite.Require(), dbConfig, testContainer)
+
+	s.store = NewDBStore(connection)
+	s.db = connection
+	s.testContainer = testContainer
+}
+
+func (s *TestStoreSuite) SetupTest() {
+	ctx := context.Background()
+	err := s.testContainer.Restore(ctx)
+	s.Require().NoError(err)
+
+	err = prepareTestData(s.testContainer, "testdata/opds_facets.sql")
+	s.Require().NoError(err, "failed to load test SQL file")
+}
+
+func (s *TestStoreSuite) TearDownSuite() {
+	err := s.db.Close()
+	s.Require().NoError(err, "failed to close database connection")
+}
+
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(TestStoreSuite))
+}
+
+// -------------------- Tests --------------------
+
+func (s *TestStoreSuite) Test_GetFacetItems() {
+	ctx := context.Background()
+	pageRequest, _ := paging.NewPageRequest(map[string][]string{})
+	for _, facet := range Facets {
+		_, _, err := s.store.GetFacetItems(ctx, facet, pageRequest)
+		s.Require().NoError(err, "the facet %q query should be valid", facet.Name)
+	}
+
+	facet, _ := FacetByName("publishers")
+	items, total, err := s.store.GetFacetItems(ctx, facet, pageRequest)
+	s.Require().NoError(err)
+	s.Equal(int64(2), total, "the publisher of the deleted book only should be skipped")
+	s.Equal([]FacetItem{{ID: 1, Name: "Manning", BookCount: 1}, {ID: 2, Name: "OReilly", BookCount: 2}}, items)
+
+	facet, _ = FacetByName("authors")
+	items, total, err = s.store.GetFacetItems(ctx, facet, pageRequest)
+	s.Require().NoError(err)
+	s.Equal(int64(2), total)
+	s.Equal([]FacetItem{{ID: 2, Name: "Jon Bodner", BookCount: 2}, {ID: 1, Name: "William Kennedy", BookCount: 1}},
+		items)
+}
+
+func (s *TestStoreSuite) Test_GetFacetItems_Paging() {
+	ctx := context.Background()
+	facet, _ := FacetByName("languages")
+	pageRequest, _ := paging.NewPageRequest(map[string][]string{"page": {"2"}, "size": {"1"}})
+
+	items, total, err := s.store.GetFacetItems(ctx, facet, pageRequest)
+	s.Require().NoError(err)
+	s.Equal(int64(2), total)
+	s.Equal([]FacetItem{{ID: 2, Name: "German", BookCount: 1}}, items)
+}
+
+func (s *TestStoreSuite) Test_GetNames() {
+	ctx := context.Background()
+	names, err := s.store.GetNames(ctx, tableFileTypes, []int64{1, 2, 5})
+	s.Require().NoError(err)
+	s.Equal(map[int64]string{1: "pdf", 2: "epub"}, names)
+}
+
+func (s *TestStoreSuite) Test_GetBookFileTypes() {
+	ctx := context.Background()
+	fileTypes, err := s.store.GetBookFileTypes(ctx, []int64{1, 2, 3})
+	s.Require().NoError(err)
+	s.Equal(map[int64][]string{1: {"epub"}, 2: {"epub", "pdf"}}, fileTypes,
+		"the file types without the stored files should be skipped")
+}
+
+func prepareTestData(testContainer *postgres.PostgresContainer, fileName string) error {
+	file, err := os.ReadFile(fileName)
+	if err != nil {
+		return err
+	}
+
+	return tests.ExecSQL(testContainer, string(file))
+}
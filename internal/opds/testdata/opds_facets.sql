@@ -0,0 +1,18 @@
+INSERT INTO ebook.publishers (id, name) VALUES (1, 'Manning'), (2, 'OReilly'), (3, 'Packt');
+INSERT INTO ebook.languages (id, name) VALUES (1, 'English'), (2, 'German');
+INSERT INTO ebook.books (id, title, pages, edition, language_id, publisher_id, publisher_url, pub_date,
+                         book_file_name, book_file_size, cover_file_name)
+VALUES (1, 'Go in Action', 264, 1, 1, 1, '', '2015-11-04', 'Go in Action.epub', 5192, ''),
+       (2, 'Learning Go', 375, 1, 1, 2, '', '2021-03-02', 'Learning Go.pdf', 5192, ''),
+       (3, 'Go Programmierung', 300, 1, 2, 2, '', '2020-01-01', 'Go Programmierung.pdf', 5192, ''),
+       (4, 'Deleted Book', 100, 1, 1, 3, '', '2015-11-04', 'Deleted Book.epub', 5192, '');
+UPDATE ebook.books SET deleted_at = now() WHERE id = 4;
+
+INSERT INTO ebook.authors (id, name) VALUES (1, 'William Kennedy'), (2, 'Jon Bodner'), (3, 'Deleted Author');
+INSERT INTO ebook.book_author (book_id, author_id) VALUES (1, 1), (2, 2), (3, 2), (4, 3);
+INSERT INTO ebook.file_types (id, name) VALUES (1, 'pdf'), (2, 'epub');
+INSERT INTO ebook.book_file_type (book_id, file_type_id) VALUES (1, 2), (2, 1), (3, 1), (4, 2);
+INSERT INTO ebook.book_files (book_id, file_type_id, object_key, size, sha256, content_type)
+VALUES (1, 2, '1/epub', 5192, REPEAT('a', 64), 'application/epub+zip'),
+       (2, 2, '2/epub', 4096, REPEAT('b', 64), 'application/epub+zip'),
+       (2, 1, '2/pdf', 5192, REPEAT('c', 64), 'application/pdf');
@@ -0,0 +1,195 @@
+package opds
+
+import (
+	"github.com/sdreger/lib-manager-go/internal/domain/book"
+	"mime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// FormatAtom - the OPDS 1.2 Atom XML feed
+	FormatAtom = "atom"
+	// FormatJSON - the OPDS 2.0 JSON feed
+	FormatJSON = "json"
+
+	KindNavigation  = "navigation"
+	KindAcquisition = "acquisition"
+
+	MediaTypeJSON       = "application/opds+json"
+	MediaTypeOpenSearch = "application/opensearchdescription+xml"
+
+	RelAcquisition = "http://opds-spec.org/acquisition"
+	RelImage       = "http://opds-spec.org/image"
+	RelThumbnail   = "http://opds-spec.org/image/thumbnail"
+	RelSortNew     = "http://opds-spec.org/sort/new"
+
+	mediaTypeAtom = "application/atom+xml"
+	idPrefix      = "urn:lib-manager:"
+)
+
+// Facet - the book attribute, the catalog can be navigated by. The books of a facet item are selected
+// by the book filter query parameter
+type Facet struct {
+	Name        string
+	Title       string
+	FilterParam string
+	table       string
+	// join - joins the non-deleted 'ebook.books' rows to the facet table, aliased as 'facet'
+	join string
+}
+
+// Facets - the navigation facets, in the order they are listed in the root catalog
+var Facets = []Facet{
+	{Name: "publishers", Title: "By publisher", FilterParam: "publisher", table: "ebook.publishers",
+		join: "JOIN ebook.books ON books.publisher_id = facet.id"},
+	{Name: "authors", Title: "By author", FilterParam: "author", table: "ebook.authors",
+		join: "JOIN ebook.book_author ON book_author.author_id = facet.id " +
+			"JOIN ebook.books ON books.id = book_author.book_id"},
+	{Name: "categories", Title: "By category", FilterParam: "category", table: "ebook.categories",
+		join: "JOIN ebook.book_category ON book_category.category_id = facet.id " +
+			"JOIN ebook.books ON books.id = book_category.book_id"},
+	{Name: "tags", Title: "By tag", FilterParam: "tag", table: "ebook.tags",
+		join: "JOIN ebook.book_tag ON book_tag.tag_id = facet.id JOIN ebook.books ON books.id = book_tag.book_id"},
+	{Name: "languages", Title: "By language", FilterParam: "language", table: "ebook.languages",
+		join: "JOIN ebook.books ON books.language_id = facet.id"},
+}
+
+// FacetByName - returns the navigation facet by its name
+func FacetByName(name string) (Facet, bool) {
+	for _, facet := range Facets {
+		if facet.Name == name {
+			return facet, true
+		}
+	}
+
+	return Facet{}, false
+}
+
+// FacetItem - the facet value along with the number of the non-deleted books having it
+type FacetItem struct {
+	ID        int64  `db:"id"`
+	Name      string `db:"name"`
+	BookCount int64  `db:"book_count"`
+}
+
+// Publication - the book lookup item along with its relation names. The links are set by the caller,
+// since those point to the API routes
+type Publication struct {
+	book.LookupItem
+	Authors    []string
+	Categories []string
+	Tags       []string
+	FileTypes  []string
+	Links      []Link
+}
+
+// Identifier - the book URN, the ISBN-13 is preferred
+func (p Publication) Identifier() string {
+	switch {
+	case p.ISBN13 != 0:
+		return "urn:isbn:" + strconv.FormatInt(p.ISBN13, 10)
+	case p.ISBN10 != "":
+		return "urn:isbn:" + p.ISBN10
+	default:
+		return BookID(p.ID)
+	}
+}
+
+// Link - the feed or entry link. The Kind is set for the links to the other catalog feeds,
+// their media type depends on the response format, so the Type is set by the feed writer
+type Link struct {
+	Rel   string
+	Href  string
+	Type  string
+	Title string
+	Kind  string
+}
+
+// Navigation - the navigation feed entry, the book count is omitted if zero
+type Navigation struct {
+	ID        string
+	Title     string
+	BookCount int64
+	Link      Link
+}
+
+// Feed - the catalog feed, either the navigation or the acquisition one, rendered in any of the formats
+type Feed struct {
+	ID      string
+	Title   string
+	Kind    string
+	Updated time.Time
+	Links   []Link
+	// SearchDescription - the OpenSearch description URL, used by the Atom feed
+	SearchDescription string
+	// SearchTemplate - the URI template of the search, used by the JSON feed, e.g. '/opds/books{?query}'
+	SearchTemplate string
+	Navigation     []Navigation
+	Publications   []Publication
+	// TotalItems, ItemsPerPage, CurrentPage - the paging properties, zero for the non-paged feeds
+	TotalItems   int64
+	ItemsPerPage int64
+	CurrentPage  int64
+}
+
+// OpenSearchDescription - the catalog search description, the template contains the '{searchTerms}' parameter
+type OpenSearchDescription struct {
+	ShortName   string
+	Description string
+	Template    string
+}
+
+// FeedID - the feed URN of the catalog path, e.g. 'urn:lib-manager:opds:authors'
+func FeedID(parts ...string) string {
+	return idPrefix + strings.Join(append([]string{"opds"}, parts...), ":")
+}
+
+// BookID - the book URN
+func BookID(bookID int64) string {
+	return idPrefix + "book:" + strconv.FormatInt(bookID, 10)
+}
+
+// AtomType - the OPDS 1.2 catalog media type of the feed kind
+func AtomType(kind string) string {
+	return mediaTypeAtom + ";profile=opds-catalog;kind=" + kind
+}
+
+// ContentType - the response content type of the feed format and kind
+func ContentType(format string, kind string) string {
+	if format == FormatJSON {
+		return MediaTypeJSON
+	}
+
+	return AtomType(kind)
+}
+
+// Negotiate - returns the feed format, preferred by the 'Accept' header value, according to the quality values.
+// The Atom feed is returned unless the OPDS 2.0 media type has a greater quality than the Atom one
+func Negotiate(accept string) string {
+	atomQuality, jsonQuality := 0.0, 0.0
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		quality := 1.0
+		if value, ok := params["q"]; ok {
+			if quality, err = strconv.ParseFloat(value, 64); err != nil {
+				continue
+			}
+		}
+		switch mediaType {
+		case MediaTypeJSON:
+			jsonQuality = max(jsonQuality, quality)
+		case mediaTypeAtom:
+			atomQuality = max(atomQuality, quality)
+		}
+	}
+	if jsonQuality > atomQuality {
+		return FormatJSON
+	}
+
+	return FormatAtom
+}
@@ -0,0 +1,55 @@
+package opds
+
+import (
+	"encoding/xml"
+	"io"
+)
+
+// openSearchDescription - the OpenSearch 1.1 description document, see https://github.com/dewitt/opensearch
+type openSearchDescription struct {
+	XMLName     xml.Name        `xml:"OpenSearchDescription"`
+	XMLNS       string          `xml:"xmlns,attr"`
+	ShortName   string          `xml:"ShortName"`
+	Description string          `xml:"Description"`
+	InputCoding string          `xml:"InputEncoding"`
+	URLs        []openSearchURL `xml:"Url"`
+}
+
+type openSearchURL struct {
+	Type     string `xml:"type,attr"`
+	Template string `xml:"template,attr"`
+}
+
+// WriteFeed - writes the feed in the requested format
+func WriteFeed(w io.Writer, format string, feed Feed) error {
+	switch format {
+	case FormatAtom:
+		return WriteAtom(w, feed)
+	case FormatJSON:
+		return WriteJSON(w, feed)
+	default:
+		return ErrUnsupportedFormat
+	}
+}
+
+// WriteOpenSearch - writes the OpenSearch description, the search results are available in both feed formats
+func WriteOpenSearch(w io.Writer, description OpenSearchDescription) error {
+	result := openSearchDescription{
+		XMLNS:       "http://a9.com/-/spec/opensearch/1.1/",
+		ShortName:   description.ShortName,
+		Description: description.Description,
+		InputCoding: "UTF-8",
+		URLs: []openSearchURL{
+			{Type: AtomType(KindAcquisition), Template: description.Template},
+			{Type: MediaTypeJSON, Template: description.Template},
+		},
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+
+	return encoder.Encode(result)
+}
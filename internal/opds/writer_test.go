@@ -0,0 +1,209 @@
+package opds
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"github.com/sdreger/lib-manager-go/internal/domain/book"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestNegotiate(t *testing.T) {
+	assert.Equal(t, FormatAtom, Negotiate(""))
+	assert.Equal(t, FormatAtom, Negotiate("*/*"))
+	assert.Equal(t, FormatJSON, Negotiate("application/opds+json"))
+	assert.Equal(t, FormatJSON, Negotiate("application/atom+xml;q=0.9, application/opds+json"))
+	assert.Equal(t, FormatAtom, Negotiate("application/opds+json, application/atom+xml;profile=opds-catalog"))
+	assert.Equal(t, FormatAtom, Negotiate("application/opds+json;q=0.5, application/atom+xml;q=0.8"))
+}
+
+func TestContentType(t *testing.T) {
+	assert.Equal(t, "application/atom+xml;profile=opds-catalog;kind=navigation",
+		ContentType(FormatAtom, KindNavigation))
+	assert.Equal(t, "application/atom+xml;profile=opds-catalog;kind=acquisition",
+		ContentType(FormatAtom, KindAcquisition))
+	assert.Equal(t, MediaTypeJSON, ContentType(FormatJSON, KindAcquisition))
+}
+
+func TestFacetByName(t *testing.T) {
+	facet, ok := FacetByName("categories")
+	require.True(t, ok)
+	assert.Equal(t, "category", facet.FilterParam)
+
+	_, ok = FacetByName("books")
+	assert.False(t, ok)
+}
+
+func TestPublication_Identifier(t *testing.T) {
+	assert.Equal(t, "urn:isbn:9781617291784",
+		Publication{LookupItem: book.LookupItem{ID: 1, ISBN10: "1617291781", ISBN13: 9781617291784}}.Identifier())
+	assert.Equal(t, "urn:isbn:1617291781",
+		Publication{LookupItem: book.LookupItem{ID: 1, ISBN10: "1617291781"}}.Identifier())
+	assert.Equal(t, "urn:lib-manager:book:1", Publication{LookupItem: book.LookupItem{ID: 1}}.Identifier())
+}
+
+func TestWriteAtom_Navigation(t *testing.T) {
+	var buffer bytes.Buffer
+	require.NoError(t, WriteFeed(&buffer, FormatAtom, getNavigationFeed()))
+
+	output := buffer.String()
+	assert.Contains(t, output, xml.Header)
+	assert.Contains(t, output, `<feed xmlns="http://www.w3.org/2005/Atom"`)
+	assert.Contains(t, output, `<id>urn:lib-manager:opds:publishers</id>`)
+	assert.Contains(t, output, `<updated>2024-01-02T03:04:05Z</updated>`)
+	assert.Contains(t, output,
+		`<link rel="self" href="/opds/publishers" type="application/atom+xml;profile=opds-catalog;kind=navigation">`)
+	assert.Contains(t, output,
+		`<link rel="search" href="/opds/search.xml" type="application/opensearchdescription+xml" title="Search">`)
+	assert.Contains(t, output, `<opensearch:totalResults>12</opensearch:totalResults>`)
+	assert.Contains(t, output, `<opensearch:startIndex>11</opensearch:startIndex>`)
+	assert.Contains(t, output, `<title>Manning</title>`)
+	assert.Contains(t, output, `<content type="text">2 books</content>`)
+	assert.Contains(t, output,
+		`<link rel="subsection" href="/opds/books?publisher=1" type="application/atom+xml;profile=opds-catalog;kind=acquisition">`)
+
+	var decoded struct {
+		Entries []struct {
+			Title string `xml:"title"`
+		} `xml:"entry"`
+	}
+	require.NoError(t, xml.Unmarshal(buffer.Bytes(), &decoded), "should be a well-formed XML")
+	assert.Len(t, decoded.Entries, 1)
+}
+
+func TestWriteAtom_Acquisition(t *testing.T) {
+	var buffer bytes.Buffer
+	require.NoError(t, WriteAtom(&buffer, getAcquisitionFeed()))
+
+	output := buffer.String()
+	assert.Contains(t, output, `<id>urn:lib-manager:book:1</id>`)
+	assert.Contains(t, output, `<title>Go in Action</title>`)
+	assert.Contains(t, output, `<author>`+"\n"+`      <name>William Kennedy</name>`)
+	assert.Contains(t, output, `<dc:language>English</dc:language>`)
+	assert.Contains(t, output, `<dc:publisher>Manning</dc:publisher>`)
+	assert.Contains(t, output, `<dc:issued>2015-11-04</dc:issued>`)
+	assert.Contains(t, output, `<dc:identifier>urn:isbn:9781617291784</dc:identifier>`)
+	assert.Contains(t, output, `<category term="Programming" label="Programming"></category>`)
+	assert.Contains(t, output, `<category term="golang" label="golang"></category>`)
+	assert.Contains(t, output, `<link rel="http://opds-spec.org/image" href="/v1/books/1/cover" type="image/jpeg">`)
+	assert.Contains(t, output,
+		`<link rel="http://opds-spec.org/acquisition" href="/v1/books/1/files/epub" type="application/epub+zip" title="EPUB">`)
+	assert.NotContains(t, output, "opensearch:totalResults", "the non-paged feed should have no paging elements")
+}
+
+func TestWriteJSON_Navigation(t *testing.T) {
+	var buffer bytes.Buffer
+	require.NoError(t, WriteFeed(&buffer, FormatJSON, getNavigationFeed()))
+
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal(buffer.Bytes(), &decoded))
+	assert.Equal(t, map[string]any{"title": "By publisher", "numberOfItems": 12.0, "itemsPerPage": 10.0,
+		"currentPage": 2.0}, decoded["metadata"])
+	assert.Contains(t, decoded["links"], map[string]any{"rel": "self", "href": "/opds/publishers",
+		"type": MediaTypeJSON})
+	assert.Contains(t, decoded["links"], map[string]any{"rel": "search", "href": "/opds/books{?query}",
+		"type": MediaTypeJSON, "title": "Search", "templated": true})
+	assert.Equal(t, []any{map[string]any{"rel": "subsection", "href": "/opds/books?publisher=1",
+		"type": MediaTypeJSON, "title": "Manning", "properties": map[string]any{"numberOfItems": 2.0}}},
+		decoded["navigation"])
+	assert.NotContains(t, decoded, "publications")
+}
+
+func TestWriteJSON_Acquisition(t *testing.T) {
+	var buffer bytes.Buffer
+	require.NoError(t, WriteJSON(&buffer, getAcquisitionFeed()))
+
+	var decoded struct {
+		Publications []jsonPublication `json:"publications"`
+	}
+	require.NoError(t, json.Unmarshal(buffer.Bytes(), &decoded))
+	require.Len(t, decoded.Publications, 1)
+	assert.Equal(t, jsonPublicationMetadata{
+		Type:          "http://schema.org/Book",
+		Identifier:    "urn:isbn:9781617291784",
+		Title:         "Go in Action",
+		Author:        []string{"William Kennedy", "Brian Ketelsen"},
+		Publisher:     "Manning",
+		Language:      "English",
+		Published:     "2015-11-04",
+		NumberOfPages: 264,
+		Subject:       []string{"Programming", "golang"},
+	}, decoded.Publications[0].Metadata)
+	assert.Equal(t, []jsonLink{{Href: "/v1/books/1/cover", Type: "image/jpeg"}}, decoded.Publications[0].Images)
+	assert.Equal(t, []jsonLink{{Rel: RelAcquisition, Href: "/v1/books/1/files/epub", Type: "application/epub+zip",
+		Title: "EPUB"}}, decoded.Publications[0].Links)
+}
+
+func TestWriteFeed_UnsupportedFormat(t *testing.T) {
+	assert.ErrorIs(t, WriteFeed(&bytes.Buffer{}, "html", Feed{}), ErrUnsupportedFormat)
+}
+
+func TestWriteOpenSearch(t *testing.T) {
+	var buffer bytes.Buffer
+	require.NoError(t, WriteOpenSearch(&buffer, OpenSearchDescription{
+		ShortName:   "Library",
+		Description: "Search the library",
+		Template:    "/opds/books?query={searchTerms}",
+	}))
+
+	output := buffer.String()
+	assert.Contains(t, output, `<OpenSearchDescription xmlns="http://a9.com/-/spec/opensearch/1.1/">`)
+	assert.Contains(t, output, `<ShortName>Library</ShortName>`)
+	assert.Contains(t, output,
+		`<Url type="application/atom+xml;profile=opds-catalog;kind=acquisition" template="/opds/books?query={searchTerms}"></Url>`)
+	assert.Contains(t, output, `<Url type="application/opds+json" template="/opds/books?query={searchTerms}"></Url>`)
+}
+
+func getNavigationFeed() Feed {
+	return Feed{
+		ID:                FeedID("publishers"),
+		Title:             "By publisher",
+		Kind:              KindNavigation,
+		Updated:           time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC),
+		Links:             []Link{{Rel: "self", Href: "/opds/publishers", Kind: KindNavigation}},
+		SearchDescription: "/opds/search.xml",
+		SearchTemplate:    "/opds/books{?query}",
+		Navigation: []Navigation{{
+			ID:        FeedID("publishers", "1"),
+			Title:     "Manning",
+			BookCount: 2,
+			Link:      Link{Rel: "subsection", Href: "/opds/books?publisher=1", Kind: KindAcquisition},
+		}},
+		TotalItems:   12,
+		ItemsPerPage: 10,
+		CurrentPage:  2,
+	}
+}
+
+func getAcquisitionFeed() Feed {
+	return Feed{
+		ID:      FeedID("books"),
+		Title:   "Books",
+		Kind:    KindAcquisition,
+		Updated: time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC),
+		Publications: []Publication{{
+			LookupItem: book.LookupItem{
+				ID:        1,
+				Title:     "Go in Action",
+				ISBN10:    "1617291781",
+				ISBN13:    9781617291784,
+				Pages:     264,
+				PubDate:   time.Date(2015, time.November, 4, 0, 0, 0, 0, time.UTC),
+				Publisher: "Manning",
+				Language:  "English",
+			},
+			Authors:    []string{"William Kennedy", "Brian Ketelsen"},
+			Categories: []string{"Programming"},
+			Tags:       []string{"golang"},
+			FileTypes:  []string{"epub"},
+			Links: []Link{
+				{Rel: RelImage, Href: "/v1/books/1/cover", Type: "image/jpeg"},
+				{Rel: RelThumbnail, Href: "/v1/books/1/cover", Type: "image/jpeg"},
+				{Rel: RelAcquisition, Href: "/v1/books/1/files/epub", Type: "application/epub+zip", Title: "EPUB"},
+			},
+		}},
+	}
+}
@@ -0,0 +1,59 @@
+package schemaorg
+
+import (
+	"github.com/sdreger/lib-manager-go/internal/domain/book"
+	"github.com/sdreger/lib-manager-go/internal/domain/bookfile"
+	"strconv"
+	"time"
+)
+
+const dateLayout = time.DateOnly
+
+// NewBook - maps the book to the schema.org Book node. The ISBN-13 is preferred over the ISBN-10,
+// the image is only set for the books having a cover
+func NewBook(entry book.Book, links Links) Book {
+	result := Book{
+		Context:             ContextURL,
+		Type:                "Book",
+		ID:                  links.ID,
+		URL:                 links.Page,
+		Name:                entry.Title,
+		AlternativeHeadline: entry.Subtitle,
+		Description:         entry.Description,
+		NumberOfPages:       int(entry.Pages),
+		InLanguage:          entry.Language,
+		Genres:              entry.Categories,
+		Keywords:            entry.Tags,
+	}
+	for _, author := range entry.Authors {
+		result.Authors = append(result.Authors, Person{Type: "Person", Name: author})
+	}
+	if entry.ISBN13 != 0 {
+		result.ISBN = strconv.FormatInt(entry.ISBN13, 10)
+	} else {
+		result.ISBN = entry.ISBN10
+	}
+	if entry.Edition > 0 {
+		result.BookEdition = strconv.Itoa(int(entry.Edition))
+	}
+	if entry.Publisher != "" {
+		result.Publisher = &Organization{Type: "Organization", Name: entry.Publisher}
+	}
+	if !entry.PubDate.IsZero() {
+		result.DatePublished = entry.PubDate.Format(dateLayout)
+	}
+	if len(entry.FileTypes) > 0 {
+		result.BookFormat = BookFormatEBook
+	}
+	for _, fileType := range entry.FileTypes {
+		result.EncodingFormats = append(result.EncodingFormats, bookfile.ContentType(fileType))
+	}
+	if entry.CoverHash != "" || entry.CoverFileName != "" {
+		result.Image = links.Image
+	}
+	if !entry.UpdatedAt.IsZero() {
+		result.DateModified = entry.UpdatedAt.UTC().Format(time.RFC3339)
+	}
+
+	return result
+}
@@ -0,0 +1,122 @@
+package schemaorg
+
+import (
+	"encoding/json"
+	"github.com/sdreger/lib-manager-go/internal/domain/book"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+const testBaseURL = "https://books.example.com"
+
+func TestNewBook(t *testing.T) {
+	node := NewBook(getTestBook(), NewLinks(testBaseURL, 1))
+
+	assert.Equal(t, Book{
+		Context:             ContextURL,
+		Type:                "Book",
+		ID:                  testBaseURL + "/v1/books/1",
+		URL:                 testBaseURL + "/ui/books/1",
+		Name:                "CockroachDB",
+		AlternativeHeadline: "The Definitive Guide",
+		Description:         "Get the lowdown on CockroachDB",
+		Authors:             []Person{{Type: "Person", Name: "John Doe"}, {Type: "Person", Name: "Amanda Lee"}},
+		ISBN:                "9781234567890",
+		NumberOfPages:       256,
+		BookEdition:         "2",
+		Publisher:           &Organization{Type: "Organization", Name: "OReilly"},
+		InLanguage:          "English",
+		DatePublished:       "2022-07-19",
+		BookFormat:          BookFormatEBook,
+		EncodingFormats:     []string{"application/pdf", "application/epub+zip"},
+		Image:               testBaseURL + "/v1/books/1/cover",
+		Genres:              []string{"Databases"},
+		Keywords:            []string{"sql"},
+		DateModified:        "2026-10-05T08:30:00Z",
+	}, node)
+
+	data, err := json.Marshal(node)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"@context":"https://schema.org","@type":"Book"`)
+	assert.Contains(t, string(data), `"author":[{"@type":"Person","name":"John Doe"}`)
+}
+
+func TestNewBook_Minimal(t *testing.T) {
+	node := NewBook(book.Book{ID: 2, Title: "Untitled", ISBN10: "1234567890"}, NewLinks(testBaseURL, 2))
+
+	assert.Equal(t, "1234567890", node.ISBN, "the ISBN-10 should be used without the ISBN-13")
+	assert.Empty(t, node.Image, "the book without a cover should not have an image")
+	assert.Nil(t, node.Publisher)
+	assert.Empty(t, node.BookFormat)
+
+	data, err := json.Marshal(node)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"@context":"https://schema.org","@type":"Book","@id":"https://books.example.com/v1/books/2",
+		"url":"https://books.example.com/ui/books/2","name":"Untitled","isbn":"1234567890"}`, string(data))
+}
+
+func TestOpenGraph(t *testing.T) {
+	tags := OpenGraph(NewBook(getTestBook(), NewLinks(testBaseURL, 1)))
+
+	assert.Equal(t, []Meta{
+		{Property: "og:type", Content: "book"},
+		{Property: "og:title", Content: "CockroachDB"},
+		{Property: "og:url", Content: testBaseURL + "/ui/books/1"},
+		{Property: "og:description", Content: "Get the lowdown on CockroachDB"},
+		{Property: "og:image", Content: testBaseURL + "/v1/books/1/cover"},
+		{Property: "book:author", Content: "John Doe"},
+		{Property: "book:author", Content: "Amanda Lee"},
+		{Property: "book:isbn", Content: "9781234567890"},
+		{Property: "book:release_date", Content: "2022-07-19"},
+		{Property: "book:tag", Content: "Databases"},
+		{Property: "book:tag", Content: "sql"},
+	}, tags)
+
+	tags = OpenGraph(NewBook(book.Book{ID: 2, Title: "Untitled", Subtitle: "A subtitle"}, NewLinks(testBaseURL, 2)))
+	assert.Equal(t, []Meta{
+		{Property: "og:type", Content: "book"},
+		{Property: "og:title", Content: "Untitled"},
+		{Property: "og:url", Content: testBaseURL + "/ui/books/2"},
+		{Property: "og:description", Content: "A subtitle"},
+	}, tags, "the subtitle should describe the book without a description")
+}
+
+func TestNegotiate(t *testing.T) {
+	tests := map[string]bool{
+		"application/ld+json":                                true,
+		"application/ld+json;profile=\"https://schema.org\"": true,
+		"application/json, application/ld+json":              true,
+		"application/ld+json;q=0.5, application/json":        false,
+		"application/json;q=0.5, application/ld+json":        true,
+		"text/html, */*;q=0.8":                               false,
+		"*/*":                                                false,
+		"":                                                   false,
+	}
+	for accept, expected := range tests {
+		assert.Equal(t, expected, Negotiate(accept), accept)
+	}
+}
+
+func getTestBook() book.Book {
+	return book.Book{
+		ID:          1,
+		Title:       "CockroachDB",
+		Subtitle:    "The Definitive Guide",
+		Description: "Get the lowdown on CockroachDB",
+		ISBN10:      "1234567890",
+		ISBN13:      9781234567890,
+		Pages:       256,
+		Edition:     2,
+		PubDate:     time.Date(2022, 7, 19, 0, 0, 0, 0, time.UTC),
+		CoverHash:   "abc",
+		Language:    "English",
+		Publisher:   "OReilly",
+		Authors:     []string{"John Doe", "Amanda Lee"},
+		Categories:  []string{"Databases"},
+		FileTypes:   []string{"pdf", "epub"},
+		Tags:        []string{"sql"},
+		UpdatedAt:   time.Date(2026, 10, 5, 8, 30, 0, 0, time.UTC),
+	}
+}
@@ -0,0 +1,38 @@
+package schemaorg
+
+// Meta - the Open Graph meta tag, see https://ogp.me
+type Meta struct {
+	Property string
+	Content  string
+}
+
+// OpenGraph - returns the Open Graph meta tags of the book type, derived from the schema.org Book node.
+// The subtitle is the description of the books without one, the empty values are skipped
+func OpenGraph(node Book) []Meta {
+	tags := []Meta{
+		{Property: "og:type", Content: "book"},
+		{Property: "og:title", Content: node.Name},
+		{Property: "og:url", Content: node.URL},
+	}
+	add := func(property, content string) {
+		if content != "" {
+			tags = append(tags, Meta{Property: property, Content: content})
+		}
+	}
+	description := node.Description
+	if description == "" {
+		description = node.AlternativeHeadline
+	}
+	add("og:description", description)
+	add("og:image", node.Image)
+	for _, author := range node.Authors {
+		add("book:author", author.Name)
+	}
+	add("book:isbn", node.ISBN)
+	add("book:release_date", node.DatePublished)
+	for _, tag := range append(append([]string{}, node.Genres...), node.Keywords...) {
+		add("book:tag", tag)
+	}
+
+	return tags
+}
@@ -0,0 +1,92 @@
+package schemaorg
+
+import (
+	"fmt"
+	"mime"
+	"strconv"
+	"strings"
+)
+
+const (
+	MediaType  = "application/ld+json"
+	ContextURL = "https://schema.org"
+	// BookFormatEBook - the format of all the library books, see https://schema.org/BookFormatType
+	BookFormatEBook = "https://schema.org/EBook"
+)
+
+// Book - the schema.org Book node, along with its author and publisher nodes, see https://schema.org/Book
+type Book struct {
+	Context             string        `json:"@context"`
+	Type                string        `json:"@type"`
+	ID                  string        `json:"@id"`
+	URL                 string        `json:"url"`
+	Name                string        `json:"name"`
+	AlternativeHeadline string        `json:"alternativeHeadline,omitempty"`
+	Description         string        `json:"description,omitempty"`
+	Authors             []Person      `json:"author,omitempty"`
+	ISBN                string        `json:"isbn,omitempty"`
+	NumberOfPages       int           `json:"numberOfPages,omitempty"`
+	BookEdition         string        `json:"bookEdition,omitempty"`
+	Publisher           *Organization `json:"publisher,omitempty"`
+	InLanguage          string        `json:"inLanguage,omitempty"`
+	DatePublished       string        `json:"datePublished,omitempty"`
+	BookFormat          string        `json:"bookFormat,omitempty"`
+	EncodingFormats     []string      `json:"encodingFormat,omitempty"`
+	Image               string        `json:"image,omitempty"`
+	Genres              []string      `json:"genre,omitempty"`
+	Keywords            []string      `json:"keywords,omitempty"`
+	DateModified        string        `json:"dateModified,omitempty"`
+}
+
+type Person struct {
+	Type string `json:"@type"`
+	Name string `json:"name"`
+}
+
+type Organization struct {
+	Type string `json:"@type"`
+	Name string `json:"name"`
+}
+
+// Links - the absolute book URLs: the API resource, which identifies the book node, the catalog browser page
+// and the cover image
+type Links struct {
+	ID    string
+	Page  string
+	Image string
+}
+
+// NewLinks - returns the book URLs under the external base URL, e.g. 'https://books.example.com'
+func NewLinks(baseURL string, bookID int64) Links {
+	return Links{
+		ID:    fmt.Sprintf("%s/v1/books/%d", baseURL, bookID),
+		Page:  fmt.Sprintf("%s/ui/books/%d", baseURL, bookID),
+		Image: fmt.Sprintf("%s/v1/books/%d/cover", baseURL, bookID),
+	}
+}
+
+// Negotiate - reports whether the 'Accept' header value prefers JSON-LD over the plain JSON, according to
+// the quality values. The wildcards only select the plain JSON
+func Negotiate(accept string) bool {
+	linkedQuality, plainQuality := 0.0, 0.0
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		quality := 1.0
+		if value, ok := params["q"]; ok {
+			if quality, err = strconv.ParseFloat(value, 64); err != nil {
+				continue
+			}
+		}
+		switch mediaType {
+		case MediaType:
+			linkedQuality = max(linkedQuality, quality)
+		case "application/json", "application/*", "*/*":
+			plainQuality = max(plainQuality, quality)
+		}
+	}
+
+	return linkedQuality > 0 && linkedQuality >= plainQuality
+}